@@ -247,12 +247,16 @@ func (c *Client) DebugBundle(platformFiles PlatformFiles, anonymize bool) (strin
 		deps.SyncResponse = resp
 
 		if e := cc.Engine(); e != nil {
+			deps.Policy = e.GetBundlePolicy()
 			deps.RefreshStatus = func() {
 				e.RunHealthProbes(context.Background(), true)
 			}
 			if cm := e.GetClientMetrics(); cm != nil {
 				deps.ClientMetrics = cm
 			}
+			if dnsState, ok := e.GetDNSServer().(debug.DNSStateSource); ok {
+				deps.DNSState = dnsState
+			}
 		}
 	}
 
@@ -260,7 +264,7 @@ func (c *Client) DebugBundle(platformFiles PlatformFiles, anonymize bool) (strin
 		deps,
 		debug.BundleConfig{
 			Anonymize:         anonymize,
-			IncludeSystemInfo: true,
+			IncludeSystemInfo: debug.AllSystemInfo(),
 		},
 	)
 
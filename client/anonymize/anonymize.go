@@ -15,6 +15,38 @@ import (
 
 const anonTLD = ".domain"
 
+// Level selects which values are anonymized.
+type Level string
+
+const (
+	// LevelNone keeps all values.
+	LevelNone Level = "none"
+	// LevelIPsOnly replaces IP addresses but keeps domain names, peer names and interface names.
+	LevelIPsOnly Level = "ips-only"
+	// LevelFull replaces IP addresses and domain names. It is what the anonymize boolean selects.
+	LevelFull Level = "full"
+)
+
+// LevelFromBool maps the anonymize boolean that predates Level: true is LevelFull, false LevelNone.
+func LevelFromBool(anonymize bool) Level {
+	if anonymize {
+		return LevelFull
+	}
+	return LevelNone
+}
+
+// ResolveLevel parses level. An empty level falls back to LevelFromBool(anonymize).
+func ResolveLevel(level string, anonymize bool) (Level, error) {
+	switch l := Level(level); l {
+	case "":
+		return LevelFromBool(anonymize), nil
+	case LevelNone, LevelIPsOnly, LevelFull:
+		return l, nil
+	default:
+		return "", fmt.Errorf("unknown anonymize level %q, use %s, %s or %s", level, LevelFull, LevelIPsOnly, LevelNone)
+	}
+}
+
 type Anonymizer struct {
 	ipAnonymizer     map[netip.Addr]netip.Addr
 	domainAnonymizer map[string]string
@@ -22,6 +54,13 @@ type Anonymizer struct {
 	currentAnonIPv6  netip.Addr
 	startAnonIPv4    netip.Addr
 	startAnonIPv6    netip.Addr
+	// ipsOnly keeps domain names, see SetIPsOnly.
+	ipsOnly bool
+	// customPatterns are user-supplied patterns whose matches are replaced with placeholders,
+	// see SetCustomPatterns.
+	customPatterns   []*regexp.Regexp
+	customAnonymizer map[string]string
+	currentCustom    int
 
 	domainKeyRegex *regexp.Regexp
 }
@@ -36,6 +75,7 @@ func NewAnonymizer(startIPv4, startIPv6 netip.Addr) *Anonymizer {
 	return &Anonymizer{
 		ipAnonymizer:     map[netip.Addr]netip.Addr{},
 		domainAnonymizer: map[string]string{},
+		customAnonymizer: map[string]string{},
 		currentAnonIPv4:  startIPv4,
 		currentAnonIPv6:  startIPv6,
 		startAnonIPv4:    startIPv4,
@@ -45,6 +85,38 @@ func NewAnonymizer(startIPv4, startIPv6 netip.Addr) *Anonymizer {
 	}
 }
 
+// SetIPsOnly makes the anonymizer keep domain names for LevelIPsOnly. IP addresses are still
+// replaced.
+func (a *Anonymizer) SetIPsOnly(ipsOnly bool) {
+	a.ipsOnly = ipsOnly
+}
+
+// SetCustomPatterns makes the anonymizer replace matches of the patterns in strings and domain
+// names with placeholders like REDACTED-1, the same placeholder for the same match. Patterns
+// apply on every anonymization level.
+func (a *Anonymizer) SetCustomPatterns(patterns []*regexp.Regexp) {
+	a.customPatterns = patterns
+}
+
+// anonymizeCustom replaces the matches of the custom patterns.
+func (a *Anonymizer) anonymizeCustom(str string) string {
+	for _, re := range a.customPatterns {
+		str = re.ReplaceAllStringFunc(str, func(match string) string {
+			if match == "" {
+				return match
+			}
+			placeholder, ok := a.customAnonymizer[match]
+			if !ok {
+				a.currentCustom++
+				placeholder = customPlaceholderPrefix + strconv.Itoa(a.currentCustom)
+				a.customAnonymizer[match] = placeholder
+			}
+			return placeholder
+		})
+	}
+	return str
+}
+
 func (a *Anonymizer) AnonymizeIP(ip netip.Addr) netip.Addr {
 	if ip.IsLoopback() ||
 		ip.IsLinkLocalUnicast() ||
@@ -112,6 +184,11 @@ func (a *Anonymizer) AnonymizeIPString(ip string) string {
 }
 
 func (a *Anonymizer) AnonymizeDomain(domain string) string {
+	domain = a.anonymizeCustom(domain)
+	if a.ipsOnly {
+		return domain
+	}
+
 	baseDomain := domain
 	hasDot := strings.HasSuffix(domain, ".")
 	if hasDot {
@@ -175,14 +252,18 @@ func (a *Anonymizer) AnonymizeURI(uri string) string {
 }
 
 func (a *Anonymizer) AnonymizeString(str string) string {
+	str = a.anonymizeCustom(str)
+
 	ipv4Regex := regexp.MustCompile(`\b(?:[0-9]{1,3}\.){3}[0-9]{1,3}\b`)
 	ipv6Regex := regexp.MustCompile(`\b([0-9a-fA-F:]+:+[0-9a-fA-F]{0,4})(?:%[0-9a-zA-Z]+)?(?:\/[0-9]{1,3})?(?::[0-9]{1,5})?\b`)
 
 	str = ipv4Regex.ReplaceAllStringFunc(str, a.AnonymizeIPString)
 	str = ipv6Regex.ReplaceAllStringFunc(str, a.AnonymizeIPString)
 
-	for domain, anonDomain := range a.domainAnonymizer {
-		str = strings.ReplaceAll(str, domain, anonDomain)
+	if !a.ipsOnly {
+		for domain, anonDomain := range a.domainAnonymizer {
+			str = strings.ReplaceAll(str, domain, anonDomain)
+		}
 	}
 
 	str = a.AnonymizeSchemeURI(str)
@@ -227,6 +308,93 @@ func (a *Anonymizer) AnonymizeRoute(route string) string {
 	return strings.Join(domains, ", ")
 }
 
+// Category is the kind of value an Anonymizer replaced.
+type Category string
+
+const (
+	CategoryIPv4   Category = "ipv4"
+	CategoryIPv6   Category = "ipv6"
+	CategoryDomain Category = "domain"
+	// CategoryCustom are matches of the patterns set with SetCustomPatterns.
+	CategoryCustom Category = "custom"
+)
+
+// Mapping is an original value and the anonymized value that replaced it.
+type Mapping struct {
+	Category   Category `json:"category"`
+	Original   string   `json:"original"`
+	Anonymized string   `json:"anonymized"`
+}
+
+// Mappings returns every value anonymized so far, sorted by category and original value.
+// The result reverses the anonymization and must never be written to a shared artifact.
+func (a *Anonymizer) Mappings() []Mapping {
+	mappings := make([]Mapping, 0, len(a.ipAnonymizer)+len(a.domainAnonymizer)+len(a.customAnonymizer))
+	for orig, anon := range a.ipAnonymizer {
+		category := CategoryIPv4
+		if orig.Is6() {
+			category = CategoryIPv6
+		}
+		mappings = append(mappings, Mapping{Category: category, Original: orig.String(), Anonymized: anon.String()})
+	}
+	for orig, anon := range a.domainAnonymizer {
+		mappings = append(mappings, Mapping{Category: CategoryDomain, Original: orig, Anonymized: anon})
+	}
+	for orig, anon := range a.customAnonymizer {
+		mappings = append(mappings, Mapping{Category: CategoryCustom, Original: orig, Anonymized: anon})
+	}
+
+	slices.SortFunc(mappings, func(x, y Mapping) int {
+		if c := strings.Compare(string(x.Category), string(y.Category)); c != 0 {
+			return c
+		}
+		return strings.Compare(x.Original, y.Original)
+	})
+	return mappings
+}
+
+// LoadMappings makes the anonymizer reuse earlier mappings, so the same original values get the
+// same anonymized values across runs. New values are assigned after the loaded ones.
+func (a *Anonymizer) LoadMappings(mappings []Mapping) error {
+	for _, m := range mappings {
+		switch m.Category {
+		case CategoryIPv4, CategoryIPv6:
+			orig, err := netip.ParseAddr(m.Original)
+			if err != nil {
+				return fmt.Errorf("parse original address %q: %w", m.Original, err)
+			}
+			anon, err := netip.ParseAddr(m.Anonymized)
+			if err != nil {
+				return fmt.Errorf("parse anonymized address %q: %w", m.Anonymized, err)
+			}
+			if orig.Is4() != anon.Is4() {
+				return fmt.Errorf("address family mismatch in mapping %s -> %s", m.Original, m.Anonymized)
+			}
+			a.ipAnonymizer[orig] = anon
+			if anon.Is4() && anon.Compare(a.currentAnonIPv4) >= 0 {
+				a.currentAnonIPv4 = anon.Next()
+			} else if !anon.Is4() && anon.Compare(a.currentAnonIPv6) >= 0 {
+				a.currentAnonIPv6 = anon.Next()
+			}
+		case CategoryDomain:
+			if !strings.HasSuffix(m.Anonymized, anonTLD) {
+				return fmt.Errorf("anonymized domain %q does not end in %s", m.Anonymized, anonTLD)
+			}
+			a.domainAnonymizer[m.Original] = m.Anonymized
+		case CategoryCustom:
+			n, err := strconv.Atoi(strings.TrimPrefix(m.Anonymized, customPlaceholderPrefix))
+			if err != nil || !strings.HasPrefix(m.Anonymized, customPlaceholderPrefix) {
+				return fmt.Errorf("invalid placeholder %q for a custom pattern match", m.Anonymized)
+			}
+			a.customAnonymizer[m.Original] = m.Anonymized
+			a.currentCustom = max(a.currentCustom, n)
+		default:
+			return fmt.Errorf("unknown mapping category %q", m.Category)
+		}
+	}
+	return nil
+}
+
 func isWellKnown(addr netip.Addr) bool {
 	wellKnown := []string{
 		"8.8.8.8", "8.8.4.4", // Google DNS IPv4
@@ -2,7 +2,11 @@ package anonymize_test
 
 import (
 	"net/netip"
+	"os"
+	"path/filepath"
 	"regexp"
+	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -243,6 +247,35 @@ func TestAnonymizString_MemorizedDomain(t *testing.T) {
 	assert.Equal(t, firstPassResult, secondPassResult, "The second pass should not further anonymize the string")
 }
 
+func TestAnonymizeString_IPsOnly(t *testing.T) {
+	anonymizer := anonymize.NewAnonymizer(anonymize.DefaultAddresses())
+	anonymizer.SetIPsOnly(true)
+
+	result := anonymizer.AnonymizeString("peer host.example.com on wt0 connected via https://relay.example.com:443 from 203.0.113.7")
+	assert.Contains(t, result, "host.example.com")
+	assert.Contains(t, result, "https://relay.example.com:443")
+	assert.Contains(t, result, "wt0")
+	assert.NotContains(t, result, "203.0.113.7")
+	assert.Equal(t, "example.com", anonymizer.AnonymizeDomain("example.com"))
+}
+
+func TestResolveLevel(t *testing.T) {
+	level, err := anonymize.ResolveLevel("", true)
+	require.NoError(t, err)
+	assert.Equal(t, anonymize.LevelFull, level)
+
+	level, err = anonymize.ResolveLevel("", false)
+	require.NoError(t, err)
+	assert.Equal(t, anonymize.LevelNone, level)
+
+	level, err = anonymize.ResolveLevel("ips-only", true)
+	require.NoError(t, err)
+	assert.Equal(t, anonymize.LevelIPsOnly, level)
+
+	_, err = anonymize.ResolveLevel("partial", false)
+	assert.Error(t, err)
+}
+
 func TestAnonymizeString_DoubleURI(t *testing.T) {
 	anonymizer := anonymize.NewAnonymizer(netip.Addr{}, netip.Addr{})
 	domain := "example.com"
@@ -305,3 +338,96 @@ func TestAnonymizeString_IPAddresses(t *testing.T) {
 		})
 	}
 }
+
+func TestMappings(t *testing.T) {
+	a := anonymize.NewAnonymizer(anonymize.DefaultAddresses())
+
+	anonIPv4 := a.AnonymizeIP(netip.MustParseAddr("203.0.113.7"))
+	anonIPv6 := a.AnonymizeIP(netip.MustParseAddr("2001:db8:1::1"))
+	anonDomain := a.AnonymizeDomain("host.example.com")
+	a.AnonymizeIP(netip.MustParseAddr("10.0.0.1"))
+
+	mappings := a.Mappings()
+	assert.Equal(t, []anonymize.Mapping{
+		{Category: anonymize.CategoryDomain, Original: "example.com", Anonymized: strings.TrimPrefix(anonDomain, "host.")},
+		{Category: anonymize.CategoryIPv4, Original: "203.0.113.7", Anonymized: anonIPv4.String()},
+		{Category: anonymize.CategoryIPv6, Original: "2001:db8:1::1", Anonymized: anonIPv6.String()},
+	}, mappings, "private addresses are not anonymized and must not be listed")
+}
+
+func TestLoadMappings(t *testing.T) {
+	first := anonymize.NewAnonymizer(anonymize.DefaultAddresses())
+	anonIPv4 := first.AnonymizeIP(netip.MustParseAddr("203.0.113.7"))
+	anonDomain := first.AnonymizeDomain("host.example.com")
+
+	second := anonymize.NewAnonymizer(anonymize.DefaultAddresses())
+	require.NoError(t, second.LoadMappings(first.Mappings()))
+
+	assert.Equal(t, anonIPv4, second.AnonymizeIP(netip.MustParseAddr("203.0.113.7")), "loaded values are reused")
+	assert.Equal(t, anonDomain, second.AnonymizeDomain("host.example.com"))
+
+	next := second.AnonymizeIP(netip.MustParseAddr("203.0.113.8"))
+	assert.NotEqual(t, anonIPv4, next, "new values are assigned after the loaded ones")
+	assert.Len(t, second.Mappings(), 3)
+
+	err := second.LoadMappings([]anonymize.Mapping{{Category: anonymize.CategoryIPv4, Original: "203.0.113.9", Anonymized: "2001:db8:ffff::1"}})
+	assert.Error(t, err)
+}
+
+func TestMapFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "anon-map.json")
+
+	mappings, err := anonymize.ReadMapFile(path)
+	require.NoError(t, err)
+	assert.Empty(t, mappings, "a missing file has no mappings")
+
+	a := anonymize.NewAnonymizer(anonymize.DefaultAddresses())
+	a.AnonymizeIP(netip.MustParseAddr("203.0.113.7"))
+	require.NoError(t, anonymize.WriteMapFile(path, a.Mappings()))
+
+	mappings, err = anonymize.ReadMapFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, a.Mappings(), mappings)
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	}
+}
+
+func TestCustomPatterns(t *testing.T) {
+	a := anonymize.NewAnonymizer(anonymize.DefaultAddresses())
+	a.SetIPsOnly(true)
+	a.SetCustomPatterns([]*regexp.Regexp{regexp.MustCompile(`project-(?:falcon|heron)`), regexp.MustCompile(`\.corp\.internal`)})
+
+	assert.Equal(t, "connecting to REDACTED-1-db REDACTED-2 REDACTED-1 198.51.100.0",
+		a.AnonymizeString("connecting to project-falcon-db project-heron project-falcon 203.0.113.7"))
+	assert.Equal(t, "REDACTED-1-db.netbird.cloud", a.AnonymizeDomain("project-falcon-db.netbird.cloud"),
+		"patterns apply to names the domain anonymization keeps")
+	assert.Equal(t, "hostREDACTED-3", a.AnonymizeDomain("host.corp.internal"))
+
+	second := anonymize.NewAnonymizer(anonymize.DefaultAddresses())
+	second.SetCustomPatterns([]*regexp.Regexp{regexp.MustCompile(`project-\w+`)})
+	require.NoError(t, second.LoadMappings(a.Mappings()))
+	assert.Equal(t, "REDACTED-2 REDACTED-4", second.AnonymizeString("project-heron project-owl"),
+		"loaded placeholders are reused and new ones numbered after them")
+
+	err := second.LoadMappings([]anonymize.Mapping{{Category: anonymize.CategoryCustom, Original: "x", Anonymized: "anon-x"}})
+	assert.Error(t, err)
+}
+
+func TestReadPatternsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "patterns.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# codenames\nproject-\\w+\n\n\\.corp\\.internal\n"), 0o600))
+
+	patterns, err := anonymize.ReadPatternsFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{`project-\w+`, `\.corp\.internal`}, patterns)
+	_, err = anonymize.CompilePatterns(patterns)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte("project-\\w+\n# comment\nbroken(\n"), 0o600))
+	_, err = anonymize.ReadPatternsFile(path)
+	assert.ErrorContains(t, err, path+":3: invalid pattern")
+}
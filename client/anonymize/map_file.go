@@ -0,0 +1,66 @@
+package anonymize
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const mapFileVersion = 1
+
+type mapFile struct {
+	Version  int       `json:"version"`
+	Mappings []Mapping `json:"mappings"`
+}
+
+// ReadMapFile reads mappings written by WriteMapFile. A missing file yields no mappings.
+func ReadMapFile(path string) ([]Mapping, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read anonymization map: %w", err)
+	}
+
+	var file mapFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse anonymization map %s: %w", path, err)
+	}
+	if file.Version != mapFileVersion {
+		return nil, fmt.Errorf("unsupported anonymization map version %d", file.Version)
+	}
+	return file.Mappings, nil
+}
+
+// WriteMapFile replaces the file at path with the mappings. The file reverses the
+// anonymization, so it is only readable by the owner.
+func WriteMapFile(path string, mappings []Mapping) error {
+	data, err := json.MarshalIndent(mapFile{Version: mapFileVersion, Mappings: mappings}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal anonymization map: %w", err)
+	}
+
+	// CreateTemp creates the file with 0600
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create anonymization map: %w", err)
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write anonymization map: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close anonymization map: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("replace anonymization map: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package anonymize
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// customPlaceholderPrefix starts the placeholders of custom pattern matches, e.g. REDACTED-1.
+const customPlaceholderPrefix = "REDACTED-"
+
+// ReadPatternsFile reads a file with one regular expression per line for SetCustomPatterns.
+// Empty lines and lines starting with # are skipped. An invalid expression fails with its line
+// number.
+func ReadPatternsFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read anonymization patterns: %w", err)
+	}
+
+	var patterns []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		pattern := strings.TrimSpace(scanner.Text())
+		if pattern == "" || strings.HasPrefix(pattern, "#") {
+			continue
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid pattern %q: %w", path, line, pattern, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read anonymization patterns: %w", err)
+	}
+	return patterns, nil
+}
+
+// CompilePatterns compiles patterns read with ReadPatternsFile.
+func CompilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for i, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid anonymization pattern %d %q: %w", i+1, pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/netbirdio/netbird/client/anonymize"
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+// shouldAnonymize reports whether output must be anonymized; --anon-map and --anon-patterns imply
+// --anonymize.
+func shouldAnonymize() bool {
+	level, err := anonymizeLevel()
+	return err == nil && level != anonymize.LevelNone
+}
+
+// anonymizeLevel maps --anonymize-level and the older --anonymize to an anonymization level.
+// An explicit --anonymize-level wins, otherwise --anonymize, --anon-map or --anon-patterns select
+// full.
+func anonymizeLevel() (anonymize.Level, error) {
+	level, err := anonymize.ResolveLevel(anonymizeLevelFlag, anonymizeFlag || anonMapFlag != "" || anonPatternsFlag != "")
+	if err != nil {
+		return "", err
+	}
+	if level == anonymize.LevelNone && anonMapFlag != "" {
+		return "", errors.New("--anon-map cannot be used with --anonymize-level none")
+	}
+	if level == anonymize.LevelNone && anonPatternsFlag != "" {
+		return "", errors.New("--anon-patterns cannot be used with --anonymize-level none")
+	}
+	return level, nil
+}
+
+// loadAnonMap returns an anonymizer seeded with the --anon-map file and the --anon-patterns
+// patterns, or nil without both flags.
+func loadAnonMap() (*anonymize.Anonymizer, error) {
+	if anonMapFlag == "" && anonPatternsFlag == "" {
+		return nil, nil //nolint:nilnil // nil anonymizer means a fresh one per run
+	}
+
+	anonymizer := anonymize.NewAnonymizer(anonymize.DefaultAddresses())
+	if anonPatternsFlag != "" {
+		patterns, err := readAnonPatterns()
+		if err != nil {
+			return nil, err
+		}
+		compiled, err := anonymize.CompilePatterns(patterns)
+		if err != nil {
+			return nil, err
+		}
+		anonymizer.SetCustomPatterns(compiled)
+	}
+	if anonMapFlag == "" {
+		return anonymizer, nil
+	}
+
+	mappings, err := anonymize.ReadMapFile(anonMapFlag)
+	if err != nil {
+		return nil, err
+	}
+	if err := anonymizer.LoadMappings(mappings); err != nil {
+		return nil, err
+	}
+	return anonymizer, nil
+}
+
+// readAnonPatterns reads the regular expressions of the --anon-patterns file. It returns nil
+// without the flag.
+func readAnonPatterns() ([]string, error) {
+	if anonPatternsFlag == "" {
+		return nil, nil
+	}
+	return anonymize.ReadPatternsFile(anonPatternsFlag)
+}
+
+// saveAnonMap writes the mappings back to the --anon-map file, including values added in this run.
+func saveAnonMap(mappings []anonymize.Mapping) error {
+	if anonMapFlag == "" {
+		return nil
+	}
+	return anonymize.WriteMapFile(anonMapFlag, mappings)
+}
+
+// readAnonMapRequest reads the --anon-map file for a debug bundle request. It returns nil without the flag.
+func readAnonMapRequest() ([]*proto.AnonymizationMapping, error) {
+	if anonMapFlag == "" {
+		return nil, nil
+	}
+	mappings, err := anonymize.ReadMapFile(anonMapFlag)
+	if err != nil {
+		return nil, err
+	}
+	return toProtoAnonMappings(mappings), nil
+}
+
+// applyAnonMapRequest asks the daemon to reuse and return the mapping when --anon-map is set.
+func applyAnonMapRequest(request *proto.DebugBundleRequest, mappings []*proto.AnonymizationMapping) {
+	if anonMapFlag == "" {
+		return
+	}
+	request.AnonymizationMap = mappings
+	request.PersistAnonymizationMap = true
+}
+
+// saveAnonMapResponse writes the mapping returned with a debug bundle to the --anon-map file.
+func saveAnonMapResponse(resp *proto.DebugBundleResponse) error {
+	if anonMapFlag == "" {
+		return nil
+	}
+	return saveAnonMap(fromProtoAnonMappings(resp.GetAnonymizationMap()))
+}
+
+func toProtoAnonMappings(mappings []anonymize.Mapping) []*proto.AnonymizationMapping {
+	result := make([]*proto.AnonymizationMapping, 0, len(mappings))
+	for _, m := range mappings {
+		result = append(result, &proto.AnonymizationMapping{
+			Category:   string(m.Category),
+			Original:   m.Original,
+			Anonymized: m.Anonymized,
+		})
+	}
+	return result
+}
+
+func fromProtoAnonMappings(mappings []*proto.AnonymizationMapping) []anonymize.Mapping {
+	result := make([]anonymize.Mapping, 0, len(mappings))
+	for _, m := range mappings {
+		result = append(result, anonymize.Mapping{
+			Category:   anonymize.Category(m.GetCategory()),
+			Original:   m.GetOriginal(),
+			Anonymized: m.GetAnonymized(),
+		})
+	}
+	return result
+}
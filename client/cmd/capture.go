@@ -91,9 +91,9 @@ func runCapture(cmd *cobra.Command, args []string) error {
 	}
 
 	if req.TextOutput {
-		cmd.PrintErrf("Capturing packets... Press Ctrl+C to stop.\n")
+		printInfoErr(cmd, "Capturing packets... Press Ctrl+C to stop.\n")
 	} else {
-		cmd.PrintErrf("Capturing packets (pcap)... Press Ctrl+C to stop.\n")
+		printInfoErr(cmd, "Capturing packets (pcap)... Press Ctrl+C to stop.\n")
 	}
 
 	streamErr := streamCapture(ctx, cmd, stream, out)
@@ -139,11 +139,11 @@ func streamCapture(ctx context.Context, cmd *cobra.Command, stream proto.DaemonS
 		pkt, err := stream.Recv()
 		if err != nil {
 			if ctx.Err() != nil {
-				cmd.PrintErrf("\nCapture stopped.\n")
+				printInfoErr(cmd, "\nCapture stopped.\n")
 				return nil //nolint:nilerr // user interrupted
 			}
 			if err == io.EOF {
-				cmd.PrintErrf("\nCapture finished.\n")
+				printInfoErr(cmd, "\nCapture finished.\n")
 				return nil
 			}
 			return handleCaptureError(err)
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/spf13/cobra"
+)
+
+// defaultDaemonTimeout bounds the connection to the daemon when --daemon-timeout is not set.
+const defaultDaemonTimeout = 10 * time.Second
+
+// defaultConnectRetry is how long debug commands wait for a daemon that is still starting when
+// --connect-retry is not set.
+const defaultConnectRetry = 3 * time.Second
+
+const connectRetryFlag = "connect-retry"
+
+const daemonNotRunningHint = "If the daemon is not running please run:\nnetbird service install\nnetbird service start"
+
+// daemonDialError explains why the daemon at addr could not be reached. It tells a daemon that
+// is not running apart from a wrong address and from a daemon that does not answer in time.
+func daemonDialError(addr string, timeout time.Duration, err error) error {
+	probeErr := probeDaemonAddr(addr, timeout)
+
+	var reason string
+	switch {
+	case errors.Is(probeErr, fs.ErrNotExist):
+		reason = fmt.Sprintf("daemon socket %s does not exist: the daemon is not running or --daemon-addr is wrong.\n%s",
+			strings.TrimPrefix(addr, "unix://"), daemonNotRunningHint)
+	case errors.Is(probeErr, fs.ErrPermission):
+		reason = fmt.Sprintf("no permission to connect to the daemon socket %s, run the command with elevated privileges",
+			strings.TrimPrefix(addr, "unix://"))
+	case errors.Is(probeErr, syscall.ECONNREFUSED):
+		reason = fmt.Sprintf("nothing accepts connections on %s: the daemon is not running or --daemon-addr is wrong.\n%s",
+			addr, daemonNotRunningHint)
+	case isTimeout(probeErr):
+		reason = fmt.Sprintf("%s did not accept a connection within %s: the address is not reachable or --daemon-addr is wrong", addr, timeout)
+	case probeErr != nil:
+		reason = fmt.Sprintf("cannot connect to %s: %v", addr, probeErr)
+	default:
+		reason = fmt.Sprintf("the daemon at %s accepts connections but did not answer within %s: it may be starting or stuck, retry or raise --daemon-timeout", addr, timeout)
+	}
+
+	return fmt.Errorf("failed to connect to daemon: %s (%v)", reason, err)
+}
+
+// probeDaemonAddr opens a plain connection to addr to find out why the gRPC dial failed.
+func probeDaemonAddr(addr string, timeout time.Duration) error {
+	network, address := "tcp", strings.TrimPrefix(addr, "tcp://")
+	if path, ok := strings.CutPrefix(addr, "unix://"); ok {
+		if _, err := os.Stat(path); err != nil {
+			return err
+		}
+		network, address = "unix", path
+	}
+
+	conn, err := net.DialTimeout(network, address, timeout)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// connectRetryFor returns how long cmd waits for a daemon that does not listen yet. Commands
+// without --connect-retry do not wait.
+func connectRetryFor(cmd *cobra.Command) time.Duration {
+	if cmd.Flag(connectRetryFlag) == nil {
+		return 0
+	}
+	return connectRetry
+}
+
+// waitForDaemon waits up to retry for the daemon to listen on addr, e.g. while it is still
+// starting. Only a missing socket or a refused connection is retried, other failures are left to
+// the dial to report. "Waiting for daemon..." is printed once when the first probe fails.
+func waitForDaemon(cmd *cobra.Command, addr string, retry time.Duration) {
+	bo := backoff.WithContext(&backoff.ExponentialBackOff{
+		InitialInterval:     100 * time.Millisecond,
+		RandomizationFactor: backoff.DefaultRandomizationFactor,
+		Multiplier:          backoff.DefaultMultiplier,
+		MaxInterval:         time.Second,
+		MaxElapsedTime:      retry,
+		Stop:                backoff.Stop,
+		Clock:               backoff.SystemClock,
+	}, cmd.Context())
+
+	var waiting bool
+	_ = backoff.RetryNotify(func() error {
+		if err := probeDaemonAddr(addr, daemonTimeout); isDaemonNotListening(err) {
+			return err
+		}
+		return nil
+	}, bo, func(error, time.Duration) {
+		if !waiting {
+			waiting = true
+			cmd.PrintErrln("Waiting for daemon...")
+		}
+	})
+}
+
+// isDaemonNotListening reports whether a probe failed because nothing listens on the daemon
+// address yet.
+func isDaemonNotListening(err error) bool {
+	return errors.Is(err, fs.ErrNotExist) || errors.Is(err, syscall.ECONNREFUSED)
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func TestDaemonDialError(t *testing.T) {
+	dialErr := errors.New("context deadline exceeded")
+
+	missing := "unix://" + filepath.Join(t.TempDir(), "netbird.sock")
+	if err := daemonDialError(missing, time.Second, dialErr); !strings.Contains(err.Error(), "does not exist") {
+		t.Errorf("missing socket: got %q", err)
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := "tcp://" + l.Addr().String()
+
+	if err := daemonDialError(addr, time.Second, dialErr); !strings.Contains(err.Error(), "did not answer") {
+		t.Errorf("listening address: got %q", err)
+	}
+
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if runtime.GOOS == "windows" {
+		// refused connections are reported as WSAECONNREFUSED
+		return
+	}
+	if err := daemonDialError(addr, time.Second, dialErr); !strings.Contains(err.Error(), "nothing accepts connections") {
+		t.Errorf("closed address: got %q", err)
+	}
+}
+
+func TestWaitForDaemon(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix sockets are not used on windows")
+	}
+
+	path := filepath.Join(t.TempDir(), "netbird.sock")
+	var stderr strings.Builder
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	cmd.SetErr(&stderr)
+
+	listening := make(chan net.Listener, 1)
+	go func() {
+		time.Sleep(300 * time.Millisecond)
+		l, err := net.Listen("unix", path)
+		if err != nil {
+			t.Error(err)
+		}
+		listening <- l
+	}()
+
+	waitForDaemon(cmd, "unix://"+path, 5*time.Second)
+	l := <-listening
+	if l == nil {
+		return
+	}
+	defer l.Close()
+
+	if err := probeDaemonAddr("unix://"+path, time.Second); err != nil {
+		t.Errorf("daemon not listening after wait: %v", err)
+	}
+	if n := strings.Count(stderr.String(), "Waiting for daemon..."); n != 1 {
+		t.Errorf("waiting message printed %d times: %q", n, stderr.String())
+	}
+}
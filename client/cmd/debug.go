@@ -2,9 +2,17 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"os/signal"
 	"os/user"
+	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -12,7 +20,9 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/netbirdio/netbird/client/anonymize"
 	"github.com/netbirdio/netbird/client/internal"
 	"github.com/netbirdio/netbird/client/internal/debug"
 	"github.com/netbirdio/netbird/client/internal/peer"
@@ -21,18 +31,67 @@ import (
 	"github.com/netbirdio/netbird/client/server"
 	mgmProto "github.com/netbirdio/netbird/shared/management/proto"
 	"github.com/netbirdio/netbird/upload-server/types"
+	"github.com/netbirdio/netbird/util"
 	"github.com/netbirdio/netbird/version"
 )
 
 const errCloseConnection = "Failed to close connection: %v"
 
 var (
-	logFileCount        uint32
-	systemInfoFlag      bool
-	uploadBundleFlag    bool
-	uploadBundleURLFlag string
+	logFileCount         uint32
+	systemInfoFlag       string
+	uploadBundleFlag     bool
+	uploadBundleURLFlag  string
+	anonymizePreviewFlag bool
+	peerMTUProbeFlag     bool
+	encryptKeyFlag       string
+	bundleOutputFlag     string
+	untilSignalFlag      bool
+	statusFormatFlag     string
+	maxSizeMBFlag        uint32
+	pcapPeerFlag         string
+	networkMapCountFlag  uint32
+	sinceFlag            time.Duration
+	tailFlag             uint32
+	incrementalFlag      bool
+	fullLogsFlag         bool
+	noLogsFlag           bool
+	forDryRunFlag        bool
+	forNoRestartFlag     bool
+	bundleJSONFlag       bool
+	showSecretsFlag      bool
+	uploadTimeoutFlag    time.Duration
+	uploadRetriesFlag    uint32
+	profilesFlag         bool
+	profileCPUFlag       time.Duration
+	bundlePeersFlag      []string
+	bundleGroupFlag      string
+	bundleDomainFlag     string
+	forIntervalFlag      time.Duration
+	logLevelPersistFlag  bool
+	bundleIncludeFlag    []string
+	logIncludeFlag       []string
+	logExcludeFlag       []string
+	outputDirFlag        string
+	listSystemInfoFlag   bool
+	logTailLevelFlag     string
+	logTailComponentFlag []string
+	bundleCompressFlag   string
+	uploadLastFlag       bool
+	noURLCheckFlag       bool
+	allowSecretsFlag     bool
+	splitSizeFlag        string
 )
 
+// allowSecretsFlagName turns off the masking of secrets in debug bundles.
+const allowSecretsFlagName = "allow-secrets"
+
+// untilInterruptArg makes "debug for" run until interrupted instead of for a fixed duration.
+const untilInterruptArg = "until-interrupt"
+
+// forRestoreTimeout bounds each call restoring the daemon state at the end of "debug for".
+const forRestoreTimeout = 30 * time.Second
+
 var debugCmd = &cobra.Command{
 	Use:   "debug",
 	Short: "Debugging commands",
@@ -41,22 +100,40 @@ var debugCmd = &cobra.Command{
 
 var debugBundleCmd = &cobra.Command{
 	Use:     "bundle",
-	Example: "  netbird debug bundle",
+	Example: "  netbird debug bundle\n  netbird debug bundle -U --json\n  sudo netbird debug bundle --offline",
 	Short:   "Create a debug bundle",
-	Long:    "Generates a compressed archive of the daemon's logs and status for debugging purposes.",
-	RunE:    debugBundle,
+	Long: "Generates a compressed archive of the daemon's logs and status for debugging purposes.\n\n" +
+		"With --encrypt-key the bundle is encrypted to a public key while it is written, so it never exists as a plain zip. " +
+		"Accepted keys are age X25519 recipients (age1..., as printed by age-keygen), producing a .zip.age file that is decrypted with \"age -d -i key.txt\", " +
+		"and OpenPGP public keys, armored or binary (gpg --export), producing a .zip.gpg file that is decrypted with \"gpg -d\".\n\n" +
+		"With --offline the CLI creates the bundle itself when the daemon crashed or does not start. It reads the daemon logs and the config " +
+		"of the active profile from disk, so it usually needs to run as root or administrator. The live status and network map only exist in the " +
+		"daemon and are missing, offline.txt in the bundle lists what is not included.\n\n" +
+		"For agents that speak HTTP instead of gRPC, the daemon can stream the same bundle from GET /debug/bundle on its " +
+		"JSON socket, see \"netbird service install --enable-json-socket --enable-debug-bundle-http\".\n\n" +
+		"With --json the result is printed to stdout as a single JSON object with the fields local_path, uploaded_key, expires_at, " +
+		"upload_failure_reason and anonymized, plus error when no bundle was created. The exit code is 0 on success, 1 for usage errors or an unreachable daemon, " +
+		"2 when no bundle was created and 3 when the bundle was created but the upload failed.\n\n" + debugEnvHelp,
+	RunE: debugBundle,
 }
 
 var logCmd = &cobra.Command{
 	Use:   "log",
 	Short: "Manage logging for the NetBird daemon",
-	Long:  `Commands to manage logging settings for the NetBird daemon, including ICE, gRPC, and general log levels.`,
+	Long:  `Commands to manage logging settings for the NetBird daemon, including ICE, gRPC, and general log levels, and to rotate the log file.`,
 }
 
 var logLevelCmd = &cobra.Command{
-	Use:   "level <level>",
-	Short: "Set the logging level for this session",
-	Long: `Sets the logging level for the current session. This setting is temporary and will revert to the default on daemon restart.
+	Use:   "level [level]",
+	Short: "Show or set the logging level for this session",
+	Long: `Sets the logging level for the current session. This setting is temporary and will revert to the default on daemon restart,
+unless --persist is set: the level is then also written into the active profile config and applied at daemon startup.
+Persisting the daemon default level, e.g. "netbird debug log level info --persist", removes the persisted level again.
+With --profile, the level targets that profile. If it is the active profile, the level applies immediately as without --profile.
+Otherwise it is written into the config of that profile and applies when the profile is next activated, the running daemon keeps its level.
+Without an argument, prints the current level and whether it is the daemon default or a session override.
+Levels can be set per component with component=level pairs, optionally after a base level, e.g. "ice=trace,grpc=warn" or "info,relay=debug".
+Components without a level use the base level. Available components are: ice, relay, grpc, peer, dns, route, firewall, engine.
 Available log levels are:
   panic:   for panic level, highest level of severity
   fatal:   for fatal level errors that cause the program to exit
@@ -65,25 +142,71 @@ Available log levels are:
   info:    for informational messages
   debug:   for debug-level messages
   trace:   for trace-level messages, which include more fine-grained information than debug`,
-	Args: cobra.ExactArgs(1),
-	RunE: setLogLevel,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeLogLevel,
+	RunE:              setLogLevel,
+}
+
+var logRotateCmd = &cobra.Command{
+	Use:   "rotate",
+	Short: "Rotate the daemon log file",
+	Long: `Closes the active daemon log file, moves it aside and starts a new one, so a following debug bundle only holds the logs of a reproduction.
+Prints the path of the rotated-out file, which is compressed to a .gz file shortly afterwards. Fails when the daemon logs to the console or syslog, or when log rotation is disabled.`,
+	Example: "  netbird debug log rotate",
+	Args:    cobra.NoArgs,
+	RunE:    rotateLog,
+}
+
+var logFormatCmd = &cobra.Command{
+	Use:   "format <text|json>",
+	Short: "Switch the daemon log between text and JSON lines",
+	Long: `Switches the format of the daemon log until the daemon restarts. JSON lines carry the level, the source, the component and fields like the peer as separate keys, for log shippers.
+Lines already written keep their format. Set NB_LOG_FORMAT=json in the service environment to start the daemon with JSON logs. Fails when the daemon logs to syslog.`,
+	Example:   "  netbird debug log format json",
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{util.LogFormatText, util.LogFormatJSON},
+	RunE:      setLogFormat,
+}
+
+var logTailCmd = &cobra.Command{
+	Use:   "tail",
+	Short: "Print the daemon log lines as they are written",
+	Long: `Streams the daemon log lines live until interrupted, like "journalctl -f" but on every platform and limited to NetBird.
+--level drops more verbose lines and --component limits the lines to components (ice, relay, grpc, peer, dns, route, firewall, engine).
+Lines more verbose than the daemon log level are never written, raise it with "netbird debug log level" first. The log file is not affected.`,
+	Example: "  netbird debug log tail --level debug --component ice --component relay",
+	Args:    cobra.NoArgs,
+	RunE:    tailLog,
 }
 
 var forCmd = &cobra.Command{
-	Use:     "for <time>",
-	Short:   "Run debug logs for a specified duration and create a debug bundle",
-	Long:    `Sets the logging level to trace, runs for the specified duration, and then generates a debug bundle.`,
-	Example: "  netbird debug for 5m",
-	Args:    cobra.ExactArgs(1),
+	Use:   "for <time|until-interrupt>",
+	Short: "Run debug logs for a specified duration and create a debug bundle",
+	Long: `Sets the logging level to trace, runs for the specified duration, and then generates a debug bundle.
+With "until-interrupt" or --until-signal it runs until Ctrl+C is pressed instead. Interrupting a timed run creates the bundle early.
+In both cases the previous log level, sync response persistence and connection state are restored afterwards.
+With --interval a bundle is also created at every interval, each named with its creation time.
+To enable sync response persistence, the service is taken down and up again. With --no-restart a service that is up keeps
+its connection instead, and the bundle may lack the current network map until management sends an update.
+The daemon logs are copied aside when the session starts and merged into the final bundle, so lines lost to a daemon
+restart or log rotation during the session are still included.
+With --run a command is run by this CLI at the start of the session, not by the daemon, and its combined output is
+added to the bundle as command-output.txt with its exit code and timing relative to the session start. The session
+still lasts the full duration when the command exits early, a command still running at its end is killed. Repeated
+--run commands run one after another.
+With --dry-run only the current state is read and the planned steps are printed.
+` + debugEnvHelp,
+	Example: "  netbird debug for 5m\n  netbird debug for until-interrupt\n  netbird debug for 30m --interval 5m\n  netbird debug for 5m --dry-run\n  netbird debug for 10m --no-restart\n  netbird debug for 30s --run \"curl -v https://internal.example.com\"",
+	Args:    cobra.MaximumNArgs(1),
 	RunE:    runForDuration,
 }
 
 var persistenceCmd = &cobra.Command{
-	Use:     "persistence [on|off]",
-	Short:   "Set sync response memory persistence",
-	Long:    `Configure whether the latest sync response should persist in memory. When enabled, the last known sync response will be kept in memory.`,
-	Example: "  netbird debug persistence on",
-	Args:    cobra.ExactArgs(1),
+	Use:     "persistence [on|off|status]",
+	Short:   "Set or show sync response memory persistence",
+	Long:    `Configure whether the latest sync response should persist in memory. When enabled, the last known sync response will be kept in memory. Without an argument or with "status" the current setting is printed.`,
+	Example: "  netbird debug persistence on\n  netbird debug persistence status",
+	Args:    cobra.MaximumNArgs(1),
 	RunE:    setSyncResponsePersistence,
 }
 
@@ -95,6 +218,49 @@ var debugConfigCmd = &cobra.Command{
 	RunE:    debugConfigDump,
 }
 
+var debugConfigDumpCmd = &cobra.Command{
+	Use:     "dump",
+	Example: "  netbird debug config dump\n  netbird debug config dump --show-secrets",
+	Short:   "Print the configuration the daemon is running with",
+	Long: "Prints the merged configuration the daemon is currently running with, after the profile file, environment, " +
+		"CLI flags and MDM policy were applied, in the JSON format of the profile file. Unlike \"debug config\", which reads " +
+		"the active profile file, this shows what the running daemon uses. The private, pre-shared and SSH keys are redacted " +
+		"unless --show-secrets is given. The setup key is only used at login and never stored.",
+	Args: cobra.NoArgs,
+	RunE: debugEffectiveConfigDump,
+}
+
+// debugEffectiveConfigDump implements `netbird debug config dump`. It prints the config the
+// daemon runs with, as returned by GetEffectiveConfig, followed by the MDM-managed fields.
+func debugEffectiveConfigDump(cmd *cobra.Command, _ []string) error {
+	conn, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			log.Errorf(errCloseConnection, err)
+		}
+	}()
+
+	client := proto.NewDaemonServiceClient(conn)
+	resp, err := client.GetEffectiveConfig(cmd.Context(), &proto.GetEffectiveConfigRequest{
+		ShowSecrets: showSecretsFlag,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get effective config: %v", status.Convert(err).Message())
+	}
+
+	if showSecretsFlag {
+		cmd.PrintErrln("Warning: the output contains the private keys of this peer. Do not share it.")
+	}
+	cmd.Println(resp.GetConfig())
+	if managed := resp.GetMDMManagedFields(); len(managed) > 0 {
+		cmd.PrintErrf("MDM managed fields: %s\n", strings.Join(managed, ", "))
+	}
+	return nil
+}
+
 // debugConfigDump implements `netbird debug config`. It resolves the
 // active profile, queries the daemon for the effective configuration
 // via GetConfig, and prints the resulting GetConfigResponse as JSON
@@ -155,6 +321,89 @@ func debugConfigDump(cmd *cobra.Command, _ []string) error {
 // request. Returns an error if the RPC fails or if the daemon reports
 // an upload failure reason.
 func debugBundle(cmd *cobra.Command, _ []string) error {
+	if err := setDebugFlagsFromEnv(cmd); err != nil {
+		return err
+	}
+	if listSystemInfoFlag {
+		printSystemInfoCollectors(cmd)
+		return nil
+	}
+
+	streamToStdout := bundleOutputFlag == "-"
+	if bundleOutputFlag != "" && !streamToStdout {
+		return fmt.Errorf("unsupported --output %q: only - (stdout) is supported", bundleOutputFlag)
+	}
+	if streamToStdout && uploadBundleFlag {
+		return errors.New("--output - and --upload-bundle cannot be used together")
+	}
+	if streamToStdout && anonymizePreviewFlag {
+		return errors.New("--output - and --anonymize-preview cannot be used together")
+	}
+	if streamToStdout && anonMapFlag != "" {
+		return errors.New("--output - and --anon-map cannot be used together")
+	}
+	if uploadLastFlag && (streamToStdout || anonymizePreviewFlag) {
+		return errors.New("--upload-last cannot be used with --output - or --anonymize-preview")
+	}
+	if bundleJSONFlag && (streamToStdout || anonymizePreviewFlag) {
+		return errors.New("--json cannot be used with --output - or --anonymize-preview")
+	}
+	if outputDirFlag != "" && (streamToStdout || uploadLastFlag) {
+		return errors.New("--output-dir cannot be used with --output - or --upload-last")
+	}
+	splitSize, err := parseSplitSize()
+	if err != nil {
+		return err
+	}
+	if splitSize > 0 && (streamToStdout || uploadBundleFlag || uploadLastFlag) {
+		return errors.New("--split-size cannot be used with --output -, --upload-bundle or --upload-last")
+	}
+	outputDir := outputDirFlag
+	if outputDir != "" {
+		// the daemon has its own working directory
+		abs, err := filepath.Abs(outputDir)
+		if err != nil {
+			return fmt.Errorf("resolve --output-dir: %w", err)
+		}
+		outputDir = abs
+	}
+	if _, err := debug.ParseStatusFormat(statusFormatFlag); err != nil {
+		return err
+	}
+	if sinceFlag < 0 {
+		return errors.New("--since must be a positive duration")
+	}
+	if profilesFlag && (profileCPUFlag <= 0 || profileCPUFlag > debug.MaxCPUProfileDuration) {
+		return fmt.Errorf("--profile-cpu-duration must be between 0 and %s", debug.MaxCPUProfileDuration)
+	}
+	if sinceFlag > 0 && cmd.Flags().Changed("log-file-count") {
+		printInfoErr(cmd, "--since is set, rotated logs are selected by age and --log-file-count is ignored\n")
+	}
+	if incrementalFlag && fullLogsFlag {
+		return errors.New("--incremental and --full cannot be used together")
+	}
+	if (incrementalFlag || fullLogsFlag) && uploadLastFlag {
+		return errors.New("--incremental and --full cannot be used with --upload-last")
+	}
+	if noLogsFlag {
+		if incrementalFlag || fullLogsFlag {
+			return errors.New("--no-logs cannot be used with --incremental or --full")
+		}
+		if ignored := noLogsIgnored(cmd.Flags()); len(ignored) > 0 {
+			printInfoErr(cmd, "--no-logs is set, ignoring %s\n", strings.Join(ignored, ", "))
+		}
+	}
+
+	if bundleOfflineFlag {
+		if traceInfraFlag {
+			return errors.New("--trace-infra needs the daemon for the server endpoints, it cannot be combined with --offline")
+		}
+		if incrementalFlag || fullLogsFlag {
+			return errors.New("--incremental and --full need the daemon for the log baseline, they cannot be combined with --offline")
+		}
+		return offlineDebugBundle(cmd, outputDir, splitSize)
+	}
+
 	conn, err := getClient(cmd)
 	if err != nil {
 		return err
@@ -165,34 +414,310 @@ func debugBundle(cmd *cobra.Command, _ []string) error {
 		}
 	}()
 
+	if uploadLastFlag {
+		return uploadLastBundle(cmd, proto.NewDaemonServiceClient(conn))
+	}
+
+	encryptionKey, err := readEncryptionKey()
+	if err != nil {
+		return err
+	}
+
+	anonMap, err := readAnonMapRequest()
+	if err != nil {
+		return err
+	}
+	anonPatterns, err := readAnonPatterns()
+	if err != nil {
+		return err
+	}
+	if err := checkAllowSecrets(cmd); err != nil {
+		return err
+	}
+
+	level, err := anonymizeLevel()
+	if err != nil {
+		return err
+	}
+
+	if _, err := debug.ParseLogFilter(logIncludeFlag, logExcludeFlag); err != nil {
+		return err
+	}
+	aclQueries, err := aclQueriesRequest()
+	if err != nil {
+		return err
+	}
+	systemInfo, systemInfoCollectors, err := systemInfoRequest()
+	if err != nil {
+		return err
+	}
+
 	client := proto.NewDaemonServiceClient(conn)
 	request := &proto.DebugBundleRequest{
-		Anonymize:    anonymizeFlag,
-		SystemInfo:   systemInfoFlag,
-		LogFileCount: logFileCount,
-		CliVersion:   version.NetbirdVersion(),
+		EncryptionKey:    encryptionKey,
+		Anonymize:        shouldAnonymize(),
+		AnonymizeLevel:   string(level),
+		SystemInfo:       systemInfo,
+		LogFileCount:     logFileCount,
+		CliVersion:       version.NetbirdVersion(),
+		AnonymizePreview: anonymizePreviewFlag,
+		PeerMtuProbe:     peerMTUProbeFlag,
+		StatusFormat:     statusFormatFlag,
+		MaxSize:          uint64(maxSizeMBFlag) * 1024 * 1024,
+		NetworkMapCount:  networkMapCountFlag,
+		Peers:            bundlePeersFlag,
+		PeerGroup:        bundleGroupFlag,
+		Domain:           bundleDomainFlag,
+		ExtraPaths:       bundleIncludeFlag,
+		Compression:      bundleCompressFlag,
+		LogInclude:       logIncludeFlag,
+		LogExclude:       logExcludeFlag,
+		OutputDir:        outputDir,
+
+		SystemInfoCollectors: systemInfoCollectors,
+		AnonymizePatterns:    anonPatterns,
+		AllowSecrets:         allowSecretsFlag,
+		SplitSize:            uint64(splitSize),
+		AclQueries:           aclQueries,
 	}
-	if uploadBundleFlag {
+	if len(bundleIncludeFlag) > 0 {
+		cmd.PrintErrln("Note: files added with --include are copied as they are and not anonymized")
+	}
+	if sinceFlag > 0 {
+		request.Since = durationpb.New(sinceFlag)
+	}
+	request.LogTail = tailFlag
+	request.IncrementalLogs = incrementalFlag
+	request.FullLogs = fullLogsFlag
+	request.NoLogs = noLogsFlag
+	if traceInfraFlag {
+		printInfoErr(cmd, "Tracing the route to the management, signal and relay servers, this takes up to %s\n", tracerouteTimeout)
+		if request.Traceroute, err = traceInfraRequest(cmd.Context(), client); err != nil {
+			cmd.PrintErrf("Warning: the bundle has no traceroute.txt: %v\n", status.Convert(err).Message())
+		}
+	}
+	if profilesFlag {
+		request.Profiles = true
+		request.ProfileCpuDuration = durationpb.New(profileCPUFlag)
+		printInfoErr(cmd, "Collecting runtime profiles, this takes at least %s\n", profileCPUFlag)
+	}
+	applyAnonMapRequest(request, anonMap)
+	if streamToStdout {
+		return streamDebugBundle(cmd, client, request)
+	}
+
+	if uploadBundleFlag && anonymizePreviewFlag {
+		printInfoErr(cmd, "Skipping upload: --anonymize-preview only creates a local bundle\n")
+	} else if uploadBundleFlag {
 		request.UploadURL = uploadBundleURLFlag
+		setUploadLimits(request)
 	}
-	resp, err := client.DebugBundle(cmd.Context(), request)
+	resp, err := requestDebugBundle(cmd, client, request)
 	if err != nil {
-		return fmt.Errorf("failed to bundle debug: %v", status.Convert(err).Message())
+		err = fmt.Errorf("failed to bundle debug: %v", status.Convert(err).Message())
+		if bundleJSONFlag {
+			return printBundleJSON(cmd, nil, err)
+		}
+		return err
+	}
+	return printBundleResponse(cmd, resp)
+}
+
+// printBundleResponse prints where a created bundle is and the result of its upload.
+func printBundleResponse(cmd *cobra.Command, resp *proto.DebugBundleResponse) error {
+	printTruncatedLogs(cmd, resp.GetTruncatedLogs())
+	if err := saveAnonMapResponse(resp); err != nil {
+		cmd.PrintErrf("Failed to update anonymization map: %v\n", err)
+	}
+	if bundleJSONFlag {
+		return printBundleJSON(cmd, resp, nil)
+	}
+	if parts := resp.GetParts(); len(parts) > 0 {
+		printSplitParts(cmd, parts)
+	} else {
+		cmd.Printf("Local file:\n%s\n", resp.GetPath())
+	}
+
+	if anonymizePreviewFlag {
+		printAnonymizationPreview(cmd, resp.GetAnonymizationPreview())
+		return nil
 	}
-	cmd.Printf("Local file:\n%s\n", resp.GetPath())
 
 	if resp.GetUploadFailureReason() != "" {
 		return fmt.Errorf("upload failed: %s", resp.GetUploadFailureReason())
 	}
 
 	if uploadBundleFlag {
-		cmd.Printf("Upload file key:\n%s\n", resp.GetUploadedKey())
+		printUploadedKey(cmd, resp)
 	}
 
 	return nil
 }
 
+// uploadLastBundle uploads the bundle the daemon generated last instead of generating a new one.
+func uploadLastBundle(cmd *cobra.Command, client proto.DaemonServiceClient) error {
+	request := &proto.DebugBundleRequest{
+		UploadLast: true,
+		UploadURL:  uploadBundleURLFlag,
+		CliVersion: version.NetbirdVersion(),
+	}
+	setUploadLimits(request)
+
+	resp, err := requestDebugBundle(cmd, client, request)
+	if err != nil {
+		err = fmt.Errorf("failed to upload last debug bundle: %v", status.Convert(err).Message())
+		if bundleJSONFlag {
+			return printBundleJSON(cmd, nil, err)
+		}
+		return err
+	}
+	if bundleJSONFlag {
+		return printBundleJSON(cmd, resp, nil)
+	}
+	cmd.Printf("Local file:\n%s\n", resp.GetPath())
+
+	if resp.GetUploadFailureReason() != "" {
+		return fmt.Errorf("upload failed: %s", resp.GetUploadFailureReason())
+	}
+	printUploadedKey(cmd, resp)
+	return nil
+}
+
+// parseSplitSize parses --split-size, zero when it is not set.
+func parseSplitSize() (int64, error) {
+	if splitSizeFlag == "" {
+		return 0, nil
+	}
+	size, err := debug.ParseSize(splitSizeFlag)
+	if err != nil {
+		return 0, fmt.Errorf("--split-size: %w", err)
+	}
+	if size < debug.MinSplitSize {
+		return 0, fmt.Errorf("--split-size must be at least %d KB", debug.MinSplitSize/1024)
+	}
+	return size, nil
+}
+
+// printSplitParts lists the parts of a split bundle and how to put them back together.
+func printSplitParts(cmd *cobra.Command, parts []string) {
+	bundle := strings.TrimSuffix(parts[0], filepath.Ext(parts[0]))
+	cmd.Printf("Local files (%d parts):\n%s\n", len(parts), strings.Join(parts, "\n"))
+	cmd.Printf("Reassemble with \"cat %s.* > %s\", see %s\n", bundle, bundle, debug.SplitNotePath(bundle))
+}
+
+// printUploadedKey prints the key of an uploaded bundle and when the upload server deletes it.
+func printUploadedKey(cmd *cobra.Command, resp *proto.DebugBundleResponse) {
+	cmd.Printf("Upload file key:\n%s\n", resp.GetUploadedKey())
+	cmd.Printf("Expires: %s\n", formatBundleExpiry(resp))
+}
+
+// formatBundleExpiry returns the expiry of an uploaded bundle in local time, or "unknown" when
+// the upload server did not report one.
+func formatBundleExpiry(resp *proto.DebugBundleResponse) string {
+	if resp.GetExpiresAt() == nil {
+		return "unknown"
+	}
+	return resp.GetExpiresAt().AsTime().Local().Format(time.RFC3339)
+}
+
+// setUploadLimits applies --upload-timeout, --upload-retries and --no-url-check to an upload request.
+func setUploadLimits(request *proto.DebugBundleRequest) {
+	request.UploadTimeout = durationpb.New(uploadTimeoutFlag)
+	request.UploadRetries = uploadRetriesFlag
+	request.SkipUploadURLCheck = noURLCheckFlag
+}
+
+// streamDebugBundle writes the bundle to stdout. Status messages go to stderr so they don't
+// corrupt the archive.
+func streamDebugBundle(cmd *cobra.Command, client proto.DaemonServiceClient, request *proto.DebugBundleRequest) error {
+	stream, err := client.DebugBundleStream(cmd.Context(), request)
+	if err != nil {
+		return fmt.Errorf("failed to bundle debug: %v", status.Convert(err).Message())
+	}
+
+	var written int64
+	out := cmd.OutOrStdout()
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to bundle debug: %v", status.Convert(err).Message())
+		}
+		n, err := out.Write(chunk.GetData())
+		if err != nil {
+			return fmt.Errorf("write debug bundle: %w", err)
+		}
+		written += int64(n)
+	}
+
+	printInfoErr(cmd, "Debug bundle written to stdout (%d bytes)\n", written)
+	return nil
+}
+
+// printTruncatedLogs tells the user which logs --max-size shortened, so a missing time range
+// in the bundle is not mistaken for a gap in logging.
+func printTruncatedLogs(cmd *cobra.Command, truncated []string) {
+	if len(truncated) == 0 {
+		return
+	}
+	cmd.PrintErrf("Logs truncated to stay within --max-size %d MB (newest lines kept):\n", maxSizeMBFlag)
+	for _, t := range truncated {
+		cmd.PrintErrf("  %s\n", t)
+	}
+}
+
+// printAnonymizationPreview prints the redaction summary returned for
+// --anonymize-preview. The original values only ever reach this terminal.
+func printAnonymizationPreview(cmd *cobra.Command, preview []*proto.AnonymizationSummary) {
+	summaries := make([]debug.AnonymizationSummary, 0, len(preview))
+	for _, p := range preview {
+		summary := debug.AnonymizationSummary{
+			Category: anonymize.Category(p.GetCategory()),
+			Count:    int(p.GetCount()),
+		}
+		for _, s := range p.GetSamples() {
+			summary.Samples = append(summary.Samples, anonymize.Mapping{
+				Category:   summary.Category,
+				Original:   s.GetOriginal(),
+				Anonymized: s.GetAnonymized(),
+			})
+		}
+		summaries = append(summaries, summary)
+	}
+
+	cmd.Println("\nAnonymization preview (local only, not included in the bundle):")
+	cmd.Print(debug.FormatAnonymizationPreview(summaries))
+}
+
+// readEncryptionKey reads the public key file given by --encrypt-key. The key is parsed by the
+// daemon, which reports unsupported formats.
+func readEncryptionKey() ([]byte, error) {
+	if encryptKeyFlag == "" {
+		return nil, nil
+	}
+	key, err := os.ReadFile(encryptKeyFlag)
+	if err != nil {
+		return nil, fmt.Errorf("read encryption key: %w", err)
+	}
+	return key, nil
+}
+
 func setLogLevel(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 && profileName != "" {
+		return fmt.Errorf("--profile requires a level to set")
+	}
+
+	// parse before dialing, so typos fail without a daemon round-trip. The daemon checks the level again.
+	var request *proto.SetLogLevelRequest
+	if len(args) > 0 {
+		var err error
+		if request, err = parseLogLevelArg(args[0]); err != nil {
+			return err
+		}
+	}
+
 	conn, err := getClient(cmd)
 	if err != nil {
 		return err
@@ -204,27 +729,289 @@ func setLogLevel(cmd *cobra.Command, args []string) error {
 	}()
 
 	client := proto.NewDaemonServiceClient(conn)
-	level := server.ParseLogLevel(args[0])
-	if level == proto.LogLevel_UNKNOWN {
-		//nolint
-		return fmt.Errorf("unknown log level: %s. Available levels are: panic, fatal, error, warn, info, debug, trace\n", args[0])
+	if request == nil {
+		return printLogLevel(cmd, client)
 	}
 
-	_, err = client.SetLogLevel(cmd.Context(), &proto.SetLogLevelRequest{
-		Level: level,
-	})
+	request.Persist = logLevelPersistFlag
+	if profileName != "" {
+		currUser, err := user.Current()
+		if err != nil {
+			return fmt.Errorf("get current user: %v", err)
+		}
+		request.ProfileName = profileName
+		request.Username = currUser.Username
+	}
+
+	resp, err := client.SetLogLevel(cmd.Context(), request)
 	if err != nil {
 		return fmt.Errorf("failed to set log level: %v", status.Convert(err).Message())
 	}
 
-	cmd.Println("Log level set successfully to", args[0])
+	for _, name := range resp.GetUnknownComponents() {
+		cmd.PrintErrf("Warning: unknown log component %q ignored\n", name)
+	}
+
+	switch {
+	case !resp.GetApplied() && resp.GetPersistCleared():
+		printInfo(cmd, "Persisted log level removed from inactive profile %s, the daemon default applies when it is next activated. The running daemon is unchanged\n", resp.GetProfileName())
+	case !resp.GetApplied():
+		printInfo(cmd, "Log level %s persisted into inactive profile %s, it takes effect when the profile is next activated. The running daemon is unchanged\n", args[0], resp.GetProfileName())
+	case profileName != "" && resp.GetPersisted():
+		printInfo(cmd, "Log level set successfully to %s for active profile %s, effective immediately and persisted\n", args[0], resp.GetProfileName())
+	case profileName != "":
+		printInfo(cmd, "Log level set successfully to %s for active profile %s, effective immediately for this session only\n", args[0], resp.GetProfileName())
+	case resp.GetPersisted():
+		printInfo(cmd, "Log level set successfully to %s and persisted, it stays in effect after daemon restarts\n", args[0])
+	case resp.GetPersistCleared():
+		printInfo(cmd, "Log level set successfully to %s, the persisted level was removed and the daemon default applies after restarts\n", args[0])
+	default:
+		printInfo(cmd, "Log level set successfully to %s for this session only, it reverts on daemon restart\n", args[0])
+	}
+	return nil
+}
+
+func rotateLog(cmd *cobra.Command, _ []string) error {
+	conn, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			log.Errorf(errCloseConnection, err)
+		}
+	}()
+
+	client := proto.NewDaemonServiceClient(conn)
+	resp, err := client.RotateLog(cmd.Context(), &proto.RotateLogRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to rotate log: %v", status.Convert(err).Message())
+	}
+
+	cmd.Printf("Log rotated, previous log moved to:\n%s\n", resp.GetRotatedPath())
+	return nil
+}
+
+func setLogFormat(cmd *cobra.Command, args []string) error {
+	conn, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			log.Errorf(errCloseConnection, err)
+		}
+	}()
+
+	client := proto.NewDaemonServiceClient(conn)
+	resp, err := client.SetLogFormat(cmd.Context(), &proto.SetLogFormatRequest{Format: args[0]})
+	if err != nil {
+		return fmt.Errorf("failed to set log format: %v", status.Convert(err).Message())
+	}
+
+	printInfo(cmd, "Log format set to %s (was %s) until the daemon restarts\n", args[0], resp.GetPreviousFormat())
+	return nil
+}
+
+func tailLog(cmd *cobra.Command, _ []string) error {
+	request := &proto.TailLogsRequest{Components: logTailComponentFlag}
+	if logTailLevelFlag != "" {
+		level, err := log.ParseLevel(logTailLevelFlag)
+		if err != nil {
+			return fmt.Errorf("invalid log level %q: %v", logTailLevelFlag, err)
+		}
+		request.Level = server.ParseLogLevel(level.String())
+	}
+
+	conn, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			log.Errorf(errCloseConnection, err)
+		}
+	}()
+
+	ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	client := proto.NewDaemonServiceClient(conn)
+	stream, err := client.TailLogs(ctx, request)
+	if err != nil {
+		return fmt.Errorf("failed to tail logs: %v", status.Convert(err).Message())
+	}
+
+	for {
+		line, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("failed to tail logs: %v", status.Convert(err).Message())
+		}
+		if line.GetDropped() > 0 {
+			cmd.PrintErrf("... %d lines dropped, the terminal is too slow\n", line.GetDropped())
+		}
+		cmd.Println(line.GetLine())
+	}
+}
+
+// parseLogLevelArg parses "level", "component=level,..." or "level,component=level,...".
+// logLevelNames are the levels "debug log level" accepts, from the highest severity to the lowest.
+var logLevelNames = []string{"panic", "fatal", "error", "warn", "info", "debug", "trace"}
+
+// completeLogLevel completes the level argument of "debug log level" with the levels and the
+// component=level pairs, also after a comma.
+func completeLogLevel(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var done, current string
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		done, current = toComplete[:i+1], toComplete[i+1:]
+	} else {
+		current = toComplete
+	}
+
+	var candidates []string
+	if name, _, isComponent := strings.Cut(current, "="); isComponent {
+		for _, level := range logLevelNames {
+			candidates = append(candidates, done+name+"="+level)
+		}
+	} else {
+		for _, level := range logLevelNames {
+			candidates = append(candidates, done+level)
+		}
+		components := make([]string, 0, len(util.LogComponents))
+		for name := range util.LogComponents {
+			components = append(components, name)
+		}
+		sort.Strings(components)
+		for _, name := range components {
+			candidates = append(candidates, done+name+"=")
+		}
+	}
+
+	var completions []string
+	noSpace := true
+	for _, c := range candidates {
+		if !strings.HasPrefix(c, toComplete) {
+			continue
+		}
+		completions = append(completions, c)
+		noSpace = noSpace && strings.HasSuffix(c, "=")
+	}
+
+	directive := cobra.ShellCompDirectiveNoFileComp
+	if noSpace && len(completions) > 0 {
+		// a component needs its level typed right after the "="
+		directive |= cobra.ShellCompDirectiveNoSpace
+	}
+	return completions, directive
+}
+
+func parseLogLevelArg(arg string) (*proto.SetLogLevelRequest, error) {
+	request := &proto.SetLogLevelRequest{}
+	for _, part := range strings.Split(arg, ",") {
+		part = strings.TrimSpace(part)
+		name, value, isComponent := strings.Cut(part, "=")
+		if !isComponent {
+			value = part
+		}
+
+		level := server.ParseLogLevel(value)
+		if level == proto.LogLevel_UNKNOWN {
+			//nolint
+			return nil, fmt.Errorf("unknown log level: %s. Available levels are: %s\n", value, strings.Join(logLevelNames, ", "))
+		}
+
+		if !isComponent {
+			if request.Level != proto.LogLevel_UNKNOWN {
+				return nil, fmt.Errorf("more than one base log level in %q", arg)
+			}
+			request.Level = level
+			continue
+		}
+
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			return nil, fmt.Errorf("missing component name in %q", part)
+		}
+		if request.Components == nil {
+			request.Components = make(map[string]proto.LogLevel)
+		}
+		request.Components[name] = level
+	}
+	return request, nil
+}
+
+// printLogLevel prints the current level in the form accepted by setLogLevel.
+func printLogLevel(cmd *cobra.Command, client proto.DaemonServiceClient) error {
+	resp, err := client.GetLogLevel(cmd.Context(), &proto.GetLogLevelRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to get log level: %v", status.Convert(err).Message())
+	}
+
+	level := strings.ToLower(resp.GetLevel().String())
+	if resp.GetIsDefault() {
+		cmd.Printf("%s (daemon default)\n", level)
+		return nil
+	}
+	override := "session override"
+	if resp.GetPersisted() {
+		override = "persisted override"
+	}
+	cmd.Printf("%s (%s, daemon default: %s)\n", level, override, strings.ToLower(resp.GetDefaultLevel().String()))
+
+	components := make([]string, 0, len(resp.GetComponents()))
+	for name, l := range resp.GetComponents() {
+		components = append(components, name+"="+strings.ToLower(l.String()))
+	}
+	sort.Strings(components)
+	for _, c := range components {
+		cmd.Println("  " + c)
+	}
 	return nil
 }
 
 func runForDuration(cmd *cobra.Command, args []string) error {
-	duration, err := time.ParseDuration(args[0])
+	if err := setDebugFlagsFromEnv(cmd); err != nil {
+		return err
+	}
+	schedule, err := parseForSchedule(args, untilSignalFlag, forIntervalFlag)
 	if err != nil {
-		return fmt.Errorf("invalid duration format: %v", err)
+		return err
+	}
+	if err := checkRunCommands(forRunFlag); err != nil {
+		return err
+	}
+
+	encryptionKey, err := readEncryptionKey()
+	if err != nil {
+		return err
+	}
+
+	anonMap, err := readAnonMapRequest()
+	if err != nil {
+		return err
+	}
+	anonPatterns, err := readAnonPatterns()
+	if err != nil {
+		return err
+	}
+	if err := checkAllowSecrets(cmd); err != nil {
+		return err
+	}
+
+	level, err := anonymizeLevel()
+	if err != nil {
+		return err
+	}
+	systemInfo, systemInfoCollectors, err := systemInfoRequest()
+	if err != nil {
+		return err
 	}
 
 	conn, err := getClient(cmd)
@@ -245,19 +1032,72 @@ func runForDuration(cmd *cobra.Command, args []string) error {
 	}
 
 	stateWasDown := stat.Status != string(internal.StatusConnected) && stat.Status != string(internal.StatusConnecting)
+	// a daemon that is down has no connection to disturb, so it is cycled regardless of --no-restart
+	restart := !forNoRestartFlag || stateWasDown
 
 	initialLogLevel, err := client.GetLogLevel(cmd.Context(), &proto.GetLogLevelRequest{})
 	if err != nil {
 		return fmt.Errorf("failed to get log level: %v", status.Convert(err).Message())
 	}
 
+	initialPersistence, err := client.GetSyncResponsePersistence(cmd.Context(), &proto.GetSyncResponsePersistenceRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to get sync response persistence: %v", status.Convert(err).Message())
+	}
+
+	if forDryRunFlag {
+		pattern, err := debug.BundleFilePattern(encryptionKey)
+		if err != nil {
+			return err
+		}
+		wantCapture, _ := cmd.Flags().GetBool("capture")
+		plan := forPlan{
+			status:             stat.Status,
+			stateWasDown:       stateWasDown,
+			restart:            restart,
+			initialLogLevel:    initialLogLevel,
+			initialPersistence: initialPersistence.GetEnabled(),
+			schedule:           schedule,
+			capture:            wantCapture,
+			pcapPeer:           pcapPeerFlag,
+			commands:           forRunFlag,
+			bundlePattern:      pattern,
+		}
+		if uploadBundleFlag {
+			plan.uploadURL = uploadBundleURLFlag
+		}
+		printForPlan(cmd, plan)
+		return nil
+	}
+
+	// the state transitions from here on are marked as the session in events.json
+	sessionStart := time.Now()
+
+	var stagedLogsID string
+	if staged, err := client.StageDebugLogs(cmd.Context(), &proto.StageDebugLogsRequest{}); err != nil {
+		cmd.PrintErrf("Warning: failed to copy the logs at the start of the session, the bundle only has the logs at its end: %v\n", status.Convert(err).Message())
+	} else {
+		stagedLogsID = staged.GetId()
+	}
+
+	// The previous state is restored on every exit path, including a failed bundle and an
+	// interrupt, on a fresh context as the command context may be canceled by then.
 	if stateWasDown {
 		if _, err := client.Up(cmd.Context(), &proto.UpRequest{}); err != nil {
 			cmd.PrintErrf("Failed to bring service up: %v\n", status.Convert(err).Message())
 		} else {
-			cmd.Println("netbird up")
+			printInfo(cmd, "netbird up\n")
 			time.Sleep(time.Second * 10)
 		}
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), forRestoreTimeout)
+			defer cancel()
+			if _, err := client.Down(ctx, &proto.DownRequest{}); err != nil {
+				cmd.PrintErrf("Failed to restore service down state: %v\n", status.Convert(err).Message())
+			} else {
+				printInfo(cmd, "netbird down\n")
+			}
+		}()
 	}
 
 	initialLevelTrace := initialLogLevel.GetLevel() >= proto.LogLevel_TRACE
@@ -268,34 +1108,77 @@ func runForDuration(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return fmt.Errorf("failed to set log level to TRACE: %v", status.Convert(err).Message())
 		}
-		cmd.Println("Log level set to trace.")
+		printInfo(cmd, "Log level set to trace.\n")
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), forRestoreTimeout)
+			defer cancel()
+			if _, err := client.SetLogLevel(ctx, &proto.SetLogLevelRequest{
+				Level:      initialLogLevel.GetLevel(),
+				Components: initialLogLevel.GetComponents(),
+			}); err != nil {
+				cmd.PrintErrf("Failed to restore log level: %v\n", status.Convert(err).Message())
+			} else {
+				printInfo(cmd, "Log level restored to %v\n", initialLogLevel.GetLevel())
+			}
+		}()
 	}
 
 	needsRestoreUp := false
-	if _, err := client.Down(cmd.Context(), &proto.DownRequest{}); err != nil {
-		cmd.PrintErrf("Failed to bring service down: %v\n", status.Convert(err).Message())
+	if restart {
+		if _, err := client.Down(cmd.Context(), &proto.DownRequest{}); err != nil {
+			cmd.PrintErrf("Failed to bring service down: %v\n", status.Convert(err).Message())
+		} else {
+			needsRestoreUp = !stateWasDown
+			printInfo(cmd, "netbird down\n")
+			// only needed while bringing the service up again below failed or was not reached
+			defer func() {
+				if !needsRestoreUp {
+					return
+				}
+				ctx, cancel := context.WithTimeout(context.Background(), forRestoreTimeout)
+				defer cancel()
+				if _, err := client.Up(ctx, &proto.UpRequest{}); err != nil {
+					cmd.PrintErrf("Failed to restore service up state: %v\n", status.Convert(err).Message())
+				} else {
+					printInfo(cmd, "netbird up (restored)\n")
+				}
+			}()
+		}
+
+		time.Sleep(1 * time.Second)
 	} else {
-		needsRestoreUp = !stateWasDown
-		cmd.Println("netbird down")
+		cmd.PrintErrln("Warning: --no-restart keeps the current connection. Sync response persistence only captures network maps " +
+			"received from now on, so the bundle may lack the current network map until management sends an update.")
 	}
 
-	time.Sleep(1 * time.Second)
-
 	// Enable sync response persistence before bringing the service up
 	if _, err := client.SetSyncResponsePersistence(cmd.Context(), &proto.SetSyncResponsePersistenceRequest{
 		Enabled: true,
 	}); err != nil {
 		cmd.PrintErrf("Failed to enable sync response persistence: %v\n", status.Convert(err).Message())
+	} else if !initialPersistence.GetEnabled() {
+		// Restore on every exit path, including failed bundle generation and interrupts.
+		defer func() {
+			restoreCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if _, err := client.SetSyncResponsePersistence(restoreCtx, &proto.SetSyncResponsePersistenceRequest{}); err != nil {
+				cmd.PrintErrf("Failed to restore sync response persistence: %v\n", status.Convert(err).Message())
+			} else {
+				printInfo(cmd, "Sync response persistence restored to off\n")
+			}
+		}()
 	}
 
-	if _, err := client.Up(cmd.Context(), &proto.UpRequest{}); err != nil {
-		cmd.PrintErrf("Failed to bring service up: %v\n", status.Convert(err).Message())
-	} else {
-		needsRestoreUp = false
-		cmd.Println("netbird up")
-	}
+	if restart {
+		if _, err := client.Up(cmd.Context(), &proto.UpRequest{}); err != nil {
+			cmd.PrintErrf("Failed to bring service up: %v\n", status.Convert(err).Message())
+		} else {
+			needsRestoreUp = false
+			printInfo(cmd, "netbird up\n")
+		}
 
-	time.Sleep(3 * time.Second)
+		time.Sleep(3 * time.Second)
+	}
 
 	cpuProfilingStarted := false
 	if _, err := client.StartCPUProfile(cmd.Context(), &proto.StartCPUProfileRequest{}); err != nil {
@@ -312,20 +1195,23 @@ func runForDuration(cmd *cobra.Command, args []string) error {
 	}
 
 	captureStarted := false
-	if wantCapture, _ := cmd.Flags().GetBool("capture"); wantCapture {
-		captureTimeout := duration + 30*time.Second
-		const maxBundleCapture = 10 * time.Minute
-		if captureTimeout > maxBundleCapture {
-			captureTimeout = maxBundleCapture
-		}
-		_, err := client.StartBundleCapture(cmd.Context(), &proto.StartBundleCaptureRequest{
-			Timeout: durationpb.New(captureTimeout),
+	if wantCapture, _ := cmd.Flags().GetBool("capture"); wantCapture || pcapPeerFlag != "" {
+		captureResp, err := client.StartBundleCapture(cmd.Context(), &proto.StartBundleCaptureRequest{
+			Timeout: durationpb.New(schedule.captureTimeout()),
+			Peer:    pcapPeerFlag,
 		})
 		if err != nil {
 			cmd.PrintErrf("Failed to start packet capture: %v\n", status.Convert(err).Message())
 		} else {
 			captureStarted = true
-			cmd.Println("Packet capture started.")
+			if expr := captureResp.GetFilterExpr(); expr != "" {
+				printInfo(cmd, "Packet capture started for peer %s (%s).\n", pcapPeerFlag, expr)
+			} else {
+				printInfo(cmd, "Packet capture started.\n")
+			}
+			if pcapPeerFlag != "" && shouldAnonymize() {
+				cmd.PrintErrln("Warning: packet captures cannot be anonymized. The bundle will contain the raw packets exchanged with this peer.")
+			}
 			// Safety: always stop on exit, even if the normal stop below runs too.
 			defer func() {
 				if captureStarted {
@@ -339,10 +1225,76 @@ func runForDuration(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	if waitErr := waitForDurationOrCancel(cmd.Context(), duration, cmd); waitErr != nil {
-		return waitErr
+	// the counters are taken once the connection is up, so the change covers the session only
+	var interfaceStatsStart *proto.InterfaceStatsSnapshot
+	if resp, err := client.GetInterfaceStats(cmd.Context(), &proto.GetInterfaceStatsRequest{}); err != nil {
+		cmd.PrintErrf("Warning: failed to read the interface counters at the start of the session, the bundle has no change of the counters: %v\n", status.Convert(err).Message())
+	} else {
+		interfaceStatsStart = resp.GetSnapshot()
+	}
+
+	timeline := newResourceTimeline(cmd, client)
+	timeline.tick(cmd.Context())
+
+	newRequest := func() *proto.DebugBundleRequest {
+		request := &proto.DebugBundleRequest{
+			Anonymize:       shouldAnonymize(),
+			AnonymizeLevel:  string(level),
+			SystemInfo:      systemInfo,
+			LogFileCount:    logFileCount,
+			CliVersion:      version.NetbirdVersion(),
+			EncryptionKey:   encryptionKey,
+			MaxSize:         uint64(maxSizeMBFlag) * 1024 * 1024,
+			NetworkMapCount: networkMapCountFlag,
+
+			SystemInfoCollectors: systemInfoCollectors,
+			AnonymizePatterns:    anonPatterns,
+			AllowSecrets:         allowSecretsFlag,
+			InterfaceStatsStart:  interfaceStatsStart,
+			ResourceTimeline:     timeline.Samples(),
+			SessionStart:         timestamppb.New(sessionStart),
+		}
+		applyAnonMapRequest(request, anonMap)
+		if uploadBundleFlag {
+			request.UploadURL = uploadBundleURLFlag
+			setUploadLimits(request)
+		}
+		if schedule.interval > 0 {
+			request.Label = time.Now().UTC().Format(bundleLabelFormat)
+		}
+		return request
+	}
+
+	// Ctrl+C ends the wait only. The bundle is still created and the previous state restored.
+	waitCtx, stopWait := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	waitIntervals := func() {}
+	if schedule.interval > 0 {
+		waitIntervals = collectIntervalBundles(waitCtx, cmd, client, schedule, newRequest)
+	}
+	var runner *commandRunner
+	if len(forRunFlag) > 0 {
+		runner = startCommands(waitCtx, cmd, forRunFlag)
+	}
+	if schedule.untilInterrupt {
+		printInfo(cmd, "Collecting debug information. Press Ctrl+C to stop and create the debug bundle.\n")
+		waitForInterrupt(waitCtx, timeline.tick)
+		printInfo(cmd, "\nInterrupted\n")
+	} else if waitErr := waitForDurationOrCancel(waitCtx, schedule.duration, cmd, timeline.tick); waitErr != nil {
+		printInfo(cmd, "\nInterrupted, creating the debug bundle early\n")
+	} else {
+		printInfo(cmd, "\nDuration completed\n")
+	}
+	stopWait()
+	waitIntervals()
+	timeline.tick(cmd.Context())
+	var commandOutput []byte
+	if runner != nil {
+		commandOutput = runner.Output()
+	}
+
+	if err := cmd.Context().Err(); err != nil {
+		return err
 	}
-	cmd.Println("\nDuration completed")
 
 	if captureStarted {
 		stopCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -351,7 +1303,7 @@ func runForDuration(cmd *cobra.Command, args []string) error {
 			cmd.PrintErrf("Failed to stop packet capture: %v\n", err)
 		} else {
 			captureStarted = false
-			cmd.Println("Packet capture stopped.")
+			printInfo(cmd, "Packet capture stopped.\n")
 		}
 	}
 
@@ -363,57 +1315,159 @@ func runForDuration(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	cmd.Println("Creating debug bundle...")
+	printInfo(cmd, "Creating debug bundle...\n")
 
-	request := &proto.DebugBundleRequest{
-		Anonymize:    anonymizeFlag,
-		SystemInfo:   systemInfoFlag,
-		LogFileCount: logFileCount,
-		CliVersion:   version.NetbirdVersion(),
-	}
-	if uploadBundleFlag {
-		request.UploadURL = uploadBundleURLFlag
-	}
-	resp, err := client.DebugBundle(cmd.Context(), request)
+	// only the final bundle merges the staged logs, the daemon removes them afterwards
+	request := newRequest()
+	request.StagedLogsId = stagedLogsID
+	request.CommandOutput = commandOutput
+	resp, err := requestDebugBundle(cmd, client, request)
 	if err != nil {
 		return fmt.Errorf("failed to bundle debug: %v", status.Convert(err).Message())
 	}
 
-	if needsRestoreUp {
-		if _, err := client.Up(cmd.Context(), &proto.UpRequest{}); err != nil {
-			cmd.PrintErrf("Failed to restore service up state: %v\n", status.Convert(err).Message())
-		} else {
-			cmd.Println("netbird up (restored)")
-		}
+	cmd.Printf("Local file:\n%s\n", resp.GetPath())
+	printTruncatedLogs(cmd, resp.GetTruncatedLogs())
+	if err := saveAnonMapResponse(resp); err != nil {
+		cmd.PrintErrf("Failed to update anonymization map: %v\n", err)
 	}
 
-	if stateWasDown {
-		if _, err := client.Down(cmd.Context(), &proto.DownRequest{}); err != nil {
-			cmd.PrintErrf("Failed to restore service down state: %v\n", status.Convert(err).Message())
-		} else {
-			cmd.Println("netbird down")
-		}
+	if resp.GetUploadFailureReason() != "" {
+		return fmt.Errorf("upload failed: %s", resp.GetUploadFailureReason())
 	}
 
-	if !initialLevelTrace {
-		if _, err := client.SetLogLevel(cmd.Context(), &proto.SetLogLevelRequest{Level: initialLogLevel.GetLevel()}); err != nil {
-			cmd.PrintErrf("Failed to restore log level: %v\n", status.Convert(err).Message())
-		} else {
-			cmd.Println("Log level restored to", initialLogLevel.GetLevel())
-		}
+	if uploadBundleFlag {
+		printUploadedKey(cmd, resp)
 	}
 
-	cmd.Printf("Local file:\n%s\n", resp.GetPath())
+	return nil
+}
 
-	if resp.GetUploadFailureReason() != "" {
-		return fmt.Errorf("upload failed: %s", resp.GetUploadFailureReason())
+// forPlan holds the daemon state read by "debug for" and the flags that decide which steps it takes.
+type forPlan struct {
+	status             string
+	stateWasDown       bool
+	restart            bool
+	initialLogLevel    *proto.GetLogLevelResponse
+	initialPersistence bool
+	schedule           forSchedule
+	capture            bool
+	pcapPeer           string
+	commands           []string
+	// uploadURL is empty when the bundle is not uploaded.
+	uploadURL     string
+	bundlePattern string
+}
+
+// printForPlan prints the steps runForDuration would take against the current daemon state
+// and what it would restore afterwards, without calling any mutating RPC.
+func printForPlan(cmd *cobra.Command, plan forPlan) {
+	cmd.Println("Dry run, no changes are made to the daemon.")
+	cmd.Printf("Current state: %s, log level %s, sync response persistence %s\n",
+		plan.status, strings.ToLower(plan.initialLogLevel.GetLevel().String()), onOff(plan.initialPersistence))
+	cmd.Println()
+	cmd.Println("Steps:")
+	for i, step := range plan.steps() {
+		cmd.Printf("  %d. %s\n", i+1, step)
 	}
 
-	if uploadBundleFlag {
-		cmd.Printf("Upload file key:\n%s\n", resp.GetUploadedKey())
+	cmd.Println()
+	cmd.Println("Restored afterwards:")
+	for _, restore := range plan.restores() {
+		cmd.Println("  - " + restore)
 	}
+}
 
-	return nil
+// levelTrace reports whether the daemon already logs at trace level.
+func (p forPlan) levelTrace() bool {
+	return p.initialLogLevel.GetLevel() >= proto.LogLevel_TRACE
+}
+
+// steps returns the steps runForDuration takes, in order.
+func (p forPlan) steps() []string {
+	steps := []string{"copy the daemon logs aside, to be merged into the final bundle"}
+	if p.stateWasDown {
+		steps = append(steps, "netbird up (service is down), then wait 10s")
+	}
+	if p.levelTrace() {
+		steps = append(steps, "keep log level trace (already set)")
+	} else {
+		steps = append(steps, "set log level to trace")
+	}
+	if p.restart {
+		steps = append(steps, "netbird down, then wait 1s")
+	}
+	if p.initialPersistence {
+		steps = append(steps, "enable sync response persistence (already on)")
+	} else if p.restart {
+		steps = append(steps, "enable sync response persistence")
+	} else {
+		steps = append(steps, "enable sync response persistence without restarting (--no-restart), the current network map is only captured after the next update")
+	}
+	if p.restart {
+		steps = append(steps, "netbird up, then wait 3s")
+	}
+	steps = append(steps, "start CPU profiling")
+	if p.pcapPeer != "" {
+		steps = append(steps, fmt.Sprintf("start packet capture for peer %s", p.pcapPeer))
+	} else if p.capture {
+		steps = append(steps, "start packet capture")
+	}
+	steps = append(steps, "read the interface counters, the bundle adds their change since then")
+	steps = append(steps, "sample the daemon goroutines, open file descriptors and memory every second for resource-timeline.csv")
+	if p.schedule.untilInterrupt {
+		steps = append(steps, "collect until Ctrl+C is pressed")
+	} else {
+		steps = append(steps, fmt.Sprintf("collect for %s", p.schedule.duration))
+	}
+	if p.schedule.interval > 0 {
+		steps = append(steps, fmt.Sprintf("create a debug bundle every %s while collecting, without the CPU profile and packet capture", p.schedule.interval))
+	}
+	for _, command := range p.commands {
+		steps = append(steps, fmt.Sprintf("run %q while collecting, after the commands before it, and record its output", command))
+	}
+	if p.pcapPeer != "" || p.capture {
+		steps = append(steps, "stop packet capture")
+	}
+	steps = append(steps, "stop CPU profiling", fmt.Sprintf("create debug bundle %s in the daemon's temporary directory (* is random)", p.bundlePattern))
+	if p.uploadURL != "" {
+		steps = append(steps, fmt.Sprintf("upload the bundle to %s", p.uploadURL))
+	}
+	return steps
+}
+
+// restores returns the daemon state runForDuration restores afterwards.
+func (p forPlan) restores() []string {
+	var restores []string
+	switch {
+	case p.stateWasDown:
+		restores = append(restores, "netbird down (service was down)")
+	case p.restart:
+		restores = append(restores, "netbird up, only if the up step failed (service was up)")
+	}
+	if !p.levelTrace() {
+		restore := "log level " + strings.ToLower(p.initialLogLevel.GetLevel().String())
+		components := make([]string, 0, len(p.initialLogLevel.GetComponents()))
+		for name, l := range p.initialLogLevel.GetComponents() {
+			components = append(components, name+"="+strings.ToLower(l.String()))
+		}
+		sort.Strings(components)
+		if len(components) > 0 {
+			restore += " with " + strings.Join(components, ",")
+		}
+		restores = append(restores, restore)
+	}
+	if !p.initialPersistence {
+		restores = append(restores, "sync response persistence off")
+	}
+	return restores
+}
+
+func onOff(enabled bool) string {
+	if enabled {
+		return "on"
+	}
+	return "off"
 }
 
 func setSyncResponsePersistence(cmd *cobra.Command, args []string) error {
@@ -427,12 +1481,25 @@ func setSyncResponsePersistence(cmd *cobra.Command, args []string) error {
 		}
 	}()
 
-	persistence := strings.ToLower(args[0])
+	client := proto.NewDaemonServiceClient(conn)
+
+	persistence := "status"
+	if len(args) > 0 {
+		persistence = strings.ToLower(args[0])
+	}
+	if persistence == "status" {
+		resp, err := client.GetSyncResponsePersistence(cmd.Context(), &proto.GetSyncResponsePersistenceRequest{})
+		if err != nil {
+			return fmt.Errorf("failed to get sync response persistence: %v", status.Convert(err).Message())
+		}
+		cmd.Printf("Sync response persistence: %s\n", onOff(resp.GetEnabled()))
+		return nil
+	}
+
 	if persistence != "on" && persistence != "off" {
-		return fmt.Errorf("invalid persistence value: %s. Use 'on' or 'off'", args[0])
+		return fmt.Errorf("invalid persistence value: %s. Use 'on', 'off' or 'status'", args[0])
 	}
 
-	client := proto.NewDaemonServiceClient(conn)
 	_, err = client.SetSyncResponsePersistence(cmd.Context(), &proto.SetSyncResponsePersistenceRequest{
 		Enabled: persistence == "on",
 	})
@@ -440,11 +1507,13 @@ func setSyncResponsePersistence(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to set sync response persistence: %v", status.Convert(err).Message())
 	}
 
-	cmd.Printf("Sync response persistence set to: %s\n", persistence)
+	printInfo(cmd, "Sync response persistence set to: %s\n", persistence)
 	return nil
 }
 
-func waitForDurationOrCancel(ctx context.Context, duration time.Duration, cmd *cobra.Command) error {
+// waitForDurationOrCancel prints the remaining time and calls onTick every second until the
+// duration passed or ctx is done.
+func waitForDurationOrCancel(ctx context.Context, duration time.Duration, cmd *cobra.Command, onTick func(context.Context)) error {
 	ticker := time.NewTicker(1 * time.Second)
 	defer ticker.Stop()
 
@@ -463,7 +1532,8 @@ func waitForDurationOrCancel(ctx context.Context, duration time.Duration, cmd *c
 					return
 				}
 				remaining := duration - elapsed
-				cmd.Printf("\rRemaining time: %s", formatDuration(remaining))
+				printInfo(cmd, "\rRemaining time: %s", formatDuration(remaining))
+				onTick(ctx)
 			}
 		}
 	}()
@@ -476,6 +1546,125 @@ func waitForDurationOrCancel(ctx context.Context, duration time.Duration, cmd *c
 	}
 }
 
+// waitForInterrupt calls onTick every second until ctx is done.
+func waitForInterrupt(ctx context.Context, onTick func(context.Context)) {
+	ticker := time.NewTicker(1 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			onTick(ctx)
+		}
+	}
+}
+
+// bundleLabelFormat is the time format of the creation time in interval bundle file names.
+const bundleLabelFormat = "20060102T150405Z"
+
+// forSchedule is how long "debug for" collects and how often it creates interval bundles.
+type forSchedule struct {
+	duration       time.Duration
+	untilInterrupt bool
+	interval       time.Duration
+}
+
+// parseForSchedule reads the schedule from the "debug for" arguments and the --until-signal
+// and --interval flags.
+func parseForSchedule(args []string, untilSignal bool, interval time.Duration) (forSchedule, error) {
+	schedule := forSchedule{
+		untilInterrupt: untilSignal || (len(args) == 1 && args[0] == untilInterruptArg),
+		interval:       interval,
+	}
+	switch {
+	case schedule.untilInterrupt && len(args) == 1 && args[0] != untilInterruptArg:
+		return forSchedule{}, errors.New("--until-signal cannot be combined with a duration")
+	case !schedule.untilInterrupt && len(args) == 0:
+		return forSchedule{}, fmt.Errorf("missing duration, pass a duration such as 5m or %s", untilInterruptArg)
+	case !schedule.untilInterrupt:
+		var err error
+		if schedule.duration, err = time.ParseDuration(args[0]); err != nil {
+			return forSchedule{}, fmt.Errorf("invalid duration format: %v", err)
+		}
+	}
+	if interval < 0 || (interval > 0 && !schedule.untilInterrupt && interval >= schedule.duration) {
+		return forSchedule{}, errors.New("--interval must be positive and shorter than the duration")
+	}
+	return schedule, nil
+}
+
+// nextInterval returns how long to wait after elapsed for the next interval bundle. Bundles are
+// created on multiples of the interval, a boundary missed while a bundle was created is skipped.
+// It returns false when no interval bundle is left before the final bundle.
+func (f forSchedule) nextInterval(elapsed time.Duration) (time.Duration, bool) {
+	if f.interval <= 0 {
+		return 0, false
+	}
+	next := (elapsed/f.interval + 1) * f.interval
+	if !f.untilInterrupt && next >= f.duration {
+		return 0, false
+	}
+	return next - elapsed, true
+}
+
+// captureTimeout is how long the daemon may capture packets, a bit longer than the schedule
+// and at most 10 minutes.
+func (f forSchedule) captureTimeout() time.Duration {
+	const maxBundleCapture = 10 * time.Minute
+	timeout := f.duration + 30*time.Second
+	if f.untilInterrupt || timeout > maxBundleCapture {
+		return maxBundleCapture
+	}
+	return timeout
+}
+
+// collectIntervalBundles creates a debug bundle on every interval of the schedule until ctx is
+// done. The returned function waits for a bundle still being created. Interval bundles leave the
+// packet capture running for the final bundle, and the daemon only adds the CPU profile once
+// profiling stopped.
+func collectIntervalBundles(ctx context.Context, cmd *cobra.Command, client proto.DaemonServiceClient, schedule forSchedule, newRequest func() *proto.DebugBundleRequest) func() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		start := time.Now()
+		for {
+			wait, ok := schedule.nextInterval(time.Since(start))
+			if !ok {
+				return
+			}
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+
+			request := newRequest()
+			request.ExcludeCapture = true
+			resp, err := client.DebugBundle(cmd.Context(), request)
+			if err != nil {
+				cmd.PrintErrf("\nFailed to create interval debug bundle: %v\n", status.Convert(err).Message())
+				continue
+			}
+			cmd.Printf("\nInterval bundle:\n%s\n", resp.GetPath())
+			if err := saveAnonMapResponse(resp); err != nil {
+				cmd.PrintErrf("Failed to update anonymization map: %v\n", err)
+			}
+			if resp.GetUploadFailureReason() != "" {
+				cmd.PrintErrf("Interval bundle upload failed: %s\n", resp.GetUploadFailureReason())
+			} else if uploadBundleFlag {
+				printUploadedKey(cmd, resp)
+			}
+		}
+	}()
+	return wg.Wait
+}
+
 func formatDuration(d time.Duration) string {
 	d = d.Round(time.Second)
 	h := d / time.Hour
@@ -497,21 +1686,16 @@ func generateDebugBundle(config *profilemanager.Config, recorder *peer.Status, c
 		}
 	}
 
-	bundleGenerator := debug.NewBundleGenerator(
-		debug.GeneratorDependencies{
-			InternalConfig: config,
-			StatusRecorder: recorder,
-			SyncResponse:   syncResponse,
-			LogPath:        logFilePath,
-			CPUProfile:     nil,
-			DaemonVersion:  version.NetbirdVersion(), // acting as daemon
-		},
-		debug.BundleConfig{
-			IncludeSystemInfo: true,
-		},
-	)
+	policy, err := signalBundlePolicy(connectClient)
+	if err != nil {
+		log.Errorf("Not generating a debug bundle from SIGUSR1: %v", err)
+		return
+	}
+
+	settings := signalBundleSettingsFromEnv()
+	log.Infof("Generating debug bundle from SIGUSR1 with %s", settings)
 
-	path, err := bundleGenerator.Generate()
+	path, err := newSignalBundleGenerator(config, recorder, syncResponse, policy, logFilePath, settings).Generate()
 	if err != nil {
 		log.Errorf("Failed to generate debug bundle: %v", err)
 		return
@@ -520,14 +1704,82 @@ func generateDebugBundle(config *profilemanager.Config, recorder *peer.Status, c
 }
 
 func init() {
+	debugCmd.PersistentFlags().DurationVar(&connectRetry, connectRetryFlag, defaultConnectRetry, "Time to wait for a daemon that is still starting before connecting. Set to 0 to fail at once")
+	debugCmd.PersistentFlags().BoolVarP(&debugQuietFlag, "quiet", "q", false, "Suppresses informational output like progress and confirmations. Errors, warnings and results such as the bundle path and upload file key are still printed")
 	debugBundleCmd.Flags().Uint32VarP(&logFileCount, "log-file-count", "C", 1, "Number of rotated log files to include in debug bundle")
-	debugBundleCmd.Flags().BoolVarP(&systemInfoFlag, "system-info", "S", true, "Adds system information to the debug bundle")
+	addSystemInfoFlag(debugBundleCmd)
+	debugBundleCmd.Flags().BoolVar(&listSystemInfoFlag, "list-system-info", false, "Prints the system information collectors --system-info accepts and exits")
 	debugBundleCmd.Flags().BoolVarP(&uploadBundleFlag, "upload-bundle", "U", false, "Uploads the debug bundle to a server")
-	debugBundleCmd.Flags().StringVar(&uploadBundleURLFlag, "upload-bundle-url", types.DefaultBundleURL, "Service URL to get an URL to upload the debug bundle")
+	debugBundleCmd.Flags().BoolVar(&noURLCheckFlag, "no-url-check", false, "Skips checking that the upload server answers before the bundle is generated")
+	debugBundleCmd.Flags().BoolVar(&uploadLastFlag, "upload-last", false, "Uploads the bundle the daemon generated last to --upload-bundle-url instead of generating a new one")
+	debugBundleCmd.Flags().BoolVar(&bundleJSONFlag, "json", false, "Prints the result as a single JSON object instead of text. Exits with 2 if no bundle was created and 3 if the upload failed")
+	debugBundleCmd.Flags().StringVar(&uploadBundleURLFlag, "upload-bundle-url", types.DefaultBundleURL, "Service URL to get an URL to upload the debug bundle, or an s3://bucket/prefix URL to upload directly using the daemon's AWS credentials (requires running as root)")
+	debugBundleCmd.Flags().DurationVar(&uploadTimeoutFlag, "upload-timeout", debug.DefaultUploadTimeout, "Timeout of each upload attempt, including the upload URL request")
+	debugBundleCmd.Flags().Uint32Var(&uploadRetriesFlag, "upload-retries", debug.DefaultUploadRetries, "Number of upload retries after a failed attempt, with exponential backoff")
+	debugBundleCmd.Flags().BoolVar(&anonymizePreviewFlag, "anonymize-preview", false, "Create an anonymized bundle without uploading it and show how many values were redacted, with sample mappings")
+	debugBundleCmd.Flags().StringVar(&statusFormatFlag, "status-format", string(debug.StatusFormatText), "Status files to include in the debug bundle: text (status.txt), json (status.json) or both")
+	debugBundleCmd.Flags().StringVarP(&bundleOutputFlag, "output", "o", "", "Set to - to stream the debug bundle to stdout instead of keeping it on disk")
+	debugBundleCmd.Flags().StringVar(&outputDirFlag, "output-dir", "", "Writes the debug bundle to this existing directory instead of the daemon's temporary directory. The daemon writes the file, so it must be able to write there. Requires running as root")
+	debugBundleCmd.Flags().StringVar(&encryptKeyFlag, "encrypt-key", "", "Encrypts the debug bundle to the age recipient or OpenPGP public key in this file")
+	debugBundleCmd.Flags().Uint32Var(&maxSizeMBFlag, "max-size", 0, "Caps the log content of the debug bundle to this many MB, keeping the newest lines (0 means no limit)")
+	debugBundleCmd.Flags().StringVar(&splitSizeFlag, "split-size", "", "Splits the debug bundle into numbered parts of at most this size, e.g. 24MB, for mail or ticket attachments. A -parts.txt note next to the parts explains how to reassemble them")
+	debugBundleCmd.Flags().Uint32Var(&networkMapCountFlag, "network-map-count", 1, "Number of stored network maps to include, newest first. Older maps need sync response persistence")
+	debugBundleCmd.Flags().BoolVar(&profilesFlag, "profiles", false, "Adds a goroutine dump, a heap profile and a CPU profile of the daemon under profiles/")
+	debugBundleCmd.Flags().DurationVar(&profileCPUFlag, "profile-cpu-duration", debug.DefaultCPUProfileDuration, "Length of the CPU profile taken with --profiles")
+	debugBundleCmd.Flags().DurationVar(&sinceFlag, "since", 0, "Only include log lines written within this duration, e.g. 15m. Takes precedence over --log-file-count")
+	debugBundleCmd.Flags().BoolVar(&noLogsFlag, "no-logs", false, "Leaves out all logs, for a small bundle of the config, status and network map only. The log selection flags are ignored")
+	debugBundleCmd.Flags().BoolVar(&incrementalFlag, "incremental", false, "Only include the log content written since the previous bundle created with --incremental or --full, marked at the start of each log. The first one includes the whole logs")
+	debugBundleCmd.Flags().BoolVar(&fullLogsFlag, "full", false, "Include the whole logs and start a new series of --incremental bundles from this bundle")
+	debugBundleCmd.Flags().Uint32Var(&tailFlag, "tail", 0, "Only include the last this many lines of each log file, counted after --since and the log patterns (0 keeps whole files)")
+	debugBundleCmd.Flags().StringArrayVar(&bundlePeersFlag, "peer", nil, "Limits the peers in the bundle status to this peer (FQDN prefix or NetBird IP). Can be repeated")
+	debugBundleCmd.Flags().StringVar(&bundleGroupFlag, "group", "", "Limits the peers in the bundle status to the members of the group with this ID, as listed by \"netbird status --detail\"")
+	debugBundleCmd.Flags().BoolVar(&traceInfraFlag, "trace-infra", false, fmt.Sprintf("Runs a traceroute to the management, signal and connected relay servers and adds it as traceroute.txt. Uses the system traceroute (tracert on Windows), ICMP first and UDP as fallback, at most %d hops and %s per server", tracerouteMaxHops, tracerouteTimeout))
+	debugBundleCmd.Flags().StringVar(&bundleDomainFlag, "domain", "", "Scopes the DNS state, resolved domains and status of the bundle to this match domain or domain route, e.g. example.internal")
+	debugBundleCmd.Flags().StringVar(&bundleCompressFlag, "compress", string(debug.CompressionGzip), "Compression of the bundle files: none, gzip or zstd. zstd bundles are named *.zst.zip and need 7-Zip, bsdtar or a recent unzip")
+	debugBundleCmd.Flags().StringArrayVar(&bundleIncludeFlag, "include", nil, "Adds files matching this absolute path or glob under extra/ in the bundle. The files are not anonymized. Requires running as root. Can be repeated")
+	debugBundleCmd.Flags().StringArrayVar(&logIncludeFlag, "log-include", nil, "Keeps only the log lines matching this regular expression. Can be repeated to keep lines matching any of them")
+	debugBundleCmd.Flags().StringArrayVar(&logExcludeFlag, "log-exclude", nil, "Drops the log lines matching this regular expression before archiving, e.g. internal project names. Can be repeated")
+	debugBundleCmd.Flags().BoolVar(&allowSecretsFlag, allowSecretsFlagName, false, "Keeps the private, pre-shared and SSH keys and other credentials in the bundle instead of masking them. Only for debugging on this machine, requires running as root and cannot be combined with --upload-bundle")
+	debugBundleCmd.Flags().StringArrayVar(&aclQueryFlag, "acl-query", nil, "Evaluates the ACL policy for this flow, \"<source> <destination> <protocol> [port]\" like \"netbird debug acl\", and adds the result to acl_queries.txt. Can be repeated")
+	debugBundleCmd.Flags().BoolVar(&peerMTUProbeFlag, "peer-mtu-probe", false, "Probes the path MTU of all connected peers and adds the results to the debug bundle")
+	debugBundleCmd.Flags().BoolVar(&bundleOfflineFlag, "offline", false, "Creates the debug bundle in the CLI without the daemon, e.g. when it crashed or does not start. Reads the daemon logs and the active profile config from disk, the live status and network map are not included")
 
 	forCmd.Flags().Uint32VarP(&logFileCount, "log-file-count", "C", 1, "Number of rotated log files to include in debug bundle")
-	forCmd.Flags().BoolVarP(&systemInfoFlag, "system-info", "S", true, "Adds system information to the debug bundle")
+	addSystemInfoFlag(forCmd)
 	forCmd.Flags().BoolVarP(&uploadBundleFlag, "upload-bundle", "U", false, "Uploads the debug bundle to a server")
-	forCmd.Flags().StringVar(&uploadBundleURLFlag, "upload-bundle-url", types.DefaultBundleURL, "Service URL to get an URL to upload the debug bundle")
+	forCmd.Flags().StringVar(&uploadBundleURLFlag, "upload-bundle-url", types.DefaultBundleURL, "Service URL to get an URL to upload the debug bundle, or an s3://bucket/prefix URL to upload directly using the daemon's AWS credentials (requires running as root)")
+	forCmd.Flags().BoolVar(&noURLCheckFlag, "no-url-check", false, "Skips checking that the upload server answers before the bundle is generated")
+	forCmd.Flags().DurationVar(&uploadTimeoutFlag, "upload-timeout", debug.DefaultUploadTimeout, "Timeout of each upload attempt, including the upload URL request")
+	forCmd.Flags().Uint32Var(&uploadRetriesFlag, "upload-retries", debug.DefaultUploadRetries, "Number of upload retries after a failed attempt, with exponential backoff")
+	forCmd.Flags().BoolVar(&untilSignalFlag, "until-signal", false, "Run until interrupted with Ctrl+C instead of for a fixed duration")
+	forCmd.Flags().StringVar(&encryptKeyFlag, "encrypt-key", "", "Encrypts the debug bundle to the age recipient or OpenPGP public key in this file")
+	forCmd.Flags().Uint32Var(&maxSizeMBFlag, "max-size", 0, "Caps the log content of the debug bundle to this many MB, keeping the newest lines (0 means no limit)")
+	forCmd.Flags().DurationVar(&forIntervalFlag, "interval", 0, "Also create a debug bundle at this interval during the debug duration. Bundle file names then include their creation time")
+	forCmd.Flags().BoolVar(&forDryRunFlag, "dry-run", false, "Print the steps and restored values against the current daemon state without changing anything")
+	forCmd.Flags().BoolVar(&forNoRestartFlag, "no-restart", false, "Enables trace logging and sync response persistence without the netbird down and up cycle when the service is up. The bundle may lack the current network map until the next update")
+	forCmd.Flags().BoolVar(&allowSecretsFlag, allowSecretsFlagName, false, "Keeps the private, pre-shared and SSH keys and other credentials in the bundle instead of masking them. Only for debugging on this machine, requires running as root and cannot be combined with --upload-bundle")
+	forCmd.Flags().Uint32Var(&networkMapCountFlag, "network-map-count", 1, "Number of network maps received during the debug duration to include, newest first")
 	forCmd.Flags().Bool("capture", false, "Capture packets during the debug duration and include in bundle")
+	forCmd.Flags().StringArrayVar(&forRunFlag, "run", nil, "Runs this shell command during the debug duration and adds its output, exit code and timing to command-output.txt in the bundle. Can be repeated to run commands one after another")
+	forCmd.Flags().StringVar(&pcapPeerFlag, "pcap", "", "Capture only the packets of this peer (FQDN, hostname or tunnel IP) during the debug duration and include them in the bundle, even if anonymized")
+	logTailCmd.Flags().StringVar(&logTailLevelFlag, "level", "", "Drops lines more verbose than this level (panic, fatal, error, warn, info, debug, trace)")
+	logTailCmd.Flags().StringArrayVar(&logTailComponentFlag, "component", nil, "Limits the lines to this component (ice, relay, grpc, peer, dns, route, firewall, engine). Can be repeated")
+	logLevelCmd.Flags().BoolVar(&logLevelPersistFlag, "persist", false, "Also writes the level into the active profile config so it survives daemon restarts")
+	logLevelCmd.Flags().StringVar(&profileName, profileNameFlag, "", "Sets the level of this profile (name or ID). An inactive profile gets the level persisted into its config")
+	debugConfigDumpCmd.Flags().BoolVar(&showSecretsFlag, "show-secrets", false, "Prints the private, pre-shared and SSH keys instead of redacting them. Requires running as root. Do not share the output")
+}
+
+// checkAllowSecrets rejects --allow-secrets for bundles leaving the machine and warns otherwise.
+func checkAllowSecrets(cmd *cobra.Command) error {
+	if !allowSecretsFlag {
+		return nil
+	}
+	if uploadBundleFlag {
+		return fmt.Errorf("--%s cannot be used with --upload-bundle", allowSecretsFlagName)
+	}
+	if bundleOutputFlag == "-" {
+		return fmt.Errorf("--%s cannot be used with --output -", allowSecretsFlagName)
+	}
+	cmd.PrintErrln("Warning: the debug bundle contains the private keys and credentials of this peer. Do not share it")
+	return nil
 }
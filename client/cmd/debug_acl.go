@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
+
+	"github.com/netbirdio/netbird/client/internal/debug"
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+var aclQueryFlag []string
+
+var debugACLCmd = &cobra.Command{
+	Use:   "acl <source> <destination> <protocol> [port]",
+	Short: "Evaluate the ACL policy for a flow",
+	Long: "Asks the daemon whether its current ACL policy allows a flow and which rule decides it, without changing the firewall. " +
+		"Source and destination are IP addresses, self is the local overlay address. The protocol is tcp, udp, icmp or all, tcp and udp need a destination port. " +
+		"Flows to this peer are checked against its inbound rules, flows from it against the outbound rules the destination peer enforces, " +
+		"and flows between other addresses against the rules of the networks this peer routes. " +
+		"The same flows can be added to a debug bundle with --acl-query.",
+	Example: "  netbird debug acl 100.64.0.2 self tcp 443\n  netbird debug acl 100.64.0.2 10.0.0.10 udp 53",
+	Args:    cobra.RangeArgs(3, 4),
+	RunE:    debugACL,
+}
+
+func init() {
+	debugCmd.AddCommand(debugACLCmd)
+}
+
+func debugACL(cmd *cobra.Command, args []string) error {
+	query, err := parseACLQuery(args)
+	if err != nil {
+		return err
+	}
+
+	conn, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			log.Errorf(errCloseConnection, err)
+		}
+	}()
+
+	client := proto.NewDaemonServiceClient(conn)
+	resp, err := client.EvaluateACL(cmd.Context(), &proto.EvaluateACLRequest{Query: query})
+	if err != nil {
+		return fmt.Errorf("failed to evaluate ACL: %v", status.Convert(err).Message())
+	}
+
+	cmd.Print(debug.FormatACLQuery(debug.ACLQuery{
+		Source:      query.GetSource(),
+		Destination: query.GetDestination(),
+		Protocol:    query.GetProtocol(),
+		Port:        query.GetPort(),
+		Allowed:     resp.GetAllowed(),
+		Rule:        resp.GetRule(),
+		Reason:      resp.GetReason(),
+	}))
+	return nil
+}
+
+// parseACLQuery parses <source> <destination> <protocol> [port] of "debug acl" and --acl-query.
+func parseACLQuery(args []string) (*proto.ACLQuery, error) {
+	if len(args) < 3 || len(args) > 4 {
+		return nil, fmt.Errorf("expected <source> <destination> <protocol> [port], got %q", strings.Join(args, " "))
+	}
+
+	query := &proto.ACLQuery{
+		Source:      args[0],
+		Destination: args[1],
+		Protocol:    strings.ToLower(args[2]),
+	}
+	switch query.Protocol {
+	case "tcp", "udp":
+		if len(args) != 4 {
+			return nil, fmt.Errorf("protocol %s needs a destination port", query.Protocol)
+		}
+		port, err := strconv.ParseUint(args[3], 10, 16)
+		if err != nil || port == 0 {
+			return nil, fmt.Errorf("invalid port %q", args[3])
+		}
+		query.Port = uint32(port)
+	case "icmp", "all":
+		if len(args) == 4 {
+			return nil, fmt.Errorf("protocol %s has no ports", query.Protocol)
+		}
+	default:
+		return nil, fmt.Errorf("invalid protocol %q, use tcp, udp, icmp or all", args[2])
+	}
+	return query, nil
+}
+
+// aclQueriesRequest parses the --acl-query flags of a bundle request.
+func aclQueriesRequest() ([]*proto.ACLQuery, error) {
+	var queries []*proto.ACLQuery
+	for _, flag := range aclQueryFlag {
+		query, err := parseACLQuery(strings.Fields(flag))
+		if err != nil {
+			return nil, fmt.Errorf("--acl-query: %w", err)
+		}
+		queries = append(queries, query)
+	}
+	return queries, nil
+}
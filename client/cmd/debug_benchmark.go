@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/netbirdio/netbird/client/internal/benchmark"
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+var debugBenchmarkDuration time.Duration
+
+var debugBenchmarkCmd = &cobra.Command{
+	Use:   "benchmark <peer>",
+	Short: "Measure the throughput to a peer through the tunnel",
+	Long: "Makes the daemon open the connection to the peer if it is not up, then measures the round trip time and loss with " +
+		fmt.Sprintf("%d UDP echo packets and the throughput with TCP transfers to the peer and back, half of --duration each way. ", benchmark.EchoCount) +
+		fmt.Sprintf("Each direction is limited to %d MiB. ", benchmark.MaxBytes>>20) +
+		"Reports whether the connection is direct or relayed, and whether that changed during the run.\n\n" +
+		fmt.Sprintf("The peer answers with the benchmark responder of its daemon on port %d of its overlay address, ", benchmark.Port) +
+		"which accepts connections from all peers and serves one transfer at a time. Peers running an older version, " +
+		"blocking inbound connections or running in netstack mode have no responder and are refused. " +
+		"The peer is given by FQDN, hostname label or overlay IP.",
+	Example: "  netbird debug benchmark peer-a.netbird.cloud\n  netbird debug benchmark 100.64.0.10 --duration 20s",
+	Args:    cobra.ExactArgs(1),
+	RunE:    debugBenchmark,
+}
+
+func init() {
+	debugBenchmarkCmd.Flags().DurationVar(&debugBenchmarkDuration, "duration", benchmark.DefaultDuration, fmt.Sprintf("Time of the transfers, half of it each direction, at most %s", benchmark.MaxDuration))
+	debugCmd.AddCommand(debugBenchmarkCmd)
+}
+
+func debugBenchmark(cmd *cobra.Command, args []string) error {
+	if debugBenchmarkDuration <= 0 || debugBenchmarkDuration > benchmark.MaxDuration {
+		return fmt.Errorf("--duration must be positive and at most %s", benchmark.MaxDuration)
+	}
+
+	conn, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			log.Errorf(errCloseConnection, err)
+		}
+	}()
+
+	cmd.Printf("Benchmarking %s for %s...\n", args[0], debugBenchmarkDuration)
+	client := proto.NewDaemonServiceClient(conn)
+	resp, err := client.BenchmarkPeer(cmd.Context(), &proto.BenchmarkPeerRequest{
+		Peer:     args[0],
+		Duration: durationpb.New(debugBenchmarkDuration),
+	})
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.NotFound:
+		return fmt.Errorf("peer not found: %v", status.Convert(err).Message())
+	default:
+		return fmt.Errorf("failed to benchmark peer: %v", status.Convert(err).Message())
+	}
+
+	cmd.Print(formatBenchmark(resp))
+	return nil
+}
+
+// formatBenchmark renders a benchmark result for the terminal.
+func formatBenchmark(resp *proto.BenchmarkPeerResponse) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Peer: %s (%s)\n", resp.GetPeer(), resp.GetIp()))
+
+	path := "direct"
+	if resp.GetRelayed() {
+		path = "relay"
+		if resp.GetRelayAddress() != "" {
+			path += " " + resp.GetRelayAddress()
+		}
+	}
+	sb.WriteString(fmt.Sprintf("Path: %s\n", path))
+	if resp.GetPathChanged() {
+		sb.WriteString("Note: the connection switched between direct and relayed during the run, the results mix both paths\n")
+	}
+
+	writeTransfer := func(name string, bytes uint64, duration *durationpb.Duration) {
+		t := benchmark.Transfer{Bytes: int64(bytes), Duration: duration.AsDuration()}
+		line := fmt.Sprintf("%s: %.1f Mbps (%.1f MiB in %s)", name, t.Mbps(), float64(t.Bytes)/(1<<20), t.Duration.Round(time.Millisecond))
+		if resp.GetMaxBytes() > 0 && bytes >= resp.GetMaxBytes() {
+			line += ", stopped at the data limit"
+		}
+		sb.WriteString(line + "\n")
+	}
+	writeTransfer("Upload", resp.GetUploadBytes(), resp.GetUploadDuration())
+	writeTransfer("Download", resp.GetDownloadBytes(), resp.GetDownloadDuration())
+
+	sent := len(resp.GetRtts()) + int(resp.GetEchoLost())
+	if sent > 0 {
+		sb.WriteString(fmt.Sprintf("Loss: %d/%d echo packets (%.0f%%)\n", resp.GetEchoLost(), sent, float64(resp.GetEchoLost())*100/float64(sent)))
+	}
+	if rtts := resp.GetRtts(); len(rtts) > 0 {
+		lowest, highest, total := rtts[0].AsDuration(), rtts[0].AsDuration(), time.Duration(0)
+		for _, rtt := range rtts {
+			lowest = min(lowest, rtt.AsDuration())
+			highest = max(highest, rtt.AsDuration())
+			total += rtt.AsDuration()
+		}
+		avg := total / time.Duration(len(rtts))
+		sb.WriteString(fmt.Sprintf("RTT min/avg/max: %s/%s/%s\n", lowest.Round(time.Microsecond), avg.Round(time.Microsecond), highest.Round(time.Microsecond)))
+	} else {
+		sb.WriteString("RTT: no echo packet was answered\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/netbirdio/netbird/client/internal/benchmark"
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+func TestFormatBenchmark(t *testing.T) {
+	resp := &proto.BenchmarkPeerResponse{
+		Peer:             "peer-a.netbird.cloud",
+		Ip:               "100.64.0.10",
+		Relayed:          true,
+		RelayAddress:     "rels://relay.netbird.io:443",
+		PathChanged:      true,
+		UploadBytes:      12_500_000,
+		UploadDuration:   durationpb.New(time.Second),
+		DownloadBytes:    benchmark.MaxBytes,
+		DownloadDuration: durationpb.New(2 * time.Second),
+		Rtts:             []*durationpb.Duration{durationpb.New(10 * time.Millisecond), durationpb.New(30 * time.Millisecond)},
+		EchoLost:         2,
+		MaxBytes:         benchmark.MaxBytes,
+	}
+
+	out := formatBenchmark(resp)
+	assert.Contains(t, out, "Peer: peer-a.netbird.cloud (100.64.0.10)\n")
+	assert.Contains(t, out, "Path: relay rels://relay.netbird.io:443\n")
+	assert.Contains(t, out, "Note: the connection switched between direct and relayed")
+	assert.Contains(t, out, "Upload: 100.0 Mbps (11.9 MiB in 1s)\n")
+	assert.Contains(t, out, "Download: 1073.7 Mbps (256.0 MiB in 2s), stopped at the data limit\n")
+	assert.Contains(t, out, "Loss: 2/4 echo packets (50%)\n")
+	assert.Contains(t, out, "RTT min/avg/max: 10ms/20ms/30ms\n")
+
+	out = formatBenchmark(&proto.BenchmarkPeerResponse{EchoLost: benchmark.EchoCount, MaxBytes: benchmark.MaxBytes})
+	assert.Contains(t, out, "Path: direct\n")
+	assert.Contains(t, out, "RTT: no echo packet was answered\n")
+	assert.NotContains(t, out, "Note:")
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/netbirdio/netbird/client/internal/debug"
+)
+
+var debugDiffCmd = &cobra.Command{
+	Use:     "diff <bundleA.zip> <bundleB.zip>",
+	Example: "  netbird debug diff netbird.debug.before.zip netbird.debug.after.zip",
+	Short:   "Compare the status and config of two debug bundles",
+	Long: "Compares the status.json and config.json of two debug bundles and lists what changed from the first to the second: " +
+		"peers that were added, removed or changed state, networks that were added or removed and changed config fields. " +
+		"Falls back to a text diff of the status summary of status.txt and of config.txt for bundles without the JSON files. " +
+		"Runs locally without the daemon. Encrypted bundles must be decrypted first.",
+	Args: cobra.ExactArgs(2),
+	RunE: debugDiff,
+}
+
+func init() {
+	debugCmd.AddCommand(debugDiffCmd)
+}
+
+func debugDiff(cmd *cobra.Command, args []string) error {
+	diff, err := debug.DiffBundles(args[0], args[1])
+	if err != nil {
+		return fmt.Errorf("failed to compare bundles: %w", err)
+	}
+	cmd.Print(debug.FormatBundleDiff(diff))
+	return nil
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// envDebugPrefix prefixes the environment variables that set debug bundle flags, e.g.
+// NETBIRD_DEBUG_UPLOAD_BUNDLE=true for --upload-bundle.
+const envDebugPrefix = "NETBIRD_DEBUG_"
+
+// debugEnvFlags are the flags of "debug bundle" and "debug for" that can be set from the
+// environment, for container entrypoints that cannot pass flags easily.
+var debugEnvFlags = []string{"anonymize", "system-info", "log-file-count", "upload-bundle", "upload-bundle-url"}
+
+// debugEnvHelp documents the environment variables in the long help of the bundle commands.
+const debugEnvHelp = "The flags --anonymize, --system-info, --log-file-count, --upload-bundle and --upload-bundle-url can also be set " +
+	"with NETBIRD_DEBUG_ environment variables named after the flag, e.g. NETBIRD_DEBUG_UPLOAD_BUNDLE=true or NETBIRD_DEBUG_LOG_FILE_COUNT=3. " +
+	"A flag given on the command line takes precedence over its variable, and the variable over the flag default. " +
+	"NB_ANONYMIZE, which applies to all commands, counts as given on the command line."
+
+// setDebugFlagsFromEnv sets the debug bundle flags of cmd that were not given on the command line
+// from their NETBIRD_DEBUG_ environment variables. An invalid value is an error like an invalid
+// flag value.
+func setDebugFlagsFromEnv(cmd *cobra.Command) error {
+	for _, name := range debugEnvFlags {
+		f := cmd.Flag(name)
+		if f == nil || f.Changed {
+			continue
+		}
+		envVar := FlagNameToEnvVar(name, envDebugPrefix)
+		value, present := os.LookupEnv(envVar)
+		if !present {
+			continue
+		}
+		if err := cmd.Flags().Set(name, value); err != nil {
+			return fmt.Errorf("invalid %s %q: %w", envVar, value, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDebugEnvTestCmd(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "bundle"}
+	cmd.Flags().Bool("anonymize", false, "")
+	cmd.Flags().String("system-info", "all", "")
+	cmd.Flags().Uint32("log-file-count", 1, "")
+	cmd.Flags().Bool("upload-bundle", false, "")
+	cmd.Flags().String("upload-bundle-url", "https://example.com", "")
+	require.NoError(t, cmd.ParseFlags(args))
+	return cmd
+}
+
+func TestSetDebugFlagsFromEnv(t *testing.T) {
+	t.Setenv("NETBIRD_DEBUG_ANONYMIZE", "true")
+	t.Setenv("NETBIRD_DEBUG_SYSTEM_INFO", "os,cpu")
+	t.Setenv("NETBIRD_DEBUG_LOG_FILE_COUNT", "3")
+	t.Setenv("NETBIRD_DEBUG_UPLOAD_BUNDLE", "true")
+	t.Setenv("NETBIRD_DEBUG_UPLOAD_BUNDLE_URL", "https://upload.example.com")
+
+	cmd := newDebugEnvTestCmd(t, "--log-file-count", "5", "--upload-bundle=false")
+	require.NoError(t, setDebugFlagsFromEnv(cmd))
+
+	anonymize, _ := cmd.Flags().GetBool("anonymize")
+	systemInfo, _ := cmd.Flags().GetString("system-info")
+	count, _ := cmd.Flags().GetUint32("log-file-count")
+	upload, _ := cmd.Flags().GetBool("upload-bundle")
+	url, _ := cmd.Flags().GetString("upload-bundle-url")
+	assert.True(t, anonymize)
+	assert.Equal(t, "os,cpu", systemInfo)
+	assert.Equal(t, uint32(5), count, "the command line takes precedence")
+	assert.False(t, upload, "the command line takes precedence")
+	assert.Equal(t, "https://upload.example.com", url)
+}
+
+func TestSetDebugFlagsFromEnvInvalid(t *testing.T) {
+	t.Setenv("NETBIRD_DEBUG_LOG_FILE_COUNT", "many")
+
+	err := setDebugFlagsFromEnv(newDebugEnvTestCmd(t))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "NETBIRD_DEBUG_LOG_FILE_COUNT")
+
+	// an invalid variable of a flag given on the command line is not read
+	assert.NoError(t, setDebugFlagsFromEnv(newDebugEnvTestCmd(t, "--log-file-count=2")))
+}
@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
+
+	"github.com/netbirdio/netbird/client/internal"
+	"github.com/netbirdio/netbird/client/internal/debug"
+	"github.com/netbirdio/netbird/client/internal/peer"
+	"github.com/netbirdio/netbird/client/proto"
+	nbstatus "github.com/netbirdio/netbird/client/status"
+	"github.com/netbirdio/netbird/version"
+)
+
+var debugInfoCmd = &cobra.Command{
+	Use:     "info",
+	Example: "  netbird debug info",
+	Short:   "Show a one-screen health summary of the daemon",
+	Long: "Summarizes the daemon version and build, the enabled and experimental feature flags, log level, connection status, peers including stale peers without a recent WireGuard handshake, relays, the most recent posture check results, sync response persistence, host routes conflicting with NetBird routes, the management and signal connections and the clock offset to the management server without creating a debug bundle. " +
+		"Only reads state. Exits with an error when the daemon is not connected or the management or signal connection is down, so it can be used in health checks. A clock offset above 30 seconds and conflicting routes print a warning but do not fail.",
+	Args: cobra.NoArgs,
+	RunE: debugInfo,
+}
+
+func init() {
+	debugInfoCmd.Flags().DurationVar(&staleThresholdFlag, "stale-threshold", nbstatus.DefaultStaleHandshakeThreshold, "Counts connected peers without a WireGuard handshake for longer than this as stale")
+	debugCmd.AddCommand(debugInfoCmd)
+}
+
+func debugInfo(cmd *cobra.Command, _ []string) error {
+	conn, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			log.Errorf(errCloseConnection, err)
+		}
+	}()
+
+	client := proto.NewDaemonServiceClient(conn)
+	stat, err := client.Status(cmd.Context(), &proto.StatusRequest{GetFullPeerStatus: true})
+	if err != nil {
+		return fmt.Errorf("failed to get status: %v", status.Convert(err).Message())
+	}
+
+	logLevel, err := client.GetLogLevel(cmd.Context(), &proto.GetLogLevelRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to get log level: %v", status.Convert(err).Message())
+	}
+
+	persistence, err := client.GetSyncResponsePersistence(cmd.Context(), &proto.GetSyncResponsePersistenceRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to get sync response persistence: %v", status.Convert(err).Message())
+	}
+
+	build, err := client.GetBuildInfo(cmd.Context(), &proto.GetBuildInfoRequest{})
+	if err != nil {
+		log.Debugf("failed to get build info: %v", status.Convert(err).Message())
+	}
+
+	routeConflicts, err := client.GetRouteConflicts(cmd.Context(), &proto.GetRouteConflictsRequest{})
+	if err != nil {
+		log.Debugf("failed to get route conflicts: %v", status.Convert(err).Message())
+	}
+
+	full := stat.GetFullStatus()
+	level := strings.ToLower(logLevel.GetLevel().String())
+	if logLevel.GetIsDefault() {
+		level += " (daemon default)"
+	} else {
+		level += " (session override)"
+	}
+
+	cmd.Printf("Daemon version:  %s\n", stat.GetDaemonVersion())
+	cmd.Printf("Daemon build:    %s\n", buildSummary(build))
+	cmd.Printf("CLI version:     %s\n", version.NetbirdVersion())
+	if build != nil {
+		enabled, experimental := debug.EnabledFeatures(featureFlagsFromProto(build.GetFlags()))
+		cmd.Printf("Features:        %s\n", featureList(enabled))
+		cmd.Printf("Experimental:    %s\n", featureList(experimental))
+	}
+	cmd.Printf("Status:          %s\n", stat.GetStatus())
+	cmd.Printf("Log level:       %s\n", level)
+	cmd.Printf("Persistence:     %s\n", onOff(persistence.GetEnabled()))
+	cmd.Printf("Management:      %s\n", connectionSummary(full.GetManagementState().GetConnected(), full.GetManagementState().GetURL(), full.GetManagementState().GetError()))
+	cmd.Printf("Signal:          %s\n", connectionSummary(full.GetSignalState().GetConnected(), full.GetSignalState().GetURL(), full.GetSignalState().GetError()))
+	cmd.Printf("Relays:          %s\n", relaySummary(full.GetRelays()))
+	cmd.Printf("Peers:           %s\n", peerSummary(full.GetPeers(), staleThresholdFlag))
+	cmd.Printf("Posture checks:  %s\n", debug.PostureSummary(postureFromProto(full.GetPosture())))
+	cmd.Printf("Route conflicts: %s\n", routeConflictSummary(routeConflicts))
+	for _, c := range routeConflicts.GetConflicts() {
+		cmd.PrintErrf("Warning: NetBird route %s overlaps host route %s, takes precedence: %s (%s)\n",
+			conflictingRouteSummary(c.GetNetbird()), conflictingRouteSummary(c.GetHost()), c.GetWinner(), c.GetReason())
+	}
+	if offset := full.GetManagementState().GetClockOffset(); offset != nil {
+		cmd.Printf("Clock offset:    %s (local clock ahead of management)\n", offset.AsDuration().Round(time.Millisecond))
+		if warning := debug.ClockSkewWarning(offset.AsDuration()); warning != "" {
+			cmd.PrintErrf("Warning: %s\n", warning)
+		}
+	}
+
+	var problems []string
+	if stat.GetStatus() != string(internal.StatusConnected) {
+		problems = append(problems, "daemon is "+stat.GetStatus())
+	}
+	if !full.GetManagementState().GetConnected() {
+		problems = append(problems, "management is disconnected")
+	}
+	if !full.GetSignalState().GetConnected() {
+		problems = append(problems, "signal is disconnected")
+	}
+	if len(problems) > 0 {
+		return errors.New("unhealthy: " + strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+// buildSummary describes the daemon build, or says it is unknown when the daemon predates
+// GetBuildInfo.
+func buildSummary(build *proto.GetBuildInfoResponse) string {
+	if build == nil {
+		return "unknown"
+	}
+	commit := build.GetCommit()
+	if commit == "" {
+		commit = "unknown commit"
+	}
+	summary := commit
+	if build.GetBuildDate() != "" {
+		summary += ", " + build.GetBuildDate()
+	}
+	return fmt.Sprintf("%s, %s %s", summary, build.GetGoVersion(), build.GetPlatform())
+}
+
+// postureFromProto converts the posture check results of the status, the zero value when the
+// daemon has none or predates them.
+func postureFromProto(state *proto.PostureState) peer.PostureEvaluation {
+	if state == nil {
+		return peer.PostureEvaluation{}
+	}
+
+	evaluation := peer.PostureEvaluation{EvaluatedAt: state.GetEvaluatedAt().AsTime()}
+	if state.GetError() != "" {
+		evaluation.Err = errors.New(state.GetError())
+	}
+	for _, c := range state.GetChecks() {
+		var check peer.PostureCheck
+		for _, p := range c.GetProcesses() {
+			check.Processes = append(check.Processes, peer.PostureProcess{
+				Path:    p.GetPath(),
+				Exists:  p.GetExists(),
+				Running: p.GetRunning(),
+			})
+		}
+		evaluation.Checks = append(evaluation.Checks, check)
+	}
+	return evaluation
+}
+
+// routeConflictSummary counts the host routes overlapping NetBird routes, or says it is unknown when
+// the daemon predates GetRouteConflicts or cannot read the routing table.
+func routeConflictSummary(resp *proto.GetRouteConflictsResponse) string {
+	if resp == nil {
+		return "unknown"
+	}
+	if len(resp.GetConflicts()) == 0 {
+		return fmt.Sprintf("none (%d NetBird routes checked)", resp.GetNetbirdRoutes())
+	}
+	var hostWins int
+	for _, c := range resp.GetConflicts() {
+		if c.GetWinner() == debug.RouteWinnerHost {
+			hostWins++
+		}
+	}
+	return fmt.Sprintf("%d, the host route takes precedence in %d", len(resp.GetConflicts()), hostWins)
+}
+
+func conflictingRouteSummary(r *proto.ConflictingRoute) string {
+	summary := r.GetPrefix()
+	if r.GetGateway() != "" {
+		summary += " via " + r.GetGateway()
+	}
+	if r.GetInterface() != "" {
+		summary += " dev " + r.GetInterface()
+	}
+	return summary
+}
+
+func featureList(names []string) string {
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, ", ")
+}
+
+func connectionSummary(connected bool, url, errMsg string) string {
+	state := "disconnected"
+	if connected {
+		state = "connected"
+	}
+	if url != "" {
+		state += " (" + url + ")"
+	}
+	if errMsg != "" {
+		state += ": " + errMsg
+	}
+	return state
+}
+
+// relaySummary counts the available relays and names the relay in use, the one with a
+// negotiated transport.
+func relaySummary(relays []*proto.RelayState) string {
+	available := 0
+	var inUse []string
+	for _, r := range relays {
+		if !r.GetAvailable() {
+			continue
+		}
+		available++
+		if r.GetTransport() != "" {
+			inUse = append(inUse, fmt.Sprintf("%s (%s)", r.GetURI(), r.GetTransport()))
+		}
+	}
+
+	summary := fmt.Sprintf("%d/%d available", available, len(relays))
+	if len(inUse) > 0 {
+		summary += ", in use: " + strings.Join(inUse, ", ")
+	}
+	return summary
+}
+
+// peerSummary counts the connected, relayed and direct peers and the connected peers without a
+// WireGuard handshake within staleThreshold.
+func peerSummary(peers []*proto.PeerState, staleThreshold time.Duration) string {
+	connected, relayed, stale := 0, 0, 0
+	now := time.Now()
+	for _, p := range peers {
+		if p.GetConnStatus() != peer.StatusConnected.String() {
+			continue
+		}
+		connected++
+		if p.GetRelayed() {
+			relayed++
+		}
+		if nbstatus.IsStaleHandshake(p, staleThreshold, now) {
+			stale++
+		}
+	}
+	summary := fmt.Sprintf("%d/%d connected, %d relayed, %d direct", connected, len(peers), relayed, connected-relayed)
+	if stale > 0 {
+		summary += fmt.Sprintf(", %d stale (no WireGuard handshake for %s)", stale, staleThreshold)
+	}
+	return summary
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+func TestConnectionSummary(t *testing.T) {
+	cases := []struct {
+		name      string
+		connected bool
+		url       string
+		errMsg    string
+		expected  string
+	}{
+		{"Connected", true, "https://api.netbird.io:443", "", "connected (https://api.netbird.io:443)"},
+		{"Disconnected with error", false, "https://api.netbird.io:443", "context deadline exceeded", "disconnected (https://api.netbird.io:443): context deadline exceeded"},
+		{"Without URL", false, "", "", "disconnected"},
+		{"Error without URL", false, "", "connection refused", "disconnected: connection refused"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, connectionSummary(tc.connected, tc.url, tc.errMsg))
+		})
+	}
+}
+
+func TestRelaySummary(t *testing.T) {
+	cases := []struct {
+		name     string
+		relays   []*proto.RelayState
+		expected string
+	}{
+		{"No relays", nil, "0/0 available"},
+		{
+			"Available, none in use",
+			[]*proto.RelayState{
+				{URI: "rels://relay-a.netbird.io:443", Available: true},
+				{URI: "stun:stun.netbird.io:3478", Available: false},
+			},
+			"1/2 available",
+		},
+		{
+			"In use",
+			[]*proto.RelayState{
+				{URI: "rels://relay-a.netbird.io:443", Available: true, Transport: "quic"},
+				{URI: "rels://relay-b.netbird.io:443", Available: true},
+			},
+			"2/2 available, in use: rels://relay-a.netbird.io:443 (quic)",
+		},
+		{
+			"Unavailable relay is not in use",
+			[]*proto.RelayState{
+				{URI: "rels://relay-a.netbird.io:443", Available: false, Transport: "ws"},
+			},
+			"0/1 available",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, relaySummary(tc.relays))
+		})
+	}
+}
+
+func TestPeerSummary(t *testing.T) {
+	now := time.Now()
+	connected := peer.StatusConnected.String()
+	idle := peer.StatusIdle.String()
+
+	cases := []struct {
+		name     string
+		peers    []*proto.PeerState
+		expected string
+	}{
+		{"No peers", nil, "0/0 connected, 0 relayed, 0 direct"},
+		{
+			"Direct and relayed",
+			[]*proto.PeerState{
+				{ConnStatus: connected, LastWireguardHandshake: timestamppb.New(now.Add(-time.Minute))},
+				{ConnStatus: connected, Relayed: true, LastWireguardHandshake: timestamppb.New(now.Add(-time.Minute))},
+				{ConnStatus: idle, Relayed: true},
+			},
+			"2/3 connected, 1 relayed, 1 direct",
+		},
+		{
+			"Stale handshake",
+			[]*proto.PeerState{
+				{ConnStatus: connected, LastWireguardHandshake: timestamppb.New(now.Add(-10 * time.Minute))},
+				{ConnStatus: connected, LastWireguardHandshake: timestamppb.New(now.Add(-time.Minute))},
+			},
+			"2/2 connected, 0 relayed, 2 direct, 1 stale (no WireGuard handshake for 5m0s)",
+		},
+		{
+			"No handshake since connecting",
+			[]*proto.PeerState{
+				{ConnStatus: connected, ConnStatusUpdate: timestamppb.New(now.Add(-time.Hour))},
+			},
+			"1/1 connected, 0 relayed, 1 direct, 1 stale (no WireGuard handshake for 5m0s)",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, peerSummary(tc.peers, 5*time.Minute))
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+// Exit codes of "debug bundle --json". Usage errors and an unreachable daemon exit with 1.
+const (
+	bundleExitFailed       = 2
+	bundleExitUploadFailed = 3
+)
+
+// bundleJSONResult is the single JSON object "debug bundle --json" prints to stdout.
+type bundleJSONResult struct {
+	LocalPath           string `json:"local_path"`
+	UploadedKey         string `json:"uploaded_key"`
+	UploadFailureReason string `json:"upload_failure_reason"`
+	Anonymized          bool   `json:"anonymized"`
+	// ExpiresAt is when the upload server deletes the bundle in RFC 3339, unset when it is unknown.
+	ExpiresAt string `json:"expires_at,omitempty"`
+	// Parts lists the part files of a split bundle, local_path is the first of them.
+	Parts []string `json:"parts,omitempty"`
+	// Error is set when no bundle was created.
+	Error string `json:"error,omitempty"`
+}
+
+// exitCodeError makes the CLI exit with a specific code. The command already reported the error.
+type exitCodeError struct {
+	code int
+	err  error
+}
+
+func (e *exitCodeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitCodeError) Unwrap() error {
+	return e.err
+}
+
+// ExitCode returns the process exit code for an error returned by Execute.
+func ExitCode(err error) int {
+	var exitErr *exitCodeError
+	if errors.As(err, &exitErr) {
+		return exitErr.code
+	}
+	return 1
+}
+
+// printBundleJSON prints the outcome of a bundle request as JSON and returns an exitCodeError
+// when the bundle or its upload failed. The error is not printed again.
+func printBundleJSON(cmd *cobra.Command, resp *proto.DebugBundleResponse, bundleErr error) error {
+	result := bundleJSONResult{
+		LocalPath:           resp.GetPath(),
+		UploadedKey:         resp.GetUploadedKey(),
+		UploadFailureReason: resp.GetUploadFailureReason(),
+		Anonymized:          resp.GetAnonymized(),
+		Parts:               resp.GetParts(),
+	}
+	if expiresAt := resp.GetExpiresAt(); expiresAt != nil {
+		result.ExpiresAt = expiresAt.AsTime().UTC().Format(time.RFC3339)
+	}
+	if bundleErr != nil {
+		result.Error = bundleErr.Error()
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("marshal result: %w", err)
+	}
+	cmd.Println(string(data))
+
+	var exitErr error
+	switch {
+	case bundleErr != nil:
+		exitErr = &exitCodeError{code: bundleExitFailed, err: bundleErr}
+	case result.UploadFailureReason != "":
+		exitErr = &exitCodeError{code: bundleExitUploadFailed, err: fmt.Errorf("upload failed: %s", result.UploadFailureReason)}
+	default:
+		return nil
+	}
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	return exitErr
+}
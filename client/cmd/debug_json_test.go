@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+func TestPrintBundleJSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		resp      *proto.DebugBundleResponse
+		bundleErr error
+		exitCode  int
+		want      bundleJSONResult
+	}{
+		{
+			name:     "uploaded",
+			resp:     &proto.DebugBundleResponse{Path: "/tmp/b.zip", UploadedKey: "key", Anonymized: true},
+			exitCode: 0,
+			want:     bundleJSONResult{LocalPath: "/tmp/b.zip", UploadedKey: "key", Anonymized: true},
+		},
+		{
+			name: "uploaded with expiry",
+			resp: &proto.DebugBundleResponse{
+				Path:        "/tmp/b.zip",
+				UploadedKey: "key",
+				ExpiresAt:   timestamppb.New(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)),
+			},
+			exitCode: 0,
+			want:     bundleJSONResult{LocalPath: "/tmp/b.zip", UploadedKey: "key", ExpiresAt: "2026-01-02T03:04:05Z"},
+		},
+		{
+			name:     "upload failed",
+			resp:     &proto.DebugBundleResponse{Path: "/tmp/b.zip", UploadFailureReason: "timeout"},
+			exitCode: bundleExitUploadFailed,
+			want:     bundleJSONResult{LocalPath: "/tmp/b.zip", UploadFailureReason: "timeout"},
+		},
+		{
+			name:      "bundle failed",
+			bundleErr: errors.New("failed to bundle debug: disk full"),
+			exitCode:  bundleExitFailed,
+			want:      bundleJSONResult{Error: "failed to bundle debug: disk full"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetOut(&out)
+
+			err := printBundleJSON(cmd, tt.resp, tt.bundleErr)
+			if tt.exitCode == 0 {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Equal(t, tt.exitCode, ExitCode(fmt.Errorf("wrapped: %w", err)))
+				assert.True(t, cmd.SilenceErrors)
+			}
+
+			var got bundleJSONResult
+			require.NoError(t, json.Unmarshal(out.Bytes(), &got))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	assert.Equal(t, 1, ExitCode(errors.New("unknown flag")))
+}
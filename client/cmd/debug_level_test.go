@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+func TestCompleteLogLevel(t *testing.T) {
+	completions, directive := completeLogLevel(nil, nil, "")
+	assert.Contains(t, completions, "trace")
+	assert.Contains(t, completions, "ice=")
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+
+	completions, directive = completeLogLevel(nil, nil, "tr")
+	assert.Equal(t, []string{"trace"}, completions)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+
+	completions, directive = completeLogLevel(nil, nil, "info,ic")
+	assert.Equal(t, []string{"info,ice="}, completions)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp|cobra.ShellCompDirectiveNoSpace, directive)
+
+	completions, _ = completeLogLevel(nil, nil, "info,ice=d")
+	assert.Equal(t, []string{"info,ice=debug"}, completions)
+
+	completions, _ = completeLogLevel(nil, []string{"info"}, "")
+	assert.Empty(t, completions)
+}
+
+func TestParseLogLevelArg(t *testing.T) {
+	request, err := parseLogLevelArg("info,ice=trace")
+	require.NoError(t, err)
+	assert.Equal(t, proto.LogLevel_INFO, request.GetLevel())
+	assert.Equal(t, map[string]proto.LogLevel{"ice": proto.LogLevel_TRACE}, request.GetComponents())
+
+	_, err = parseLogLevelArg("tarce")
+	assert.ErrorContains(t, err, "unknown log level: tarce")
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import "github.com/spf13/pflag"
+
+// noLogsIgnoredFlags select the logs of a bundle, they have no effect with --no-logs.
+var noLogsIgnoredFlags = []string{
+	"log-file-count",
+	"since",
+	"tail",
+	"log-include",
+	"log-exclude",
+	"max-size",
+}
+
+// noLogsIgnored returns the log selection flags that were set although --no-logs leaves out
+// all logs.
+func noLogsIgnored(flags *pflag.FlagSet) []string {
+	var ignored []string
+	for _, name := range noLogsIgnoredFlags {
+		if flags.Changed(name) {
+			ignored = append(ignored, "--"+name)
+		}
+	}
+	return ignored
+}
@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoLogsIgnored(t *testing.T) {
+	flags := pflag.NewFlagSet("bundle", pflag.ContinueOnError)
+	flags.Uint32("log-file-count", 1, "")
+	flags.Duration("since", 0, "")
+	flags.Uint32("tail", 0, "")
+	flags.Bool("anonymize", false, "")
+	require.NoError(t, flags.Parse([]string{"--since", "5m", "--tail=10", "--anonymize"}))
+
+	assert.Equal(t, []string{"--since", "--tail"}, noLogsIgnored(flags))
+}
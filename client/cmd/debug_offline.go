@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/netbirdio/netbird/client/anonymize"
+	"github.com/netbirdio/netbird/client/internal/debug"
+	"github.com/netbirdio/netbird/client/internal/profilemanager"
+	"github.com/netbirdio/netbird/client/proto"
+	"github.com/netbirdio/netbird/util"
+	"github.com/netbirdio/netbird/version"
+)
+
+var bundleOfflineFlag bool
+
+// offlineUnsupportedFlags need the running daemon, they are rejected with --offline.
+var offlineUnsupportedFlags = []string{
+	"upload-last",
+	"peer",
+	"group",
+	"domain",
+	"acl-query",
+	"peer-mtu-probe",
+	"profiles",
+	"network-map-count",
+}
+
+// checkOfflineFlags rejects the debug bundle flags that need the running daemon.
+func checkOfflineFlags(flags *pflag.FlagSet) error {
+	var unsupported []string
+	for _, name := range offlineUnsupportedFlags {
+		if flags.Changed(name) {
+			unsupported = append(unsupported, "--"+name)
+		}
+	}
+	if bundleOutputFlag == "-" {
+		unsupported = append(unsupported, "--output -")
+	}
+	if len(unsupported) > 0 {
+		return fmt.Errorf("--offline cannot be used with %s, they need the running daemon", strings.Join(unsupported, ", "))
+	}
+	return nil
+}
+
+// offlineBundlePaths returns the log file and default config path of the daemon. The paths the
+// service was installed with are used unless --log-file or --config are set.
+func offlineBundlePaths() (logPath string, cfgPath string) {
+	logs, cfgPath := logFiles, configPath
+	params, err := loadServiceParams()
+	if err != nil {
+		log.Debugf("failed to load service params: %v", err)
+	}
+	if params != nil {
+		if !rootCmd.PersistentFlags().Changed("log-file") && len(params.LogFiles) > 0 {
+			logs = params.LogFiles
+		}
+		if !rootCmd.PersistentFlags().Changed("config") && params.ConfigPath != "" {
+			cfgPath = params.ConfigPath
+		}
+	}
+	return util.FindFirstLogPath(logs), cfgPath
+}
+
+// readOfflineConfig reads the config of the active profile the way the daemon does, without
+// creating it.
+func readOfflineConfig(defaultConfigPath string) (*profilemanager.Config, error) {
+	activeProf, err := profilemanager.NewServiceManager(defaultConfigPath).GetActiveProfileState()
+	if err != nil {
+		return nil, fmt.Errorf("get active profile: %w", err)
+	}
+	path, err := activeProf.FilePath()
+	if err != nil {
+		return nil, fmt.Errorf("get config path of profile %s: %w", activeProf.ID, err)
+	}
+	config, err := profilemanager.GetConfig(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// offlineDebugBundle creates the debug bundle in the CLI process, for when the daemon crashed or
+// does not start. The live status and network map only exist in the daemon and are left out.
+func offlineDebugBundle(cmd *cobra.Command, outputDir string, splitSize int64) error {
+	if err := checkOfflineFlags(cmd.Flags()); err != nil {
+		return err
+	}
+
+	encryptionKey, err := readEncryptionKey()
+	if err != nil {
+		return err
+	}
+	level, err := anonymizeLevel()
+	if err != nil {
+		return err
+	}
+	var anonMap []anonymize.Mapping
+	if anonMapFlag != "" {
+		if anonMap, err = anonymize.ReadMapFile(anonMapFlag); err != nil {
+			return err
+		}
+	}
+	patterns, err := readAnonPatterns()
+	if err != nil {
+		return err
+	}
+	anonPatterns, err := anonymize.CompilePatterns(patterns)
+	if err != nil {
+		return err
+	}
+	if err := checkAllowSecrets(cmd); err != nil {
+		return err
+	}
+	systemInfo, err := debug.ParseSystemInfo(systemInfoFlag)
+	if err != nil {
+		return fmt.Errorf("invalid --system-info: %w", err)
+	}
+	compression, err := debug.ParseCompression(bundleCompressFlag)
+	if err != nil {
+		return err
+	}
+	logFilter, err := debug.ParseLogFilter(logIncludeFlag, logExcludeFlag)
+	if err != nil {
+		return err
+	}
+	if err := debug.ValidateExtraPaths(bundleIncludeFlag); err != nil {
+		return err
+	}
+	if outputDir != "" {
+		if err := debug.ValidateOutputDir(outputDir); err != nil {
+			return err
+		}
+	}
+
+	upload := uploadBundleFlag && !anonymizePreviewFlag
+	if uploadBundleFlag && anonymizePreviewFlag {
+		printInfoErr(cmd, "Skipping upload: --anonymize-preview only creates a local bundle\n")
+	}
+	uploadURL := uploadBundleURLFlag
+	if upload {
+		if uploadURL, err = debug.NormalizeUploadURL(uploadURL); err != nil {
+			return err
+		}
+		if !noURLCheckFlag {
+			if err := debug.CheckUploadURL(cmd.Context(), uploadURL); err != nil {
+				return err
+			}
+		}
+	}
+
+	logPath, cfgPath := offlineBundlePaths()
+	config, err := readOfflineConfig(cfgPath)
+	if err != nil {
+		printInfoErr(cmd, "The config is not included: %v\n", err)
+	}
+	if logPath == "" {
+		printInfoErr(cmd, "The daemon logs to no file, the bundle has no daemon logs\n")
+	} else {
+		printInfoErr(cmd, "Creating the debug bundle without the daemon, reading the logs from %s\n", logPath)
+	}
+	if len(bundleIncludeFlag) > 0 {
+		cmd.PrintErrln("Note: files added with --include are copied as they are and not anonymized")
+	}
+	policy, err := savedBundlePolicy()
+	if err != nil {
+		return fmt.Errorf("the debug bundle policy of the daemon is unknown: %w", err)
+	}
+
+	generator := debug.NewBundleGenerator(
+		debug.GeneratorDependencies{
+			InternalConfig: config,
+			LogPath:        logPath,
+			TempDir:        outputDir,
+			CliVersion:     version.NetbirdVersion(),
+			Policy:         policy,
+		},
+		debug.BundleConfig{
+			Anonymize:           level != anonymize.LevelNone || anonymizePreviewFlag,
+			AnonymizeIPsOnly:    level == anonymize.LevelIPsOnly,
+			IncludeSystemInfo:   systemInfo,
+			LogFileCount:        logFileCount,
+			EncryptionKey:       encryptionKey,
+			MaxSize:             int64(maxSizeMBFlag) * 1024 * 1024,
+			Since:               sinceFlag,
+			LogTail:             int(tailFlag),
+			AnonymizationMap:    anonMap,
+			StableAnonymization: anonMapFlag != "",
+			AnonymizePatterns:   anonPatterns,
+			AllowSecrets:        allowSecretsFlag,
+			ExtraPaths:          bundleIncludeFlag,
+			Compression:         compression,
+			LogFilter:           logFilter,
+			SplitSize:           splitSize,
+			Offline:             true,
+			NoLogs:              noLogsFlag,
+		},
+	)
+
+	path, err := generator.Generate()
+	if err != nil {
+		err = fmt.Errorf("failed to bundle debug: %w", err)
+		if bundleJSONFlag {
+			return printBundleJSON(cmd, nil, err)
+		}
+		return err
+	}
+
+	resp := &proto.DebugBundleResponse{
+		Path:       path,
+		Anonymized: generator.Anonymized(),
+		Parts:      generator.Parts(),
+	}
+	for _, t := range generator.TruncatedLogs() {
+		resp.TruncatedLogs = append(resp.TruncatedLogs, t.String())
+	}
+	if anonMapFlag != "" {
+		resp.AnonymizationMap = toProtoAnonMappings(generator.AnonymizationMappings())
+	}
+	if anonymizePreviewFlag {
+		resp.AnonymizationPreview = toProtoAnonPreview(generator.AnonymizationPreview())
+	}
+
+	if upload {
+		var managementURL string
+		if config != nil && config.ManagementURL != nil {
+			managementURL = config.ManagementURL.String()
+		}
+		opts := debug.UploadOptions{Timeout: uploadTimeoutFlag, Retries: uploadRetriesFlag}
+		result, err := debug.UploadDebugBundleWithOptions(cmd.Context(), uploadURL, managementURL, path, opts)
+		if err != nil {
+			resp.UploadFailureReason = err.Error()
+		} else {
+			resp.UploadedKey = result.Key
+			if !result.ExpiresAt.IsZero() {
+				resp.ExpiresAt = timestamppb.New(result.ExpiresAt)
+			}
+		}
+	}
+
+	return printBundleResponse(cmd, resp)
+}
+
+func toProtoAnonPreview(summaries []debug.AnonymizationSummary) []*proto.AnonymizationSummary {
+	preview := make([]*proto.AnonymizationSummary, 0, len(summaries))
+	for _, s := range summaries {
+		summary := &proto.AnonymizationSummary{
+			Category: string(s.Category),
+			Count:    uint32(s.Count),
+		}
+		for _, m := range s.Samples {
+			summary.Samples = append(summary.Samples, &proto.AnonymizationSample{
+				Original:   m.Original,
+				Anonymized: m.Anonymized,
+			})
+		}
+		preview = append(preview, summary)
+	}
+	return preview
+}
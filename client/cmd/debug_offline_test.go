@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckOfflineFlags(t *testing.T) {
+	flags := pflag.NewFlagSet("bundle", pflag.ContinueOnError)
+	flags.Bool("anonymize", false, "")
+	flags.Bool("upload-last", false, "")
+	flags.StringArray("peer", nil, "")
+	flags.Bool("peer-mtu-probe", false, "")
+
+	require.NoError(t, flags.Parse([]string{"--anonymize"}))
+	assert.NoError(t, checkOfflineFlags(flags))
+
+	require.NoError(t, flags.Parse([]string{"--peer", "peer-a", "--peer-mtu-probe"}))
+	err := checkOfflineFlags(flags)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--peer, --peer-mtu-probe")
+	assert.NotContains(t, err.Error(), "--upload-last")
+
+	bundleOutputFlag = "-"
+	t.Cleanup(func() { bundleOutputFlag = "" })
+	assert.ErrorContains(t, checkOfflineFlags(pflag.NewFlagSet("bundle", pflag.ContinueOnError)), "--output -")
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/netbirdio/netbird/client/internal/debug"
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+var (
+	debugPingCount   uint32
+	debugPingTimeout time.Duration
+	debugPingJSON    bool
+)
+
+var debugPingCmd = &cobra.Command{
+	Use:   "ping <peer>",
+	Short: "Check connectivity to a peer now",
+	Long: "Makes the daemon open the connection to the peer if it is not up, then sends ICMP echo requests to it through the tunnel. " +
+		"Reports how long opening the connection took, the path (direct or relay), the selected ICE candidate pair and the RTTs. " +
+		"The peer is given by FQDN, hostname label or overlay IP. Exits with an error if the peer is not found or unreachable. " +
+		"Results of the last hour are added to debug bundles, so pings run during \"netbird debug for\" end up in its bundle. " +
+		"The peer must allow ICMP from this peer to report RTTs.",
+	Example: "  netbird debug ping peer-a.netbird.cloud\n  netbird debug ping 100.64.0.10 --count 10",
+	Args:    cobra.ExactArgs(1),
+	RunE:    debugPing,
+}
+
+func init() {
+	debugPingCmd.Flags().Uint32VarP(&debugPingCount, "count", "c", 3, "Number of echo requests to send")
+	debugPingCmd.Flags().DurationVar(&debugPingTimeout, "timeout", 15*time.Second, "Maximum time to wait for the connection to come up")
+	debugPingCmd.Flags().BoolVar(&debugPingJSON, "json", false, "Print the result as JSON")
+	debugCmd.AddCommand(debugPingCmd)
+}
+
+func debugPing(cmd *cobra.Command, args []string) error {
+	if debugPingCount == 0 {
+		return errors.New("--count must be at least 1")
+	}
+	if debugPingTimeout <= 0 {
+		return errors.New("--timeout must be positive")
+	}
+
+	conn, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			log.Errorf(errCloseConnection, err)
+		}
+	}()
+
+	client := proto.NewDaemonServiceClient(conn)
+	resp, err := client.PeerProbe(cmd.Context(), &proto.PeerProbeRequest{
+		Peer:    args[0],
+		Count:   debugPingCount,
+		Timeout: durationpb.New(debugPingTimeout),
+	})
+	if status.Code(err) == codes.NotFound {
+		return fmt.Errorf("peer not found: %v", status.Convert(err).Message())
+	}
+	if err != nil {
+		return fmt.Errorf("failed to probe peer: %v", status.Convert(err).Message())
+	}
+
+	probe := debug.PeerProbe{
+		Peer:            resp.GetPeer(),
+		IP:              resp.GetIp(),
+		Time:            time.Now(),
+		Reachable:       resp.GetReachable(),
+		Error:           resp.GetError(),
+		ConnectTime:     resp.GetConnectTime().AsDuration(),
+		Relayed:         resp.GetRelayed(),
+		RelayAddress:    resp.GetRelayAddress(),
+		LocalCandidate:  resp.GetLocalIceCandidateType(),
+		RemoteCandidate: resp.GetRemoteIceCandidateType(),
+		LocalEndpoint:   resp.GetLocalIceCandidateEndpoint(),
+		RemoteEndpoint:  resp.GetRemoteIceCandidateEndpoint(),
+		Lost:            int(resp.GetLost()),
+		Note:            resp.GetNote(),
+	}
+	for _, rtt := range resp.GetRtts() {
+		probe.RTTs = append(probe.RTTs, rtt.AsDuration())
+	}
+
+	if debugPingJSON {
+		data, err := json.MarshalIndent(probe, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal result: %w", err)
+		}
+		cmd.Println(string(data))
+	} else {
+		cmd.Print(debug.FormatPeerProbe(probe))
+	}
+
+	if !probe.Reachable {
+		return fmt.Errorf("peer %s is unreachable", probe.Peer)
+	}
+	return nil
+}
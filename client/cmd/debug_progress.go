@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+// progressLogInterval is how often a non-terminal gets a line while a stage is still running.
+const progressLogInterval = 10 * time.Second
+
+// requestDebugBundle creates a debug bundle and prints the progress reported by the daemon to
+// stderr. Daemons without progress support fall back to the plain DebugBundle call.
+func requestDebugBundle(cmd *cobra.Command, client proto.DaemonServiceClient, request *proto.DebugBundleRequest) (*proto.DebugBundleResponse, error) {
+	stream, err := client.DebugBundleWithProgress(cmd.Context(), request)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := newBundleProgress(progressOutput(cmd))
+	defer progress.stop()
+
+	for {
+		event, err := stream.Recv()
+		if status.Code(err) == codes.Unimplemented {
+			progress.stop()
+			return client.DebugBundle(cmd.Context(), request)
+		}
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("daemon closed the progress stream without a response")
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if resp := event.GetResponse(); resp != nil {
+			return resp, nil
+		}
+		progress.setStage(event.GetStage())
+	}
+}
+
+// bundleProgress redraws the current stage and the elapsed time on a terminal, like the
+// remaining time of "debug for". Other outputs get a line per stage and a periodic line
+// while a stage runs.
+type bundleProgress struct {
+	out      io.Writer
+	terminal bool
+	start    time.Time
+
+	mu         sync.Mutex
+	stage      string
+	lastLogged time.Time
+	lineWidth  int
+
+	done     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+func newBundleProgress(out io.Writer) *bundleProgress {
+	p := &bundleProgress{
+		out:   out,
+		start: time.Now(),
+		done:  make(chan struct{}),
+	}
+	if f, ok := out.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		p.terminal = true
+	}
+
+	p.wg.Add(1)
+	go p.tick()
+	return p
+}
+
+func (p *bundleProgress) tick() {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			p.mu.Lock()
+			if progressDue(p.stage, p.terminal, time.Since(p.lastLogged)) {
+				p.print()
+			}
+			p.mu.Unlock()
+		}
+	}
+}
+
+func (p *bundleProgress) setStage(stage string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stage = stage
+	p.print()
+}
+
+// print writes the current stage. The caller must hold p.mu.
+func (p *bundleProgress) print() {
+	var out string
+	out, p.lineWidth = renderProgress(p.stage, time.Since(p.start), p.terminal, p.lineWidth)
+	p.lastLogged = time.Now()
+	_, _ = io.WriteString(p.out, out)
+}
+
+// stop ends the periodic output and clears the progress line on a terminal. It is safe to
+// call more than once.
+func (p *bundleProgress) stop() {
+	p.stopOnce.Do(func() {
+		close(p.done)
+		p.wg.Wait()
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if out := clearProgress(p.terminal, p.lineWidth); out != "" {
+			_, _ = io.WriteString(p.out, out)
+		}
+	})
+}
+
+// progressDue reports whether the periodic tick prints the stage again, sinceLogged after the
+// last line.
+func progressDue(stage string, terminal bool, sinceLogged time.Duration) bool {
+	return stage != "" && (terminal || sinceLogged >= progressLogInterval)
+}
+
+// renderProgress returns the output for stage after elapsed and the width of the progress line.
+// A terminal gets the line redrawn in place, padded to cover a longer previous line of
+// lineWidth. Other outputs get a full line.
+func renderProgress(stage string, elapsed time.Duration, terminal bool, lineWidth int) (string, int) {
+	if !terminal {
+		return fmt.Sprintf("[%s] %s\n", formatDuration(elapsed), stage), lineWidth
+	}
+
+	line := fmt.Sprintf("%s %s...", formatDuration(elapsed), stage)
+	padding := max(lineWidth-len(line), 0)
+	return "\r" + line + strings.Repeat(" ", padding), len(line)
+}
+
+// clearProgress returns the output that clears a progress line of lineWidth on a terminal.
+func clearProgress(terminal bool, lineWidth int) string {
+	if !terminal || lineWidth == 0 {
+		return ""
+	}
+	return "\r" + strings.Repeat(" ", lineWidth) + "\r"
+}
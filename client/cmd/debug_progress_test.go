@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderProgress(t *testing.T) {
+	tests := []struct {
+		name      string
+		stage     string
+		elapsed   time.Duration
+		terminal  bool
+		lineWidth int
+		want      string
+		wantWidth int
+	}{
+		{
+			name:    "line per stage",
+			stage:   "collecting logs",
+			elapsed: 3 * time.Second,
+			want:    "[00:00:03] collecting logs\n",
+		},
+		{
+			name:      "line keeps the width",
+			stage:     "compressing",
+			elapsed:   time.Minute + 5*time.Second,
+			lineWidth: 7,
+			want:      "[00:01:05] compressing\n",
+			wantWidth: 7,
+		},
+		{
+			name:      "first redraw",
+			stage:     "collecting logs",
+			elapsed:   3 * time.Second,
+			terminal:  true,
+			want:      "\r00:00:03 collecting logs...",
+			wantWidth: 27,
+		},
+		{
+			name:      "redraw over a longer line",
+			stage:     "compressing",
+			elapsed:   4 * time.Second,
+			terminal:  true,
+			lineWidth: 27,
+			want:      "\r00:00:04 compressing...    ",
+			wantWidth: 23,
+		},
+		{
+			name:      "redraw over a shorter line",
+			stage:     "gathering system info",
+			elapsed:   time.Hour,
+			terminal:  true,
+			lineWidth: 23,
+			want:      "\r01:00:00 gathering system info...",
+			wantWidth: 33,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, width := renderProgress(tt.stage, tt.elapsed, tt.terminal, tt.lineWidth)
+			assert.Equal(t, tt.want, out)
+			assert.Equal(t, tt.wantWidth, width)
+		})
+	}
+}
+
+func TestProgressDue(t *testing.T) {
+	tests := []struct {
+		name        string
+		stage       string
+		terminal    bool
+		sinceLogged time.Duration
+		want        bool
+	}{
+		{name: "no stage yet", terminal: true, sinceLogged: time.Minute},
+		{name: "terminal redraws every tick", stage: "compressing", terminal: true, sinceLogged: time.Second, want: true},
+		{name: "line too recent", stage: "compressing", sinceLogged: progressLogInterval - time.Second},
+		{name: "line after the interval", stage: "compressing", sinceLogged: progressLogInterval, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, progressDue(tt.stage, tt.terminal, tt.sinceLogged))
+		})
+	}
+}
+
+func TestClearProgress(t *testing.T) {
+	tests := []struct {
+		name      string
+		terminal  bool
+		lineWidth int
+		want      string
+	}{
+		{name: "not a terminal", lineWidth: 10},
+		{name: "nothing drawn", terminal: true},
+		{name: "drawn line", terminal: true, lineWidth: 5, want: "\r     \r"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, clearProgress(tt.terminal, tt.lineWidth))
+		})
+	}
+}
+
+func TestBundleProgressNonTerminal(t *testing.T) {
+	var out bytes.Buffer
+	progress := newBundleProgress(&out)
+	progress.setStage("collecting logs")
+	progress.setStage("compressing")
+	progress.stop()
+	progress.stop()
+
+	assert.Equal(t, "[00:00:00] collecting logs\n[00:00:00] compressing\n", out.String())
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+var debugQuietFlag bool
+
+// printInfo prints informational output of the debug commands, like progress and confirmations,
+// unless --quiet is set. Results such as the bundle path and errors are printed directly.
+func printInfo(cmd *cobra.Command, format string, args ...any) {
+	if debugQuietFlag {
+		return
+	}
+	cmd.Printf(format, args...)
+}
+
+// printInfoErr is printInfo for notes written to stderr.
+func printInfoErr(cmd *cobra.Command, format string, args ...any) {
+	if debugQuietFlag {
+		return
+	}
+	cmd.PrintErrf(format, args...)
+}
+
+// progressOutput returns where progress is drawn, nowhere with --quiet.
+func progressOutput(cmd *cobra.Command) io.Writer {
+	if debugQuietFlag {
+		return io.Discard
+	}
+	return cmd.ErrOrStderr()
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintInfoQuiet(t *testing.T) {
+	t.Cleanup(func() { debugQuietFlag = false })
+
+	var out, errOut bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	cmd.SetErr(&errOut)
+
+	printInfo(cmd, "netbird %s\n", "up")
+	printInfoErr(cmd, "note\n")
+	assert.Equal(t, "netbird up\n", out.String())
+	assert.Equal(t, "note\n", errOut.String())
+
+	out.Reset()
+	errOut.Reset()
+	debugQuietFlag = true
+	printInfo(cmd, "netbird %s\n", "up")
+	printInfoErr(cmd, "note\n")
+	cmd.PrintErrln("error")
+	assert.Empty(t, out.String())
+	assert.Equal(t, "error\n", errOut.String())
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/netbirdio/netbird/client/internal"
+	"github.com/netbirdio/netbird/client/internal/debug"
+)
+
+var (
+	replayPeerKeyFlag string
+	replayMapFlag     string
+)
+
+var debugReplayCmd = &cobra.Command{
+	Use:     "replay <bundle.zip>",
+	Example: "  netbird debug replay netbird.debug.123456.zip\n  netbird debug replay --map network_map-1.json --peer-key <key> netbird.debug.123456.zip",
+	Short:   "Compute the routes and firewall rules of a network map captured in a debug bundle",
+	Long: "Reads network_map.json from a debug bundle and runs it through the route classification and ACL computation of the client, " +
+		"then prints the routes the peer would serve and install and the firewall rules it would add. " +
+		"Runs locally without the daemon and changes nothing on the system: no route is installed and the firewall rules are only recorded. " +
+		"Routes are classified for the peer the bundle was created on, inferred from the map unless --peer-key is given. " +
+		"Whether default routes are supported depends on this machine. Exits with an error when the map has entries the client would skip.",
+	Args: cobra.ExactArgs(1),
+	RunE: debugReplay,
+}
+
+func init() {
+	debugReplayCmd.Flags().StringVar(&replayPeerKeyFlag, "peer-key", "", "WireGuard public key of the peer the bundle was created on")
+	debugReplayCmd.Flags().StringVar(&replayMapFlag, "map", debug.NetworkMapFile, "Network map file in the bundle, e.g. network_map-1.json for an older map")
+	debugCmd.AddCommand(debugReplayCmd)
+}
+
+func debugReplay(cmd *cobra.Command, args []string) error {
+	syncResponse, err := debug.ReadBundleSyncResponse(args[0], replayMapFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read network map: %w", err)
+	}
+
+	result := internal.ReplayNetworkMap(syncResponse.GetNetworkMap(), replayPeerKeyFlag)
+	cmd.Printf("Network map serial: %d\n", syncResponse.GetNetworkMap().GetSerial())
+	cmd.Print(internal.FormatReplay(result))
+
+	if n := len(result.Invalid); n > 0 {
+		return fmt.Errorf("network map has %d invalid entries", n)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
+
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+const (
+	// maxResourceSamples bounds the resource timeline sent with the bundle request. A longer session
+	// keeps every second sample and samples half as often from then on.
+	maxResourceSamples = 7200
+	// resourceSampleTimeout bounds a single sample, a slow daemon skips the tick.
+	resourceSampleTimeout = 500 * time.Millisecond
+)
+
+// resourceTimeline samples the runtime stats of the daemon during "debug for", for
+// resource-timeline.csv in the bundle.
+type resourceTimeline struct {
+	cmd    *cobra.Command
+	client proto.DaemonServiceClient
+
+	mu      sync.Mutex
+	samples []*proto.RuntimeStatsSample
+	// every is the number of ticks per sample, ticks counts the ticks since the last sample.
+	every  int
+	ticks  int
+	warned bool
+}
+
+func newResourceTimeline(cmd *cobra.Command, client proto.DaemonServiceClient) *resourceTimeline {
+	return &resourceTimeline{cmd: cmd, client: client, every: 1}
+}
+
+// tick takes a sample every t.every calls. A failed sample prints a warning once.
+func (t *resourceTimeline) tick(ctx context.Context) {
+	t.mu.Lock()
+	t.ticks++
+	if t.ticks < t.every {
+		t.mu.Unlock()
+		return
+	}
+	t.ticks = 0
+	t.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, resourceSampleTimeout)
+	defer cancel()
+	resp, err := t.client.GetRuntimeStats(ctx, &proto.GetRuntimeStatsRequest{})
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err != nil {
+		// the wait ended during the sample, e.g. with Ctrl+C
+		if ctx.Err() != nil && !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return
+		}
+		if !t.warned {
+			t.warned = true
+			t.cmd.PrintErrf("\nWarning: failed to sample the daemon resource usage, resource-timeline.csv has gaps: %v\n", status.Convert(err).Message())
+		}
+		return
+	}
+
+	t.samples = append(t.samples, resp.GetSample())
+	if len(t.samples) >= maxResourceSamples {
+		kept := t.samples[:0]
+		for i := 0; i < len(t.samples); i += 2 {
+			kept = append(kept, t.samples[i])
+		}
+		t.samples = kept
+		t.every *= 2
+	}
+}
+
+// Samples returns a copy of the samples taken so far.
+func (t *resourceTimeline) Samples() []*proto.RuntimeStatsSample {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]*proto.RuntimeStatsSample(nil), t.samples...)
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+type fakeRuntimeStatsClient struct {
+	proto.DaemonServiceClient
+	calls int
+	err   error
+}
+
+func (c *fakeRuntimeStatsClient) GetRuntimeStats(_ context.Context, _ *proto.GetRuntimeStatsRequest, _ ...grpc.CallOption) (*proto.GetRuntimeStatsResponse, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &proto.GetRuntimeStatsResponse{Sample: &proto.RuntimeStatsSample{Goroutines: uint64(c.calls)}}, nil
+}
+
+func TestResourceTimelineDecimates(t *testing.T) {
+	client := &fakeRuntimeStatsClient{}
+	timeline := newResourceTimeline(&cobra.Command{}, client)
+
+	for range maxResourceSamples {
+		timeline.tick(context.Background())
+	}
+	samples := timeline.Samples()
+	assert.Len(t, samples, maxResourceSamples/2)
+	assert.Equal(t, uint64(1), samples[0].GetGoroutines())
+	assert.Equal(t, uint64(3), samples[1].GetGoroutines())
+
+	// from now on every second tick is sampled
+	timeline.tick(context.Background())
+	timeline.tick(context.Background())
+	assert.Equal(t, maxResourceSamples+1, client.calls)
+	assert.Len(t, timeline.Samples(), maxResourceSamples/2+1)
+}
+
+func TestResourceTimelineWarnsOnce(t *testing.T) {
+	var stderr bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetErr(&stderr)
+	timeline := newResourceTimeline(cmd, &fakeRuntimeStatsClient{err: errors.New("unimplemented")})
+
+	timeline.tick(context.Background())
+	timeline.tick(context.Background())
+	assert.Empty(t, timeline.Samples())
+	assert.Equal(t, 1, bytes.Count(stderr.Bytes(), []byte("Warning: failed to sample")))
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	stderr.Reset()
+	timeline = newResourceTimeline(cmd, &fakeRuntimeStatsClient{err: context.Canceled})
+	timeline.tick(canceled)
+	assert.Empty(t, stderr.String())
+}
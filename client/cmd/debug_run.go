@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/netbirdio/netbird/client/internal/debug"
+)
+
+// commandOutputReserve is kept free of command output for the start and end lines of each
+// command, so they survive when the output is capped.
+const commandOutputReserve = 64 << 10
+
+var forRunFlag []string
+
+// commandRunner runs the --run commands of "debug for" one after another during the session.
+// It records their combined stdout and stderr with start times and durations relative to the
+// start of the session.
+type commandRunner struct {
+	start    time.Time
+	commands []string
+
+	mu  sync.Mutex
+	out bytes.Buffer
+	// dropped counts the output bytes left out to stay within debug.MaxCommandOutput.
+	dropped int
+
+	done chan struct{}
+}
+
+// checkRunCommands rejects empty --run commands.
+func checkRunCommands(commands []string) error {
+	for _, c := range commands {
+		if strings.TrimSpace(c) == "" {
+			return errors.New("--run needs a command")
+		}
+	}
+	return nil
+}
+
+// startCommands runs the commands in the background until they are done or ctx ends. Commands
+// still running when ctx ends are killed, commands not started yet are skipped.
+func startCommands(ctx context.Context, cmd *cobra.Command, commands []string) *commandRunner {
+	r := &commandRunner{
+		start:    time.Now(),
+		commands: commands,
+		done:     make(chan struct{}),
+	}
+	go func() {
+		defer close(r.done)
+		for _, command := range commands {
+			r.run(ctx, cmd, command)
+		}
+	}()
+	return r
+}
+
+// Output waits for the runner to finish and returns the recorded output.
+func (r *commandRunner) Output() []byte {
+	<-r.done
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.dropped > 0 {
+		fmt.Fprintf(&r.out, "\n%d bytes of output were left out, the output is capped at %d bytes\n", r.dropped, debug.MaxCommandOutput)
+	}
+	out := r.out.Bytes()
+	if len(out) > debug.MaxCommandOutput {
+		out = out[:debug.MaxCommandOutput]
+	}
+	return out
+}
+
+func (r *commandRunner) run(ctx context.Context, cmd *cobra.Command, command string) {
+	started := time.Since(r.start)
+	r.writeLine(fmt.Sprintf("=== [+%s] $ %s", formatOffset(started), command))
+	if ctx.Err() != nil {
+		r.writeLine("=== not started, the session ended before")
+		return
+	}
+
+	c := shellCommand(ctx, command)
+	c.Stdout = r
+	c.Stderr = r
+	c.WaitDelay = time.Second
+	err := c.Run()
+
+	ended := time.Since(r.start)
+	result := commandResult(ctx, c, err)
+	r.writeLine(fmt.Sprintf("=== [+%s] %s after %s", formatOffset(ended), result, formatOffset(ended-started)))
+	r.writeLine("")
+	printInfo(cmd, "\nCommand %q %s at +%s\n", command, result, formatOffset(ended))
+}
+
+// commandResult describes how a command ended.
+func commandResult(ctx context.Context, c *exec.Cmd, err error) string {
+	var exitErr *exec.ExitError
+	switch {
+	case c.ProcessState == nil:
+		return fmt.Sprintf("failed to start: %v", err)
+	case ctx.Err() != nil && !c.ProcessState.Exited():
+		return "killed at the end of the session"
+	case err == nil || errors.As(err, &exitErr):
+		return fmt.Sprintf("exited with code %d", c.ProcessState.ExitCode())
+	default:
+		return fmt.Sprintf("exited with code %d: %v", c.ProcessState.ExitCode(), err)
+	}
+}
+
+// Write records command output up to the cap, counting what is left out.
+func (r *commandRunner) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := len(p)
+	room := max(debug.MaxCommandOutput-commandOutputReserve-r.out.Len(), 0)
+	if room < len(p) {
+		r.dropped += len(p) - room
+		p = p[:room]
+	}
+	r.out.Write(p)
+	// report everything as written, a short write would make exec stop copying the output
+	return n, nil
+}
+
+func (r *commandRunner) writeLine(line string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.out.Len() > 0 && !bytes.HasSuffix(r.out.Bytes(), []byte("\n")) {
+		r.out.WriteByte('\n')
+	}
+	r.out.WriteString(line + "\n")
+}
+
+// shellCommand runs command with the shell of the platform, so it can use quoting and pipes.
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
+
+func formatOffset(d time.Duration) string {
+	return d.Round(100 * time.Millisecond).String()
+}
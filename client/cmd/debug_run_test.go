@@ -0,0 +1,44 @@
+//go:build !windows
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandRunner(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := startCommands(ctx, cmd, []string{"echo out; echo err >&2; exit 3", "sleep 30", "echo never"})
+	time.Sleep(500 * time.Millisecond)
+	cancel()
+	out := string(r.Output())
+
+	assert.Contains(t, out, "$ echo out; echo err >&2; exit 3\nout\nerr\n")
+	assert.Contains(t, out, "exited with code 3")
+	assert.Contains(t, out, "$ sleep 30\n")
+	assert.Contains(t, out, "killed at the end of the session")
+	assert.Contains(t, out, "$ echo never\n=== not started, the session ended before\n")
+	assert.NotContains(t, out, "never\nnever")
+}
+
+func TestCommandRunnerCapsOutput(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+
+	r := startCommands(context.Background(), cmd, []string{"head -c 2000000 /dev/zero", "echo after"})
+	out := r.Output()
+
+	assert.LessOrEqual(t, len(out), 1<<20)
+	assert.Contains(t, string(out), "exited with code 0")
+	assert.Contains(t, string(out), "$ echo after\n=== ", "the commands after the cap still run, without output")
+	assert.Contains(t, string(out), "bytes of output were left out")
+}
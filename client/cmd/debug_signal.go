@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netbirdio/netbird/client/internal"
+	"github.com/netbirdio/netbird/client/internal/debug"
+	"github.com/netbirdio/netbird/client/internal/peer"
+	"github.com/netbirdio/netbird/client/internal/profilemanager"
+	mgmProto "github.com/netbirdio/netbird/shared/management/proto"
+	"github.com/netbirdio/netbird/version"
+)
+
+// Settings of the debug bundles triggered by SIGUSR1, or the debug event on Windows. Set them in
+// the environment of the daemon, e.g. with "netbird service install --service-env".
+const (
+	envSignalBundleAnonymize    = "NB_SIGNAL_BUNDLE_ANONYMIZE"
+	envSignalBundleSystemInfo   = "NB_SIGNAL_BUNDLE_SYSTEM_INFO"
+	envSignalBundleOutputDir    = "NB_SIGNAL_BUNDLE_OUTPUT_DIR"
+	envSignalBundleLogFileCount = "NB_SIGNAL_BUNDLE_LOG_FILE_COUNT"
+)
+
+// signalBundleSettings is what a signal-triggered debug bundle contains and where it is written.
+type signalBundleSettings struct {
+	anonymize    bool
+	systemInfo   debug.SystemInfoSet
+	outputDir    string
+	logFileCount uint32
+}
+
+// signalBundleSettingsFromEnv reads the settings of signal-triggered bundles from the environment.
+// Unset variables keep the defaults: not anonymized, all system information, the temp directory
+// and one log file. Invalid values are logged and keep the default too.
+func signalBundleSettingsFromEnv() signalBundleSettings {
+	settings := signalBundleSettings{
+		systemInfo:   debug.AllSystemInfo(),
+		logFileCount: 1,
+	}
+
+	if value := os.Getenv(envSignalBundleAnonymize); value != "" {
+		anonymize, err := strconv.ParseBool(value)
+		if err != nil {
+			log.Warnf("ignoring invalid %s %q: %v", envSignalBundleAnonymize, value, err)
+		} else {
+			settings.anonymize = anonymize
+		}
+	}
+
+	if value, ok := os.LookupEnv(envSignalBundleSystemInfo); ok {
+		systemInfo, err := debug.ParseSystemInfo(value)
+		if err != nil {
+			log.Warnf("ignoring invalid %s %q: %v", envSignalBundleSystemInfo, value, err)
+		} else {
+			settings.systemInfo = systemInfo
+		}
+	}
+
+	if value := os.Getenv(envSignalBundleOutputDir); value != "" {
+		if err := debug.ValidateOutputDir(value); err != nil {
+			log.Warnf("ignoring %s: %v", envSignalBundleOutputDir, err)
+		} else {
+			settings.outputDir = value
+		}
+	}
+
+	if value := os.Getenv(envSignalBundleLogFileCount); value != "" {
+		count, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || count == 0 {
+			log.Warnf("ignoring invalid %s %q, it must be a positive number", envSignalBundleLogFileCount, value)
+		} else {
+			settings.logFileCount = uint32(count)
+		}
+	}
+
+	return settings
+}
+
+func (s signalBundleSettings) String() string {
+	systemInfo := "none"
+	if names := s.systemInfo.Names(); len(names) > 0 {
+		systemInfo = strings.Join(names, ",")
+	}
+	outputDir := s.outputDir
+	if outputDir == "" {
+		outputDir = os.TempDir()
+	}
+	return fmt.Sprintf("anonymize=%t system-info=%s output-dir=%s log-file-count=%d", s.anonymize, systemInfo, outputDir, s.logFileCount)
+}
+
+// signalBundlePolicy returns the bundle policy of the running engine, or the one the daemon saved
+// before when the engine is not running.
+func signalBundlePolicy(connectClient *internal.ConnectClient) (*debug.BundlePolicy, error) {
+	if connectClient != nil {
+		if engine := connectClient.Engine(); engine != nil {
+			return engine.GetBundlePolicy(), nil
+		}
+	}
+	return savedBundlePolicy()
+}
+
+// savedBundlePolicy reads the bundle policy the daemon saved, nil if there is none.
+func savedBundlePolicy() (*debug.BundlePolicy, error) {
+	path := debug.BundlePolicyPath()
+	if path == "" {
+		return nil, nil
+	}
+	return debug.LoadBundlePolicy(path)
+}
+
+// newSignalBundleGenerator returns the generator of a signal-triggered bundle. The policy
+// overrides the settings like it does for bundles requested through the daemon.
+func newSignalBundleGenerator(config *profilemanager.Config, recorder *peer.Status, syncResponse *mgmProto.SyncResponse, policy *debug.BundlePolicy, logFilePath string, settings signalBundleSettings) *debug.BundleGenerator {
+	return debug.NewBundleGenerator(
+		debug.GeneratorDependencies{
+			InternalConfig: config,
+			StatusRecorder: recorder,
+			SyncResponse:   syncResponse,
+			LogPath:        logFilePath,
+			CPUProfile:     nil,
+			DaemonVersion:  version.NetbirdVersion(), // acting as daemon
+			TempDir:        settings.outputDir,
+			Policy:         policy,
+		},
+		debug.BundleConfig{
+			Anonymize:         settings.anonymize,
+			IncludeSystemInfo: settings.systemInfo,
+			LogFileCount:      settings.logFileCount,
+		},
+	)
+}
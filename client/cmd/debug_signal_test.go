@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/netbirdio/netbird/client/configs"
+	"github.com/netbirdio/netbird/client/internal/debug"
+)
+
+func TestSignalBundleSettingsFromEnv(t *testing.T) {
+	settings := signalBundleSettingsFromEnv()
+	assert.False(t, settings.anonymize)
+	assert.Equal(t, debug.AllSystemInfo(), settings.systemInfo)
+	assert.Empty(t, settings.outputDir)
+	assert.Equal(t, uint32(1), settings.logFileCount)
+
+	dir := t.TempDir()
+	t.Setenv(envSignalBundleAnonymize, "true")
+	t.Setenv(envSignalBundleSystemInfo, "none")
+	t.Setenv(envSignalBundleOutputDir, dir)
+	t.Setenv(envSignalBundleLogFileCount, "3")
+
+	settings = signalBundleSettingsFromEnv()
+	assert.True(t, settings.anonymize)
+	assert.Empty(t, settings.systemInfo)
+	assert.Equal(t, dir, settings.outputDir)
+	assert.Equal(t, uint32(3), settings.logFileCount)
+	assert.Contains(t, settings.String(), "system-info=none")
+
+	t.Setenv(envSignalBundleAnonymize, "maybe")
+	t.Setenv(envSignalBundleOutputDir, dir+"/missing")
+	t.Setenv(envSignalBundleLogFileCount, "0")
+
+	settings = signalBundleSettingsFromEnv()
+	assert.False(t, settings.anonymize)
+	assert.Empty(t, settings.outputDir)
+	assert.Equal(t, uint32(1), settings.logFileCount)
+}
+
+func TestSignalBundlePolicy(t *testing.T) {
+	stateDir := configs.StateDir
+	configs.StateDir = t.TempDir()
+	t.Cleanup(func() { configs.StateDir = stateDir })
+
+	policy, err := signalBundlePolicy(nil)
+	require.NoError(t, err)
+	assert.Nil(t, policy, "no policy was saved")
+
+	require.NoError(t, debug.SaveBundlePolicy(debug.BundlePolicyPath(), &debug.BundlePolicy{ForceAnonymize: true}))
+	policy, err = signalBundlePolicy(nil)
+	require.NoError(t, err)
+	require.NotNil(t, policy, "the saved policy applies while the engine is not running")
+
+	t.Setenv(envSignalBundleAnonymize, "false")
+	t.Setenv(envSignalBundleSystemInfo, "none")
+	settings := signalBundleSettingsFromEnv()
+	settings.outputDir = t.TempDir()
+	require.False(t, settings.anonymize)
+
+	generator := newSignalBundleGenerator(nil, nil, nil, policy, "", settings)
+	path, err := generator.Generate()
+	require.NoError(t, err)
+	assert.FileExists(t, path)
+	assert.True(t, generator.Anonymized(), "the environment cannot turn off the anonymization the policy forces")
+}
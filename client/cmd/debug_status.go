@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
+
+	"github.com/netbirdio/netbird/client/proto"
+	nbstatus "github.com/netbirdio/netbird/client/status"
+)
+
+// defaultWatchInterval is the refresh interval of "debug status --watch".
+const defaultWatchInterval = time.Second
+
+var (
+	statusWatchFlag    bool
+	statusIntervalFlag time.Duration
+)
+
+var debugStatusCmd = &cobra.Command{
+	Use:     "status",
+	Example: "  netbird debug status --watch --interval 500ms",
+	Short:   "Show a one-line status summary, optionally refreshed in place",
+	Long: "Prints the daemon status, the management and signal connections, the peers and the relays on one line. " +
+		"With --watch the line is redrawn every --interval over a single daemon connection until interrupted with Ctrl+C. " +
+		"While the daemon is down the line shows \"daemon unavailable\" and the summary resumes once the daemon is back.",
+	Args: cobra.NoArgs,
+	RunE: debugStatus,
+}
+
+func init() {
+	debugStatusCmd.Flags().BoolVarP(&statusWatchFlag, "watch", "w", false, "Redraws the status summary every --interval until interrupted")
+	debugStatusCmd.Flags().DurationVar(&statusIntervalFlag, "interval", defaultWatchInterval, "Refresh interval of --watch")
+	debugStatusCmd.Flags().DurationVar(&staleThresholdFlag, "stale-threshold", nbstatus.DefaultStaleHandshakeThreshold, "Counts connected peers without a WireGuard handshake for longer than this as stale")
+	debugCmd.AddCommand(debugStatusCmd)
+}
+
+func debugStatus(cmd *cobra.Command, _ []string) error {
+	if statusIntervalFlag <= 0 {
+		return errors.New("--interval must be positive")
+	}
+
+	conn, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			log.Errorf(errCloseConnection, err)
+		}
+	}()
+
+	client := proto.NewDaemonServiceClient(conn)
+	fetch := func(ctx context.Context) (*proto.StatusResponse, error) {
+		ctx, cancel := context.WithTimeout(ctx, daemonTimeout)
+		defer cancel()
+
+		resp, err := client.Status(ctx, &proto.StatusRequest{GetFullPeerStatus: true})
+		if err != nil {
+			// reconnect as soon as the daemon is back instead of after the gRPC reconnect backoff
+			conn.ResetConnectBackoff()
+			return nil, errors.New(status.Convert(err).Message())
+		}
+		return resp, nil
+	}
+
+	if !statusWatchFlag {
+		resp, err := fetch(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("failed to get status: %v", err)
+		}
+		cmd.Println(statusLine(resp, staleThresholdFlag))
+		return nil
+	}
+
+	ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	watchStatus(ctx, cmd, statusIntervalFlag, fetch)
+	return nil
+}
+
+// watchStatus redraws the status line every interval until ctx is done. Failed requests show
+// since when the daemon is unavailable, the next successful one resumes the summary.
+func watchStatus(ctx context.Context, cmd *cobra.Command, interval time.Duration, fetch func(context.Context) (*proto.StatusResponse, error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var downSince time.Time
+	var lastLen int
+	for {
+		resp, err := fetch(ctx)
+		if ctx.Err() != nil {
+			cmd.Println()
+			return
+		}
+
+		now := time.Now()
+		var line string
+		if err != nil {
+			log.Debugf("failed to get status: %v", err)
+			if downSince.IsZero() {
+				downSince = now
+			}
+			line = "daemon unavailable since " + downSince.Format(time.TimeOnly)
+		} else {
+			downSince = time.Time{}
+			line = statusLine(resp, staleThresholdFlag)
+		}
+		line = now.Format(time.TimeOnly) + " " + line
+
+		// pad with spaces to overwrite the rest of a longer previous line
+		cmd.Printf("\r%s%s", line, strings.Repeat(" ", max(lastLen-len(line), 0)))
+		lastLen = len(line)
+
+		select {
+		case <-ctx.Done():
+			cmd.Println()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// statusLine summarizes a status response on one line.
+func statusLine(resp *proto.StatusResponse, staleThreshold time.Duration) string {
+	full := resp.GetFullStatus()
+	return strings.Join([]string{
+		resp.GetStatus(),
+		"management " + connectedState(full.GetManagementState().GetConnected()),
+		"signal " + connectedState(full.GetSignalState().GetConnected()),
+		"peers " + peerSummary(full.GetPeers(), staleThreshold),
+		"relays " + relaySummary(full.GetRelays()),
+	}, " | ")
+}
+
+func connectedState(connected bool) string {
+	if connected {
+		return "connected"
+	}
+	return "disconnected"
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+func TestStatusLine(t *testing.T) {
+	resp := &proto.StatusResponse{
+		Status: "Connected",
+		FullStatus: &proto.FullStatus{
+			ManagementState: &proto.ManagementState{Connected: true},
+			SignalState:     &proto.SignalState{},
+			Peers: []*proto.PeerState{
+				{ConnStatus: "Connected", Relayed: true},
+				{ConnStatus: "Idle"},
+			},
+			Relays: []*proto.RelayState{{URI: "rels://relay", Available: true}},
+		},
+	}
+
+	expected := "Connected | management connected | signal disconnected | peers 1/2 connected, 1 relayed, 0 direct | relays 1/1 available"
+	if line := statusLine(resp, 0); line != expected {
+		t.Errorf("got %q, want %q", line, expected)
+	}
+}
+
+func TestWatchStatus(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the last request is interrupted
+	responses := []error{nil, errors.New("connection refused"), errors.New("connection refused"), nil, nil}
+	var calls int
+	fetch := func(context.Context) (*proto.StatusResponse, error) {
+		err := responses[calls]
+		calls++
+		if calls == len(responses) {
+			cancel()
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &proto.StatusResponse{Status: "Connected", FullStatus: &proto.FullStatus{}}, nil
+	}
+
+	var out strings.Builder
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	watchStatus(ctx, cmd, time.Millisecond, fetch)
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\r")[1:]
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 redraws before the interrupt, got %q", out.String())
+	}
+	if !strings.Contains(lines[0], "Connected |") {
+		t.Errorf("first redraw: %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "daemon unavailable since") || !strings.Contains(lines[2], "daemon unavailable since") {
+		t.Errorf("redraws while the daemon is down: %q", lines[1:3])
+	}
+	if !strings.Contains(lines[3], "Connected |") {
+		t.Errorf("redraw after the daemon is back: %q", lines[3])
+	}
+	if !strings.HasSuffix(out.String(), "\n") {
+		t.Error("watch does not end with a newline")
+	}
+}
@@ -0,0 +1,346 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/netbirdio/netbird/client/internal"
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+// forDaemonServer is a connected daemon at INFO level whose bundles fail.
+type forDaemonServer struct {
+	proto.UnimplementedDaemonServiceServer
+
+	// onPersistence is called when sync response persistence is enabled.
+	onPersistence func()
+
+	mu     sync.Mutex
+	levels []proto.LogLevel
+}
+
+func (s *forDaemonServer) Status(context.Context, *proto.StatusRequest) (*proto.StatusResponse, error) {
+	return &proto.StatusResponse{Status: string(internal.StatusConnected)}, nil
+}
+
+func (s *forDaemonServer) GetLogLevel(context.Context, *proto.GetLogLevelRequest) (*proto.GetLogLevelResponse, error) {
+	return &proto.GetLogLevelResponse{Level: proto.LogLevel_INFO}, nil
+}
+
+func (s *forDaemonServer) SetLogLevel(_ context.Context, req *proto.SetLogLevelRequest) (*proto.SetLogLevelResponse, error) {
+	s.mu.Lock()
+	s.levels = append(s.levels, req.GetLevel())
+	s.mu.Unlock()
+	return &proto.SetLogLevelResponse{}, nil
+}
+
+func (s *forDaemonServer) GetSyncResponsePersistence(context.Context, *proto.GetSyncResponsePersistenceRequest) (*proto.GetSyncResponsePersistenceResponse, error) {
+	return &proto.GetSyncResponsePersistenceResponse{}, nil
+}
+
+func (s *forDaemonServer) SetSyncResponsePersistence(_ context.Context, req *proto.SetSyncResponsePersistenceRequest) (*proto.SetSyncResponsePersistenceResponse, error) {
+	if req.GetEnabled() && s.onPersistence != nil {
+		s.onPersistence()
+	}
+	return &proto.SetSyncResponsePersistenceResponse{}, nil
+}
+
+func (s *forDaemonServer) DebugBundleWithProgress(*proto.DebugBundleRequest, grpc.ServerStreamingServer[proto.DebugBundleProgressEvent]) error {
+	return status.Error(codes.Internal, "disk full")
+}
+
+func (s *forDaemonServer) setLevels() []proto.LogLevel {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]proto.LogLevel(nil), s.levels...)
+}
+
+func startForDaemon(t *testing.T, server *forDaemonServer) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := grpc.NewServer()
+	proto.RegisterDaemonServiceServer(s, server)
+	go func() {
+		_ = s.Serve(listener)
+	}()
+	t.Cleanup(s.Stop)
+
+	origDaemonAddr, origNoRestart := daemonAddr, forNoRestartFlag
+	t.Cleanup(func() {
+		daemonAddr, forNoRestartFlag = origDaemonAddr, origNoRestart
+	})
+	daemonAddr = "tcp://" + listener.Addr().String()
+	forNoRestartFlag = true
+}
+
+func TestRunForDurationRestoresLogLevelOnFailedBundle(t *testing.T) {
+	server := &forDaemonServer{}
+	startForDaemon(t, server)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+
+	err := runForDuration(cmd, []string{"1ms"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "disk full")
+	assert.Equal(t, []proto.LogLevel{proto.LogLevel_TRACE, proto.LogLevel_INFO}, server.setLevels())
+}
+
+func TestRunForDurationRestoresLogLevelOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server := &forDaemonServer{onPersistence: cancel}
+	startForDaemon(t, server)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(ctx)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+
+	require.ErrorIs(t, runForDuration(cmd, []string{"1m"}), context.Canceled)
+	assert.Equal(t, []proto.LogLevel{proto.LogLevel_TRACE, proto.LogLevel_INFO}, server.setLevels(),
+		"the log level is restored on a fresh context")
+}
+
+func TestParseForSchedule(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		untilSignal bool
+		interval    time.Duration
+		want        forSchedule
+		wantErr     string
+	}{
+		{name: "duration", args: []string{"5m"}, want: forSchedule{duration: 5 * time.Minute}},
+		{name: "duration with interval", args: []string{"30m"}, interval: 5 * time.Minute, want: forSchedule{duration: 30 * time.Minute, interval: 5 * time.Minute}},
+		{name: "until interrupt argument", args: []string{untilInterruptArg}, want: forSchedule{untilInterrupt: true}},
+		{name: "until signal flag", untilSignal: true, interval: time.Hour, want: forSchedule{untilInterrupt: true, interval: time.Hour}},
+		{name: "until signal flag and argument", args: []string{untilInterruptArg}, untilSignal: true, want: forSchedule{untilInterrupt: true}},
+		{name: "until signal flag with duration", args: []string{"5m"}, untilSignal: true, wantErr: "cannot be combined"},
+		{name: "missing duration", wantErr: "missing duration"},
+		{name: "invalid duration", args: []string{"5 minutes"}, wantErr: "invalid duration format"},
+		{name: "negative interval", args: []string{"5m"}, interval: -time.Minute, wantErr: "--interval must be positive"},
+		{name: "interval as long as the duration", args: []string{"5m"}, interval: 5 * time.Minute, wantErr: "shorter than the duration"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := parseForSchedule(tt.args, tt.untilSignal, tt.interval)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, schedule)
+		})
+	}
+}
+
+func TestForScheduleNextInterval(t *testing.T) {
+	thirtyMinutes := forSchedule{duration: 30 * time.Minute, interval: 5 * time.Minute}
+	untilInterrupt := forSchedule{untilInterrupt: true, interval: 5 * time.Minute}
+
+	tests := []struct {
+		name     string
+		schedule forSchedule
+		elapsed  time.Duration
+		wantWait time.Duration
+		wantOK   bool
+	}{
+		{name: "no interval", schedule: forSchedule{duration: 30 * time.Minute}},
+		{name: "first interval", schedule: thirtyMinutes, wantWait: 5 * time.Minute, wantOK: true},
+		{name: "on a boundary", schedule: thirtyMinutes, elapsed: 10 * time.Minute, wantWait: 5 * time.Minute, wantOK: true},
+		{name: "after a slow bundle", schedule: thirtyMinutes, elapsed: 11 * time.Minute, wantWait: 4 * time.Minute, wantOK: true},
+		{name: "missed boundary is skipped", schedule: thirtyMinutes, elapsed: 16 * time.Minute, wantWait: 4 * time.Minute, wantOK: true},
+		{name: "last interval before the end", schedule: thirtyMinutes, elapsed: 21 * time.Minute, wantWait: 4 * time.Minute, wantOK: true},
+		{name: "end is the final bundle", schedule: thirtyMinutes, elapsed: 25 * time.Minute},
+		{name: "until interrupt never ends", schedule: untilInterrupt, elapsed: 10 * time.Hour, wantWait: 5 * time.Minute, wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wait, ok := tt.schedule.nextInterval(tt.elapsed)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantWait, wait)
+		})
+	}
+}
+
+func TestForScheduleCaptureTimeout(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule forSchedule
+		want     time.Duration
+	}{
+		{name: "short duration", schedule: forSchedule{duration: time.Minute}, want: 90 * time.Second},
+		{name: "long duration", schedule: forSchedule{duration: time.Hour}, want: 10 * time.Minute},
+		{name: "until interrupt", schedule: forSchedule{untilInterrupt: true}, want: 10 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.schedule.captureTimeout())
+		})
+	}
+}
+
+func TestForPlanSteps(t *testing.T) {
+	info := &proto.GetLogLevelResponse{Level: proto.LogLevel_INFO}
+	trace := &proto.GetLogLevelResponse{Level: proto.LogLevel_TRACE}
+
+	tests := []struct {
+		name string
+		plan forPlan
+		want []string
+	}{
+		{
+			name: "connected",
+			plan: forPlan{
+				restart:         true,
+				initialLogLevel: info,
+				schedule:        forSchedule{duration: 5 * time.Minute},
+				bundlePattern:   "netbird.debug.*.zip",
+			},
+			want: []string{
+				"copy the daemon logs aside, to be merged into the final bundle",
+				"set log level to trace",
+				"netbird down, then wait 1s",
+				"enable sync response persistence",
+				"netbird up, then wait 3s",
+				"start CPU profiling",
+				"read the interface counters, the bundle adds their change since then",
+				"sample the daemon goroutines, open file descriptors and memory every second for resource-timeline.csv",
+				"collect for 5m0s",
+				"stop CPU profiling",
+				"create debug bundle netbird.debug.*.zip in the daemon's temporary directory (* is random)",
+			},
+		},
+		{
+			name: "down, already at trace, with intervals, capture, commands and upload",
+			plan: forPlan{
+				stateWasDown:       true,
+				restart:            true,
+				initialLogLevel:    trace,
+				initialPersistence: true,
+				schedule:           forSchedule{untilInterrupt: true, interval: time.Minute},
+				capture:            true,
+				commands:           []string{"ping -c 3 peer"},
+				uploadURL:          "https://upload.example.com",
+				bundlePattern:      "netbird.debug.*.zip.age",
+			},
+			want: []string{
+				"copy the daemon logs aside, to be merged into the final bundle",
+				"netbird up (service is down), then wait 10s",
+				"keep log level trace (already set)",
+				"netbird down, then wait 1s",
+				"enable sync response persistence (already on)",
+				"netbird up, then wait 3s",
+				"start CPU profiling",
+				"start packet capture",
+				"read the interface counters, the bundle adds their change since then",
+				"sample the daemon goroutines, open file descriptors and memory every second for resource-timeline.csv",
+				"collect until Ctrl+C is pressed",
+				"create a debug bundle every 1m0s while collecting, without the CPU profile and packet capture",
+				`run "ping -c 3 peer" while collecting, after the commands before it, and record its output`,
+				"stop packet capture",
+				"stop CPU profiling",
+				"create debug bundle netbird.debug.*.zip.age in the daemon's temporary directory (* is random)",
+				"upload the bundle to https://upload.example.com",
+			},
+		},
+		{
+			name: "no restart with a peer capture",
+			plan: forPlan{
+				initialLogLevel: info,
+				schedule:        forSchedule{duration: time.Minute},
+				pcapPeer:        "peer-a.netbird.cloud",
+				bundlePattern:   "netbird.debug.*.zip",
+			},
+			want: []string{
+				"copy the daemon logs aside, to be merged into the final bundle",
+				"set log level to trace",
+				"enable sync response persistence without restarting (--no-restart), the current network map is only captured after the next update",
+				"start CPU profiling",
+				"start packet capture for peer peer-a.netbird.cloud",
+				"read the interface counters, the bundle adds their change since then",
+				"sample the daemon goroutines, open file descriptors and memory every second for resource-timeline.csv",
+				"collect for 1m0s",
+				"stop packet capture",
+				"stop CPU profiling",
+				"create debug bundle netbird.debug.*.zip in the daemon's temporary directory (* is random)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.plan.steps())
+		})
+	}
+}
+
+func TestForPlanRestores(t *testing.T) {
+	tests := []struct {
+		name string
+		plan forPlan
+		want []string
+	}{
+		{
+			name: "connected",
+			plan: forPlan{
+				restart:         true,
+				initialLogLevel: &proto.GetLogLevelResponse{Level: proto.LogLevel_INFO},
+			},
+			want: []string{
+				"netbird up, only if the up step failed (service was up)",
+				"log level info",
+				"sync response persistence off",
+			},
+		},
+		{
+			name: "down with component levels",
+			plan: forPlan{
+				stateWasDown: true,
+				restart:      true,
+				initialLogLevel: &proto.GetLogLevelResponse{
+					Level:      proto.LogLevel_WARN,
+					Components: map[string]proto.LogLevel{"ice": proto.LogLevel_DEBUG, "dns": proto.LogLevel_TRACE},
+				},
+				initialPersistence: true,
+			},
+			want: []string{
+				"netbird down (service was down)",
+				"log level warn with dns=trace,ice=debug",
+			},
+		},
+		{
+			name: "nothing to restore",
+			plan: forPlan{
+				initialLogLevel:    &proto.GetLogLevelResponse{Level: proto.LogLevel_TRACE},
+				initialPersistence: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.plan.restores())
+		})
+	}
+}
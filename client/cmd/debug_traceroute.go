@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/netbirdio/netbird/client/internal/debug"
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+const (
+	// tracerouteMaxHops bounds the hops of each trace.
+	tracerouteMaxHops = 20
+	// tracerouteProbeWait is how long a hop is waited for.
+	tracerouteProbeWait = 2 * time.Second
+	// tracerouteTimeout bounds each trace including its fallbacks, the traces run in parallel.
+	tracerouteTimeout = 45 * time.Second
+)
+
+var traceInfraFlag bool
+
+// tracerouteTarget is an infrastructure endpoint traced with --trace-infra.
+type tracerouteTarget struct {
+	name string
+	host string
+}
+
+// infraTargets returns the management, signal and available relay hosts of the status, each
+// host once.
+func infraTargets(st *proto.FullStatus) []tracerouteTarget {
+	var targets []tracerouteTarget
+	seen := make(map[string]bool)
+	add := func(name, uri string) {
+		host := endpointHost(uri)
+		if host == "" || seen[host] {
+			return
+		}
+		seen[host] = true
+		targets = append(targets, tracerouteTarget{name: name, host: host})
+	}
+
+	add("management", st.GetManagementState().GetURL())
+	add("signal", st.GetSignalState().GetURL())
+	for _, relay := range st.GetRelays() {
+		// STUN and TURN servers are probed too, only the relays carry peer traffic
+		if relay.GetAvailable() && strings.HasPrefix(relay.GetURI(), "rel") {
+			add("relay", relay.GetURI())
+		}
+	}
+	return targets
+}
+
+// endpointHost returns the host of a server URL such as https://api.netbird.io:443 or
+// rels://relay.netbird.io:443, or of a bare host:port.
+func endpointHost(uri string) string {
+	if uri == "" {
+		return ""
+	}
+	if u, err := url.Parse(uri); err == nil && u.Host != "" {
+		return u.Hostname()
+	}
+	if host, _, err := net.SplitHostPort(uri); err == nil {
+		return host
+	}
+	return uri
+}
+
+// tracerouteCommands returns the traceroute commands to try for host, in order: ICMP first,
+// then UDP, which works without privileges on more systems.
+func tracerouteCommands(host string) [][]string {
+	hops := strconv.Itoa(tracerouteMaxHops)
+	if runtime.GOOS == "windows" {
+		return [][]string{{"tracert", "-d", "-h", hops, "-w", strconv.Itoa(int(tracerouteProbeWait.Milliseconds())), host}}
+	}
+
+	wait := strconv.Itoa(int(tracerouteProbeWait.Seconds()))
+	commands := [][]string{
+		{"traceroute", "-I", "-n", "-q", "1", "-m", hops, "-w", wait, host},
+		{"traceroute", "-n", "-q", "1", "-m", hops, "-w", wait, host},
+	}
+	if runtime.GOOS == "linux" {
+		commands = append(commands, []string{"tracepath", "-n", "-m", hops, host})
+	}
+	return commands
+}
+
+// traceInfra traces the route to each target in parallel and returns the results in the order
+// of the targets, capped at debug.MaxTracerouteOutput.
+func traceInfra(ctx context.Context, targets []tracerouteTarget) []byte {
+	results := make([][]byte, len(targets))
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = traceTarget(ctx, target)
+		}()
+	}
+	wg.Wait()
+
+	out := bytes.Join(results, []byte("\n"))
+	if len(out) > debug.MaxTracerouteOutput {
+		out = out[:debug.MaxTracerouteOutput]
+	}
+	return out
+}
+
+// traceTarget runs the traceroute commands for a target until one succeeds.
+func traceTarget(ctx context.Context, target tracerouteTarget) []byte {
+	ctx, cancel := context.WithTimeout(ctx, tracerouteTimeout)
+	defer cancel()
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "=== %s %s\n", target.name, target.host)
+	for _, args := range tracerouteCommands(target.host) {
+		fmt.Fprintf(&out, "$ %s\n", strings.Join(args, " "))
+
+		c := exec.CommandContext(ctx, args[0], args[1:]...)
+		c.WaitDelay = time.Second
+		output, err := c.CombinedOutput()
+		out.Write(output)
+		if len(output) > 0 && !bytes.HasSuffix(output, []byte("\n")) {
+			out.WriteByte('\n')
+		}
+		if err == nil {
+			return out.Bytes()
+		}
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			fmt.Fprintf(&out, "stopped after %s\n", tracerouteTimeout)
+			return out.Bytes()
+		}
+		if ctx.Err() != nil {
+			out.WriteString("canceled\n")
+			return out.Bytes()
+		}
+		fmt.Fprintf(&out, "failed: %v\n", err)
+	}
+	out.WriteString("no traceroute command succeeded\n")
+	return out.Bytes()
+}
+
+// traceInfraRequest traces the route to the infrastructure endpoints the daemon is connected to.
+func traceInfraRequest(ctx context.Context, client proto.DaemonServiceClient) ([]byte, error) {
+	resp, err := client.Status(ctx, &proto.StatusRequest{GetFullPeerStatus: true})
+	if err != nil {
+		return nil, fmt.Errorf("get the infrastructure endpoints: %w", err)
+	}
+	targets := infraTargets(resp.GetFullStatus())
+	if len(targets) == 0 {
+		return []byte("No management, signal or relay endpoint is known to the daemon.\n"), nil
+	}
+	return traceInfra(ctx, targets), nil
+}
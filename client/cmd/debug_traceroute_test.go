@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+func TestInfraTargets(t *testing.T) {
+	st := &proto.FullStatus{
+		ManagementState: &proto.ManagementState{URL: "https://api.example.com:443"},
+		SignalState:     &proto.SignalState{URL: "https://api.example.com:443"},
+		Relays: []*proto.RelayState{
+			{URI: "stun:stun.example.com:3478", Available: true},
+			{URI: "rels://relay-a.example.com:443", Available: true},
+			{URI: "rels://relay-b.example.com:443", Available: false},
+		},
+	}
+
+	assert.Equal(t, []tracerouteTarget{
+		{name: "management", host: "api.example.com"},
+		{name: "relay", host: "relay-a.example.com"},
+	}, infraTargets(st))
+	assert.Empty(t, infraTargets(&proto.FullStatus{}))
+}
+
+func TestEndpointHost(t *testing.T) {
+	assert.Equal(t, "signal.example.com", endpointHost("https://signal.example.com:10000"))
+	assert.Equal(t, "192.0.2.1", endpointHost("192.0.2.1:443"))
+	assert.Equal(t, "2001:db8::1", endpointHost("rels://[2001:db8::1]:443"))
+	assert.Equal(t, "relay.example.com", endpointHost("relay.example.com"))
+	assert.Empty(t, endpointHost(""))
+}
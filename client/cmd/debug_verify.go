@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/netbirdio/netbird/client/internal/debug"
+)
+
+var debugVerifyCmd = &cobra.Command{
+	Use:     "verify <bundle.zip>",
+	Example: "  netbird debug verify netbird.debug.123456.zip",
+	Short:   "Verify a debug bundle against its manifest and checksums",
+	Long: "Checks every file of a debug bundle against its manifest.json and SHA256SUMS and reports missing, modified and unlisted files, " +
+		"the NetBird version that created it and whether it was anonymized. Runs locally without the daemon. " +
+		"Exits with an error when verification fails. Bundles without a manifest are only listed.",
+	Args: cobra.ExactArgs(1),
+	RunE: debugVerify,
+}
+
+func init() {
+	debugCmd.AddCommand(debugVerifyCmd)
+}
+
+func debugVerify(cmd *cobra.Command, args []string) error {
+	result, err := debug.VerifyBundle(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to verify bundle: %w", err)
+	}
+
+	if !result.HasManifest {
+		cmd.Println("No manifest.json found, the bundle was created by an older version and cannot be verified.")
+		cmd.Println("Contents:")
+		for _, name := range result.Files {
+			cmd.Printf("  %s\n", name)
+		}
+		return nil
+	}
+
+	cmd.Printf("Generated at:    %s\n", result.GeneratedAt.Format(time.RFC3339))
+	cmd.Printf("Daemon version:  %s\n", valueOrUnknown(result.DaemonVersion))
+	cmd.Printf("CLI version:     %s\n", valueOrUnknown(result.CliVersion))
+	if result.AnonymizeLevel != "" {
+		cmd.Printf("Anonymized:      %t (level %s)\n", result.Anonymized, result.AnonymizeLevel)
+	} else {
+		cmd.Printf("Anonymized:      %t\n", result.Anonymized)
+	}
+	cmd.Printf("Files:           %d\n", len(result.Files))
+
+	printFileList(cmd, "Missing files", result.Missing)
+	printFileList(cmd, "Modified files", result.Mismatched)
+	printFileList(cmd, "Files not in the manifest", result.Unlisted)
+
+	if !result.OK() {
+		return errors.New("verification failed: " + verifySummary(result))
+	}
+	cmd.Println("Verification succeeded")
+	return nil
+}
+
+func printFileList(cmd *cobra.Command, title string, files []string) {
+	if len(files) == 0 {
+		return
+	}
+	cmd.Printf("%s:\n", title)
+	for _, name := range files {
+		cmd.Printf("  %s\n", name)
+	}
+}
+
+func verifySummary(result *debug.BundleVerification) string {
+	var parts []string
+	if n := len(result.Missing); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d missing", n))
+	}
+	if n := len(result.Mismatched); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d modified", n))
+	}
+	if n := len(result.Unlisted); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d not in the manifest", n))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func valueOrUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
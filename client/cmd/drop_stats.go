@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
+
+	"github.com/netbirdio/netbird/client/internal/debug"
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+var dropStatsCmd = &cobra.Command{
+	Use:     "drop-stats",
+	Example: "  netbird debug drop-stats",
+	Short:   "Show packet drop counters by reason",
+	Long:    "Shows how many inbound packets the userspace firewall dropped and why (malformed, ACL denied, routing disabled, fragments, forwarder unavailable), including each reason's share of all inbound packets. Counters start when the engine starts.",
+	Args:    cobra.NoArgs,
+	RunE:    dropStats,
+}
+
+func init() {
+	debugCmd.AddCommand(dropStatsCmd)
+}
+
+func dropStats(cmd *cobra.Command, _ []string) error {
+	conn, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			log.Errorf(errCloseConnection, err)
+		}
+	}()
+
+	client := proto.NewDaemonServiceClient(conn)
+	resp, err := client.GetDropStats(cmd.Context(), &proto.GetDropStatsRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to get drop stats: %v", status.Convert(err).Message())
+	}
+
+	drops := make([]debug.DropCount, 0, len(resp.GetDrops()))
+	for _, d := range resp.GetDrops() {
+		drops = append(drops, debug.DropCount{
+			Reason:  d.GetReason(),
+			Packets: d.GetPackets(),
+		})
+	}
+
+	cmd.Print(debug.FormatDropStats(resp.GetInboundPackets(), drops))
+	return nil
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
+
+	"github.com/netbirdio/netbird/client/internal/debug"
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+var listFeaturesCmd = &cobra.Command{
+	Use:     "list-features",
+	Example: "  netbird debug list-features",
+	Short:   "List feature flags and their current state",
+	Long:    "Lists the daemon's feature flags, whether each one is enabled and where the value comes from (build default, env, config or management).",
+	Args:    cobra.NoArgs,
+	RunE:    listFeatures,
+}
+
+func init() {
+	debugCmd.AddCommand(listFeaturesCmd)
+}
+
+func listFeatures(cmd *cobra.Command, _ []string) error {
+	conn, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			log.Errorf(errCloseConnection, err)
+		}
+	}()
+
+	client := proto.NewDaemonServiceClient(conn)
+	resp, err := client.ListFeatureFlags(cmd.Context(), &proto.ListFeatureFlagsRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to list feature flags: %v", status.Convert(err).Message())
+	}
+
+	cmd.Print(debug.FormatFeatureFlags(featureFlagsFromProto(resp.GetFlags())))
+	return nil
+}
+
+func featureFlagsFromProto(protoFlags []*proto.FeatureFlag) []debug.FeatureFlag {
+	flags := make([]debug.FeatureFlag, 0, len(protoFlags))
+	for _, f := range protoFlags {
+		flags = append(flags, debug.FeatureFlag{
+			Name:         f.GetName(),
+			Enabled:      f.GetEnabled(),
+			Source:       debug.FeatureSource(f.GetSource()),
+			Detail:       f.GetDetail(),
+			Experimental: f.GetExperimental(),
+		})
+	}
+	return flags
+}
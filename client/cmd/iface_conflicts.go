@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"net/netip"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
+
+	"github.com/netbirdio/netbird/client/internal/debug"
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+var interfaceConflictsCmd = &cobra.Command{
+	Use:     "interface-conflicts",
+	Example: "  netbird debug interface-conflicts",
+	Short:   "Find host subnets overlapping NetBird networks",
+	Long: "Compares the NetBird overlay network and the routes received from peers against the subnets of the host interfaces and lists every overlap. " +
+		"An overlap, e.g. a LAN in 100.64.0.0/10 next to the NetBird network, makes traffic leave through the wrong interface.",
+	Args: cobra.NoArgs,
+	RunE: interfaceConflicts,
+}
+
+func init() {
+	debugCmd.AddCommand(interfaceConflictsCmd)
+}
+
+func interfaceConflicts(cmd *cobra.Command, _ []string) error {
+	conn, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			log.Errorf(errCloseConnection, err)
+		}
+	}()
+
+	client := proto.NewDaemonServiceClient(conn)
+	resp, err := client.GetInterfaceConflicts(cmd.Context(), &proto.GetInterfaceConflictsRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to get interface conflicts: %v", status.Convert(err).Message())
+	}
+
+	report := debug.InterfaceConflictReport{
+		RoutesChecked:     int(resp.GetRoutesChecked()),
+		InterfacesChecked: int(resp.GetInterfacesChecked()),
+	}
+	for _, overlay := range resp.GetOverlay() {
+		if prefix, err := netip.ParsePrefix(overlay); err == nil {
+			report.Overlay = append(report.Overlay, prefix)
+		}
+	}
+	for _, c := range resp.GetConflicts() {
+		network, err := netip.ParsePrefix(c.GetNetwork())
+		if err != nil {
+			return fmt.Errorf("parse network %q: %w", c.GetNetwork(), err)
+		}
+		host, err := netip.ParsePrefix(c.GetHost())
+		if err != nil {
+			return fmt.Errorf("parse host address %q: %w", c.GetHost(), err)
+		}
+		report.Conflicts = append(report.Conflicts, debug.InterfaceConflict{
+			Kind:      c.GetKind(),
+			Network:   network,
+			Interface: c.GetInterface(),
+			Host:      host,
+		})
+	}
+
+	cmd.Print(debug.FormatInterfaceConflicts(report))
+	return nil
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
+
+	"github.com/netbirdio/netbird/client/internal/debug"
+	"github.com/netbirdio/netbird/client/internal/mtuprobe"
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+var (
+	peerMTUProbeAll         bool
+	peerMTUProbeJSON        bool
+	peerMTUProbeParallelism uint32
+	peerMTUProbeThreshold   int
+)
+
+var peerMTUProbeCmd = &cobra.Command{
+	Use:   "peer-mtu-probe [peer...]",
+	Short: "Discover the path MTU to peers",
+	Long: "Sends ICMP echo requests of increasing size through the tunnel to find the largest packet that reaches each peer. " +
+		"Peers are given by FQDN, hostname label or overlay IP, or use --all to probe every connected peer. " +
+		"Peers whose path MTU is below the threshold (the tunnel MTU by default) are flagged, they usually point at a link that drops large packets. " +
+		"Peers must allow ICMP from this peer to be probed.",
+	Example: "  netbird debug peer-mtu-probe peer-a.netbird.cloud\n  netbird debug peer-mtu-probe --all --json",
+	RunE:    peerMTUProbe,
+}
+
+func init() {
+	peerMTUProbeCmd.Flags().BoolVar(&peerMTUProbeAll, "all", false, "Probe all connected peers")
+	peerMTUProbeCmd.Flags().BoolVar(&peerMTUProbeJSON, "json", false, "Print the results as JSON")
+	peerMTUProbeCmd.Flags().Uint32Var(&peerMTUProbeParallelism, "parallel", mtuprobe.DefaultParallelism, "Maximum number of peers probed at once")
+	peerMTUProbeCmd.Flags().IntVar(&peerMTUProbeThreshold, "threshold", 0, "Flag peers with a path MTU below this size (default: the tunnel MTU)")
+	debugCmd.AddCommand(peerMTUProbeCmd)
+}
+
+type peerMTUOutput struct {
+	TunnelMTU int                 `json:"tunnel_mtu"`
+	Threshold int                 `json:"threshold"`
+	Peers     []peerMTUOutputPeer `json:"peers"`
+}
+
+type peerMTUOutputPeer struct {
+	debug.PeerMTU
+	BelowThreshold bool `json:"below_threshold"`
+}
+
+func peerMTUProbe(cmd *cobra.Command, args []string) error {
+	if !peerMTUProbeAll && len(args) == 0 {
+		return errors.New("no peers given, pass peers or use --all")
+	}
+	if peerMTUProbeAll && len(args) > 0 {
+		return errors.New("--all cannot be combined with peer arguments")
+	}
+
+	conn, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			log.Errorf(errCloseConnection, err)
+		}
+	}()
+
+	client := proto.NewDaemonServiceClient(conn)
+	resp, err := client.ProbePeerMTU(cmd.Context(), &proto.ProbePeerMTURequest{
+		Peers:       args,
+		All:         peerMTUProbeAll,
+		Parallelism: peerMTUProbeParallelism,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to probe peer MTU: %v", status.Convert(err).Message())
+	}
+
+	report := debug.PeerMTUReport{TunnelMTU: int(resp.GetTunnelMtu())}
+	for _, r := range resp.GetResults() {
+		report.Peers = append(report.Peers, debug.PeerMTU{
+			Peer:    r.GetPeer(),
+			IP:      r.GetIp(),
+			PathMTU: int(r.GetPathMtu()),
+			Probes:  int(r.GetProbes()),
+			Error:   r.GetError(),
+		})
+	}
+
+	if !peerMTUProbeJSON {
+		cmd.Print(debug.FormatPeerMTU(report, peerMTUProbeThreshold))
+		return nil
+	}
+
+	threshold := peerMTUProbeThreshold
+	if threshold <= 0 {
+		threshold = report.TunnelMTU
+	}
+	out := peerMTUOutput{
+		TunnelMTU: report.TunnelMTU,
+		Threshold: threshold,
+		Peers:     make([]peerMTUOutputPeer, 0, len(report.Peers)),
+	}
+	for _, p := range report.Peers {
+		out.Peers = append(out.Peers, peerMTUOutputPeer{PeerMTU: p, BelowThreshold: p.BelowThreshold(threshold)})
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal results: %w", err)
+	}
+	cmd.Println(string(data))
+	return nil
+}
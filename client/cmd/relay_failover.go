@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+var relayFailoverTimeout time.Duration
+
+var relayFailoverTestCmd = &cobra.Command{
+	Use:     "relay-failover-test",
+	Example: "  netbird debug relay-failover-test --timeout 1m",
+	Short:   "Test failover to another relay server",
+	Long: "Temporarily makes the currently selected relay server unreachable for the daemon, waits until it connects to another relay server and then makes the server reachable again. " +
+		"Reports the failover sequence and how long it took. Peer connections relayed through the current server are re-established during the test.",
+	Args: cobra.NoArgs,
+	RunE: relayFailoverTest,
+}
+
+func init() {
+	relayFailoverTestCmd.Flags().DurationVar(&relayFailoverTimeout, "timeout", 2*time.Minute, "Maximum time to wait for the failover")
+	debugCmd.AddCommand(relayFailoverTestCmd)
+}
+
+func relayFailoverTest(cmd *cobra.Command, _ []string) error {
+	conn, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			log.Errorf(errCloseConnection, err)
+		}
+	}()
+
+	client := proto.NewDaemonServiceClient(conn)
+	resp, err := client.RelayFailoverTest(cmd.Context(), &proto.RelayFailoverTestRequest{
+		Timeout: durationpb.New(relayFailoverTimeout),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to run relay failover test: %v", status.Convert(err).Message())
+	}
+
+	cmd.Printf("Relay failover sequence:\n")
+	for _, e := range resp.GetEvents() {
+		cmd.Printf("  +%-10s %s\n", e.GetElapsed().AsDuration().Round(time.Millisecond), e.GetMessage())
+	}
+
+	if resp.GetTo() == "" {
+		return fmt.Errorf("daemon did not fail over from %s within %s", resp.GetFrom(), relayFailoverTimeout)
+	}
+
+	cmd.Printf("\nFailed over from %s to %s in %s\n", resp.GetFrom(), resp.GetTo(), resp.GetDuration().AsDuration().Round(time.Millisecond))
+	return nil
+}
@@ -54,6 +54,8 @@ var (
 	oldDefaultLogFile       string
 	logFiles                []string
 	daemonAddr              string
+	daemonTimeout           = defaultDaemonTimeout
+	connectRetry            = defaultConnectRetry
 	managementURL           string
 	adminURL                string
 	setupKey                string
@@ -70,6 +72,9 @@ var (
 	autoConnectDisabled     bool
 	extraIFaceBlackList     []string
 	anonymizeFlag           bool
+	anonymizeLevelFlag      string
+	anonMapFlag             string
+	anonPatternsFlag        string
 	dnsRouteInterval        time.Duration
 	// lazyConnEnabled is the parse target for the deprecated --enable-lazy-connection
 	// flag. The flag is inert; the value is no longer read (use NB_LAZY_CONN instead).
@@ -87,6 +92,12 @@ var (
 		SilenceUsage: true,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			SetFlagsFromEnvVars(cmd.Root())
+			if _, err := anonymizeLevel(); err != nil {
+				return err
+			}
+			if daemonTimeout <= 0 {
+				return fmt.Errorf("--daemon-timeout must be positive, got %s", daemonTimeout)
+			}
 
 			// Don't resolve for service commands — they create the socket, not connect to it.
 			if !isServiceCmd(cmd) {
@@ -147,6 +158,7 @@ func init() {
 	}
 
 	rootCmd.PersistentFlags().StringVar(&daemonAddr, "daemon-addr", defaultDaemonAddr, "Daemon service address to serve CLI requests [unix|tcp]://[path|host:port]")
+	rootCmd.PersistentFlags().DurationVar(&daemonTimeout, "daemon-timeout", defaultDaemonTimeout, "Time to wait for the connection to the daemon")
 	rootCmd.PersistentFlags().StringVarP(&managementURL, "management-url", "m", "", fmt.Sprintf("Management Service URL [http|https]://[host]:[port] (default \"%s\")", profilemanager.DefaultManagementURL))
 	rootCmd.PersistentFlags().StringVar(&adminURL, "admin-url", "", fmt.Sprintf("Admin Panel URL [http|https]://[host]:[port] (default \"%s\")", profilemanager.DefaultAdminURL))
 	rootCmd.PersistentFlags().StringVarP(&logLevel, "log-level", "l", "info", "sets NetBird log level")
@@ -157,6 +169,9 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&preSharedKey, preSharedKeyFlag, "", "Sets WireGuard PreSharedKey property. If set, then only peers that have the same key can communicate.")
 	rootCmd.PersistentFlags().StringVarP(&hostName, "hostname", "n", "", "Sets a custom hostname for the device")
 	rootCmd.PersistentFlags().BoolVarP(&anonymizeFlag, "anonymize", "A", false, "anonymize IP addresses and non-netbird.io domains in logs and status output")
+	rootCmd.PersistentFlags().StringVar(&anonymizeLevelFlag, "anonymize-level", "", "anonymization level: full (same as --anonymize), ips-only (replace IP addresses, keep domain, peer and interface names) or none. Overrides --anonymize")
+	rootCmd.PersistentFlags().StringVar(&anonMapFlag, "anon-map", "", "file with a mapping of original to anonymized values that is loaded and extended, so values are anonymized the same way across runs. Implies --anonymize. Without it, anonymized values differ on every run")
+	rootCmd.PersistentFlags().StringVar(&anonPatternsFlag, "anon-patterns", "", "file with one regular expression per line, e.g. for project names or internal domain suffixes. Matches are replaced with placeholders like REDACTED-1 in logs and status output. Lines starting with # are ignored. Implies --anonymize")
 	rootCmd.PersistentFlags().StringVarP(&configPath, "config", "c", profilemanager.DefaultConfigPath, "Overrides the default profile file location")
 
 	rootCmd.AddCommand(upCmd)
@@ -180,9 +195,13 @@ func init() {
 	debugCmd.AddCommand(debugBundleCmd)
 	debugCmd.AddCommand(logCmd)
 	logCmd.AddCommand(logLevelCmd)
+	logCmd.AddCommand(logRotateCmd)
+	logCmd.AddCommand(logFormatCmd)
+	logCmd.AddCommand(logTailCmd)
 	debugCmd.AddCommand(forCmd)
 	debugCmd.AddCommand(persistenceCmd)
 	debugCmd.AddCommand(debugConfigCmd)
+	debugConfigCmd.AddCommand(debugConfigDumpCmd)
 
 	// kubernetes commands
 	rootCmd.AddCommand(kubernetesCmd)
@@ -264,9 +283,10 @@ func FlagNameToEnvVar(cmdFlag string, prefix string) string {
 	return prefix + upper
 }
 
-// DialClientGRPCServer returns client connection to the daemon server.
+// DialClientGRPCServer returns client connection to the daemon server. The dial is bounded by
+// --daemon-timeout.
 func DialClientGRPCServer(ctx context.Context, addr string) (*grpc.ClientConn, error) {
-	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	ctx, cancel := context.WithTimeout(ctx, daemonTimeout)
 	defer cancel()
 
 	return grpc.DialContext(
@@ -419,15 +439,18 @@ func migrateToNetbird(oldPath, newPath string) bool {
 	return true
 }
 
+// getClient connects to the daemon. Commands with --connect-retry first wait for a daemon that
+// does not listen yet.
 func getClient(cmd *cobra.Command) (*grpc.ClientConn, error) {
 	cmd.SetOut(cmd.OutOrStdout())
 
+	if retry := connectRetryFor(cmd); retry > 0 {
+		waitForDaemon(cmd, daemonAddr, retry)
+	}
+
 	conn, err := DialClientGRPCServer(cmd.Context(), daemonAddr)
 	if err != nil {
-		//nolint
-		return nil, fmt.Errorf("failed to connect to daemon error: %v\n"+
-			"If the daemon is not running please run: "+
-			"\nnetbird service install \nnetbird service start\n", err)
+		return nil, daemonDialError(daemonAddr, daemonTimeout, err)
 	}
 
 	return conn, nil
@@ -9,6 +9,7 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/kardianos/service"
 	"github.com/spf13/cobra"
@@ -30,6 +31,14 @@ var (
 	serviceEnvVars   []string
 	jsonSocket       string
 	enableJSONSocket bool
+
+	// settings of the debug bundles the daemon creates after repeated reconnect failures
+	autoBundleFailures    int
+	autoBundleWindow      time.Duration
+	autoBundleMinInterval time.Duration
+	autoBundleOutputDir   string
+	autoBundleUploadURL   string
+	autoBundleAnonymize   bool
 )
 
 type program struct {
@@ -55,6 +64,14 @@ func init() {
 	serviceCmd.PersistentFlags().BoolVar(&networksDisabled, "disable-networks", false, "Disables network selection. If enabled, the client will not allow listing, selecting, or deselecting networks. To persist, use: netbird service install --disable-networks")
 	serviceCmd.PersistentFlags().BoolVar(&enableJSONSocket, "enable-json-socket", false, "Enables the HTTP/JSON API socket served by grpc-gateway. To persist, use: netbird service install --enable-json-socket")
 	serviceCmd.PersistentFlags().StringVar(&jsonSocket, "json-socket", defaultJSONSocket, "HTTP/JSON API socket address [unix|tcp]://[path|host:port]. Requires --enable-json-socket to serve. To persist, use: netbird service install --enable-json-socket --json-socket")
+	serviceCmd.PersistentFlags().BoolVar(&enableDebugBundleHTTP, "enable-debug-bundle-http", false, "Serves GET "+debugBundleHTTPPath+" on the HTTP/JSON API socket, streaming a new debug bundle like \"netbird debug bundle\" for agents that speak HTTP. Query parameters mirror the bundle options, e.g. ?anonymize=true&system_info=false. Requires --enable-json-socket and is only served on a unix socket or a loopback address. To persist, use: netbird service install --enable-json-socket --enable-debug-bundle-http")
+
+	serviceCmd.PersistentFlags().IntVar(&autoBundleFailures, "auto-bundle-failures", 0, "Creates a debug bundle when the daemon fails to reconnect this many times in a row within --auto-bundle-window, e.g. on unattended devices whose logs rotate away before someone notices the outage. 0 disables it. To persist, use: netbird service install --auto-bundle-failures")
+	serviceCmd.PersistentFlags().DurationVar(&autoBundleWindow, "auto-bundle-window", server.DefaultAutoBundleWindow, "Time window of the reconnect failures counted by --auto-bundle-failures")
+	serviceCmd.PersistentFlags().DurationVar(&autoBundleMinInterval, "auto-bundle-min-interval", server.DefaultAutoBundleMinInterval, "Least time between two debug bundles created after reconnect failures")
+	serviceCmd.PersistentFlags().StringVar(&autoBundleOutputDir, "auto-bundle-output-dir", "", "Existing directory the debug bundles created after reconnect failures are written to, instead of the daemon's temporary directory")
+	serviceCmd.PersistentFlags().StringVar(&autoBundleUploadURL, "auto-bundle-upload-url", "", "Uploads the debug bundles created after reconnect failures to this URL. The upload server is not checked first")
+	serviceCmd.PersistentFlags().BoolVar(&autoBundleAnonymize, "auto-bundle-anonymize", false, "Anonymizes the debug bundles created after reconnect failures")
 
 	rootCmd.PersistentFlags().StringVarP(&serviceName, "service", "s", defaultServiceName, "Netbird system service name")
 	serviceEnvDesc := `Sets extra environment variables for the service. ` +
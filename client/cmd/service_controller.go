@@ -23,6 +23,9 @@ func validateJSONSocketFlags() error {
 	if serviceCmd.PersistentFlags().Changed("json-socket") && !enableJSONSocket {
 		return fmt.Errorf("--json-socket requires --enable-json-socket to configure the daemon JSON gateway")
 	}
+	if enableDebugBundleHTTP && !enableJSONSocket {
+		return fmt.Errorf("--enable-debug-bundle-http requires --enable-json-socket, the endpoint is served on the daemon JSON socket")
+	}
 	return nil
 }
 
@@ -38,7 +41,7 @@ func (p *program) Start(svc service.Service) error {
 	system.UpdateStaticInfoAsync()
 
 	// in any case, even if configuration does not exists we run daemon to serve CLI gRPC API.
-	p.serv = grpc.NewServer()
+	p.serv = grpc.NewServer(grpc.Creds(server.NewCallerCredentials()))
 
 	daemonListener, err := listenOnAddress(daemonAddr)
 	if err != nil {
@@ -74,6 +77,14 @@ func (p *program) Start(svc service.Service) error {
 		}
 
 		serverInstance := server.New(p.ctx, util.FindFirstLogPath(logFiles), configPath, profilesDisabled, updateSettingsDisabled, captureEnabled, networksDisabled)
+		serverInstance.EnableAutoBundle(server.AutoBundleSettings{
+			Failures:    autoBundleFailures,
+			Window:      autoBundleWindow,
+			MinInterval: autoBundleMinInterval,
+			OutputDir:   autoBundleOutputDir,
+			UploadURL:   autoBundleUploadURL,
+			Anonymize:   autoBundleAnonymize,
+		})
 		if err := serverInstance.Start(); err != nil {
 			log.Fatalf("failed to start daemon: %v", err)
 		}
@@ -0,0 +1,314 @@
+//go:build !ios && !android
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/netbirdio/netbird/client/internal/debug"
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+// debugBundleHTTPPath streams a freshly generated debug bundle from the JSON socket, for agents that
+// speak HTTP but not gRPC. It is served with --enable-debug-bundle-http only.
+const debugBundleHTTPPath = "/debug/bundle"
+
+var enableDebugBundleHTTP bool
+
+// debugBundleQueryParams are the query parameters of GET /debug/bundle. They mirror the fields of
+// DebugBundleRequest, except for the upload, output directory, split, extra file and secret
+// options, which only make sense for the CLI.
+var debugBundleQueryParams = map[string]bool{
+	"anonymize":              true,
+	"anonymize_level":        true,
+	"system_info":            true,
+	"system_info_collectors": true,
+	"log_file_count":         true,
+	"status_format":          true,
+	"max_size":               true,
+	"network_map_count":      true,
+	"since":                  true,
+	"tail":                   true,
+	"peer":                   true,
+	"group":                  true,
+	"domain":                 true,
+	"label":                  true,
+	"compression":            true,
+	"log_include":            true,
+	"log_exclude":            true,
+	"profiles":               true,
+	"profile_cpu_duration":   true,
+	"peer_mtu_probe":         true,
+}
+
+// isLoopback reports whether only local clients can connect to the listener: a unix socket or a
+// TCP socket bound to a loopback address.
+func (l *socketListener) isLoopback() bool {
+	if l.network == "unix" {
+		return true
+	}
+	addr, ok := l.Addr().(*net.TCPAddr)
+	return ok && addr.IP.IsLoopback()
+}
+
+// debugBundleHTTPHosts returns the Host headers GET /debug/bundle accepts: the loopback names with
+// the bound port, or without a port on a unix socket, which has none.
+func (l *socketListener) debugBundleHTTPHosts() []string {
+	names := []string{"localhost", "127.0.0.1", "::1"}
+	hosts := make([]string, 0, len(names))
+	if l.network == "unix" {
+		for _, name := range names {
+			if strings.Contains(name, ":") {
+				name = "[" + name + "]"
+			}
+			hosts = append(hosts, name)
+		}
+		return hosts
+	}
+
+	addr, ok := l.Addr().(*net.TCPAddr)
+	if !ok {
+		return nil
+	}
+	for _, name := range names {
+		hosts = append(hosts, net.JoinHostPort(name, strconv.Itoa(addr.Port)))
+	}
+	return hosts
+}
+
+// registerDebugBundleHTTP adds GET /debug/bundle to the JSON gateway. The endpoint is only served on
+// a loopback listener, it hands out the logs and network details of the peer. Access is controlled
+// like the rest of the JSON socket, by who can connect to it.
+func (p *program) registerDebugBundleHTTP(mux *runtime.ServeMux, jsonListener *socketListener, daemonEndpoint string) error {
+	if !jsonListener.isLoopback() {
+		log.Warnf("not serving %s: the daemon JSON socket %s is not bound to a loopback address", debugBundleHTTPPath, jsonListener.address)
+		return nil
+	}
+
+	conn, err := grpc.NewClient(grpcGatewayEndpoint(daemonEndpoint), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("connect debug bundle endpoint to daemon: %w", err)
+	}
+	go func() {
+		<-p.ctx.Done()
+		if err := conn.Close(); err != nil {
+			log.Debugf("failed to close debug bundle endpoint connection: %v", err)
+		}
+	}()
+
+	handler := debugBundleHTTPHandler(proto.NewDaemonServiceClient(conn), jsonListener.debugBundleHTTPHosts())
+	if err := mux.HandlePath(http.MethodGet, debugBundleHTTPPath, handler); err != nil {
+		return fmt.Errorf("register %s: %w", debugBundleHTTPPath, err)
+	}
+	log.Infof("serving debug bundles at %s on the daemon JSON socket", debugBundleHTTPPath)
+	return nil
+}
+
+// debugBundleHTTPHandler streams the bundle of a DebugBundleStream request as the response body.
+// Errors before the first chunk are returned with the HTTP status of the gRPC code, later errors
+// abort the response so the client does not take a truncated bundle for a complete one.
+//
+// Requests from a browser are refused: a page that rebinds its domain to 127.0.0.1 sends its own
+// Host, and any cross-origin request carries an Origin header.
+func debugBundleHTTPHandler(client proto.DaemonServiceClient, hosts []string) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		if _, ok := r.Header["Origin"]; ok {
+			http.Error(w, "cross-origin requests are not allowed", http.StatusForbidden)
+			return
+		}
+		if !slices.Contains(hosts, strings.ToLower(r.Host)) {
+			http.Error(w, fmt.Sprintf("host %q is not allowed, use localhost", r.Host), http.StatusForbidden)
+			return
+		}
+
+		request, err := debugBundleRequestFromQuery(r.URL.Query())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		stream, err := client.DebugBundleStream(r.Context(), request)
+		if err != nil {
+			writeGRPCError(w, err)
+			return
+		}
+		chunk, err := stream.Recv()
+		if err != nil {
+			writeGRPCError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/zip")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", debugBundleHTTPFileName(request.GetCompression())))
+		w.WriteHeader(http.StatusOK)
+
+		var written int64
+		for {
+			n, err := w.Write(chunk.GetData())
+			written += int64(n)
+			if err != nil {
+				log.Debugf("debug bundle client went away after %d bytes: %v", written, err)
+				return
+			}
+			chunk, err = stream.Recv()
+			if errors.Is(err, io.EOF) {
+				log.Infof("streamed debug bundle over HTTP (%d bytes)", written)
+				return
+			}
+			if err != nil {
+				log.Errorf("failed to stream debug bundle over HTTP after %d bytes: %v", written, err)
+				panic(http.ErrAbortHandler)
+			}
+		}
+	}
+}
+
+func writeGRPCError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	http.Error(w, st.Message(), runtime.HTTPStatusFromCode(st.Code()))
+}
+
+func debugBundleHTTPFileName(compression string) string {
+	if c, err := debug.ParseCompression(compression); err == nil && c == debug.CompressionZstd {
+		return "netbird.debug.zst.zip"
+	}
+	return "netbird.debug.zip"
+}
+
+// debugBundleRequestFromQuery maps the query parameters of GET /debug/bundle to a bundle request.
+// Unknown parameters are rejected, so a misspelled option does not silently fall back to its default.
+// Repeated options like peer can be given several times or comma separated. The daemon validates the
+// values like those of the CLI.
+func debugBundleRequestFromQuery(query url.Values) (*proto.DebugBundleRequest, error) {
+	for name := range query {
+		if !debugBundleQueryParams[name] {
+			return nil, fmt.Errorf("unknown query parameter %q", name)
+		}
+	}
+
+	request := &proto.DebugBundleRequest{
+		AnonymizeLevel:       query.Get("anonymize_level"),
+		StatusFormat:         query.Get("status_format"),
+		PeerGroup:            query.Get("group"),
+		Domain:               query.Get("domain"),
+		Label:                query.Get("label"),
+		Compression:          query.Get("compression"),
+		Peers:                queryList(query, "peer"),
+		SystemInfoCollectors: queryList(query, "system_info_collectors"),
+		LogInclude:           query["log_include"],
+		LogExclude:           query["log_exclude"],
+	}
+
+	var err error
+	if request.Anonymize, err = queryBool(query, "anonymize", false); err != nil {
+		return nil, err
+	}
+	if request.SystemInfo, err = queryBool(query, "system_info", true); err != nil {
+		return nil, err
+	}
+	if request.Profiles, err = queryBool(query, "profiles", false); err != nil {
+		return nil, err
+	}
+	if request.PeerMtuProbe, err = queryBool(query, "peer_mtu_probe", false); err != nil {
+		return nil, err
+	}
+	if request.LogFileCount, err = queryUint32(query, "log_file_count", 1); err != nil {
+		return nil, err
+	}
+	if request.NetworkMapCount, err = queryUint32(query, "network_map_count", 1); err != nil {
+		return nil, err
+	}
+	if request.LogTail, err = queryUint32(query, "tail", 0); err != nil {
+		return nil, err
+	}
+	if value := query.Get("max_size"); value != "" {
+		size, err := debug.ParseSize(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max_size: %w", err)
+		}
+		request.MaxSize = uint64(size)
+	}
+	since, err := queryDuration(query, "since")
+	if err != nil {
+		return nil, err
+	}
+	if since > 0 {
+		request.Since = durationpb.New(since)
+	}
+	cpuDuration, err := queryDuration(query, "profile_cpu_duration")
+	if err != nil {
+		return nil, err
+	}
+	if request.Profiles {
+		if cpuDuration == 0 {
+			cpuDuration = debug.DefaultCPUProfileDuration
+		}
+		request.ProfileCpuDuration = durationpb.New(cpuDuration)
+	}
+	return request, nil
+}
+
+// queryList returns the values of a repeated parameter, split at commas.
+func queryList(query url.Values, name string) []string {
+	var values []string
+	for _, value := range query[name] {
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
+}
+
+func queryBool(query url.Values, name string, def bool) (bool, error) {
+	value := query.Get(name)
+	if value == "" {
+		return def, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s %q: must be true or false", name, value)
+	}
+	return b, nil
+}
+
+func queryUint32(query url.Values, name string, def uint32) (uint32, error) {
+	value := query.Get(name)
+	if value == "" {
+		return def, nil
+	}
+	n, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: must be a number", name, value)
+	}
+	return uint32(n), nil
+}
+
+func queryDuration(query url.Values, name string) (time.Duration, error) {
+	value := query.Get(name)
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid %s %q: must be a positive duration like 15m", name, value)
+	}
+	return d, nil
+}
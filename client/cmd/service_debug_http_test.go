@@ -0,0 +1,167 @@
+//go:build !ios && !android
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+type fakeBundleStreamClient struct {
+	proto.DaemonServiceClient
+	request *proto.DebugBundleRequest
+	chunks  []string
+	err     error
+}
+
+func (c *fakeBundleStreamClient) DebugBundleStream(_ context.Context, in *proto.DebugBundleRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[proto.DebugBundleChunk], error) {
+	c.request = in
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &fakeBundleStream{chunks: c.chunks}, nil
+}
+
+type fakeBundleStream struct {
+	grpc.ClientStream
+	chunks []string
+}
+
+func (s *fakeBundleStream) Recv() (*proto.DebugBundleChunk, error) {
+	if len(s.chunks) == 0 {
+		return nil, io.EOF
+	}
+	chunk := &proto.DebugBundleChunk{Data: []byte(s.chunks[0])}
+	s.chunks = s.chunks[1:]
+	return chunk, nil
+}
+
+func TestDebugBundleRequestFromQuery(t *testing.T) {
+	query, err := url.ParseQuery("anonymize=true&system_info=false&peer=peer-a,peer-b&peer=100.64.0.5&since=15m&max_size=10MB&log_file_count=3")
+	require.NoError(t, err)
+
+	request, err := debugBundleRequestFromQuery(query)
+	require.NoError(t, err)
+	assert.True(t, request.GetAnonymize())
+	assert.False(t, request.GetSystemInfo())
+	assert.Equal(t, []string{"peer-a", "peer-b", "100.64.0.5"}, request.GetPeers())
+	assert.Equal(t, 15*time.Minute, request.GetSince().AsDuration())
+	assert.Equal(t, uint64(10<<20), request.GetMaxSize())
+	assert.Equal(t, uint32(3), request.GetLogFileCount())
+
+	request, err = debugBundleRequestFromQuery(url.Values{})
+	require.NoError(t, err)
+	assert.False(t, request.GetAnonymize())
+	assert.True(t, request.GetSystemInfo(), "system info is included by default like in the CLI")
+	assert.Equal(t, uint32(1), request.GetLogFileCount())
+
+	_, err = debugBundleRequestFromQuery(url.Values{"anonymise": {"true"}})
+	assert.ErrorContains(t, err, "unknown query parameter")
+	_, err = debugBundleRequestFromQuery(url.Values{"anonymize": {"yes please"}})
+	assert.ErrorContains(t, err, "anonymize")
+	_, err = debugBundleRequestFromQuery(url.Values{"since": {"-5m"}})
+	assert.ErrorContains(t, err, "since")
+}
+
+func TestDebugBundleHTTPHandler(t *testing.T) {
+	hosts := []string{"localhost"}
+	client := &fakeBundleStreamClient{chunks: []string{"PK", "bundle"}}
+	rec := httptest.NewRecorder()
+	debugBundleHTTPHandler(client, hosts)(rec, httptest.NewRequest(http.MethodGet, "http://localhost/debug/bundle?anonymize=true", nil), nil)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/zip", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "PKbundle", rec.Body.String())
+	assert.True(t, client.request.GetAnonymize())
+
+	client = &fakeBundleStreamClient{err: status.Error(codes.InvalidArgument, "unknown system information collector")}
+	rec = httptest.NewRecorder()
+	debugBundleHTTPHandler(client, hosts)(rec, httptest.NewRequest(http.MethodGet, "http://localhost/debug/bundle", nil), nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "unknown system information collector")
+
+	rec = httptest.NewRecorder()
+	debugBundleHTTPHandler(client, hosts)(rec, httptest.NewRequest(http.MethodGet, "http://localhost/debug/bundle?upload=true", nil), nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestDebugBundleHTTPHandlerRefusesBrowsers(t *testing.T) {
+	client := &fakeBundleStreamClient{chunks: []string{"PK"}}
+	handler := debugBundleHTTPHandler(client, []string{"localhost:8080", "127.0.0.1:8080", "[::1]:8080"})
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "http://attacker.example.com:8080/debug/bundle", nil), nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code, "a rebound domain keeps its Host")
+
+	rec = httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "http://localhost:9090/debug/bundle", nil), nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code, "only the bound port")
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost:8080/debug/bundle", nil)
+	req.Header.Set("Origin", "null")
+	rec = httptest.NewRecorder()
+	handler(rec, req, nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code, "cross-origin requests are refused")
+	assert.Nil(t, client.request, "refused requests don't create a bundle")
+
+	rec = httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "http://127.0.0.1:8080/debug/bundle", nil), nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestDebugBundleHTTPHosts(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	assert.Equal(t, []string{
+		fmt.Sprintf("localhost:%d", port),
+		fmt.Sprintf("127.0.0.1:%d", port),
+		fmt.Sprintf("[::1]:%d", port),
+	}, (&socketListener{Listener: listener, network: "tcp"}).debugBundleHTTPHosts())
+	assert.Equal(t, []string{"localhost", "127.0.0.1", "[::1]"}, (&socketListener{network: "unix"}).debugBundleHTTPHosts())
+}
+
+func TestSocketListenerIsLoopback(t *testing.T) {
+	loopback, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer loopback.Close()
+	assert.True(t, (&socketListener{Listener: loopback, network: "tcp"}).isLoopback())
+
+	wildcard, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	defer wildcard.Close()
+	assert.False(t, (&socketListener{Listener: wildcard, network: "tcp"}).isLoopback())
+
+	assert.True(t, (&socketListener{network: "unix"}).isLoopback())
+}
+
+func TestDebugBundleHTTPRequiresJSONSocket(t *testing.T) {
+	preserveJSONSocketTestState(t)
+	origEnableDebugBundleHTTP := enableDebugBundleHTTP
+	t.Cleanup(func() { enableDebugBundleHTTP = origEnableDebugBundleHTTP })
+
+	enableJSONSocket = false
+	enableDebugBundleHTTP = true
+	assert.ErrorContains(t, validateJSONSocketFlags(), "--enable-json-socket")
+
+	enableJSONSocket = true
+	assert.NoError(t, validateJSONSocketFlags())
+	assert.Contains(t, buildServiceArguments(), "--enable-debug-bundle-http")
+}
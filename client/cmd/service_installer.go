@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 
 	log "github.com/sirupsen/logrus"
 
@@ -71,6 +72,27 @@ func buildServiceArguments() []string {
 		args = append(args, "--enable-json-socket", "--json-socket", jsonSocket)
 	}
 
+	if enableDebugBundleHTTP {
+		args = append(args, "--enable-debug-bundle-http")
+	}
+
+	if autoBundleFailures > 0 {
+		args = append(args,
+			"--auto-bundle-failures", strconv.Itoa(autoBundleFailures),
+			"--auto-bundle-window", autoBundleWindow.String(),
+			"--auto-bundle-min-interval", autoBundleMinInterval.String(),
+		)
+		if autoBundleOutputDir != "" {
+			args = append(args, "--auto-bundle-output-dir", autoBundleOutputDir)
+		}
+		if autoBundleUploadURL != "" {
+			args = append(args, "--auto-bundle-upload-url", autoBundleUploadURL)
+		}
+		if autoBundleAnonymize {
+			args = append(args, "--auto-bundle-anonymize")
+		}
+	}
+
 	return args
 }
 
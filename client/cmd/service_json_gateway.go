@@ -28,6 +28,11 @@ func (p *program) startJSONGateway(jsonListener *socketListener, daemonEndpoint
 	if err := proto.RegisterDaemonServiceHandlerFromEndpoint(p.ctx, mux, grpcGatewayEndpoint(daemonEndpoint), opts); err != nil {
 		return err
 	}
+	if enableDebugBundleHTTP {
+		if err := p.registerDebugBundleHTTP(mux, jsonListener, daemonEndpoint); err != nil {
+			return err
+		}
+	}
 
 	jsonServer := &http.Server{
 		Handler:           mux,
@@ -9,6 +9,7 @@ import (
 	"maps"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -32,6 +33,13 @@ type serviceParams struct {
 	EnableCapture         bool              `json:"enable_capture,omitempty"`
 	DisableNetworks       bool              `json:"disable_networks,omitempty"`
 	EnableJSONSocket      bool              `json:"enable_json_socket,omitempty"`
+	EnableDebugBundleHTTP bool              `json:"enable_debug_bundle_http,omitempty"`
+	AutoBundleFailures    int               `json:"auto_bundle_failures,omitempty"`
+	AutoBundleWindow      time.Duration     `json:"auto_bundle_window,omitempty"`
+	AutoBundleMinInterval time.Duration     `json:"auto_bundle_min_interval,omitempty"`
+	AutoBundleOutputDir   string            `json:"auto_bundle_output_dir,omitempty"`
+	AutoBundleUploadURL   string            `json:"auto_bundle_upload_url,omitempty"`
+	AutoBundleAnonymize   bool              `json:"auto_bundle_anonymize,omitempty"`
 	ServiceEnvVars        map[string]string `json:"service_env_vars,omitempty"`
 }
 
@@ -86,6 +94,13 @@ func currentServiceParams() *serviceParams {
 		EnableCapture:         captureEnabled,
 		DisableNetworks:       networksDisabled,
 		EnableJSONSocket:      enableJSONSocket,
+		EnableDebugBundleHTTP: enableDebugBundleHTTP,
+		AutoBundleFailures:    autoBundleFailures,
+		AutoBundleWindow:      autoBundleWindow,
+		AutoBundleMinInterval: autoBundleMinInterval,
+		AutoBundleOutputDir:   autoBundleOutputDir,
+		AutoBundleUploadURL:   autoBundleUploadURL,
+		AutoBundleAnonymize:   autoBundleAnonymize,
 	}
 
 	if len(serviceEnvVars) > 0 {
@@ -135,6 +150,10 @@ func applyServiceParams(cmd *cobra.Command, params *serviceParams) {
 		enableJSONSocket = params.EnableJSONSocket
 	}
 
+	if !serviceCmd.PersistentFlags().Changed("enable-debug-bundle-http") {
+		enableDebugBundleHTTP = params.EnableDebugBundleHTTP
+	}
+
 	// For optional fields where empty means "use default", always apply so
 	// that an explicit clear (--management-url "") persists across reinstalls.
 	if !rootCmd.PersistentFlags().Changed("management-url") {
@@ -165,9 +184,35 @@ func applyServiceParams(cmd *cobra.Command, params *serviceParams) {
 		networksDisabled = params.DisableNetworks
 	}
 
+	applyAutoBundleParams(params)
+
 	applyServiceEnvParams(cmd, params)
 }
 
+// applyAutoBundleParams merges the saved settings of the debug bundles created after reconnect
+// failures. Unset durations of an older service.json keep the defaults.
+func applyAutoBundleParams(params *serviceParams) {
+	flags := serviceCmd.PersistentFlags()
+	if !flags.Changed("auto-bundle-failures") {
+		autoBundleFailures = params.AutoBundleFailures
+	}
+	if !flags.Changed("auto-bundle-window") && params.AutoBundleWindow != 0 {
+		autoBundleWindow = params.AutoBundleWindow
+	}
+	if !flags.Changed("auto-bundle-min-interval") && params.AutoBundleMinInterval != 0 {
+		autoBundleMinInterval = params.AutoBundleMinInterval
+	}
+	if !flags.Changed("auto-bundle-output-dir") {
+		autoBundleOutputDir = params.AutoBundleOutputDir
+	}
+	if !flags.Changed("auto-bundle-upload-url") {
+		autoBundleUploadURL = params.AutoBundleUploadURL
+	}
+	if !flags.Changed("auto-bundle-anonymize") {
+		autoBundleAnonymize = params.AutoBundleAnonymize
+	}
+}
+
 // applyServiceEnvParams merges saved service environment variables.
 // If --service-env was explicitly set with values, explicit values win on key
 // conflict but saved keys not in the explicit set are carried over.
@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -391,6 +392,28 @@ func TestCurrentServiceParams_EmptyEnvVarsAfterParse(t *testing.T) {
 // TestServiceParams_FieldsCoveredInFunctions ensures that all serviceParams fields are
 // referenced in both currentServiceParams() and applyServiceParams(). If a new field is
 // added to serviceParams but not wired into these functions, this test fails.
+func TestBuildServiceArgumentsAutoBundle(t *testing.T) {
+	origFailures, origWindow, origMinInterval := autoBundleFailures, autoBundleWindow, autoBundleMinInterval
+	origOutputDir, origUploadURL, origAnonymize := autoBundleOutputDir, autoBundleUploadURL, autoBundleAnonymize
+	t.Cleanup(func() {
+		autoBundleFailures, autoBundleWindow, autoBundleMinInterval = origFailures, origWindow, origMinInterval
+		autoBundleOutputDir, autoBundleUploadURL, autoBundleAnonymize = origOutputDir, origUploadURL, origAnonymize
+	})
+
+	autoBundleFailures = 0
+	assert.NotContains(t, buildServiceArguments(), "--auto-bundle-failures", "disabled by default")
+
+	autoBundleFailures = 5
+	autoBundleWindow = 15 * time.Minute
+	autoBundleMinInterval = time.Hour
+	autoBundleOutputDir = ""
+	autoBundleUploadURL = "https://upload.example.com"
+	autoBundleAnonymize = true
+	args := strings.Join(buildServiceArguments(), " ")
+	assert.Contains(t, args, "--auto-bundle-failures 5 --auto-bundle-window 15m0s --auto-bundle-min-interval 1h0m0s --auto-bundle-upload-url https://upload.example.com --auto-bundle-anonymize")
+	assert.NotContains(t, args, "--auto-bundle-output-dir")
+}
+
 func TestServiceParams_FieldsCoveredInFunctions(t *testing.T) {
 	fset := token.NewFileSet()
 	file, err := parser.ParseFile(fset, "service_params.go", nil, 0)
@@ -403,10 +426,12 @@ func TestServiceParams_FieldsCoveredInFunctions(t *testing.T) {
 	// Collect field names referenced in currentServiceParams and applyServiceParams.
 	currentFields := extractFuncFieldRefs(t, file, "currentServiceParams", structFields)
 	applyFields := extractFuncFieldRefs(t, file, "applyServiceParams", structFields)
-	// applyServiceEnvParams handles ServiceEnvVars indirectly.
-	applyEnvFields := extractFuncFieldRefs(t, file, "applyServiceEnvParams", structFields)
-	for k, v := range applyEnvFields {
-		applyFields[k] = v
+	// applyServiceEnvParams handles ServiceEnvVars indirectly, applyAutoBundleParams the
+	// auto bundle settings.
+	for _, name := range []string{"applyServiceEnvParams", "applyAutoBundleParams"} {
+		for k, v := range extractFuncFieldRefs(t, file, name, structFields) {
+			applyFields[k] = v
+		}
 	}
 
 	for _, field := range structFields {
@@ -460,6 +485,7 @@ func TestServiceParams_BuildArgsCoversAllFlags(t *testing.T) {
 	nonParamGlobals := map[string]bool{
 		"args": true, "append": true, "string": true, "_": true,
 		"logFile": true, // range variable over logFiles
+		"strconv": true, "Itoa": true, "String": true,
 	}
 	for ref := range buildFields {
 		if nonParamGlobals[ref] {
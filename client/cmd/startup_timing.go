@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
+
+	"github.com/netbirdio/netbird/client/internal/debug"
+	"github.com/netbirdio/netbird/client/internal/startuptiming"
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+var startupTimingCmd = &cobra.Command{
+	Use:     "startup-timing",
+	Example: "  netbird debug startup-timing",
+	Short:   "Show how long each startup phase took",
+	Long:    "Shows the duration of each phase of the latest client startup (config load, management connect, login and sync, signal and relay connect, interface creation, firewall, route install) and names the slowest phase. Failed connect attempts are listed with their error.",
+	Args:    cobra.NoArgs,
+	RunE:    startupTiming,
+}
+
+func init() {
+	debugCmd.AddCommand(startupTimingCmd)
+}
+
+func startupTiming(cmd *cobra.Command, _ []string) error {
+	conn, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			log.Errorf(errCloseConnection, err)
+		}
+	}()
+
+	client := proto.NewDaemonServiceClient(conn)
+	resp, err := client.GetStartupTiming(cmd.Context(), &proto.GetStartupTimingRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to get startup timing: %v", status.Convert(err).Message())
+	}
+
+	snapshot := startuptiming.Snapshot{
+		Complete: resp.GetComplete(),
+		Total:    resp.GetTotal().AsDuration(),
+	}
+	if resp.GetStartedAt() != nil {
+		snapshot.StartedAt = resp.GetStartedAt().AsTime()
+	}
+	for _, p := range resp.GetPhases() {
+		snapshot.Phases = append(snapshot.Phases, startuptiming.Phase{
+			Name:     p.GetName(),
+			Attempt:  int(p.GetAttempt()),
+			Started:  p.GetStarted().AsTime(),
+			Duration: p.GetDuration().AsDuration(),
+			Err:      p.GetError(),
+		})
+	}
+
+	cmd.Print(debug.FormatStartupTiming(snapshot))
+	return nil
+}
@@ -29,7 +29,9 @@ var (
 	ipsFilterMap         map[string]struct{}
 	prefixNamesFilterMap map[string]struct{}
 	connectionTypeFilter string
+	groupFilter          string
 	checkFlag            string
+	staleThresholdFlag   time.Duration
 )
 
 var statusCmd = &cobra.Command{
@@ -52,7 +54,9 @@ func init() {
 	statusCmd.PersistentFlags().StringSliceVarP(&prefixNamesFilter, "filter-by-names", "N", []string{}, "filters the detailed output by a list of one or more peer FQDN or hostnames, e.g., --filter-by-names peer-a,peer-b.netbird.cloud")
 	statusCmd.PersistentFlags().StringVarP(&statusFilter, "filter-by-status", "S", "", "filters the detailed output by connection status(idle|connecting|connected), e.g., --filter-by-status connected")
 	statusCmd.PersistentFlags().StringVarP(&connectionTypeFilter, "filter-by-connection-type", "T", "", "filters the detailed output by connection type (P2P|Relayed), e.g., --filter-by-connection-type P2P")
+	statusCmd.PersistentFlags().StringVar(&groupFilter, "group", "", "limits the output to the peers in the group with this ID. Peer groups are only known when management sends the component network map")
 	statusCmd.PersistentFlags().StringVarP(&checkFlag, "check", "C", "", "run a health check and exit with code 0 on success, 1 on failure (live|ready|startup)")
+	statusCmd.PersistentFlags().DurationVar(&staleThresholdFlag, "stale-threshold", nbstatus.DefaultStaleHandshakeThreshold, "marks connected peers without a WireGuard handshake for longer than this as stale")
 }
 
 func statusFunc(cmd *cobra.Command, args []string) error {
@@ -121,8 +125,19 @@ func statusFunc(cmd *cobra.Command, args []string) error {
 		sessionExpiresAt = ts.AsTime().UTC()
 	}
 
+	anonymizer, err := loadAnonMap()
+	if err != nil {
+		return err
+	}
+	level, err := anonymizeLevel()
+	if err != nil {
+		return err
+	}
+
 	var outputInformationHolder = nbstatus.ConvertToStatusOutputOverview(resp.GetFullStatus(), nbstatus.ConvertOptions{
-		Anonymize:            anonymizeFlag,
+		Anonymize:            shouldAnonymize(),
+		Anonymizer:           anonymizer,
+		AnonymizeLevel:       level,
 		DaemonVersion:        resp.GetDaemonVersion(),
 		DaemonStatus:         nbstatus.ParseDaemonStatus(status),
 		StatusFilter:         statusFilter,
@@ -130,9 +145,17 @@ func statusFunc(cmd *cobra.Command, args []string) error {
 		PrefixNamesFilterMap: prefixNamesFilterMap,
 		IPsFilter:            ipsFilterMap,
 		ConnectionTypeFilter: connectionTypeFilter,
+		GroupFilter:          groupFilter,
 		ProfileName:          profName,
 		SessionExpiresAt:     sessionExpiresAt,
+
+		StaleHandshakeThreshold: staleThresholdFlag,
 	})
+	if anonymizer != nil {
+		if err := saveAnonMap(anonymizer.Mappings()); err != nil {
+			return err
+		}
+	}
 	var statusOutputString string
 	switch {
 	case detailFlag:
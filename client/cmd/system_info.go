@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/netbirdio/netbird/client/internal/debug"
+)
+
+// addSystemInfoFlag registers --system-info. Without a value it selects all collectors, like the
+// former boolean flag did.
+func addSystemInfoFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&systemInfoFlag, "system-info", "S", "all", "Adds system information to the debug bundle: all, none (or true, false) or a comma-separated list of collectors, e.g. os,cpu,mem. See --list-system-info")
+	cmd.Flags().Lookup("system-info").NoOptDefVal = "all"
+}
+
+// systemInfoRequest parses --system-info into the DebugBundleRequest fields. Daemons without
+// collector selection only read systemInfo and run all collectors if it is set.
+func systemInfoRequest() (bool, []string, error) {
+	set, err := debug.ParseSystemInfo(systemInfoFlag)
+	if err != nil {
+		return false, nil, fmt.Errorf("invalid --system-info: %w", err)
+	}
+	return len(set) > 0, set.Names(), nil
+}
+
+func printSystemInfoCollectors(cmd *cobra.Command) {
+	cmd.Println("System information collectors for --system-info:")
+	for _, c := range debug.SystemInfoCollectors() {
+		cmd.Printf("  %-20s %s\n", c.Name, c.Description)
+		cmd.Printf("  %-20s files: %s\n", "", c.Files)
+	}
+	cmd.Println()
+	cmd.Println("Select them with --system-info=" + strings.Join([]string{debug.SystemInfoOS, debug.SystemInfoCPU, debug.SystemInfoMem}, ",") + ", all of them with --system-info=all (the default) or none with --system-info=none.")
+}
@@ -185,6 +185,41 @@ func TestIptablesManagerDenyRules(t *testing.T) {
 	})
 }
 
+func TestIptablesManagerNetbirdRules(t *testing.T) {
+	manager, err := Create(ifaceMock, iface.DefaultMTU)
+	require.NoError(t, err)
+	require.NoError(t, manager.Init(nil))
+
+	defer func() {
+		err := manager.Close(nil)
+		require.NoError(t, err)
+	}()
+
+	_, err = manager.AddPeerFiltering(nil, netip.MustParseAddr("10.20.0.3").AsSlice(), "tcp", nil, &fw.Port{Values: []uint16{22}}, fw.ActionDrop, "")
+	require.NoError(t, err)
+	_, err = manager.AddPeerFiltering(nil, netip.MustParseAddr("10.20.0.4").AsSlice(), "udp", nil, &fw.Port{Values: []uint16{53}}, fw.ActionAccept, "")
+	require.NoError(t, err)
+	_, err = manager.AddRouteFiltering(nil, []netip.Prefix{netip.MustParsePrefix("10.20.0.0/24")}, fw.Network{Prefix: netip.MustParsePrefix("192.168.1.0/24")}, fw.ProtocolTCP, nil, &fw.Port{Values: []uint16{443}}, fw.ActionAccept)
+	require.NoError(t, err)
+
+	dump, err := manager.NetbirdRules()
+	require.NoError(t, err)
+
+	for _, want := range []string{
+		"iptables (IPv4):",
+		"*filter",
+		"-N " + chainNameInputRules,
+		"-j " + chainNameInputRules,
+		"--dport 22",
+		"--dport 53",
+		"--dport 443",
+		"*nat",
+	} {
+		require.Contains(t, dump, want)
+	}
+	require.NotContains(t, dump, "-P INPUT", "the policies of foreign chains are not NetBird rules")
+}
+
 func TestIptablesManagerIPSet(t *testing.T) {
 	mock := &iFaceMock{
 		NameFunc: func() string {
@@ -0,0 +1,69 @@
+package iptables
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/coreos/go-iptables/iptables"
+)
+
+// netbirdChainPrefix is the common prefix of all chains the manager creates.
+const netbirdChainPrefix = "NETBIRD-"
+
+// NetbirdRules returns the iptables rules the manager installed in "iptables -S" format: the
+// NetBird chains in full and the rules of other chains that jump to them or match the
+// WireGuard interface.
+func (m *Manager) NetbirdRules() (string, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var builder strings.Builder
+	builder.WriteString("iptables (IPv4):\n")
+	writeNetbirdRules(&builder, m.ipv4Client, m.wgIface.Name())
+
+	if m.hasIPv6() {
+		builder.WriteString("\nip6tables (IPv6):\n")
+		writeNetbirdRules(&builder, m.ipv6Client, m.wgIface.Name())
+	}
+	return builder.String(), nil
+}
+
+func writeNetbirdRules(builder *strings.Builder, ipt *iptables.IPTables, ifaceName string) {
+	for _, table := range []string{tableFilter, tableNat, tableMangle, tableRaw} {
+		builder.WriteString(fmt.Sprintf("\n*%s\n", table))
+
+		chains, err := ipt.ListChains(table)
+		if err != nil {
+			builder.WriteString(fmt.Sprintf("# failed to list chains: %v\n", err))
+			continue
+		}
+
+		for _, chain := range chains {
+			rules, err := ipt.List(table, chain)
+			if err != nil {
+				builder.WriteString(fmt.Sprintf("# failed to list chain %s: %v\n", chain, err))
+				continue
+			}
+
+			own := strings.HasPrefix(chain, netbirdChainPrefix)
+			for _, rule := range rules {
+				if own || isNetbirdRule(rule, ifaceName) {
+					builder.WriteString(rule + "\n")
+				}
+			}
+		}
+	}
+}
+
+// isNetbirdRule reports whether a rule of a foreign chain belongs to NetBird.
+func isNetbirdRule(rule, ifaceName string) bool {
+	if strings.Contains(rule, netbirdChainPrefix) {
+		return true
+	}
+	for _, field := range []string{"-i ", "-o "} {
+		if strings.Contains(rule, field+ifaceName+" ") || strings.HasSuffix(rule, field+ifaceName) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,27 @@
+package iptables
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsNetbirdRule(t *testing.T) {
+	tests := []struct {
+		name string
+		rule string
+		want bool
+	}{
+		{name: "jump to a NetBird chain", rule: "-A INPUT -j NETBIRD-ACL-INPUT", want: true},
+		{name: "input interface", rule: "-A INPUT -i wt0 -j ACCEPT", want: true},
+		{name: "output interface at the end", rule: "-A FORWARD -o wt0", want: true},
+		{name: "interface with a common prefix", rule: "-A INPUT -i wt01 -j ACCEPT", want: false},
+		{name: "foreign rule", rule: "-A INPUT -p tcp -m tcp --dport 22 -j ACCEPT", want: false},
+		{name: "foreign chain policy", rule: "-P FORWARD DROP", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isNetbirdRule(tt.rule, "wt0"))
+		})
+	}
+}
@@ -166,6 +166,43 @@ func TestNftablesManager(t *testing.T) {
 	require.NoError(t, err, "failed to reset")
 }
 
+func TestNftablesManagerNetbirdTables(t *testing.T) {
+	manager, err := Create(ifaceMock, iface.DefaultMTU)
+	require.NoError(t, err)
+	require.NoError(t, manager.Init(nil))
+
+	defer func() {
+		err := manager.Close(nil)
+		require.NoError(t, err)
+	}()
+
+	_, err = manager.AddPeerFiltering(nil, netip.MustParseAddr("100.96.0.2").AsSlice(), fw.ProtocolTCP, nil, &fw.Port{Values: []uint16{22}}, fw.ActionDrop, "")
+	require.NoError(t, err)
+	_, err = manager.AddRouteFiltering(nil, []netip.Prefix{netip.MustParsePrefix("100.96.0.0/16")}, fw.Network{Prefix: netip.MustParsePrefix("192.168.1.0/24")}, fw.ProtocolTCP, nil, &fw.Port{Values: []uint16{443}}, fw.ActionAccept)
+	require.NoError(t, err)
+	require.NoError(t, manager.Flush())
+
+	tables := manager.NetbirdTables()
+	require.NotEmpty(t, tables)
+	require.Equal(t, tableNameNetbird, tables[0].Name)
+	require.Equal(t, nftables.TableFamilyIPv4, tables[0].Family)
+
+	testClient := &nftables.Conn{}
+	chains, err := testClient.ListChainsOfTableFamily(tables[0].Family)
+	require.NoError(t, err)
+
+	var rules int
+	for _, chain := range chains {
+		if chain.Table.Name != tables[0].Name {
+			continue
+		}
+		chainRules, err := testClient.GetRules(tables[0], chain)
+		require.NoError(t, err)
+		rules += len(chainRules)
+	}
+	require.Positive(t, rules, "the dumped table holds the peer and route ACLs")
+}
+
 func TestNftablesManagerRuleOrder(t *testing.T) {
 	// This test verifies rule insertion order in nftables peer ACLs
 	// We add accept rule first, then deny rule to test ordering behavior
@@ -0,0 +1,18 @@
+package nftables
+
+import (
+	"github.com/google/nftables"
+)
+
+// NetbirdTables returns the nftables tables the manager installed its rules in, one per
+// address family.
+func (m *Manager) NetbirdTables() []*nftables.Table {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	tables := []*nftables.Table{m.router.workTable}
+	if m.hasIPv6() {
+		tables = append(tables, m.router6.workTable)
+	}
+	return tables
+}
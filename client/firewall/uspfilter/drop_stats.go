@@ -0,0 +1,88 @@
+package uspfilter
+
+import (
+	"sync/atomic"
+)
+
+// DropReason classifies why the userspace filter dropped an inbound packet.
+type DropReason int
+
+const (
+	// DropMalformed covers packets that could not be decoded or have an unknown network layer.
+	DropMalformed DropReason = iota
+	// DropPeerACL covers packets to a local address denied by the peer ACLs.
+	DropPeerACL
+	// DropRouteACL covers routed packets denied by the route ACLs.
+	DropRouteACL
+	// DropRoutingDisabled covers packets for a non-local destination while routing is disabled.
+	DropRoutingDisabled
+	// DropFragment covers fragments that are unsupported, overlapping or lack an allowed first fragment.
+	DropFragment
+	// DropNoForwarder covers packets that should be forwarded before the forwarder is initialized.
+	DropNoForwarder
+
+	dropReasonCount
+)
+
+func (r DropReason) String() string {
+	return map[DropReason]string{
+		DropMalformed:       "malformed",
+		DropPeerACL:         "peer_acl_denied",
+		DropRouteACL:        "route_acl_denied",
+		DropRoutingDisabled: "routing_disabled",
+		DropFragment:        "fragment",
+		DropNoForwarder:     "forwarder_unavailable",
+	}[r]
+}
+
+// DropReasons returns all drop reasons in display order.
+func DropReasons() []DropReason {
+	reasons := make([]DropReason, 0, dropReasonCount)
+	for r := DropReason(0); r < dropReasonCount; r++ {
+		reasons = append(reasons, r)
+	}
+	return reasons
+}
+
+// DropStats is a snapshot of the inbound packet drop counters.
+type DropStats struct {
+	// InboundPackets is the number of inbound packets seen by the filter.
+	InboundPackets uint64
+	Drops          map[DropReason]uint64
+}
+
+// Total returns the number of dropped packets across all reasons.
+func (s DropStats) Total() uint64 {
+	var total uint64
+	for _, n := range s.Drops {
+		total += n
+	}
+	return total
+}
+
+type dropCounters struct {
+	inbound atomic.Uint64
+	reasons [dropReasonCount]atomic.Uint64
+}
+
+// drop counts a dropped packet and returns true so callers can return its result directly.
+func (c *dropCounters) drop(reason DropReason) bool {
+	c.reasons[reason].Add(1)
+	return true
+}
+
+func (c *dropCounters) snapshot() DropStats {
+	stats := DropStats{
+		InboundPackets: c.inbound.Load(),
+		Drops:          make(map[DropReason]uint64, dropReasonCount),
+	}
+	for r := DropReason(0); r < dropReasonCount; r++ {
+		stats.Drops[r] = c.reasons[r].Load()
+	}
+	return stats
+}
+
+// DropStats returns the inbound packet drop counters accumulated since the filter was created.
+func (m *Manager) DropStats() DropStats {
+	return m.drops.snapshot()
+}
@@ -122,6 +122,7 @@ type Manager struct {
 	icmpTracker    *conntrack.ICMPTracker
 	tcpTracker     *conntrack.TCPTracker
 	fragments      *fragmentTracker
+	drops          dropCounters
 	forwarder      atomic.Pointer[forwarder.Forwarder]
 	pendingCapture atomic.Pointer[forwarder.PacketCapture]
 	logger         *nblog.Logger
@@ -1075,18 +1076,20 @@ func (m *Manager) tcpHooksDrop(dport uint16, dstIP netip.Addr, packetData []byte
 // filterInbound implements filtering logic for incoming packets.
 // If it returns true, the packet should be dropped.
 func (m *Manager) filterInbound(packetData []byte, size int) bool {
+	m.drops.inbound.Add(1)
+
 	d := m.decoders.Get().(*decoder)
 	defer m.decoders.Put(d)
 
 	valid, fragment := m.isValidPacket(d, packetData)
 	if !valid {
-		return true
+		return m.drops.drop(DropMalformed)
 	}
 
 	srcIP, dstIP := m.extractIPs(d)
 	if !srcIP.IsValid() {
 		m.logger.Error1("Unknown network layer: %v", d.decoded[0])
-		return true
+		return m.drops.drop(DropMalformed)
 	}
 
 	// gopacket does not decode the transport header of any IP fragment, so
@@ -1108,7 +1111,7 @@ func (m *Manager) filterInboundDecoded(d *decoder, srcIP, dstIP netip.Addr, pack
 		// Re-decode after port DNAT translation to update port information
 		if err := d.decodePacket(packetData); err != nil {
 			m.logger.Error1("failed to re-decode packet after port DNAT: %v", err)
-			return true
+			return m.drops.drop(DropMalformed)
 		}
 		srcIP, dstIP = m.extractIPs(d)
 	}
@@ -1117,7 +1120,7 @@ func (m *Manager) filterInboundDecoded(d *decoder, srcIP, dstIP netip.Addr, pack
 		// Re-decode after translation to get original addresses
 		if err := d.decodePacket(packetData); err != nil {
 			m.logger.Error1("failed to re-decode packet after reverse DNAT: %v", err)
-			return true
+			return m.drops.drop(DropMalformed)
 		}
 		srcIP, dstIP = m.extractIPs(d)
 	}
@@ -1212,7 +1215,7 @@ func (m *Manager) filterInboundFragment(d *decoder, srcIP, dstIP netip.Addr, siz
 		if m.logger.Enabled(nblog.LevelTrace) {
 			m.logger.Trace2("dropping unsupported fragment: src=%v dst=%v", srcIP, dstIP)
 		}
-		return true
+		return m.drops.drop(DropFragment)
 	}
 
 	if meta.offset != 0 {
@@ -1231,7 +1234,7 @@ func (m *Manager) filterInboundFragment(d *decoder, srcIP, dstIP netip.Addr, siz
 			m.logger.Trace3("dropping first fragment without full L4 header: src=%v dst=%v id=%v",
 				srcIP, dstIP, meta.key.id)
 		}
-		return true
+		return m.drops.drop(DropFragment)
 	}
 
 	return m.filterFirstFragment(d, meta, srcIP, dstIP, size)
@@ -1248,13 +1251,13 @@ func (m *Manager) filterTrailingFragment(meta fragmentMeta, srcIP, dstIP netip.A
 			m.logger.Trace3("dropping overlapping fragment rewriting inspected header: src=%v dst=%v id=%v",
 				srcIP, dstIP, meta.key.id)
 		}
-		return true
+		return m.drops.drop(DropFragment)
 	default:
 		if m.logger.Enabled(nblog.LevelTrace) {
 			m.logger.Trace3("dropping fragment with no allowed first fragment: src=%v dst=%v id=%v",
 				srcIP, dstIP, meta.key.id)
 		}
-		return true
+		return m.drops.drop(DropFragment)
 	}
 }
 
@@ -1275,7 +1278,7 @@ func (m *Manager) filterFirstFragment(d *decoder, meta fragmentMeta, srcIP, dstI
 		if blocked {
 			m.storeDropFlow("Dropping local first fragment (ACL denied): rule_id=%s proto=%v src=%s:%d dst=%s:%d",
 				d, srcIP, dstIP, ruleID, size)
-			return true
+			return m.drops.drop(DropPeerACL)
 		}
 		m.trackInbound(d, srcIP, dstIP, ruleID, size)
 		m.recordFirstFragment(meta)
@@ -1286,7 +1289,7 @@ func (m *Manager) filterFirstFragment(d *decoder, meta fragmentMeta, srcIP, dstI
 		if m.logger.Enabled(nblog.LevelTrace) {
 			m.logger.Trace2("Dropping routed fragment (routing disabled): src=%s dst=%s", srcIP, dstIP)
 		}
-		return true
+		return m.drops.drop(DropRoutingDisabled)
 	}
 	if m.nativeRouter.Load() {
 		m.trackInbound(d, srcIP, dstIP, nil, size)
@@ -1301,7 +1304,7 @@ func (m *Manager) filterFirstFragment(d *decoder, meta fragmentMeta, srcIP, dstI
 	if !pass {
 		m.storeDropFlow("Dropping routed first fragment (ACL denied): rule_id=%s proto=%v src=%s:%d dst=%s:%d",
 			d, srcIP, dstIP, ruleID, size)
-		return true
+		return m.drops.drop(DropRouteACL)
 	}
 
 	m.recordFirstFragment(meta)
@@ -1353,7 +1356,7 @@ func (m *Manager) handleLocalTraffic(d *decoder, srcIP, dstIP netip.Addr, packet
 	if blocked {
 		m.storeDropFlow("Dropping local packet (ACL denied): rule_id=%s proto=%v src=%s:%d dst=%s:%d",
 			d, srcIP, dstIP, ruleID, size)
-		return true
+		return m.drops.drop(DropPeerACL)
 	}
 
 	if m.shouldForward(d, dstIP) {
@@ -1371,7 +1374,7 @@ func (m *Manager) handleForwardedLocalTraffic(packetData []byte) bool {
 	fwd := m.forwarder.Load()
 	if fwd == nil {
 		m.logger.Trace("Dropping local packet (forwarder not initialized)")
-		return true
+		return m.drops.drop(DropNoForwarder)
 	}
 
 	if err := fwd.InjectIncomingPacket(packetData); err != nil {
@@ -1391,7 +1394,7 @@ func (m *Manager) handleRoutedTraffic(d *decoder, srcIP, dstIP netip.Addr, packe
 			m.logger.Trace2("Dropping routed packet (routing disabled): src=%s dst=%s",
 				srcIP, dstIP)
 		}
-		return true
+		return m.drops.drop(DropRoutingDisabled)
 	}
 
 	// Pass to native stack if native router is enabled or forced
@@ -1407,13 +1410,14 @@ func (m *Manager) handleRoutedTraffic(d *decoder, srcIP, dstIP netip.Addr, packe
 	if !pass {
 		m.storeDropFlow("Dropping routed packet (ACL denied): rule_id=%s proto=%v src=%s:%d dst=%s:%d",
 			d, srcIP, dstIP, ruleID, size)
-		return true
+		return m.drops.drop(DropRouteACL)
 	}
 
 	// Let forwarder handle the packet if it passed route ACLs
 	fwd := m.forwarder.Load()
 	if fwd == nil {
 		m.logger.Trace("failed to forward routed packet (forwarder not initialized)")
+		m.drops.drop(DropNoForwarder)
 	} else {
 		fwd.RegisterRuleID(srcIP, dstIP, srcPort, dstPort, ruleID)
 
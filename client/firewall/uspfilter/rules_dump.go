@@ -0,0 +1,145 @@
+package uspfilter
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/google/gopacket"
+
+	firewall "github.com/netbirdio/netbird/client/firewall/manager"
+)
+
+// NetbirdRules returns the rule set of the userspace filter in a human-readable form: the
+// peer ACLs, the route ACLs and the DNAT mappings. Rules of the native firewall are not included,
+// see NativeFirewall.
+func (m *Manager) NetbirdRules() (string, error) {
+	var builder strings.Builder
+
+	m.mutex.RLock()
+	builder.WriteString("Userspace filter:\n")
+	builder.WriteString(fmt.Sprintf("Stateful: %t, routing: %t, native router: %t, netstack: %t\n",
+		m.stateful, m.routingEnabled.Load(), m.nativeRouter.Load(), m.netstack))
+
+	builder.WriteString("\nIncoming deny rules:\n")
+	writePeerRules(&builder, m.incomingDenyRules)
+	builder.WriteString("\nIncoming allow rules:\n")
+	writePeerRules(&builder, m.incomingRules)
+	builder.WriteString("\nOutgoing rules:\n")
+	writePeerRules(&builder, m.outgoingRules)
+
+	builder.WriteString("\nRoute rules (evaluation order):\n")
+	if len(m.routeRules) == 0 {
+		builder.WriteString("  none\n")
+	}
+	for _, rule := range m.routeRules {
+		builder.WriteString("  " + formatRouteRule(rule) + "\n")
+	}
+	m.mutex.RUnlock()
+
+	m.dnatMutex.RLock()
+	builder.WriteString("\nDNAT mappings:\n")
+	if len(m.dnatMappings) == 0 {
+		builder.WriteString("  none\n")
+	}
+	originals := make([]netip.Addr, 0, len(m.dnatMappings))
+	for original := range m.dnatMappings {
+		originals = append(originals, original)
+	}
+	slices.SortFunc(originals, func(a, b netip.Addr) int { return a.Compare(b) })
+	for _, original := range originals {
+		builder.WriteString(fmt.Sprintf("  %s -> %s\n", original, m.dnatMappings[original]))
+	}
+	m.dnatMutex.RUnlock()
+
+	m.portDNATMutex.RLock()
+	builder.WriteString("\nPort redirections:\n")
+	if len(m.portDNATRules) == 0 {
+		builder.WriteString("  none\n")
+	}
+	for _, rule := range m.portDNATRules {
+		builder.WriteString(fmt.Sprintf("  %s %s:%d -> %d\n", formatLayer(rule.protocol), rule.targetIP, rule.origPort, rule.targetPort))
+	}
+	m.portDNATMutex.RUnlock()
+
+	return builder.String(), nil
+}
+
+// NativeFirewall returns the host firewall manager the filter delegates routing to, nil if there is none.
+func (m *Manager) NativeFirewall() firewall.Manager {
+	return m.nativeFirewall
+}
+
+func writePeerRules(builder *strings.Builder, rules map[netip.Addr]RuleSet) {
+	var sorted []PeerRule
+	for _, set := range rules {
+		for _, rule := range set {
+			sorted = append(sorted, rule)
+		}
+	}
+	if len(sorted) == 0 {
+		builder.WriteString("  none\n")
+		return
+	}
+
+	slices.SortFunc(sorted, func(a, b PeerRule) int {
+		if c := a.ip.Compare(b.ip); c != 0 {
+			return c
+		}
+		return strings.Compare(a.id, b.id)
+	})
+	for _, rule := range sorted {
+		builder.WriteString("  " + formatPeerRule(rule) + "\n")
+	}
+}
+
+func formatPeerRule(rule PeerRule) string {
+	action := firewall.ActionAccept
+	if rule.drop {
+		action = firewall.ActionDrop
+	}
+
+	peer := "any"
+	if rule.matchByIP {
+		peer = rule.ip.String()
+	}
+
+	return fmt.Sprintf("%s peer %s proto %s%s [%x]", action, peer, formatLayer(rule.protoLayer), formatPorts(rule.sPort, rule.dPort), rule.mgmtId)
+}
+
+func formatRouteRule(rule *RouteRule) string {
+	sources := make([]string, 0, len(rule.sources))
+	for _, src := range rule.sources {
+		sources = append(sources, src.String())
+	}
+
+	destinations := make([]string, 0, len(rule.destinations))
+	for _, dst := range rule.destinations {
+		destinations = append(destinations, dst.String())
+	}
+	if rule.dstSet != (firewall.Set{}) {
+		destinations = append(destinations, "set "+rule.dstSet.String())
+	}
+
+	return fmt.Sprintf("%s from %s to %s proto %s%s [%x]", rule.action, strings.Join(sources, ","), strings.Join(destinations, ","),
+		formatLayer(rule.protoLayer), formatPorts(rule.srcPort, rule.dstPort), rule.mgmtId)
+}
+
+func formatPorts(sPort, dPort *firewall.Port) string {
+	var ports string
+	if sPort != nil && len(sPort.Values) > 0 {
+		ports += " sport " + sPort.String()
+	}
+	if dPort != nil && len(dPort.Values) > 0 {
+		ports += " dport " + dPort.String()
+	}
+	return ports
+}
+
+func formatLayer(layer gopacket.LayerType) string {
+	if layer == layerTypeAll {
+		return "all"
+	}
+	return strings.ToLower(layer.String())
+}
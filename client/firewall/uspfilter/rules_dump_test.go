@@ -0,0 +1,142 @@
+package uspfilter
+
+import (
+	"net"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fw "github.com/netbirdio/netbird/client/firewall/manager"
+	nbiface "github.com/netbirdio/netbird/client/iface"
+	"github.com/netbirdio/netbird/client/iface/device"
+)
+
+func TestNetbirdRules(t *testing.T) {
+	type peerACL struct {
+		id     string
+		ip     string
+		proto  fw.Protocol
+		sPort  *fw.Port
+		dPort  *fw.Port
+		action fw.Action
+	}
+	type routeACL struct {
+		id      string
+		sources []string
+		dest    fw.Network
+		proto   fw.Protocol
+		dPort   *fw.Port
+		action  fw.Action
+	}
+
+	tests := []struct {
+		name   string
+		peers  []peerACL
+		routes []routeACL
+		// want are lines of the dump in the order they must appear
+		want []string
+	}{
+		{
+			name: "no rules",
+			want: []string{
+				"Userspace filter:",
+				"Incoming deny rules:", "  none",
+				"Incoming allow rules:", "  none",
+				"Outgoing rules:", "  none",
+				"Route rules (evaluation order):", "  none",
+				"DNAT mappings:", "  none",
+				"Port redirections:", "  none",
+			},
+		},
+		{
+			name: "peer rules sorted by address",
+			peers: []peerACL{
+				{id: "b", ip: "100.64.0.2", proto: fw.ProtocolUDP, dPort: &fw.Port{Values: []uint16{53}}, action: fw.ActionAccept},
+				{id: "a", ip: "100.64.0.1", proto: fw.ProtocolTCP, dPort: &fw.Port{IsRange: true, Values: []uint16{8000, 8080}}, action: fw.ActionAccept},
+				{id: "c", ip: "100.64.0.3", proto: fw.ProtocolTCP, sPort: &fw.Port{Values: []uint16{22}}, action: fw.ActionDrop},
+				{id: "d", ip: "0.0.0.0", proto: fw.ProtocolALL, action: fw.ActionAccept},
+			},
+			want: []string{
+				"Incoming deny rules:",
+				"  drop peer 100.64.0.3 proto tcp sport 22 [63]",
+				"Incoming allow rules:",
+				"  accept peer any proto all [64]",
+				"  accept peer 100.64.0.1 proto tcp dport range:8000,8080 [61]",
+				"  accept peer 100.64.0.2 proto udp dport 53 [62]",
+				"Route rules (evaluation order):", "  none",
+			},
+		},
+		{
+			name: "route rules with deny first",
+			routes: []routeACL{
+				{
+					id:      "r1",
+					sources: []string{"100.64.0.0/16", "100.65.0.0/16"},
+					dest:    fw.Network{Prefix: netip.MustParsePrefix("192.168.1.0/24")},
+					proto:   fw.ProtocolTCP,
+					dPort:   &fw.Port{Values: []uint16{443}},
+					action:  fw.ActionAccept,
+				},
+				{
+					id:      "r2",
+					sources: []string{"100.64.0.5/32"},
+					dest:    fw.Network{Prefix: netip.MustParsePrefix("192.168.1.10/32")},
+					proto:   fw.ProtocolALL,
+					action:  fw.ActionDrop,
+				},
+			},
+			want: []string{
+				"Incoming allow rules:", "  none",
+				"Route rules (evaluation order):",
+				"  drop from 100.64.0.5/32 to 192.168.1.10/32 proto all [7232]",
+				"  accept from 100.64.0.0/16,100.65.0.0/16 to 192.168.1.0/24 proto tcp dport 443 [7231]",
+				"DNAT mappings:", "  none",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := Create(&IFaceMock{
+				SetFilterFunc: func(device.PacketFilter) error { return nil },
+			}, false, flowLogger, nbiface.DefaultMTU)
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				require.NoError(t, m.Close(nil))
+			})
+
+			for _, p := range tt.peers {
+				_, err := m.AddPeerFiltering([]byte(p.id), net.ParseIP(p.ip), p.proto, p.sPort, p.dPort, p.action, "")
+				require.NoError(t, err)
+			}
+			for _, r := range tt.routes {
+				sources := make([]netip.Prefix, 0, len(r.sources))
+				for _, src := range r.sources {
+					sources = append(sources, netip.MustParsePrefix(src))
+				}
+				_, err := m.AddRouteFiltering([]byte(r.id), sources, r.dest, r.proto, nil, r.dPort, r.action)
+				require.NoError(t, err)
+			}
+
+			dump, err := m.NetbirdRules()
+			require.NoError(t, err)
+
+			lines := strings.Split(dump, "\n")
+			next := 0
+			for _, want := range tt.want {
+				found := false
+				for ; next < len(lines); next++ {
+					if lines[next] == want {
+						found = true
+						next++
+						break
+					}
+				}
+				assert.Truef(t, found, "line %q missing or out of order in:\n%s", want, dump)
+			}
+		})
+	}
+}
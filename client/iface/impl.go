@@ -0,0 +1,82 @@
+package iface
+
+import (
+	"runtime/debug"
+
+	"github.com/netbirdio/netbird/client/iface/device"
+)
+
+// WireGuard implementations reported in Implementation.Mode.
+const (
+	ImplKernel    = "kernel"
+	ImplUserspace = "userspace"
+	ImplNetstack  = "netstack"
+)
+
+// wireguardGoModule is the module path of wireguard-go, replaced by the NetBird fork.
+const wireguardGoModule = "golang.zx2c4.com/wireguard"
+
+// Implementation describes the WireGuard implementation behind an interface.
+type Implementation struct {
+	// Mode is ImplKernel, ImplUserspace (wireguard-go on a TUN device) or ImplNetstack
+	// (wireguard-go on the gVisor netstack, without a host interface).
+	Mode string
+	// Version is the version of the kernel module or of the wireguard-go module.
+	Version string
+	// InterfaceName is the name of the WireGuard interface.
+	InterfaceName string
+	// InterfaceType is the kind of host interface, e.g. wireguard, tun, utun or wintun.
+	InterfaceType string
+	// TunDriver describes the TUN driver and kernel the userspace implementation runs on, empty
+	// when unknown.
+	TunDriver string
+}
+
+// Implementation reports whether the interface uses the kernel WireGuard module or wireguard-go,
+// the version of either and the host interface and TUN driver it runs on.
+func (w *WGIface) Implementation() Implementation {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	impl := Implementation{Mode: ImplUserspace}
+	if w.tun != nil {
+		impl.InterfaceName = w.tun.DeviceName()
+	}
+	switch {
+	case isNetstackDevice(w.tun):
+		impl.Mode = ImplNetstack
+		impl.Version = wireguardGoVersion()
+		impl.InterfaceType = "none (gVisor netstack)"
+		return impl
+	case !w.userspaceBind:
+		impl.Mode = ImplKernel
+	default:
+		impl.Version = wireguardGoVersion()
+	}
+	platformImplementation(&impl)
+	return impl
+}
+
+func isNetstackDevice(tun WGTunDevice) bool {
+	_, ok := tun.(*device.TunNetstackDevice)
+	return ok
+}
+
+// wireguardGoVersion returns the version of the wireguard-go module the binary was built with,
+// naming the replacement module if there is one.
+func wireguardGoVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	for _, dep := range info.Deps {
+		if dep.Path != wireguardGoModule {
+			continue
+		}
+		if dep.Replace != nil {
+			return dep.Replace.Path + " " + dep.Replace.Version
+		}
+		return dep.Path + " " + dep.Version
+	}
+	return "unknown"
+}
@@ -0,0 +1,6 @@
+package iface
+
+func platformImplementation(impl *Implementation) {
+	impl.InterfaceType = "tun"
+	impl.TunDriver = "tun of the Android VpnService"
+}
@@ -0,0 +1,29 @@
+//go:build darwin || dragonfly || freebsd || netbsd || openbsd
+
+package iface
+
+import (
+	"fmt"
+	"runtime"
+
+	"golang.org/x/sys/unix"
+)
+
+func platformImplementation(impl *Implementation) {
+	kernel, err := unix.Sysctl("kern.osrelease")
+	if err != nil {
+		kernel = "unknown"
+	}
+
+	switch runtime.GOOS {
+	case "darwin":
+		impl.InterfaceType = "utun"
+		impl.TunDriver = "utun, Darwin kernel " + kernel
+	case "ios":
+		impl.InterfaceType = "utun"
+		impl.TunDriver = "utun of the Network Extension packet tunnel, Darwin kernel " + kernel
+	default:
+		impl.InterfaceType = "tun"
+		impl.TunDriver = fmt.Sprintf("tun(4), %s kernel %s", runtime.GOOS, kernel)
+	}
+}
@@ -0,0 +1,5 @@
+package iface
+
+func platformImplementation(impl *Implementation) {
+	impl.InterfaceType = "none"
+}
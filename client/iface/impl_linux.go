@@ -0,0 +1,38 @@
+//go:build linux && !android
+
+package iface
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func platformImplementation(impl *Implementation) {
+	kernel := readTrimmed("/proc/sys/kernel/osrelease")
+	if impl.Mode == ImplKernel {
+		impl.InterfaceType = "wireguard"
+		impl.Version = moduleVersion("wireguard", kernel)
+		return
+	}
+	impl.InterfaceType = "tun"
+	impl.TunDriver = moduleVersion("tun", kernel) + " via /dev/net/tun"
+}
+
+// moduleVersion describes the version of a kernel module. Modules built into the kernel without
+// a version are described by the kernel release.
+func moduleVersion(name, kernel string) string {
+	if version := readTrimmed(filepath.Join("/sys/module", name, "version")); version != "" {
+		return fmt.Sprintf("%s module %s, kernel %s", name, version, kernel)
+	}
+	return fmt.Sprintf("%s in kernel %s", name, kernel)
+}
+
+func readTrimmed(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
@@ -0,0 +1,22 @@
+package iface
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/windows"
+	"golang.zx2c4.com/wintun"
+)
+
+func platformImplementation(impl *Implementation) {
+	impl.InterfaceType = "wintun"
+
+	v := windows.RtlGetVersion()
+	release := fmt.Sprintf("Windows %d.%d.%d", v.MajorVersion, v.MinorVersion, v.BuildNumber)
+
+	version, err := wintun.RunningVersion()
+	if err != nil {
+		impl.TunDriver = fmt.Sprintf("Wintun driver version unknown (%v), %s", err, release)
+		return
+	}
+	impl.TunDriver = fmt.Sprintf("Wintun driver %d.%d, %s", version>>16, version&0xffff, release)
+}
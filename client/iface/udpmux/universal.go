@@ -300,10 +300,42 @@ func (m *UniversalUDPMuxDefault) sendSTUN(serverAddr net.Addr) (chan struct{}, e
 	return addrMap.waitAddrReceived, nil
 }
 
+// STUNMapping is the address a STUN server reported for the shared socket.
+type STUNMapping struct {
+	// Server is the address of the STUN server.
+	Server     string
+	Mapped     net.UDPAddr
+	ReceivedAt time.Time
+	// Expired is set when the mapping is older than the cache TTL and will be requested again.
+	Expired bool
+}
+
+// STUNMappings returns the last address each STUN server reported, including expired ones.
+// Servers that have not answered yet are left out.
+func (m *UniversalUDPMuxDefault) STUNMappings() []STUNMapping {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	mappings := make([]STUNMapping, 0, len(m.xorMappedMap))
+	for server, mapped := range m.xorMappedMap {
+		if mapped.pending() {
+			continue
+		}
+		mappings = append(mappings, STUNMapping{
+			Server:     server,
+			Mapped:     net.UDPAddr{IP: mapped.addr.IP, Port: mapped.addr.Port},
+			ReceivedAt: mapped.receivedAt,
+			Expired:    mapped.expired(),
+		})
+	}
+	return mappings
+}
+
 type xorMapped struct {
 	addr             *stun.XORMappedAddress
 	waitAddrReceived chan struct{}
 	expiresAt        time.Time
+	receivedAt       time.Time
 }
 
 func (a *xorMapped) closeWaiters() {
@@ -328,5 +360,6 @@ func (a *xorMapped) expired() bool {
 
 func (a *xorMapped) SetAddr(addr *stun.XORMappedAddress) {
 	a.addr = addr
+	a.receivedAt = time.Now()
 	a.closeWaiters()
 }
@@ -37,7 +37,9 @@ type DefaultManager struct {
 	routeRules         map[id.RuleID]struct{}
 	previousConfigHash uint64
 	hasAppliedConfig   bool
-	mutex              sync.Mutex
+	// policy is the input of the last ApplyFiltering call, evaluated by Evaluate.
+	policy *appliedPolicy
+	mutex  sync.Mutex
 }
 
 func NewDefaultManager(fm firewall.Manager) *DefaultManager {
@@ -60,6 +62,14 @@ func (d *DefaultManager) ApplyFiltering(networkMap *mgmProto.NetworkMap, dnsRout
 		return
 	}
 
+	d.policy = &appliedPolicy{
+		peerRules:           networkMap.GetFirewallRules(),
+		legacyPeerRules:     len(networkMap.GetFirewallRules()) == 0 && !networkMap.GetFirewallRulesIsEmpty(),
+		routeRules:          networkMap.GetRoutesFirewallRules(),
+		legacyRouteRules:    len(networkMap.GetRoutesFirewallRules()) == 0 && !networkMap.GetRoutesFirewallRulesIsEmpty(),
+		dnsRouteFeatureFlag: dnsRouteFeatureFlag,
+	}
+
 	// Skip the full rebuild + flush when the inputs that drive the firewall
 	// state are byte-for-byte identical to the last successfully applied
 	// update. Management re-sends the same network map far more often than it
@@ -294,18 +304,9 @@ func (d *DefaultManager) protoRuleToFirewallRule(
 		return "", nil, fmt.Errorf("skipping firewall rule: %s", err)
 	}
 
-	var port *firewall.Port
-	if !portInfoEmpty(r.PortInfo) {
-		port = convertPortInfo(r.PortInfo)
-	} else if r.Port != "" {
-		// old version of management, single port
-		value, err := strconv.Atoi(r.Port)
-		if err != nil {
-			return "", nil, fmt.Errorf("invalid port: %w", err)
-		}
-		port = &firewall.Port{
-			Values: []uint16{uint16(value)},
-		}
+	port, err := peerRulePort(r)
+	if err != nil {
+		return "", nil, err
 	}
 
 	ruleID := d.getPeerRuleID(ip, protocol, int(r.Direction), port, action)
@@ -334,6 +335,25 @@ func (d *DefaultManager) protoRuleToFirewallRule(
 	return ruleID, rules, nil
 }
 
+// peerRulePort returns the port of a peer rule, nil when it applies to all ports.
+func peerRulePort(r *mgmProto.FirewallRule) (*firewall.Port, error) {
+	if !portInfoEmpty(r.PortInfo) {
+		return convertPortInfo(r.PortInfo), nil
+	}
+	if r.Port == "" {
+		return nil, nil
+	}
+
+	// old version of management, single port
+	value, err := strconv.Atoi(r.Port)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port: %w", err)
+	}
+	return &firewall.Port{
+		Values: []uint16{uint16(value)},
+	}, nil
+}
+
 func portInfoEmpty(portInfo *mgmProto.PortInfo) bool {
 	if portInfo == nil {
 		return true
@@ -512,3 +532,62 @@ func getDefault(prefix netip.Prefix) netip.Prefix {
 	}
 	return netip.PrefixFrom(netip.IPv4Unspecified(), 0)
 }
+
+// ValidateRules checks the firewall and route firewall rules of a network map with the conversions
+// ApplyFiltering uses and returns an error per rule it would skip, naming the offending rule.
+func ValidateRules(networkMap *mgmProto.NetworkMap) []error {
+	var errs []error
+	for i, r := range networkMap.GetFirewallRules() {
+		if err := validatePeerRule(r); err != nil {
+			//nolint:staticcheck // PeerIP names the rule for old management
+			errs = append(errs, fmt.Errorf("firewall rule %d (peer %s, policy %x): %w", i, r.GetPeerIP(), r.GetPolicyID(), err))
+		}
+	}
+	for i, r := range networkMap.GetRoutesFirewallRules() {
+		if err := validateRouteRule(r); err != nil {
+			errs = append(errs, fmt.Errorf("route firewall rule %d (destination %s, policy %x): %w", i, r.GetDestination(), r.GetPolicyID(), err))
+		}
+	}
+	return errs
+}
+
+func validatePeerRule(r *mgmProto.FirewallRule) error {
+	if _, err := extractRuleIP(r); err != nil {
+		return err
+	}
+	if _, err := convertToFirewallProtocol(r.Protocol); err != nil {
+		return fmt.Errorf("invalid protocol: %w", err)
+	}
+	if _, err := convertFirewallAction(r.Action); err != nil {
+		return fmt.Errorf("invalid action: %w", err)
+	}
+	if portInfoEmpty(r.PortInfo) && r.Port != "" {
+		if _, err := strconv.Atoi(r.Port); err != nil {
+			return fmt.Errorf("invalid port: %w", err)
+		}
+	}
+	if r.Direction != mgmProto.RuleDirection_IN && r.Direction != mgmProto.RuleDirection_OUT {
+		return fmt.Errorf("invalid direction %v", r.Direction)
+	}
+	return nil
+}
+
+func validateRouteRule(r *mgmProto.RouteFirewallRule) error {
+	for _, sourceRange := range r.SourceRanges {
+		if _, err := netip.ParsePrefix(sourceRange); err != nil {
+			return fmt.Errorf("parse source range: %w", err)
+		}
+	}
+	if !r.IsDynamic {
+		if _, err := netip.ParsePrefix(r.Destination); err != nil {
+			return fmt.Errorf("parse destination: %w", err)
+		}
+	}
+	if _, err := convertToFirewallProtocol(r.Protocol); err != nil {
+		return fmt.Errorf("invalid protocol: %w", err)
+	}
+	if _, err := convertFirewallAction(r.Action); err != nil {
+		return fmt.Errorf("invalid action: %w", err)
+	}
+	return nil
+}
@@ -632,3 +632,24 @@ func TestFirewallConfigHashDeterministic(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, h1, h2, "hash must be order-independent for rule slices")
 }
+
+func TestValidateRules(t *testing.T) {
+	networkMap := &mgmProto.NetworkMap{
+		FirewallRules: []*mgmProto.FirewallRule{
+			{PeerIP: "10.93.0.1", Direction: mgmProto.RuleDirection_IN, Action: mgmProto.RuleAction_ACCEPT, Protocol: mgmProto.RuleProtocol_TCP, Port: "80"},
+			{PeerIP: "not-an-ip", Direction: mgmProto.RuleDirection_IN, Action: mgmProto.RuleAction_ACCEPT, Protocol: mgmProto.RuleProtocol_ALL},
+			{PeerIP: "10.93.0.2", Direction: mgmProto.RuleDirection_OUT, Action: mgmProto.RuleAction_ACCEPT, Protocol: mgmProto.RuleProtocol_TCP, Port: "http"},
+		},
+		RoutesFirewallRules: []*mgmProto.RouteFirewallRule{
+			{SourceRanges: []string{"100.64.0.0/10"}, Destination: "10.0.0.0/8", Action: mgmProto.RuleAction_ACCEPT, Protocol: mgmProto.RuleProtocol_ALL},
+			{SourceRanges: []string{"100.64.0.0/10"}, Destination: "10.0.0.0", Action: mgmProto.RuleAction_ACCEPT, Protocol: mgmProto.RuleProtocol_ALL},
+		},
+	}
+
+	errs := ValidateRules(networkMap)
+	require.Len(t, errs, 3)
+	assert.Contains(t, errs[0].Error(), "firewall rule 1 (peer not-an-ip")
+	assert.Contains(t, errs[1].Error(), "firewall rule 2 (peer 10.93.0.2")
+	assert.Contains(t, errs[1].Error(), "invalid port")
+	assert.Contains(t, errs[2].Error(), "route firewall rule 1 (destination 10.0.0.0")
+}
@@ -0,0 +1,188 @@
+package acl
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strconv"
+
+	firewall "github.com/netbirdio/netbird/client/firewall/manager"
+	mgmProto "github.com/netbirdio/netbird/shared/management/proto"
+)
+
+// ErrNoPolicy is returned by Evaluate before a network map was applied.
+var ErrNoPolicy = errors.New("no ACL policy applied yet")
+
+// Query is a flow evaluated against the applied ACL policy.
+type Query struct {
+	Source      netip.Addr
+	Destination netip.Addr
+	// Protocol is tcp, udp, icmp or all. all only matches rules for all protocols.
+	Protocol firewall.Protocol
+	// Port is the destination port of tcp and udp flows. Zero only matches rules without ports.
+	Port uint16
+}
+
+func (q Query) String() string {
+	s := fmt.Sprintf("%s -> %s %s", q.Source, q.Destination, q.Protocol)
+	if q.Port != 0 {
+		s += "/" + strconv.Itoa(int(q.Port))
+	}
+	return s
+}
+
+// Decision is the result of evaluating a Query.
+type Decision struct {
+	Allowed bool
+	// Rule names the deciding rule like ValidateRules does, empty when no rule matched.
+	Rule string
+	// Reason explains the decision, e.g. which rule set was evaluated or why nothing matched.
+	Reason string
+}
+
+// appliedPolicy is the ACL input of the last ApplyFiltering call, kept for Evaluate.
+type appliedPolicy struct {
+	peerRules           []*mgmProto.FirewallRule
+	legacyPeerRules     bool
+	routeRules          []*mgmProto.RouteFirewallRule
+	legacyRouteRules    bool
+	dnsRouteFeatureFlag bool
+}
+
+// Evaluate decides whether the applied policy allows the flow and which rule decides it, with
+// the conversions ApplyFiltering uses. It does not change any state. Drop rules take precedence
+// over accept rules, and a flow matching no rule is dropped.
+//
+// Flows to one of the local overlay addresses are matched against the inbound peer rules, flows
+// from one of them against the outbound peer rules, which the remote peer enforces. Other flows
+// are matched against the route rules, as this peer routes them.
+func (d *DefaultManager) Evaluate(q Query, local ...netip.Addr) (Decision, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.firewall == nil {
+		return Decision{}, errors.New("firewall manager is not supported, traffic is not filtered")
+	}
+	if d.policy == nil {
+		return Decision{}, ErrNoPolicy
+	}
+	if q.Protocol != firewall.ProtocolTCP && q.Protocol != firewall.ProtocolUDP && q.Port != 0 {
+		return Decision{}, fmt.Errorf("port %d given for protocol %s", q.Port, q.Protocol)
+	}
+
+	switch {
+	case slices.Contains(local, q.Destination):
+		return evaluatePeerRules(d.policy, q, mgmProto.RuleDirection_IN, q.Source), nil
+	case slices.Contains(local, q.Source):
+		return evaluatePeerRules(d.policy, q, mgmProto.RuleDirection_OUT, q.Destination), nil
+	default:
+		return evaluateRouteRules(d.policy, q), nil
+	}
+}
+
+func evaluatePeerRules(policy *appliedPolicy, q Query, direction mgmProto.RuleDirection, peer netip.Addr) Decision {
+	ruleSet := "inbound peer rules"
+	if direction == mgmProto.RuleDirection_OUT {
+		ruleSet = "outbound peer rules, enforced by the destination peer"
+	}
+	if policy.legacyPeerRules {
+		return Decision{Allowed: true, Reason: "the management service predates ACL rules, all peer traffic is allowed"}
+	}
+
+	var accept string
+	for i, r := range policy.peerRules {
+		if r.GetDirection() != direction {
+			continue
+		}
+		ip, err := extractRuleIP(r)
+		if err != nil || (!ip.IsUnspecified() && ip != peer) {
+			continue
+		}
+		port, err := peerRulePort(r)
+		if err != nil || !ruleMatches(r.GetProtocol(), port, q) {
+			continue
+		}
+
+		//nolint:staticcheck // PeerIP names the rule for old management
+		name := fmt.Sprintf("firewall rule %d (peer %s, policy %x)", i, r.GetPeerIP(), r.GetPolicyID())
+		if r.GetAction() == mgmProto.RuleAction_DROP {
+			return Decision{Rule: name, Reason: "dropped by " + ruleSet}
+		}
+		if accept == "" {
+			accept = name
+		}
+	}
+
+	if accept != "" {
+		return Decision{Allowed: true, Rule: accept, Reason: "accepted by " + ruleSet}
+	}
+	return Decision{Reason: "no rule matches in " + ruleSet + ", dropped by default"}
+}
+
+func evaluateRouteRules(policy *appliedPolicy, q Query) Decision {
+	if policy.legacyRouteRules {
+		return Decision{Allowed: true, Reason: "the management service predates route ACL rules, all routed traffic is allowed"}
+	}
+
+	var accept string
+	var skippedDomainRules int
+	for i, r := range policy.routeRules {
+		if !slices.ContainsFunc(r.GetSourceRanges(), func(source string) bool {
+			prefix, err := netip.ParsePrefix(source)
+			return err == nil && prefix.Contains(q.Source)
+		}) {
+			continue
+		}
+
+		destination, err := determineDestination(r, policy.dnsRouteFeatureFlag, []netip.Prefix{netip.PrefixFrom(q.Source, q.Source.BitLen())})
+		if err != nil {
+			continue
+		}
+		if destination.IsSet() {
+			skippedDomainRules++
+			continue
+		}
+		if !destination.Prefix.Contains(q.Destination) || !ruleMatches(r.GetProtocol(), convertPortInfo(r.GetPortInfo()), q) {
+			continue
+		}
+
+		name := fmt.Sprintf("route firewall rule %d (destination %s, policy %x)", i, r.GetDestination(), r.GetPolicyID())
+		if r.GetAction() == mgmProto.RuleAction_DROP {
+			return Decision{Rule: name, Reason: "dropped by route rules"}
+		}
+		if accept == "" {
+			accept = name
+		}
+	}
+
+	if accept != "" {
+		return Decision{Allowed: true, Rule: accept, Reason: "accepted by route rules"}
+	}
+	reason := "no route rule matches, dropped by default"
+	if skippedDomainRules > 0 {
+		reason += fmt.Sprintf(" (%d domain rules depend on DNS resolution and were not evaluated)", skippedDomainRules)
+	}
+	return Decision{Reason: reason}
+}
+
+// ruleMatches reports whether a rule with the given protocol and destination port covers q.
+func ruleMatches(protocol mgmProto.RuleProtocol, port *firewall.Port, q Query) bool {
+	ruleProtocol, err := convertToFirewallProtocol(protocol)
+	if err != nil {
+		return false
+	}
+	if ruleProtocol != firewall.ProtocolALL && ruleProtocol != q.Protocol {
+		return false
+	}
+	if port == nil {
+		return true
+	}
+	if q.Port == 0 {
+		return false
+	}
+	if port.IsRange && len(port.Values) == 2 {
+		return q.Port >= port.Values[0] && q.Port <= port.Values[1]
+	}
+	return slices.Contains(port.Values, q.Port)
+}
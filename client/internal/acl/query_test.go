@@ -0,0 +1,114 @@
+package acl
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	firewall "github.com/netbirdio/netbird/client/firewall/manager"
+	mgmProto "github.com/netbirdio/netbird/shared/management/proto"
+)
+
+func TestEvaluate(t *testing.T) {
+	local := netip.MustParseAddr("100.64.0.1")
+	peerA := netip.MustParseAddr("100.64.0.2")
+	peerB := netip.MustParseAddr("100.64.0.3")
+
+	policy := &appliedPolicy{
+		peerRules: []*mgmProto.FirewallRule{
+			{PeerIP: "100.64.0.2", Direction: mgmProto.RuleDirection_IN, Action: mgmProto.RuleAction_ACCEPT, Protocol: mgmProto.RuleProtocol_TCP,
+				PortInfo: &mgmProto.PortInfo{PortSelection: &mgmProto.PortInfo_Port{Port: 443}}},
+			{PeerIP: "100.64.0.2", Direction: mgmProto.RuleDirection_IN, Action: mgmProto.RuleAction_ACCEPT, Protocol: mgmProto.RuleProtocol_UDP,
+				PortInfo: &mgmProto.PortInfo{PortSelection: &mgmProto.PortInfo_Range_{Range: &mgmProto.PortInfo_Range{Start: 5000, End: 6000}}}},
+			{PeerIP: "100.64.0.3", Direction: mgmProto.RuleDirection_IN, Action: mgmProto.RuleAction_ACCEPT, Protocol: mgmProto.RuleProtocol_ALL},
+			{PeerIP: "100.64.0.3", Direction: mgmProto.RuleDirection_IN, Action: mgmProto.RuleAction_DROP, Protocol: mgmProto.RuleProtocol_TCP, Port: "22"},
+			{PeerIP: "100.64.0.3", Direction: mgmProto.RuleDirection_OUT, Action: mgmProto.RuleAction_ACCEPT, Protocol: mgmProto.RuleProtocol_ICMP},
+		},
+		routeRules: []*mgmProto.RouteFirewallRule{
+			{SourceRanges: []string{"100.64.0.0/16"}, Destination: "10.0.0.0/24", Action: mgmProto.RuleAction_ACCEPT, Protocol: mgmProto.RuleProtocol_UDP,
+				PortInfo: &mgmProto.PortInfo{PortSelection: &mgmProto.PortInfo_Port{Port: 53}}},
+			{SourceRanges: []string{"100.64.0.0/16"}, IsDynamic: true, Domains: []string{"example.com"}, Action: mgmProto.RuleAction_ACCEPT, Protocol: mgmProto.RuleProtocol_ALL},
+		},
+		dnsRouteFeatureFlag: true,
+	}
+	d := &DefaultManager{firewall: &firewallStub{}, policy: policy}
+
+	tests := []struct {
+		name    string
+		query   Query
+		allowed bool
+		rule    string
+		reason  string
+	}{
+		{
+			name:    "inbound accepted",
+			query:   Query{Source: peerA, Destination: local, Protocol: firewall.ProtocolTCP, Port: 443},
+			allowed: true,
+			rule:    "firewall rule 0",
+		},
+		{
+			name:   "inbound other port",
+			query:  Query{Source: peerA, Destination: local, Protocol: firewall.ProtocolTCP, Port: 80},
+			reason: "no rule matches in inbound peer rules, dropped by default",
+		},
+		{
+			name:    "inbound port range",
+			query:   Query{Source: peerA, Destination: local, Protocol: firewall.ProtocolUDP, Port: 5353},
+			allowed: true,
+			rule:    "firewall rule 1",
+		},
+		{
+			name:   "drop takes precedence",
+			query:  Query{Source: peerB, Destination: local, Protocol: firewall.ProtocolTCP, Port: 22},
+			rule:   "firewall rule 3",
+			reason: "dropped by inbound peer rules",
+		},
+		{
+			name:    "all protocols",
+			query:   Query{Source: peerB, Destination: local, Protocol: firewall.ProtocolICMP},
+			allowed: true,
+			rule:    "firewall rule 2",
+		},
+		{
+			name:    "outbound",
+			query:   Query{Source: local, Destination: peerB, Protocol: firewall.ProtocolICMP},
+			allowed: true,
+			rule:    "firewall rule 4",
+			reason:  "accepted by outbound peer rules, enforced by the destination peer",
+		},
+		{
+			name:    "routed",
+			query:   Query{Source: peerA, Destination: netip.MustParseAddr("10.0.0.10"), Protocol: firewall.ProtocolUDP, Port: 53},
+			allowed: true,
+			rule:    "route firewall rule 0",
+		},
+		{
+			name:   "routed domain rules skipped",
+			query:  Query{Source: peerA, Destination: netip.MustParseAddr("192.0.2.1"), Protocol: firewall.ProtocolTCP, Port: 443},
+			reason: "no route rule matches, dropped by default (1 domain rules depend on DNS resolution and were not evaluated)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision, err := d.Evaluate(tt.query, local)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.allowed, decision.Allowed)
+			assert.Contains(t, decision.Rule, tt.rule)
+			if tt.reason != "" {
+				assert.Equal(t, tt.reason, decision.Reason)
+			}
+		})
+	}
+
+	_, err := d.Evaluate(Query{Source: peerA, Destination: local, Protocol: firewall.ProtocolICMP, Port: 1}, local)
+	assert.Error(t, err, "port without tcp or udp")
+
+	_, err = (&DefaultManager{firewall: &firewallStub{}}).Evaluate(Query{}, local)
+	assert.ErrorIs(t, err, ErrNoPolicy)
+}
+
+// firewallStub satisfies firewall.Manager for Evaluate, which never calls it.
+type firewallStub struct {
+	firewall.Manager
+}
@@ -0,0 +1,269 @@
+// Package benchmark measures the throughput, round trip time and loss to a peer through the tunnel.
+// Every daemon runs a Responder on its overlay address. Run sends echo packets to the responder of
+// a peer over UDP, then transfers data to it and back over TCP, each direction bounded by MaxBytes.
+// Peers that do not answer the handshake of the responder are refused with ErrNotSupported.
+package benchmark
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"os"
+	"time"
+)
+
+const (
+	// Port is the TCP and UDP port of the responder on the overlay address.
+	Port = 33050
+	// MaxBytes bounds the data transferred in each direction of a benchmark.
+	MaxBytes = 256 << 20
+	// DefaultDuration is the time of a benchmark when none is given, half of it each direction.
+	DefaultDuration = 10 * time.Second
+	// MaxDuration is the longest benchmark a responder serves.
+	MaxDuration = time.Minute
+
+	// EchoCount is the number of echo packets sent to measure the round trip time and loss.
+	EchoCount = 10
+
+	version = 1
+
+	opHello    = 1
+	opUpload   = 2
+	opDownload = 3
+
+	statusOK          = 0
+	statusBusy        = 1
+	statusUnsupported = 2
+
+	requestLen = 14
+	replyLen   = 6
+	echoLen    = 64
+	chunkSize  = 128 << 10
+
+	dialTimeout  = 5 * time.Second
+	replyTimeout = 5 * time.Second
+	echoTimeout  = time.Second
+	// drainTimeout bounds waiting for the responder to count an upload after the last write.
+	drainTimeout = 15 * time.Second
+)
+
+var magic = [4]byte{'N', 'B', 'B', 'M'}
+
+var (
+	// ErrNotSupported is returned when the peer does not answer the handshake of the responder,
+	// e.g. because it runs an older version, blocks inbound connections or runs in netstack mode.
+	ErrNotSupported = errors.New("peer does not support benchmarks")
+	// ErrBusy is returned when the peer is serving another benchmark.
+	ErrBusy = errors.New("peer is running another benchmark")
+)
+
+// Transfer is the data moved in one direction of a benchmark.
+type Transfer struct {
+	Bytes    int64
+	Duration time.Duration
+}
+
+// Mbps returns the throughput of the transfer in megabits per second.
+func (t Transfer) Mbps() float64 {
+	if t.Duration <= 0 {
+		return 0
+	}
+	return float64(t.Bytes) * 8 / t.Duration.Seconds() / 1e6
+}
+
+// Result is the outcome of a benchmark.
+type Result struct {
+	// Upload is the data sent to the peer, Download the data received from it.
+	Upload   Transfer
+	Download Transfer
+	// RTTs holds the round trip times of the answered echo packets.
+	RTTs []time.Duration
+	// EchoLost is the number of echo packets without an answer.
+	EchoLost int
+}
+
+// Run benchmarks the responder at addr: EchoCount echo packets, then an upload and a download
+// for half of duration each. A transfer ends early when MaxBytes are moved.
+func Run(ctx context.Context, addr netip.AddrPort, duration time.Duration) (Result, error) {
+	if duration <= 0 || duration > MaxDuration {
+		return Result{}, fmt.Errorf("duration must be between 0 and %s", MaxDuration)
+	}
+
+	conn, err := request(ctx, addr, opHello)
+	if err != nil {
+		return Result{}, err
+	}
+	_ = conn.Close()
+
+	var res Result
+	res.RTTs, res.EchoLost = echo(ctx, addr)
+
+	if res.Upload, err = upload(ctx, addr, duration/2); err != nil {
+		return res, fmt.Errorf("upload: %w", err)
+	}
+	if res.Download, err = download(ctx, addr, duration/2); err != nil {
+		return res, fmt.Errorf("download: %w", err)
+	}
+	return res, nil
+}
+
+// request connects to the responder and sends the operation. A responder that does not answer
+// with the protocol reply fails with ErrNotSupported. The connection is closed when ctx is done.
+func request(ctx context.Context, addr netip.AddrPort, op byte) (*net.TCPConn, error) {
+	dialer := net.Dialer{Timeout: dialTimeout}
+	c, err := dialer.DialContext(ctx, "tcp", addr.String())
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("%w: no responder on port %d: %v", ErrNotSupported, addr.Port(), err)
+	}
+	conn := c.(*net.TCPConn)
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+
+	fail := func(err error) (*net.TCPConn, error) {
+		stop()
+		_ = conn.Close()
+		return nil, err
+	}
+
+	req := make([]byte, requestLen)
+	copy(req, magic[:])
+	req[4] = version
+	req[5] = op
+	binary.BigEndian.PutUint64(req[6:], MaxBytes)
+
+	_ = conn.SetDeadline(time.Now().Add(replyTimeout))
+	if _, err := conn.Write(req); err != nil {
+		return fail(fmt.Errorf("send request: %w", err))
+	}
+	reply := make([]byte, replyLen)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return fail(fmt.Errorf("%w: no handshake reply: %v", ErrNotSupported, err))
+	}
+	if [4]byte(reply[:4]) != magic {
+		return fail(fmt.Errorf("%w: unexpected handshake reply", ErrNotSupported))
+	}
+
+	switch reply[5] {
+	case statusOK:
+	case statusBusy:
+		return fail(ErrBusy)
+	default:
+		return fail(fmt.Errorf("%w: the peer runs benchmark protocol version %d, this peer %d", ErrNotSupported, reply[4], version))
+	}
+	_ = conn.SetDeadline(time.Time{})
+	return conn, nil
+}
+
+// echo sends EchoCount echo packets one after the other and waits up to echoTimeout for each answer.
+func echo(ctx context.Context, addr netip.AddrPort) ([]time.Duration, int) {
+	conn, err := net.DialUDP("udp", nil, net.UDPAddrFromAddrPort(addr))
+	if err != nil {
+		return nil, EchoCount
+	}
+	defer conn.Close()
+
+	var rtts []time.Duration
+	packet := make([]byte, echoLen)
+	reply := make([]byte, echoLen)
+	copy(packet, magic[:])
+	packet[4] = version
+	for seq := uint32(0); seq < EchoCount && ctx.Err() == nil; seq++ {
+		binary.BigEndian.PutUint32(packet[5:], seq)
+		sent := time.Now()
+		if _, err := conn.Write(packet); err != nil {
+			continue
+		}
+		if waitEcho(conn, reply, seq, sent.Add(echoTimeout)) {
+			rtts = append(rtts, time.Since(sent))
+		}
+	}
+	return rtts, EchoCount - len(rtts)
+}
+
+// waitEcho reads until the answer to seq arrives, skipping late answers to earlier packets.
+func waitEcho(conn *net.UDPConn, buf []byte, seq uint32, deadline time.Time) bool {
+	_ = conn.SetReadDeadline(deadline)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return false
+		}
+		if n == echoLen && [4]byte(buf[:4]) == magic && binary.BigEndian.Uint32(buf[5:]) == seq {
+			return true
+		}
+	}
+}
+
+// upload writes to the responder for duration, then waits for the number of bytes it received.
+// The duration includes draining the data still in flight.
+func upload(ctx context.Context, addr netip.AddrPort, duration time.Duration) (Transfer, error) {
+	conn, err := request(ctx, addr, opUpload)
+	if err != nil {
+		return Transfer{}, err
+	}
+	defer conn.Close()
+
+	buf := make([]byte, chunkSize)
+	start := time.Now()
+	end := start.Add(duration)
+	_ = conn.SetWriteDeadline(end.Add(drainTimeout))
+	for sent := int64(0); sent < MaxBytes && time.Now().Before(end); {
+		n, err := conn.Write(buf[:min(int64(len(buf)), MaxBytes-sent)])
+		sent += int64(n)
+		if err != nil {
+			return Transfer{}, contextErr(ctx, err)
+		}
+	}
+	if err := conn.CloseWrite(); err != nil {
+		return Transfer{}, contextErr(ctx, err)
+	}
+
+	_ = conn.SetReadDeadline(time.Now().Add(drainTimeout))
+	var received [8]byte
+	if _, err := io.ReadFull(conn, received[:]); err != nil {
+		return Transfer{}, fmt.Errorf("read received bytes: %w", contextErr(ctx, err))
+	}
+	return Transfer{
+		Bytes:    int64(binary.BigEndian.Uint64(received[:])),
+		Duration: time.Since(start),
+	}, nil
+}
+
+// download reads from the responder for duration or until MaxBytes arrived.
+func download(ctx context.Context, addr netip.AddrPort, duration time.Duration) (Transfer, error) {
+	conn, err := request(ctx, addr, opDownload)
+	if err != nil {
+		return Transfer{}, err
+	}
+	defer conn.Close()
+
+	buf := make([]byte, chunkSize)
+	start := time.Now()
+	_ = conn.SetReadDeadline(start.Add(duration))
+	var received int64
+	for received < MaxBytes {
+		n, err := conn.Read(buf)
+		received += int64(n)
+		if errors.Is(err, io.EOF) || errors.Is(err, os.ErrDeadlineExceeded) {
+			break
+		}
+		if err != nil {
+			return Transfer{}, contextErr(ctx, err)
+		}
+	}
+	return Transfer{Bytes: received, Duration: time.Since(start)}, nil
+}
+
+// contextErr returns the context error instead of the error of a connection closed by it.
+func contextErr(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
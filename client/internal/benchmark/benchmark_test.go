@@ -0,0 +1,86 @@
+package benchmark
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func listenLoopback(t *testing.T, allowed netip.Prefix) *Responder {
+	t.Helper()
+	r, err := Listen(netip.MustParseAddrPort("127.0.0.1:0"), allowed)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = r.Close() })
+	return r
+}
+
+func TestRun(t *testing.T) {
+	r := listenLoopback(t, netip.MustParsePrefix("127.0.0.0/8"))
+
+	res, err := Run(context.Background(), r.Addr(), 200*time.Millisecond)
+	require.NoError(t, err)
+
+	assert.Len(t, res.RTTs, EchoCount)
+	assert.Zero(t, res.EchoLost)
+	assert.Positive(t, res.Upload.Bytes)
+	assert.LessOrEqual(t, res.Upload.Bytes, int64(MaxBytes))
+	assert.Positive(t, res.Upload.Mbps())
+	assert.Positive(t, res.Download.Bytes)
+	assert.LessOrEqual(t, res.Download.Bytes, int64(MaxBytes))
+	assert.Positive(t, res.Download.Mbps())
+}
+
+func TestRunNotSupported(t *testing.T) {
+	// a TCP server that does not speak the protocol
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		_, _ = conn.Write([]byte("SSH-2.0-OpenSSH\r\n"))
+		_ = conn.Close()
+	}()
+
+	_, err = Run(context.Background(), listener.Addr().(*net.TCPAddr).AddrPort(), time.Second)
+	require.ErrorIs(t, err, ErrNotSupported)
+
+	// nothing listening
+	addr := listener.Addr().(*net.TCPAddr).AddrPort()
+	require.NoError(t, listener.Close())
+	_, err = Run(context.Background(), addr, time.Second)
+	require.ErrorIs(t, err, ErrNotSupported)
+}
+
+func TestRunOutsideAllowedNetwork(t *testing.T) {
+	r := listenLoopback(t, netip.MustParsePrefix("100.64.0.0/10"))
+
+	_, err := Run(context.Background(), r.Addr(), time.Second)
+	require.ErrorIs(t, err, ErrNotSupported)
+}
+
+func TestRunBusy(t *testing.T) {
+	r := listenLoopback(t, netip.MustParsePrefix("127.0.0.0/8"))
+	r.transfer.Store(true)
+
+	_, err := Run(context.Background(), r.Addr(), time.Second)
+	require.ErrorIs(t, err, ErrBusy)
+}
+
+func TestRunDuration(t *testing.T) {
+	_, err := Run(context.Background(), netip.MustParseAddrPort("127.0.0.1:1"), 2*MaxDuration)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrNotSupported)
+}
+
+func TestTransferMbps(t *testing.T) {
+	assert.InDelta(t, 8.0, Transfer{Bytes: 1_000_000, Duration: time.Second}.Mbps(), 0.001)
+	assert.Zero(t, Transfer{Bytes: 1_000_000}.Mbps())
+}
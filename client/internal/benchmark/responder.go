@@ -0,0 +1,204 @@
+package benchmark
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// maxConns bounds the connections a responder serves at once, only one of them transfers data.
+const maxConns = 4
+
+// Responder answers the benchmarks of other peers: echo packets over UDP and transfers over TCP.
+// It serves one transfer at a time, bounded by MaxBytes and MaxDuration, and only to sources in
+// the allowed network.
+type Responder struct {
+	listener   *net.TCPListener
+	packetConn *net.UDPConn
+	allowed    netip.Prefix
+
+	conns    chan struct{}
+	transfer atomic.Bool
+	wg       sync.WaitGroup
+}
+
+// Listen starts a responder on addr, a port of zero picks a free one for both protocols.
+func Listen(addr netip.AddrPort, allowed netip.Prefix) (*Responder, error) {
+	listener, err := net.ListenTCP("tcp", net.TCPAddrFromAddrPort(addr))
+	if err != nil {
+		return nil, fmt.Errorf("listen tcp: %w", err)
+	}
+	bound := listener.Addr().(*net.TCPAddr).AddrPort()
+	packetConn, err := net.ListenUDP("udp", net.UDPAddrFromAddrPort(netip.AddrPortFrom(addr.Addr(), bound.Port())))
+	if err != nil {
+		_ = listener.Close()
+		return nil, fmt.Errorf("listen udp: %w", err)
+	}
+
+	r := &Responder{
+		listener:   listener,
+		packetConn: packetConn,
+		allowed:    allowed,
+		conns:      make(chan struct{}, maxConns),
+	}
+	r.wg.Add(2)
+	go r.serveTransfers()
+	go r.serveEcho()
+
+	log.Infof("benchmark responder listening on %s", bound)
+	return r, nil
+}
+
+// Addr returns the address the responder listens on.
+func (r *Responder) Addr() netip.AddrPort {
+	return r.listener.Addr().(*net.TCPAddr).AddrPort()
+}
+
+// Close stops the responder and waits for the running benchmarks to end.
+func (r *Responder) Close() error {
+	err := errors.Join(r.listener.Close(), r.packetConn.Close())
+	r.wg.Wait()
+	return err
+}
+
+func (r *Responder) serveTransfers() {
+	defer r.wg.Done()
+
+	var conns sync.WaitGroup
+	defer conns.Wait()
+
+	// open holds the accepted connections, closed when the listener is, to end running transfers.
+	var mu sync.Mutex
+	open := make(map[net.Conn]struct{})
+	defer func() {
+		mu.Lock()
+		for conn := range open {
+			_ = conn.Close()
+		}
+		mu.Unlock()
+	}()
+
+	for {
+		conn, err := r.listener.AcceptTCP()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				log.Debugf("benchmark responder stopped accepting: %v", err)
+			}
+			return
+		}
+		if !r.allowed.Contains(conn.RemoteAddr().(*net.TCPAddr).AddrPort().Addr().Unmap()) {
+			_ = conn.Close()
+			continue
+		}
+		select {
+		case r.conns <- struct{}{}:
+		default:
+			_ = conn.Close()
+			continue
+		}
+
+		mu.Lock()
+		open[conn] = struct{}{}
+		mu.Unlock()
+
+		conns.Add(1)
+		go func() {
+			defer conns.Done()
+			r.handle(conn)
+			_ = conn.Close()
+			mu.Lock()
+			delete(open, conn)
+			mu.Unlock()
+			<-r.conns
+		}()
+	}
+}
+
+func (r *Responder) handle(conn *net.TCPConn) {
+	_ = conn.SetDeadline(time.Now().Add(replyTimeout))
+	req := make([]byte, requestLen)
+	if _, err := io.ReadFull(conn, req); err != nil || [4]byte(req[:4]) != magic {
+		return
+	}
+
+	reply := make([]byte, replyLen)
+	copy(reply, magic[:])
+	reply[4] = version
+
+	op, size := req[5], min(binary.BigEndian.Uint64(req[6:]), MaxBytes)
+	switch {
+	case req[4] != version || op < opHello || op > opDownload:
+		reply[5] = statusUnsupported
+		_, _ = conn.Write(reply)
+		return
+	case op == opHello:
+		_, _ = conn.Write(reply)
+		return
+	}
+
+	if !r.transfer.CompareAndSwap(false, true) {
+		reply[5] = statusBusy
+		_, _ = conn.Write(reply)
+		return
+	}
+	defer r.transfer.Store(false)
+
+	_ = conn.SetDeadline(time.Now().Add(MaxDuration + drainTimeout))
+	if _, err := conn.Write(reply); err != nil {
+		return
+	}
+
+	peer := conn.RemoteAddr().String()
+	switch op {
+	case opUpload:
+		received, err := io.CopyN(io.Discard, conn, int64(size))
+		if err != nil && !errors.Is(err, io.EOF) {
+			log.Debugf("benchmark upload from %s ended after %d bytes: %v", peer, received, err)
+			return
+		}
+		var count [8]byte
+		binary.BigEndian.PutUint64(count[:], uint64(received))
+		_, _ = conn.Write(count[:])
+		log.Debugf("benchmark upload from %s: received %d bytes", peer, received)
+	case opDownload:
+		sent, err := io.CopyN(conn, zeroReader{}, int64(size))
+		log.Debugf("benchmark download to %s: sent %d bytes (%v)", peer, sent, err)
+	}
+}
+
+// serveEcho answers echo packets with the same packet, so an answer is never larger than a request.
+func (r *Responder) serveEcho() {
+	defer r.wg.Done()
+
+	buf := make([]byte, 2048)
+	for {
+		n, src, err := r.packetConn.ReadFromUDPAddrPort(buf)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			continue
+		}
+		if n != echoLen || [4]byte(buf[:4]) != magic || !r.allowed.Contains(src.Addr().Unmap()) {
+			continue
+		}
+		if _, err := r.packetConn.WriteToUDPAddrPort(buf[:n], src); err != nil {
+			log.Debugf("failed to answer benchmark echo of %s: %v", src, err)
+		}
+	}
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
@@ -32,8 +32,10 @@ import (
 	"github.com/netbirdio/netbird/client/internal/metrics"
 	"github.com/netbirdio/netbird/client/internal/peer"
 	"github.com/netbirdio/netbird/client/internal/profilemanager"
+	"github.com/netbirdio/netbird/client/internal/startuptiming"
 	"github.com/netbirdio/netbird/client/internal/statemanager"
 	"github.com/netbirdio/netbird/client/internal/stdnet"
+	"github.com/netbirdio/netbird/client/internal/syncstore"
 	"github.com/netbirdio/netbird/client/internal/updater"
 	"github.com/netbirdio/netbird/client/internal/updater/installer"
 	nbnet "github.com/netbirdio/netbird/client/net"
@@ -67,6 +69,7 @@ type ConnectClient struct {
 	engineMutex   sync.Mutex
 	clientMetrics *metrics.ClientMetrics
 	updateManager *updater.Manager
+	startupTiming *startuptiming.Recorder
 
 	persistSyncResponse bool
 }
@@ -94,6 +97,11 @@ func (c *ConnectClient) SetUpdateManager(um *updater.Manager) {
 	c.updateManager = um
 }
 
+// SetStartupTiming sets the recorder the startup phase durations are written to.
+func (c *ConnectClient) SetStartupTiming(r *startuptiming.Recorder) {
+	c.startupTiming = r
+}
+
 // Run with main logic.
 func (c *ConnectClient) Run(runningChan chan struct{}, logPath string) error {
 	if androidRunOverride != nil {
@@ -260,6 +268,7 @@ func (c *ConnectClient) run(mobileDependency MobileDependency, runningChan chan
 	defer func() {
 		c.statusRecorder.SetSessionExpiresAt(time.Time{})
 		c.statusRecorder.ClientStop()
+		c.statusRecorder.RecordStateTransition(peer.StateEventStopped, "")
 	}()
 	operation := func() error {
 		// if context cancelled we not start new backoff cycle
@@ -268,17 +277,26 @@ func (c *ConnectClient) run(mobileDependency MobileDependency, runningChan chan
 		}
 
 		state.Set(StatusConnecting)
+		c.statusRecorder.RecordStateTransition(peer.StateEventConnecting, c.config.ManagementURL.Host)
+		c.startupTiming.NextAttempt()
 
 		engineCtx, cancel := context.WithCancel(c.ctx)
 		defer func() {
 			_, err := state.Status()
+			reason := "engine stopped"
+			if err != nil {
+				reason = err.Error()
+			}
+			c.statusRecorder.RecordStateTransition(peer.StateEventDisconnected, reason)
 			c.statusRecorder.MarkManagementDisconnected(err)
 			c.statusRecorder.CleanLocalPeerState()
 			cancel()
 		}()
 
 		log.Debugf("connecting to the Management service %s", c.config.ManagementURL.Host)
+		done := c.startupTiming.Start(startuptiming.PhaseManagementConnect)
 		mgmClient, err := mgm.NewClient(engineCtx, c.config.ManagementURL.Host, myPrivateKey, mgmTlsEnabled)
+		done(err)
 		if err != nil {
 			// On daemon shutdown / Down() the parent context is cancelled
 			// and the dial fails with "context canceled". Wrapping that
@@ -313,12 +331,15 @@ func (c *ConnectClient) run(mobileDependency MobileDependency, runningChan chan
 
 		// connect (just a connection, no stream yet) and login to Management Service to get an initial global Netbird config
 		loginStarted := time.Now()
+		done = c.startupTiming.Start(startuptiming.PhaseManagementLogin)
 		loginResp, err := loginToManagement(engineCtx, mgmClient, publicSSHKey, c.config)
+		done(err)
 		if err != nil {
 			c.clientMetrics.RecordLoginDuration(engineCtx, time.Since(loginStarted), false)
 			log.Debug(err)
 			if s, ok := gstatus.FromError(err); ok && (s.Code() == codes.PermissionDenied) {
 				state.Set(StatusNeedsLogin)
+				c.statusRecorder.RecordStateTransition(peer.StateEventLoginRequired, err.Error())
 				c.runCancel()
 				return backoff.Permanent(wrapErr(err)) // unrecoverable error
 			}
@@ -353,7 +374,9 @@ func (c *ConnectClient) run(mobileDependency MobileDependency, runningChan chan
 		}()
 
 		// with the global Netbird config in hand connect (just a connection, no stream yet) Signal
+		done = c.startupTiming.Start(startuptiming.PhaseSignalConnect)
 		signalClient, err := connectToSignal(engineCtx, loginResp.GetNetbirdConfig(), myPrivateKey)
+		done(err)
 		if err != nil {
 			log.Error(err)
 			return wrapErr(err)
@@ -399,7 +422,10 @@ func (c *ConnectClient) run(mobileDependency MobileDependency, runningChan chan
 				}
 			}
 			log.Infof("connecting to the Relay service(s): %s", strings.Join(relayURLs, ", "))
-			if err = relayManager.Serve(); err != nil {
+			done = c.startupTiming.Start(startuptiming.PhaseRelayConnect)
+			err = relayManager.Serve()
+			done(err)
+			if err != nil {
 				log.Error(err)
 			}
 		}
@@ -417,12 +443,16 @@ func (c *ConnectClient) run(mobileDependency MobileDependency, runningChan chan
 			UpdateManager:  c.updateManager,
 			ClientMetrics:  c.clientMetrics,
 			MetricsCtx:     c.ctx,
+			StartupTiming:  c.startupTiming,
 		}, mobileDependency)
 		engine.SetSyncResponsePersistence(c.persistSyncResponse)
 		c.engine = engine
 		c.engineMutex.Unlock()
 
-		if err := engine.Start(loginResp.GetNetbirdConfig(), c.config.ManagementURL); err != nil {
+		done = c.startupTiming.Start(startuptiming.PhaseEngineStart)
+		err = engine.Start(loginResp.GetNetbirdConfig(), c.config.ManagementURL)
+		done(err)
+		if err != nil {
 			log.Errorf("error while starting Netbird Connection Engine: %s", err)
 			return wrapErr(err)
 		}
@@ -433,6 +463,7 @@ func (c *ConnectClient) run(mobileDependency MobileDependency, runningChan chan
 
 		log.Infof("Netbird engine started, the IP is: %s", peerConfig.GetAddress())
 		state.Set(StatusConnected)
+		c.statusRecorder.RecordStateTransition(peer.StateEventConnected, peerConfig.GetAddress())
 
 		if runningChan != nil {
 			select {
@@ -471,7 +502,7 @@ func (c *ConnectClient) run(mobileDependency MobileDependency, runningChan chan
 	// inter-attempt sleep — otherwise a 15s MaxInterval can keep the retry
 	// loop alive long after the caller asked to give up, leaving the
 	// status stream stuck at Connecting.
-	err = backoff.Retry(operation, backoff.WithContext(backOff, c.ctx))
+	err = backoff.RetryNotify(operation, backoff.WithContext(backOff, c.ctx), c.statusRecorder.RecordRetry)
 	if err != nil {
 		log.Debugf("exiting client retry loop due to unrecoverable error: %s", err)
 		if s, ok := gstatus.FromError(err); ok && (s.Code() == codes.PermissionDenied) {
@@ -527,6 +558,25 @@ func (c *ConnectClient) GetLatestSyncResponse() (*mgmProto.SyncResponse, error)
 	return syncResponse, nil
 }
 
+// GetNetworkMapHistory returns up to n stored sync responses, newest first.
+func (c *ConnectClient) GetNetworkMapHistory(n int) ([]syncstore.Snapshot, error) {
+	engine := c.Engine()
+	if engine == nil {
+		return nil, errors.New("engine is not initialized")
+	}
+
+	history, err := engine.GetNetworkMapHistory(n)
+	if err != nil {
+		return nil, fmt.Errorf("get network map history: %w", err)
+	}
+
+	if len(history) == 0 {
+		return nil, errors.New("sync response is not available")
+	}
+
+	return history, nil
+}
+
 // SetLogLevel sets the log level for the firewall manager if the engine is running.
 func (c *ConnectClient) SetLogLevel(level log.Level) {
 	engine := c.Engine()
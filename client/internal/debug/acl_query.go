@@ -0,0 +1,89 @@
+package debug
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const aclQueriesFile = "acl_queries.txt"
+
+// ACLQuery is a flow evaluated against the ACL policy of the daemon, e.g. with
+// "netbird debug acl" or "netbird debug bundle --acl-query".
+type ACLQuery struct {
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+	Protocol    string `json:"protocol"`
+	Port        uint32 `json:"port,omitempty"`
+	Allowed     bool   `json:"allowed"`
+	// Rule names the deciding rule, empty when no rule matched.
+	Rule   string `json:"rule,omitempty"`
+	Reason string `json:"reason,omitempty"`
+	// Error is set when the query could not be evaluated.
+	Error string `json:"error,omitempty"`
+}
+
+// Flow returns the queried flow, e.g. 100.64.0.2 -> 100.64.0.1 tcp/443.
+func (q ACLQuery) Flow() string {
+	flow := fmt.Sprintf("%s -> %s %s", q.Source, q.Destination, q.Protocol)
+	if q.Port != 0 {
+		flow += fmt.Sprintf("/%d", q.Port)
+	}
+	return flow
+}
+
+// FormatACLQuery renders an evaluated ACL query for the terminal and the bundle.
+func FormatACLQuery(q ACLQuery) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Flow: %s\n", q.Flow()))
+	if q.Error != "" {
+		sb.WriteString(fmt.Sprintf("Result: not evaluated: %s\n", q.Error))
+		return sb.String()
+	}
+
+	result := "denied"
+	if q.Allowed {
+		result = "allowed"
+	}
+	sb.WriteString(fmt.Sprintf("Result: %s\n", result))
+	if q.Rule != "" {
+		sb.WriteString(fmt.Sprintf("Rule: %s\n", q.Rule))
+	}
+	if q.Reason != "" {
+		sb.WriteString(fmt.Sprintf("Reason: %s\n", q.Reason))
+	}
+	return sb.String()
+}
+
+// addACLQueries writes the ACL queries requested with the bundle.
+func (g *BundleGenerator) addACLQueries() error {
+	if len(g.aclQueries) == 0 {
+		return nil
+	}
+
+	var sb strings.Builder
+	for i, q := range g.aclQueries {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		if g.anonymize {
+			q = g.anonymizeACLQuery(q)
+		}
+		sb.WriteString(FormatACLQuery(q))
+	}
+	if err := g.addFileToZip(strings.NewReader(sb.String()), aclQueriesFile); err != nil {
+		return fmt.Errorf("add ACL queries file to zip: %w", err)
+	}
+	log.Debugf("added %d ACL queries to debug bundle", len(g.aclQueries))
+	return nil
+}
+
+func (g *BundleGenerator) anonymizeACLQuery(q ACLQuery) ACLQuery {
+	q.Source = g.anonymizer.AnonymizeIPString(q.Source)
+	q.Destination = g.anonymizer.AnonymizeIPString(q.Destination)
+	q.Rule = g.anonymizer.AnonymizeString(q.Rule)
+	q.Reason = g.anonymizer.AnonymizeString(q.Reason)
+	q.Error = g.anonymizer.AnonymizeString(q.Error)
+	return q
+}
@@ -0,0 +1,59 @@
+package debug
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/netbirdio/netbird/version"
+)
+
+const buildInfoFile = "build.txt"
+
+// EnabledFeatures returns the names of the enabled feature flags, the experimental ones
+// separately.
+func EnabledFeatures(flags []FeatureFlag) (enabled, experimental []string) {
+	for _, f := range flags {
+		if !f.Enabled {
+			continue
+		}
+		if f.Experimental {
+			experimental = append(experimental, f.Name)
+		} else {
+			enabled = append(enabled, f.Name)
+		}
+	}
+	return enabled, experimental
+}
+
+// FormatBuildInfo renders the build metadata followed by the feature flag table.
+func FormatBuildInfo(build version.BuildInfo, flags []FeatureFlag) string {
+	_, experimental := EnabledFeatures(flags)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Version:      %s\n", build.Version))
+	sb.WriteString(fmt.Sprintf("Commit:       %s\n", orUnknown(build.Commit)))
+	sb.WriteString(fmt.Sprintf("Build date:   %s\n", orUnknown(build.Date)))
+	sb.WriteString(fmt.Sprintf("Go version:   %s\n", build.GoVersion))
+	sb.WriteString(fmt.Sprintf("Platform:     %s\n", build.Platform))
+	sb.WriteString(fmt.Sprintf("Experimental: %s\n", listOrNone(experimental)))
+	sb.WriteString("\n")
+	sb.WriteString(FormatFeatureFlags(flags))
+	return sb.String()
+}
+
+// addBuildInfo writes the build metadata of the daemon and its feature flags. Nothing in it
+// identifies the peer, so it is not anonymized.
+func (g *BundleGenerator) addBuildInfo() error {
+	flags := CollectFeatureFlags(g.internalConfig, g.syncResponse)
+	if err := g.addFileToZip(strings.NewReader(FormatBuildInfo(version.Build(), flags)), buildInfoFile); err != nil {
+		return fmt.Errorf("add build info file to zip: %w", err)
+	}
+	return nil
+}
+
+func listOrNone(items []string) string {
+	if len(items) == 0 {
+		return "none"
+	}
+	return strings.Join(items, ", ")
+}
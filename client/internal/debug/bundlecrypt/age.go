@@ -0,0 +1,32 @@
+package bundlecrypt
+
+import (
+	"fmt"
+	"io"
+
+	"filippo.io/age"
+)
+
+type ageRecipient struct {
+	recipient *age.X25519Recipient
+}
+
+func parseAgeRecipient(s string) (*ageRecipient, error) {
+	recipient, err := age.ParseX25519Recipient(s)
+	if err != nil {
+		return nil, fmt.Errorf("parse age recipient: %w", err)
+	}
+	return &ageRecipient{recipient: recipient}, nil
+}
+
+func (r *ageRecipient) Extension() string {
+	return ".age"
+}
+
+func (r *ageRecipient) Encrypt(w io.Writer) (io.WriteCloser, error) {
+	enc, err := age.Encrypt(w, r.recipient)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt to age recipient: %w", err)
+	}
+	return enc, nil
+}
@@ -0,0 +1,63 @@
+// Package bundlecrypt encrypts debug bundles to a recipient public key.
+//
+// Two key formats are accepted:
+//   - age X25519 recipients ("age1..."), as printed by age-keygen. Key files may contain
+//     comment lines starting with "#". The output is an age v1 file, decryptable with
+//     "age -d -i key.txt".
+//   - OpenPGP public keys, ASCII-armored or binary, as exported by "gpg --export [--armor]".
+//     The output is an OpenPGP message, decryptable with "gpg -d".
+package bundlecrypt
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+)
+
+// Recipient encrypts data to a public key.
+type Recipient interface {
+	// Encrypt returns a writer that encrypts to w. Closing it flushes the remaining ciphertext
+	// but does not close w.
+	Encrypt(w io.Writer) (io.WriteCloser, error)
+	// Extension is the file extension of the encrypted output, e.g. ".age".
+	Extension() string
+}
+
+// ParseRecipient parses an age recipient or an OpenPGP public key.
+func ParseRecipient(key []byte) (Recipient, error) {
+	key = bytes.TrimSpace(key)
+	if len(key) == 0 {
+		return nil, errors.New("empty key")
+	}
+
+	if bytes.Contains(key, []byte("PRIVATE KEY")) || bytes.Contains(key, []byte("AGE-SECRET-KEY-")) {
+		return nil, errors.New("a private key was given, use the recipient's public key instead")
+	}
+
+	if line := firstKeyLine(key); strings.HasPrefix(line, "age1") {
+		return parseAgeRecipient(line)
+	}
+
+	if bytes.HasPrefix(key, []byte("-----BEGIN PGP PUBLIC KEY BLOCK-----")) || isBinaryPGP(key) {
+		return parsePGPRecipient(key)
+	}
+
+	return nil, errors.New("unrecognized key format: expected an age X25519 recipient (age1...) or an OpenPGP public key")
+}
+
+// firstKeyLine returns the first line that is neither empty nor a comment.
+func firstKeyLine(key []byte) string {
+	for _, line := range strings.Split(string(key), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			return line
+		}
+	}
+	return ""
+}
+
+// isBinaryPGP reports whether the data starts with an OpenPGP packet tag.
+func isBinaryPGP(key []byte) bool {
+	return key[0]&0x80 != 0
+}
@@ -0,0 +1,105 @@
+package bundlecrypt
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+func TestParseRecipient(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	recipient := identity.Recipient().String()
+	badChecksum := []byte(recipient)
+	badChecksum[len(badChecksum)-1] = bech32Charset[(strings.IndexByte(bech32Charset, recipient[len(recipient)-1])+1)%32]
+
+	tests := []struct {
+		name    string
+		key     string
+		wantExt string
+		wantErr string
+	}{
+		{name: "age recipient", key: recipient, wantExt: ".age"},
+		{name: "age key file", key: "# created: 2025-01-01\n# public key: " + recipient + "\n" + recipient + "\n", wantExt: ".age"},
+		{name: "age identity", key: "AGE-SECRET-KEY-1QQQ", wantErr: "private key"},
+		{name: "bad checksum", key: string(badChecksum), wantErr: "checksum"},
+		{name: "empty", key: "  \n", wantErr: "empty key"},
+		{name: "garbage", key: "ssh-ed25519 AAAA", wantErr: "unrecognized key format"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseRecipient([]byte(tt.key))
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantExt, r.Extension())
+		})
+	}
+}
+
+func TestAgeRoundTrip(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	r, err := ParseRecipient([]byte(identity.Recipient().String()))
+	require.NoError(t, err)
+
+	const chunkSize = 64 * 1024
+	for _, size := range []int{0, 10, chunkSize, chunkSize*2 + 7} {
+		plaintext := make([]byte, size)
+		_, _ = rand.Read(plaintext)
+
+		var out bytes.Buffer
+		w, err := r.Encrypt(&out)
+		require.NoError(t, err)
+		_, err = w.Write(plaintext)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+
+		dec, err := age.Decrypt(&out, identity)
+		require.NoError(t, err)
+		decrypted, err := io.ReadAll(dec)
+		require.NoError(t, err)
+		assert.True(t, bytes.Equal(plaintext, decrypted), "size %d", size)
+	}
+}
+
+func TestPGPRoundTrip(t *testing.T) {
+	entity, err := openpgp.NewEntity("test", "", "test@example.com", nil)
+	require.NoError(t, err)
+
+	var key bytes.Buffer
+	aw, err := armor.Encode(&key, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(aw))
+	require.NoError(t, aw.Close())
+
+	r, err := ParseRecipient(key.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, ".gpg", r.Extension())
+
+	var out bytes.Buffer
+	w, err := r.Encrypt(&out)
+	require.NoError(t, err)
+	_, err = w.Write([]byte("bundle"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	md, err := openpgp.ReadMessage(&out, openpgp.EntityList{entity}, nil, nil)
+	require.NoError(t, err)
+	plaintext, err := io.ReadAll(md.UnverifiedBody)
+	require.NoError(t, err)
+	assert.Equal(t, "bundle", string(plaintext))
+}
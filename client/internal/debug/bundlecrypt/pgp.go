@@ -0,0 +1,43 @@
+package bundlecrypt
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+)
+
+type pgpRecipient struct {
+	entities openpgp.EntityList
+}
+
+func parsePGPRecipient(key []byte) (*pgpRecipient, error) {
+	var entities openpgp.EntityList
+	var err error
+	if bytes.HasPrefix(key, []byte("-----BEGIN")) {
+		entities, err = openpgp.ReadArmoredKeyRing(bytes.NewReader(key))
+	} else {
+		entities, err = openpgp.ReadKeyRing(bytes.NewReader(key))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse OpenPGP public key: %w", err)
+	}
+	if len(entities) == 0 {
+		return nil, errors.New("parse OpenPGP public key: no keys found")
+	}
+	return &pgpRecipient{entities: entities}, nil
+}
+
+func (r *pgpRecipient) Extension() string {
+	return ".gpg"
+}
+
+func (r *pgpRecipient) Encrypt(w io.Writer) (io.WriteCloser, error) {
+	enc, err := openpgp.Encrypt(w, r.entities, nil, &openpgp.FileHints{IsBinary: true}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt to OpenPGP key: %w", err)
+	}
+	return enc, nil
+}
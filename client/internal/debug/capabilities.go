@@ -0,0 +1,49 @@
+package debug
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+)
+
+const serverCapabilitiesFile = "server-capabilities.txt"
+
+// FormatServerCapabilities renders the capabilities of the last full sync as a table of all known
+// capabilities, so the ones the server did not offer are listed too.
+func FormatServerCapabilities(caps peer.ServerCapabilities) string {
+	if caps.ReceivedAt.IsZero() {
+		return "No full sync was received from the management server since the engine started.\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Last full sync: %s\n", caps.ReceivedAt.UTC().Format(time.RFC3339)))
+	sb.WriteString(fmt.Sprintf("Sync message version: %d\n\n", caps.SyncVersion))
+	sb.WriteString(fmt.Sprintf("%-28s %-8s %s\n", "Capability", "Offered", "Description"))
+	for _, c := range peer.KnownServerCapabilities {
+		offered := "no"
+		if caps.Offers(c.Name) {
+			offered = "yes"
+		}
+		sb.WriteString(fmt.Sprintf("%-28s %-8s %s\n", c.Name, offered, c.Description))
+	}
+	return sb.String()
+}
+
+// addServerCapabilities writes the capabilities the management server offered in its last full
+// sync. They hold no peer data and are not anonymized.
+func (g *BundleGenerator) addServerCapabilities() error {
+	if g.statusRecorder == nil {
+		log.Debug("skipping server capabilities in debug bundle: no status recorder")
+		return nil
+	}
+
+	content := FormatServerCapabilities(g.statusRecorder.GetServerCapabilities())
+	if err := g.addFileToZip(strings.NewReader(content), serverCapabilitiesFile); err != nil {
+		return fmt.Errorf("add %s to zip: %w", serverCapabilitiesFile, err)
+	}
+	return nil
+}
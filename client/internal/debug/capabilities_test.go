@@ -0,0 +1,25 @@
+package debug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+)
+
+func TestFormatServerCapabilities(t *testing.T) {
+	assert.Contains(t, FormatServerCapabilities(peer.ServerCapabilities{}), "No full sync")
+
+	out := FormatServerCapabilities(peer.ServerCapabilities{
+		SyncVersion: 1,
+		Offered:     []string{peer.ServerCapabilityRelay, peer.ServerCapabilityPostureChecks},
+		ReceivedAt:  time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+	})
+	assert.Contains(t, out, "Last full sync: 2025-01-01T10:00:00Z\n")
+	assert.Contains(t, out, "Sync message version: 1\n")
+	assert.Regexp(t, `(?m)^relay\s+yes\s`, out)
+	assert.Regexp(t, `(?m)^posture-checks\s+yes\s`, out)
+	assert.Regexp(t, `(?m)^flow-logging\s+no\s`, out)
+}
@@ -0,0 +1,28 @@
+package debug
+
+import (
+	"fmt"
+	"strings"
+)
+
+const commandOutputFile = "command-output.txt"
+
+// MaxCommandOutput caps the command output sent with a bundle request, keeping it well below
+// the gRPC message size limit.
+const MaxCommandOutput = 1 << 20
+
+// addCommandOutput writes the output of the commands run with "netbird debug for --run".
+func (g *BundleGenerator) addCommandOutput() error {
+	if len(g.commandOutput) == 0 {
+		return nil
+	}
+
+	content := string(g.commandOutput)
+	if g.anonymize {
+		content = g.anonymizer.AnonymizeString(content)
+	}
+	if err := g.addFileToZip(strings.NewReader(content), commandOutputFile); err != nil {
+		return fmt.Errorf("add command output file to zip: %w", err)
+	}
+	return nil
+}
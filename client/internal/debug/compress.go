@@ -0,0 +1,69 @@
+package debug
+
+import (
+	"archive/zip"
+	"fmt"
+	"strings"
+)
+
+// Compression selects how the files in the bundle archive are compressed.
+type Compression string
+
+const (
+	// CompressionNone stores the files uncompressed.
+	CompressionNone Compression = "none"
+	// CompressionGzip deflates the files, like gzip does. It is the default.
+	CompressionGzip Compression = "gzip"
+	// CompressionZstd compresses the files with zstd (zip method 93). Older unzip tools cannot
+	// extract such archives, 7-Zip and bsdtar can.
+	CompressionZstd Compression = "zstd"
+)
+
+// zstdBundleExtension replaces .zip in the names of zstd bundles, so they are not mistaken for
+// archives every unzip tool can extract.
+const zstdBundleExtension = ".zst.zip"
+
+// ParseCompression parses a compression name, defaulting to gzip when empty. zstd fails when it
+// is not compiled into this build.
+func ParseCompression(compression string) (Compression, error) {
+	switch c := Compression(strings.ToLower(compression)); c {
+	case "":
+		return CompressionGzip, nil
+	case CompressionNone, CompressionGzip:
+		return c, nil
+	case CompressionZstd:
+		if !zstdAvailable {
+			return "", fmt.Errorf("zstd compression is not available in this build")
+		}
+		return c, nil
+	default:
+		return "", fmt.Errorf("unknown compression %q, use none, gzip or zstd", compression)
+	}
+}
+
+func (c Compression) zipMethod() uint16 {
+	switch c {
+	case CompressionNone:
+		return zip.Store
+	case CompressionZstd:
+		return zstdMethod
+	default:
+		return zip.Deflate
+	}
+}
+
+// bundlePattern adjusts the extension of a bundle file pattern to the compression.
+func (c Compression) bundlePattern(pattern string) string {
+	if c != CompressionZstd {
+		return pattern
+	}
+	return strings.TrimSuffix(pattern, ".zip") + zstdBundleExtension
+}
+
+// registerCompression makes the archive writer support the compression.
+func (c Compression) registerCompression(archive *zip.Writer) error {
+	if c != CompressionZstd {
+		return nil
+	}
+	return registerZstdCompressor(archive)
+}
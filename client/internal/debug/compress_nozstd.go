@@ -0,0 +1,22 @@
+//go:build nozstd
+
+package debug
+
+import (
+	"archive/zip"
+	"errors"
+)
+
+// zstdMethod is the zip compression method of zstd, see APPNOTE.TXT 4.4.5.
+const zstdMethod uint16 = 93
+
+// zstdAvailable is false in builds with the nozstd tag, which leave out the zstd encoder.
+const zstdAvailable = false
+
+func registerZstdCompressor(*zip.Writer) error {
+	return errors.New("zstd compression is not available in this build")
+}
+
+func registerZstdDecompressor(*zip.Reader) {
+	// zstd entries fail to open with zip.ErrAlgorithm
+}
@@ -0,0 +1,64 @@
+package debug
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCompression(t *testing.T) {
+	c, err := ParseCompression("")
+	require.NoError(t, err)
+	assert.Equal(t, CompressionGzip, c)
+
+	c, err = ParseCompression("NONE")
+	require.NoError(t, err)
+	assert.Equal(t, CompressionNone, c)
+
+	_, err = ParseCompression("brotli")
+	assert.Error(t, err)
+}
+
+func TestBundleCompression(t *testing.T) {
+	for _, c := range []Compression{CompressionNone, CompressionGzip, CompressionZstd} {
+		t.Run(string(c), func(t *testing.T) {
+			if c == CompressionZstd && !zstdAvailable {
+				t.Skip("built without zstd")
+			}
+
+			path := filepath.Join(t.TempDir(), c.bundlePattern("bundle.zip"))
+			f, err := os.Create(path)
+			require.NoError(t, err)
+
+			g := NewBundleGenerator(GeneratorDependencies{}, BundleConfig{Compression: c})
+			g.archive = zip.NewWriter(f)
+			require.NoError(t, g.compression.registerCompression(g.archive))
+			require.NoError(t, g.addFileToZip(strings.NewReader(strings.Repeat("log line\n", 100)), "client.log"))
+			require.NoError(t, g.addManifest())
+			require.NoError(t, g.addChecksums())
+			require.NoError(t, g.archive.Close())
+			require.NoError(t, f.Close())
+
+			result, err := VerifyBundle(path)
+			require.NoError(t, err)
+			assert.True(t, result.OK())
+
+			archive, err := zip.OpenReader(path)
+			require.NoError(t, err)
+			defer func() {
+				_ = archive.Close()
+			}()
+			for _, file := range archive.File {
+				assert.Equal(t, c.zipMethod(), file.Method, file.Name)
+			}
+		})
+	}
+
+	assert.Equal(t, "netbird.debug.*.zst.zip", CompressionZstd.bundlePattern(bundleFilePattern))
+	assert.Equal(t, bundleFilePattern, CompressionNone.bundlePattern(bundleFilePattern))
+}
@@ -0,0 +1,44 @@
+//go:build !nozstd
+
+package debug
+
+import (
+	"archive/zip"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// zstdMethod is the zip compression method of zstd, see APPNOTE.TXT 4.4.5.
+const zstdMethod uint16 = 93
+
+const zstdAvailable = true
+
+func registerZstdCompressor(archive *zip.Writer) error {
+	archive.RegisterCompressor(zstdMethod, func(out io.Writer) (io.WriteCloser, error) {
+		return zstd.NewWriter(out)
+	})
+	return nil
+}
+
+func registerZstdDecompressor(archive *zip.Reader) {
+	archive.RegisterDecompressor(zstdMethod, func(in io.Reader) io.ReadCloser {
+		decoder, err := zstd.NewReader(in)
+		if err != nil {
+			return errReadCloser{err: err}
+		}
+		return decoder.IOReadCloser()
+	})
+}
+
+type errReadCloser struct {
+	err error
+}
+
+func (r errReadCloser) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+func (r errReadCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,110 @@
+package debug
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+)
+
+const connectionsFile = "connections.txt"
+
+// ChannelStateSource provides the gRPC channel states of the management and signal clients.
+type ChannelStateSource interface {
+	ServerChannelStates() (management, signal string)
+}
+
+// addConnections writes the state and history of the management and signal connections and the
+// client retry loop, as tracked by the status recorder.
+func (g *BundleGenerator) addConnections() error {
+	if g.statusRecorder == nil {
+		return nil
+	}
+
+	var mgmChannel, signalChannel string
+	if g.channelStates != nil {
+		mgmChannel, signalChannel = g.channelStates.ServerChannelStates()
+	}
+	mgmHistory, signalHistory := g.statusRecorder.GetConnectionHistory()
+	now := time.Now()
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Generated at: %s\n\n", now.Format(time.RFC3339)))
+
+	mgm := g.statusRecorder.GetManagementState()
+	sb.WriteString("Management:\n")
+	g.writeConnection(&sb, now, mgm.URL, mgm.Connected, mgm.Error, mgmChannel, mgmHistory)
+	sb.WriteString(fmt.Sprintf("  Last sync: %s\n", formatSince(now, g.statusRecorder.GetLastManagementSync())))
+
+	sig := g.statusRecorder.GetSignalState()
+	sb.WriteString("\nSignal:\n")
+	g.writeConnection(&sb, now, sig.URL, sig.Connected, sig.Error, signalChannel, signalHistory)
+
+	retry := g.statusRecorder.GetRetryState()
+	sb.WriteString("\nClient retry loop:\n")
+	if retry.Attempts == 0 {
+		sb.WriteString("  No failed attempts since the last management connection\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("  Failed attempts: %d\n", retry.Attempts))
+		sb.WriteString(fmt.Sprintf("  Last attempt: %s\n", formatSince(now, retry.LastAttempt)))
+		if retry.NextRetry.After(now) {
+			sb.WriteString(fmt.Sprintf("  Next retry: in %s\n", retry.NextRetry.Sub(now).Round(time.Second)))
+		}
+		if retry.LastError != "" {
+			sb.WriteString(fmt.Sprintf("  Last error: %s\n", g.anonymizeConnectionError(retry.LastError)))
+		}
+	}
+
+	return g.addFileToZip(strings.NewReader(sb.String()), connectionsFile)
+}
+
+func (g *BundleGenerator) writeConnection(sb *strings.Builder, now time.Time, url string, connected bool, err error, channel string, history peer.ConnectionHistory) {
+	if g.anonymize {
+		url = g.anonymizer.AnonymizeURI(url)
+	}
+	state := "disconnected"
+	if connected {
+		state = "connected"
+	}
+
+	sb.WriteString(fmt.Sprintf("  URL: %s\n", url))
+	sb.WriteString(fmt.Sprintf("  State: %s\n", state))
+	if channel != "" {
+		sb.WriteString(fmt.Sprintf("  gRPC channel: %s\n", channel))
+	}
+	if err != nil {
+		sb.WriteString(fmt.Sprintf("  Error: %s\n", g.anonymizeConnectionError(err.Error())))
+	}
+	sb.WriteString(fmt.Sprintf("  Last connected: %s\n", formatSince(now, history.LastConnected)))
+	sb.WriteString(fmt.Sprintf("  Last disconnected: %s\n", formatSince(now, history.LastDisconnected)))
+	sb.WriteString(fmt.Sprintf("  Disconnects: %d\n", history.Disconnects))
+
+	if len(history.Errors) == 0 {
+		return
+	}
+	sb.WriteString("  Recent errors (oldest first):\n")
+	for _, e := range history.Errors {
+		sb.WriteString(fmt.Sprintf("    %s", e.Last.Format(time.RFC3339)))
+		if e.Count > 1 {
+			sb.WriteString(fmt.Sprintf(" (%dx since %s)", e.Count, e.First.Format(time.RFC3339)))
+		}
+		sb.WriteString(fmt.Sprintf(": %s\n", g.anonymizeConnectionError(e.Error)))
+	}
+}
+
+// anonymizeConnectionError anonymizes the addresses and domains in an error message, which
+// often names the server or the local address.
+func (g *BundleGenerator) anonymizeConnectionError(msg string) string {
+	if !g.anonymize {
+		return msg
+	}
+	return g.anonymizer.AnonymizeString(msg)
+}
+
+func formatSince(now, t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return fmt.Sprintf("%s (%s ago)", t.Format(time.RFC3339), now.Sub(t).Round(time.Second))
+}
@@ -5,6 +5,8 @@ import (
 	"bufio"
 	"bytes"
 	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -12,8 +14,10 @@ import (
 	"io/fs"
 	"net"
 	"net/netip"
+	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"runtime/pprof"
 	"sort"
@@ -26,8 +30,11 @@ import (
 
 	"github.com/netbirdio/netbird/client/anonymize"
 	"github.com/netbirdio/netbird/client/configs"
+	firewall "github.com/netbirdio/netbird/client/firewall/manager"
 	"github.com/netbirdio/netbird/client/internal/peer"
 	"github.com/netbirdio/netbird/client/internal/profilemanager"
+	"github.com/netbirdio/netbird/client/internal/startuptiming"
+	"github.com/netbirdio/netbird/client/internal/syncstore"
 	"github.com/netbirdio/netbird/client/internal/updater/installer"
 	nbstatus "github.com/netbirdio/netbird/client/status"
 	mgmProto "github.com/netbirdio/netbird/shared/management/proto"
@@ -38,11 +45,26 @@ const readmeContent = `Netbird debug bundle
 This debug bundle contains the following files.
 If the --anonymize flag is set, the files are anonymized to protect sensitive information.
 
-status.txt: Anonymized status information of the NetBird client.
-client.log: Most recent, anonymized client log file of the NetBird client.
+manifest.json: Bundle metadata (generation time, versions, platform, whether anonymization and system info collection were enabled) and the list of all files in the bundle with their size and SHA-256 checksum. For bundles collected remotely it also records the peer's clock offset relative to the management server. When the management server enforces a debug bundle policy, the applied policy is recorded as well.
+SHA256SUMS: SHA-256 checksums of all other files in the bundle. Verify the extracted bundle with "sha256sum -c SHA256SUMS".
+status.txt: Anonymized status information of the NetBird client, including the connection history of each peer (the last 32 ICE and relay connection changes, sent offers and WireGuard handshake timeouts since the daemon started), the daemon uptime with the number of daemon restarts, and connected peers marked as stale when their last WireGuard handshake is older than 3 minutes. The exit nodes are listed with each candidate peer, its connection health and whether it is selected and active, i.e. the peer traffic of a selected exit node leaves through. When management sends peer groups, the peer details are listed per group with the connected and total peers of each. Only the members of a group are included when --group was provided. With --domain only the peers, nameserver groups and networks related to that domain are included. Omitted when --status-format=json was provided.
+offline.txt: Only present in bundles created with "netbird debug bundle --offline" by the CLI while the daemon was not running. Lists what the bundle is missing because only the daemon has it, like status.txt and network_map.json, and the log file that was read.
+status.json: Anonymized status information in the JSON format of "netbird status --json", for automated processing. Only present when --status-format was json or both. "netbird debug diff" compares it and config.json between two bundles.
+reachability.csv: The reachability of each peer from this node, one row per peer sorted by name: "direct" for a P2P connection, "relay" for a relayed one, "idle" for a peer without a tunnel because lazy connections open it on demand, and "unreachable" otherwise, with the status and its last change, the ICE candidate types, the relay address, the latency and whether the WireGuard handshake is stale. It is this node's row of the mesh reachability matrix; the management server does not aggregate a cross-peer view, so compare the files of the bundles of several peers instead. Covers the same peers as status.txt and is anonymized the same way.
+client.log: Most recent, anonymized client log file of the NetBird client. Bundles of "netbird debug for" also contain the lines of the log copy taken when the session started that the current log no longer has, e.g. after a daemon restart, above a "staged at the start of the debug session" marker line.
 netbird.err: Most recent, anonymized stderr log file of the NetBird client.
 netbird.out: Most recent, anonymized stdout log file of the NetBird client.
-routes.txt: Detailed system routing table in tabular format including destination, gateway, interface, metrics, and protocol information, if --system-info flag was provided.
+log-filter.txt: Present when --log-include or --log-exclude filtered the log lines. Lists the number of patterns and the lines removed from each log, but not the patterns or the removed lines.
+os.txt: Operating system, kernel, virtualization, hostname (anonymized if --anonymize is set), boot time and uptime. Like the other system information files, only present when its collector was selected with --system-info (all by default, see "netbird debug bundle --list-system-info"); manifest.json lists the collectors that ran.
+cpu.txt: CPU model, physical and logical core counts, utilization over one second and load averages.
+mem.txt: Physical memory and swap usage, and the memory the daemon itself uses.
+disk.txt: Size and free space of the file systems holding the config, state, log and temp directories. A full disk breaks config writes and log rotation.
+env.txt: Environment variables of the daemon. Values of variables with secret-looking names (key, token, secret, password, credential) and values that look like tokens (JWTs, long random strings, URLs with a password) are always masked as ***, other values are anonymized if --anonymize is set.
+routes.txt: Detailed system routing table in tabular format including destination, gateway, interface, metrics, and protocol information, if --system-info flag was provided. Where the routing table cannot be read, the file states why.
+wireguard-impl.txt: The WireGuard implementation of the daemon: the kernel module or the userspace wireguard-go, optionally on the gVisor netstack, with the module version, the interface name and type (e.g. wireguard, tun, utun, wintun) and the TUN driver and kernel release where available. Only present while the interface exists.
+wireguard.txt: Live state of the WireGuard interface in "wg show" format: public keys, endpoints, allowed IPs, latest handshake and transfer counters of each peer. Endpoints and allowed IPs are anonymized.
+interface-stats.txt: Traffic counters of the network interfaces that are up (bytes, packets, errors and drops received and sent) and the bytes sent and received over the WireGuard interface, summed over its peers. Bundles of "netbird debug for" also have the change of the counters since the start of the session, which shows whether traffic was flowing. Mobile platforms only have the WireGuard counters.
+resource-timeline.csv: Only in bundles of "netbird debug for". The goroutine count, open file descriptors, heap and total memory of the Go runtime and the GC cycles of the daemon, sampled every second during the session, to spot leaks as steady growth. The file descriptor column is empty on Windows.
 interfaces.txt: Anonymized network interface information, if --system-info flag was provided.
 ip_rules.txt: Detailed IP routing rules in tabular format including priority, source, destination, interfaces, table, and action information (Linux only), if --system-info flag was provided.
 iptables.txt: Anonymized iptables (IPv4) rules with packet counters, if --system-info flag was provided.
@@ -52,11 +74,38 @@ nftables.txt: Anonymized nftables rules with packet counters across all families
 sysctls.txt: Forwarding, reverse-path filter, source-validation, and conntrack accounting sysctl values that the NetBird client may read or modify, if --system-info flag was provided (Linux only).
 resolv.conf: DNS resolver configuration from /etc/resolv.conf (Unix systems only), if --system-info flag was provided.
 scutil_dns.txt: DNS configuration from scutil --dns (macOS only), if --system-info flag was provided.
-resolved_domains.txt: Anonymized resolved domain IP addresses from the status recorder.
+resolved_domains.txt: Anonymized resolved domain IP addresses from the status recorder. With --domain only the domain routes matching that domain are included.
+dns.txt: DNS configuration of the NetBird DNS server: listen address, the host manager that configures the system resolver, match and search domains, upstream nameservers per domain with their last success and failure, NetBird-managed zones and records, and the host resolvers used as fallback. Domains and addresses are anonymized when anonymization is enabled. With --domain only the domains, nameserver groups, zones and records on the resolution path of that domain are included.
+dns-stats.txt: The most recent DNS queries the upstream nameservers did not answer with records since the DNS server started, with the upstreams tried and their errors, and the hits, misses and refreshes of the management domains cache. A failure of kind "upstream" means no upstream answered (timeout, unreachable, SERVFAIL or REFUSED), an upstream failing many names points at a misconfigured nameserver. A failure of kind "name" means an upstream answered that the name does not exist (NXDOMAIN). Queried names, addresses and errors are anonymized when anonymization is enabled. With --domain only the failures of names matching that domain are listed.
 config.txt: Anonymized configuration information of the NetBird client.
-network_map.json: Anonymized sync response containing peer configurations, routes, DNS settings, and firewall rules.
+config.json: The effective configuration of the daemon in the format of the profile file, with the private, pre-shared and SSH keys redacted unless --allow-secrets was provided. Addresses are anonymized like in config.txt.
+build.txt: Build metadata of the daemon (version, commit, commit date, Go version, platform), the enabled experimental features and the feature flag table of features.txt. Not anonymized, it contains nothing that identifies the peer.
+features.txt: Effective state of the client's feature flags and the source (default, env, config, management) that decided each value.
+server-capabilities.txt: The capabilities the management server offered in its last full sync, e.g. flow logging, posture checks or SSH, derived from the settings it sent, and the sync message version. All known capabilities are listed with whether they were offered, a feature that is not offered is not available on the peer. Not anonymized, it contains no peer data.
+posture.txt: The results of the most recent posture check evaluation on this peer: each process check the management server asked for with whether its processes exist and run, whether it passes, and whether the results were reported to management. A process check passes when all of its processes run; the access of the policies requiring a failing check is denied. The management server makes the decision, the checks it evaluates from the system information alone, like the OS version or location, are not listed. With --anonymize the user name of paths in home directories is replaced.
+drop_stats.txt: Inbound packet drop counters of the userspace filter by reason (malformed, ACL denied, routing disabled, fragments, forwarder unavailable). Only present when the userspace filter is active.
+firewall.txt: The firewall rules NetBird installed, taken from the daemon's firewall manager: the NetBird iptables chains and the rules jumping to them, the NetBird nftables tables, or the peer, route and DNAT rules of the userspace filter followed by the rules of the native firewall it delegates routing to. Rules of other software are left out. Addresses are anonymized if --anonymize is set.
+startup_timing.txt: Duration of each phase of the latest client startup (config load, management login and sync, interface creation, route install) and the slowest phase.
+peer_mtu.txt: Probed path MTU of each connected peer, flagging peers below the tunnel MTU. Only present when --peer-mtu-probe was provided.
+peer_probes.txt: The results of "netbird debug ping" run in the hour before the bundle, e.g. during "netbird debug for": whether the peer was reachable, how long opening the connection took, the path (direct or relay), the selected ICE candidate pair and the RTTs of the echo requests sent through the tunnel. Only present when a ping ran. Peers and addresses are anonymized if --anonymize is set.
+acl_queries.txt: The flows given with --acl-query evaluated against the current ACL policy, like "netbird debug acl": whether each flow is allowed, the deciding rule and why. Only present when --acl-query was given. Addresses are anonymized if --anonymize is set.
+time.txt: The local time, the time zone, the NTP synchronization status reported by the system (timedatectl on Linux, systemsetup on macOS, w32tm on Windows) and the clock skew to the management server, measured from the Date header of its responses with an accuracy of about a second. A skew above 30 seconds is flagged, it makes login and relay authentication fail.
+proxy.txt: The proxy environment variables of the daemon (HTTP_PROXY, HTTPS_PROXY, NO_PROXY and ALL_PROXY, upper and lower case) and the proxy its HTTP clients, like the SSO login and the bundle upload, select for the management, signal and relay servers. The connections to these servers themselves are always direct, NetBird does not dial them through a proxy. Credentials in proxy URLs are never included, proxy hosts and NO_PROXY entries are anonymized if --anonymize is set.
+connections.txt: The management and signal connections: server URL, state, gRPC channel state, the last error, when they last connected and disconnected, the number of disconnects and up to 10 recent errors with their repeat counts, plus the last management sync and the failed attempts and next retry of the client retry loop. Collected from the daemon's connection state, not from the logs. URLs and addresses in errors are anonymized if --anonymize is set.
+interface_conflicts.txt: Host interface subnets that overlap the NetBird overlay network or routes through peers. Overlaps send traffic out of the wrong interface. Addresses are anonymized if --anonymize is set.
+route-conflicts.txt: Host routes in the main routing table that overlap routes installed by NetBird, each with the route that takes precedence and why. On Linux with policy routing the main table is looked up before the NetBird table, so any overlapping host route other than the default route wins; elsewhere the longest prefix wins, then the lowest metric. A winning host route makes traffic bypass NetBird, a winning NetBird route pulls host traffic into the tunnel. Addresses are anonymized if --anonymize is set. Not available on mobile platforms.
+nat.txt: The last address each STUN server reported for the ICE socket, the NAT mapping behavior derived from them (endpoint-dependent mapping means symmetric NAT, so direct connections often fall back to relay), and the ICE candidate types of the connected peers. Filtering behavior is not tested. Addresses are anonymized if --anonymize is set.
+mtu.txt: The configured MTU and the actual MTU of the NetBird interface, the MTU of the host interfaces that are up, and the path MTU of peers when --peer-mtu-probe is set. Host interfaces too small to carry full-size tunnel packets after the WireGuard overhead (60 bytes over IPv4, 80 over IPv6) are flagged, e.g. a PPPoE link (1492) with an MTU of 1420. Interface MTUs are read on all platforms; the NetBird interface is not found when the userspace netstack is used.
+command-output.txt: The combined stdout and stderr of the commands given to "netbird debug for" with --run, in the order they ran, with their start time and duration relative to the start of the session and their exit code. Anonymized if --anonymize is set. Only present when --run was given.
+traceroute.txt: Traceroutes taken by the CLI to the management, signal and connected relay servers when "netbird debug bundle" was run with --trace-infra, using the system traceroute with ICMP first and UDP as fallback, at most 20 hops per server. The hop addresses are anonymized consistently with the rest of the bundle if --anonymize is set.
+extra/: Files added with --include, named after their absolute path with separators replaced by "_". They are never anonymized, even if --anonymize is set, but secrets are masked like in all other files. Files larger than 10 MB and paths in directories that may hold credentials, like NetBird's state directory or ~/.ssh, are left out.
+network_map.json: Anonymized sync response containing peer configurations, routes, DNS settings, and firewall rules. "netbird debug replay" computes the routes and firewall rules of the peer from it without applying them.
+network_map-N.json: Older anonymized sync responses, 1 being the one before network_map.json. Only present when --network-map-count was greater than 1 and persistence kept a history.
+network_map_history.txt: Time each included network map was received and its serial. Only present with network_map-N.json files.
+events.json: The recent state transitions of the daemon, oldest first: connecting, connected, disconnected with the reason, login required, stopped, management and signal connected and disconnected, management config applied and network map updated, each with its time and a detail. The last 512 transitions since the daemon started are kept in memory, so they survive log rotation; "dropped" counts older ones. Bundles of "netbird debug for" have the session start in "session_start" and mark the transitions since then with "in_session". Details are anonymized if --anonymize is set.
 state.json: Anonymized client state dump containing netbird states for the active profile.
 service_params.json: Sanitized service install parameters (service.json). Sensitive environment variable values are masked. Only present when service.json exists.
+service.txt: The service manager's view of the daemon: the detected service manager (e.g. systemd, launchd, Windows service), the service state, and where available the unit properties including the restart count and the last 50 service manager log lines. Contains a note on platforms without a recognized service manager.
 metrics.txt: Buffered client metrics in InfluxDB line protocol format. Only present when metrics collection is enabled. Peer identifiers are anonymized.
 mutex.prof: Mutex profiling information.
 goroutine.prof: Goroutine profiling information.
@@ -65,10 +114,32 @@ heap.prof: Heap profiling information (snapshot of memory allocations).
 allocs.prof: Allocations profiling information.
 threadcreate.prof: Thread creation profiling information.
 cpu.prof: CPU profiling information.
+profiles/goroutine.txt, profiles/heap.pprof, profiles/cpu.pprof: Runtime profiles of the daemon process, taken when --profiles was provided: goroutine counts by stack, the live heap after a garbage collection, and a CPU profile of --profile-cpu-duration (5 seconds by default). They describe NetBird's own code and contain no user data, so they are never anonymized. Open the .pprof files with "go tool pprof".
 stack_trace.txt: Complete stack traces of all goroutines at the time of bundle creation.
-capture.pcap: Packet capture in pcap format. Only present when capture was running during bundle collection. Omitted from anonymized bundles because it contains raw decrypted packet data.
+capture.pcap: Packet capture in pcap format. Only present when capture was running during bundle collection. Omitted from anonymized bundles because it contains raw decrypted packet data, unless the capture was limited to a single peer with "netbird debug for --pcap <peer>". Such a capture is never anonymized. Captures are capped at 100 MB; later packets are dropped.
 
 
+Log Size Cap
+When --max-size was provided, the log files are capped to that size before compression. The newest log lines are kept, and older content is replaced with a line starting with "[netbird debug bundle:" that states how many bytes were truncated. Older rotated logs that no longer fit are left out. Status, system information and network map files are never truncated.
+
+Compression
+The files in the bundle are deflated unless --compress selected otherwise. With --compress none they are stored uncompressed. With --compress zstd they are compressed with zstd and the bundle is named *.zst.zip: extract it with 7-Zip, bsdtar or a recent unzip, older unzip versions do not support the method.
+
+Log Format
+The daemon writes its log as text lines unless it was switched to JSON lines with "netbird debug log format json" or started with NB_LOG_FORMAT=json. The "log_format" field of manifest.json records the format at bundle creation. Lines written before a switch keep the previous format, the switch itself is logged.
+
+Log Time Window
+When --since was provided, only log lines written within that duration before the bundle was created are included. Lines without a timestamp, like stack traces, are kept or dropped together with the preceding timestamped line. Rotated logs are selected by their modification time instead of --log-file-count. The captured window is recorded in the "log_window" section of manifest.json.
+
+Log Tail
+When --tail was provided, only the last lines of each log file are included, counted after --since and the --log-include and --log-exclude patterns. The undated header lines of a log are kept, and the left out lines are replaced with a line starting with "[netbird debug bundle:" that states how many there were. The staged logs marker line is kept when the lines around it are left out.
+
+No Logs
+Bundles created with --no-logs contain no log files, for a quick review of the config, status and network map. The log selection flags like --since and --tail are ignored, and "logs_omitted" is set in manifest.json.
+
+Incremental Logs
+Bundles created with --incremental only include the log content written since the previous bundle created with --incremental or --full. Each log that continues a log of that baseline bundle starts with a line beginning with "[netbird debug bundle:" that names the baseline bundle and its time and states how many bytes were left out. Rotated logs last written before the baseline bundle are left out, newer ones are selected by their modification time instead of --log-file-count. The baseline is recorded in the "incremental" section of manifest.json. A bundle created with --incremental without an earlier baseline includes the whole logs.
+
 Anonymization Process
 The files in this bundle have been anonymized to protect sensitive information. Here's how the anonymization was applied:
 
@@ -82,9 +153,18 @@ Reoccuring IP addresses are replaced with the same anonymized address.
 
 Note: The anonymized IP addresses in the status file do not match those in the log and routes files. However, the anonymized IP addresses are consistent within the status file and across the routes and log files.
 
+Anonymized values are chosen anew for every bundle unless --anon-map was provided. With --anon-map, the mapping file is loaded so the same original value gets the same anonymized value as in earlier bundles, new values are appended to the file, and the status file uses the same mapping as the other files. The mapping file reverses the anonymization and is never part of the bundle.
+
 Domains
 All domain names (except for the netbird domains) are replaced with randomly generated strings ending in ".domain". Anonymized domains are consistent across all files in the bundle.
 Reoccuring domain names are replaced with the same anonymized domain.
+With --anonymize-level ips-only, domain names, peer names and interface names are kept and only IP addresses are replaced. The manifest records the level as "anonymize_level".
+
+Custom Patterns
+With --anon-patterns, matches of the regular expressions in the given file, e.g. project names or internal DNS suffixes, are replaced with placeholders like REDACTED-1 in all anonymized files, including peer names in the status file, on every anonymization level. The same match gets the same placeholder across the bundle, and with --anon-map across bundles.
+
+Secrets
+Secrets are masked as *** in all text files of the bundle, whether --anonymize is set or not: the WireGuard private and pre-shared keys and the SSH key of the config, the relay, flow and TURN credentials of the network map, JWTs, and the values of fields named like a private key, pre-shared key, setup key or token, e.g. "setup-key=..." or "Authorization: Bearer ...". Known keys are also found in other encodings, like the hex form of the WireGuard UAPI. Binary files like profiles and packet captures are not changed. With --allow-secrets, meant for debugging on the machine itself only, these secrets are kept and config.json holds the keys. Environment variables in env.txt and service_params.json stay masked. The "secrets_masked" field of manifest.json records whether secrets were masked. A management policy that enforces anonymization also enforces masking.
 
 Sync Response
 The network_map.json file contains the following anonymized information:
@@ -123,6 +203,16 @@ This will open a web browser tab with the profiling information.
 Stack Trace
 The stack_trace.txt file contains a complete snapshot of all goroutine stack traces at the time the debug bundle was created.
 
+Manifest
+The manifest.json file describes the bundle itself. When the bundle was requested remotely, the "clock" section holds:
+- reference_time: Management server time when the request was dispatched
+- received_at: Local time when the peer received the request
+- offset_ms: Local clock minus the management clock, in milliseconds (includes the request delivery latency)
+
+To correlate logs from bundles collected on several peers at once, subtract each bundle's offset_ms from its local log timestamps.
+
+The "files" section lists every file in the bundle except manifest.json and SHA256SUMS, with its uncompressed size in bytes and its SHA-256 checksum. A file missing from the archive or with a different checksum indicates that the bundle was truncated or modified after it was created. Run "netbird debug verify <bundle.zip>" to check the bundle against both files.
+
 Routes
 The routes.txt file contains detailed routing table information in a tabular format:
 
@@ -241,6 +331,11 @@ const (
 
 	darwinErrorLogPath  = "/var/log/netbird.out.log"
 	darwinStdoutLogPath = "/var/log/netbird.err.log"
+
+	// bundleFilePattern is the os.CreateTemp pattern of the bundle file before encryption.
+	bundleFilePattern = "netbird.debug.*.zip"
+	// labeledBundleFilePattern is bundleFilePattern with a BundleConfig.Label.
+	labeledBundleFilePattern = "netbird.debug.%s.*.zip"
 )
 
 // MetricsExporter is an interface for exporting metrics
@@ -265,18 +360,216 @@ type BundleGenerator struct {
 	clientMetrics  MetricsExporter
 	daemonVersion  string
 	cliVersion     string
+	clockReference *ClockReference
+	dropStats      DropStatsSource
+	dnsState       DNSStateSource
+	startupTiming  *startuptiming.Recorder
+	peerMTU        *PeerMTUReport
+	peerProbes     []PeerProbe
+	aclQueries     []ACLQuery
+	policy         *BundlePolicy
+	// networkMapHistory holds the stored sync responses, newest first, including syncResponse.
+	networkMapHistory []syncstore.Snapshot
+	progress          func(stage string)
+	firewall          firewall.Manager
+	nat               NATSource
+	clockSkew         ClockSkewSource
+	channelStates     ChannelStateSource
+	wireGuardImpl     WireGuardImplSource
 
 	anonymize         bool
-	includeSystemInfo bool
+	includeSystemInfo SystemInfoSet
 	logFileCount      uint32
+	encryptionKey     []byte
+	statusFormat      StatusFormat
+	maxSize           int64
+	since             time.Duration
+	includeRawCapture bool
+	includeProfiles   bool
+	anonymizationMap  []anonymize.Mapping
+	stableAnonymizer  bool
+	anonymizePatterns []*regexp.Regexp
+	// allowSecrets keeps the secrets of the config and network maps, secrets masks them otherwise.
+	allowSecrets bool
+	secrets      *secretRedactor
+
+	// cpuProfileDuration is the length of the CPU profile taken with includeProfiles.
+	cpuProfileDuration time.Duration
+	// peerSelectors limits the peers in the status files.
+	peerSelectors []string
+	label         string
+	// peerGroup limits the peers in the status files to the members of this group ID.
+	peerGroup string
+	// domain scopes the status, DNS and resolved domain files to this domain.
+	domain string
+	// anonymizeIPsOnly keeps domain names in anonymized bundles.
+	anonymizeIPsOnly bool
+	// extraPaths are the --include patterns, copied under extra/ as they are.
+	extraPaths []string
+	// commandOutput is written to command-output.txt when set.
+	commandOutput []byte
+	// traceroute is written to traceroute.txt when set.
+	traceroute []byte
+	// interfaceStatsStart are the interface counters at the start of the session, see
+	// BundleConfig.InterfaceStatsStart.
+	interfaceStatsStart *InterfaceStats
+	// resourceTimeline are the runtime stats sampled during the session, see
+	// BundleConfig.ResourceTimeline.
+	resourceTimeline []RuntimeStats
+	// sessionStart is the start of the "debug for" session, zero for other bundles.
+	sessionStart time.Time
+	// stagedLogsDir holds log copies staged by StageLogs, merged with the current logs.
+	stagedLogsDir string
+	// compression of the files in the archive, CompressionGzip unless set.
+	compression Compression
+	// splitSize splits the bundle file into parts of at most this many bytes, zero writes one file.
+	splitSize int64
+	parts     []string
+	// offline adds offline.txt, the bundle was created without the daemon.
+	offline bool
+	// noLogs leaves out all logs.
+	noLogs bool
+
+	// logCutoff drops log lines written before it, zero keeps all lines.
+	logCutoff time.Time
+	// logFilter drops log lines by pattern, nil keeps all lines.
+	logFilter    *LogFilter
+	filteredLogs []filteredLog
+	// logTail keeps only the last lines of each log, zero keeps all lines.
+	logTail int
+	// logBaseline leaves out the log content of the previous incremental bundle, nil keeps all.
+	logBaseline *LogBaseline
+	// recordLogBaseline records where the active logs end in logEnds, for recordedBaseline.
+	recordLogBaseline bool
+	logEnds           map[string]LogOffset
+	recordedBaseline  *LogBaseline
+	// logBudget is the uncompressed log content that still fits maxSize.
+	logBudget     int64
+	truncatedLogs []TruncatedLog
 
 	archive *zip.Writer
+	// files records every file added to the archive for the manifest and SHA256SUMS.
+	files []manifestFile
+}
+
+// StatusFormat selects the status files written to the bundle.
+type StatusFormat string
+
+const (
+	StatusFormatText StatusFormat = "text"
+	StatusFormatJSON StatusFormat = "json"
+	StatusFormatBoth StatusFormat = "both"
+)
+
+// ParseStatusFormat parses a status format, defaulting to text when empty.
+func ParseStatusFormat(format string) (StatusFormat, error) {
+	switch f := StatusFormat(strings.ToLower(format)); f {
+	case "":
+		return StatusFormatText, nil
+	case StatusFormatText, StatusFormatJSON, StatusFormatBoth:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unknown status format %q, use text, json or both", format)
+	}
 }
 
 type BundleConfig struct {
 	Anonymize         bool
-	IncludeSystemInfo bool
+	IncludeSystemInfo SystemInfoSet // The system information collectors to run, none if empty.
 	LogFileCount      uint32
+	// EncryptionKey is an age X25519 recipient (age1...) or an OpenPGP public key. When set, the
+	// bundle is encrypted while it is written and never stored as a plain zip.
+	EncryptionKey []byte
+	// StatusFormat selects status.txt, status.json or both. Empty means text.
+	StatusFormat StatusFormat
+	// MaxSize caps the uncompressed log content in bytes, zero means no cap. The newest log lines
+	// are kept and older content is truncated. Status and system information are always complete.
+	MaxSize int64
+	// Since keeps only log lines written within this duration before Generate, zero keeps all.
+	// When set, rotated logs are selected by age and LogFileCount is ignored.
+	Since time.Duration
+	// LogTail keeps only the last lines of each log file, counted after Since and LogFilter.
+	// Zero keeps all lines.
+	LogTail int
+	// LogBaseline leaves out the log content that was already in the bundle that recorded it, see
+	// LoadLogBaseline. Rotated logs last written before it are left out and LogFileCount is
+	// ignored, like with Since. Nil includes the whole logs.
+	LogBaseline *LogBaseline
+	// RecordLogBaseline records where the active logs end, see RecordedLogBaseline.
+	RecordLogBaseline bool
+	// IncludeProfiles adds a goroutine dump, a heap profile and a CPU profile of
+	// CPUProfileDuration (DefaultCPUProfileDuration when zero) under profiles/.
+	IncludeProfiles    bool
+	CPUProfileDuration time.Duration
+	// IncludeRawCapture keeps the packet capture in anonymized bundles. Packets cannot be
+	// anonymized, so this is only set for captures the user explicitly limited to one peer.
+	IncludeRawCapture bool
+	// AnonymizationMap seeds the anonymizer with the mappings of earlier bundles.
+	AnonymizationMap []anonymize.Mapping
+	// StableAnonymization anonymizes the status with the same mapping as the other files,
+	// so the mapping returned by AnonymizationMappings covers the whole bundle.
+	StableAnonymization bool
+	// AnonymizePatterns are replaced with placeholders like REDACTED-1 in anonymized files,
+	// including the status, see anonymize.Anonymizer.SetCustomPatterns.
+	AnonymizePatterns []*regexp.Regexp
+	// AllowSecrets keeps the private, pre-shared and SSH keys in config.json and the relay and TURN
+	// credentials in the network maps, and turns off the masking of secrets in all other files.
+	// Secrets are masked regardless of Anonymize unless this is set. Only for local debugging.
+	AllowSecrets bool
+
+	// PeerSelectors limits the peers in the status files to those matching any selector by
+	// FQDN prefix or NetBird IP, see nbstatus.SelectPeers. Empty includes all peers.
+	PeerSelectors []string
+	// PeerGroup limits the peers in the status files to the members of the group with this ID,
+	// see nbstatus.PeersInGroup. Empty includes all peers.
+	PeerGroup string
+	// Domain scopes status.txt, status.json, dns.txt and resolved_domains.txt to the resolution
+	// path and routes of this domain, see nbstatus.DomainMatches. Empty includes everything.
+	Domain string
+	// Label is added to the bundle file name, e.g. netbird.debug.<label>.*.zip. It must not
+	// contain path separators or "*".
+	Label string
+	// AnonymizeIPsOnly keeps domain, peer and interface names when Anonymize is set, see
+	// anonymize.LevelIPsOnly.
+	AnonymizeIPsOnly bool
+	// ExtraPaths are absolute paths or glob patterns of files added under extra/. The files are
+	// never anonymized. Validate them with ValidateExtraPaths.
+	ExtraPaths []string
+	// CommandOutput is the output of the commands run with "netbird debug for --run", written to
+	// command-output.txt. It is anonymized like the logs.
+	CommandOutput []byte
+	// Traceroute is the output of the traceroutes of "netbird debug bundle --trace-infra",
+	// written to traceroute.txt. It is anonymized like the logs.
+	Traceroute []byte
+	// InterfaceStatsStart are the interface counters taken with CollectInterfaceStats at the start
+	// of a "netbird debug for" session. interface-stats.txt then also has the change since then.
+	InterfaceStatsStart *InterfaceStats
+	// ResourceTimeline are the runtime stats of the daemon sampled with CollectRuntimeStats during
+	// a "netbird debug for" session, written to resource-timeline.csv.
+	ResourceTimeline []RuntimeStats
+	// SessionStart is the start of a "netbird debug for" session. The state transitions since
+	// then are marked in events.json.
+	SessionStart time.Time
+	// Compression of the files in the archive. Empty means CompressionGzip. zstd bundles are
+	// named *.zst.zip.
+	Compression Compression
+	// LogFilter drops log lines matching its exclude patterns or none of its include patterns
+	// before they are archived. Nil keeps all lines. See ParseLogFilter.
+	LogFilter *LogFilter
+	// StagedLogsDir is a directory returned by StagedLogsDir. Its log copies are merged with the
+	// current logs, keeping lines the current logs no longer have.
+	StagedLogsDir string
+	// SplitSize writes the bundle file as <bundle>.001, <bundle>.002 and so on of at most this
+	// many bytes, plus a <bundle>-parts.txt note on how to reassemble them. Zero writes one file.
+	// It must be at least MinSplitSize.
+	SplitSize int64
+	// Offline marks a bundle created by the CLI without the daemon, see "netbird debug bundle
+	// --offline". It has no live status or network map, offline.txt notes their absence.
+	Offline bool
+	// NoLogs leaves out all logs, for a bundle of the config, status and network map only. The
+	// log selection settings like LogFileCount, Since and LogTail are ignored. The manifest notes
+	// the omission.
+	NoLogs bool
 }
 
 type GeneratorDependencies struct {
@@ -285,7 +578,7 @@ type GeneratorDependencies struct {
 	SyncResponse   *mgmProto.SyncResponse
 	LogPath        string
 	UILogPath      string // Absolute path to the desktop UI's gui-client.log, reported via RegisterUILog. Empty if no UI registered one.
-	TempDir        string // Directory for temporary bundle zip files. If empty, os.TempDir() is used.
+	TempDir        string // Directory the bundle zip file is written to, e.g. --output-dir. If empty, os.TempDir() is used.
 	StatePath      string // Path to the state file. If empty, the ServiceManager default path is used.
 	CPUProfile     []byte
 	CapturePath    string
@@ -293,6 +586,32 @@ type GeneratorDependencies struct {
 	ClientMetrics  MetricsExporter
 	DaemonVersion  string
 	CliVersion     string
+	// ClockReference relates the local clock to the coordinator of a remote bundle
+	// job. It is recorded in the manifest so bundles from several peers can be aligned.
+	ClockReference *ClockReference
+	DropStats      DropStatsSource // Optional. Nil when the userspace filter is not in use.
+	DNSState       DNSStateSource  // Optional. Nil when the DNS server is not running.
+	StartupTiming  *startuptiming.Recorder
+	PeerMTU        *PeerMTUReport // Optional. Set when a peer MTU probe ran before the bundle.
+	PeerProbes     []PeerProbe    // Optional. The peer probes that ran shortly before the bundle.
+	ACLQueries     []ACLQuery     // Optional. The evaluated --acl-query flows.
+	// Policy is the management-enforced bundle policy. It overrides the BundleConfig in Generate.
+	Policy *BundlePolicy
+	// NetworkMapHistory holds the last stored sync responses, newest first. Optional. The first
+	// entry is the SyncResponse; older ones are added as network_map-1.json and up.
+	NetworkMapHistory []syncstore.Snapshot
+	// Progress is called with a short description of each stage of Generate. Optional.
+	Progress func(stage string)
+	// Firewall is the daemon's firewall manager, its rules are written to firewall.txt. Optional.
+	Firewall firewall.Manager
+	// NAT provides the STUN discovery results written to nat.txt. Optional.
+	NAT NATSource
+	// ClockSkew provides the management clock skew written to time.txt. Optional.
+	ClockSkew ClockSkewSource
+	// ChannelStates provides the gRPC channel states written to connections.txt. Optional.
+	ChannelStates ChannelStateSource
+	// WireGuardImpl provides the WireGuard implementation written to wireguard-impl.txt. Optional.
+	WireGuardImpl WireGuardImplSource
 }
 
 func NewBundleGenerator(deps GeneratorDependencies, cfg BundleConfig) *BundleGenerator {
@@ -302,7 +621,12 @@ func NewBundleGenerator(deps GeneratorDependencies, cfg BundleConfig) *BundleGen
 		logFileCount = 1
 	}
 
-	return &BundleGenerator{
+	compression := cfg.Compression
+	if compression == "" {
+		compression = CompressionGzip
+	}
+
+	g := &BundleGenerator{
 		anonymizer: anonymize.NewAnonymizer(anonymize.DefaultAddresses()),
 
 		internalConfig: deps.InternalConfig,
@@ -318,41 +642,207 @@ func NewBundleGenerator(deps GeneratorDependencies, cfg BundleConfig) *BundleGen
 		clientMetrics:  deps.ClientMetrics,
 		daemonVersion:  deps.DaemonVersion,
 		cliVersion:     deps.CliVersion,
+		clockReference: deps.ClockReference,
+		dropStats:      deps.DropStats,
+		dnsState:       deps.DNSState,
+		startupTiming:  deps.StartupTiming,
+		peerMTU:        deps.PeerMTU,
+		peerProbes:     deps.PeerProbes,
+		aclQueries:     deps.ACLQueries,
+		policy:         deps.Policy,
+
+		networkMapHistory: deps.NetworkMapHistory,
+		progress:          deps.Progress,
+		firewall:          deps.Firewall,
+		nat:               deps.NAT,
+		clockSkew:         deps.ClockSkew,
+		channelStates:     deps.ChannelStates,
+		wireGuardImpl:     deps.WireGuardImpl,
 
 		anonymize:         cfg.Anonymize,
 		includeSystemInfo: cfg.IncludeSystemInfo,
 		logFileCount:      logFileCount,
+		encryptionKey:     cfg.EncryptionKey,
+		statusFormat:      cfg.StatusFormat,
+		maxSize:           cfg.MaxSize,
+		since:             cfg.Since,
+		includeRawCapture: cfg.IncludeRawCapture,
+		includeProfiles:   cfg.IncludeProfiles,
+		anonymizationMap:  cfg.AnonymizationMap,
+		stableAnonymizer:  cfg.StableAnonymization,
+		anonymizePatterns: cfg.AnonymizePatterns,
+		allowSecrets:      cfg.AllowSecrets,
+
+		cpuProfileDuration:  cfg.CPUProfileDuration,
+		peerSelectors:       cfg.PeerSelectors,
+		peerGroup:           cfg.PeerGroup,
+		domain:              cfg.Domain,
+		label:               cfg.Label,
+		anonymizeIPsOnly:    cfg.AnonymizeIPsOnly,
+		extraPaths:          cfg.ExtraPaths,
+		commandOutput:       cfg.CommandOutput,
+		traceroute:          cfg.Traceroute,
+		interfaceStatsStart: cfg.InterfaceStatsStart,
+		resourceTimeline:    cfg.ResourceTimeline,
+		sessionStart:        cfg.SessionStart,
+		compression:         compression,
+		stagedLogsDir:       cfg.StagedLogsDir,
+		logFilter:           cfg.LogFilter,
+		logTail:             cfg.LogTail,
+		logBaseline:         cfg.LogBaseline,
+		recordLogBaseline:   cfg.RecordLogBaseline,
+		splitSize:           cfg.SplitSize,
+		offline:             cfg.Offline,
+		noLogs:              cfg.NoLogs,
+	}
+	if !g.allowSecrets {
+		g.secrets = g.secretsRedactor()
+	}
+	return g
+}
+
+// BundleFilePattern returns the os.CreateTemp pattern of the bundle file name for the given
+// encryption key, e.g. netbird.debug.*.zip.age. The * is replaced by a random string.
+func BundleFilePattern(encryptionKey []byte) (string, error) {
+	if len(encryptionKey) == 0 {
+		return bundleFilePattern, nil
+	}
+	encrypter, err := parseEncryptionKey(encryptionKey)
+	if err != nil {
+		return "", fmt.Errorf("parse encryption key: %w", err)
 	}
+	return bundleFilePattern + encrypter.Extension(), nil
 }
 
-// Generate creates a debug bundle and returns the location.
+// Anonymized reports whether the bundle is anonymized, including when the management policy
+// forced it. It is final once Generate ran.
+func (g *BundleGenerator) Anonymized() bool {
+	return g.anonymize
+}
+
+// Parts returns the part files of a split bundle in order, nil when the bundle was not split.
+// It is final once Generate ran.
+func (g *BundleGenerator) Parts() []string {
+	return g.parts
+}
+
+// Generate creates a debug bundle and returns the location. A split bundle returns the
+// location of its first part, see Parts.
 func (g *BundleGenerator) Generate() (resp string, err error) {
-	bundlePath, err := os.CreateTemp(g.tempDir, "netbird.debug.*.zip")
+	g.applyPolicy()
+	g.parts = nil
+	if g.splitSize != 0 && g.splitSize < MinSplitSize {
+		return "", fmt.Errorf("split size must be at least %d bytes", MinSplitSize)
+	}
+
+	g.logBudget = g.maxSize
+	g.truncatedLogs = nil
+	g.filteredLogs = nil
+	g.logCutoff = time.Time{}
+	if g.since > 0 {
+		g.logCutoff = time.Now().Add(-g.since)
+	}
+	g.logEnds = make(map[string]LogOffset)
+	g.recordedBaseline = nil
+	// rotated logs last written from now on belong to the next incremental bundle
+	baselineTime := time.Now()
+	g.files = nil
+
+	g.anonymizer.SetIPsOnly(g.anonymizeIPsOnly)
+	g.anonymizer.SetCustomPatterns(g.anonymizePatterns)
+	if err := g.anonymizer.LoadMappings(g.anonymizationMap); err != nil {
+		return "", fmt.Errorf("load anonymization map: %w", err)
+	}
+
+	var encrypter bundleEncrypter
+	pattern := bundleFilePattern
+	if g.label != "" {
+		if strings.ContainsAny(g.label, `/\*`) {
+			return "", fmt.Errorf("invalid bundle label %q", g.label)
+		}
+		pattern = fmt.Sprintf(labeledBundleFilePattern, g.label)
+	}
+	pattern = g.compression.bundlePattern(pattern)
+	if len(g.encryptionKey) > 0 {
+		if encrypter, err = parseEncryptionKey(g.encryptionKey); err != nil {
+			return "", fmt.Errorf("parse encryption key: %w", err)
+		}
+		pattern += encrypter.Extension()
+	}
+
+	// CreateTemp opens with O_EXCL and 0600: an existing file is never written through and the
+	// bundle is only readable by the daemon user.
+	bundlePath, err := os.CreateTemp(g.tempDir, pattern)
 	if err != nil {
 		return "", fmt.Errorf("create zip file: %w", err)
 	}
+	var parts *partWriter
 	defer func() {
 		if closeErr := bundlePath.Close(); closeErr != nil && err == nil {
 			err = fmt.Errorf("close zip file: %w", closeErr)
 		}
 
-		if err != nil {
+		// a split bundle only reserves the name, the content is in the parts
+		if err != nil || parts != nil {
 			if removeErr := os.Remove(bundlePath.Name()); removeErr != nil {
 				log.Errorf("Failed to remove zip file: %v", removeErr)
 			}
 		}
+		if err != nil && parts != nil {
+			parts.remove()
+		}
 	}()
 
-	g.archive = zip.NewWriter(bundlePath)
+	var out io.Writer = bundlePath
+	if g.splitSize > 0 {
+		parts = newPartWriter(bundlePath.Name(), g.splitSize)
+		out = parts
+	}
+	var encryptWriter io.WriteCloser
+	if encrypter != nil {
+		if encryptWriter, err = encrypter.Encrypt(out); err != nil {
+			return "", fmt.Errorf("start encryption: %w", err)
+		}
+		out = encryptWriter
+	}
+
+	g.archive = zip.NewWriter(out)
+	if err := g.compression.registerCompression(g.archive); err != nil {
+		return "", err
+	}
 
 	if err := g.createArchive(); err != nil {
 		return "", err
 	}
+	if g.recordLogBaseline {
+		g.recordedBaseline = &LogBaseline{
+			Bundle:    filepath.Base(bundlePath.Name()),
+			CreatedAt: baselineTime,
+			Logs:      g.logEnds,
+		}
+	}
 
+	g.reportProgress("compressing")
 	if err := g.archive.Close(); err != nil {
 		return "", fmt.Errorf("close archive writer: %w", err)
 	}
 
+	if encryptWriter != nil {
+		if err := encryptWriter.Close(); err != nil {
+			return "", fmt.Errorf("finish encryption: %w", err)
+		}
+	}
+
+	if parts != nil {
+		if err := parts.Close(); err != nil {
+			return "", err
+		}
+		if err := parts.writeNote(); err != nil {
+			return "", err
+		}
+		g.parts = parts.Paths()
+		return g.parts[0], nil
+	}
 	return bundlePath.Name(), nil
 }
 
@@ -361,22 +851,122 @@ func (g *BundleGenerator) createArchive() error {
 		return fmt.Errorf("add readme: %w", err)
 	}
 
+	if err := g.addOfflineNote(); err != nil {
+		log.Errorf("failed to add offline note to debug bundle: %v", err)
+	}
+
+	g.reportProgress("collecting status")
 	if err := g.addStatus(); err != nil {
 		return fmt.Errorf("add status: %w", err)
 	}
 
+	if err := g.addStateEvents(); err != nil {
+		log.Errorf("failed to add state events to debug bundle: %v", err)
+	}
+
 	if err := g.addConfig(); err != nil {
 		log.Errorf("failed to add config to debug bundle: %v", err)
 	}
 
+	if err := g.addFeatureFlags(); err != nil {
+		log.Errorf("failed to add feature flags to debug bundle: %v", err)
+	}
+
+	if err := g.addServerCapabilities(); err != nil {
+		log.Errorf("failed to add server capabilities to debug bundle: %v", err)
+	}
+
+	if err := g.addPosture(); err != nil {
+		log.Errorf("failed to add posture checks to debug bundle: %v", err)
+	}
+
+	if err := g.addBuildInfo(); err != nil {
+		log.Errorf("failed to add build info to debug bundle: %v", err)
+	}
+
 	if err := g.addResolvedDomains(); err != nil {
 		log.Errorf("failed to add resolved domains to debug bundle: %v", err)
 	}
 
-	if g.includeSystemInfo {
+	if err := g.addDNS(); err != nil {
+		log.Errorf("failed to add DNS state to debug bundle: %v", err)
+	}
+
+	if err := g.addDNSStats(); err != nil {
+		log.Errorf("failed to add DNS stats to debug bundle: %v", err)
+	}
+
+	if err := g.addDropStats(); err != nil {
+		log.Errorf("failed to add drop stats to debug bundle: %v", err)
+	}
+
+	if err := g.addNetbirdFirewallRules(); err != nil {
+		log.Errorf("failed to add firewall rules to debug bundle: %v", err)
+	}
+
+	if err := g.addStartupTiming(); err != nil {
+		log.Errorf("failed to add startup timing to debug bundle: %v", err)
+	}
+
+	if err := g.addPeerMTU(); err != nil {
+		log.Errorf("failed to add peer MTU to debug bundle: %v", err)
+	}
+
+	if err := g.addPeerProbes(); err != nil {
+		log.Errorf("failed to add peer probes to debug bundle: %v", err)
+	}
+
+	if err := g.addACLQueries(); err != nil {
+		log.Errorf("failed to add ACL queries to debug bundle: %v", err)
+	}
+
+	if err := g.addInterfaceConflicts(); err != nil {
+		log.Errorf("failed to add interface conflicts to debug bundle: %v", err)
+	}
+
+	if err := g.addRouteConflicts(); err != nil {
+		log.Errorf("failed to add route conflicts to debug bundle: %v", err)
+	}
+
+	if err := g.addNAT(); err != nil {
+		log.Errorf("failed to add NAT to debug bundle: %v", err)
+	}
+
+	if err := g.addMTU(); err != nil {
+		log.Errorf("failed to add MTU to debug bundle: %v", err)
+	}
+
+	if err := g.addTime(); err != nil {
+		log.Errorf("failed to add time to debug bundle: %v", err)
+	}
+
+	if err := g.addProxy(); err != nil {
+		log.Errorf("failed to add proxy settings to debug bundle: %v", err)
+	}
+
+	if err := g.addConnections(); err != nil {
+		log.Errorf("failed to add connections to debug bundle: %v", err)
+	}
+
+	if err := g.addExtraFiles(); err != nil {
+		log.Errorf("failed to add included files to debug bundle: %v", err)
+	}
+
+	if err := g.addCommandOutput(); err != nil {
+		log.Errorf("failed to add command output to debug bundle: %v", err)
+	}
+
+	if err := g.addTraceroute(); err != nil {
+		log.Errorf("failed to add traceroute to debug bundle: %v", err)
+	}
+
+	if len(g.includeSystemInfo) > 0 {
+		g.reportProgress("gathering system info")
 		g.addSystemInfo()
 	}
 
+	g.reportProgress("collecting profiles")
+
 	if err := g.addProf(); err != nil {
 		log.Errorf("failed to add profiles to debug bundle: %v", err)
 	}
@@ -385,6 +975,10 @@ func (g *BundleGenerator) createArchive() error {
 		log.Errorf("failed to add CPU profile to debug bundle: %v", err)
 	}
 
+	if err := g.addRuntimeProfiles(); err != nil {
+		log.Errorf("failed to add runtime profiles to debug bundle: %v", err)
+	}
+
 	if err := g.addCaptureFile(); err != nil {
 		log.Errorf("failed to add capture file to debug bundle: %v", err)
 	}
@@ -393,10 +987,15 @@ func (g *BundleGenerator) createArchive() error {
 		log.Errorf("failed to add stack trace to debug bundle: %v", err)
 	}
 
+	g.reportProgress("collecting network map and state")
 	if err := g.addSyncResponse(); err != nil {
 		return fmt.Errorf("add sync response: %w", err)
 	}
 
+	if err := g.addNetworkMapHistory(); err != nil {
+		log.Errorf("failed to add network map history to debug bundle: %v", err)
+	}
+
 	if err := g.addStateFile(); err != nil {
 		log.Errorf("failed to add state file to debug bundle: %v", err)
 	}
@@ -409,6 +1008,10 @@ func (g *BundleGenerator) createArchive() error {
 		log.Errorf("failed to add service params to debug bundle: %v", err)
 	}
 
+	if err := g.addServiceStatus(); err != nil {
+		log.Errorf("failed to add service status to debug bundle: %v", err)
+	}
+
 	if err := g.addMetrics(); err != nil {
 		log.Errorf("failed to add metrics to debug bundle: %v", err)
 	}
@@ -417,45 +1020,74 @@ func (g *BundleGenerator) createArchive() error {
 		log.Errorf("failed to add wg show output: %v", err)
 	}
 
-	if err := g.addPlatformLog(); err != nil {
-		log.Errorf("failed to add logs to debug bundle: %v", err)
+	if err := g.addWireGuardImpl(); err != nil {
+		log.Errorf("failed to add WireGuard implementation to debug bundle: %v", err)
 	}
 
-	if err := g.addUILog(); err != nil {
-		log.Errorf("failed to add UI log to debug bundle: %v", err)
+	if err := g.addInterfaceStats(); err != nil {
+		log.Errorf("failed to add interface stats to debug bundle: %v", err)
 	}
 
-	if err := g.addUpdateLogs(); err != nil {
-		log.Errorf("failed to add updater logs: %v", err)
+	if err := g.addResourceTimeline(); err != nil {
+		log.Errorf("failed to add resource timeline to debug bundle: %v", err)
+	}
+
+	if g.noLogs {
+		log.Info("leaving out the logs of the debug bundle as requested")
+	} else {
+		g.addLogs()
+	}
+
+	// the manifest and checksums cover all other files, so they go last
+	if err := g.addManifest(); err != nil {
+		log.Errorf("failed to add manifest to debug bundle: %v", err)
+	}
+
+	if err := g.addChecksums(); err != nil {
+		log.Errorf("failed to add checksums to debug bundle: %v", err)
 	}
 
 	return nil
 }
 
-func (g *BundleGenerator) addSystemInfo() {
-	if err := g.addRoutes(); err != nil {
-		log.Errorf("failed to add routes to debug bundle: %v", err)
+// addLogs adds the daemon, UI and updater logs.
+func (g *BundleGenerator) addLogs() {
+	g.reportProgress("collecting logs")
+	if err := g.addPlatformLog(); err != nil {
+		log.Errorf("failed to add logs to debug bundle: %v", err)
 	}
 
-	if err := g.addInterfaces(); err != nil {
-		log.Errorf("failed to add interfaces to debug bundle: %v", err)
+	if err := g.addUILog(); err != nil {
+		log.Errorf("failed to add UI log to debug bundle: %v", err)
 	}
 
-	if err := g.addIPRules(); err != nil {
-		log.Errorf("failed to add IP rules to debug bundle: %v", err)
+	if err := g.addUpdateLogs(); err != nil {
+		log.Errorf("failed to add updater logs: %v", err)
 	}
 
-	if err := g.addFirewallRules(); err != nil {
-		log.Errorf("failed to add firewall rules to debug bundle: %v", err)
+	if err := g.addLogFilterNote(); err != nil {
+		log.Errorf("failed to add log filter note to debug bundle: %v", err)
 	}
+}
 
-	if err := g.addSysctls(); err != nil {
-		log.Errorf("failed to add sysctls to debug bundle: %v", err)
+// reportProgress passes the stage of Generate to the Progress callback, if any.
+func (g *BundleGenerator) reportProgress(stage string) {
+	if g.progress != nil {
+		g.progress(stage)
 	}
+}
 
-	if err := g.addDNSInfo(); err != nil {
-		log.Errorf("failed to add DNS info to debug bundle: %v", err)
+// addRoutesUnavailable writes routes.txt explaining why the routing table is missing, so the bundle
+// shows the gap instead of silently omitting the file.
+func (g *BundleGenerator) addRoutesUnavailable(reason string) error {
+	if g.anonymize {
+		reason = g.anonymizer.AnonymizeString(reason)
+	}
+	content := fmt.Sprintf("Routing table not available: %s\n", reason)
+	if err := g.addFileToZip(strings.NewReader(content), "routes.txt"); err != nil {
+		return fmt.Errorf("add routes file to zip: %w", err)
 	}
+	return nil
 }
 
 func (g *BundleGenerator) addReadme() error {
@@ -480,17 +1112,43 @@ func (g *BundleGenerator) addStatus() error {
 
 		fullStatus := g.statusRecorder.GetFullStatus()
 		protoFullStatus := nbstatus.ToProtoFullStatus(fullStatus)
-		overview := nbstatus.ConvertToStatusOutputOverview(protoFullStatus, nbstatus.ConvertOptions{
+		options := nbstatus.ConvertOptions{
 			Anonymize:     g.anonymize,
 			ProfileName:   profName,
 			DaemonVersion: g.daemonVersion,
-		})
+			PeerSelectors: g.peerSelectors,
+			GroupFilter:   g.peerGroup,
+			DomainFilter:  g.domain,
+		}
+		if g.anonymize && g.anonymizeIPsOnly {
+			options.AnonymizeLevel = anonymize.LevelIPsOnly
+		}
+		// a fresh anonymizer would not know the custom patterns or their placeholders in the logs
+		if g.stableAnonymizer || len(g.anonymizePatterns) > 0 {
+			options.Anonymizer = g.anonymizer
+		}
+		overview := nbstatus.ConvertToStatusOutputOverview(protoFullStatus, options)
 		overview.CliVersion = g.cliVersion
-		statusOutput := overview.FullDetailSummary()
 
-		statusReader := strings.NewReader(statusOutput)
-		if err := g.addFileToZip(statusReader, "status.txt"); err != nil {
-			return fmt.Errorf("add status file to zip: %w", err)
+		if g.statusFormat != StatusFormatJSON {
+			statusReader := strings.NewReader(overview.FullDetailSummary())
+			if err := g.addFileToZip(statusReader, "status.txt"); err != nil {
+				return fmt.Errorf("add status file to zip: %w", err)
+			}
+		}
+
+		if g.statusFormat == StatusFormatJSON || g.statusFormat == StatusFormatBoth {
+			statusJSON, err := overview.JSON()
+			if err != nil {
+				return fmt.Errorf("marshal status: %w", err)
+			}
+			if err := g.addFileToZip(strings.NewReader(statusJSON), "status.json"); err != nil {
+				return fmt.Errorf("add status JSON file to zip: %w", err)
+			}
+		}
+
+		if err := g.addReachability(overview); err != nil {
+			log.Errorf("failed to add reachability to debug bundle: %v", err)
 		}
 		seedFromStatus(g.anonymizer, &fullStatus)
 	} else {
@@ -545,9 +1203,50 @@ func (g *BundleGenerator) addConfig() error {
 		return fmt.Errorf("add config file to zip: %w", err)
 	}
 
+	if err := g.addConfigJSON(); err != nil {
+		return fmt.Errorf("add config JSON file to zip: %w", err)
+	}
+
 	return nil
 }
 
+// addConfigJSON adds the effective config in the format of the profile file. Secrets are redacted
+// unless allowed and the addresses anonymized like in config.txt.
+func (g *BundleGenerator) addConfigJSON() error {
+	cfg := g.internalConfig.Redacted()
+	if g.allowSecrets {
+		copied := *g.internalConfig
+		cfg = &copied
+	}
+	if g.anonymize {
+		if cfg.ManagementURL != nil {
+			cfg.ManagementURL = g.anonymizeURL(cfg.ManagementURL)
+		}
+		if cfg.AdminURL != nil {
+			cfg.AdminURL = g.anonymizeURL(cfg.AdminURL)
+		}
+		cfg.NATExternalIPs = anonymizeNATExternalIPs(cfg.NATExternalIPs, g.anonymizer)
+		if cfg.CustomDNSAddress != "" {
+			cfg.CustomDNSAddress = g.anonymizer.AnonymizeString(cfg.CustomDNSAddress)
+		}
+	}
+
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal config: %w", err)
+	}
+	return g.addFileToZip(bytes.NewReader(data), "config.json")
+}
+
+// anonymizeURL anonymizes a URL, dropping it if the result cannot be parsed.
+func (g *BundleGenerator) anonymizeURL(u *url.URL) *url.URL {
+	anonymized, err := url.Parse(g.anonymizer.AnonymizeURI(u.String()))
+	if err != nil {
+		return nil
+	}
+	return anonymized
+}
+
 const (
 	serviceParamsFile    = "service.json"
 	serviceParamsBundle  = "service_params.json"
@@ -746,10 +1445,13 @@ func (g *BundleGenerator) addCaptureFile() error {
 		return nil
 	}
 
-	if g.anonymize {
+	if g.anonymize && !g.includeRawCapture {
 		log.Info("skipping capture file in anonymized bundle (contains raw packet data)")
 		return nil
 	}
+	if g.anonymize {
+		log.Warn("adding raw packet capture to anonymized debug bundle: packets cannot be anonymized")
+	}
 
 	f, err := os.Open(g.capturePath)
 	if err != nil {
@@ -798,6 +1500,9 @@ func (g *BundleGenerator) addResolvedDomains() error {
 	}
 
 	resolvedDomains := g.statusRecorder.GetResolvedDomainsStates()
+	if g.domain != "" {
+		resolvedDomains = resolvedDomainsForScope(resolvedDomains, g.domain)
+	}
 	if len(resolvedDomains) == 0 {
 		log.Debugf("skipping resolved domains in debug bundle: no resolved domains")
 		return nil
@@ -818,8 +1523,12 @@ func (g *BundleGenerator) addSyncResponse() error {
 		return nil
 	}
 
+	return g.addSyncResponseFile(g.syncResponse, "network_map.json")
+}
+
+func (g *BundleGenerator) addSyncResponseFile(syncResponse *mgmProto.SyncResponse, filename string) error {
 	if g.anonymize {
-		if err := anonymizeSyncResponse(g.syncResponse, g.anonymizer); err != nil {
+		if err := anonymizeSyncResponse(syncResponse, g.anonymizer); err != nil {
 			return fmt.Errorf("anonymize sync response: %w", err)
 		}
 	}
@@ -831,37 +1540,40 @@ func (g *BundleGenerator) addSyncResponse() error {
 		AllowPartial:    true,
 	}
 
-	g.maskSecrets()
+	if !g.allowSecrets {
+		maskSecrets(syncResponse)
+	}
 
-	jsonBytes, err := options.Marshal(g.syncResponse)
+	jsonBytes, err := options.Marshal(syncResponse)
 	if err != nil {
 		return fmt.Errorf("generate json: %w", err)
 	}
 
-	if err := g.addFileToZip(bytes.NewReader(jsonBytes), "network_map.json"); err != nil {
+	if err := g.addFileToZip(bytes.NewReader(jsonBytes), filename); err != nil {
 		return fmt.Errorf("add sync response to zip: %w", err)
 	}
 
 	return nil
 }
 
-func (g *BundleGenerator) maskSecrets() {
-	if g.syncResponse == nil || g.syncResponse.NetbirdConfig == nil {
+func maskSecrets(syncResponse *mgmProto.SyncResponse) {
+	if syncResponse == nil || syncResponse.NetbirdConfig == nil {
 		return
 	}
 
-	if g.syncResponse.NetbirdConfig.Flow != nil {
-		g.syncResponse.NetbirdConfig.Flow.TokenPayload = maskedValue
-
+	if syncResponse.NetbirdConfig.Flow != nil {
+		syncResponse.NetbirdConfig.Flow.TokenPayload = maskedValue
+		syncResponse.NetbirdConfig.Flow.TokenSignature = maskedValue
 	}
 
-	if g.syncResponse.NetbirdConfig.Relay != nil {
-		g.syncResponse.NetbirdConfig.Relay.TokenPayload = maskedValue
+	if syncResponse.NetbirdConfig.Relay != nil {
+		syncResponse.NetbirdConfig.Relay.TokenPayload = maskedValue
+		syncResponse.NetbirdConfig.Relay.TokenSignature = maskedValue
 	}
 
-	for i := range g.syncResponse.NetbirdConfig.Turns {
-		if g.syncResponse.NetbirdConfig.Turns[i] != nil {
-			g.syncResponse.NetbirdConfig.Turns[i].Password = maskedValue
+	for i := range syncResponse.NetbirdConfig.Turns {
+		if syncResponse.NetbirdConfig.Turns[i] != nil {
+			syncResponse.NetbirdConfig.Turns[i].Password = maskedValue
 		}
 	}
 }
@@ -926,6 +1638,16 @@ func (g *BundleGenerator) addUpdateLogs() error {
 		}
 
 		baseName := filepath.Base(logFile)
+		if g.logFilter != nil {
+			var removed int
+			if data, removed, err = g.logFilter.filterLines(bytes.NewReader(data)); err != nil {
+				return fmt.Errorf("filter update log file %s: %w", baseName, err)
+			}
+			g.filteredLogs = append(g.filteredLogs, filteredLog{name: filepath.Join("update-logs", baseName), removed: removed})
+		}
+		if g.logTail > 0 {
+			data = tailLog(data, g.logTail)
+		}
 		if err := g.addFileToZip(bytes.NewReader(data), filepath.Join("update-logs", baseName)); err != nil {
 			return fmt.Errorf("add update log file %s to zip: %w", baseName, err)
 		}
@@ -994,26 +1716,19 @@ func (g *BundleGenerator) addLogfile() error {
 		return nil
 	}
 
-	logDir := filepath.Dir(g.logPath)
+	logFiles := activeLogFiles(g.logPath)
 
-	if err := g.addSingleLogfile(g.logPath, clientLogFile); err != nil {
+	if err := g.addSingleLogfile(logFiles[clientLogFile], clientLogFile); err != nil {
 		return fmt.Errorf("add client log file to zip: %w", err)
 	}
 
-	g.addRotatedLogFiles(logDir, clientLogPrefix)
-
-	stdErrLogPath := filepath.Join(logDir, errorLogFile)
-	stdoutLogPath := filepath.Join(logDir, stdoutLogFile)
-	if runtime.GOOS == "darwin" {
-		stdErrLogPath = darwinErrorLogPath
-		stdoutLogPath = darwinStdoutLogPath
-	}
+	g.addRotatedLogFiles(filepath.Dir(g.logPath), clientLogPrefix)
 
-	if err := g.addSingleLogfile(stdErrLogPath, errorLogFile); err != nil {
+	if err := g.addSingleLogfile(logFiles[errorLogFile], errorLogFile); err != nil {
 		log.Warnf("Failed to add %s to zip: %v", errorLogFile, err)
 	}
 
-	if err := g.addSingleLogfile(stdoutLogPath, stdoutLogFile); err != nil {
+	if err := g.addSingleLogfile(logFiles[stdoutLogFile], stdoutLogFile); err != nil {
 		log.Warnf("Failed to add %s to zip: %v", stdoutLogFile, err)
 	}
 
@@ -1041,6 +1756,10 @@ func (g *BundleGenerator) addUILog() error {
 
 // addSingleLogfile adds a single log file to the archive
 func (g *BundleGenerator) addSingleLogfile(logPath, targetName string) error {
+	if staged, ok := g.stagedLog(targetName); ok {
+		return g.addStagedLogfile(logPath, targetName, staged)
+	}
+
 	logFile, err := os.Open(logPath)
 	if err != nil {
 		return fmt.Errorf("open log file %s: %w", targetName, err)
@@ -1051,12 +1770,63 @@ func (g *BundleGenerator) addSingleLogfile(logPath, targetName string) error {
 		}
 	}()
 
-	var logReader io.Reader = logFile
+	stat, err := logFile.Stat()
+	if err != nil {
+		return fmt.Errorf("stat log file %s: %w", targetName, err)
+	}
+	section, marker, err := g.incrementalLog(targetName, logFile, stat.Size())
+	if err != nil {
+		return err
+	}
+	var src io.ReaderAt = section
+	size := section.Size()
+	if g.filtersLogs() {
+		data, err := g.filterLog(targetName, section)
+		if err != nil {
+			return fmt.Errorf("filter log file %s: %w", targetName, err)
+		}
+		src, size = bytes.NewReader(data), int64(len(data))
+	}
+
+	return g.addLogContent(src, size, targetName, marker)
+}
+
+// addStagedLogfile adds a log file merged with its copy staged at the start of a debug session.
+// The current file may be gone, e.g. when the daemon restarted with a different log target.
+func (g *BundleGenerator) addStagedLogfile(logPath, targetName string, staged []byte) error {
+	current, err := os.ReadFile(logPath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("read log file %s: %w", targetName, err)
+	}
+
+	data := mergeStagedLog(staged, current)
+	if g.filtersLogs() {
+		if data, err = g.filterLog(targetName, bytes.NewReader(data)); err != nil {
+			return fmt.Errorf("filter log file %s: %w", targetName, err)
+		}
+	}
+
+	return g.addLogContent(bytes.NewReader(data), int64(len(data)), targetName, "")
+}
+
+// addLogContent adds log content to the archive, limited to the log budget and anonymized if set.
+// A non-empty marker is put in front of it.
+func (g *BundleGenerator) addLogContent(src io.ReaderAt, size int64, targetName, marker string) error {
+	logReader := g.limitLog(targetName, src, size)
+	if logReader == nil {
+		return nil
+	}
+
 	if g.anonymize {
 		var writer *io.PipeWriter
+		source := logReader
 		logReader, writer = io.Pipe()
 
-		go anonymizeLog(logFile, writer, g.anonymizer)
+		go anonymizeLog(source, writer, g.anonymizer)
+	}
+	// the bundle name in the marker is not anonymized, manifest.json has it too
+	if marker != "" {
+		logReader = io.MultiReader(strings.NewReader(marker), logReader)
 	}
 	if err := g.addFileToZip(logReader, targetName); err != nil {
 		return fmt.Errorf("add %s to zip: %w", targetName, err)
@@ -1087,12 +1857,37 @@ func (g *BundleGenerator) addSingleLogFileGz(logPath, targetName string) error {
 		}
 	}()
 
-	var logReader io.Reader = gzr
+	src, marker, err := g.incrementalLogReader(gzr)
+	if err != nil {
+		return fmt.Errorf("read start of gz log file %s: %w", targetName, err)
+	}
+	if g.filtersLogs() {
+		data, err := g.filterLog(targetName, src)
+		if err != nil {
+			return fmt.Errorf("filter gz log file %s: %w", targetName, err)
+		}
+		src = bytes.NewReader(data)
+	}
+
+	logReader, err := g.limitLogReader(targetName, src)
+	if err != nil {
+		return err
+	}
+	if logReader == nil {
+		return nil
+	}
+
 	if g.anonymize {
 		var pw *io.PipeWriter
+		source := logReader
 		logReader, pw = io.Pipe()
-		go anonymizeLog(gzr, pw, g.anonymizer)
+		go anonymizeLog(source, pw, g.anonymizer)
+	}
+	if marker != "" {
+		logReader = io.MultiReader(strings.NewReader(marker), logReader)
 	}
+	// the archive sees the compressed content only
+	logReader = g.redactSecrets(logReader)
 
 	var buf bytes.Buffer
 	gw := gzip.NewWriter(&buf)
@@ -1111,7 +1906,8 @@ func (g *BundleGenerator) addSingleLogFileGz(logPath, targetName string) error {
 	return nil
 }
 
-// addRotatedLogFiles adds rotated log files to the bundle based on logFileCount.
+// addRotatedLogFiles adds rotated log files to the bundle based on logFileCount. With a
+// --since window or an incremental baseline all rotated files modified since then are added instead.
 // prefix is the base log name without extension (e.g. "client", "gui-client");
 // the glob matches both files rotated by us and by logrotate on linux.
 func (g *BundleGenerator) addRotatedLogFiles(logDir, prefix string) {
@@ -1147,12 +1943,24 @@ func (g *BundleGenerator) addRotatedLogFiles(logDir, prefix string) {
 	})
 
 	maxFiles := int(g.logFileCount)
+	cutoff := g.rotatedLogCutoff()
+	if !cutoff.IsZero() {
+		// the time window is the tighter constraint, files are only limited by their age
+		maxFiles = len(files)
+	}
 	if maxFiles > len(files) {
 		maxFiles = len(files)
 	}
 
 	for i := 0; i < maxFiles; i++ {
 		name := filepath.Base(files[i])
+		if !cutoff.IsZero() {
+			if info, err := os.Stat(files[i]); err == nil && info.ModTime().Before(cutoff) {
+				// files are sorted newest first, all remaining ones are older
+				log.Debugf("skipping rotated log %s: last written before %s", name, cutoff.Format(time.RFC3339))
+				break
+			}
+		}
 		if strings.HasSuffix(name, ".gz") {
 			err = g.addSingleLogFileGz(files[i], name)
 		} else {
@@ -1167,7 +1975,7 @@ func (g *BundleGenerator) addRotatedLogFiles(logDir, prefix string) {
 func (g *BundleGenerator) addFileToZip(reader io.Reader, filename string) error {
 	header := &zip.FileHeader{
 		Name:     filename,
-		Method:   zip.Deflate,
+		Method:   g.compression.zipMethod(),
 		Modified: time.Now(),
 
 		CreatorVersion: 20,    // Version 2.0
@@ -1189,10 +1997,19 @@ func (g *BundleGenerator) addFileToZip(reader io.Reader, filename string) error
 		return fmt.Errorf("create zip file header: %w", err)
 	}
 
-	if _, err := io.Copy(writer, reader); err != nil {
+	reader = g.redactSecrets(reader)
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(writer, hash), reader)
+	if err != nil {
 		return fmt.Errorf("write file to zip: %w", err)
 	}
 
+	g.files = append(g.files, manifestFile{
+		Name:   filename,
+		Size:   size,
+		SHA256: hex.EncodeToString(hash.Sum(nil)),
+	})
+
 	return nil
 }
 
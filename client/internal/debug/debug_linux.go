@@ -18,6 +18,7 @@ import (
 	"github.com/google/nftables/expr"
 	log "github.com/sirupsen/logrus"
 
+	firewall "github.com/netbirdio/netbird/client/firewall/manager"
 	"github.com/netbirdio/netbird/client/internal/routemanager/systemops"
 )
 
@@ -72,16 +73,6 @@ func (g *BundleGenerator) trySystemdLogFallback() error {
 	return nil
 }
 
-// getServiceName gets the service name from environment or defaults to netbird
-func getServiceName() string {
-	if unitName := os.Getenv("SYSTEMD_UNIT"); unitName != "" {
-		log.Debugf("Detected SYSTEMD_UNIT environment variable: %s", unitName)
-		return unitName
-	}
-
-	return "netbird"
-}
-
 // getSystemdLogs retrieves logs from systemd journal for a specific service using journalctl
 func getSystemdLogs(serviceName string) (string, error) {
 	args := []string{
@@ -154,6 +145,26 @@ func (g *BundleGenerator) addFirewallRules() error {
 	return nil
 }
 
+// netbirdTablesSource is implemented by the nftables firewall manager.
+type netbirdTablesSource interface {
+	NetbirdTables() []*nftables.Table
+}
+
+// platformFirewallRules formats the tables of the nftables firewall manager. It reports false
+// for other managers.
+func platformFirewallRules(manager firewall.Manager) (string, bool, error) {
+	source, ok := manager.(netbirdTablesSource)
+	if !ok {
+		return "", false, nil
+	}
+
+	conn, err := nftables.New()
+	if err != nil {
+		return "", true, fmt.Errorf("create nftables connection: %w", err)
+	}
+	return formatTables(conn, source.NetbirdTables()), true, nil
+}
+
 // addIPTablesRulesToBundle collects iptables/ip6tables rules and writes them to the bundle.
 func (g *BundleGenerator) addIPTablesRulesToBundle(saveBin, listBin, filename string) {
 	rules, err := collectIPTablesRules(saveBin, listBin)
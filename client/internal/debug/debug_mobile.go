@@ -2,10 +2,41 @@
 
 package debug
 
+import "errors"
+
 func (g *BundleGenerator) addRoutes() error {
-	return nil
+	return g.addRoutesUnavailable("reading the routing table is not supported on this platform")
+}
+
+// CheckRouteConflicts is not supported on mobile platforms, the routing table cannot be read.
+func CheckRouteConflicts(wgIface string) (RouteConflictReport, error) {
+	return RouteConflictReport{Interface: wgIface}, errors.New("reading the routing table is not supported on this platform")
 }
 
 func (g *BundleGenerator) addDNSInfo() error {
 	return nil
 }
+
+func serviceManagerReport() string {
+	return "No service manager on this platform. The daemon runs inside the NetBird app.\n"
+}
+
+func timeSyncReport() string {
+	return "Not available on this platform.\n"
+}
+
+func (g *BundleGenerator) addOSInfo() error {
+	return nil
+}
+
+func (g *BundleGenerator) addCPUInfo() error {
+	return nil
+}
+
+func (g *BundleGenerator) addMemInfo() error {
+	return nil
+}
+
+func (g *BundleGenerator) addDiskInfo() error {
+	return nil
+}
@@ -2,11 +2,20 @@
 
 package debug
 
+import (
+	firewall "github.com/netbirdio/netbird/client/firewall/manager"
+)
+
 // collectFirewallRules returns nothing on non-linux systems
 func (g *BundleGenerator) addFirewallRules() error {
 	return nil
 }
 
+// platformFirewallRules reports false, only Linux has firewall managers without NetbirdRules
+func platformFirewallRules(firewall.Manager) (string, bool, error) {
+	return "", false, nil
+}
+
 func (g *BundleGenerator) trySystemdLogFallback() error {
 	// Systemd is only available on Linux
 	// TODO: Add BSD support
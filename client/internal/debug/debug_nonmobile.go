@@ -6,13 +6,16 @@ import (
 	"fmt"
 	"strings"
 
+	log "github.com/sirupsen/logrus"
+
 	"github.com/netbirdio/netbird/client/internal/routemanager/systemops"
 )
 
 func (g *BundleGenerator) addRoutes() error {
 	detailedRoutes, err := systemops.GetDetailedRoutesFromTable()
 	if err != nil {
-		return fmt.Errorf("get detailed routes: %w", err)
+		log.Warnf("failed to get detailed routes for debug bundle: %v", err)
+		return g.addRoutesUnavailable(fmt.Sprintf("get detailed routes: %v", err))
 	}
 
 	routesContent := formatRoutesTable(detailedRoutes, g.anonymize, g.anonymizer)
@@ -23,3 +26,27 @@ func (g *BundleGenerator) addRoutes() error {
 
 	return nil
 }
+
+// CheckRouteConflicts reads the host routing tables and compares the NetBird routes against the host routes.
+func CheckRouteConflicts(wgIface string) (RouteConflictReport, error) {
+	detailedRoutes, err := systemops.GetDetailedRoutesFromTable()
+	if err != nil {
+		return RouteConflictReport{Interface: wgIface}, fmt.Errorf("get detailed routes: %w", err)
+	}
+
+	routes := make([]RouteEntry, 0, len(detailedRoutes))
+	for _, r := range detailedRoutes {
+		entry := RouteEntry{
+			Prefix:  r.Dst,
+			Gateway: r.Gw,
+			Table:   r.Table,
+			// Windows adds the interface metric to the route metric when choosing a route
+			Metric: r.Metric + max(r.InterfaceMetric, 0),
+		}
+		if r.Interface != nil {
+			entry.Interface = r.Interface.Name
+		}
+		routes = append(routes, entry)
+	}
+	return FindRouteConflicts(routes, wgIface), nil
+}
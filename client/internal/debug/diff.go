@@ -0,0 +1,555 @@
+package debug
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	nbstatus "github.com/netbirdio/netbird/client/status"
+)
+
+const (
+	statusJSONFile = "status.json"
+	statusTextFile = "status.txt"
+	configJSONFile = "config.json"
+	configTextFile = "config.txt"
+)
+
+// BundleInfo identifies a bundle of a BundleDiff. GeneratedAt, DaemonVersion and Anonymized are
+// zero for bundles without a manifest.
+type BundleInfo struct {
+	Path          string
+	GeneratedAt   time.Time
+	DaemonVersion string
+	Anonymized    bool
+}
+
+// ValueChange is a field that differs between two bundles. Old or New is empty when the field is
+// only in one of them.
+type ValueChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// PeerChangeKind tells whether a peer was added, removed or changed in the second bundle.
+type PeerChangeKind string
+
+const (
+	PeerAdded   PeerChangeKind = "added"
+	PeerRemoved PeerChangeKind = "removed"
+	PeerChanged PeerChangeKind = "changed"
+)
+
+// PeerChange is a peer that differs between two bundles.
+type PeerChange struct {
+	// Peer is the FQDN of the peer, its NetBird IP or public key without one.
+	Peer string
+	Kind PeerChangeKind
+	// Status is the status of an added or removed peer.
+	Status string
+	// Fields are the changed fields of a changed peer.
+	Fields []ValueChange
+}
+
+// BundleDiff is the result of DiffBundles: what changed from bundle A to bundle B.
+type BundleDiff struct {
+	A BundleInfo
+	B BundleInfo
+
+	// StatusFile is status.json when both bundles have it, status.txt when both have that one
+	// instead and empty when the status can't be compared.
+	StatusFile string
+	// Status, Peers and the networks are set when StatusFile is status.json.
+	Status          []ValueChange
+	Peers           []PeerChange
+	NetworksAdded   []string
+	NetworksRemoved []string
+	// StatusLines is a line diff of the status summaries of status.txt, each line prefixed with
+	// "- " or "+ ".
+	StatusLines []string
+
+	// ConfigFile is config.json when both bundles have it, config.txt when both have that one
+	// instead and empty when the config can't be compared.
+	ConfigFile string
+	// Config holds the changed fields of config.json, nested fields joined with a dot.
+	Config []ValueChange
+	// ConfigLines is a line diff of config.txt.
+	ConfigLines []string
+}
+
+// Empty reports whether no difference was found in the compared files.
+func (d *BundleDiff) Empty() bool {
+	return len(d.Status) == 0 && len(d.Peers) == 0 && len(d.NetworksAdded) == 0 && len(d.NetworksRemoved) == 0 &&
+		len(d.StatusLines) == 0 && len(d.Config) == 0 && len(d.ConfigLines) == 0
+}
+
+// DiffBundles compares the status and config of two debug bundles. It compares status.json and
+// config.json when both bundles have them and falls back to a line diff of the status summary of
+// status.txt and of config.txt. Encrypted bundles have to be decrypted first.
+func DiffBundles(pathA, pathB string) (*BundleDiff, error) {
+	a, err := readDiffFiles(pathA)
+	if err != nil {
+		return nil, err
+	}
+	b, err := readDiffFiles(pathB)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &BundleDiff{A: a.info, B: b.info}
+	if err := d.diffStatus(a, b); err != nil {
+		return nil, err
+	}
+	if err := d.diffConfig(a, b); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+type diffFiles struct {
+	info  BundleInfo
+	files map[string][]byte
+}
+
+func readDiffFiles(path string) (*diffFiles, error) {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("open bundle %s (encrypted bundles must be decrypted first): %w", path, err)
+	}
+	defer func() {
+		_ = archive.Close()
+	}()
+	registerZstdDecompressor(&archive.Reader)
+
+	result := &diffFiles{info: BundleInfo{Path: path}, files: make(map[string][]byte)}
+	for _, name := range []string{statusJSONFile, statusTextFile, configJSONFile, configTextFile} {
+		data, err := fs.ReadFile(archive, name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read %s of %s: %w", name, path, err)
+		}
+		result.files[name] = data
+	}
+
+	if manifest, err := readBundleManifest(&archive.Reader); err == nil {
+		result.info.GeneratedAt = manifest.GeneratedAt
+		result.info.DaemonVersion = manifest.DaemonVersion
+		result.info.Anonymized = manifest.Anonymized
+	}
+	return result, nil
+}
+
+func (f *diffFiles) has(name string) bool {
+	_, ok := f.files[name]
+	return ok
+}
+
+func (d *BundleDiff) diffStatus(a, b *diffFiles) error {
+	switch {
+	case a.has(statusJSONFile) && b.has(statusJSONFile):
+		var oldStatus, newStatus nbstatus.OutputOverview
+		if err := json.Unmarshal(a.files[statusJSONFile], &oldStatus); err != nil {
+			return fmt.Errorf("parse %s of %s: %w", statusJSONFile, a.info.Path, err)
+		}
+		if err := json.Unmarshal(b.files[statusJSONFile], &newStatus); err != nil {
+			return fmt.Errorf("parse %s of %s: %w", statusJSONFile, b.info.Path, err)
+		}
+		d.StatusFile = statusJSONFile
+		d.Status = diffFields(statusFields(oldStatus), statusFields(newStatus))
+		d.Peers = diffPeers(oldStatus.Peers.Details, newStatus.Peers.Details)
+		d.NetworksAdded, d.NetworksRemoved = diffLists(oldStatus.Networks, newStatus.Networks)
+	case a.has(statusTextFile) && b.has(statusTextFile):
+		d.StatusFile = statusTextFile
+		d.StatusLines = diffLines(statusSummaryLines(a.files[statusTextFile]), statusSummaryLines(b.files[statusTextFile]))
+	}
+	return nil
+}
+
+func (d *BundleDiff) diffConfig(a, b *diffFiles) error {
+	switch {
+	case a.has(configJSONFile) && b.has(configJSONFile):
+		oldConfig, err := flattenJSON(a.files[configJSONFile])
+		if err != nil {
+			return fmt.Errorf("parse %s of %s: %w", configJSONFile, a.info.Path, err)
+		}
+		newConfig, err := flattenJSON(b.files[configJSONFile])
+		if err != nil {
+			return fmt.Errorf("parse %s of %s: %w", configJSONFile, b.info.Path, err)
+		}
+		d.ConfigFile = configJSONFile
+		d.Config = diffMaps(oldConfig, newConfig)
+	case a.has(configTextFile) && b.has(configTextFile):
+		d.ConfigFile = configTextFile
+		d.ConfigLines = diffLines(splitLines(a.files[configTextFile]), splitLines(b.files[configTextFile]))
+	}
+	return nil
+}
+
+type diffField struct {
+	name  string
+	value string
+}
+
+func statusFields(o nbstatus.OutputOverview) []diffField {
+	interfaceType := "Userspace"
+	if o.KernelInterface {
+		interfaceType = "Kernel"
+	}
+	return []diffField{
+		{"Daemon version", o.DaemonVersion},
+		{"Daemon status", string(o.DaemonStatus)},
+		{"Profile", o.ProfileName},
+		{"Management", serverState(o.ManagementState.Connected, o.ManagementState.Error)},
+		{"Management URL", o.ManagementState.URL},
+		{"Signal", serverState(o.SignalState.Connected, o.SignalState.Error)},
+		{"Signal URL", o.SignalState.URL},
+		{"Relays available", fmt.Sprintf("%d/%d", o.Relays.Available, o.Relays.Total)},
+		{"Peers connected", fmt.Sprintf("%d/%d", o.Peers.Connected, o.Peers.Total)},
+		{"Stale peers", strconv.Itoa(o.Peers.Stale)},
+		{"FQDN", o.FQDN},
+		{"NetBird IP", o.IP},
+		{"NetBird IPv6", o.IPv6},
+		{"Public key", o.PubKey},
+		{"Interface type", interfaceType},
+		{"WireGuard port", strconv.Itoa(o.WgPort)},
+		{"Quantum resistance", strconv.FormatBool(o.RosenpassEnabled)},
+		{"Lazy connection", strconv.FormatBool(o.LazyConnectionEnabled)},
+		{"Forwarding rules", strconv.Itoa(o.NumberOfForwardingRules)},
+		{"SSH server", strconv.FormatBool(o.SSHServerState.Enabled)},
+	}
+}
+
+func serverState(connected bool, err string) string {
+	if connected {
+		return "Connected"
+	}
+	if err != "" {
+		return "Disconnected, " + err
+	}
+	return "Disconnected"
+}
+
+func peerFields(p nbstatus.PeerStateDetailOutput) []diffField {
+	networks := append([]string(nil), p.Networks...)
+	sort.Strings(networks)
+	var candidates string
+	if p.IceCandidateType.Local != "" || p.IceCandidateType.Remote != "" {
+		candidates = p.IceCandidateType.Local + "/" + p.IceCandidateType.Remote
+	}
+	return []diffField{
+		{"status", p.Status},
+		{"connection type", p.ConnType},
+		{"NetBird IP", p.IP},
+		{"relay address", p.RelayAddress},
+		{"ICE candidates", candidates},
+		{"stale", strconv.FormatBool(p.Stale)},
+		{"quantum resistance", strconv.FormatBool(p.RosenpassEnabled)},
+		{"networks", strings.Join(networks, ", ")},
+	}
+}
+
+// diffFields compares fields listed in the same order.
+func diffFields(a, b []diffField) []ValueChange {
+	var changes []ValueChange
+	for i := range a {
+		if a[i].value != b[i].value {
+			changes = append(changes, ValueChange{Field: a[i].name, Old: a[i].value, New: b[i].value})
+		}
+	}
+	return changes
+}
+
+func peerName(p nbstatus.PeerStateDetailOutput) string {
+	switch {
+	case p.FQDN != "":
+		return p.FQDN
+	case p.IP != "":
+		return p.IP
+	default:
+		return p.PubKey
+	}
+}
+
+// diffPeers matches the peers by public key, which anonymization keeps, and falls back to the
+// FQDN for peers without one.
+func diffPeers(a, b []nbstatus.PeerStateDetailOutput) []PeerChange {
+	key := func(p nbstatus.PeerStateDetailOutput) string {
+		if p.PubKey != "" {
+			return p.PubKey
+		}
+		return p.FQDN
+	}
+	newPeers := make(map[string]nbstatus.PeerStateDetailOutput, len(b))
+	for _, p := range b {
+		newPeers[key(p)] = p
+	}
+
+	var changes []PeerChange
+	seen := make(map[string]struct{}, len(a))
+	for _, oldPeer := range a {
+		seen[key(oldPeer)] = struct{}{}
+		newPeer, ok := newPeers[key(oldPeer)]
+		if !ok {
+			changes = append(changes, PeerChange{Peer: peerName(oldPeer), Kind: PeerRemoved, Status: oldPeer.Status})
+			continue
+		}
+		if fields := diffFields(peerFields(oldPeer), peerFields(newPeer)); len(fields) > 0 {
+			changes = append(changes, PeerChange{Peer: peerName(newPeer), Kind: PeerChanged, Fields: fields})
+		}
+	}
+	for _, newPeer := range b {
+		if _, ok := seen[key(newPeer)]; !ok {
+			changes = append(changes, PeerChange{Peer: peerName(newPeer), Kind: PeerAdded, Status: newPeer.Status})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Peer < changes[j].Peer
+	})
+	return changes
+}
+
+// diffLists returns the sorted entries only in b and only in a.
+func diffLists(a, b []string) (added, removed []string) {
+	inA := make(map[string]struct{}, len(a))
+	for _, s := range a {
+		inA[s] = struct{}{}
+	}
+	inB := make(map[string]struct{}, len(b))
+	for _, s := range b {
+		inB[s] = struct{}{}
+		if _, ok := inA[s]; !ok {
+			added = append(added, s)
+		}
+	}
+	for _, s := range a {
+		if _, ok := inB[s]; !ok {
+			removed = append(removed, s)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+// flattenJSON maps the fields of a JSON object to their JSON encoded values, nested objects
+// joined with a dot. Arrays are compared as a whole.
+func flattenJSON(data []byte) (map[string]string, error) {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	fields := make(map[string]string)
+	if err := flattenValue("", v, fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func flattenValue(prefix string, v any, fields map[string]string) error {
+	if object, ok := v.(map[string]any); ok && len(object) > 0 {
+		for k, child := range object {
+			name := k
+			if prefix != "" {
+				name = prefix + "." + k
+			}
+			if err := flattenValue(name, child, fields); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	fields[prefix] = string(encoded)
+	return nil
+}
+
+func diffMaps(a, b map[string]string) []ValueChange {
+	keys := make(map[string]struct{}, len(a)+len(b))
+	for k := range a {
+		keys[k] = struct{}{}
+	}
+	for k := range b {
+		keys[k] = struct{}{}
+	}
+
+	var changes []ValueChange
+	for _, k := range sortedKeys(keys) {
+		if a[k] != b[k] {
+			changes = append(changes, ValueChange{Field: k, Old: a[k], New: b[k]})
+		}
+	}
+	return changes
+}
+
+// statusSummaryLines returns the general summary at the end of status.txt, starting with the OS
+// line, or all lines of an older format without one.
+func statusSummaryLines(data []byte) []string {
+	text := string(data)
+	if i := strings.LastIndex(text, "\nOS: "); i >= 0 {
+		text = text[i+1:]
+	}
+	return splitLines([]byte(text))
+}
+
+func splitLines(data []byte) []string {
+	text := strings.TrimRight(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+// diffLines returns the lines only in a prefixed with "- " and the lines only in b prefixed with
+// "+ ", in order, based on their longest common subsequence.
+func diffLines(a, b []string) []string {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var lines []string
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, "- "+a[i])
+			i++
+		default:
+			lines = append(lines, "+ "+b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, "- "+a[i])
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, "+ "+b[j])
+	}
+	return lines
+}
+
+// FormatBundleDiff renders a BundleDiff as text.
+func FormatBundleDiff(d *BundleDiff) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("A: %s\n", formatBundleInfo(d.A)))
+	sb.WriteString(fmt.Sprintf("B: %s\n", formatBundleInfo(d.B)))
+	if d.A.Anonymized || d.B.Anonymized {
+		sb.WriteString("Anonymized names and addresses only match between bundles created with the same anonymization mapping. Peers are matched by public key.\n")
+	}
+
+	switch d.StatusFile {
+	case statusJSONFile:
+		sb.WriteString("\nStatus:\n")
+		writeValueChanges(&sb, d.Status)
+
+		sb.WriteString("\nPeers:\n")
+		if len(d.Peers) == 0 {
+			sb.WriteString("  no changes\n")
+		}
+		for _, p := range d.Peers {
+			switch p.Kind {
+			case PeerAdded:
+				sb.WriteString(fmt.Sprintf("  + %s (%s)\n", p.Peer, p.Status))
+			case PeerRemoved:
+				sb.WriteString(fmt.Sprintf("  - %s (%s)\n", p.Peer, p.Status))
+			default:
+				sb.WriteString(fmt.Sprintf("  ~ %s\n", p.Peer))
+				for _, f := range p.Fields {
+					sb.WriteString(fmt.Sprintf("      %s: %s -> %s\n", f.Field, valueOrNone(f.Old), valueOrNone(f.New)))
+				}
+			}
+		}
+
+		sb.WriteString("\nNetworks:\n")
+		if len(d.NetworksAdded) == 0 && len(d.NetworksRemoved) == 0 {
+			sb.WriteString("  no changes\n")
+		}
+		for _, n := range d.NetworksAdded {
+			sb.WriteString(fmt.Sprintf("  + %s\n", n))
+		}
+		for _, n := range d.NetworksRemoved {
+			sb.WriteString(fmt.Sprintf("  - %s\n", n))
+		}
+	case statusTextFile:
+		sb.WriteString("\nStatus summary (status.json is not in both bundles, text diff of status.txt):\n")
+		writeLineDiff(&sb, d.StatusLines)
+	default:
+		sb.WriteString("\nStatus: not compared, neither status.json nor status.txt is in both bundles\n")
+	}
+
+	switch d.ConfigFile {
+	case configJSONFile:
+		sb.WriteString("\nConfig:\n")
+		writeValueChanges(&sb, d.Config)
+	case configTextFile:
+		sb.WriteString("\nConfig (config.json is not in both bundles, text diff of config.txt):\n")
+		writeLineDiff(&sb, d.ConfigLines)
+	default:
+		sb.WriteString("\nConfig: not compared, neither config.json nor config.txt is in both bundles\n")
+	}
+
+	return sb.String()
+}
+
+func formatBundleInfo(info BundleInfo) string {
+	if info.GeneratedAt.IsZero() {
+		return info.Path + " (no manifest)"
+	}
+	version := info.DaemonVersion
+	if version == "" {
+		version = "unknown"
+	}
+	return fmt.Sprintf("%s (generated %s, daemon %s)", info.Path, info.GeneratedAt.UTC().Format(time.RFC3339), version)
+}
+
+func writeValueChanges(sb *strings.Builder, changes []ValueChange) {
+	if len(changes) == 0 {
+		sb.WriteString("  no changes\n")
+	}
+	for _, c := range changes {
+		sb.WriteString(fmt.Sprintf("  %s: %s -> %s\n", c.Field, valueOrNone(c.Old), valueOrNone(c.New)))
+	}
+}
+
+func writeLineDiff(sb *strings.Builder, lines []string) {
+	if len(lines) == 0 {
+		sb.WriteString("  no changes\n")
+	}
+	for _, l := range lines {
+		sb.WriteString("  " + l + "\n")
+	}
+}
+
+func valueOrNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
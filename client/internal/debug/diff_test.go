@@ -0,0 +1,121 @@
+package debug
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	nbstatus "github.com/netbirdio/netbird/client/status"
+)
+
+func statusJSON(t *testing.T, overview nbstatus.OutputOverview) string {
+	t.Helper()
+	data, err := json.Marshal(overview)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestDiffBundles(t *testing.T) {
+	before := nbstatus.OutputOverview{
+		DaemonVersion:   "0.50.0",
+		ManagementState: nbstatus.ManagementStateOutput{URL: "https://api.netbird.io:443", Connected: true},
+		Peers: nbstatus.PeersStateOutput{Total: 2, Connected: 2, Details: []nbstatus.PeerStateDetailOutput{
+			{FQDN: "db.netbird.cloud", PubKey: "key-db", Status: "Connected", ConnType: "P2P"},
+			{FQDN: "web.netbird.cloud", PubKey: "key-web", Status: "Connected", ConnType: "Relayed"},
+		}},
+		Networks: []string{"10.0.0.0/24", "10.1.0.0/24"},
+	}
+	after := before
+	after.DaemonVersion = "0.51.0"
+	after.ManagementState.Connected = false
+	after.ManagementState.Error = "context deadline exceeded"
+	after.Peers = nbstatus.PeersStateOutput{Total: 2, Connected: 1, Details: []nbstatus.PeerStateDetailOutput{
+		{FQDN: "db-renamed.netbird.cloud", PubKey: "key-db", Status: "Idle"},
+		{FQDN: "app.netbird.cloud", PubKey: "key-app", Status: "Connected", ConnType: "P2P"},
+	}}
+	after.Networks = []string{"10.1.0.0/24", "10.2.0.0/24"}
+
+	pathA := writeTestBundle(t, map[string]string{
+		"status.json": statusJSON(t, before),
+		"config.json": `{"ManagementURL":"https://api.netbird.io:443","WgPort":51820,"DNSLabels":["a"],"Extra":{"MTU":1280}}`,
+	}, nil)
+	pathB := writeTestBundle(t, map[string]string{
+		"status.json": statusJSON(t, after),
+		"config.json": `{"ManagementURL":"https://api.netbird.io:443","WgPort":51821,"DNSLabels":["a","b"],"Extra":{"MTU":1280,"LazyConnection":true}}`,
+	}, nil)
+
+	diff, err := DiffBundles(pathA, pathB)
+	require.NoError(t, err)
+	assert.Equal(t, "0.1.0", diff.A.DaemonVersion)
+	assert.True(t, diff.A.Anonymized)
+	assert.Equal(t, "status.json", diff.StatusFile)
+	assert.Equal(t, []ValueChange{
+		{Field: "Daemon version", Old: "0.50.0", New: "0.51.0"},
+		{Field: "Management", Old: "Connected", New: "Disconnected, context deadline exceeded"},
+		{Field: "Peers connected", Old: "2/2", New: "1/2"},
+	}, diff.Status)
+
+	assert.Equal(t, []PeerChange{
+		{Peer: "app.netbird.cloud", Kind: PeerAdded, Status: "Connected"},
+		{Peer: "db-renamed.netbird.cloud", Kind: PeerChanged, Fields: []ValueChange{
+			{Field: "status", Old: "Connected", New: "Idle"},
+			{Field: "connection type", Old: "P2P", New: ""},
+		}},
+		{Peer: "web.netbird.cloud", Kind: PeerRemoved, Status: "Connected"},
+	}, diff.Peers, "peers are matched by public key")
+	assert.Equal(t, []string{"10.2.0.0/24"}, diff.NetworksAdded)
+	assert.Equal(t, []string{"10.0.0.0/24"}, diff.NetworksRemoved)
+
+	assert.Equal(t, "config.json", diff.ConfigFile)
+	assert.Equal(t, []ValueChange{
+		{Field: "DNSLabels", Old: `["a"]`, New: `["a","b"]`},
+		{Field: "Extra.LazyConnection", Old: "", New: "true"},
+		{Field: "WgPort", Old: "51820", New: "51821"},
+	}, diff.Config)
+
+	out := FormatBundleDiff(diff)
+	assert.Contains(t, out, "  Management: Connected -> Disconnected, context deadline exceeded\n")
+	assert.Contains(t, out, "  + app.netbird.cloud (Connected)\n")
+	assert.Contains(t, out, "  - web.netbird.cloud (Connected)\n")
+	assert.Contains(t, out, "  ~ db-renamed.netbird.cloud\n      status: Connected -> Idle\n      connection type: P2P -> (none)\n")
+	assert.Contains(t, out, "  + 10.2.0.0/24\n")
+	assert.Contains(t, out, "  Extra.LazyConnection: (none) -> true\n")
+	assert.Contains(t, out, "Peers are matched by public key")
+}
+
+func TestDiffBundlesTextFallback(t *testing.T) {
+	statusA := "Peers detail:\n db.netbird.cloud:\n  Status: Connected\n\nOS: linux/amd64\nDaemon version: 0.50.0\nManagement: Connected\nPeers count: 1/1 Connected\n"
+	statusB := "Peers detail:\n db.netbird.cloud:\n  Status: Idle\n\nOS: linux/amd64\nDaemon version: 0.50.0\nManagement: Disconnected\nPeers count: 0/1 Connected\n"
+
+	pathA := writeTestBundle(t, map[string]string{"status.txt": statusA, "config.txt": "WgPort: 51820\nMTU: 1280\n"}, nil)
+	pathB := writeTestBundle(t, map[string]string{
+		"status.txt":  statusB,
+		"status.json": "{}",
+		"config.txt":  "WgPort: 51820\nMTU: 1420\n",
+	}, nil)
+
+	diff, err := DiffBundles(pathA, pathB)
+	require.NoError(t, err)
+	assert.Equal(t, "status.txt", diff.StatusFile)
+	assert.Equal(t, []string{
+		"- Management: Connected",
+		"- Peers count: 1/1 Connected",
+		"+ Management: Disconnected",
+		"+ Peers count: 0/1 Connected",
+	}, diff.StatusLines, "only the status summary is compared")
+	assert.Equal(t, "config.txt", diff.ConfigFile)
+	assert.Equal(t, []string{"- MTU: 1280", "+ MTU: 1420"}, diff.ConfigLines)
+	assert.False(t, diff.Empty())
+
+	same, err := DiffBundles(pathA, pathA)
+	require.NoError(t, err)
+	assert.True(t, same.Empty())
+	assert.Contains(t, FormatBundleDiff(same), "no changes")
+}
+
+func TestDiffLines(t *testing.T) {
+	assert.Empty(t, diffLines([]string{"a", "b"}, []string{"a", "b"}))
+	assert.Equal(t, []string{"- b", "+ x", "+ d"}, diffLines([]string{"a", "b", "c"}, []string{"a", "x", "c", "d"}))
+}
@@ -0,0 +1,155 @@
+package debug
+
+import (
+	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netbirdio/netbird/client/anonymize"
+	nbdns "github.com/netbirdio/netbird/client/internal/dns"
+)
+
+// DNSStateSource provides the DNS server configuration written to dns.txt.
+type DNSStateSource interface {
+	DebugState() nbdns.DebugState
+}
+
+func (g *BundleGenerator) addDNS() error {
+	if g.dnsState == nil {
+		log.Debug("skipping DNS state in debug bundle: DNS server not running")
+		return nil
+	}
+
+	state := g.dnsState.DebugState()
+	var scopeNote string
+	if g.domain != "" {
+		state = scopeDNSState(state, g.domain)
+		scope := g.domain
+		if g.anonymize {
+			scope = g.anonymizer.AnonymizeDomain(scope)
+		}
+		scopeNote = fmt.Sprintf("Scoped to the resolution path of %s, other domains are left out.\n\n", scope)
+	}
+
+	content := scopeNote + formatDNSState(state, g.anonymize, g.anonymizer)
+	if err := g.addFileToZip(strings.NewReader(content), "dns.txt"); err != nil {
+		return fmt.Errorf("add DNS state file to zip: %w", err)
+	}
+	return nil
+}
+
+func formatDNSState(state nbdns.DebugState, anonymize bool, anonymizer *anonymize.Anonymizer) string {
+	domainName := func(d string) string {
+		if anonymize {
+			return anonymizer.AnonymizeDomain(d)
+		}
+		return d
+	}
+	anonIP := func(ip netip.Addr) netip.Addr {
+		if anonymize {
+			return anonymizer.AnonymizeIP(ip)
+		}
+		return ip
+	}
+
+	var builder strings.Builder
+	builder.WriteString("DNS Server:\n")
+	builder.WriteString("===========\n")
+	if state.ServerIP.IsValid() {
+		builder.WriteString(fmt.Sprintf("Listen address: %s\n", netip.AddrPortFrom(anonIP(state.ServerIP), uint16(state.ServerPort))))
+	}
+	builder.WriteString(fmt.Sprintf("Host manager: %s\n", state.HostManager))
+	builder.WriteString(fmt.Sprintf("Route all queries: %t\n", state.RouteAll))
+
+	builder.WriteString("\nHost Domains:\n")
+	if len(state.Domains) == 0 {
+		builder.WriteString("  none\n")
+	}
+	for _, d := range state.Domains {
+		kind := "search"
+		if d.MatchOnly {
+			kind = "match only"
+		}
+		if d.Disabled {
+			kind += ", disabled"
+		}
+		builder.WriteString(fmt.Sprintf("  %s (%s)\n", domainName(d.Domain), kind))
+	}
+
+	builder.WriteString("\nNameserver Groups:\n")
+	if len(state.NameServerGroups) == 0 {
+		builder.WriteString("  none\n")
+	}
+	for i, group := range state.NameServerGroups {
+		scope := "primary"
+		if !group.Primary {
+			domains := make([]string, 0, len(group.Domains))
+			for _, d := range group.Domains {
+				domains = append(domains, domainName(d))
+			}
+			scope = "domains: " + strings.Join(domains, ", ")
+		}
+		builder.WriteString(fmt.Sprintf("  Group %d (%s, search domains: %t)\n", i+1, scope, group.SearchDomainsEnabled))
+		for _, ns := range group.NameServers {
+			health := formatUpstreamHealth(state.UpstreamHealth[netip.AddrPortFrom(ns.IP, uint16(ns.Port))])
+			if anonymize {
+				health = anonymizer.AnonymizeString(health)
+			}
+			builder.WriteString(fmt.Sprintf("    %s %s%s\n", netip.AddrPortFrom(anonIP(ns.IP), uint16(ns.Port)), ns.NSType, health))
+		}
+	}
+
+	builder.WriteString("\nNetBird-managed Zones:\n")
+	if len(state.CustomZones) == 0 {
+		builder.WriteString("  none\n")
+	}
+	for _, zone := range state.CustomZones {
+		builder.WriteString(fmt.Sprintf("  %s (%d records, search domain: %t, authoritative: %t)\n",
+			domainName(zone.Domain), len(zone.Records), !zone.SearchDomainDisabled, !zone.NonAuthoritative))
+		records := make([]string, 0, len(zone.Records))
+		for _, r := range zone.Records {
+			rdata := r.RData
+			if anonymize {
+				if ip, err := netip.ParseAddr(rdata); err == nil {
+					rdata = anonIP(ip).String()
+				} else {
+					rdata = domainName(rdata)
+				}
+			}
+			records = append(records, fmt.Sprintf("    %s %d %s %s %s\n", domainName(r.Name), r.TTL, r.Class, dns.Type(r.Type).String(), rdata))
+		}
+		sort.Strings(records)
+		for _, r := range records {
+			builder.WriteString(r)
+		}
+	}
+
+	builder.WriteString("\nHost Resolvers (fallback):\n")
+	if len(state.HostNameservers) == 0 {
+		builder.WriteString("  none reported by the host manager\n")
+	}
+	for _, ip := range state.HostNameservers {
+		builder.WriteString(fmt.Sprintf("  %s\n", anonIP(ip)))
+	}
+
+	return builder.String()
+}
+
+func formatUpstreamHealth(h nbdns.UpstreamHealth) string {
+	var parts []string
+	if !h.LastOk.IsZero() {
+		parts = append(parts, "last ok "+h.LastOk.UTC().Format(time.RFC3339))
+	}
+	if !h.LastFail.IsZero() {
+		parts = append(parts, fmt.Sprintf("last failure %s: %s", h.LastFail.UTC().Format(time.RFC3339), h.LastErr))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
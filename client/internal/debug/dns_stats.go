@@ -0,0 +1,154 @@
+package debug
+
+import (
+	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netbirdio/netbird/client/anonymize"
+	nbdns "github.com/netbirdio/netbird/client/internal/dns"
+	nbstatus "github.com/netbirdio/netbird/client/status"
+)
+
+const dnsStatsFile = "dns-stats.txt"
+
+// addDNSStats writes the recent DNS query failures and the cache counters of the DNS server.
+func (g *BundleGenerator) addDNSStats() error {
+	if g.dnsState == nil {
+		log.Debug("skipping DNS stats in debug bundle: DNS server not running")
+		return nil
+	}
+
+	stats := g.dnsState.DebugState().QueryStats
+	var scopeNote string
+	if g.domain != "" {
+		stats.Failures = scopeQueryFailures(stats.Failures, g.domain)
+		scope := g.domain
+		if g.anonymize {
+			scope = g.anonymizer.AnonymizeDomain(scope)
+		}
+		scopeNote = fmt.Sprintf("Scoped to %s, the failures of other names are left out. The counters cover all queries.\n\n", scope)
+	}
+
+	content := scopeNote + formatDNSStats(stats, g.anonymize, g.anonymizer)
+	if err := g.addFileToZip(strings.NewReader(content), dnsStatsFile); err != nil {
+		return fmt.Errorf("add %s to zip: %w", dnsStatsFile, err)
+	}
+	return nil
+}
+
+// scopeQueryFailures keeps the failures of the names matching the domain.
+func scopeQueryFailures(failures []nbdns.QueryFailure, scope string) []nbdns.QueryFailure {
+	var scoped []nbdns.QueryFailure
+	for _, f := range failures {
+		if nbstatus.DomainMatches(f.Name, scope) {
+			scoped = append(scoped, f)
+		}
+	}
+	return scoped
+}
+
+func formatDNSStats(stats nbdns.QueryStats, anonymize bool, anonymizer *anonymize.Anonymizer) string {
+	domainName := func(d string) string {
+		if anonymize {
+			return anonymizer.AnonymizeDomain(d)
+		}
+		return d
+	}
+	upstreamAddr := func(addr netip.AddrPort) netip.AddrPort {
+		if anonymize {
+			return netip.AddrPortFrom(anonymizer.AnonymizeIP(addr.Addr()), addr.Port())
+		}
+		return addr
+	}
+	errText := func(s string) string {
+		if anonymize {
+			return anonymizer.AnonymizeString(s)
+		}
+		return s
+	}
+
+	var builder strings.Builder
+	builder.WriteString("DNS Query Failures:\n")
+	builder.WriteString("===================\n")
+	builder.WriteString(fmt.Sprintf("Since the DNS server started: %d upstream, %d name\n\n", stats.UpstreamFailures, stats.NameFailures))
+	builder.WriteString("upstream: no upstream answered, the upstreams tried timed out, were unreachable or returned\n" +
+		"          SERVFAIL or REFUSED. For many different names this is a misconfigured or unreachable\n" +
+		"          nameserver, not a problem of the names.\n")
+	builder.WriteString("name:     an upstream answered that the name does not exist (NXDOMAIN) or failed it for a\n" +
+		"          reason every upstream shares, like a DNSSEC validation failure. The upstream works,\n" +
+		"          the name does not resolve.\n")
+
+	builder.WriteString("\nUpstreams without an answer:\n")
+	summaries := upstreamFailureSummaries(stats.Failures)
+	if len(summaries) == 0 {
+		builder.WriteString("  none\n")
+	}
+	for _, s := range summaries {
+		builder.WriteString(fmt.Sprintf("  %s: %d names, last error: %s\n", upstreamAddr(s.upstream), s.names, errText(s.lastErr)))
+	}
+
+	builder.WriteString("\nRecent Failures (oldest first):\n")
+	if len(stats.Failures) == 0 {
+		builder.WriteString("  none\n")
+	}
+	for _, f := range stats.Failures {
+		attempts := make([]string, 0, len(f.Attempts))
+		for _, a := range f.Attempts {
+			attempts = append(attempts, fmt.Sprintf("%s %s", upstreamAddr(a.Upstream), errText(a.Err)))
+		}
+		builder.WriteString(fmt.Sprintf("  %s %-8s %s %s: %s\n", f.Time.UTC().Format(time.RFC3339), f.Kind,
+			dns.Type(f.Type).String(), domainName(f.Name), strings.Join(attempts, "; ")))
+	}
+
+	cache := stats.Cache
+	builder.WriteString("\nManagement Domains Cache:\n")
+	builder.WriteString(fmt.Sprintf("  Entries: %d\n", cache.Entries))
+	builder.WriteString(fmt.Sprintf("  Hits: %d (stale: %d)\n", cache.Hits, cache.StaleHits))
+	builder.WriteString(fmt.Sprintf("  Misses: %d\n", cache.Misses))
+	builder.WriteString(fmt.Sprintf("  Refreshes: %d (failed: %d)\n", cache.Refreshes, cache.RefreshFailures))
+
+	return builder.String()
+}
+
+type upstreamFailureSummary struct {
+	upstream netip.AddrPort
+	names    int
+	lastErr  string
+}
+
+// upstreamFailureSummaries counts the distinct names each upstream failed to answer, an upstream
+// failing many names is more likely misconfigured than the names.
+func upstreamFailureSummaries(failures []nbdns.QueryFailure) []upstreamFailureSummary {
+	names := make(map[netip.AddrPort]map[string]struct{})
+	lastErr := make(map[netip.AddrPort]string)
+	for _, f := range failures {
+		if f.Kind != nbdns.QueryFailureUpstream {
+			continue
+		}
+		for _, a := range f.Attempts {
+			if names[a.Upstream] == nil {
+				names[a.Upstream] = make(map[string]struct{})
+			}
+			names[a.Upstream][strings.ToLower(f.Name)] = struct{}{}
+			lastErr[a.Upstream] = a.Err
+		}
+	}
+
+	summaries := make([]upstreamFailureSummary, 0, len(names))
+	for upstream, n := range names {
+		summaries = append(summaries, upstreamFailureSummary{upstream: upstream, names: len(n), lastErr: lastErr[upstream]})
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].names != summaries[j].names {
+			return summaries[i].names > summaries[j].names
+		}
+		return summaries[i].upstream.String() < summaries[j].upstream.String()
+	})
+	return summaries
+}
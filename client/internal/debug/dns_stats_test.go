@@ -0,0 +1,63 @@
+package debug
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/netbirdio/netbird/client/anonymize"
+	nbdns "github.com/netbirdio/netbird/client/internal/dns"
+	"github.com/netbirdio/netbird/client/internal/dns/mgmt"
+)
+
+func TestFormatDNSStats(t *testing.T) {
+	misconfigured := netip.MustParseAddrPort("203.0.113.53:53")
+	working := netip.MustParseAddrPort("198.51.100.53:53")
+	at := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	stats := nbdns.QueryStats{
+		Failures: []nbdns.QueryFailure{
+			{Time: at, Name: "db.corp.example.com.", Type: dns.TypeA, Kind: nbdns.QueryFailureUpstream,
+				Attempts: []nbdns.QueryAttempt{{Upstream: misconfigured, Err: "timeout after 2s"}}},
+			{Time: at, Name: "web.corp.example.com.", Type: dns.TypeA, Kind: nbdns.QueryFailureUpstream,
+				Attempts: []nbdns.QueryAttempt{{Upstream: misconfigured, Err: "REFUSED"}}},
+			{Time: at, Name: "missing.example.org.", Type: dns.TypeAAAA, Kind: nbdns.QueryFailureName,
+				Attempts: []nbdns.QueryAttempt{{Upstream: working, Err: "NXDOMAIN"}}},
+		},
+		UpstreamFailures: 7,
+		NameFailures:     1,
+		Cache:            mgmt.CacheStats{Entries: 4, Hits: 120, StaleHits: 3, Misses: 2, Refreshes: 9, RefreshFailures: 1},
+	}
+
+	plain := formatDNSStats(stats, false, anonymize.NewAnonymizer(anonymize.DefaultAddresses()))
+	assert.Contains(t, plain, "Since the DNS server started: 7 upstream, 1 name\n")
+	assert.Contains(t, plain, "  203.0.113.53:53: 2 names, last error: REFUSED\n")
+	assert.NotContains(t, plain, "198.51.100.53:53: ", "an upstream answering NXDOMAIN works")
+	assert.Contains(t, plain, "  2026-01-02T10:00:00Z upstream A db.corp.example.com.: 203.0.113.53:53 timeout after 2s\n")
+	assert.Contains(t, plain, "  2026-01-02T10:00:00Z name     AAAA missing.example.org.: 198.51.100.53:53 NXDOMAIN\n")
+	assert.Contains(t, plain, "  Hits: 120 (stale: 3)\n")
+	assert.Contains(t, plain, "  Refreshes: 9 (failed: 1)\n")
+
+	anonymizer := anonymize.NewAnonymizer(anonymize.DefaultAddresses())
+	anonymized := formatDNSStats(stats, true, anonymizer)
+	for _, secret := range []string{"corp.example.com", "example.org", "203.0.113.53", "198.51.100.53"} {
+		assert.NotContains(t, anonymized, secret)
+	}
+	masked := anonymizer.AnonymizeDomain("db.corp.example.com.")
+	assert.Contains(t, anonymized, masked, "names are masked consistently with the rest of the bundle")
+}
+
+func TestScopeQueryFailures(t *testing.T) {
+	failures := []nbdns.QueryFailure{
+		{Name: "db.corp.example.com."},
+		{Name: "example.org."},
+	}
+
+	scoped := scopeQueryFailures(failures, "corp.example.com")
+	require.Len(t, scoped, 1)
+	assert.Equal(t, "db.corp.example.com.", scoped[0].Name)
+	assert.Empty(t, scopeQueryFailures(failures, "other.example.net"))
+}
@@ -0,0 +1,78 @@
+package debug
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/netbirdio/netbird/client/anonymize"
+	nbdns "github.com/netbirdio/netbird/client/internal/dns"
+	dnsconfig "github.com/netbirdio/netbird/dns"
+)
+
+func TestFormatDNSState(t *testing.T) {
+	state := nbdns.DebugState{
+		ServerIP:    netip.MustParseAddr("100.64.0.1"),
+		ServerPort:  53,
+		HostManager: "systemd",
+		Domains: []nbdns.DomainConfig{
+			{Domain: "corp.example.com.", MatchOnly: true},
+		},
+		NameServerGroups: []*dnsconfig.NameServerGroup{{
+			Domains:     []string{"corp.example.com"},
+			NameServers: []dnsconfig.NameServer{{IP: netip.MustParseAddr("203.0.113.53"), NSType: dnsconfig.UDPNameServerType, Port: 53}},
+		}},
+		CustomZones: []dnsconfig.CustomZone{{
+			Domain:  "internal.example.org.",
+			Records: []dnsconfig.SimpleRecord{{Name: "db.internal.example.org.", Type: 1, Class: "IN", TTL: 300, RData: "203.0.113.5"}},
+		}},
+		HostNameservers: []netip.Addr{netip.MustParseAddr("192.0.2.1")},
+	}
+
+	plain := formatDNSState(state, false, anonymize.NewAnonymizer(anonymize.DefaultAddresses()))
+	assert.Contains(t, plain, "Host manager: systemd")
+	assert.Contains(t, plain, "corp.example.com. (match only)")
+	assert.Contains(t, plain, "203.0.113.53:53 udp")
+	assert.Contains(t, plain, "db.internal.example.org. 300 IN A 203.0.113.5")
+	assert.Contains(t, plain, "  192.0.2.1\n")
+
+	anonymized := formatDNSState(state, true, anonymize.NewAnonymizer(anonymize.DefaultAddresses()))
+	for _, secret := range []string{"corp.example.com", "internal.example.org", "203.0.113.53", "203.0.113.5", "192.0.2.1"} {
+		assert.NotContains(t, anonymized, secret)
+	}
+}
+
+func TestScopeDNSState(t *testing.T) {
+	primary := &dnsconfig.NameServerGroup{Primary: true, NameServers: []dnsconfig.NameServer{{IP: netip.MustParseAddr("8.8.8.8"), NSType: dnsconfig.UDPNameServerType, Port: 53}}}
+	corp := &dnsconfig.NameServerGroup{Domains: []string{"corp.example.com"}, NameServers: []dnsconfig.NameServer{{IP: netip.MustParseAddr("203.0.113.53"), NSType: dnsconfig.UDPNameServerType, Port: 53}}}
+	state := nbdns.DebugState{
+		Domains: []nbdns.DomainConfig{
+			{Domain: "corp.example.com.", MatchOnly: true},
+			{Domain: "example.org."},
+		},
+		NameServerGroups: []*dnsconfig.NameServerGroup{primary, corp},
+		CustomZones: []dnsconfig.CustomZone{{
+			Domain: "corp.example.com.",
+			Records: []dnsconfig.SimpleRecord{
+				{Name: "db.corp.example.com.", Type: 1, Class: "IN", TTL: 300, RData: "203.0.113.5"},
+				{Name: "web.corp.example.com.", Type: 1, Class: "IN", TTL: 300, RData: "203.0.113.6"},
+			},
+		}},
+	}
+
+	scoped := scopeDNSState(state, "db.corp.example.com")
+	assert.Equal(t, []nbdns.DomainConfig{{Domain: "corp.example.com.", MatchOnly: true}}, scoped.Domains)
+	assert.Equal(t, []*dnsconfig.NameServerGroup{corp}, scoped.NameServerGroups)
+	require.Len(t, scoped.CustomZones, 1)
+	assert.Equal(t, []dnsconfig.SimpleRecord{state.CustomZones[0].Records[0]}, scoped.CustomZones[0].Records)
+	assert.Len(t, state.CustomZones[0].Records, 2, "the original state is left as is")
+	assert.True(t, DNSStateHasDomain(state, "db.corp.example.com"))
+
+	scoped = scopeDNSState(state, "unrelated.test")
+	assert.Empty(t, scoped.Domains)
+	assert.Empty(t, scoped.CustomZones)
+	assert.Equal(t, []*dnsconfig.NameServerGroup{primary}, scoped.NameServerGroups)
+	assert.False(t, DNSStateHasDomain(state, "unrelated.test"))
+}
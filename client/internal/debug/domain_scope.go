@@ -0,0 +1,76 @@
+package debug
+
+import (
+	"slices"
+
+	nbdns "github.com/netbirdio/netbird/client/internal/dns"
+	"github.com/netbirdio/netbird/client/internal/peer"
+	nbstatus "github.com/netbirdio/netbird/client/status"
+	dnsconfig "github.com/netbirdio/netbird/dns"
+	"github.com/netbirdio/netbird/shared/management/domain"
+)
+
+// DNSStateHasDomain reports whether a host domain, a nameserver group domain or a NetBird-managed
+// zone of the DNS state matches the domain, see nbstatus.DomainMatches.
+func DNSStateHasDomain(state nbdns.DebugState, scope string) bool {
+	scoped := scopeDNSState(state, scope)
+	return len(scoped.Domains) > 0 || len(scoped.CustomZones) > 0 ||
+		slices.ContainsFunc(scoped.NameServerGroups, func(g *dnsconfig.NameServerGroup) bool { return !g.Primary })
+}
+
+// scopeDNSState keeps the parts of the DNS state on the resolution path of the domain: the
+// matching host domains, the nameserver groups for the domain or the primary groups when none
+// matches, and the matching zones with their matching records.
+func scopeDNSState(state nbdns.DebugState, scope string) nbdns.DebugState {
+	var domains []nbdns.DomainConfig
+	for _, d := range state.Domains {
+		if nbstatus.DomainMatches(d.Domain, scope) {
+			domains = append(domains, d)
+		}
+	}
+	state.Domains = domains
+
+	var matched, primary []*dnsconfig.NameServerGroup
+	for _, g := range state.NameServerGroups {
+		if g.Primary {
+			primary = append(primary, g)
+			continue
+		}
+		if slices.ContainsFunc(g.Domains, func(d string) bool { return nbstatus.DomainMatches(d, scope) }) {
+			matched = append(matched, g)
+		}
+	}
+	if len(matched) > 0 {
+		state.NameServerGroups = matched
+	} else {
+		state.NameServerGroups = primary
+	}
+
+	var zones []dnsconfig.CustomZone
+	for _, zone := range state.CustomZones {
+		if !nbstatus.DomainMatches(zone.Domain, scope) {
+			continue
+		}
+		var records []dnsconfig.SimpleRecord
+		for _, r := range zone.Records {
+			if nbstatus.DomainMatches(r.Name, scope) {
+				records = append(records, r)
+			}
+		}
+		zone.Records = records
+		zones = append(zones, zone)
+	}
+	state.CustomZones = zones
+	return state
+}
+
+// resolvedDomainsForScope keeps the resolved domains of the domain routes matching the domain.
+func resolvedDomainsForScope(resolved map[domain.Domain]peer.ResolvedDomainInfo, scope string) map[domain.Domain]peer.ResolvedDomainInfo {
+	scoped := make(map[domain.Domain]peer.ResolvedDomainInfo)
+	for d, info := range resolved {
+		if nbstatus.DomainMatches(d.SafeString(), scope) || nbstatus.DomainMatches(info.ParentDomain.SafeString(), scope) {
+			scoped[d] = info
+		}
+	}
+	return scoped
+}
@@ -0,0 +1,72 @@
+package debug
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netbirdio/netbird/client/firewall/uspfilter"
+)
+
+// DropStatsSource provides the inbound packet drop counters of the userspace filter.
+type DropStatsSource interface {
+	DropStats() uspfilter.DropStats
+}
+
+// DropCount is the number of packets dropped for a single reason.
+type DropCount struct {
+	Reason  string
+	Packets uint64
+}
+
+// DropCounts flattens drop stats into a list ordered like uspfilter.DropReasons.
+func DropCounts(stats uspfilter.DropStats) []DropCount {
+	counts := make([]DropCount, 0, len(stats.Drops))
+	for _, reason := range uspfilter.DropReasons() {
+		counts = append(counts, DropCount{
+			Reason:  reason.String(),
+			Packets: stats.Drops[reason],
+		})
+	}
+	return counts
+}
+
+// FormatDropStats renders drop counters as an aligned table, with each reason's
+// share of all inbound packets.
+func FormatDropStats(inbound uint64, drops []DropCount) string {
+	var total uint64
+	for _, d := range drops {
+		total += d.Packets
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("Inbound packets: %d\n", inbound))
+	builder.WriteString(fmt.Sprintf("Dropped packets: %d (%s)\n\n", total, percentOf(total, inbound)))
+	builder.WriteString(fmt.Sprintf("%-24s %12s %8s\n", "Reason", "Packets", "Share"))
+	for _, d := range drops {
+		builder.WriteString(fmt.Sprintf("%-24s %12d %8s\n", d.Reason, d.Packets, percentOf(d.Packets, inbound)))
+	}
+	return builder.String()
+}
+
+func percentOf(n, total uint64) string {
+	if total == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f%%", float64(n)*100/float64(total))
+}
+
+func (g *BundleGenerator) addDropStats() error {
+	if g.dropStats == nil {
+		log.Debug("skipping drop stats in debug bundle: userspace filter not active")
+		return nil
+	}
+
+	stats := g.dropStats.DropStats()
+	content := FormatDropStats(stats.InboundPackets, DropCounts(stats))
+	if err := g.addFileToZip(strings.NewReader(content), "drop_stats.txt"); err != nil {
+		return fmt.Errorf("add drop stats file to zip: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package debug
+
+import (
+	"errors"
+	"io"
+)
+
+// ErrEncryptionUnsupported is returned by Generate when an encryption key is set but the build
+// cannot encrypt bundles.
+var ErrEncryptionUnsupported = errors.New("debug bundle encryption is not supported in this build")
+
+// bundleEncrypter encrypts the bundle archive to a recipient public key.
+type bundleEncrypter interface {
+	Encrypt(w io.Writer) (io.WriteCloser, error)
+	Extension() string
+}
@@ -0,0 +1,7 @@
+//go:build ios || android
+
+package debug
+
+func parseEncryptionKey([]byte) (bundleEncrypter, error) {
+	return nil, ErrEncryptionUnsupported
+}
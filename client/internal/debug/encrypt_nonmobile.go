@@ -0,0 +1,12 @@
+//go:build !ios && !android
+
+package debug
+
+import (
+	"github.com/netbirdio/netbird/client/internal/debug/bundlecrypt"
+)
+
+// parseEncryptionKey accepts an age X25519 recipient or an OpenPGP public key.
+func parseEncryptionKey(key []byte) (bundleEncrypter, error) {
+	return bundlecrypt.ParseRecipient(key)
+}
@@ -0,0 +1,76 @@
+package debug
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+)
+
+const stateEventsFile = "events.json"
+
+type stateEvents struct {
+	// SessionStart is the start of the "debug for" session the bundle was created for.
+	SessionStart *time.Time `json:"session_start,omitempty"`
+	// Dropped counts the older transitions that no longer fit the in-memory log.
+	Dropped int          `json:"dropped"`
+	Events  []stateEvent `json:"events"`
+}
+
+type stateEvent struct {
+	Time   time.Time `json:"time"`
+	Event  string    `json:"event"`
+	Detail string    `json:"detail,omitempty"`
+	// InSession marks the transitions during the "debug for" session.
+	InSession bool `json:"in_session,omitempty"`
+}
+
+// FormatStateEvents renders the daemon state transitions as JSON, oldest first. With a non-zero
+// sessionStart the transitions from then on are marked as part of the session.
+func FormatStateEvents(transitions []peer.StateTransition, dropped int, sessionStart time.Time, anonymize func(string) string) ([]byte, error) {
+	out := stateEvents{Dropped: dropped, Events: make([]stateEvent, 0, len(transitions))}
+	if !sessionStart.IsZero() {
+		start := sessionStart.UTC()
+		out.SessionStart = &start
+	}
+	for _, t := range transitions {
+		detail := t.Detail
+		if anonymize != nil {
+			detail = anonymize(detail)
+		}
+		out.Events = append(out.Events, stateEvent{
+			Time:      t.Time.UTC(),
+			Event:     t.Event,
+			Detail:    detail,
+			InSession: !sessionStart.IsZero() && !t.Time.Before(sessionStart),
+		})
+	}
+	return json.MarshalIndent(out, "", "  ")
+}
+
+// addStateEvents writes the daemon state transitions kept by the status recorder. The details
+// carry addresses and error messages and are anonymized like the logs.
+func (g *BundleGenerator) addStateEvents() error {
+	if g.statusRecorder == nil {
+		log.Debug("skipping state events in debug bundle: no status recorder")
+		return nil
+	}
+
+	var anonymize func(string) string
+	if g.anonymize {
+		anonymize = g.anonymizer.AnonymizeString
+	}
+	transitions, dropped := g.statusRecorder.StateTransitions()
+	data, err := FormatStateEvents(transitions, dropped, g.sessionStart, anonymize)
+	if err != nil {
+		return fmt.Errorf("marshal state events: %w", err)
+	}
+	if err := g.addFileToZip(bytes.NewReader(data), stateEventsFile); err != nil {
+		return fmt.Errorf("add %s to zip: %w", stateEventsFile, err)
+	}
+	return nil
+}
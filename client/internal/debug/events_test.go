@@ -0,0 +1,41 @@
+package debug
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+)
+
+func TestFormatStateEvents(t *testing.T) {
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	transitions := []peer.StateTransition{
+		{Time: start.Add(-time.Minute), Event: peer.StateEventConnected, Detail: "100.64.0.1/16"},
+		{Time: start, Event: peer.StateEventDisconnected, Detail: "engine stopped"},
+		{Time: start.Add(time.Second), Event: peer.StateEventConnecting, Detail: "api.example.com:443"},
+	}
+
+	data, err := FormatStateEvents(transitions, 2, start, strings.ToUpper)
+	require.NoError(t, err)
+
+	var out stateEvents
+	require.NoError(t, json.Unmarshal(data, &out))
+	require.NotNil(t, out.SessionStart)
+	assert.True(t, out.SessionStart.Equal(start))
+	assert.Equal(t, 2, out.Dropped)
+	require.Len(t, out.Events, 3)
+	assert.False(t, out.Events[0].InSession)
+	assert.True(t, out.Events[1].InSession)
+	assert.True(t, out.Events[2].InSession)
+	assert.Equal(t, "API.EXAMPLE.COM:443", out.Events[2].Detail)
+
+	data, err = FormatStateEvents(nil, 0, time.Time{}, nil)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "session_start")
+	assert.Contains(t, string(data), `"events": []`)
+}
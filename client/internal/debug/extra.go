@@ -0,0 +1,235 @@
+package debug
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netbirdio/netbird/client/configs"
+)
+
+const (
+	// extraDir holds the files added with --include.
+	extraDir = "extra/"
+	// maxExtraFileSize is the largest file added with --include.
+	maxExtraFileSize = 10 << 20
+	// maxExtraFiles bounds the files matched by all --include patterns together.
+	maxExtraFiles = 100
+)
+
+// deniedExtraComponents are directory names whose content is never added with --include,
+// wherever they are.
+var deniedExtraComponents = []string{".ssh", ".gnupg", ".aws", ".kube", ".docker"}
+
+// deniedExtraPrefixes lists directories and files that hold credentials or are not regular
+// files. NetBird's own state directory is denied too, it holds the private keys.
+func deniedExtraPrefixes() []string {
+	prefixes := []string{configs.StateDir}
+	switch runtime.GOOS {
+	case "windows":
+		windir := os.Getenv("WINDIR")
+		if windir == "" {
+			windir = `C:\Windows`
+		}
+		prefixes = append(prefixes, filepath.Join(windir, "System32", "config"))
+	default:
+		prefixes = append(prefixes,
+			"/proc", "/sys", "/dev",
+			"/etc/shadow", "/etc/gshadow", "/etc/master.passwd", "/etc/sudoers",
+			"/etc/ssh", "/etc/ssl/private", "/etc/netbird",
+			"/private/var/db", "/Library/Keychains",
+		)
+	}
+	return prefixes
+}
+
+// ValidateExtraPaths checks the --include patterns before a bundle is generated. Patterns must
+// be absolute and must not point into a denied directory.
+func ValidateExtraPaths(patterns []string) error {
+	for _, pattern := range patterns {
+		if !filepath.IsAbs(pattern) {
+			return fmt.Errorf("include path %s is not absolute", pattern)
+		}
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("include path %s: %w", pattern, err)
+		}
+		if isDeniedExtraPath(pattern) {
+			return fmt.Errorf("include path %s is in a directory that may hold credentials", pattern)
+		}
+	}
+	return nil
+}
+
+func isDeniedExtraPath(path string) bool {
+	path = filepath.Clean(path)
+	for _, prefix := range deniedExtraPrefixes() {
+		if prefix == "" {
+			continue
+		}
+		prefix = filepath.Clean(prefix)
+		if pathHasPrefix(path, prefix) {
+			return true
+		}
+	}
+
+	for _, part := range strings.Split(filepath.ToSlash(path), "/") {
+		for _, denied := range deniedExtraComponents {
+			if strings.EqualFold(part, denied) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func pathHasPrefix(path, prefix string) bool {
+	if runtime.GOOS == "windows" {
+		path, prefix = strings.ToLower(path), strings.ToLower(prefix)
+	}
+	return path == prefix || strings.HasPrefix(path, strings.TrimSuffix(prefix, string(filepath.Separator))+string(filepath.Separator))
+}
+
+// extraFile is a file matched by an --include pattern.
+type extraFile struct {
+	// match is the path the pattern matched, it names the file in the bundle.
+	match string
+	// resolved is the path without symlinks that is read.
+	resolved string
+}
+
+// resolveExtraFiles expands the patterns to regular files. Matches that resolve into a denied
+// directory through a symlink are skipped.
+func resolveExtraFiles(patterns []string) ([]extraFile, error) {
+	seen := make(map[string]struct{})
+	var files []extraFile
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("expand %s: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			log.Warnf("include path %s matches no files", pattern)
+		}
+
+		for _, match := range matches {
+			resolved, err := filepath.EvalSymlinks(match)
+			if err != nil {
+				log.Warnf("skipping included file %s: %v", match, err)
+				continue
+			}
+			if isDeniedExtraPath(resolved) {
+				log.Warnf("skipping included file %s: it resolves to %s, which may hold credentials", match, resolved)
+				continue
+			}
+			info, err := os.Stat(resolved)
+			if err != nil || !info.Mode().IsRegular() {
+				log.Debugf("skipping included path %s: not a regular file", match)
+				continue
+			}
+			if _, ok := seen[resolved]; ok {
+				continue
+			}
+			seen[resolved] = struct{}{}
+
+			if len(files) == maxExtraFiles {
+				return files, fmt.Errorf("include paths match more than %d files", maxExtraFiles)
+			}
+			files = append(files, extraFile{match: match, resolved: resolved})
+		}
+	}
+	return files, nil
+}
+
+// flattenExtraPath turns an absolute path into a file name below extraDir, e.g.
+// /etc/app/health.json into etc_app_health.json.
+func flattenExtraPath(path string) string {
+	path = strings.TrimPrefix(path, filepath.VolumeName(path))
+	path = strings.Trim(filepath.ToSlash(path), "/")
+	return strings.ReplaceAll(path, "/", "_")
+}
+
+// addExtraFiles adds the files matching the --include patterns below extra/. They are not
+// anonymized.
+func (g *BundleGenerator) addExtraFiles() error {
+	if len(g.extraPaths) == 0 {
+		return nil
+	}
+
+	files, resolveErr := resolveExtraFiles(g.extraPaths)
+
+	used := make(map[string]int)
+	var errs []error
+	for _, file := range files {
+		name := flattenExtraPath(file.match)
+		if n := used[name]; n > 0 {
+			ext := filepath.Ext(name)
+			name = fmt.Sprintf("%s-%d%s", strings.TrimSuffix(name, ext), n+1, ext)
+		}
+		used[flattenExtraPath(file.match)]++
+
+		if err := g.addExtraFile(file.resolved, extraDir+name); err != nil {
+			errs = append(errs, fmt.Errorf("add %s: %w", file.match, err))
+		}
+	}
+
+	if resolveErr != nil {
+		errs = append(errs, resolveErr)
+	}
+	return errors.Join(errs...)
+}
+
+// addExtraFile adds the file at the resolved path. The path is opened without following a
+// symlink and checked again once open, so a file swapped for a symlink after it was resolved is
+// not read.
+func (g *BundleGenerator) addExtraFile(resolved, name string) error {
+	file, err := openNoFollow(resolved)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.Errorf("failed to close included file %s: %v", resolved, err)
+		}
+	}()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if err := checkOpenedExtraFile(resolved, info); err != nil {
+		return err
+	}
+	if info.Size() > maxExtraFileSize {
+		return fmt.Errorf("file is larger than %d MB", maxExtraFileSize>>20)
+	}
+
+	return g.addFileToZip(file, name)
+}
+
+// checkOpenedExtraFile checks that the opened file is a regular file that is still at the
+// resolved path, without a symlink on the way to it and outside of the denied directories.
+func checkOpenedExtraFile(resolved string, opened os.FileInfo) error {
+	if !opened.Mode().IsRegular() {
+		return errors.New("not a regular file")
+	}
+	current, err := filepath.EvalSymlinks(resolved)
+	if err != nil {
+		return err
+	}
+	if current != resolved || isDeniedExtraPath(current) {
+		return fmt.Errorf("path changed to %s while it was read", current)
+	}
+	info, err := os.Lstat(resolved)
+	if err != nil {
+		return err
+	}
+	if !os.SameFile(info, opened) {
+		return errors.New("file was replaced while it was read")
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+//go:build !unix
+
+package debug
+
+import (
+	"errors"
+	"os"
+)
+
+// openNoFollow opens a file for reading and fails if it is a symlink. Without O_NOFOLLOW the
+// path is checked before it is opened, checkOpenedExtraFile catches a swap in between.
+func openNoFollow(path string) (*os.File, error) {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.Mode()&os.ModeSymlink != 0 {
+		return nil, errors.New("file is a symlink")
+	}
+	return os.Open(path)
+}
@@ -0,0 +1,87 @@
+package debug
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateExtraPaths(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix paths")
+	}
+
+	assert.NoError(t, ValidateExtraPaths([]string{"/var/log/app/*.log", "/opt/app/health.json"}))
+	assert.Error(t, ValidateExtraPaths([]string{"app.log"}), "relative path")
+	assert.Error(t, ValidateExtraPaths([]string{"/etc/shadow"}))
+	assert.Error(t, ValidateExtraPaths([]string{"/proc/self/environ"}))
+	assert.Error(t, ValidateExtraPaths([]string{"/home/user/.ssh/id_ed25519"}))
+	assert.Error(t, ValidateExtraPaths([]string{"/var/log/[app"}), "malformed pattern")
+}
+
+func TestResolveExtraFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.log"), []byte("a"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.log"), []byte("b"), 0o600))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "sub.log"), 0o700))
+
+	files, err := resolveExtraFiles([]string{filepath.Join(dir, "*.log"), filepath.Join(dir, "a.log")})
+	require.NoError(t, err)
+	require.Len(t, files, 2, "directories and duplicates are skipped")
+	assert.Equal(t, filepath.Join(dir, "a.log"), files[0].match)
+	assert.Equal(t, filepath.Join(dir, "b.log"), files[1].match)
+
+	if runtime.GOOS != "windows" {
+		sshDir := filepath.Join(dir, ".ssh")
+		require.NoError(t, os.Mkdir(sshDir, 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(sshDir, "id"), []byte("key"), 0o600))
+		require.NoError(t, os.Symlink(filepath.Join(sshDir, "id"), filepath.Join(dir, "link.txt")))
+
+		files, err = resolveExtraFiles([]string{filepath.Join(dir, "link.txt")})
+		require.NoError(t, err)
+		assert.Empty(t, files, "symlinks into denied directories are skipped")
+	}
+}
+
+func TestFlattenExtraPath(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix paths")
+	}
+
+	assert.Equal(t, "etc_app_health.json", flattenExtraPath("/etc/app/health.json"))
+	assert.Equal(t, "app.log", flattenExtraPath("/app.log"))
+}
+
+func TestAddExtraFileRejectsSymlink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks need privileges on windows")
+	}
+
+	dir := t.TempDir()
+	target := filepath.Join(dir, "target.log")
+	require.NoError(t, os.WriteFile(target, []byte("a"), 0o600))
+	link := filepath.Join(dir, "link.log")
+	require.NoError(t, os.Symlink(target, link))
+
+	f, err := openNoFollow(link)
+	if err == nil {
+		_ = f.Close()
+	}
+	assert.Error(t, err, "a path swapped for a symlink after it was resolved is not followed")
+
+	resolved, err := filepath.EvalSymlinks(target)
+	require.NoError(t, err)
+	info, err := os.Stat(resolved)
+	require.NoError(t, err)
+	assert.NoError(t, checkOpenedExtraFile(resolved, info))
+
+	other := filepath.Join(dir, "other.log")
+	require.NoError(t, os.WriteFile(other, []byte("b"), 0o600))
+	otherInfo, err := os.Stat(other)
+	require.NoError(t, err)
+	assert.Error(t, checkOpenedExtraFile(resolved, otherInfo), "a replaced file is not read")
+}
@@ -0,0 +1,13 @@
+//go:build unix
+
+package debug
+
+import (
+	"os"
+	"syscall"
+)
+
+// openNoFollow opens a file for reading and fails if it is a symlink.
+func openNoFollow(path string) (*os.File, error) {
+	return os.OpenFile(path, os.O_RDONLY|syscall.O_NOFOLLOW, 0)
+}
@@ -0,0 +1,168 @@
+package debug
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/netbirdio/netbird/client/firewall"
+	"github.com/netbirdio/netbird/client/firewall/uspfilter"
+	"github.com/netbirdio/netbird/client/iface/netstack"
+	"github.com/netbirdio/netbird/client/internal/lazyconn"
+	"github.com/netbirdio/netbird/client/internal/metrics"
+	"github.com/netbirdio/netbird/client/internal/peer"
+	"github.com/netbirdio/netbird/client/internal/profilemanager"
+	mgmProto "github.com/netbirdio/netbird/shared/management/proto"
+)
+
+// FeatureSource describes where the effective value of a feature flag comes from.
+type FeatureSource string
+
+const (
+	FeatureSourceDefault    FeatureSource = "default"
+	FeatureSourceEnv        FeatureSource = "env"
+	FeatureSourceConfig     FeatureSource = "config"
+	FeatureSourceManagement FeatureSource = "management"
+)
+
+// FeatureFlag is the effective state of a single feature flag.
+type FeatureFlag struct {
+	Name    string
+	Enabled bool
+	Source  FeatureSource
+	// Detail names the setting that produced the value, e.g. the env variable.
+	Detail string
+	// Experimental marks features that are not considered stable yet.
+	Experimental bool
+}
+
+// envFeature is a feature gated purely by a boolean environment variable.
+type envFeature struct {
+	name     string
+	env      string
+	inverted bool // the variable disables the feature when set to true
+	enabled  bool // build default when the variable is unset or invalid
+}
+
+var envFeatures = []envFeature{
+	{name: "force_relay", env: peer.EnvKeyNBForceRelay, enabled: runtime.GOOS == "js"},
+	{name: "netstack", env: netstack.EnvUseNetstackMode},
+	{name: "userspace_firewall", env: firewall.EnvForceUserspaceFirewall},
+	{name: "conntrack", env: uspfilter.EnvDisableConntrack, inverted: true, enabled: true},
+	{name: "userspace_routing", env: uspfilter.EnvDisableUserspaceRouting, inverted: true, enabled: true},
+	{name: "force_userspace_router", env: uspfilter.EnvForceUserspaceRouter},
+	{name: "mss_clamping", env: uspfilter.EnvDisableMSSClamping, inverted: true, enabled: true},
+	{name: "local_forwarding", env: uspfilter.EnvEnableLocalForwarding},
+	{name: "metrics_push", env: metrics.EnvMetricsPushEnabled},
+}
+
+// CollectFeatureFlags returns the effective state of the client's feature flags
+// together with the source that decided each value. Local overrides (env, config)
+// take precedence over the management feature flags, mirroring the engine.
+func CollectFeatureFlags(config *profilemanager.Config, syncResponse *mgmProto.SyncResponse) []FeatureFlag {
+	flags := []FeatureFlag{
+		lazyConnectionFeature(config, syncResponse),
+		rosenpassFeature(config),
+	}
+
+	for _, f := range envFeatures {
+		flags = append(flags, f.resolve())
+	}
+
+	return flags
+}
+
+func (f envFeature) resolve() FeatureFlag {
+	flag := FeatureFlag{
+		Name:    f.name,
+		Enabled: f.enabled,
+		Source:  FeatureSourceDefault,
+	}
+
+	val, ok := os.LookupEnv(f.env)
+	if !ok || val == "" {
+		return flag
+	}
+
+	set, err := strconv.ParseBool(val)
+	if err != nil {
+		return flag
+	}
+
+	flag.Enabled = set != f.inverted
+	flag.Source = FeatureSourceEnv
+	flag.Detail = fmt.Sprintf("%s=%s", f.env, val)
+	return flag
+}
+
+func lazyConnectionFeature(config *profilemanager.Config, syncResponse *mgmProto.SyncResponse) FeatureFlag {
+	flag := FeatureFlag{
+		Name:   "lazy_connection",
+		Source: FeatureSourceDefault,
+	}
+
+	if state := lazyconn.EnvState(); state != lazyconn.StateUnset {
+		flag.Enabled = state == lazyconn.StateOn
+		flag.Source = FeatureSourceEnv
+		flag.Detail = fmt.Sprintf("%s=%s", lazyconn.EnvLazyConn, os.Getenv(lazyconn.EnvLazyConn))
+		return flag
+	}
+
+	if config != nil {
+		if state := lazyconn.ParseState(config.LazyConnection); state != lazyconn.StateUnset {
+			flag.Enabled = state == lazyconn.StateOn
+			flag.Source = FeatureSourceConfig
+			flag.Detail = "MDM policy"
+			return flag
+		}
+	}
+
+	if peerConfig := syncResponse.GetNetworkMap().GetPeerConfig(); peerConfig != nil {
+		flag.Enabled = peerConfig.GetLazyConnectionEnabled()
+		flag.Source = FeatureSourceManagement
+	}
+
+	return flag
+}
+
+func rosenpassFeature(config *profilemanager.Config) FeatureFlag {
+	flag := FeatureFlag{
+		Name:         "rosenpass",
+		Source:       FeatureSourceDefault,
+		Experimental: true,
+	}
+	if config == nil || !config.RosenpassEnabled {
+		return flag
+	}
+
+	flag.Enabled = true
+	flag.Source = FeatureSourceConfig
+	if config.RosenpassPermissive {
+		flag.Detail = "permissive"
+	}
+	return flag
+}
+
+// FormatFeatureFlags renders feature flags as an aligned table.
+func FormatFeatureFlags(flags []FeatureFlag) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("%-24s %-8s %-12s %s\n", "Feature", "State", "Source", "Detail"))
+	for _, f := range flags {
+		state := "disabled"
+		if f.Enabled {
+			state = "enabled"
+		}
+		builder.WriteString(fmt.Sprintf("%-24s %-8s %-12s %s\n", f.Name, state, f.Source, f.Detail))
+	}
+	return builder.String()
+}
+
+func (g *BundleGenerator) addFeatureFlags() error {
+	flags := CollectFeatureFlags(g.internalConfig, g.syncResponse)
+	if err := g.addFileToZip(strings.NewReader(FormatFeatureFlags(flags)), "features.txt"); err != nil {
+		return fmt.Errorf("add feature flags file to zip: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package debug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netbirdio/netbird/client/internal/lazyconn"
+	"github.com/netbirdio/netbird/client/internal/profilemanager"
+	mgmProto "github.com/netbirdio/netbird/shared/management/proto"
+)
+
+func TestEnvFeatureResolve(t *testing.T) {
+	const env = "NB_TEST_FEATURE"
+
+	tests := []struct {
+		name    string
+		feature envFeature
+		value   string
+		enabled bool
+		source  FeatureSource
+	}{
+		{name: "unset keeps default", feature: envFeature{env: env, enabled: true}, enabled: true, source: FeatureSourceDefault},
+		{name: "enable", feature: envFeature{env: env}, value: "true", enabled: true, source: FeatureSourceEnv},
+		{name: "inverted disables", feature: envFeature{env: env, inverted: true, enabled: true}, value: "true", enabled: false, source: FeatureSourceEnv},
+		{name: "invalid keeps default", feature: envFeature{env: env}, value: "maybe", enabled: false, source: FeatureSourceDefault},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(env, tt.value)
+
+			flag := tt.feature.resolve()
+			assert.Equal(t, tt.enabled, flag.Enabled)
+			assert.Equal(t, tt.source, flag.Source)
+		})
+	}
+}
+
+func TestLazyConnectionFeaturePrecedence(t *testing.T) {
+	sync := &mgmProto.SyncResponse{
+		NetworkMap: &mgmProto.NetworkMap{
+			PeerConfig: &mgmProto.PeerConfig{LazyConnectionEnabled: true},
+		},
+	}
+
+	t.Setenv(lazyconn.EnvLazyConn, "")
+	flag := lazyConnectionFeature(&profilemanager.Config{}, sync)
+	assert.True(t, flag.Enabled)
+	assert.Equal(t, FeatureSourceManagement, flag.Source)
+
+	flag = lazyConnectionFeature(&profilemanager.Config{LazyConnection: "off"}, sync)
+	assert.False(t, flag.Enabled)
+	assert.Equal(t, FeatureSourceConfig, flag.Source)
+
+	t.Setenv(lazyconn.EnvLazyConn, "on")
+	flag = lazyConnectionFeature(&profilemanager.Config{LazyConnection: "off"}, sync)
+	assert.True(t, flag.Enabled)
+	assert.Equal(t, FeatureSourceEnv, flag.Source)
+
+	t.Setenv(lazyconn.EnvLazyConn, "")
+	flag = lazyConnectionFeature(nil, nil)
+	assert.False(t, flag.Enabled)
+	assert.Equal(t, FeatureSourceDefault, flag.Source)
+}
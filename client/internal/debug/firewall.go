@@ -0,0 +1,75 @@
+package debug
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	firewall "github.com/netbirdio/netbird/client/firewall/manager"
+)
+
+const firewallFile = "firewall.txt"
+
+// FirewallRulesSource is implemented by firewall managers that can list the rules they installed.
+type FirewallRulesSource interface {
+	NetbirdRules() (string, error)
+}
+
+// nativeFirewallSource is implemented by the userspace filter, which leaves routing to the host
+// firewall when it can.
+type nativeFirewallSource interface {
+	NativeFirewall() firewall.Manager
+}
+
+// addNetbirdFirewallRules adds the rules the daemon's firewall manager installed. Unlike the
+// iptables.txt and nftables.txt system info files it leaves out rules NetBird didn't create.
+func (g *BundleGenerator) addNetbirdFirewallRules() error {
+	if g.firewall == nil {
+		log.Debug("skipping firewall rules in debug bundle: firewall manager not running")
+		return nil
+	}
+
+	content := collectNetbirdFirewallRules(g.firewall)
+	if g.anonymize {
+		content = g.anonymizer.AnonymizeString(content)
+	}
+
+	if err := g.addFileToZip(strings.NewReader(content), firewallFile); err != nil {
+		return fmt.Errorf("add firewall rules file to zip: %w", err)
+	}
+	return nil
+}
+
+func collectNetbirdFirewallRules(manager firewall.Manager) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("Firewall manager: %T\n\n", manager))
+
+	rules, err := netbirdFirewallRules(manager)
+	if err != nil {
+		builder.WriteString(fmt.Sprintf("Failed to list rules: %v\n", err))
+	} else {
+		builder.WriteString(rules)
+	}
+
+	if source, ok := manager.(nativeFirewallSource); ok && source.NativeFirewall() != nil {
+		builder.WriteString("\n")
+		builder.WriteString(collectNetbirdFirewallRules(source.NativeFirewall()))
+	}
+	return builder.String()
+}
+
+func netbirdFirewallRules(manager firewall.Manager) (string, error) {
+	if source, ok := manager.(FirewallRulesSource); ok {
+		return source.NetbirdRules()
+	}
+
+	rules, ok, err := platformFirewallRules(manager)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "Listing rules is not supported by this firewall manager\n", nil
+	}
+	return rules, nil
+}
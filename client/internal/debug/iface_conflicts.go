@@ -0,0 +1,239 @@
+package debug
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+)
+
+const (
+	// ConflictOverlay marks a host subnet overlapping the NetBird overlay network.
+	ConflictOverlay = "overlay"
+	// ConflictRoute marks a host subnet overlapping a network routed through a peer.
+	ConflictRoute = "route"
+)
+
+// HostInterface is a host network interface with the subnets of its addresses.
+type HostInterface struct {
+	Name     string
+	Prefixes []netip.Prefix
+}
+
+// InterfaceConflict is a host interface subnet overlapping a NetBird network.
+type InterfaceConflict struct {
+	Kind      string
+	Network   netip.Prefix
+	Interface string
+	Host      netip.Prefix
+}
+
+// InterfaceConflictReport is the outcome of comparing NetBird networks against host interfaces.
+type InterfaceConflictReport struct {
+	Overlay           []netip.Prefix
+	RoutesChecked     int
+	InterfacesChecked int
+	Conflicts         []InterfaceConflict
+}
+
+// HostInterfaces lists the host interfaces that are up, skipping loopback and the named NetBird interface.
+// Link-local addresses are ignored.
+func HostInterfaces(exclude string) ([]HostInterface, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, fmt.Errorf("get interfaces: %w", err)
+	}
+
+	var hosts []HostInterface
+	for _, iface := range ifaces {
+		if iface.Name == exclude || iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+
+		addrs, err := iface.Addrs()
+		if err != nil {
+			log.Debugf("failed to get addresses of interface %s: %v", iface.Name, err)
+			continue
+		}
+
+		host := HostInterface{Name: iface.Name}
+		for _, addr := range addrs {
+			ipNet, ok := addr.(*net.IPNet)
+			if !ok {
+				continue
+			}
+			ip, ok := netip.AddrFromSlice(ipNet.IP)
+			if !ok {
+				continue
+			}
+			ip = ip.Unmap()
+			if ip.IsLinkLocalUnicast() {
+				continue
+			}
+			bits, _ := ipNet.Mask.Size()
+			host.Prefixes = append(host.Prefixes, netip.PrefixFrom(ip, bits))
+		}
+		if len(host.Prefixes) > 0 {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts, nil
+}
+
+// FindInterfaceConflicts reports every host interface subnet overlapping the overlay networks or routes.
+// Default routes are skipped, they overlap every subnet by design.
+func FindInterfaceConflicts(overlay, routes []netip.Prefix, hosts []HostInterface) []InterfaceConflict {
+	var conflicts []InterfaceConflict
+	check := func(kind string, network netip.Prefix) {
+		for _, host := range hosts {
+			for _, prefix := range host.Prefixes {
+				if network.Overlaps(prefix.Masked()) {
+					conflicts = append(conflicts, InterfaceConflict{
+						Kind:      kind,
+						Network:   network,
+						Interface: host.Name,
+						Host:      prefix,
+					})
+				}
+			}
+		}
+	}
+
+	for _, network := range overlay {
+		check(ConflictOverlay, network.Masked())
+	}
+	for _, network := range routes {
+		if network.Bits() == 0 {
+			continue
+		}
+		check(ConflictRoute, network.Masked())
+	}
+	return conflicts
+}
+
+// CheckInterfaceConflicts compares the overlay network and the routes of the peers in the status
+// against the host interfaces, skipping the NetBird interface wgIface.
+func CheckInterfaceConflicts(status peer.FullStatus, wgIface string) (InterfaceConflictReport, error) {
+	var report InterfaceConflictReport
+	for _, addr := range []string{status.LocalPeerState.IP, status.LocalPeerState.IPv6} {
+		if addr == "" {
+			continue
+		}
+		prefix, err := netip.ParsePrefix(addr)
+		if err != nil {
+			log.Debugf("failed to parse overlay address %q: %v", addr, err)
+			continue
+		}
+		report.Overlay = append(report.Overlay, prefix)
+	}
+
+	routes := make(map[netip.Prefix]struct{})
+	for _, p := range status.Peers {
+		for route := range p.GetRoutes() {
+			// domain routes have no fixed prefix to compare
+			if prefix, err := netip.ParsePrefix(route); err == nil {
+				routes[prefix.Masked()] = struct{}{}
+			}
+		}
+	}
+	routeList := make([]netip.Prefix, 0, len(routes))
+	for prefix := range routes {
+		routeList = append(routeList, prefix)
+	}
+	sort.Slice(routeList, func(i, j int) bool {
+		return routeList[i].String() < routeList[j].String()
+	})
+	report.RoutesChecked = len(routeList)
+
+	hosts, err := HostInterfaces(wgIface)
+	if err != nil {
+		return report, err
+	}
+	report.InterfacesChecked = len(hosts)
+	report.Conflicts = FindInterfaceConflicts(report.Overlay, routeList, hosts)
+	return report, nil
+}
+
+// FormatInterfaceConflicts renders an interface conflict report as text.
+func FormatInterfaceConflicts(report InterfaceConflictReport) string {
+	var builder strings.Builder
+
+	overlay := make([]string, 0, len(report.Overlay))
+	for _, prefix := range report.Overlay {
+		overlay = append(overlay, prefix.Masked().String())
+	}
+	if len(overlay) == 0 {
+		overlay = append(overlay, "unknown (not connected)")
+	}
+	builder.WriteString(fmt.Sprintf("Overlay network: %s\n", strings.Join(overlay, ", ")))
+	builder.WriteString(fmt.Sprintf("Routes checked: %d\n", report.RoutesChecked))
+	builder.WriteString(fmt.Sprintf("Host interfaces checked: %d\n\n", report.InterfacesChecked))
+
+	if len(report.Conflicts) == 0 {
+		builder.WriteString("No conflicts found.\n")
+		return builder.String()
+	}
+
+	builder.WriteString(fmt.Sprintf("%-8s %-20s %-16s %s\n", "Kind", "NetBird network", "Interface", "Host address"))
+	for _, c := range report.Conflicts {
+		builder.WriteString(fmt.Sprintf("%-8s %-20s %-16s %s\n", c.Kind, c.Network, c.Interface, c.Host))
+	}
+
+	builder.WriteString(fmt.Sprintf("\n%d conflict(s) found. ", len(report.Conflicts)))
+	builder.WriteString("Traffic for overlapping addresses may leave through the wrong interface. " +
+		"Change the host subnet, or the NetBird network range or route in the management settings.\n")
+	return builder.String()
+}
+
+func (g *BundleGenerator) addInterfaceConflicts() error {
+	if g.statusRecorder == nil {
+		log.Debug("skipping interface conflicts in debug bundle: no status recorder")
+		return nil
+	}
+
+	var wgIface string
+	if g.internalConfig != nil {
+		wgIface = g.internalConfig.WgIface
+	}
+
+	report, err := CheckInterfaceConflicts(g.statusRecorder.GetFullStatus(), wgIface)
+	if err != nil {
+		return fmt.Errorf("check interface conflicts: %w", err)
+	}
+
+	if g.anonymize {
+		report = g.anonymizeInterfaceConflicts(report)
+	}
+
+	content := FormatInterfaceConflicts(report)
+	if err := g.addFileToZip(strings.NewReader(content), "interface_conflicts.txt"); err != nil {
+		return fmt.Errorf("add interface conflicts file to zip: %w", err)
+	}
+	return nil
+}
+
+func (g *BundleGenerator) anonymizeInterfaceConflicts(report InterfaceConflictReport) InterfaceConflictReport {
+	anonPrefix := func(prefix netip.Prefix) netip.Prefix {
+		return netip.PrefixFrom(g.anonymizer.AnonymizeIP(prefix.Addr()), prefix.Bits())
+	}
+
+	overlay := make([]netip.Prefix, 0, len(report.Overlay))
+	for _, prefix := range report.Overlay {
+		overlay = append(overlay, anonPrefix(prefix))
+	}
+	report.Overlay = overlay
+
+	conflicts := make([]InterfaceConflict, 0, len(report.Conflicts))
+	for _, c := range report.Conflicts {
+		c.Network = anonPrefix(c.Network)
+		c.Host = anonPrefix(c.Host)
+		conflicts = append(conflicts, c)
+	}
+	report.Conflicts = conflicts
+	return report
+}
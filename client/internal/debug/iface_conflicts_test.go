@@ -0,0 +1,41 @@
+package debug
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindInterfaceConflicts(t *testing.T) {
+	hosts := []HostInterface{
+		{Name: "eth0", Prefixes: []netip.Prefix{netip.MustParsePrefix("100.64.3.7/24")}},
+		{Name: "eth1", Prefixes: []netip.Prefix{netip.MustParsePrefix("192.168.1.10/24")}},
+		{Name: "eth2", Prefixes: []netip.Prefix{netip.MustParsePrefix("172.16.0.5/16")}},
+	}
+	overlay := []netip.Prefix{netip.MustParsePrefix("100.64.0.1/16")}
+	routes := []netip.Prefix{
+		netip.MustParsePrefix("0.0.0.0/0"),
+		netip.MustParsePrefix("192.168.0.0/16"),
+		netip.MustParsePrefix("10.0.0.0/8"),
+	}
+
+	conflicts := FindInterfaceConflicts(overlay, routes, hosts)
+	require.Len(t, conflicts, 2)
+
+	assert.Equal(t, InterfaceConflict{
+		Kind:      ConflictOverlay,
+		Network:   netip.MustParsePrefix("100.64.0.0/16"),
+		Interface: "eth0",
+		Host:      netip.MustParsePrefix("100.64.3.7/24"),
+	}, conflicts[0])
+	assert.Equal(t, InterfaceConflict{
+		Kind:      ConflictRoute,
+		Network:   netip.MustParsePrefix("192.168.0.0/16"),
+		Interface: "eth1",
+		Host:      netip.MustParsePrefix("192.168.1.10/24"),
+	}, conflicts[1])
+
+	assert.Empty(t, FindInterfaceConflicts(overlay, nil, hosts[1:]))
+}
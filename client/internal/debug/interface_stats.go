@@ -0,0 +1,222 @@
+package debug
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netbirdio/netbird/client/iface/configurer"
+)
+
+const interfaceStatsFile = "interface-stats.txt"
+
+// interfaceStatsTimeout bounds reading the interface counters.
+const interfaceStatsTimeout = 5 * time.Second
+
+// InterfaceCounters are the traffic counters of a network interface since it was created.
+type InterfaceCounters struct {
+	Name      string
+	RxBytes   uint64
+	TxBytes   uint64
+	RxPackets uint64
+	TxPackets uint64
+	RxErrors  uint64
+	TxErrors  uint64
+	RxDropped uint64
+	TxDropped uint64
+}
+
+// InterfaceStats holds the interface counters at one point in time.
+type InterfaceStats struct {
+	Time time.Time
+	// Interfaces are the network interfaces that are up, without loopback, ordered by name.
+	Interfaces []InterfaceCounters
+	// WireGuard holds the bytes sent and received over the WireGuard interface, summed over its
+	// peers. It is read from the interface itself, so it is also set when the interface is not a
+	// network interface of the system, like with the netstack. Nil when the interface is not up.
+	WireGuard *InterfaceCounters
+}
+
+// WireGuardStatsSource provides the statistics of the WireGuard interface and its peers.
+type WireGuardStatsSource interface {
+	PeersStatus() (*configurer.Stats, error)
+}
+
+// CollectInterfaceStats reads the counters of the network interfaces and of the WireGuard
+// interface of wg, which may be nil. The returned error names the counters that could not be
+// read, the returned stats hold the rest.
+func CollectInterfaceStats(wg WireGuardStatsSource) (InterfaceStats, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), interfaceStatsTimeout)
+	defer cancel()
+
+	stats := InterfaceStats{Time: time.Now().UTC()}
+
+	var errs []error
+	interfaces, err := readInterfaceCounters(ctx)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("network interface counters: %w", err))
+	}
+	stats.Interfaces = interfaces
+
+	if wg != nil {
+		wgStats, err := wg.PeersStatus()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("WireGuard counters: %w", err))
+		} else {
+			stats.WireGuard = sumPeerTransfer(wgStats)
+		}
+	}
+	return stats, errors.Join(errs...)
+}
+
+func sumPeerTransfer(stats *configurer.Stats) *InterfaceCounters {
+	counters := &InterfaceCounters{Name: stats.DeviceName}
+	for _, peer := range stats.Peers {
+		counters.RxBytes += uint64(peer.RxBytes)
+		counters.TxBytes += uint64(peer.TxBytes)
+	}
+	return counters
+}
+
+// FormatInterfaceStats renders the interface counters of end and, when start is set, the change
+// since start. Counters lower than at the start were reset in between, for example because the
+// interface was created again, their change is counted from end alone.
+func FormatInterfaceStats(start *InterfaceStats, end InterfaceStats, collectErr error) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Interface counters at %s\n", end.Time.Format(time.RFC3339)))
+	if collectErr != nil {
+		sb.WriteString(fmt.Sprintf("Not available: %v\n", collectErr))
+	}
+	sb.WriteString("\n")
+	writeWireGuardTransfer(&sb, end.WireGuard, "")
+	writeInterfaceTable(&sb, end.Interfaces, wireGuardName(end), nil)
+
+	if start == nil {
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("\nChange since the start of the debug session at %s (%s):\n\n",
+		start.Time.Format(time.RFC3339), end.Time.Sub(start.Time).Round(time.Second)))
+
+	var wgNote string
+	wgDelta := end.WireGuard
+	if start.WireGuard != nil && end.WireGuard != nil {
+		var reset bool
+		wgDelta, reset = counterDelta(*start.WireGuard, *end.WireGuard)
+		if reset {
+			wgNote = " (counters went down, a peer was removed or the interface created again, counted from zero)"
+		}
+	} else if end.WireGuard != nil {
+		wgNote = " (interface not up at the start)"
+	}
+	writeWireGuardTransfer(&sb, wgDelta, wgNote)
+
+	startByName := make(map[string]InterfaceCounters, len(start.Interfaces))
+	for _, c := range start.Interfaces {
+		startByName[c.Name] = c
+	}
+	deltas := make([]InterfaceCounters, 0, len(end.Interfaces))
+	notes := make(map[string]string)
+	for _, c := range end.Interfaces {
+		before, ok := startByName[c.Name]
+		if !ok {
+			deltas = append(deltas, c)
+			notes[c.Name] = "new"
+			continue
+		}
+		delta, reset := counterDelta(before, c)
+		if reset {
+			notes[c.Name] = "reset"
+		}
+		deltas = append(deltas, *delta)
+	}
+	writeInterfaceTable(&sb, deltas, wireGuardName(end), notes)
+	if len(notes) > 0 {
+		sb.WriteString("\nnew: the interface was not up at the start. reset: its counters were reset, counted from zero.\n")
+	}
+	return sb.String()
+}
+
+func wireGuardName(stats InterfaceStats) string {
+	if stats.WireGuard == nil {
+		return ""
+	}
+	return stats.WireGuard.Name
+}
+
+func writeWireGuardTransfer(sb *strings.Builder, counters *InterfaceCounters, note string) {
+	if counters == nil {
+		sb.WriteString("WireGuard: interface not up\n\n")
+		return
+	}
+	sb.WriteString(fmt.Sprintf("WireGuard %s, summed over its peers%s:\n", counters.Name, note))
+	sb.WriteString(fmt.Sprintf("  %d B received, %d B sent\n\n", counters.RxBytes, counters.TxBytes))
+}
+
+func writeInterfaceTable(sb *strings.Builder, interfaces []InterfaceCounters, wgName string, notes map[string]string) {
+	if len(interfaces) == 0 {
+		sb.WriteString("No network interface counters.\n")
+		return
+	}
+
+	sb.WriteString(fmt.Sprintf("%-20s %14s %12s %9s %9s %14s %12s %9s %9s\n",
+		"Interface", "RX bytes", "RX packets", "RX errs", "RX drops", "TX bytes", "TX packets", "TX errs", "TX drops"))
+	for _, c := range interfaces {
+		name := c.Name
+		if name == wgName {
+			name += " (wg)"
+		}
+		line := fmt.Sprintf("%-20s %14d %12d %9d %9d %14d %12d %9d %9d",
+			name, c.RxBytes, c.RxPackets, c.RxErrors, c.RxDropped, c.TxBytes, c.TxPackets, c.TxErrors, c.TxDropped)
+		if note := notes[c.Name]; note != "" {
+			line += "  " + note
+		}
+		sb.WriteString(line + "\n")
+	}
+}
+
+// counterDelta returns the change from start to end. When a counter went down the counters were
+// reset in between and end is returned as the change.
+func counterDelta(start, end InterfaceCounters) (*InterfaceCounters, bool) {
+	if end.RxBytes < start.RxBytes || end.TxBytes < start.TxBytes ||
+		end.RxPackets < start.RxPackets || end.TxPackets < start.TxPackets ||
+		end.RxErrors < start.RxErrors || end.TxErrors < start.TxErrors ||
+		end.RxDropped < start.RxDropped || end.TxDropped < start.TxDropped {
+		return &end, true
+	}
+	return &InterfaceCounters{
+		Name:      end.Name,
+		RxBytes:   end.RxBytes - start.RxBytes,
+		TxBytes:   end.TxBytes - start.TxBytes,
+		RxPackets: end.RxPackets - start.RxPackets,
+		TxPackets: end.TxPackets - start.TxPackets,
+		RxErrors:  end.RxErrors - start.RxErrors,
+		TxErrors:  end.TxErrors - start.TxErrors,
+		RxDropped: end.RxDropped - start.RxDropped,
+		TxDropped: end.TxDropped - start.TxDropped,
+	}, false
+}
+
+// addInterfaceStats writes the current interface counters and the change since the start of the
+// "debug for" session when the request carried its counters.
+func (g *BundleGenerator) addInterfaceStats() error {
+	var wg WireGuardStatsSource
+	if g.statusRecorder != nil {
+		wg = g.statusRecorder
+	}
+	end, err := CollectInterfaceStats(wg)
+	if err != nil {
+		log.Debugf("failed to read some interface counters: %v", err)
+	}
+
+	content := FormatInterfaceStats(g.interfaceStatsStart, end, err)
+	if err := g.addFileToZip(strings.NewReader(content), interfaceStatsFile); err != nil {
+		return fmt.Errorf("add %s to zip: %w", interfaceStatsFile, err)
+	}
+	return nil
+}
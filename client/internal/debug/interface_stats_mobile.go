@@ -0,0 +1,14 @@
+//go:build ios || android
+
+package debug
+
+import (
+	"context"
+	"errors"
+)
+
+// readInterfaceCounters is not supported on mobile platforms, only the WireGuard counters are
+// available there.
+func readInterfaceCounters(context.Context) ([]InterfaceCounters, error) {
+	return nil, errors.New("not supported on this platform")
+}
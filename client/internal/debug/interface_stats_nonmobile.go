@@ -0,0 +1,52 @@
+//go:build !ios && !android
+
+package debug
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+
+	gopsnet "github.com/shirou/gopsutil/v4/net"
+)
+
+// readInterfaceCounters returns the counters of the network interfaces that are up, without
+// loopback. Platforms without error or drop counters report them as zero.
+func readInterfaceCounters(ctx context.Context) ([]InterfaceCounters, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, fmt.Errorf("list interfaces: %w", err)
+	}
+	up := make(map[string]bool, len(ifaces))
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp != 0 && iface.Flags&net.FlagLoopback == 0 {
+			up[iface.Name] = true
+		}
+	}
+
+	stats, err := gopsnet.IOCountersWithContext(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("read counters: %w", err)
+	}
+
+	var counters []InterfaceCounters
+	for _, s := range stats {
+		if !up[s.Name] {
+			continue
+		}
+		counters = append(counters, InterfaceCounters{
+			Name:      s.Name,
+			RxBytes:   s.BytesRecv,
+			TxBytes:   s.BytesSent,
+			RxPackets: s.PacketsRecv,
+			TxPackets: s.PacketsSent,
+			RxErrors:  s.Errin,
+			TxErrors:  s.Errout,
+			RxDropped: s.Dropin,
+			TxDropped: s.Dropout,
+		})
+	}
+	sort.Slice(counters, func(i, j int) bool { return counters[i].Name < counters[j].Name })
+	return counters, nil
+}
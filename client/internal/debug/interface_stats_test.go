@@ -0,0 +1,79 @@
+package debug
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/netbirdio/netbird/client/iface/configurer"
+)
+
+type fakeWireGuardStats struct {
+	stats *configurer.Stats
+	err   error
+}
+
+func (f fakeWireGuardStats) PeersStatus() (*configurer.Stats, error) {
+	return f.stats, f.err
+}
+
+func TestCollectInterfaceStatsWireGuard(t *testing.T) {
+	stats, _ := CollectInterfaceStats(fakeWireGuardStats{stats: &configurer.Stats{
+		DeviceName: "wt0",
+		Peers: []configurer.Peer{
+			{RxBytes: 100, TxBytes: 10},
+			{RxBytes: 200, TxBytes: 20},
+		},
+	}})
+	require.NotNil(t, stats.WireGuard)
+	assert.Equal(t, InterfaceCounters{Name: "wt0", RxBytes: 300, TxBytes: 30}, *stats.WireGuard)
+
+	stats, err := CollectInterfaceStats(fakeWireGuardStats{err: errors.New("wgInterface is nil")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "WireGuard counters: wgInterface is nil")
+	assert.Nil(t, stats.WireGuard)
+}
+
+func TestFormatInterfaceStats(t *testing.T) {
+	start := &InterfaceStats{
+		Time:      time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC),
+		WireGuard: &InterfaceCounters{Name: "wt0", RxBytes: 1000, TxBytes: 500},
+		Interfaces: []InterfaceCounters{
+			{Name: "eth0", RxBytes: 10000, TxBytes: 8000, RxPackets: 100, TxPackets: 80, RxDropped: 1},
+			{Name: "wt0", RxBytes: 900, TxBytes: 400, RxPackets: 9, TxPackets: 4},
+		},
+	}
+	end := InterfaceStats{
+		Time:      time.Date(2026, 1, 2, 10, 5, 0, 0, time.UTC),
+		WireGuard: &InterfaceCounters{Name: "wt0", RxBytes: 1500, TxBytes: 700},
+		Interfaces: []InterfaceCounters{
+			{Name: "eth0", RxBytes: 12000, TxBytes: 9000, RxPackets: 120, TxPackets: 90, RxDropped: 3},
+			{Name: "eth1", RxBytes: 50, TxBytes: 60, RxPackets: 1, TxPackets: 2},
+			{Name: "wt0", RxBytes: 100, TxBytes: 50, RxPackets: 1, TxPackets: 1},
+		},
+	}
+
+	out := FormatInterfaceStats(start, end, nil)
+
+	assert.Contains(t, out, "Interface counters at 2026-01-02T10:05:00Z\n")
+	assert.Contains(t, out, "Change since the start of the debug session at 2026-01-02T10:00:00Z (5m0s)")
+	assert.Contains(t, out, "  500 B received, 200 B sent\n")
+	assert.Contains(t, out, "eth0                           2000           20         0         2           1000           10         0         0\n")
+	assert.Contains(t, out, "eth1                             50            1         0         0             60            2         0         0  new\n")
+	assert.Contains(t, out, "wt0 (wg)                        100            1         0         0             50            1         0         0  reset\n")
+	assert.NotContains(t, out, "Not available")
+}
+
+func TestFormatInterfaceStatsWithoutStart(t *testing.T) {
+	end := InterfaceStats{Time: time.Date(2026, 1, 2, 10, 5, 0, 0, time.UTC)}
+
+	out := FormatInterfaceStats(nil, end, errors.New("network interface counters: not supported on this platform"))
+
+	assert.Contains(t, out, "Not available: network interface counters: not supported on this platform\n")
+	assert.Contains(t, out, "WireGuard: interface not up\n")
+	assert.Contains(t, out, "No network interface counters.\n")
+	assert.NotContains(t, out, "Change since")
+}
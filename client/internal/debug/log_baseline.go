@@ -0,0 +1,178 @@
+package debug
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/netbirdio/netbird/util"
+)
+
+// LogBaselineFile is the file in the daemon's config directory that holds the LogBaseline of the
+// last incremental or full bundle.
+const LogBaselineFile = "debug-log-baseline.json"
+
+// logHeadSize is the length of the start of a log that identifies it across bundles.
+const logHeadSize = 4096
+
+// LogBaseline records where the active logs ended when the previous bundle of an incremental
+// series was created, see BundleConfig.LogBaseline.
+type LogBaseline struct {
+	// Bundle is the file name of the bundle that recorded the baseline.
+	Bundle    string    `json:"bundle"`
+	CreatedAt time.Time `json:"created_at"`
+	// Logs are the active logs by their name in the bundle, e.g. client.log.
+	Logs map[string]LogOffset `json:"logs"`
+}
+
+// LogOffset is the size of a log when the baseline was recorded and the SHA-256 of its first
+// bytes, up to logHeadSize. A log that no longer starts like that was rotated or truncated since.
+type LogOffset struct {
+	Size int64  `json:"size"`
+	Head string `json:"head"`
+}
+
+// LoadLogBaseline reads the baseline from path, nil if no incremental or full bundle recorded one.
+func LoadLogBaseline(path string) (*LogBaseline, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read log baseline: %w", err)
+	}
+
+	var baseline LogBaseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("parse log baseline %s: %w", path, err)
+	}
+	return &baseline, nil
+}
+
+// SaveLogBaseline writes the baseline to path for the next incremental bundle.
+func SaveLogBaseline(path string, baseline *LogBaseline) error {
+	if err := util.WriteJsonWithRestrictedPermission(context.Background(), path, baseline); err != nil {
+		return fmt.Errorf("write log baseline: %w", err)
+	}
+	return nil
+}
+
+// start returns the offset where the content of a log that is not in the baseline bundle begins:
+// the recorded size of the baseline log it starts like, 0 if it is not one of them. size is the
+// current size of the log, negative if unknown.
+func (b *LogBaseline) start(head []byte, size int64) int64 {
+	if b == nil {
+		return 0
+	}
+	for _, o := range b.Logs {
+		// an empty log matches any start
+		if o.Size == 0 || (size >= 0 && size < o.Size) {
+			continue
+		}
+		n := min(o.Size, logHeadSize)
+		if int64(len(head)) >= n && logHeadHash(head[:n]) == o.Head {
+			return o.Size
+		}
+	}
+	return 0
+}
+
+// marker notes the log content left out at the start of a log.
+func (b *LogBaseline) marker(skipped int64) string {
+	return fmt.Sprintf("[netbird debug bundle: %d bytes already in the baseline bundle %s of %s left out by --incremental]\n",
+		skipped, b.Bundle, b.CreatedAt.UTC().Format(time.RFC3339))
+}
+
+func logHeadHash(head []byte) string {
+	sum := sha256.Sum256(head)
+	return hex.EncodeToString(sum[:])
+}
+
+// readLogHead returns the first logHeadSize bytes of a log, or all of a shorter one.
+func readLogHead(r io.ReaderAt, size int64) ([]byte, error) {
+	head := make([]byte, min(size, logHeadSize))
+	if _, err := r.ReadAt(head, 0); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	return head, nil
+}
+
+// isActiveLog reports whether a log is one the daemon or the UI currently writes to, as opposed
+// to a rotated one. Only active logs are recorded in a baseline.
+func isActiveLog(targetName string) bool {
+	switch targetName {
+	case clientLogFile, uiLogFile, errorLogFile, stdoutLogFile:
+		return true
+	}
+	return false
+}
+
+// incrementalLog returns the section of a log that is new since the baseline and the marker to
+// put in front of it, empty if nothing was left out. The end of an active log is recorded for the
+// next baseline.
+func (g *BundleGenerator) incrementalLog(targetName string, r io.ReaderAt, size int64) (*io.SectionReader, string, error) {
+	if g.logBaseline == nil && !g.recordLogBaseline {
+		return io.NewSectionReader(r, 0, size), "", nil
+	}
+
+	head, err := readLogHead(r, size)
+	if err != nil {
+		return nil, "", fmt.Errorf("read start of %s: %w", targetName, err)
+	}
+	if g.recordLogBaseline && isActiveLog(targetName) {
+		n := min(size, logHeadSize)
+		g.logEnds[targetName] = LogOffset{Size: size, Head: logHeadHash(head[:n])}
+	}
+
+	start := g.logBaseline.start(head, size)
+	if start == 0 {
+		return io.NewSectionReader(r, 0, size), "", nil
+	}
+	return io.NewSectionReader(r, start, size-start), g.logBaseline.marker(start), nil
+}
+
+// incrementalLogReader is incrementalLog for a log that can only be read sequentially, like a
+// decompressed rotated log. Rotated logs are not recorded in a baseline.
+func (g *BundleGenerator) incrementalLogReader(r io.Reader) (io.Reader, string, error) {
+	if g.logBaseline == nil {
+		return r, "", nil
+	}
+
+	br := bufio.NewReaderSize(r, logHeadSize)
+	head, err := br.Peek(logHeadSize)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, "", err
+	}
+	start := g.logBaseline.start(head, -1)
+	if start == 0 {
+		return br, "", nil
+	}
+	if _, err := br.Discard(int(start)); err != nil && !errors.Is(err, io.EOF) {
+		return nil, "", err
+	}
+	return br, g.logBaseline.marker(start), nil
+}
+
+// rotatedLogCutoff returns the time before which rotated logs are left out: the later of the
+// --since cutoff and the baseline, zero if neither is set.
+func (g *BundleGenerator) rotatedLogCutoff() time.Time {
+	cutoff := g.logCutoff
+	if g.logBaseline != nil && g.logBaseline.CreatedAt.After(cutoff) {
+		cutoff = g.logBaseline.CreatedAt
+	}
+	return cutoff
+}
+
+// RecordedLogBaseline returns the baseline for the next incremental bundle, nil unless
+// BundleConfig.RecordLogBaseline was set. It is final once Generate ran.
+func (g *BundleGenerator) RecordedLogBaseline() *LogBaseline {
+	return g.recordedBaseline
+}
@@ -0,0 +1,87 @@
+package debug
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readIncrementalLog(t *testing.T, g *BundleGenerator, name, content string) (string, string) {
+	t.Helper()
+	section, marker, err := g.incrementalLog(name, strings.NewReader(content), int64(len(content)))
+	require.NoError(t, err)
+	data, err := io.ReadAll(section)
+	require.NoError(t, err)
+	return string(data), marker
+}
+
+func TestIncrementalLog(t *testing.T) {
+	first := "2026-01-02T10:00:00Z INFO started\n"
+	grown := first + "2026-01-02T10:05:00Z INFO connected\n"
+
+	g := &BundleGenerator{recordLogBaseline: true, logEnds: make(map[string]LogOffset)}
+	data, marker := readIncrementalLog(t, g, clientLogFile, first)
+	assert.Equal(t, first, data, "without a baseline the whole log is included")
+	assert.Empty(t, marker)
+	_, _ = readIncrementalLog(t, g, "client.log.1", "rotated\n")
+	require.Contains(t, g.logEnds, clientLogFile)
+	assert.NotContains(t, g.logEnds, "client.log.1", "rotated logs are not recorded")
+
+	baseline := &LogBaseline{
+		Bundle:    "netbird.debug.1.zip",
+		CreatedAt: time.Date(2026, 1, 2, 10, 1, 0, 0, time.UTC),
+		Logs:      g.logEnds,
+	}
+	g = &BundleGenerator{logBaseline: baseline}
+	data, marker = readIncrementalLog(t, g, clientLogFile, grown)
+	assert.Equal(t, "2026-01-02T10:05:00Z INFO connected\n", data)
+	assert.Equal(t, "[netbird debug bundle: 34 bytes already in the baseline bundle netbird.debug.1.zip of 2026-01-02T10:01:00Z left out by --incremental]\n", marker)
+
+	rotated := "2026-01-02T10:06:00Z INFO new log\n"
+	data, marker = readIncrementalLog(t, g, clientLogFile, rotated)
+	assert.Equal(t, rotated, data, "a log that starts differently was rotated and is included whole")
+	assert.Empty(t, marker)
+
+	// the log active at the baseline was rotated and compressed since
+	r, marker, err := g.incrementalLogReader(strings.NewReader(grown))
+	require.NoError(t, err)
+	rest, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "2026-01-02T10:05:00Z INFO connected\n", string(rest))
+	assert.NotEmpty(t, marker)
+}
+
+func TestLogBaselineStartShrunk(t *testing.T) {
+	content := "2026-01-02T10:00:00Z INFO started\n"
+	baseline := &LogBaseline{Logs: map[string]LogOffset{
+		clientLogFile: {Size: int64(len(content)), Head: logHeadHash([]byte(content))},
+		errorLogFile:  {Size: 0, Head: logHeadHash(nil)},
+	}}
+
+	assert.Equal(t, int64(len(content)), baseline.start([]byte(content), int64(len(content))))
+	assert.Zero(t, baseline.start([]byte(content[:10]), 10), "a truncated log is included whole")
+	assert.Zero(t, (*LogBaseline)(nil).start([]byte(content), int64(len(content))))
+}
+
+func TestLoadLogBaseline(t *testing.T) {
+	path := filepath.Join(t.TempDir(), LogBaselineFile)
+
+	baseline, err := LoadLogBaseline(path)
+	require.NoError(t, err)
+	assert.Nil(t, baseline, "no baseline before the first incremental bundle")
+
+	want := &LogBaseline{
+		Bundle:    "netbird.debug.1.zip",
+		CreatedAt: time.Date(2026, 1, 2, 10, 1, 0, 0, time.UTC),
+		Logs:      map[string]LogOffset{clientLogFile: {Size: 34, Head: "abc"}},
+	}
+	require.NoError(t, SaveLogBaseline(path, want))
+	baseline, err = LoadLogBaseline(path)
+	require.NoError(t, err)
+	assert.Equal(t, want, baseline)
+}
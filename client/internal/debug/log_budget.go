@@ -0,0 +1,86 @@
+package debug
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// TruncatedLog describes a log file that was shortened or left out to stay within BundleConfig.MaxSize.
+type TruncatedLog struct {
+	Name string
+	// DroppedBytes is the amount of older uncompressed content that was left out.
+	DroppedBytes int64
+}
+
+func (t TruncatedLog) String() string {
+	if t.DroppedBytes == 0 {
+		return t.Name + " (dropped)"
+	}
+	return fmt.Sprintf("%s (%d bytes dropped)", t.Name, t.DroppedBytes)
+}
+
+// TruncatedLogs returns the logs shortened by the size cap in the last Generate call.
+func (g *BundleGenerator) TruncatedLogs() []TruncatedLog {
+	return g.truncatedLogs
+}
+
+// limitLog returns the newest content of a log that fits the remaining log budget. Logs are
+// added newest first, so once the budget is spent older files are dropped entirely. A marker
+// line replaces the truncated head of a log. It returns nil if nothing of the log fits.
+func (g *BundleGenerator) limitLog(name string, r io.ReaderAt, size int64) io.Reader {
+	if g.maxSize <= 0 {
+		return io.NewSectionReader(r, 0, size)
+	}
+	if size <= g.logBudget {
+		g.logBudget -= size
+		return io.NewSectionReader(r, 0, size)
+	}
+
+	remaining := g.logBudget
+	g.logBudget = 0
+	if remaining == 0 {
+		log.Debugf("dropping %s from debug bundle: size cap reached", name)
+		g.truncatedLogs = append(g.truncatedLogs, TruncatedLog{Name: name, DroppedBytes: size})
+		return nil
+	}
+
+	// start at the next full line to keep the first line intact
+	offset := size - remaining
+	br := bufio.NewReader(io.NewSectionReader(r, offset, remaining))
+	skipped, err := br.ReadString('\n')
+	if err != nil {
+		// a single partial line does not fit
+		g.truncatedLogs = append(g.truncatedLogs, TruncatedLog{Name: name, DroppedBytes: size})
+		return nil
+	}
+	dropped := offset + int64(len(skipped))
+
+	log.Debugf("truncating %s in debug bundle: dropping %d bytes of older content", name, dropped)
+	g.truncatedLogs = append(g.truncatedLogs, TruncatedLog{Name: name, DroppedBytes: dropped})
+	marker := fmt.Sprintf("[netbird debug bundle: %d bytes of older log content truncated to stay within --max-size]\n", dropped)
+	return io.MultiReader(strings.NewReader(marker), br)
+}
+
+// limitLogReader applies limitLog to a log that can only be read sequentially, like a
+// decompressed rotated log, by buffering it in memory.
+func (g *BundleGenerator) limitLogReader(name string, r io.Reader) (io.Reader, error) {
+	if g.maxSize <= 0 {
+		return r, nil
+	}
+	if g.logBudget <= 0 {
+		// skip decompressing logs that cannot fit anymore; the dropped size is unknown
+		g.truncatedLogs = append(g.truncatedLogs, TruncatedLog{Name: name})
+		return nil, nil
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", name, err)
+	}
+	return g.limitLog(name, bytes.NewReader(data), int64(len(data))), nil
+}
@@ -0,0 +1,48 @@
+package debug
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitLog(t *testing.T) {
+	newest := "line 3\nline 4\n"
+	older := "line 1\nline 2\n"
+
+	g := &BundleGenerator{maxSize: 16, logBudget: 16}
+
+	r := g.limitLog("client.log", strings.NewReader(newest), int64(len(newest)))
+	require.NotNil(t, r)
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, newest, string(data), "a log within the budget is kept whole")
+
+	g.logBudget = 10
+	r = g.limitLog("client.log.1", strings.NewReader(older), int64(len(older)))
+	require.NotNil(t, r)
+	data, err = io.ReadAll(r)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "[netbird debug bundle: 7 bytes"), string(data))
+	assert.True(t, strings.HasSuffix(string(data), "\nline 2\n"), "the newest full line is kept")
+
+	assert.Nil(t, g.limitLog("client.log.2", strings.NewReader(older), int64(len(older))), "nothing fits once the budget is spent")
+	assert.Equal(t, []TruncatedLog{
+		{Name: "client.log.1", DroppedBytes: 7},
+		{Name: "client.log.2", DroppedBytes: int64(len(older))},
+	}, g.TruncatedLogs())
+}
+
+func TestLimitLogUnlimited(t *testing.T) {
+	g := &BundleGenerator{}
+	content := strings.Repeat("x", 100)
+	r := g.limitLog("client.log", strings.NewReader(content), int64(len(content)))
+	require.NotNil(t, r)
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(data))
+	assert.Empty(t, g.TruncatedLogs())
+}
@@ -0,0 +1,150 @@
+package debug
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+const logFilterFile = "log-filter.txt"
+
+// LogFilter keeps or drops log lines by regular expression before they are added to a bundle.
+// A line is kept if it matches any include pattern, or there is none, and no exclude pattern.
+type LogFilter struct {
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+}
+
+// ParseLogFilter compiles the --log-include and --log-exclude patterns. It returns nil if both
+// are empty.
+func ParseLogFilter(include, exclude []string) (*LogFilter, error) {
+	if len(include) == 0 && len(exclude) == 0 {
+		return nil, nil
+	}
+
+	var f LogFilter
+	for _, pattern := range include {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log include pattern %q: %w", pattern, err)
+		}
+		f.include = append(f.include, re)
+	}
+	for _, pattern := range exclude {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log exclude pattern %q: %w", pattern, err)
+		}
+		f.exclude = append(f.exclude, re)
+	}
+	return &f, nil
+}
+
+func (f *LogFilter) keep(line []byte) bool {
+	for _, re := range f.exclude {
+		if re.Match(line) {
+			return false
+		}
+	}
+	if len(f.include) == 0 {
+		return true
+	}
+	for _, re := range f.include {
+		if re.Match(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterLines returns the kept lines of a log and the number of dropped lines.
+func (f *LogFilter) filterLines(r io.Reader) ([]byte, int, error) {
+	var out bytes.Buffer
+	var removed int
+
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadBytes('\n')
+		if len(line) > 0 {
+			if f.keep(bytes.TrimSuffix(line, []byte("\n"))) {
+				out.Write(line)
+			} else {
+				removed++
+			}
+		}
+		if err == io.EOF {
+			return out.Bytes(), removed, nil
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("read log: %w", err)
+		}
+	}
+}
+
+// filteredLog records the lines dropped from a log by the LogFilter.
+type filteredLog struct {
+	name    string
+	removed int
+}
+
+// filtersLogs reports whether logs are filtered by time, by pattern or cut to their last lines
+// before they are added.
+func (g *BundleGenerator) filtersLogs() bool {
+	return !g.logCutoff.IsZero() || g.logFilter != nil || g.logTail > 0
+}
+
+// filterLog drops the lines of a log written before the --since cutoff and those rejected by the
+// LogFilter, then keeps the last --tail lines of the rest.
+func (g *BundleGenerator) filterLog(targetName string, r io.Reader) ([]byte, error) {
+	var data []byte
+	if !g.logCutoff.IsZero() {
+		var err error
+		if data, err = filterLogSince(r, g.logCutoff); err != nil {
+			return nil, fmt.Errorf("by time: %w", err)
+		}
+		r = bytes.NewReader(data)
+	}
+
+	if g.logFilter != nil {
+		var removed int
+		var err error
+		if data, removed, err = g.logFilter.filterLines(r); err != nil {
+			return nil, fmt.Errorf("by pattern: %w", err)
+		}
+		g.filteredLogs = append(g.filteredLogs, filteredLog{name: targetName, removed: removed})
+	} else if data == nil {
+		var err error
+		if data, err = io.ReadAll(r); err != nil {
+			return nil, fmt.Errorf("read log: %w", err)
+		}
+	}
+
+	if g.logTail > 0 {
+		data = tailLog(data, g.logTail)
+	}
+	return data, nil
+}
+
+// addLogFilterNote records that the logs were filtered by pattern and how many lines were
+// dropped. The patterns are left out, they may name what the user wants to keep private.
+func (g *BundleGenerator) addLogFilterNote() error {
+	if g.logFilter == nil {
+		return nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("The log lines of this bundle were filtered before archiving.\n")
+	sb.WriteString(fmt.Sprintf("Include patterns: %d\nExclude patterns: %d\n\n", len(g.logFilter.include), len(g.logFilter.exclude)))
+
+	var total int
+	for _, f := range g.filteredLogs {
+		sb.WriteString(fmt.Sprintf("%s: %d lines removed\n", f.name, f.removed))
+		total += f.removed
+	}
+	sb.WriteString(fmt.Sprintf("Total: %d lines removed\n", total))
+
+	return g.addFileToZip(strings.NewReader(sb.String()), logFilterFile)
+}
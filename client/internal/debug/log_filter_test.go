@@ -0,0 +1,71 @@
+package debug
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogFilter(t *testing.T) {
+	content := strings.Join([]string{
+		"2025-01-01T10:00:00.000Z INFO client/internal/engine.go:100: connected to project-falcon",
+		"2025-01-01T10:00:01.000Z INFO client/internal/peer/conn.go:200: peer connected",
+		"2025-01-01T10:00:02.000Z WARN client/internal/dns/server.go:300: resolving falcon.internal failed",
+		"2025-01-01T10:00:03.000Z INFO client/internal/dns/server.go:310: upstream ok",
+		"",
+	}, "\n")
+
+	tests := []struct {
+		name        string
+		include     []string
+		exclude     []string
+		wantLines   []string
+		wantRemoved int
+	}{
+		{
+			name:        "exclude",
+			exclude:     []string{`(?i)falcon`},
+			wantLines:   []string{"peer connected", "upstream ok"},
+			wantRemoved: 2,
+		},
+		{
+			name:        "include",
+			include:     []string{`dns/`},
+			wantLines:   []string{"falcon.internal", "upstream ok"},
+			wantRemoved: 2,
+		},
+		{
+			name:        "exclude wins over include",
+			include:     []string{`dns/`},
+			exclude:     []string{`falcon`},
+			wantLines:   []string{"upstream ok"},
+			wantRemoved: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseLogFilter(tt.include, tt.exclude)
+			require.NoError(t, err)
+
+			data, removed, err := f.filterLines(strings.NewReader(content))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRemoved, removed)
+
+			lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+			require.Len(t, lines, len(tt.wantLines))
+			for i, want := range tt.wantLines {
+				assert.Contains(t, lines[i], want)
+			}
+		})
+	}
+
+	f, err := ParseLogFilter(nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, f)
+
+	_, err = ParseLogFilter(nil, []string{"("})
+	assert.ErrorContains(t, err, "invalid log exclude pattern")
+}
@@ -0,0 +1,53 @@
+package debug
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// logTimestamp parses the timestamp that starts a log line, as written by the netbird text
+// formatter or the logrus text formatter (time="..."). It returns false for lines without one,
+// like stack traces or multi-line messages.
+func logTimestamp(line []byte) (time.Time, bool) {
+	field, _, _ := bytes.Cut(line, []byte(" "))
+	ts := strings.Trim(strings.TrimPrefix(string(field), "time="), `"`)
+	if ts == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// filterLogSince returns the lines of a log written at or after cutoff. Lines without a
+// timestamp belong to the preceding line with one and are kept or dropped with it. Lines
+// before the first timestamp cannot be dated and are kept.
+func filterLogSince(r io.Reader, cutoff time.Time) ([]byte, error) {
+	var out bytes.Buffer
+	keep := true
+
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadBytes('\n')
+		if len(line) > 0 {
+			if t, ok := logTimestamp(line); ok {
+				keep = !t.Before(cutoff)
+			}
+			if keep {
+				out.Write(line)
+			}
+		}
+		if err == io.EOF {
+			return out.Bytes(), nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read log: %w", err)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package debug
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterLogSince(t *testing.T) {
+	content := strings.Join([]string{
+		"goroutine header without timestamp",
+		"2025-01-01T10:00:00.000Z INFO client/internal/engine.go:100: old entry",
+		"old stack line",
+		`time="2025-01-01T10:14:59Z" level=info msg="old logrus entry"`,
+		"2025-01-01T10:15:00.000Z WARN client/internal/engine.go:200: new entry",
+		"new stack line",
+		`time="2025-01-01T10:20:00+00:00" level=info msg="new logrus entry"`,
+		"",
+	}, "\n")
+	cutoff := time.Date(2025, 1, 1, 10, 15, 0, 0, time.UTC)
+
+	data, err := filterLogSince(strings.NewReader(content), cutoff)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Join([]string{
+		"goroutine header without timestamp",
+		"2025-01-01T10:15:00.000Z WARN client/internal/engine.go:200: new entry",
+		"new stack line",
+		`time="2025-01-01T10:20:00+00:00" level=info msg="new logrus entry"`,
+		"",
+	}, "\n"), string(data))
+}
@@ -0,0 +1,60 @@
+package debug
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// maxLogHeaderLines bounds the undated lines at the start of a log that --tail keeps as its
+// header. A log without any timestamped line has no header, all of its lines count.
+const maxLogHeaderLines = 10
+
+// tailLog keeps the last n lines of a log. The header, the undated lines before the first
+// timestamped one, is kept on top and a line starting with "[netbird debug bundle:" states how
+// many lines were left out. A dropped staged logs marker is kept below it, so the log still
+// shows it was merged. Logs of at most n lines are returned unchanged.
+func tailLog(data []byte, n int) []byte {
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	if len(lines) > 0 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+
+	header := 0
+	for header < len(lines) {
+		if _, ok := logTimestamp(lines[header]); ok {
+			break
+		}
+		header++
+	}
+	if header == len(lines) || header > maxLogHeaderLines {
+		header = 0
+	}
+
+	body := lines[header:]
+	if len(body) <= n {
+		return data
+	}
+	dropped, kept := body[:len(body)-n], body[len(body)-n:]
+
+	var staged bool
+	removed := len(dropped)
+	for _, line := range dropped {
+		if string(line) == stagedLogsMarker {
+			staged = true
+			removed--
+		}
+	}
+
+	var out bytes.Buffer
+	for _, line := range lines[:header] {
+		out.Write(line)
+	}
+	out.WriteString(fmt.Sprintf("[netbird debug bundle: %d older log lines left out by --tail]\n", removed))
+	if staged {
+		out.WriteString(stagedLogsMarker)
+	}
+	for _, line := range kept {
+		out.Write(line)
+	}
+	return out.Bytes()
+}
@@ -0,0 +1,101 @@
+package debug
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTailLog(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		n     int
+		want  []string
+	}{
+		{
+			name: "short log unchanged",
+			lines: []string{
+				"2025-01-01T10:00:00.000Z INFO first",
+				"2025-01-01T10:00:01.000Z INFO second",
+			},
+			n: 2,
+			want: []string{
+				"2025-01-01T10:00:00.000Z INFO first",
+				"2025-01-01T10:00:01.000Z INFO second",
+			},
+		},
+		{
+			name: "keeps header",
+			lines: []string{
+				"log started by the service wrapper",
+				"2025-01-01T10:00:00.000Z INFO first",
+				"2025-01-01T10:00:01.000Z INFO second",
+				"2025-01-01T10:00:02.000Z INFO third",
+			},
+			n: 1,
+			want: []string{
+				"log started by the service wrapper",
+				"[netbird debug bundle: 2 older log lines left out by --tail]",
+				"2025-01-01T10:00:02.000Z INFO third",
+			},
+		},
+		{
+			name: "undated log has no header",
+			lines: []string{
+				"panic: boom",
+				"goroutine 1 [running]:",
+				"main.main()",
+			},
+			n: 1,
+			want: []string{
+				"[netbird debug bundle: 2 older log lines left out by --tail]",
+				"main.main()",
+			},
+		},
+		{
+			name: "keeps staged marker",
+			lines: []string{
+				"2025-01-01T10:00:00.000Z INFO staged",
+				strings.TrimSuffix(stagedLogsMarker, "\n"),
+				"2025-01-01T10:00:01.000Z INFO current",
+				"2025-01-01T10:00:02.000Z INFO latest",
+			},
+			n: 2,
+			want: []string{
+				"[netbird debug bundle: 1 older log lines left out by --tail]",
+				strings.TrimSuffix(stagedLogsMarker, "\n"),
+				"2025-01-01T10:00:01.000Z INFO current",
+				"2025-01-01T10:00:02.000Z INFO latest",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tailLog([]byte(strings.Join(tt.lines, "\n")+"\n"), tt.n)
+			assert.Equal(t, strings.Join(tt.want, "\n")+"\n", string(got))
+		})
+	}
+}
+
+func TestFilterLogTailAfterPatterns(t *testing.T) {
+	content := strings.Join([]string{
+		"2025-01-01T10:00:00.000Z INFO dns: first",
+		"2025-01-01T10:00:01.000Z INFO peer: second",
+		"2025-01-01T10:00:02.000Z INFO dns: third",
+		"2025-01-01T10:00:03.000Z INFO peer: fourth",
+		"",
+	}, "\n")
+
+	filter, err := ParseLogFilter([]string{"dns:"}, nil)
+	require.NoError(t, err)
+	g := &BundleGenerator{logFilter: filter, logTail: 1}
+	require.True(t, g.filtersLogs())
+
+	data, err := g.filterLog("client.log", strings.NewReader(content))
+	require.NoError(t, err)
+	assert.Equal(t, "[netbird debug bundle: 1 older log lines left out by --tail]\n2025-01-01T10:00:02.000Z INFO dns: third\n", string(data))
+}
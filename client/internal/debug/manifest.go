@@ -0,0 +1,165 @@
+package debug
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netbirdio/netbird/client/anonymize"
+	"github.com/netbirdio/netbird/util"
+)
+
+const (
+	manifestFileName = "manifest.json"
+	checksumsFile    = "SHA256SUMS"
+	manifestVersion  = 1
+)
+
+// ClockReference ties the local clock to a reference clock, e.g. the management
+// server that dispatched a remote bundle job to several peers at once.
+type ClockReference struct {
+	// ReferenceTime is the reference clock's time when the request was sent.
+	ReferenceTime time.Time
+	// ReceivedAt is the local time when the request was received.
+	ReceivedAt time.Time
+}
+
+// Offset returns how far the local clock is ahead of the reference clock.
+// The value includes the delivery latency of the request, which bounds its precision.
+func (c ClockReference) Offset() time.Duration {
+	return c.ReceivedAt.Sub(c.ReferenceTime)
+}
+
+type bundleManifest struct {
+	Version       int            `json:"version"`
+	GeneratedAt   time.Time      `json:"generated_at"`
+	DaemonVersion string         `json:"daemon_version,omitempty"`
+	CliVersion    string         `json:"cli_version,omitempty"`
+	OS            string         `json:"os"`
+	Arch          string         `json:"arch"`
+	Anonymized    bool           `json:"anonymized"`
+	SystemInfo    bool           `json:"system_info"`
+	Clock         *manifestClock `json:"clock,omitempty"`
+	// SecretsMasked reports whether secrets were masked, false only with BundleConfig.AllowSecrets.
+	SecretsMasked bool `json:"secrets_masked"`
+	// AnonymizeLevel is the anonymize.Level of the bundle.
+	AnonymizeLevel anonymize.Level `json:"anonymize_level"`
+	// Policy is the management-enforced bundle policy that was applied, if any.
+	Policy *BundlePolicy `json:"policy,omitempty"`
+	// LogWindow is the time range of the included log lines when --since was set.
+	LogWindow *manifestLogWindow `json:"log_window,omitempty"`
+	// Incremental names the bundle whose log content was left out with --incremental.
+	Incremental *manifestIncremental `json:"incremental,omitempty"`
+	// LogsOmitted is set when the logs were left out on purpose with --no-logs.
+	LogsOmitted bool `json:"logs_omitted,omitempty"`
+	// LogFormat is the format the daemon wrote its log in when the bundle was created: text, json
+	// or syslog.
+	LogFormat string `json:"log_format"`
+	// SystemInfoCollectors lists the system information collectors that ran.
+	SystemInfoCollectors []string `json:"system_info_collectors,omitempty"`
+	// Files lists the files added to the bundle before the manifest.
+	Files []manifestFile `json:"files"`
+}
+
+type manifestFile struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+type manifestLogWindow struct {
+	Since time.Time `json:"since"`
+	Until time.Time `json:"until"`
+}
+
+type manifestIncremental struct {
+	BaselineBundle string    `json:"baseline_bundle"`
+	BaselineTime   time.Time `json:"baseline_time"`
+}
+
+type manifestClock struct {
+	ReferenceTime time.Time `json:"reference_time"`
+	ReceivedAt    time.Time `json:"received_at"`
+	// OffsetMs is the local clock minus the reference clock, in milliseconds.
+	// Subtract it from local log timestamps to align them with other bundles of the set.
+	OffsetMs int64 `json:"offset_ms"`
+}
+
+func (g *BundleGenerator) buildManifest() bundleManifest {
+	manifest := bundleManifest{
+		Version:       manifestVersion,
+		GeneratedAt:   time.Now().UTC(),
+		DaemonVersion: g.daemonVersion,
+		CliVersion:    g.cliVersion,
+		OS:            runtime.GOOS,
+		Arch:          runtime.GOARCH,
+		Anonymized:    g.anonymize,
+		SecretsMasked: !g.allowSecrets,
+		SystemInfo:    len(g.includeSystemInfo) > 0,
+		Policy:        g.policy,
+		Files:         append([]manifestFile{}, g.files...),
+	}
+	manifest.LogFormat = util.LogFormat(log.StandardLogger())
+	manifest.SystemInfoCollectors = g.includeSystemInfo.Names()
+
+	manifest.AnonymizeLevel = anonymize.LevelFromBool(g.anonymize)
+	if g.anonymize && g.anonymizeIPsOnly {
+		manifest.AnonymizeLevel = anonymize.LevelIPsOnly
+	}
+
+	manifest.LogsOmitted = g.noLogs
+	if !g.logCutoff.IsZero() && !g.noLogs {
+		manifest.LogWindow = &manifestLogWindow{
+			Since: g.logCutoff.UTC(),
+			Until: manifest.GeneratedAt,
+		}
+	}
+
+	if g.logBaseline != nil {
+		manifest.Incremental = &manifestIncremental{
+			BaselineBundle: g.logBaseline.Bundle,
+			BaselineTime:   g.logBaseline.CreatedAt.UTC(),
+		}
+	}
+
+	if g.clockReference != nil {
+		manifest.Clock = &manifestClock{
+			ReferenceTime: g.clockReference.ReferenceTime.UTC(),
+			ReceivedAt:    g.clockReference.ReceivedAt.UTC(),
+			OffsetMs:      g.clockReference.Offset().Milliseconds(),
+		}
+	}
+
+	return manifest
+}
+
+func (g *BundleGenerator) addManifest() error {
+	data, err := json.MarshalIndent(g.buildManifest(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal manifest: %w", err)
+	}
+
+	if err := g.addFileToZip(bytes.NewReader(data), manifestFileName); err != nil {
+		return fmt.Errorf("add manifest file to zip: %w", err)
+	}
+	return nil
+}
+
+// addChecksums writes SHA256SUMS in the format of sha256sum, covering all files added so far
+// including the manifest.
+func (g *BundleGenerator) addChecksums() error {
+	var sb strings.Builder
+	for _, f := range g.files {
+		sb.WriteString(f.SHA256 + "  " + f.Name + "\n")
+	}
+
+	if err := g.addFileToZip(strings.NewReader(sb.String()), checksumsFile); err != nil {
+		return fmt.Errorf("add checksums file to zip: %w", err)
+	}
+	return nil
+}
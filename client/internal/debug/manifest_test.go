@@ -0,0 +1,116 @@
+package debug
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mgmProto "github.com/netbirdio/netbird/shared/management/proto"
+)
+
+func TestBuildManifestClockOffset(t *testing.T) {
+	ref := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	g := NewBundleGenerator(GeneratorDependencies{
+		DaemonVersion: "0.1.0",
+		ClockReference: &ClockReference{
+			ReferenceTime: ref,
+			ReceivedAt:    ref.Add(1500 * time.Millisecond),
+		},
+	}, BundleConfig{})
+
+	manifest := g.buildManifest()
+	assert.Equal(t, manifestVersion, manifest.Version)
+	assert.Equal(t, "0.1.0", manifest.DaemonVersion)
+	require.NotNil(t, manifest.Clock)
+	assert.Equal(t, int64(1500), manifest.Clock.OffsetMs)
+	assert.Equal(t, ref, manifest.Clock.ReferenceTime)
+
+	g = NewBundleGenerator(GeneratorDependencies{}, BundleConfig{})
+	assert.Nil(t, g.buildManifest().Clock, "local bundles carry no clock reference")
+}
+
+func TestBuildManifestLogsOmitted(t *testing.T) {
+	g := NewBundleGenerator(GeneratorDependencies{}, BundleConfig{NoLogs: true})
+	g.logCutoff = time.Now().Add(-time.Hour)
+
+	manifest := g.buildManifest()
+	assert.True(t, manifest.LogsOmitted)
+	assert.Nil(t, manifest.LogWindow, "a bundle without logs has no log window")
+
+	assert.False(t, NewBundleGenerator(GeneratorDependencies{}, BundleConfig{}).buildManifest().LogsOmitted)
+}
+
+func TestApplyPolicy(t *testing.T) {
+	policy := &BundlePolicy{ForceAnonymize: true, ExcludeCaptures: true, ExcludeNetworkMap: true, ExcludeSystemInfo: true}
+	g := NewBundleGenerator(GeneratorDependencies{
+		CapturePath:  "/tmp/capture.pcap",
+		SyncResponse: &mgmProto.SyncResponse{},
+		Policy:       policy,
+	}, BundleConfig{IncludeSystemInfo: AllSystemInfo()})
+
+	g.applyPolicy()
+	assert.True(t, g.anonymize)
+	assert.Empty(t, g.capturePath)
+	assert.Nil(t, g.syncResponse)
+	assert.Empty(t, g.includeSystemInfo)
+	assert.Equal(t, policy, g.buildManifest().Policy)
+
+	g = NewBundleGenerator(GeneratorDependencies{CapturePath: "/tmp/capture.pcap"}, BundleConfig{})
+	g.applyPolicy()
+	assert.False(t, g.anonymize)
+	assert.Equal(t, "/tmp/capture.pcap", g.capturePath)
+	assert.Nil(t, g.buildManifest().Policy)
+}
+
+func TestManifestFileChecksums(t *testing.T) {
+	var buf bytes.Buffer
+	g := NewBundleGenerator(GeneratorDependencies{}, BundleConfig{Anonymize: true, IncludeSystemInfo: AllSystemInfo()})
+	g.archive = zip.NewWriter(&buf)
+
+	require.NoError(t, g.addFileToZip(strings.NewReader("status"), "status.txt"))
+	require.NoError(t, g.addFileToZip(strings.NewReader("log line\n"), "client.log"))
+	require.NoError(t, g.addManifest())
+	require.NoError(t, g.addChecksums())
+	require.NoError(t, g.archive.Close())
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	contents := make(map[string][]byte)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+		contents[f.Name] = data
+	}
+
+	var manifest bundleManifest
+	require.NoError(t, json.Unmarshal(contents[manifestFileName], &manifest))
+	assert.True(t, manifest.Anonymized)
+	assert.True(t, manifest.SystemInfo)
+	require.Len(t, manifest.Files, 2)
+	for _, f := range manifest.Files {
+		sum := sha256.Sum256(contents[f.Name])
+		assert.Equal(t, hex.EncodeToString(sum[:]), f.SHA256, f.Name)
+		assert.Equal(t, int64(len(contents[f.Name])), f.Size, f.Name)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(contents[checksumsFile])), "\n")
+	require.Len(t, lines, 3, "SHA256SUMS covers the manifest too")
+	for _, line := range lines {
+		sum, name, ok := strings.Cut(line, "  ")
+		require.True(t, ok, line)
+		expected := sha256.Sum256(contents[name])
+		assert.Equal(t, hex.EncodeToString(expected[:]), sum, name)
+	}
+}
@@ -0,0 +1,151 @@
+package debug
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const mtuFile = "mtu.txt"
+
+// WireGuard encapsulation overhead: outer IP header, UDP header and the WireGuard header.
+const (
+	wgOverheadIPv4 = 20 + 8 + 32
+	wgOverheadIPv6 = 40 + 8 + 32
+)
+
+// InterfaceMTU is the MTU of a host interface.
+type InterfaceMTU struct {
+	Name string
+	MTU  int
+}
+
+// MTUReport holds the tunnel MTU, the MTU of the host interfaces and the probed path MTU of peers.
+type MTUReport struct {
+	Tunnel string
+	// ConfiguredMTU is the MTU in the profile config, management can override it.
+	ConfiguredMTU int
+	// InterfaceMTU is the MTU of the tunnel interface, zero if the interface was not found.
+	InterfaceMTU int
+	Host         []InterfaceMTU
+	// PeerMTU is set when a peer MTU probe ran before the bundle.
+	PeerMTU *PeerMTUReport
+}
+
+// effectiveMTU is the MTU of the tunnel interface, the configured MTU when it was not found.
+func (r MTUReport) effectiveMTU() int {
+	if r.InterfaceMTU > 0 {
+		return r.InterfaceMTU
+	}
+	return r.ConfiguredMTU
+}
+
+// CheckMTU reads the MTU of the tunnel interface and of the host interfaces that are up.
+func CheckMTU(tunnel string, configured int) (MTUReport, error) {
+	report := MTUReport{Tunnel: tunnel, ConfiguredMTU: configured}
+
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return report, fmt.Errorf("get interfaces: %w", err)
+	}
+	for _, iface := range ifaces {
+		if iface.Name == tunnel {
+			report.InterfaceMTU = iface.MTU
+			continue
+		}
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		report.Host = append(report.Host, InterfaceMTU{Name: iface.Name, MTU: iface.MTU})
+	}
+	sort.Slice(report.Host, func(i, j int) bool {
+		return report.Host[i].Name < report.Host[j].Name
+	})
+	return report, nil
+}
+
+// FormatMTUReport renders an MTU report as text. Host interfaces too small to carry full-size
+// tunnel packets without fragmentation are flagged.
+func FormatMTUReport(report MTUReport) string {
+	var builder strings.Builder
+
+	tunnelMTU := report.effectiveMTU()
+	builder.WriteString(fmt.Sprintf("Configured tunnel MTU: %d\n", report.ConfiguredMTU))
+	if report.InterfaceMTU > 0 {
+		builder.WriteString(fmt.Sprintf("Interface %s MTU: %d\n", report.Tunnel, report.InterfaceMTU))
+	} else {
+		builder.WriteString(fmt.Sprintf("Interface %s: not found (not up, or the userspace netstack is used)\n", report.Tunnel))
+	}
+	builder.WriteString(fmt.Sprintf("WireGuard overhead: %d bytes over IPv4, %d bytes over IPv6\n", wgOverheadIPv4, wgOverheadIPv6))
+
+	builder.WriteString("\nHost interfaces:\n")
+	if len(report.Host) == 0 {
+		builder.WriteString("  none\n")
+	}
+	var flagged int
+	for _, h := range report.Host {
+		var note string
+		switch {
+		case tunnelMTU == 0:
+		case h.MTU-wgOverheadIPv4 < tunnelMTU:
+			note = fmt.Sprintf("  <- too small: tunnel packets fragment, use a tunnel MTU of at most %d", h.MTU-wgOverheadIPv6)
+			flagged++
+		case h.MTU-wgOverheadIPv6 < tunnelMTU:
+			note = fmt.Sprintf("  <- too small over an IPv6 underlay, use a tunnel MTU of at most %d", h.MTU-wgOverheadIPv6)
+			flagged++
+		}
+		builder.WriteString(fmt.Sprintf("  %-20s %6d%s\n", h.Name, h.MTU, note))
+	}
+	if flagged > 0 {
+		builder.WriteString(fmt.Sprintf("\n%d interface(s) cannot carry full-size tunnel packets if they carry the tunnel, "+
+			"e.g. a PPPoE link (1492) with the default tunnel MTU. Lower the MTU with \"netbird up --mtu\".\n", flagged))
+	}
+
+	builder.WriteString("\nPath MTU of peers:\n")
+	if report.PeerMTU == nil {
+		builder.WriteString("  not measured, create the bundle with --peer-mtu-probe to probe the connected peers\n")
+		return builder.String()
+	}
+	peers := append([]PeerMTU(nil), report.PeerMTU.Peers...)
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].Peer < peers[j].Peer
+	})
+	for _, p := range peers {
+		switch {
+		case p.Error != "":
+			builder.WriteString(fmt.Sprintf("  %-40s error: %s\n", p.Peer, p.Error))
+		case p.BelowThreshold(report.PeerMTU.TunnelMTU):
+			builder.WriteString(fmt.Sprintf("  %-40s %6d  <- below the tunnel MTU %d\n", p.Peer, p.PathMTU, report.PeerMTU.TunnelMTU))
+		default:
+			builder.WriteString(fmt.Sprintf("  %-40s %6d\n", p.Peer, p.PathMTU))
+		}
+	}
+	return builder.String()
+}
+
+func (g *BundleGenerator) addMTU() error {
+	var tunnel string
+	var configured int
+	if g.internalConfig != nil {
+		tunnel = g.internalConfig.WgIface
+		configured = int(g.internalConfig.MTU)
+	}
+
+	report, err := CheckMTU(tunnel, configured)
+	if err != nil {
+		log.Warnf("failed to read interface MTUs for debug bundle: %v", err)
+	}
+
+	if g.peerMTU != nil {
+		peerMTU := g.peerMTUReport()
+		report.PeerMTU = &peerMTU
+	}
+
+	if err := g.addFileToZip(strings.NewReader(FormatMTUReport(report)), mtuFile); err != nil {
+		return fmt.Errorf("add MTU file to zip: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package debug
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatMTUReport(t *testing.T) {
+	report := MTUReport{
+		Tunnel:        "wt0",
+		ConfiguredMTU: 1280,
+		InterfaceMTU:  1420,
+		Host: []InterfaceMTU{
+			{Name: "eth0", MTU: 1500},
+			{Name: "ppp0", MTU: 1492},
+			{Name: "wg1", MTU: 1400},
+		},
+	}
+
+	out := FormatMTUReport(report)
+	assert.Contains(t, out, "Interface wt0 MTU: 1420")
+	assert.Contains(t, out, "  eth0                   1500\n")
+	assert.Contains(t, out, "ppp0                   1492  <- too small over an IPv6 underlay, use a tunnel MTU of at most 1412")
+	assert.Contains(t, out, "wg1                    1400  <- too small: tunnel packets fragment, use a tunnel MTU of at most 1320")
+	assert.Contains(t, out, "2 interface(s) cannot carry full-size tunnel packets")
+	assert.Contains(t, out, "not measured, create the bundle with --peer-mtu-probe")
+
+	report.InterfaceMTU = 0
+	report.PeerMTU = &PeerMTUReport{
+		TunnelMTU: 1280,
+		Peers: []PeerMTU{
+			{Peer: "b.netbird.cloud", PathMTU: 1280},
+			{Peer: "a.netbird.cloud", PathMTU: 1200},
+			{Peer: "c.netbird.cloud", Error: "timeout"},
+		},
+	}
+	out = FormatMTUReport(report)
+	assert.Contains(t, out, "Interface wt0: not found")
+	assert.NotContains(t, out, "cannot carry full-size tunnel packets")
+	assert.Contains(t, out, "a.netbird.cloud                            1200  <- below the tunnel MTU 1280")
+	assert.Contains(t, out, "c.netbird.cloud                          error: timeout")
+	assert.Less(t, strings.Index(out, "a.netbird.cloud"), strings.Index(out, "b.netbird.cloud"))
+}
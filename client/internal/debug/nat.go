@@ -0,0 +1,244 @@
+package debug
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netbirdio/netbird/client/iface/udpmux"
+	"github.com/netbirdio/netbird/client/internal/peer"
+)
+
+const natFile = "nat.txt"
+
+// NAT mapping behaviors derived from the STUN mappings.
+const (
+	NATMappingUnknown             = "unknown"
+	NATMappingNone                = "no NAT"
+	NATMappingEndpointIndependent = "endpoint-independent"
+	NATMappingEndpointDependent   = "endpoint-dependent"
+)
+
+// NATSource provides the STUN discovery results of the ICE socket.
+type NATSource interface {
+	STUNMappings() []udpmux.STUNMapping
+}
+
+// NATReport holds the STUN discovery results and the ICE candidate types of the connected peers.
+type NATReport struct {
+	Mappings []udpmux.STUNMapping
+	Mapping  string
+	// LocalAddrs are the host addresses compared against the mapped addresses.
+	LocalAddrs []netip.Addr
+	Peers      []NATPeer
+}
+
+// NATPeer is the candidate pair a connected peer uses.
+type NATPeer struct {
+	FQDN       string
+	Local      string
+	Remote     string
+	Relayed    bool
+	LocalAddr  string
+	RemoteAddr string
+}
+
+// ClassifyNATMapping derives the NAT mapping behavior from the mapped addresses reported by
+// different STUN servers. A mapped address on a local interface means there is no NAT.
+func ClassifyNATMapping(mappings []udpmux.STUNMapping, localAddrs []netip.Addr) string {
+	if len(mappings) == 0 {
+		return NATMappingUnknown
+	}
+
+	distinct := make(map[netip.AddrPort]struct{})
+	for _, m := range mappings {
+		mapped := m.Mapped.AddrPort()
+		distinct[netip.AddrPortFrom(mapped.Addr().Unmap(), mapped.Port())] = struct{}{}
+	}
+
+	for _, local := range localAddrs {
+		for mapped := range distinct {
+			if mapped.Addr() == local {
+				return NATMappingNone
+			}
+		}
+	}
+
+	switch {
+	case len(distinct) > 1:
+		return NATMappingEndpointDependent
+	case len(mappings) > 1:
+		return NATMappingEndpointIndependent
+	default:
+		return NATMappingUnknown
+	}
+}
+
+// CheckNAT builds a NAT report from the STUN mappings and the peers in the status.
+func CheckNAT(mappings []udpmux.STUNMapping, status peer.FullStatus) NATReport {
+	sort.Slice(mappings, func(i, j int) bool {
+		return mappings[i].Server < mappings[j].Server
+	})
+
+	localAddrs, err := localUnicastAddrs()
+	if err != nil {
+		log.Debugf("failed to get local addresses for NAT classification: %v", err)
+	}
+
+	report := NATReport{
+		Mappings:   mappings,
+		Mapping:    ClassifyNATMapping(mappings, localAddrs),
+		LocalAddrs: localAddrs,
+	}
+
+	for _, p := range status.Peers {
+		if p.ConnStatus != peer.StatusConnected {
+			continue
+		}
+		report.Peers = append(report.Peers, NATPeer{
+			FQDN:       p.FQDN,
+			Local:      p.LocalIceCandidateType,
+			Remote:     p.RemoteIceCandidateType,
+			Relayed:    p.Relayed,
+			LocalAddr:  p.LocalIceCandidateEndpoint,
+			RemoteAddr: p.RemoteIceCandidateEndpoint,
+		})
+	}
+	sort.Slice(report.Peers, func(i, j int) bool {
+		return report.Peers[i].FQDN < report.Peers[j].FQDN
+	})
+	return report
+}
+
+// FormatNATReport renders a NAT report as text.
+func FormatNATReport(report NATReport, now time.Time) string {
+	var builder strings.Builder
+
+	builder.WriteString("STUN mapped addresses of the ICE socket:\n")
+	if len(report.Mappings) == 0 {
+		builder.WriteString("  none (no STUN server answered yet, or ICE is not running)\n")
+	}
+	for _, m := range report.Mappings {
+		age := fmt.Sprintf("%s ago", now.Sub(m.ReceivedAt).Round(time.Second))
+		if m.Expired {
+			age += ", expired"
+		}
+		builder.WriteString(fmt.Sprintf("  %-24s -> %-24s (%s)\n", m.Server, m.Mapped.String(), age))
+	}
+
+	builder.WriteString(fmt.Sprintf("\nNAT mapping behavior: %s\n", report.Mapping))
+	switch report.Mapping {
+	case NATMappingNone:
+		builder.WriteString("  A mapped address is assigned to a local interface, the host has a public address.\n")
+	case NATMappingEndpointIndependent:
+		builder.WriteString("  All STUN servers saw the same address and port. Peers can connect directly through server reflexive candidates.\n")
+	case NATMappingEndpointDependent:
+		builder.WriteString("  STUN servers saw different addresses or ports (symmetric NAT). Direct connections only work when the other peer is reachable, otherwise they go through a relay.\n")
+	default:
+		builder.WriteString("  At least two STUN servers must answer to tell the mapping behavior.\n")
+	}
+	builder.WriteString("NAT filtering behavior: not tested, the daemon does not run RFC 5780 filtering tests.\n")
+
+	builder.WriteString("\nCandidate types of connected peers:\n")
+	if len(report.Peers) == 0 {
+		builder.WriteString("  none\n")
+	}
+	pairs := make(map[string]int)
+	for _, p := range report.Peers {
+		pair := fmt.Sprintf("%s/%s", orUnknown(p.Local), orUnknown(p.Remote))
+		if p.Relayed {
+			pair += " (relayed)"
+		}
+		pairs[pair]++
+		builder.WriteString(fmt.Sprintf("  %-40s %-24s local %s, remote %s\n", p.FQDN, pair, orUnknown(p.LocalAddr), orUnknown(p.RemoteAddr)))
+	}
+
+	if len(pairs) > 0 {
+		builder.WriteString("\nCandidate pairs (local/remote):\n")
+		names := make([]string, 0, len(pairs))
+		for pair := range pairs {
+			names = append(names, pair)
+		}
+		sort.Strings(names)
+		for _, pair := range names {
+			builder.WriteString(fmt.Sprintf("  %-24s %d\n", pair, pairs[pair]))
+		}
+	}
+	return builder.String()
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func localUnicastAddrs() ([]netip.Addr, error) {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil, err
+	}
+
+	var local []netip.Addr
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok {
+			continue
+		}
+		ip, ok := netip.AddrFromSlice(ipNet.IP)
+		if !ok || ip.IsLoopback() || ip.IsLinkLocalUnicast() {
+			continue
+		}
+		local = append(local, ip.Unmap())
+	}
+	return local, nil
+}
+
+func (g *BundleGenerator) addNAT() error {
+	if g.nat == nil {
+		log.Debug("skipping NAT in debug bundle: ICE is not running")
+		return nil
+	}
+
+	var status peer.FullStatus
+	if g.statusRecorder != nil {
+		status = g.statusRecorder.GetFullStatus()
+	}
+	report := CheckNAT(g.nat.STUNMappings(), status)
+
+	if g.anonymize {
+		report = g.anonymizeNATReport(report)
+	}
+
+	if err := g.addFileToZip(strings.NewReader(FormatNATReport(report, time.Now())), natFile); err != nil {
+		return fmt.Errorf("add NAT file to zip: %w", err)
+	}
+	return nil
+}
+
+func (g *BundleGenerator) anonymizeNATReport(report NATReport) NATReport {
+	mappings := make([]udpmux.STUNMapping, 0, len(report.Mappings))
+	for _, m := range report.Mappings {
+		m.Server = g.anonymizer.AnonymizeString(m.Server)
+		m.Mapped = g.anonymizer.AnonymizeUDPAddr(m.Mapped)
+		mappings = append(mappings, m)
+	}
+	report.Mappings = mappings
+	report.LocalAddrs = nil
+
+	peers := make([]NATPeer, 0, len(report.Peers))
+	for _, p := range report.Peers {
+		p.FQDN = g.anonymizer.AnonymizeDomain(p.FQDN)
+		p.LocalAddr = g.anonymizer.AnonymizeString(p.LocalAddr)
+		p.RemoteAddr = g.anonymizer.AnonymizeString(p.RemoteAddr)
+		peers = append(peers, p)
+	}
+	report.Peers = peers
+	return report
+}
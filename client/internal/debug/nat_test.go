@@ -0,0 +1,84 @@
+package debug
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netbirdio/netbird/client/iface/udpmux"
+)
+
+func TestClassifyNATMapping(t *testing.T) {
+	mapping := func(server, mapped string) udpmux.STUNMapping {
+		return udpmux.STUNMapping{Server: server, Mapped: *net.UDPAddrFromAddrPort(netip.MustParseAddrPort(mapped))}
+	}
+
+	tests := []struct {
+		name     string
+		mappings []udpmux.STUNMapping
+		local    []netip.Addr
+		want     string
+	}{
+		{name: "no answers", want: NATMappingUnknown},
+		{
+			name:     "single server",
+			mappings: []udpmux.STUNMapping{mapping("192.0.2.1:3478", "203.0.113.5:40000")},
+			want:     NATMappingUnknown,
+		},
+		{
+			name: "same mapping",
+			mappings: []udpmux.STUNMapping{
+				mapping("192.0.2.1:3478", "203.0.113.5:40000"),
+				mapping("192.0.2.2:3478", "203.0.113.5:40000"),
+			},
+			want: NATMappingEndpointIndependent,
+		},
+		{
+			name: "different ports",
+			mappings: []udpmux.STUNMapping{
+				mapping("192.0.2.1:3478", "203.0.113.5:40000"),
+				mapping("192.0.2.2:3478", "203.0.113.5:40017"),
+			},
+			want: NATMappingEndpointDependent,
+		},
+		{
+			name:     "public address",
+			mappings: []udpmux.STUNMapping{mapping("192.0.2.1:3478", "203.0.113.5:51820")},
+			local:    []netip.Addr{netip.MustParseAddr("203.0.113.5")},
+			want:     NATMappingNone,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ClassifyNATMapping(tt.mappings, tt.local))
+		})
+	}
+}
+
+func TestFormatNATReport(t *testing.T) {
+	now := time.Now()
+	report := NATReport{
+		Mappings: []udpmux.STUNMapping{{
+			Server:     "192.0.2.1:3478",
+			Mapped:     net.UDPAddr{IP: net.ParseIP("203.0.113.5"), Port: 40000},
+			ReceivedAt: now.Add(-10 * time.Second),
+		}},
+		Mapping: NATMappingEndpointDependent,
+		Peers: []NATPeer{
+			{FQDN: "a.netbird.cloud", Local: "srflx", Remote: "host"},
+			{FQDN: "b.netbird.cloud", Local: "relay", Remote: "relay", Relayed: true},
+		},
+	}
+
+	out := FormatNATReport(report, now)
+	assert.Contains(t, out, "192.0.2.1:3478")
+	assert.Contains(t, out, "203.0.113.5:40000")
+	assert.Contains(t, out, "10s ago")
+	assert.Contains(t, out, "NAT mapping behavior: endpoint-dependent")
+	assert.Contains(t, out, "symmetric NAT")
+	assert.Contains(t, out, "srflx/host")
+	assert.Contains(t, out, "relay/relay (relayed)")
+}
@@ -0,0 +1,35 @@
+package debug
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const networkMapHistoryFile = "network_map_history.txt"
+
+// addNetworkMapHistory adds the sync responses older than network_map.json and an index with
+// the time each network map was received, so churn across updates can be followed.
+func (g *BundleGenerator) addNetworkMapHistory() error {
+	if g.syncResponse == nil || len(g.networkMapHistory) < 2 {
+		return nil
+	}
+
+	var index strings.Builder
+	index.WriteString("File\tReceived\tSerial\n")
+	for i, snapshot := range g.networkMapHistory {
+		filename := "network_map.json"
+		if i > 0 {
+			filename = fmt.Sprintf("network_map-%d.json", i)
+			if err := g.addSyncResponseFile(snapshot.Response, filename); err != nil {
+				return fmt.Errorf("add %s: %w", filename, err)
+			}
+		}
+		fmt.Fprintf(&index, "%s\t%s\t%d\n", filename, snapshot.ReceivedAt.UTC().Format(time.RFC3339Nano), snapshot.Response.GetNetworkMap().GetSerial())
+	}
+
+	if err := g.addFileToZip(strings.NewReader(index.String()), networkMapHistoryFile); err != nil {
+		return fmt.Errorf("add network map history index to zip: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package debug
+
+import (
+	"fmt"
+	"strings"
+)
+
+const offlineFile = "offline.txt"
+
+// offlineNote lists what a bundle created without the daemon is missing. The daemon holds these in
+// memory, so they are gone when it is not running.
+const offlineNote = `This bundle was created with "netbird debug bundle --offline" by the CLI, without the daemon.
+
+Not included, only the running daemon has them:
+- status.txt and status.json: the live status of the peers, management, signal and relays
+- network_map.json: the last network map received from management
+- connection history, WireGuard peer statistics, NAT, DNS and firewall state
+- daemon profiles, packet captures and peer MTU probes
+
+Included as far as the CLI could read them: the daemon logs from the log file path, the config of
+the active profile, the state file, the routing table and the system information. Files the CLI
+user was not allowed to read are missing, run the command with elevated privileges to include them.
+`
+
+// addOfflineNote writes offline.txt to bundles created without the daemon, so the missing live
+// state is not mistaken for a daemon that had none.
+func (g *BundleGenerator) addOfflineNote() error {
+	if !g.offline {
+		return nil
+	}
+
+	content := offlineNote
+	if g.logPath != "" {
+		logPath := g.logPath
+		if g.anonymize {
+			logPath = g.anonymizer.AnonymizeString(logPath)
+		}
+		content += fmt.Sprintf("\nLog file: %s\n", logPath)
+	}
+	if err := g.addFileToZip(strings.NewReader(content), offlineFile); err != nil {
+		return fmt.Errorf("add offline note to zip: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package debug
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ValidateOutputDir checks that the daemon can write a bundle to dir before it is generated.
+// The directory must be absolute and exist, it is not created.
+func ValidateOutputDir(dir string) error {
+	if !filepath.IsAbs(dir) {
+		return fmt.Errorf("output directory %s is not absolute", dir)
+	}
+
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("output directory %s is not accessible by the daemon: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("output directory %s is not a directory", dir)
+	}
+
+	probe, err := os.CreateTemp(dir, ".netbird-write-check-*")
+	if err != nil {
+		return fmt.Errorf("output directory %s is not writable by the daemon: %w", dir, err)
+	}
+	name := probe.Name()
+	if err := probe.Close(); err != nil {
+		return fmt.Errorf("close write check file: %w", err)
+	}
+	if err := os.Remove(name); err != nil {
+		return fmt.Errorf("remove write check file: %w", err)
+	}
+	return nil
+}
+
+// ResolveOutputDir resolves the symlinks of dir and validates the result, so the bundle is
+// written to the directory that was checked and not to wherever a link points later.
+func ResolveOutputDir(dir string) (string, error) {
+	if !filepath.IsAbs(dir) {
+		return "", fmt.Errorf("output directory %s is not absolute", dir)
+	}
+	resolved, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", fmt.Errorf("output directory %s is not accessible by the daemon: %w", dir, err)
+	}
+	if err := ValidateOutputDir(resolved); err != nil {
+		return "", err
+	}
+	return resolved, nil
+}
@@ -0,0 +1,45 @@
+package debug
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateOutputDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, ValidateOutputDir(dir))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "the write check leaves no file behind")
+
+	file := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(file, nil, 0o600))
+	assert.ErrorContains(t, ValidateOutputDir(file), "is not a directory")
+	assert.ErrorContains(t, ValidateOutputDir(filepath.Join(dir, "missing")), "is not accessible by the daemon")
+	assert.ErrorContains(t, ValidateOutputDir("bundles"), "is not absolute")
+}
+
+func TestResolveOutputDir(t *testing.T) {
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+
+	resolved, err := ResolveOutputDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, dir, resolved)
+
+	link := filepath.Join(t.TempDir(), "link")
+	if err := os.Symlink(dir, link); err != nil {
+		t.Skipf("create symlink: %v", err)
+	}
+	resolved, err = ResolveOutputDir(link)
+	require.NoError(t, err)
+	assert.Equal(t, dir, resolved, "the bundle is written to the link target that was checked")
+
+	_, err = ResolveOutputDir("bundles")
+	assert.ErrorContains(t, err, "is not absolute")
+}
@@ -0,0 +1,114 @@
+package debug
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netbirdio/netbird/client/internal/mtuprobe"
+)
+
+// PeerMTU is the probed path MTU of a single peer.
+type PeerMTU struct {
+	Peer string `json:"peer"`
+	IP   string `json:"ip"`
+	// PathMTU is the largest packet size that reached the peer, zero if it was unreachable.
+	PathMTU int    `json:"path_mtu"`
+	Probes  int    `json:"probes"`
+	Error   string `json:"error,omitempty"`
+}
+
+// PeerMTUReport is the outcome of probing the path MTU of several peers.
+type PeerMTUReport struct {
+	// TunnelMTU is the local tunnel MTU, the largest size probed.
+	TunnelMTU int       `json:"tunnel_mtu"`
+	Peers     []PeerMTU `json:"peers"`
+}
+
+// PeerMTUs flattens probe results for display and transport.
+func PeerMTUs(results []mtuprobe.Result) []PeerMTU {
+	peers := make([]PeerMTU, 0, len(results))
+	for _, r := range results {
+		p := PeerMTU{
+			Peer:    r.Name,
+			IP:      r.Addr.String(),
+			PathMTU: r.PathMTU,
+			Probes:  r.Probes,
+		}
+		if r.Err != nil {
+			p.Error = r.Err.Error()
+		}
+		peers = append(peers, p)
+	}
+	return peers
+}
+
+// BelowThreshold reports whether the peer was reached but only with packets smaller than threshold.
+func (p PeerMTU) BelowThreshold(threshold int) bool {
+	return p.Error == "" && p.PathMTU < threshold
+}
+
+// FormatPeerMTU renders a peer MTU report as an aligned table, flagging peers below threshold.
+// A non-positive threshold defaults to the tunnel MTU.
+func FormatPeerMTU(report PeerMTUReport, threshold int) string {
+	if threshold <= 0 {
+		threshold = report.TunnelMTU
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("Tunnel MTU: %d\n", report.TunnelMTU))
+	builder.WriteString(fmt.Sprintf("Threshold: %d\n\n", threshold))
+
+	if len(report.Peers) == 0 {
+		builder.WriteString("No peers probed.\n")
+		return builder.String()
+	}
+
+	builder.WriteString(fmt.Sprintf("%-40s %-16s %8s %6s  %s\n", "Peer", "IP", "Path MTU", "Probes", "Status"))
+	var below, unreachable int
+	for _, p := range report.Peers {
+		status := "ok"
+		switch {
+		case p.Error != "":
+			status = "unreachable: " + p.Error
+			unreachable++
+		case p.BelowThreshold(threshold):
+			status = "LOW"
+			below++
+		}
+		builder.WriteString(fmt.Sprintf("%-40s %-16s %8d %6d  %s\n", p.Peer, p.IP, p.PathMTU, p.Probes, status))
+	}
+
+	builder.WriteString(fmt.Sprintf("\n%d of %d peers below threshold, %d unreachable\n", below, len(report.Peers), unreachable))
+	return builder.String()
+}
+
+func (g *BundleGenerator) addPeerMTU() error {
+	if g.peerMTU == nil {
+		log.Debug("skipping peer MTU in debug bundle: no probe requested")
+		return nil
+	}
+
+	content := FormatPeerMTU(g.peerMTUReport(), 0)
+	if err := g.addFileToZip(strings.NewReader(content), "peer_mtu.txt"); err != nil {
+		return fmt.Errorf("add peer MTU file to zip: %w", err)
+	}
+	return nil
+}
+
+// peerMTUReport returns a copy of the peer MTU report, anonymized if requested.
+func (g *BundleGenerator) peerMTUReport() PeerMTUReport {
+	report := *g.peerMTU
+	if !g.anonymize {
+		return report
+	}
+	report.Peers = make([]PeerMTU, 0, len(g.peerMTU.Peers))
+	for _, p := range g.peerMTU.Peers {
+		p.Peer = g.anonymizer.AnonymizeDomain(p.Peer)
+		p.IP = g.anonymizer.AnonymizeIPString(p.IP)
+		p.Error = g.anonymizer.AnonymizeString(p.Error)
+		report.Peers = append(report.Peers, p)
+	}
+	return report
+}
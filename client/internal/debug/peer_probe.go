@@ -0,0 +1,126 @@
+package debug
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const peerProbesFile = "peer_probes.txt"
+
+// PeerProbe is the outcome of an on-demand connectivity check to a peer.
+type PeerProbe struct {
+	Peer string    `json:"peer"`
+	IP   string    `json:"ip"`
+	Time time.Time `json:"time"`
+	// Reachable is true if the connection is up and, unless pinging is not supported, the peer
+	// answered through the tunnel.
+	Reachable bool   `json:"reachable"`
+	Error     string `json:"error,omitempty"`
+	// ConnectTime is how long opening the connection took, zero if it was already connected.
+	ConnectTime     time.Duration `json:"connect_time,omitempty"`
+	Relayed         bool          `json:"relayed"`
+	RelayAddress    string        `json:"relay_address,omitempty"`
+	LocalCandidate  string        `json:"local_candidate,omitempty"`
+	RemoteCandidate string        `json:"remote_candidate,omitempty"`
+	LocalEndpoint   string        `json:"local_endpoint,omitempty"`
+	RemoteEndpoint  string        `json:"remote_endpoint,omitempty"`
+	// RTTs holds the round trip times of the answered echo requests.
+	RTTs []time.Duration `json:"rtts,omitempty"`
+	Lost int             `json:"lost"`
+	// Note explains a probe without RTTs, e.g. in netstack mode.
+	Note string `json:"note,omitempty"`
+}
+
+// Path returns how the connection to the peer is established.
+func (p PeerProbe) Path() string {
+	switch {
+	case !p.Reachable && p.LocalCandidate == "" && !p.Relayed:
+		return "none"
+	case p.Relayed:
+		return "relay"
+	default:
+		return "direct"
+	}
+}
+
+// FormatPeerProbe renders a peer probe for the terminal and the bundle.
+func FormatPeerProbe(p PeerProbe) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Peer: %s (%s)\n", p.Peer, p.IP))
+	sb.WriteString(fmt.Sprintf("Time: %s\n", p.Time.Format(time.RFC3339)))
+
+	result := "reachable"
+	if !p.Reachable {
+		result = "unreachable"
+	}
+	if p.Error != "" {
+		result += ": " + p.Error
+	}
+	sb.WriteString(fmt.Sprintf("Result: %s\n", result))
+
+	if p.ConnectTime > 0 {
+		sb.WriteString(fmt.Sprintf("Connection opened in: %s\n", p.ConnectTime.Round(time.Millisecond)))
+	}
+	sb.WriteString(fmt.Sprintf("Path: %s\n", p.Path()))
+	if p.RelayAddress != "" {
+		sb.WriteString(fmt.Sprintf("Relay: %s\n", p.RelayAddress))
+	}
+	if p.LocalCandidate != "" || p.RemoteCandidate != "" {
+		sb.WriteString(fmt.Sprintf("Candidate pair: %s %s <-> %s %s\n", p.LocalCandidate, p.LocalEndpoint, p.RemoteCandidate, p.RemoteEndpoint))
+	}
+
+	if sent := len(p.RTTs) + p.Lost; sent > 0 {
+		sb.WriteString(fmt.Sprintf("Echo replies: %d/%d\n", len(p.RTTs), sent))
+	}
+	if len(p.RTTs) > 0 {
+		lowest, highest, total := p.RTTs[0], p.RTTs[0], time.Duration(0)
+		for _, rtt := range p.RTTs {
+			lowest = min(lowest, rtt)
+			highest = max(highest, rtt)
+			total += rtt
+		}
+		avg := total / time.Duration(len(p.RTTs))
+		sb.WriteString(fmt.Sprintf("RTT min/avg/max: %s/%s/%s\n", lowest.Round(time.Microsecond), avg.Round(time.Microsecond), highest.Round(time.Microsecond)))
+	}
+	if p.Note != "" {
+		sb.WriteString(fmt.Sprintf("Note: %s\n", p.Note))
+	}
+	return sb.String()
+}
+
+// addPeerProbes writes the peer probes run shortly before the bundle, e.g. with "netbird debug ping"
+// during "netbird debug for".
+func (g *BundleGenerator) addPeerProbes() error {
+	if len(g.peerProbes) == 0 {
+		log.Debug("skipping peer probes in debug bundle: none ran recently")
+		return nil
+	}
+
+	var sb strings.Builder
+	for i, p := range g.peerProbes {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		if g.anonymize {
+			p = g.anonymizePeerProbe(p)
+		}
+		sb.WriteString(FormatPeerProbe(p))
+	}
+	if err := g.addFileToZip(strings.NewReader(sb.String()), peerProbesFile); err != nil {
+		return fmt.Errorf("add peer probes file to zip: %w", err)
+	}
+	return nil
+}
+
+func (g *BundleGenerator) anonymizePeerProbe(p PeerProbe) PeerProbe {
+	p.Peer = g.anonymizer.AnonymizeDomain(p.Peer)
+	p.IP = g.anonymizer.AnonymizeIPString(p.IP)
+	p.Error = g.anonymizer.AnonymizeString(p.Error)
+	p.RelayAddress = g.anonymizer.AnonymizeURI(p.RelayAddress)
+	p.LocalEndpoint = g.anonymizer.AnonymizeString(p.LocalEndpoint)
+	p.RemoteEndpoint = g.anonymizer.AnonymizeString(p.RemoteEndpoint)
+	return p
+}
@@ -0,0 +1,37 @@
+package debug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatPeerProbe(t *testing.T) {
+	out := FormatPeerProbe(PeerProbe{
+		Peer:            "peer-a.netbird.cloud",
+		IP:              "100.64.0.10",
+		Reachable:       true,
+		ConnectTime:     1500 * time.Millisecond,
+		LocalCandidate:  "host",
+		RemoteCandidate: "srflx",
+		LocalEndpoint:   "192.168.1.2:51820",
+		RemoteEndpoint:  "203.0.113.5:40000",
+		RTTs:            []time.Duration{10 * time.Millisecond, 30 * time.Millisecond},
+		Lost:            1,
+	})
+	assert.Contains(t, out, "Result: reachable\n")
+	assert.Contains(t, out, "Connection opened in: 1.5s\n")
+	assert.Contains(t, out, "Path: direct\n")
+	assert.Contains(t, out, "Candidate pair: host 192.168.1.2:51820 <-> srflx 203.0.113.5:40000\n")
+	assert.Contains(t, out, "Echo replies: 2/3\n")
+	assert.Contains(t, out, "RTT min/avg/max: 10ms/20ms/30ms\n")
+
+	out = FormatPeerProbe(PeerProbe{Peer: "peer-b", IP: "100.64.0.11", Error: "connection not established within 15s, the peer is connecting"})
+	assert.Contains(t, out, "Result: unreachable: connection not established within 15s")
+	assert.Contains(t, out, "Path: none\n")
+	assert.NotContains(t, out, "RTT")
+
+	out = FormatPeerProbe(PeerProbe{Peer: "peer-c", Reachable: true, Relayed: true, RelayAddress: "rels://relay.netbird.io:443"})
+	assert.Contains(t, out, "Path: relay\nRelay: rels://relay.netbird.io:443\n")
+}
@@ -0,0 +1,130 @@
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netbirdio/netbird/client/configs"
+	mgmProto "github.com/netbirdio/netbird/shared/management/proto"
+	"github.com/netbirdio/netbird/util"
+)
+
+// bundlePolicyFile is the file in the state directory that holds the last policy the daemon
+// received, so bundles generated without the daemon follow it too.
+const bundlePolicyFile = "debug-bundle-policy.json"
+
+// BundlePolicy restricts the content of debug bundles. It is pushed by the management server
+// and enforced by Generate regardless of the BundleConfig.
+type BundlePolicy struct {
+	ForceAnonymize    bool `json:"force_anonymize"`
+	ExcludeCaptures   bool `json:"exclude_captures"`
+	ExcludeNetworkMap bool `json:"exclude_network_map"`
+	ExcludeSystemInfo bool `json:"exclude_system_info"`
+}
+
+// BundlePolicyFromProto converts a management policy, returning nil if there is none.
+func BundlePolicyFromProto(p *mgmProto.DebugBundlePolicy) *BundlePolicy {
+	if p == nil {
+		return nil
+	}
+	return &BundlePolicy{
+		ForceAnonymize:    p.GetForceAnonymize(),
+		ExcludeCaptures:   p.GetExcludeCaptures(),
+		ExcludeNetworkMap: p.GetExcludeNetworkMap(),
+		ExcludeSystemInfo: p.GetExcludeSystemInfo(),
+	}
+}
+
+// Equal reports whether two policies restrict bundles the same way. Nil policies are equal.
+func (p *BundlePolicy) Equal(other *BundlePolicy) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+	return *p == *other
+}
+
+// BundlePolicyPath returns the file the daemon saves its bundle policy to, empty on platforms
+// without a state directory.
+func BundlePolicyPath() string {
+	if configs.StateDir == "" {
+		return ""
+	}
+	return filepath.Join(configs.StateDir, bundlePolicyFile)
+}
+
+// LoadBundlePolicy reads a policy saved by SaveBundlePolicy. It returns nil without an error when
+// no policy was saved.
+func LoadBundlePolicy(path string) (*BundlePolicy, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read bundle policy: %w", err)
+	}
+
+	var policy BundlePolicy
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("parse bundle policy %s: %w", path, err)
+	}
+	return &policy, nil
+}
+
+// SaveBundlePolicy writes the policy to path, or removes the file when there is no policy.
+func SaveBundlePolicy(path string, policy *BundlePolicy) error {
+	if policy == nil {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("remove bundle policy: %w", err)
+		}
+		return nil
+	}
+	if err := util.WriteJsonWithRestrictedPermission(context.Background(), path, policy); err != nil {
+		return fmt.Errorf("write bundle policy: %w", err)
+	}
+	return nil
+}
+
+// applyPolicy overrides the bundle configuration with the management policy.
+func (g *BundleGenerator) applyPolicy() {
+	if g.policy == nil {
+		return
+	}
+
+	if g.policy.ForceAnonymize && !g.anonymize {
+		log.Info("debug bundle policy: anonymizing bundle")
+		g.anonymize = true
+	}
+	if g.policy.ForceAnonymize && g.anonymizeIPsOnly {
+		log.Info("debug bundle policy: anonymizing domain names too")
+		g.anonymizeIPsOnly = false
+	}
+	if g.policy.ForceAnonymize && g.allowSecrets {
+		log.Info("debug bundle policy: masking secrets")
+		g.allowSecrets = false
+		g.secrets = g.secretsRedactor()
+	}
+	if g.policy.ForceAnonymize && g.includeRawCapture {
+		log.Info("debug bundle policy: excluding raw packet capture from anonymized bundle")
+		g.includeRawCapture = false
+	}
+	if g.policy.ExcludeCaptures && g.capturePath != "" {
+		log.Info("debug bundle policy: excluding packet capture")
+		g.capturePath = ""
+	}
+	if g.policy.ExcludeNetworkMap && g.syncResponse != nil {
+		log.Info("debug bundle policy: excluding network map")
+		g.syncResponse = nil
+		g.networkMapHistory = nil
+	}
+	if g.policy.ExcludeSystemInfo && len(g.includeSystemInfo) > 0 {
+		log.Info("debug bundle policy: excluding system information")
+		g.includeSystemInfo = nil
+	}
+}
@@ -0,0 +1,37 @@
+package debug
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadBundlePolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), bundlePolicyFile)
+
+	policy, err := LoadBundlePolicy(path)
+	require.NoError(t, err)
+	assert.Nil(t, policy, "no policy before the daemon received one")
+
+	want := &BundlePolicy{ForceAnonymize: true, ExcludeCaptures: true}
+	require.NoError(t, SaveBundlePolicy(path, want))
+	policy, err = LoadBundlePolicy(path)
+	require.NoError(t, err)
+	assert.Equal(t, want, policy)
+
+	require.NoError(t, SaveBundlePolicy(path, nil))
+	policy, err = LoadBundlePolicy(path)
+	require.NoError(t, err)
+	assert.Nil(t, policy, "a removed policy is removed from disk too")
+	require.NoError(t, SaveBundlePolicy(path, nil))
+}
+
+func TestBundlePolicyEqual(t *testing.T) {
+	assert.True(t, (*BundlePolicy)(nil).Equal(nil))
+	assert.False(t, (*BundlePolicy)(nil).Equal(&BundlePolicy{}))
+	assert.False(t, (&BundlePolicy{}).Equal(nil))
+	assert.True(t, (&BundlePolicy{ForceAnonymize: true}).Equal(&BundlePolicy{ForceAnonymize: true}))
+	assert.False(t, (&BundlePolicy{ForceAnonymize: true}).Equal(&BundlePolicy{ExcludeCaptures: true}))
+}
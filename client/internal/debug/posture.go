@@ -0,0 +1,111 @@
+package debug
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+)
+
+const postureFile = "posture.txt"
+
+// userPathPattern matches the user name of a path in a home directory, e.g. /home/alice or
+// C:\Users\alice.
+var userPathPattern = regexp.MustCompile(`(?i)([/\\](?:home|Users)[/\\])[^/\\]+`)
+
+// anonymizeUserPath replaces the user name of a path in a home directory. The rest of a process
+// check path is set by the account administrator and identifies no host.
+func anonymizeUserPath(path string) string {
+	return userPathPattern.ReplaceAllString(path, "${1}user")
+}
+
+// PostureSummary describes the expected access of the posture check results in one line.
+func PostureSummary(e peer.PostureEvaluation) string {
+	if e.EvaluatedAt.IsZero() {
+		return "not evaluated yet"
+	}
+	if len(e.Checks) == 0 {
+		return "no process checks requested"
+	}
+
+	var failed []string
+	for i, c := range e.Checks {
+		if !c.Passed() {
+			failed = append(failed, fmt.Sprintf("%d", i+1))
+		}
+	}
+	summary := fmt.Sprintf("%d/%d process checks pass", len(e.Checks)-len(failed), len(e.Checks))
+	if len(failed) > 0 {
+		summary += fmt.Sprintf(", check %s failing, access of the policies requiring it is denied", strings.Join(failed, ", "))
+	}
+	if e.Err != nil {
+		summary += " (not reported: " + e.Err.Error() + ")"
+	}
+	return summary
+}
+
+// FormatPosture renders the most recent posture check results with the access they are expected
+// to result in. anonymizePath is applied to the process paths when set.
+func FormatPosture(e peer.PostureEvaluation, anonymizePath func(string) string) string {
+	if e.EvaluatedAt.IsZero() {
+		return "No posture checks were evaluated since the engine started.\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Last evaluation: %s\n", e.EvaluatedAt.UTC().Format(time.RFC3339)))
+	if e.Err != nil {
+		sb.WriteString(fmt.Sprintf("Reported to management: no, %v\n", e.Err))
+	} else {
+		sb.WriteString("Reported to management: yes\n")
+	}
+	sb.WriteString(fmt.Sprintf("Expected access: %s\n\n", PostureSummary(e)))
+	sb.WriteString("The management server evaluates these results and decides the access. The checks it evaluates\n" +
+		"from the reported system information alone, like the OS or NetBird version, the location or the\n" +
+		"network range, are not sent to the client and not listed here.\n")
+
+	for i, c := range e.Checks {
+		result := "pass"
+		if !c.Passed() {
+			result = "fail"
+		}
+		sb.WriteString(fmt.Sprintf("\nProcess check %d: %s\n", i+1, result))
+		for _, p := range c.Processes {
+			path := p.Path
+			if anonymizePath != nil {
+				path = anonymizePath(path)
+			}
+			exists, running := "missing", "not running"
+			if p.Exists {
+				exists = "exists"
+			}
+			if p.Running {
+				running = "running"
+			}
+			sb.WriteString(fmt.Sprintf("  %-8s %-12s %s\n", exists, running, path))
+		}
+	}
+	return sb.String()
+}
+
+// addPosture writes the most recent posture check results. Paths in home directories have their
+// user name replaced if anonymization is set.
+func (g *BundleGenerator) addPosture() error {
+	if g.statusRecorder == nil {
+		log.Debug("skipping posture checks in debug bundle: no status recorder")
+		return nil
+	}
+
+	var anonymizePath func(string) string
+	if g.anonymize {
+		anonymizePath = anonymizeUserPath
+	}
+	content := FormatPosture(g.statusRecorder.GetPostureEvaluation(), anonymizePath)
+	if err := g.addFileToZip(strings.NewReader(content), postureFile); err != nil {
+		return fmt.Errorf("add %s to zip: %w", postureFile, err)
+	}
+	return nil
+}
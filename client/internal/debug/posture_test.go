@@ -0,0 +1,45 @@
+package debug
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+)
+
+func TestFormatPosture(t *testing.T) {
+	assert.Equal(t, "No posture checks were evaluated since the engine started.\n", FormatPosture(peer.PostureEvaluation{}, nil))
+
+	evaluation := peer.PostureEvaluation{
+		EvaluatedAt: time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC),
+		Checks: []peer.PostureCheck{
+			{Processes: []peer.PostureProcess{{Path: "/usr/bin/agent", Exists: true, Running: true}}},
+			{Processes: []peer.PostureProcess{
+				{Path: "/home/alice/bin/edr", Exists: true},
+				{Path: `C:\Users\alice\edr.exe`},
+			}},
+		},
+	}
+	content := FormatPosture(evaluation, anonymizeUserPath)
+	assert.Contains(t, content, "Last evaluation: 2026-01-02T10:00:00Z\n")
+	assert.Contains(t, content, "Reported to management: yes\n")
+	assert.Contains(t, content, "Expected access: 1/2 process checks pass, check 2 failing, access of the policies requiring it is denied\n")
+	assert.Contains(t, content, "\nProcess check 1: pass\n  exists   running      /usr/bin/agent\n")
+	assert.Contains(t, content, "\nProcess check 2: fail\n  exists   not running  /home/user/bin/edr\n  missing  not running  C:\\Users\\user\\edr.exe\n")
+	assert.NotContains(t, content, "alice")
+
+	evaluation.Err = errors.New("timed out")
+	assert.Contains(t, FormatPosture(evaluation, nil), "Reported to management: no, timed out\n")
+}
+
+func TestPostureSummary(t *testing.T) {
+	assert.Equal(t, "not evaluated yet", PostureSummary(peer.PostureEvaluation{}))
+	assert.Equal(t, "no process checks requested", PostureSummary(peer.PostureEvaluation{EvaluatedAt: time.Now()}))
+	assert.Equal(t, "1/1 process checks pass", PostureSummary(peer.PostureEvaluation{
+		EvaluatedAt: time.Now(),
+		Checks:      []peer.PostureCheck{{Processes: []peer.PostureProcess{{Path: "/usr/bin/agent", Exists: true, Running: true}}}},
+	}))
+}
@@ -0,0 +1,62 @@
+package debug
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/netbirdio/netbird/client/anonymize"
+)
+
+// previewSamplesPerCategory caps how many original→anonymized pairs are shown per category.
+const previewSamplesPerCategory = 5
+
+// AnonymizationSummary reports how many values of one category were anonymized,
+// with a few sample mappings. It reveals the original values and is only ever
+// returned to the local caller, never written to the bundle.
+type AnonymizationSummary struct {
+	Category anonymize.Category
+	Count    int
+	Samples  []anonymize.Mapping
+}
+
+// AnonymizationPreview summarizes the values anonymized while generating the bundle.
+// It is only meaningful after Generate has run with anonymization enabled.
+func (g *BundleGenerator) AnonymizationPreview() []AnonymizationSummary {
+	var summaries []AnonymizationSummary
+	for _, m := range g.anonymizer.Mappings() {
+		if len(summaries) == 0 || summaries[len(summaries)-1].Category != m.Category {
+			summaries = append(summaries, AnonymizationSummary{Category: m.Category})
+		}
+		s := &summaries[len(summaries)-1]
+		s.Count++
+		if len(s.Samples) < previewSamplesPerCategory {
+			s.Samples = append(s.Samples, m)
+		}
+	}
+	return summaries
+}
+
+// FormatAnonymizationPreview renders an anonymization preview for the terminal.
+func FormatAnonymizationPreview(summaries []AnonymizationSummary) string {
+	if len(summaries) == 0 {
+		return "No values were anonymized.\n"
+	}
+
+	var builder strings.Builder
+	for _, s := range summaries {
+		builder.WriteString(fmt.Sprintf("%s: %d anonymized\n", s.Category, s.Count))
+		for _, m := range s.Samples {
+			builder.WriteString(fmt.Sprintf("  %s -> %s\n", m.Original, m.Anonymized))
+		}
+		if more := s.Count - len(s.Samples); more > 0 {
+			builder.WriteString(fmt.Sprintf("  ... and %d more\n", more))
+		}
+	}
+	return builder.String()
+}
+
+// AnonymizationMappings returns every mapping used for the bundle, including the loaded ones.
+// Like the preview, it reverses the anonymization and must stay with the local caller.
+func (g *BundleGenerator) AnonymizationMappings() []anonymize.Mapping {
+	return g.anonymizer.Mappings()
+}
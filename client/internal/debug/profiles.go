@@ -0,0 +1,68 @@
+package debug
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"runtime/pprof"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultCPUProfileDuration is the CPU profile length of BundleConfig.IncludeProfiles.
+	DefaultCPUProfileDuration = 5 * time.Second
+	// MaxCPUProfileDuration bounds the CPU profile so bundle generation doesn't stall.
+	MaxCPUProfileDuration = time.Minute
+
+	// profilesDir holds the on-demand runtime profiles. They describe the daemon process only,
+	// so they are never anonymized.
+	profilesDir = "profiles/"
+)
+
+// addRuntimeProfiles adds a goroutine dump, a heap profile and a CPU profile taken now.
+func (g *BundleGenerator) addRuntimeProfiles() error {
+	if !g.includeProfiles {
+		return nil
+	}
+
+	var goroutines bytes.Buffer
+	if err := pprof.Lookup("goroutine").WriteTo(&goroutines, 1); err != nil {
+		return fmt.Errorf("write goroutine profile: %w", err)
+	}
+	if err := g.addFileToZip(&goroutines, profilesDir+"goroutine.txt"); err != nil {
+		return fmt.Errorf("add goroutine profile to zip: %w", err)
+	}
+
+	// run a GC so the heap profile reflects the live heap
+	runtime.GC()
+	var heap bytes.Buffer
+	if err := pprof.Lookup("heap").WriteTo(&heap, 0); err != nil {
+		return fmt.Errorf("write heap profile: %w", err)
+	}
+	if err := g.addFileToZip(&heap, profilesDir+"heap.pprof"); err != nil {
+		return fmt.Errorf("add heap profile to zip: %w", err)
+	}
+
+	duration := g.cpuProfileDuration
+	if duration <= 0 {
+		duration = DefaultCPUProfileDuration
+	}
+	duration = min(duration, MaxCPUProfileDuration)
+
+	var cpu bytes.Buffer
+	if err := pprof.StartCPUProfile(&cpu); err != nil {
+		// only one CPU profile can run at a time, e.g. during "netbird debug for"
+		log.Warnf("skipping CPU profile in debug bundle: %v", err)
+		return nil
+	}
+	log.Debugf("collecting CPU profile for %s", duration)
+	time.Sleep(duration)
+	pprof.StopCPUProfile()
+
+	if err := g.addFileToZip(&cpu, profilesDir+"cpu.pprof"); err != nil {
+		return fmt.Errorf("add CPU profile to zip: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+package debug
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"os"
+	"strings"
+
+	"golang.org/x/net/http/httpproxy"
+
+	mgmProto "github.com/netbirdio/netbird/shared/management/proto"
+)
+
+const proxyFile = "proxy.txt"
+
+// proxyEnvVars are the proxy environment variables in the order they are listed. Go reads the
+// upper case name first. ALL_PROXY is only read by other tools like curl, it is listed to spot a
+// proxy that NetBird ignores.
+var proxyEnvVars = []string{"HTTPS_PROXY", "https_proxy", "HTTP_PROXY", "http_proxy", "NO_PROXY", "no_proxy", "ALL_PROXY", "all_proxy"}
+
+const proxyNote = `NetBird has no proxy setting of its own. Its connections to management, signal and the relays
+use its own dialer and are always made directly, without the proxy. On a network that only lets
+traffic out through a proxy they fail, while the SSO login and the debug bundle upload, which are
+plain HTTP clients, go through the proxy selected above and still work.
+`
+
+// proxyTarget is a server the daemon connects to.
+type proxyTarget struct {
+	name string
+	url  *url.URL
+}
+
+// addProxy writes the proxy environment of the daemon and the proxy its HTTP clients would use
+// for the management, signal and relay servers. Credentials in proxy URLs are never included,
+// proxy hosts are anonymized with the bundle.
+func (g *BundleGenerator) addProxy() error {
+	mask := func(host string) string { return host }
+	if g.anonymize {
+		mask = g.anonymizeProxyHost
+	}
+
+	content := formatProxyReport(os.Getenv, g.proxyTargets(), mask)
+	if err := g.addFileToZip(strings.NewReader(content), proxyFile); err != nil {
+		return fmt.Errorf("add proxy file to zip: %w", err)
+	}
+	return nil
+}
+
+// proxyTargets returns the management server of the config and the signal and relay servers of
+// the last network map.
+func (g *BundleGenerator) proxyTargets() []proxyTarget {
+	var targets []proxyTarget
+	if g.internalConfig != nil && g.internalConfig.ManagementURL != nil {
+		targets = append(targets, proxyTarget{name: "Management", url: g.internalConfig.ManagementURL})
+	}
+
+	nbConfig := g.syncResponse.GetNetbirdConfig()
+	if signal := nbConfig.GetSignal(); signal.GetUri() != "" {
+		scheme := "https"
+		if signal.GetProtocol() == mgmProto.HostConfig_HTTP {
+			scheme = "http"
+		}
+		targets = append(targets, proxyTarget{name: "Signal", url: &url.URL{Scheme: scheme, Host: signal.GetUri()}})
+	}
+	for _, relay := range nbConfig.GetRelay().GetUrls() {
+		u, err := url.Parse(relay)
+		if err != nil || u.Host == "" {
+			continue
+		}
+		targets = append(targets, proxyTarget{name: "Relay", url: u})
+	}
+	return targets
+}
+
+// formatProxyReport renders the proxy variables read with getenv and the proxy selected for each
+// target. mask replaces host names and addresses, credentials are always removed.
+func formatProxyReport(getenv func(string) string, targets []proxyTarget, mask func(string) string) string {
+	var sb strings.Builder
+
+	sb.WriteString("Proxy environment variables of the daemon:\n")
+	var set int
+	for _, name := range proxyEnvVars {
+		value := getenv(name)
+		if value == "" {
+			continue
+		}
+		set++
+		if strings.EqualFold(name, "NO_PROXY") {
+			sb.WriteString(fmt.Sprintf("  %s=%s\n", name, maskNoProxy(value, mask)))
+			continue
+		}
+		proxy, hadCredentials := stripProxyCredentials(value)
+		line := fmt.Sprintf("  %s=%s", name, maskProxyURL(proxy, mask))
+		if hadCredentials {
+			line += " (credentials removed)"
+		}
+		sb.WriteString(line + "\n")
+	}
+	if set == 0 {
+		sb.WriteString("  none set\n")
+	}
+
+	cfg := &httpproxy.Config{
+		HTTPProxy:  firstEnv(getenv, "HTTP_PROXY", "http_proxy"),
+		HTTPSProxy: firstEnv(getenv, "HTTPS_PROXY", "https_proxy"),
+		NoProxy:    firstEnv(getenv, "NO_PROXY", "no_proxy"),
+		CGI:        getenv("REQUEST_METHOD") != "",
+	}
+	proxyFunc := cfg.ProxyFunc()
+
+	sb.WriteString("\nProxy the HTTP clients of the daemon select:\n")
+	if len(targets) == 0 {
+		sb.WriteString("  no servers known, the config and network map are not available\n")
+	}
+	for _, target := range targets {
+		sb.WriteString(fmt.Sprintf("  %s %s: %s\n", target.name, maskProxyURL(target.url.String(), mask), proxySelection(cfg, proxyFunc, target.url, mask)))
+	}
+
+	sb.WriteString("\nManagement connection: direct, NetBird dials management without a proxy\n\n")
+	sb.WriteString(proxyNote)
+	return sb.String()
+}
+
+// proxySelection describes the proxy selected for target, relay URLs are looked up like HTTPS.
+func proxySelection(cfg *httpproxy.Config, proxyFunc func(*url.URL) (*url.URL, error), target *url.URL, mask func(string) string) string {
+	lookup := *target
+	configured := cfg.HTTPSProxy
+	switch lookup.Scheme {
+	case "http", "rel", "ws":
+		lookup.Scheme = "http"
+		configured = cfg.HTTPProxy
+	default:
+		lookup.Scheme = "https"
+	}
+
+	proxy, err := proxyFunc(&lookup)
+	switch {
+	case err != nil:
+		// the error quotes the proxy value, credentials included
+		return "invalid proxy setting, HTTP clients fail to connect"
+	case proxy != nil:
+		proxy.User = nil
+		return "via proxy " + maskProxyURL(proxy.String(), mask)
+	case configured != "":
+		return "direct, excluded by NO_PROXY or a local address"
+	default:
+		return "direct, no proxy set"
+	}
+}
+
+// stripProxyCredentials removes the user info from a proxy URL, also from values without a scheme
+// like user:pass@proxy:3128.
+func stripProxyCredentials(raw string) (string, bool) {
+	u, err := url.Parse(raw)
+	if err == nil && u.Host != "" {
+		if u.User == nil {
+			return raw, false
+		}
+		u.User = nil
+		return u.String(), true
+	}
+	if i := strings.LastIndex(raw, "@"); i >= 0 {
+		return raw[i+1:], true
+	}
+	return raw, false
+}
+
+// maskProxyURL applies mask to the host of a proxy or server URL. Values without a scheme are
+// taken as host[:port].
+func maskProxyURL(raw string, mask func(string) string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return mask(raw)
+	}
+	u.Host = mask(u.Host)
+	return u.String()
+}
+
+// maskNoProxy applies mask to each entry of a NO_PROXY list, keeping the leading "." or "*." of
+// domain suffixes.
+func maskNoProxy(value string, mask func(string) string) string {
+	entries := strings.Split(value, ",")
+	for i, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		prefix := ""
+		for _, p := range []string{"*.", "."} {
+			if strings.HasPrefix(entry, p) {
+				prefix, entry = p, strings.TrimPrefix(entry, p)
+				break
+			}
+		}
+		if entry == "" || entry == "*" {
+			entries[i] = prefix + entry
+			continue
+		}
+		entries[i] = prefix + mask(entry)
+	}
+	return strings.Join(entries, ",")
+}
+
+// anonymizeProxyHost anonymizes a host, host:port, address or prefix of the proxy settings.
+func (g *BundleGenerator) anonymizeProxyHost(host string) string {
+	if h, port, err := net.SplitHostPort(host); err == nil {
+		return net.JoinHostPort(g.anonymizeProxyHost(h), port)
+	}
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if addr.IsLoopback() {
+			return host
+		}
+		return g.anonymizer.AnonymizeIP(addr).String()
+	}
+	if prefix, err := netip.ParsePrefix(host); err == nil {
+		return netip.PrefixFrom(g.anonymizer.AnonymizeIP(prefix.Addr()), prefix.Bits()).String()
+	}
+	if host == "localhost" {
+		return host
+	}
+	return g.anonymizer.AnonymizeDomain(host)
+}
+
+func firstEnv(getenv func(string) string, names ...string) string {
+	for _, name := range names {
+		if value := getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,94 @@
+package debug
+
+import (
+	"encoding/csv"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+	nbstatus "github.com/netbirdio/netbird/client/status"
+)
+
+const reachabilityFile = "reachability.csv"
+
+// Reachability of a peer in reachability.csv.
+const (
+	reachabilityDirect      = "direct"
+	reachabilityRelay       = "relay"
+	reachabilityIdle        = "idle"
+	reachabilityUnreachable = "unreachable"
+)
+
+// peerReachability classifies how this node reaches a peer. Idle peers have no tunnel because
+// lazy connections open it on demand, they are not known to be unreachable.
+func peerReachability(p nbstatus.PeerStateDetailOutput) string {
+	switch p.Status {
+	case peer.StatusConnected.String():
+		if p.ConnType == "Relayed" {
+			return reachabilityRelay
+		}
+		return reachabilityDirect
+	case peer.StatusIdle.String():
+		return reachabilityIdle
+	default:
+		return reachabilityUnreachable
+	}
+}
+
+// FormatReachability renders the row of this node in the mesh reachability matrix as CSV, one
+// record per peer sorted by FQDN, so the files of several peers can be diffed and joined.
+func FormatReachability(peers []nbstatus.PeerStateDetailOutput) (string, error) {
+	peers = slices.Clone(peers)
+	slices.SortFunc(peers, func(a, b nbstatus.PeerStateDetailOutput) int {
+		return strings.Compare(a.FQDN, b.FQDN)
+	})
+
+	var sb strings.Builder
+	w := csv.NewWriter(&sb)
+	if err := w.Write([]string{"peer", "ip", "reachability", "status", "since", "local_candidate", "remote_candidate", "relay_address", "latency_ms", "stale"}); err != nil {
+		return "", err
+	}
+	for _, p := range peers {
+		reachability := peerReachability(p)
+		var latency, since string
+		if reachability == reachabilityDirect || reachability == reachabilityRelay {
+			latency = strconv.FormatInt(p.Latency.Milliseconds(), 10)
+		}
+		if !p.LastStatusUpdate.IsZero() {
+			since = p.LastStatusUpdate.UTC().Format(time.RFC3339)
+		}
+		record := []string{
+			p.FQDN,
+			p.IP,
+			reachability,
+			p.Status,
+			since,
+			p.IceCandidateType.Local,
+			p.IceCandidateType.Remote,
+			p.RelayAddress,
+			latency,
+			strconv.FormatBool(p.Stale),
+		}
+		if err := w.Write(record); err != nil {
+			return "", err
+		}
+	}
+	w.Flush()
+	return sb.String(), w.Error()
+}
+
+// addReachability writes the per-peer reachability of this node from the status overview, which
+// is already limited to the selected peers and anonymized.
+func (g *BundleGenerator) addReachability(overview nbstatus.OutputOverview) error {
+	content, err := FormatReachability(overview.Peers.Details)
+	if err != nil {
+		return fmt.Errorf("format reachability: %w", err)
+	}
+	if err := g.addFileToZip(strings.NewReader(content), reachabilityFile); err != nil {
+		return fmt.Errorf("add %s to zip: %w", reachabilityFile, err)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package debug
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	nbstatus "github.com/netbirdio/netbird/client/status"
+)
+
+func TestFormatReachability(t *testing.T) {
+	since := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	content, err := FormatReachability([]nbstatus.PeerStateDetailOutput{
+		{FQDN: "relayed.netbird.cloud", IP: "100.64.0.3", Status: "Connected", LastStatusUpdate: since, ConnType: "Relayed", RelayAddress: "rels://relay.netbird.io:443", Latency: 35 * time.Millisecond},
+		{FQDN: "direct.netbird.cloud", IP: "100.64.0.2", Status: "Connected", LastStatusUpdate: since, ConnType: "P2P", IceCandidateType: nbstatus.IceCandidateType{Local: "host", Remote: "srflx"}, Latency: 12 * time.Millisecond, Stale: true},
+		{FQDN: "idle.netbird.cloud", IP: "100.64.0.4", Status: "Idle", ConnType: "-"},
+		{FQDN: "down.netbird.cloud", IP: "100.64.0.5", Status: "Connecting", LastStatusUpdate: since, ConnType: "-"},
+	})
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(content), "\n")
+	assert.Equal(t, []string{
+		"peer,ip,reachability,status,since,local_candidate,remote_candidate,relay_address,latency_ms,stale",
+		"direct.netbird.cloud,100.64.0.2,direct,Connected,2026-01-02T10:00:00Z,host,srflx,,12,true",
+		"down.netbird.cloud,100.64.0.5,unreachable,Connecting,2026-01-02T10:00:00Z,,,,,false",
+		"idle.netbird.cloud,100.64.0.4,idle,Idle,,,,,,false",
+		"relayed.netbird.cloud,100.64.0.3,relay,Connected,2026-01-02T10:00:00Z,,,rels://relay.netbird.io:443,35,false",
+	}, lines)
+}
@@ -0,0 +1,168 @@
+package debug
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"io"
+	"regexp"
+	"strings"
+
+	"golang.zx2c4.com/wireguard/wgtypes"
+
+	"github.com/netbirdio/netbird/client/internal/profilemanager"
+	mgmProto "github.com/netbirdio/netbird/shared/management/proto"
+)
+
+// minKnownSecretLen is the length below which a known secret is not searched for, so short values
+// like "1" of a misconfigured field do not mask unrelated text.
+const minKnownSecretLen = 8
+
+// binarySniffLen is the number of leading bytes checked for a NUL byte to detect binary files.
+const binarySniffLen = 512
+
+var (
+	// jwtValue matches JSON web tokens, whose header and payload are base64 encoded JSON objects.
+	jwtValue = regexp.MustCompile(`eyJ[\w-]+\.eyJ[\w-]+\.[\w-]*`)
+	// secretField matches the value of a field or log attribute named like a secret, e.g.
+	// private_key=..., "setupKey": "..." or Authorization: Bearer ... Values that are already
+	// masked with * are left alone.
+	secretField = regexp.MustCompile(`(?i)((?:private|pre-?shared|setup)[-_ ]?key|\bpsk|\b(?:id|access|refresh|jwt)[-_ ]?token|\bauthorization)(\\?"?\s*[:=]\s*\\?"?(?:bearer\s+)?)([^\s"'\\,;}*]+)`)
+)
+
+// secretRedactor masks secrets in the text files of a bundle, independent of anonymization. It
+// replaces the secret values it knows, the WireGuard, pre-shared and SSH keys of the config and
+// the relay, flow and TURN credentials of the network maps, wherever they appear, as well as JWTs
+// and the values of fields named like a private key, pre-shared key, setup key or token.
+type secretRedactor struct {
+	known []string
+}
+
+// newSecretRedactor collects the known secrets of the config and the sync responses.
+func newSecretRedactor(config *profilemanager.Config, syncResponses ...*mgmProto.SyncResponse) *secretRedactor {
+	r := &secretRedactor{}
+	if config != nil {
+		r.addKey(config.PrivateKey)
+		r.addKey(config.PreSharedKey)
+		for _, line := range strings.Split(config.SSHKey, "\n") {
+			if line = strings.TrimSpace(line); !strings.HasPrefix(line, "-----") {
+				r.add(line)
+			}
+		}
+	}
+
+	for _, resp := range syncResponses {
+		cfg := resp.GetNetbirdConfig()
+		if cfg == nil {
+			continue
+		}
+		r.add(cfg.GetRelay().GetTokenPayload())
+		r.add(cfg.GetRelay().GetTokenSignature())
+		r.add(cfg.GetFlow().GetTokenPayload())
+		r.add(cfg.GetFlow().GetTokenSignature())
+		for _, turn := range cfg.GetTurns() {
+			r.add(turn.GetPassword())
+		}
+	}
+	return r
+}
+
+// SecretRedactor masks the secrets that bundles mask in other text the daemon hands out, like
+// streamed log lines.
+type SecretRedactor struct {
+	redactor *secretRedactor
+}
+
+// NewSecretRedactor returns a redactor of the known secrets of the config and the sync responses,
+// JWTs and fields named like a secret.
+func NewSecretRedactor(config *profilemanager.Config, syncResponses ...*mgmProto.SyncResponse) *SecretRedactor {
+	return &SecretRedactor{redactor: newSecretRedactor(config, syncResponses...)}
+}
+
+// Redact returns s with all secrets masked.
+func (r *SecretRedactor) Redact(s string) string {
+	return r.redactor.redact(s)
+}
+
+// addKey adds a WireGuard key in its base64 form and in the hex form of the WireGuard UAPI.
+func (r *secretRedactor) addKey(key string) {
+	r.add(key)
+	if parsed, err := wgtypes.ParseKey(key); err == nil {
+		r.add(hex.EncodeToString(parsed[:]))
+	}
+}
+
+func (r *secretRedactor) add(secret string) {
+	if len(secret) < minKnownSecretLen {
+		return
+	}
+	for _, known := range r.known {
+		if known == secret {
+			return
+		}
+	}
+	r.known = append(r.known, secret)
+}
+
+// redact returns s with all secrets replaced with maskedValue.
+func (r *secretRedactor) redact(s string) string {
+	for _, secret := range r.known {
+		s = strings.ReplaceAll(s, secret, maskedValue)
+	}
+	s = jwtValue.ReplaceAllString(s, maskedValue)
+	return secretField.ReplaceAllString(s, "${1}${2}"+maskedValue)
+}
+
+// reader returns a reader of src with the secrets of every line masked. Binary content, like
+// profiles, packet captures and compressed logs, is passed through as is.
+func (r *secretRedactor) reader(src io.Reader) io.Reader {
+	br := bufio.NewReader(src)
+	// a short or failed read is detected again by the first Read
+	head, _ := br.Peek(binarySniffLen)
+	if bytes.IndexByte(head, 0) >= 0 {
+		return br
+	}
+	return &redactingReader{src: br, redactor: r}
+}
+
+// redactingReader masks secrets line by line. Line endings are kept, so content without secrets
+// is passed through unchanged.
+type redactingReader struct {
+	src      *bufio.Reader
+	redactor *secretRedactor
+	pending  []byte
+	err      error
+}
+
+func (rr *redactingReader) Read(p []byte) (int, error) {
+	for len(rr.pending) == 0 {
+		if rr.err != nil {
+			return 0, rr.err
+		}
+		line, err := rr.src.ReadString('\n')
+		rr.pending = []byte(rr.redactor.redact(line))
+		rr.err = err
+	}
+
+	n := copy(p, rr.pending)
+	rr.pending = rr.pending[n:]
+	return n, nil
+}
+
+// redactSecrets returns a reader of src with secrets masked, or src itself when the bundle may
+// contain secrets.
+func (g *BundleGenerator) redactSecrets(src io.Reader) io.Reader {
+	if g.secrets == nil {
+		return src
+	}
+	return g.secrets.reader(src)
+}
+
+// secretsRedactor builds the secret redactor of the generator from its config and network maps.
+func (g *BundleGenerator) secretsRedactor() *secretRedactor {
+	responses := []*mgmProto.SyncResponse{g.syncResponse}
+	for _, snapshot := range g.networkMapHistory {
+		responses = append(responses, snapshot.Response)
+	}
+	return newSecretRedactor(g.internalConfig, responses...)
+}
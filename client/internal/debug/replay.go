@@ -0,0 +1,53 @@
+package debug
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	mgmProto "github.com/netbirdio/netbird/shared/management/proto"
+)
+
+// NetworkMapFile is the bundle file holding the latest sync response.
+const NetworkMapFile = "network_map.json"
+
+// ReadBundleSyncResponse reads a sync response captured in a debug bundle, network_map.json or one
+// of the older network_map-N.json files. Encrypted bundles have to be decrypted first.
+func ReadBundleSyncResponse(path, name string) (*mgmProto.SyncResponse, error) {
+	if name == "" {
+		name = NetworkMapFile
+	}
+
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("open bundle (encrypted bundles must be decrypted first): %w", err)
+	}
+	defer func() {
+		_ = archive.Close()
+	}()
+	registerZstdDecompressor(&archive.Reader)
+
+	rc, err := archive.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", name, err)
+	}
+	defer func() {
+		_ = rc.Close()
+	}()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", name, err)
+	}
+
+	var syncResponse mgmProto.SyncResponse
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, &syncResponse); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", name, err)
+	}
+	if syncResponse.GetNetworkMap() == nil {
+		return nil, fmt.Errorf("%s contains no network map", name)
+	}
+	return &syncResponse, nil
+}
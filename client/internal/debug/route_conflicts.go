@@ -0,0 +1,220 @@
+package debug
+
+import (
+	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const routeConflictsFile = "route-conflicts.txt"
+
+const (
+	// RouteWinnerHost marks a conflict where the host route takes precedence.
+	RouteWinnerHost = "host"
+	// RouteWinnerNetBird marks a conflict where the NetBird route takes precedence.
+	RouteWinnerNetBird = "netbird"
+	// RouteWinnerUnclear marks a conflict between equally specific routes with the same metric.
+	RouteWinnerUnclear = "unclear"
+)
+
+// RouteEntry is a routing table entry as compared by the route conflict check.
+type RouteEntry struct {
+	Prefix    netip.Prefix
+	Gateway   netip.Addr
+	Interface string
+	Table     string
+	Metric    int
+}
+
+// RouteConflict is a host route overlapping a route installed by NetBird.
+type RouteConflict struct {
+	NetBird RouteEntry
+	Host    RouteEntry
+	// Winner is the route taking precedence for the overlapping addresses, one of the RouteWinner constants.
+	Winner string
+	Reason string
+}
+
+// RouteConflictReport is the outcome of comparing the NetBird routes against the host routing table.
+type RouteConflictReport struct {
+	Interface string
+	// PolicyRouting is set when NetBird routes live in their own table, consulted after the main table.
+	PolicyRouting bool
+	NetBirdRoutes int
+	HostRoutes    int
+	Conflicts     []RouteConflict
+}
+
+// FindRouteConflicts compares the routes through the NetBird interface wgIface, or in the NetBird table,
+// against the other routes of the main table. Default routes are skipped, they overlap every route by design.
+// Routes of the local table and link-local and multicast routes only cover the host itself and are skipped too.
+func FindRouteConflicts(routes []RouteEntry, wgIface string) RouteConflictReport {
+	report := RouteConflictReport{Interface: wgIface}
+
+	var netbird, host []RouteEntry
+	for _, r := range routes {
+		if r.Table == "netbird" {
+			report.PolicyRouting = true
+		}
+		if r.Prefix.Bits() == 0 {
+			continue
+		}
+		switch {
+		case r.Table == "netbird" || wgIface != "" && r.Interface == wgIface:
+			netbird = append(netbird, r)
+		case r.Table != "" && r.Table != "main":
+			// other tables are selected by their own rules
+			continue
+		case r.Prefix.Addr().IsMulticast() || r.Prefix.Addr().IsLinkLocalUnicast():
+			continue
+		default:
+			host = append(host, r)
+		}
+	}
+	report.NetBirdRoutes = len(netbird)
+	report.HostRoutes = len(host)
+
+	for _, nb := range netbird {
+		for _, h := range host {
+			if !nb.Prefix.Masked().Overlaps(h.Prefix.Masked()) {
+				continue
+			}
+			winner, reason := routePrecedence(nb, h, report.PolicyRouting)
+			report.Conflicts = append(report.Conflicts, RouteConflict{
+				NetBird: nb,
+				Host:    h,
+				Winner:  winner,
+				Reason:  reason,
+			})
+		}
+	}
+
+	sort.SliceStable(report.Conflicts, func(i, j int) bool {
+		a, b := report.Conflicts[i], report.Conflicts[j]
+		if a.NetBird.Prefix != b.NetBird.Prefix {
+			return a.NetBird.Prefix.String() < b.NetBird.Prefix.String()
+		}
+		return a.Host.Prefix.String() < b.Host.Prefix.String()
+	})
+	return report
+}
+
+// routePrecedence decides which of two overlapping routes carries the traffic to the overlapping addresses.
+// With policy routing the main table is looked up first, ignoring its default route, so any host route wins.
+// Otherwise the longest prefix wins and the metric decides between equally specific routes.
+func routePrecedence(nb, host RouteEntry, policyRouting bool) (string, string) {
+	switch {
+	case policyRouting && nb.Table == "netbird":
+		return RouteWinnerHost, "main table routes other than the default route are looked up before the NetBird table"
+	case host.Prefix.Bits() > nb.Prefix.Bits():
+		return RouteWinnerHost, "more specific prefix"
+	case host.Prefix.Bits() < nb.Prefix.Bits():
+		return RouteWinnerNetBird, "more specific prefix"
+	case host.Metric < nb.Metric:
+		return RouteWinnerHost, fmt.Sprintf("same prefix, lower metric %d < %d", host.Metric, nb.Metric)
+	case host.Metric > nb.Metric:
+		return RouteWinnerNetBird, fmt.Sprintf("same prefix, lower metric %d < %d", nb.Metric, host.Metric)
+	default:
+		return RouteWinnerUnclear, "same prefix and metric, the operating system picks one"
+	}
+}
+
+// FormatRouteConflicts renders a route conflict report as text.
+func FormatRouteConflicts(report RouteConflictReport) string {
+	var builder strings.Builder
+
+	iface := report.Interface
+	if iface == "" {
+		iface = "unknown"
+	}
+	builder.WriteString(fmt.Sprintf("NetBird interface: %s\n", iface))
+	if report.PolicyRouting {
+		builder.WriteString("Route selection: main table first (except its default route), then the NetBird table\n")
+	} else {
+		builder.WriteString("Route selection: longest prefix, then lowest metric\n")
+	}
+	builder.WriteString(fmt.Sprintf("NetBird routes checked: %d\n", report.NetBirdRoutes))
+	builder.WriteString(fmt.Sprintf("Host routes checked: %d\n\n", report.HostRoutes))
+
+	if len(report.Conflicts) == 0 {
+		builder.WriteString("No conflicts found.\n")
+		return builder.String()
+	}
+
+	for i, c := range report.Conflicts {
+		builder.WriteString(fmt.Sprintf("%d. NetBird %s\n", i+1, formatRouteEntry(c.NetBird)))
+		builder.WriteString(fmt.Sprintf("   Host    %s\n", formatRouteEntry(c.Host)))
+		builder.WriteString(fmt.Sprintf("   Takes precedence: %s (%s)\n", c.Winner, c.Reason))
+	}
+
+	builder.WriteString(fmt.Sprintf("\n%d conflict(s) found. ", len(report.Conflicts)))
+	builder.WriteString("Where the host route takes precedence, traffic for the overlapping addresses bypasses NetBird. " +
+		"Where the NetBird route does, host traffic for them is sent through NetBird. " +
+		"Remove or narrow the host route, or change the NetBird route in the management settings.\n")
+	return builder.String()
+}
+
+func formatRouteEntry(r RouteEntry) string {
+	parts := []string{r.Prefix.String()}
+	if r.Gateway.IsValid() {
+		parts = append(parts, "via "+r.Gateway.String())
+	}
+	if r.Interface != "" {
+		parts = append(parts, "dev "+r.Interface)
+	}
+	if r.Table != "" {
+		parts = append(parts, "table "+r.Table)
+	}
+	parts = append(parts, fmt.Sprintf("metric %d", r.Metric))
+	return strings.Join(parts, " ")
+}
+
+func (g *BundleGenerator) addRouteConflicts() error {
+	var wgIface string
+	if g.internalConfig != nil {
+		wgIface = g.internalConfig.WgIface
+	}
+
+	var content string
+	report, err := CheckRouteConflicts(wgIface)
+	if err != nil {
+		log.Debugf("failed to check route conflicts: %v", err)
+		reason := err.Error()
+		if g.anonymize {
+			reason = g.anonymizer.AnonymizeString(reason)
+		}
+		content = fmt.Sprintf("Route conflict check not available: %s\n", reason)
+	} else {
+		if g.anonymize {
+			report = g.anonymizeRouteConflicts(report)
+		}
+		content = FormatRouteConflicts(report)
+	}
+
+	if err := g.addFileToZip(strings.NewReader(content), routeConflictsFile); err != nil {
+		return fmt.Errorf("add route conflicts file to zip: %w", err)
+	}
+	return nil
+}
+
+func (g *BundleGenerator) anonymizeRouteConflicts(report RouteConflictReport) RouteConflictReport {
+	anonEntry := func(r RouteEntry) RouteEntry {
+		r.Prefix = netip.PrefixFrom(g.anonymizer.AnonymizeIP(r.Prefix.Addr()), r.Prefix.Bits())
+		if r.Gateway.IsValid() {
+			r.Gateway = g.anonymizer.AnonymizeIP(r.Gateway)
+		}
+		return r
+	}
+
+	conflicts := make([]RouteConflict, 0, len(report.Conflicts))
+	for _, c := range report.Conflicts {
+		c.NetBird = anonEntry(c.NetBird)
+		c.Host = anonEntry(c.Host)
+		conflicts = append(conflicts, c)
+	}
+	report.Conflicts = conflicts
+	return report
+}
@@ -0,0 +1,68 @@
+package debug
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindRouteConflicts(t *testing.T) {
+	gw := netip.MustParseAddr("192.168.1.1")
+	routes := []RouteEntry{
+		{Prefix: netip.MustParsePrefix("0.0.0.0/0"), Gateway: gw, Interface: "eth0", Table: "main", Metric: 100},
+		{Prefix: netip.MustParsePrefix("192.168.1.0/24"), Interface: "eth0", Table: "main", Metric: 100},
+		{Prefix: netip.MustParsePrefix("10.10.0.0/16"), Gateway: gw, Interface: "eth0", Table: "main"},
+		{Prefix: netip.MustParsePrefix("192.168.1.1/32"), Interface: "eth0", Table: "local"},
+		{Prefix: netip.MustParsePrefix("0.0.0.0/0"), Interface: "wt0", Table: "main", Metric: 50},
+		{Prefix: netip.MustParsePrefix("10.0.0.0/8"), Interface: "wt0", Table: "main", Metric: 10},
+		{Prefix: netip.MustParsePrefix("192.168.0.0/16"), Interface: "wt0", Table: "main"},
+		{Prefix: netip.MustParsePrefix("172.16.0.0/12"), Interface: "wt0", Table: "main"},
+	}
+
+	report := FindRouteConflicts(routes, "wt0")
+	assert.False(t, report.PolicyRouting)
+	assert.Equal(t, 3, report.NetBirdRoutes)
+	assert.Equal(t, 2, report.HostRoutes)
+	require.Len(t, report.Conflicts, 2)
+
+	assert.Equal(t, netip.MustParsePrefix("10.0.0.0/8"), report.Conflicts[0].NetBird.Prefix)
+	assert.Equal(t, netip.MustParsePrefix("10.10.0.0/16"), report.Conflicts[0].Host.Prefix)
+	assert.Equal(t, RouteWinnerHost, report.Conflicts[0].Winner)
+
+	assert.Equal(t, netip.MustParsePrefix("192.168.0.0/16"), report.Conflicts[1].NetBird.Prefix)
+	assert.Equal(t, netip.MustParsePrefix("192.168.1.0/24"), report.Conflicts[1].Host.Prefix)
+	assert.Equal(t, RouteWinnerHost, report.Conflicts[1].Winner)
+}
+
+func TestFindRouteConflictsPolicyRouting(t *testing.T) {
+	routes := []RouteEntry{
+		{Prefix: netip.MustParsePrefix("10.0.0.0/8"), Interface: "eth1", Table: "main"},
+		{Prefix: netip.MustParsePrefix("10.1.0.0/16"), Interface: "wt0", Table: "netbird"},
+		{Prefix: netip.MustParsePrefix("10.2.0.0/16"), Interface: "eth2", Table: "100"},
+	}
+
+	report := FindRouteConflicts(routes, "wt0")
+	assert.True(t, report.PolicyRouting)
+	require.Len(t, report.Conflicts, 1)
+	// the less specific main table route still wins, the main table is looked up first
+	assert.Equal(t, RouteWinnerHost, report.Conflicts[0].Winner)
+	assert.Equal(t, "eth1", report.Conflicts[0].Host.Interface)
+}
+
+func TestRoutePrecedence(t *testing.T) {
+	nb := RouteEntry{Prefix: netip.MustParsePrefix("10.0.0.0/16"), Metric: 10}
+
+	winner, _ := routePrecedence(nb, RouteEntry{Prefix: netip.MustParsePrefix("10.0.0.0/8")}, false)
+	assert.Equal(t, RouteWinnerNetBird, winner)
+
+	winner, _ = routePrecedence(nb, RouteEntry{Prefix: netip.MustParsePrefix("10.0.0.0/16"), Metric: 5}, false)
+	assert.Equal(t, RouteWinnerHost, winner)
+
+	winner, _ = routePrecedence(nb, RouteEntry{Prefix: netip.MustParsePrefix("10.0.0.0/16"), Metric: 20}, false)
+	assert.Equal(t, RouteWinnerNetBird, winner)
+
+	winner, _ = routePrecedence(nb, RouteEntry{Prefix: netip.MustParsePrefix("10.0.0.0/16"), Metric: 10}, false)
+	assert.Equal(t, RouteWinnerUnclear, winner)
+}
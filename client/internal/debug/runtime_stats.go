@@ -0,0 +1,108 @@
+package debug
+
+import (
+	"encoding/csv"
+	"fmt"
+	"runtime/metrics"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const resourceTimelineFile = "resource-timeline.csv"
+
+// runtimeMetrics are read with runtime/metrics, which unlike runtime.ReadMemStats does not stop
+// the world, so sampling every second does not perturb the daemon.
+var runtimeMetrics = []string{
+	"/sched/goroutines:goroutines",
+	"/memory/classes/heap/objects:bytes",
+	"/memory/classes/total:bytes",
+	"/gc/cycles/total:gc-cycles",
+}
+
+// RuntimeStats is a sample of the resource usage of the daemon process.
+type RuntimeStats struct {
+	Time       time.Time
+	Goroutines uint64
+	// OpenFDs is the number of open file descriptors, -1 when the platform does not report it.
+	OpenFDs int64
+	// HeapBytes is the memory of the live and not yet swept heap objects.
+	HeapBytes uint64
+	// TotalBytes is all memory mapped by the Go runtime.
+	TotalBytes uint64
+	GCCycles   uint64
+}
+
+// CollectRuntimeStats samples the resource usage of the current process.
+func CollectRuntimeStats() RuntimeStats {
+	samples := make([]metrics.Sample, len(runtimeMetrics))
+	for i, name := range runtimeMetrics {
+		samples[i].Name = name
+	}
+	metrics.Read(samples)
+
+	value := func(i int) uint64 {
+		if samples[i].Value.Kind() != metrics.KindUint64 {
+			return 0
+		}
+		return samples[i].Value.Uint64()
+	}
+
+	return RuntimeStats{
+		Time:       time.Now().UTC(),
+		Goroutines: value(0),
+		OpenFDs:    openFDCount(),
+		HeapBytes:  value(1),
+		TotalBytes: value(2),
+		GCCycles:   value(3),
+	}
+}
+
+// FormatResourceTimeline renders the samples as CSV, one row per sample with the seconds since
+// the first one. An unknown file descriptor count is left empty.
+func FormatResourceTimeline(samples []RuntimeStats) (string, error) {
+	var sb strings.Builder
+	w := csv.NewWriter(&sb)
+	if err := w.Write([]string{"time", "elapsed_seconds", "goroutines", "open_fds", "heap_bytes", "total_bytes", "gc_cycles"}); err != nil {
+		return "", err
+	}
+	for _, s := range samples {
+		fds := ""
+		if s.OpenFDs >= 0 {
+			fds = strconv.FormatInt(s.OpenFDs, 10)
+		}
+		record := []string{
+			s.Time.UTC().Format(time.RFC3339),
+			strconv.FormatFloat(s.Time.Sub(samples[0].Time).Seconds(), 'f', 0, 64),
+			strconv.FormatUint(s.Goroutines, 10),
+			fds,
+			strconv.FormatUint(s.HeapBytes, 10),
+			strconv.FormatUint(s.TotalBytes, 10),
+			strconv.FormatUint(s.GCCycles, 10),
+		}
+		if err := w.Write(record); err != nil {
+			return "", err
+		}
+	}
+	w.Flush()
+	return sb.String(), w.Error()
+}
+
+// addResourceTimeline writes the resource usage sampled by "debug for" during its session.
+func (g *BundleGenerator) addResourceTimeline() error {
+	if len(g.resourceTimeline) == 0 {
+		log.Debug("skipping resource timeline in debug bundle: no samples")
+		return nil
+	}
+
+	content, err := FormatResourceTimeline(g.resourceTimeline)
+	if err != nil {
+		return fmt.Errorf("format resource timeline: %w", err)
+	}
+	if err := g.addFileToZip(strings.NewReader(content), resourceTimelineFile); err != nil {
+		return fmt.Errorf("add %s to zip: %w", resourceTimelineFile, err)
+	}
+	return nil
+}
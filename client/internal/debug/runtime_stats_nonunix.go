@@ -0,0 +1,8 @@
+//go:build !unix
+
+package debug
+
+// openFDCount is not available on this platform.
+func openFDCount() int64 {
+	return -1
+}
@@ -0,0 +1,40 @@
+package debug
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectRuntimeStats(t *testing.T) {
+	stats := CollectRuntimeStats()
+
+	assert.Positive(t, stats.Goroutines)
+	assert.Positive(t, stats.HeapBytes)
+	assert.GreaterOrEqual(t, stats.TotalBytes, stats.HeapBytes)
+	if runtime.GOOS == "windows" {
+		assert.Equal(t, int64(-1), stats.OpenFDs)
+	} else {
+		assert.Positive(t, stats.OpenFDs)
+	}
+}
+
+func TestFormatResourceTimeline(t *testing.T) {
+	start := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	content, err := FormatResourceTimeline([]RuntimeStats{
+		{Time: start, Goroutines: 40, OpenFDs: 12, HeapBytes: 1000, TotalBytes: 5000, GCCycles: 3},
+		{Time: start.Add(2 * time.Second), Goroutines: 45, OpenFDs: -1, HeapBytes: 1200, TotalBytes: 5100, GCCycles: 4},
+	})
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(content), "\n")
+	assert.Equal(t, []string{
+		"time,elapsed_seconds,goroutines,open_fds,heap_bytes,total_bytes,gc_cycles",
+		"2026-01-02T10:00:00Z,0,40,12,1000,5000,3",
+		"2026-01-02T10:00:02Z,2,45,,1200,5100,4",
+	}, lines)
+}
@@ -0,0 +1,26 @@
+package debug
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	serviceFile = "service.txt"
+	// serviceJournalLines bounds the service manager log excerpt in service.txt.
+	serviceJournalLines = 50
+)
+
+// addServiceStatus adds the service manager's view of the daemon: the unit state, the restart
+// count and the recent service manager log lines, where available.
+func (g *BundleGenerator) addServiceStatus() error {
+	content := serviceManagerReport()
+	if g.anonymize {
+		content = g.anonymizer.AnonymizeString(content)
+	}
+
+	if err := g.addFileToZip(strings.NewReader(content), serviceFile); err != nil {
+		return fmt.Errorf("add service status to zip: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+//go:build !ios && !android
+
+package debug
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/kardianos/service"
+	log "github.com/sirupsen/logrus"
+)
+
+const serviceCommandTimeout = 10 * time.Second
+
+// statusOnlyService satisfies service.Interface to query a service without running it.
+type statusOnlyService struct{}
+
+func (statusOnlyService) Start(service.Service) error { return nil }
+func (statusOnlyService) Stop(service.Service) error  { return nil }
+
+// getServiceName gets the service name from environment or defaults to the name used by
+// "netbird service install".
+func getServiceName() string {
+	if unitName := os.Getenv("SYSTEMD_UNIT"); unitName != "" {
+		log.Debugf("Detected SYSTEMD_UNIT environment variable: %s", unitName)
+		return unitName
+	}
+
+	if runtime.GOOS == "windows" {
+		return "Netbird"
+	}
+	return "netbird"
+}
+
+func serviceManagerReport() string {
+	system := service.ChosenSystem()
+	if system == nil {
+		return "No supported service manager detected on this host. The daemon is not running as a managed service.\n"
+	}
+
+	name := strings.TrimSuffix(getServiceName(), ".service")
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Service manager: %s\n", system.String()))
+	sb.WriteString(fmt.Sprintf("Service name: %s\n", name))
+	sb.WriteString(fmt.Sprintf("Status: %s\n", serviceStatus(system, name)))
+
+	for _, c := range serviceManagerCommands(system.String(), name) {
+		sb.WriteString(fmt.Sprintf("\n$ %s\n", strings.Join(c, " ")))
+		sb.WriteString(runServiceCommand(c[0], c[1:]...))
+	}
+	return sb.String()
+}
+
+func serviceStatus(system service.System, name string) string {
+	svc, err := system.New(statusOnlyService{}, &service.Config{Name: name})
+	if err != nil {
+		return fmt.Sprintf("unknown (%v)", err)
+	}
+
+	status, err := svc.Status()
+	if err != nil {
+		return fmt.Sprintf("unknown (%v)", err)
+	}
+	switch status {
+	case service.StatusRunning:
+		return "running"
+	case service.StatusStopped:
+		return "stopped"
+	default:
+		return "unknown"
+	}
+}
+
+// serviceManagerCommands returns the commands that describe the service in the given service
+// manager. Unknown service managers only get the status.
+func serviceManagerCommands(system, name string) [][]string {
+	switch {
+	case strings.Contains(system, "systemd"):
+		unit := name + ".service"
+		return [][]string{
+			{"systemctl", "show", unit, "--no-pager",
+				"--property=LoadState,ActiveState,SubState,UnitFileState,FragmentPath,Result,NRestarts,ExecMainPID,ExecMainStartTimestamp,ExecMainStatus"},
+			{"systemctl", "status", unit, "--no-pager", "--lines=0"},
+			{"journalctl", "-u", unit, "--no-pager", "--output", "short-iso", "--lines", fmt.Sprint(serviceJournalLines)},
+		}
+	case strings.Contains(system, "openrc"):
+		return [][]string{{"rc-service", name, "status"}}
+	case strings.Contains(system, "launchd"):
+		// includes the state, the number of runs and the last exit code
+		return [][]string{{"launchctl", "print", "system/" + name}}
+	case strings.Contains(system, "windows"):
+		return [][]string{{"sc", "queryex", name}, {"sc", "qc", name}, {"sc", "qfailure", name}}
+	default:
+		return nil
+	}
+}
+
+// runServiceCommand returns the output of a service manager command. Status commands exit with
+// an error for stopped services, so the output is kept in that case too.
+func runServiceCommand(name string, args ...string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), serviceCommandTimeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+	result := string(output)
+	if result != "" && !strings.HasSuffix(result, "\n") {
+		result += "\n"
+	}
+	if err != nil {
+		result += fmt.Sprintf("(%s: %v)\n", name, err)
+	}
+	return result
+}
+
+// timeSyncReport returns whether the system clock is synchronized by NTP, as reported by the
+// time service of the platform.
+func timeSyncReport() string {
+	var command []string
+	switch runtime.GOOS {
+	case "linux":
+		command = []string{"timedatectl", "show", "-p", "NTP", "-p", "NTPSynchronized", "-p", "TimeUSec"}
+	case "darwin":
+		command = []string{"systemsetup", "-getusingnetworktime"}
+	case "windows":
+		command = []string{"w32tm", "/query", "/status"}
+	default:
+		return "Not available on this platform.\n"
+	}
+	return fmt.Sprintf("$ %s\n%s", strings.Join(command, " "), runServiceCommand(command[0], command[1:]...))
+}
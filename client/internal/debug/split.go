@@ -0,0 +1,207 @@
+package debug
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// MinSplitSize is the smallest part size of a split bundle.
+	MinSplitSize = 64 << 10
+	// maxSplitParts keeps the part suffixes at three digits, so the parts sort by name.
+	maxSplitParts = 999
+	// splitNoteSuffix names the reassembly note of a split bundle. It does not match the
+	// <bundle>.* glob of the parts, so "cat bundle.zip.* > bundle.zip" does not pick it up.
+	splitNoteSuffix = "-parts.txt"
+)
+
+// sizeUnits are the units accepted by ParseSize. They are powers of 1024, like --max-size.
+var sizeUnits = []struct {
+	suffix string
+	factor int64
+}{
+	{"KIB", 1 << 10},
+	{"MIB", 1 << 20},
+	{"GIB", 1 << 30},
+	{"KB", 1 << 10},
+	{"MB", 1 << 20},
+	{"GB", 1 << 30},
+	{"K", 1 << 10},
+	{"M", 1 << 20},
+	{"G", 1 << 30},
+	{"B", 1},
+}
+
+// ParseSize parses a size like 24MB, 512KiB or 10485760. KB, MB and GB are powers of 1024.
+func ParseSize(s string) (int64, error) {
+	value := strings.ToUpper(strings.TrimSpace(s))
+	factor := int64(1)
+	for _, unit := range sizeUnits {
+		if strings.HasSuffix(value, unit.suffix) {
+			value = strings.TrimSpace(strings.TrimSuffix(value, unit.suffix))
+			factor = unit.factor
+			break
+		}
+	}
+
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid size %q, use a positive number with an optional unit like 24MB", s)
+	}
+	if n > (1<<62)/factor {
+		return 0, fmt.Errorf("size %q is too large", s)
+	}
+	return n * factor, nil
+}
+
+// SplitPartPath returns the path of the n-th part of a split bundle, counting from 1, e.g.
+// bundle.zip.001.
+func SplitPartPath(bundlePath string, n int) string {
+	return fmt.Sprintf("%s.%03d", bundlePath, n)
+}
+
+// SplitNotePath returns the path of the reassembly note of a split bundle.
+func SplitNotePath(bundlePath string) string {
+	return bundlePath + splitNoteSuffix
+}
+
+// splitPart is a written part of a split bundle.
+type splitPart struct {
+	path string
+	size int64
+	sum  string
+}
+
+// partWriter writes a stream across numbered files of at most size bytes. The files are created
+// as the stream reaches them, so the last one holds the remainder.
+type partWriter struct {
+	base  string
+	size  int64
+	parts []splitPart
+
+	current *os.File
+	hash    hash.Hash
+	written int64
+}
+
+func newPartWriter(base string, size int64) *partWriter {
+	return &partWriter{base: base, size: size}
+}
+
+func (w *partWriter) Write(p []byte) (int, error) {
+	var total int
+	for len(p) > 0 {
+		if w.current == nil || w.written == w.size {
+			if err := w.next(); err != nil {
+				return total, err
+			}
+		}
+
+		chunk := p[:min(int64(len(p)), w.size-w.written)]
+		n, err := w.current.Write(chunk)
+		w.hash.Write(chunk[:n])
+		w.written += int64(n)
+		total += n
+		p = p[n:]
+		if err != nil {
+			return total, fmt.Errorf("write bundle part: %w", err)
+		}
+	}
+	return total, nil
+}
+
+// next closes the current part and starts the next one.
+func (w *partWriter) next() error {
+	if err := w.closeCurrent(); err != nil {
+		return err
+	}
+	if len(w.parts) == maxSplitParts {
+		return fmt.Errorf("bundle needs more than %d parts of %d bytes, use a larger split size", maxSplitParts, w.size)
+	}
+
+	path := SplitPartPath(w.base, len(w.parts)+1)
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return fmt.Errorf("create bundle part: %w", err)
+	}
+	w.parts = append(w.parts, splitPart{path: path})
+	w.current = f
+	w.hash = sha256.New()
+	w.written = 0
+	return nil
+}
+
+func (w *partWriter) closeCurrent() error {
+	if w.current == nil {
+		return nil
+	}
+	last := &w.parts[len(w.parts)-1]
+	last.size = w.written
+	last.sum = hex.EncodeToString(w.hash.Sum(nil))
+
+	err := w.current.Close()
+	w.current = nil
+	if err != nil {
+		return fmt.Errorf("close bundle part: %w", err)
+	}
+	return nil
+}
+
+// Close closes the last part. The parts are complete once it returns without error.
+func (w *partWriter) Close() error {
+	return w.closeCurrent()
+}
+
+// Paths returns the paths of the written parts in order.
+func (w *partWriter) Paths() []string {
+	paths := make([]string, 0, len(w.parts))
+	for _, part := range w.parts {
+		paths = append(paths, part.path)
+	}
+	return paths
+}
+
+// remove deletes the parts of a bundle that could not be completed.
+func (w *partWriter) remove() {
+	_ = w.closeCurrent()
+	for _, part := range w.parts {
+		if err := os.Remove(part.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Errorf("Failed to remove bundle part: %v", err)
+		}
+	}
+}
+
+// writeNote writes the reassembly note next to the parts, listing them with their size and
+// SHA256 so a part damaged by a mail or ticket system is found before the zip fails to open.
+func (w *partWriter) writeNote() error {
+	name := filepath.Base(w.base)
+	var b strings.Builder
+	fmt.Fprintf(&b, "This NetBird debug bundle was split into %d parts of at most %d bytes.\n", len(w.parts), w.size)
+	fmt.Fprintf(&b, "Attach or upload all parts. To reassemble %s, put the parts in one directory and run\n\n", name)
+	fmt.Fprintf(&b, "  Linux, macOS: cat %s.* > %s\n", name, name)
+
+	names := make([]string, 0, len(w.parts))
+	for _, part := range w.parts {
+		names = append(names, filepath.Base(part.path))
+	}
+	fmt.Fprintf(&b, "  Windows:      copy /b %s %s\n\n", strings.Join(names, "+"), name)
+
+	b.WriteString("Parts (SHA256, size in bytes, name):\n")
+	for i, part := range w.parts {
+		fmt.Fprintf(&b, "%s  %d  %s\n", part.sum, part.size, names[i])
+	}
+
+	if err := os.WriteFile(SplitNotePath(w.base), []byte(b.String()), 0o600); err != nil {
+		return fmt.Errorf("write split note: %w", err)
+	}
+	return nil
+}
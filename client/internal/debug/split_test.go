@@ -0,0 +1,86 @@
+package debug
+
+import (
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+		wantErr  bool
+	}{
+		{input: "24MB", expected: 24 << 20},
+		{input: "24mb", expected: 24 << 20},
+		{input: "512KiB", expected: 512 << 10},
+		{input: "1 G", expected: 1 << 30},
+		{input: "1048576", expected: 1 << 20},
+		{input: "100B", expected: 100},
+		{input: "", wantErr: true},
+		{input: "MB", wantErr: true},
+		{input: "-1MB", wantErr: true},
+		{input: "1.5MB", wantErr: true},
+		{input: "10TB", wantErr: true},
+		{input: "9999999999GB", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			size, err := ParseSize(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, size)
+		})
+	}
+}
+
+func TestPartWriter(t *testing.T) {
+	base := filepath.Join(t.TempDir(), "netbird.debug.1.zip")
+	data := make([]byte, 2500)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+
+	w := newPartWriter(base, 1000)
+	for chunk := range slices.Chunk(data, 700) {
+		_, err := w.Write(chunk)
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	require.NoError(t, w.writeNote())
+
+	paths := w.Paths()
+	require.Equal(t, []string{base + ".001", base + ".002", base + ".003"}, paths)
+
+	var joined []byte
+	for i, path := range paths {
+		part, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, []int{1000, 1000, 500}[i], len(part))
+		joined = append(joined, part...)
+	}
+	assert.Equal(t, data, joined, "cat of the parts restores the stream")
+
+	note, err := os.ReadFile(SplitNotePath(base))
+	require.NoError(t, err)
+	assert.Contains(t, string(note), "cat netbird.debug.1.zip.* > netbird.debug.1.zip")
+	assert.Contains(t, string(note), "copy /b netbird.debug.1.zip.001+netbird.debug.1.zip.002+netbird.debug.1.zip.003 netbird.debug.1.zip")
+	assert.Contains(t, string(note), "500  netbird.debug.1.zip.003")
+
+	matches, err := filepath.Glob(base + ".*")
+	require.NoError(t, err)
+	assert.Equal(t, paths, matches, "the note does not match the glob of the parts")
+
+	w.remove()
+	for _, path := range paths {
+		assert.NoFileExists(t, path)
+	}
+}
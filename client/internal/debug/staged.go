@@ -0,0 +1,208 @@
+package debug
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// stagedLogsDirName is the directory under the temp directory that holds staged log copies.
+const stagedLogsDirName = "netbird-staged-logs"
+
+// maxStagedLogsAge is how long staged copies are kept when no bundle picks them up.
+const maxStagedLogsAge = 24 * time.Hour
+
+// stagedLogsMarker separates staged lines from the current log lines in a merged log.
+const stagedLogsMarker = "----- lines above were staged at the start of the debug session and are missing from the current log -----\n"
+
+var stagedLogsIDPattern = regexp.MustCompile(`^[0-9a-f]{16}$`)
+
+// StagedLogsRoot returns the directory staged log copies are created in. It is outside the log
+// directory, so log rotation and a daemon restart leave it alone.
+func StagedLogsRoot() string {
+	return filepath.Join(os.TempDir(), stagedLogsDirName)
+}
+
+// StageLogs copies the active daemon log files next to logPath (client.log, netbird.err and
+// netbird.out) to a new directory under root and returns its ID and the staged files. A bundle
+// created with the ID merges the copies with the logs it finds, so lines lost when the daemon
+// restarts in between are kept. Copies older than a day are removed.
+func StageLogs(root, logPath string) (string, []string, error) {
+	pruneStagedLogs(root, maxStagedLogsAge)
+
+	idBytes := make([]byte, 8)
+	if _, err := rand.Read(idBytes); err != nil {
+		return "", nil, fmt.Errorf("generate staging id: %w", err)
+	}
+	id := hex.EncodeToString(idBytes)
+
+	dir := filepath.Join(root, id)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", nil, fmt.Errorf("create staging directory: %w", err)
+	}
+
+	var staged []string
+	for targetName, path := range activeLogFiles(logPath) {
+		err := copyFile(path, filepath.Join(dir, targetName))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return "", nil, fmt.Errorf("stage %s: %w", targetName, err)
+		}
+		staged = append(staged, targetName)
+	}
+	return id, staged, nil
+}
+
+// StagedLogsDir returns the directory of the staged copies with the given ID under root.
+func StagedLogsDir(root, id string) (string, error) {
+	if !stagedLogsIDPattern.MatchString(id) {
+		return "", fmt.Errorf("invalid staged logs id %q", id)
+	}
+	dir := filepath.Join(root, id)
+	if _, err := os.Stat(dir); err != nil {
+		return "", fmt.Errorf("staged logs %s: %w", id, err)
+	}
+	return dir, nil
+}
+
+// activeLogFiles maps the bundle names of the active daemon logs to their paths.
+func activeLogFiles(logPath string) map[string]string {
+	logDir := filepath.Dir(logPath)
+	files := map[string]string{
+		clientLogFile: logPath,
+		errorLogFile:  filepath.Join(logDir, errorLogFile),
+		stdoutLogFile: filepath.Join(logDir, stdoutLogFile),
+	}
+	if runtime.GOOS == "darwin" {
+		files[errorLogFile] = darwinErrorLogPath
+		files[stdoutLogFile] = darwinStdoutLogPath
+	}
+	return files
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = in.Close()
+	}()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func pruneStagedLogs(root string, maxAge time.Duration) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || !entry.IsDir() || time.Since(info.ModTime()) < maxAge {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(root, entry.Name())); err != nil {
+			log.Warnf("failed to remove staged logs %s: %v", entry.Name(), err)
+		}
+	}
+}
+
+// stagedLog returns the staged copy of a log, if the bundle was created with staged logs.
+func (g *BundleGenerator) stagedLog(targetName string) ([]byte, bool) {
+	if g.stagedLogsDir == "" {
+		return nil, false
+	}
+	data, err := os.ReadFile(filepath.Join(g.stagedLogsDir, targetName))
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			log.Warnf("failed to read staged log %s: %v", targetName, err)
+		}
+		return nil, false
+	}
+	return data, true
+}
+
+// mergeStagedLog merges the staged copy of a log with its current content. Staged lines are
+// only kept if they are older than the first dated line of the current log, so lines in both
+// appear once. Lines without a timestamp belong to the preceding dated line. If neither can be
+// dated, the staged copy is kept unless the current log still starts with it.
+func mergeStagedLog(staged, current []byte) []byte {
+	if len(current) == 0 {
+		return staged
+	}
+	if bytes.HasPrefix(current, staged) {
+		return current
+	}
+
+	older := staged
+	if first, ok := firstLogTimestamp(current); ok {
+		older = logLinesBefore(staged, first)
+	}
+	if len(older) == 0 {
+		return current
+	}
+
+	merged := make([]byte, 0, len(older)+len(stagedLogsMarker)+len(current)+1)
+	merged = append(merged, older...)
+	if older[len(older)-1] != '\n' {
+		merged = append(merged, '\n')
+	}
+	merged = append(merged, stagedLogsMarker...)
+	return append(merged, current...)
+}
+
+func firstLogTimestamp(data []byte) (time.Time, bool) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if t, ok := logTimestamp(scanner.Bytes()); ok {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// logLinesBefore returns the lines written before t. Lines before the first timestamp cannot be
+// dated and are kept.
+func logLinesBefore(data []byte, t time.Time) []byte {
+	var out bytes.Buffer
+	keep := true
+	for len(data) > 0 {
+		line := data
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			line = data[:i+1]
+		}
+		data = data[len(line):]
+
+		if ts, ok := logTimestamp(line); ok {
+			keep = ts.Before(t)
+		}
+		if keep {
+			out.Write(line)
+		}
+	}
+	return out.Bytes()
+}
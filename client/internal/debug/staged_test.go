@@ -0,0 +1,83 @@
+package debug
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeStagedLog(t *testing.T) {
+	staged := strings.Join([]string{
+		"2025-01-01T10:00:00.000Z INFO client/internal/engine.go:100: before restart",
+		"stack line",
+		"2025-01-01T10:05:00.000Z INFO client/internal/engine.go:110: still in both",
+		"",
+	}, "\n")
+
+	tests := []struct {
+		name    string
+		staged  string
+		current string
+		want    string
+	}{
+		{
+			name:    "current log grew",
+			staged:  staged,
+			current: staged + "2025-01-01T10:06:00.000Z INFO client/internal/engine.go:120: new\n",
+			want:    staged + "2025-01-01T10:06:00.000Z INFO client/internal/engine.go:120: new\n",
+		},
+		{
+			name:    "current log restarted",
+			staged:  staged,
+			current: "2025-01-01T10:05:00.000Z INFO client/internal/engine.go:110: still in both\n",
+			want: "2025-01-01T10:00:00.000Z INFO client/internal/engine.go:100: before restart\nstack line\n" +
+				stagedLogsMarker +
+				"2025-01-01T10:05:00.000Z INFO client/internal/engine.go:110: still in both\n",
+		},
+		{
+			name:    "current log missing",
+			staged:  staged,
+			current: "",
+			want:    staged,
+		},
+		{
+			name:    "undated logs",
+			staged:  "panic: old\n",
+			current: "panic: new\n",
+			want:    "panic: old\n" + stagedLogsMarker + "panic: new\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, string(mergeStagedLog([]byte(tt.staged), []byte(tt.current))))
+		})
+	}
+}
+
+func TestStageLogs(t *testing.T) {
+	logDir := t.TempDir()
+	logPath := filepath.Join(logDir, clientLogFile)
+	require.NoError(t, os.WriteFile(logPath, []byte("staged\n"), 0o600))
+
+	root := t.TempDir()
+	id, files, err := StageLogs(root, logPath)
+	require.NoError(t, err)
+	assert.Contains(t, files, clientLogFile)
+
+	dir, err := StagedLogsDir(root, id)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(logPath, []byte("current\n"), 0o600))
+
+	g := &BundleGenerator{stagedLogsDir: dir}
+	staged, ok := g.stagedLog(clientLogFile)
+	require.True(t, ok)
+	assert.Equal(t, "staged\n", string(staged))
+
+	_, err = StagedLogsDir(root, "../"+id)
+	assert.Error(t, err)
+}
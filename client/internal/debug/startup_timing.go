@@ -0,0 +1,58 @@
+package debug
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netbirdio/netbird/client/internal/startuptiming"
+)
+
+// FormatStartupTiming renders the startup phases as an aligned table and names the slowest phase.
+func FormatStartupTiming(s startuptiming.Snapshot) string {
+	if s.StartedAt.IsZero() {
+		return "No startup recorded since the daemon started.\n"
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("Startup began: %s\n", s.StartedAt.UTC().Format(time.RFC3339)))
+	if s.Complete {
+		builder.WriteString(fmt.Sprintf("Status: complete after %s\n\n", s.Total.Round(time.Millisecond)))
+	} else {
+		builder.WriteString(fmt.Sprintf("Status: in progress for %s\n\n", s.Total.Round(time.Millisecond)))
+	}
+
+	if len(s.Phases) == 0 {
+		builder.WriteString("No phases recorded yet.\n")
+		return builder.String()
+	}
+
+	builder.WriteString(fmt.Sprintf("%-20s %7s %12s  %s\n", "Phase", "Attempt", "Duration", "Error"))
+	var slowest startuptiming.Phase
+	for _, p := range s.Phases {
+		builder.WriteString(fmt.Sprintf("%-20s %7d %12s  %s\n", p.Name, p.Attempt, p.Duration.Round(time.Millisecond), p.Err))
+		if p.Duration > slowest.Duration {
+			slowest = p
+		}
+	}
+
+	if slowest.Name != "" {
+		builder.WriteString(fmt.Sprintf("\nSlowest phase: %s (%s)\n", slowest.Name, slowest.Duration.Round(time.Millisecond)))
+	}
+	return builder.String()
+}
+
+func (g *BundleGenerator) addStartupTiming() error {
+	if g.startupTiming == nil {
+		log.Debug("skipping startup timing in debug bundle: no recorder provided")
+		return nil
+	}
+
+	content := FormatStartupTiming(g.startupTiming.Snapshot())
+	if err := g.addFileToZip(strings.NewReader(content), "startup_timing.txt"); err != nil {
+		return fmt.Errorf("add startup timing file to zip: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package debug
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// System information collectors selectable with --system-info.
+const (
+	SystemInfoOS                = "os"
+	SystemInfoCPU               = "cpu"
+	SystemInfoMem               = "mem"
+	SystemInfoDisk              = "disk"
+	SystemInfoEnv               = "env"
+	SystemInfoNetworkInterfaces = "network-interfaces"
+	SystemInfoRoutes            = "routes"
+	SystemInfoIPRules           = "ip-rules"
+	SystemInfoFirewall          = "firewall"
+	SystemInfoSysctls           = "sysctls"
+	SystemInfoDNS               = "dns"
+)
+
+// SystemInfoCollector describes a collector for "netbird debug bundle --list-system-info".
+type SystemInfoCollector struct {
+	Name        string
+	Files       string
+	Description string
+}
+
+// systemInfoCollectors lists the collectors in the order they run.
+var systemInfoCollectors = []SystemInfoCollector{
+	{SystemInfoOS, "os.txt", "Operating system, kernel, virtualization, hostname and uptime"},
+	{SystemInfoCPU, "cpu.txt", "CPU model, core counts, utilization and load averages"},
+	{SystemInfoMem, "mem.txt", "Physical memory and swap usage"},
+	{SystemInfoDisk, "disk.txt", "Free space of the file systems holding the config, state, log and temp directories"},
+	{SystemInfoEnv, "env.txt", "Environment variables of the daemon, values of secret-looking names and token-like values are masked"},
+	{SystemInfoNetworkInterfaces, "interfaces.txt", "Network interfaces with their addresses"},
+	{SystemInfoRoutes, "routes.txt", "System routing table"},
+	{SystemInfoIPRules, "ip_rules.txt", "IP routing rules (Linux only)"},
+	{SystemInfoFirewall, "iptables.txt, ip6tables.txt, ipset.txt, nftables.txt", "Host firewall rules with packet counters (Linux only)"},
+	{SystemInfoSysctls, "sysctls.txt", "Forwarding, reverse path filter and conntrack sysctls (Linux only)"},
+	{SystemInfoDNS, "resolv.conf, scutil_dns.txt", "Host DNS resolver configuration"},
+}
+
+// SystemInfoCollectors returns the available system information collectors.
+func SystemInfoCollectors() []SystemInfoCollector {
+	return append([]SystemInfoCollector(nil), systemInfoCollectors...)
+}
+
+// SystemInfoSet is the set of system information collectors to run. An empty set collects no
+// system information.
+type SystemInfoSet map[string]struct{}
+
+// AllSystemInfo returns the set of all collectors, the default of --system-info.
+func AllSystemInfo() SystemInfoSet {
+	set := make(SystemInfoSet, len(systemInfoCollectors))
+	for _, c := range systemInfoCollectors {
+		set[c.Name] = struct{}{}
+	}
+	return set
+}
+
+// ParseSystemInfo parses a --system-info value: "true" or "all" selects every collector, "false",
+// "none" or an empty value none, otherwise a comma-separated list of collector names.
+func ParseSystemInfo(value string) (SystemInfoSet, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true", "all":
+		return AllSystemInfo(), nil
+	case "false", "none", "":
+		return SystemInfoSet{}, nil
+	}
+	return NewSystemInfoSet(strings.Split(value, ","))
+}
+
+// NewSystemInfoSet returns the set of the named collectors. Unknown names fail.
+func NewSystemInfoSet(names []string) (SystemInfoSet, error) {
+	all := AllSystemInfo()
+	set := make(SystemInfoSet, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if _, ok := all[name]; !ok {
+			return nil, fmt.Errorf("unknown system info collector %q, see --list-system-info", name)
+		}
+		set[name] = struct{}{}
+	}
+	return set, nil
+}
+
+// Has reports whether the collector is in the set.
+func (s SystemInfoSet) Has(name string) bool {
+	_, ok := s[name]
+	return ok
+}
+
+// Names returns the collectors of the set in the order they run.
+func (s SystemInfoSet) Names() []string {
+	var names []string
+	for _, c := range systemInfoCollectors {
+		if s.Has(c.Name) {
+			names = append(names, c.Name)
+		}
+	}
+	return names
+}
+
+func (g *BundleGenerator) addSystemInfo() {
+	steps := []struct {
+		collector string
+		name      string
+		add       func() error
+	}{
+		{SystemInfoOS, "OS info", g.addOSInfo},
+		{SystemInfoCPU, "CPU info", g.addCPUInfo},
+		{SystemInfoMem, "memory info", g.addMemInfo},
+		{SystemInfoDisk, "disk info", g.addDiskInfo},
+		{SystemInfoEnv, "environment", g.addEnvironment},
+		{SystemInfoRoutes, "routes", g.addRoutes},
+		{SystemInfoNetworkInterfaces, "interfaces", g.addInterfaces},
+		{SystemInfoIPRules, "IP rules", g.addIPRules},
+		{SystemInfoFirewall, "firewall rules", g.addFirewallRules},
+		{SystemInfoSysctls, "sysctls", g.addSysctls},
+		{SystemInfoDNS, "DNS info", g.addDNSInfo},
+	}
+	for _, step := range steps {
+		if !g.includeSystemInfo.Has(step.collector) {
+			continue
+		}
+		if err := step.add(); err != nil {
+			log.Errorf("failed to add %s to debug bundle: %v", step.name, err)
+		}
+	}
+}
+
+// tokenLikeValue matches values that look like credentials: JWTs, long base64 or hex strings
+// and URLs with a password.
+var tokenLikeValue = regexp.MustCompile(`^eyJ[\w-]+\.[\w-]+\.[\w-]*$|^[A-Za-z0-9+/_\-=.]{24,}$|://[^/\s:@]+:[^/\s@]+@`)
+
+// redactEnvValue masks the value of a variable with a secret-looking name or a token-like value.
+func redactEnvValue(key, value string) (string, bool) {
+	if value == "" {
+		return value, false
+	}
+	if isSensitiveEnvVar(key) || looksLikeToken(value) {
+		return maskedValue, true
+	}
+	return value, false
+}
+
+// looksLikeToken reports whether a value looks like a credential. Long values without digits or
+// without letters, like paths and version lists, are not treated as tokens.
+func looksLikeToken(value string) bool {
+	if !tokenLikeValue.MatchString(value) {
+		return false
+	}
+	if strings.Contains(value, "://") || strings.HasPrefix(value, "eyJ") {
+		return true
+	}
+	return strings.ContainsAny(value, "0123456789") && strings.IndexFunc(value, isLetter) >= 0
+}
+
+func isLetter(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+}
+
+// addEnvironment writes the environment of the daemon with secrets masked. Values are also
+// anonymized if requested.
+func (g *BundleGenerator) addEnvironment() error {
+	env := os.Environ()
+	sort.Strings(env)
+
+	var sb strings.Builder
+	var masked int
+	for _, kv := range env {
+		key, value, _ := strings.Cut(kv, "=")
+		value, redacted := redactEnvValue(key, value)
+		if redacted {
+			masked++
+		} else if g.anonymize {
+			value = g.anonymizer.AnonymizeString(value)
+		}
+		sb.WriteString(fmt.Sprintf("%s=%s\n", key, value))
+	}
+	sb.WriteString(fmt.Sprintf("\n%d of %d values masked as %s\n", masked, len(env), maskedValue))
+
+	return g.addFileToZip(strings.NewReader(sb.String()), "env.txt")
+}
+
+// formatBytes formats a size with IEC units, e.g. 1.5 GiB.
+func formatBytes(b uint64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+	div, exp := uint64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(b)/float64(div), "KMGTPE"[exp])
+}
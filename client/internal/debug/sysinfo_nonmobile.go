@@ -0,0 +1,144 @@
+//go:build !ios && !android
+
+package debug
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/cpu"
+	"github.com/shirou/gopsutil/v4/disk"
+	"github.com/shirou/gopsutil/v4/host"
+	"github.com/shirou/gopsutil/v4/load"
+	"github.com/shirou/gopsutil/v4/mem"
+
+	"github.com/netbirdio/netbird/client/configs"
+	"github.com/netbirdio/netbird/client/internal/profilemanager"
+)
+
+// sysInfoTimeout bounds each system information query.
+const sysInfoTimeout = 10 * time.Second
+
+func (g *BundleGenerator) addOSInfo() error {
+	ctx, cancel := context.WithTimeout(context.Background(), sysInfoTimeout)
+	defer cancel()
+
+	info, err := host.InfoWithContext(ctx)
+	if err != nil {
+		return fmt.Errorf("get host info: %w", err)
+	}
+
+	hostname := info.Hostname
+	if g.anonymize {
+		hostname = g.anonymizer.AnonymizeDomain(hostname)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("OS: %s/%s\n", runtime.GOOS, runtime.GOARCH))
+	sb.WriteString(fmt.Sprintf("Platform: %s %s (%s)\n", info.Platform, info.PlatformVersion, info.PlatformFamily))
+	sb.WriteString(fmt.Sprintf("Kernel: %s (%s)\n", info.KernelVersion, info.KernelArch))
+	if info.VirtualizationSystem != "" {
+		sb.WriteString(fmt.Sprintf("Virtualization: %s (%s)\n", info.VirtualizationSystem, info.VirtualizationRole))
+	}
+	sb.WriteString(fmt.Sprintf("Hostname: %s\n", hostname))
+	sb.WriteString(fmt.Sprintf("Boot time: %s\n", time.Unix(int64(info.BootTime), 0).UTC().Format(time.RFC3339)))
+	sb.WriteString(fmt.Sprintf("Uptime: %s\n", (time.Duration(info.Uptime) * time.Second).String()))
+	sb.WriteString(fmt.Sprintf("Processes: %d\n", info.Procs))
+
+	return g.addFileToZip(strings.NewReader(sb.String()), "os.txt")
+}
+
+func (g *BundleGenerator) addCPUInfo() error {
+	ctx, cancel := context.WithTimeout(context.Background(), sysInfoTimeout)
+	defer cancel()
+
+	var sb strings.Builder
+	if infos, err := cpu.InfoWithContext(ctx); err != nil {
+		sb.WriteString(fmt.Sprintf("Model: unavailable: %v\n", err))
+	} else if len(infos) > 0 {
+		sb.WriteString(fmt.Sprintf("Model: %s (%.0f MHz)\n", infos[0].ModelName, infos[0].Mhz))
+	}
+	if physical, err := cpu.CountsWithContext(ctx, false); err == nil {
+		sb.WriteString(fmt.Sprintf("Physical cores: %d\n", physical))
+	}
+	if logical, err := cpu.CountsWithContext(ctx, true); err == nil {
+		sb.WriteString(fmt.Sprintf("Logical cores: %d\n", logical))
+	}
+	sb.WriteString(fmt.Sprintf("GOMAXPROCS: %d\n", runtime.GOMAXPROCS(0)))
+
+	if percent, err := cpu.PercentWithContext(ctx, time.Second, false); err != nil {
+		sb.WriteString(fmt.Sprintf("Utilization: unavailable: %v\n", err))
+	} else if len(percent) > 0 {
+		sb.WriteString(fmt.Sprintf("Utilization: %.1f%% (1s sample)\n", percent[0]))
+	}
+	if runtime.GOOS != "windows" {
+		if avg, err := load.AvgWithContext(ctx); err == nil {
+			sb.WriteString(fmt.Sprintf("Load average: %.2f %.2f %.2f\n", avg.Load1, avg.Load5, avg.Load15))
+		}
+	}
+
+	return g.addFileToZip(strings.NewReader(sb.String()), "cpu.txt")
+}
+
+func (g *BundleGenerator) addMemInfo() error {
+	ctx, cancel := context.WithTimeout(context.Background(), sysInfoTimeout)
+	defer cancel()
+
+	vm, err := mem.VirtualMemoryWithContext(ctx)
+	if err != nil {
+		return fmt.Errorf("get memory info: %w", err)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Total: %s\n", formatBytes(vm.Total)))
+	sb.WriteString(fmt.Sprintf("Available: %s\n", formatBytes(vm.Available)))
+	sb.WriteString(fmt.Sprintf("Used: %s (%.1f%%)\n", formatBytes(vm.Used), vm.UsedPercent))
+	if swap, err := mem.SwapMemoryWithContext(ctx); err == nil {
+		sb.WriteString(fmt.Sprintf("Swap total: %s\n", formatBytes(swap.Total)))
+		sb.WriteString(fmt.Sprintf("Swap used: %s (%.1f%%)\n", formatBytes(swap.Used), swap.UsedPercent))
+	}
+
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	sb.WriteString(fmt.Sprintf("\nDaemon heap in use: %s\n", formatBytes(ms.HeapInuse)))
+	sb.WriteString(fmt.Sprintf("Daemon memory from the OS: %s\n", formatBytes(ms.Sys)))
+
+	return g.addFileToZip(strings.NewReader(sb.String()), "mem.txt")
+}
+
+// addDiskInfo writes the usage of the file systems holding the directories NetBird writes to.
+// A full disk breaks config and state writes and log rotation.
+func (g *BundleGenerator) addDiskInfo() error {
+	ctx, cancel := context.WithTimeout(context.Background(), sysInfoTimeout)
+	defer cancel()
+
+	dirs := []struct{ name, path string }{
+		{"config", profilemanager.DefaultConfigPathDir},
+		{"state", configs.StateDir},
+		{"temp", os.TempDir()},
+	}
+	if filepath.IsAbs(g.logPath) {
+		dirs = append(dirs, struct{ name, path string }{"log", filepath.Dir(g.logPath)})
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%-8s %-12s %10s %10s %6s  %s\n", "Dir", "File system", "Total", "Free", "Used", "Path"))
+	for _, d := range dirs {
+		if d.path == "" {
+			continue
+		}
+		usage, err := disk.UsageWithContext(ctx, d.path)
+		if err != nil {
+			sb.WriteString(fmt.Sprintf("%-8s %s: %v\n", d.name, d.path, err))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("%-8s %-12s %10s %10s %5.1f%%  %s\n", d.name, usage.Fstype, formatBytes(usage.Total), formatBytes(usage.Free), usage.UsedPercent, d.path))
+	}
+
+	return g.addFileToZip(strings.NewReader(sb.String()), "disk.txt")
+}
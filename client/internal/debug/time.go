@@ -0,0 +1,73 @@
+package debug
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const timeFile = "time.txt"
+
+// ClockSkewWarningThreshold is the management clock skew above which time.txt and debug info
+// warn. Certificates and tokens are rejected long before, but login and relay auth start failing
+// with a skew of minutes.
+const ClockSkewWarningThreshold = 30 * time.Second
+
+// ClockSkewSource provides the clock skew to the management server measured by the daemon.
+type ClockSkewSource interface {
+	// ManagementClockSkew returns how far the local clock is ahead of the management server and
+	// when it was measured. ok is false if no skew was measured yet.
+	ManagementClockSkew() (offset time.Duration, measuredAt time.Time, ok bool)
+}
+
+// ClockSkewWarning returns a warning for a clock offset above ClockSkewWarningThreshold, empty
+// otherwise.
+func ClockSkewWarning(offset time.Duration) string {
+	if offset.Abs() <= ClockSkewWarningThreshold {
+		return ""
+	}
+	direction := "ahead of"
+	if offset < 0 {
+		direction = "behind"
+	}
+	return fmt.Sprintf("the local clock is %s %s the management server, authentication may fail", offset.Abs().Round(time.Second), direction)
+}
+
+// addTime writes the local time, the time zone, the NTP status of the system and the clock skew
+// to the management server.
+func (g *BundleGenerator) addTime() error {
+	now := time.Now()
+	zone, zoneOffset := now.Zone()
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Local time: %s\n", now.Format(time.RFC3339Nano)))
+	sb.WriteString(fmt.Sprintf("UTC time: %s\n", now.UTC().Format(time.RFC3339Nano)))
+	sb.WriteString(fmt.Sprintf("Time zone: %s (%s, UTC%+.1fh)\n\n", time.Local.String(), zone, float64(zoneOffset)/3600))
+
+	sb.WriteString("Management clock skew:\n")
+	sb.WriteString(formatClockSkew(g.clockSkew))
+
+	sb.WriteString("\nTime synchronization:\n")
+	sb.WriteString(timeSyncReport())
+
+	return g.addFileToZip(strings.NewReader(sb.String()), timeFile)
+}
+
+func formatClockSkew(source ClockSkewSource) string {
+	if source == nil {
+		return "  not available, the client is not running\n"
+	}
+	offset, measuredAt, ok := source.ManagementClockSkew()
+	if !ok {
+		return "  not measured, the management server did not send its time yet\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("  Offset: %s (positive: local clock ahead)\n", offset.Round(time.Millisecond)))
+	sb.WriteString(fmt.Sprintf("  Measured at: %s\n", measuredAt.Format(time.RFC3339)))
+	sb.WriteString("  Accuracy: about 1s, from the Date header of the management server\n")
+	if warning := ClockSkewWarning(offset); warning != "" {
+		sb.WriteString(fmt.Sprintf("  WARNING: %s\n", warning))
+	}
+	return sb.String()
+}
@@ -0,0 +1,37 @@
+package debug
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClockSkew struct {
+	offset time.Duration
+	ok     bool
+}
+
+func (f fakeClockSkew) ManagementClockSkew() (time.Duration, time.Time, bool) {
+	return f.offset, time.Now(), f.ok
+}
+
+func TestClockSkewWarning(t *testing.T) {
+	assert.Empty(t, ClockSkewWarning(0))
+	assert.Empty(t, ClockSkewWarning(-ClockSkewWarningThreshold))
+	assert.Contains(t, ClockSkewWarning(2*time.Minute), "2m0s ahead of")
+	assert.Contains(t, ClockSkewWarning(-90*time.Second), "1m30s behind")
+}
+
+func TestFormatClockSkew(t *testing.T) {
+	assert.Contains(t, formatClockSkew(nil), "not available")
+	assert.Contains(t, formatClockSkew(fakeClockSkew{}), "not measured")
+
+	out := formatClockSkew(fakeClockSkew{offset: 1500 * time.Millisecond, ok: true})
+	assert.Contains(t, out, "Offset: 1.5s")
+	assert.False(t, strings.Contains(out, "WARNING"))
+
+	out = formatClockSkew(fakeClockSkew{offset: -5 * time.Minute, ok: true})
+	assert.Contains(t, out, "WARNING: the local clock is 5m0s behind")
+}
@@ -0,0 +1,29 @@
+package debug
+
+import (
+	"fmt"
+	"strings"
+)
+
+const tracerouteFile = "traceroute.txt"
+
+// MaxTracerouteOutput caps the traceroute output sent with a bundle request.
+const MaxTracerouteOutput = 256 << 10
+
+// addTraceroute writes the traceroutes of "netbird debug bundle --trace-infra". The anonymizer
+// maps the hop addresses like the addresses in the logs, so a hop keeps its replacement across
+// the files of the bundle.
+func (g *BundleGenerator) addTraceroute() error {
+	if len(g.traceroute) == 0 {
+		return nil
+	}
+
+	content := string(g.traceroute)
+	if g.anonymize {
+		content = g.anonymizer.AnonymizeString(content)
+	}
+	if err := g.addFileToZip(strings.NewReader(content), tracerouteFile); err != nil {
+		return fmt.Errorf("add traceroute file to zip: %w", err)
+	}
+	return nil
+}
@@ -4,49 +4,165 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	neturl "net/url"
 	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	log "github.com/sirupsen/logrus"
 
 	"github.com/netbirdio/netbird/upload-server/types"
 )
 
 const maxBundleUploadSize = 50 * 1024 * 1024
 
+const (
+	// DefaultUploadTimeout bounds a single upload attempt when no timeout is requested.
+	DefaultUploadTimeout = 5 * time.Minute
+	// DefaultUploadRetries is the number of retries after a failed upload attempt.
+	DefaultUploadRetries = 1
+	// uploadURLCheckTimeout bounds CheckUploadURL.
+	uploadURLCheckTimeout = 10 * time.Second
+)
+
+// UploadOptions bounds a debug bundle upload. The zero value makes a single attempt
+// without a timeout.
+type UploadOptions struct {
+	// Timeout bounds each attempt, including the presigned URL request. Zero means no timeout.
+	Timeout time.Duration
+	// Retries is the number of attempts after the first failed one. Retries back off
+	// exponentially, starting at one second.
+	Retries uint32
+}
+
+// UploadRejectedError is returned when the upload server refuses a bundle because of a rate
+// limit or a storage quota. Retrying right away does not help, so it is not retried.
+type UploadRejectedError struct {
+	StatusCode int
+	Reason     string
+	// RetryAfter is the wait the server asked for in its Retry-After header, zero if it sent none.
+	RetryAfter time.Duration
+}
+
+func (e *UploadRejectedError) Error() string {
+	msg := "upload rejected: " + e.Reason
+	if e.RetryAfter > 0 {
+		msg += fmt.Sprintf(", retry after %s", e.RetryAfter)
+	}
+	return fmt.Sprintf("%s (status %d)", msg, e.StatusCode)
+}
+
+// UploadResult is a completed debug bundle upload.
+type UploadResult struct {
+	Key string
+	// ExpiresAt is when the server deletes the bundle, zero when the server does not report it.
+	ExpiresAt time.Time
+}
+
+// UploadDebugBundle uploads the bundle at filePath and returns its key. url is either the
+// upload-server endpoint that hands out presigned URLs or an s3://bucket/prefix URL, which
+// uploads directly with the standard AWS credential resolution.
 func UploadDebugBundle(ctx context.Context, url, managementURL, filePath string) (key string, err error) {
+	result, err := UploadDebugBundleWithOptions(ctx, url, managementURL, filePath, UploadOptions{})
+	return result.Key, err
+}
+
+// UploadDebugBundleWithOptions is UploadDebugBundle with a timeout per attempt and retries. It
+// also returns the expiry of the bundle when the server reports one. The returned error states
+// how many attempts were made.
+func UploadDebugBundleWithOptions(ctx context.Context, url, managementURL, filePath string, opts UploadOptions) (UploadResult, error) {
+	var result UploadResult
+	attempts := 0
+	operation := func() error {
+		attempts++
+		attemptCtx := ctx
+		if opts.Timeout > 0 {
+			var cancel context.CancelFunc
+			attemptCtx, cancel = context.WithTimeout(ctx, opts.Timeout)
+			defer cancel()
+		}
+
+		var err error
+		result, err = uploadDebugBundle(attemptCtx, url, managementURL, filePath)
+		if err != nil && errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+			return fmt.Errorf("attempt timed out after %s: %w", opts.Timeout, err)
+		}
+		return err
+	}
+
+	b := &backoff.ExponentialBackOff{
+		InitialInterval:     time.Second,
+		RandomizationFactor: backoff.DefaultRandomizationFactor,
+		Multiplier:          2,
+		MaxInterval:         30 * time.Second,
+		Stop:                backoff.Stop,
+		Clock:               backoff.SystemClock,
+	}
+	b.Reset()
+
+	err := backoff.RetryNotify(operation,
+		backoff.WithContext(backoff.WithMaxRetries(b, uint64(opts.Retries)), ctx),
+		func(err error, duration time.Duration) {
+			log.Warnf("debug bundle upload attempt %d failed, retrying in %s: %v", attempts, duration.Round(time.Millisecond), err)
+		},
+	)
+	if err != nil {
+		return UploadResult{}, fmt.Errorf("%w (%d of %d attempts made)", err, attempts, opts.Retries+1)
+	}
+	return result, nil
+}
+
+func uploadDebugBundle(ctx context.Context, url, managementURL, filePath string) (UploadResult, error) {
+	if bucket, prefix, ok, err := parseS3URL(url); ok {
+		if err != nil {
+			return UploadResult{}, backoff.Permanent(err)
+		}
+		return uploadToS3(ctx, bucket, prefix, managementURL, filePath)
+	}
+
 	response, err := getUploadURL(ctx, url, managementURL)
 	if err != nil {
-		return "", err
+		return UploadResult{}, err
 	}
 
-	err = upload(ctx, filePath, response)
+	expiresAt, err := upload(ctx, filePath, response)
 	if err != nil {
-		return "", err
+		return UploadResult{}, err
+	}
+	if response.ExpiresAt != nil {
+		expiresAt = *response.ExpiresAt
 	}
-	return response.Key, nil
+	return UploadResult{Key: response.Key, ExpiresAt: expiresAt}, nil
 }
 
-func upload(ctx context.Context, filePath string, response *types.GetURLResponse) error {
+// upload puts the bundle to the presigned URL of response. It returns the expiry the storage
+// reports in the x-amz-expiration header of S3 lifecycle rules, zero when there is none.
+func upload(ctx context.Context, filePath string, response *types.GetURLResponse) (time.Time, error) {
 	fileData, err := os.Open(filePath)
 	if err != nil {
-		return fmt.Errorf("open file: %w", err)
+		return time.Time{}, backoff.Permanent(fmt.Errorf("open file: %w", err))
 	}
 
 	defer fileData.Close()
 
 	stat, err := fileData.Stat()
 	if err != nil {
-		return fmt.Errorf("stat file: %w", err)
+		return time.Time{}, backoff.Permanent(fmt.Errorf("stat file: %w", err))
 	}
 
 	if stat.Size() > maxBundleUploadSize {
-		return fmt.Errorf("file size exceeds maximum limit of %d bytes", maxBundleUploadSize)
+		return time.Time{}, backoff.Permanent(fmt.Errorf("file size exceeds maximum limit of %d bytes", maxBundleUploadSize))
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "PUT", response.URL, fileData)
 	if err != nil {
-		return fmt.Errorf("create PUT request: %w", err)
+		return time.Time{}, fmt.Errorf("create PUT request: %w", err)
 	}
 
 	req.ContentLength = stat.Size()
@@ -54,15 +170,33 @@ func upload(ctx context.Context, filePath string, response *types.GetURLResponse
 
 	putResp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("upload failed: %v", err)
+		return time.Time{}, fmt.Errorf("upload failed: %v", err)
 	}
 	defer putResp.Body.Close()
 
 	if putResp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(putResp.Body)
-		return fmt.Errorf("upload status %d: %s", putResp.StatusCode, string(body))
+		return time.Time{}, uploadStatusError("upload", putResp)
 	}
-	return nil
+	return parseAmzExpiration(putResp.Header.Get("x-amz-expiration")), nil
+}
+
+// parseAmzExpiration returns the expiry date of an S3 x-amz-expiration value like
+// expiry-date="Fri, 23 Dec 2012 00:00:00 GMT", rule-id="rule". It is zero when the value has none.
+func parseAmzExpiration(value string) time.Time {
+	const field = `expiry-date="`
+	i := strings.Index(value, field)
+	if i < 0 {
+		return time.Time{}
+	}
+	date, _, ok := strings.Cut(value[i+len(field):], `"`)
+	if !ok {
+		return time.Time{}
+	}
+	expiresAt, err := http.ParseTime(date)
+	if err != nil {
+		return time.Time{}
+	}
+	return expiresAt
 }
 
 func getUploadURL(ctx context.Context, url string, managementURL string) (*types.GetURLResponse, error) {
@@ -81,8 +215,7 @@ func getUploadURL(ctx context.Context, url string, managementURL string) (*types
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("get presigned URL status %d: %s", resp.StatusCode, string(body))
+		return nil, uploadStatusError("get presigned URL", resp)
 	}
 
 	urlBytes, err := io.ReadAll(resp.Body)
@@ -96,6 +229,133 @@ func getUploadURL(ctx context.Context, url string, managementURL string) (*types
 	return &response, nil
 }
 
+// uploadStatusError turns an unexpected response into an error. Rate limit and quota rejections
+// become a permanent UploadRejectedError.
+func uploadStatusError(stage string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+
+	if reason := uploadRejectionReason(resp.StatusCode, string(body)); reason != "" {
+		return backoff.Permanent(&UploadRejectedError{
+			StatusCode: resp.StatusCode,
+			Reason:     reason,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		})
+	}
+	return fmt.Errorf("%s status %d: %s", stage, resp.StatusCode, string(body))
+}
+
+// uploadRejectionReason recognizes HTTP 429 and 4xx responses that mention a quota, e.g. the
+// QuotaExceeded error of S3 compatible storage. It returns an empty string for other responses.
+func uploadRejectionReason(statusCode int, body string) string {
+	quota := strings.Contains(strings.ToLower(body), "quota")
+	switch {
+	case statusCode < 400 || statusCode >= 500:
+		return ""
+	case quota:
+		return "quota exceeded"
+	case statusCode == http.StatusTooManyRequests:
+		return "rate limit exceeded"
+	case statusCode == http.StatusRequestEntityTooLarge:
+		return "bundle too large for the upload server"
+	default:
+		return ""
+	}
+}
+
+// parseRetryAfter parses a Retry-After header in seconds or as an HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date).Round(time.Second); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
+
+// NormalizeUploadURL trims an upload URL, adds https:// when the scheme is missing and the
+// upload-server path when the path is empty. s3:// URLs are only checked for a bucket.
+func NormalizeUploadURL(rawURL string) (string, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if _, _, ok, err := parseS3URL(rawURL); ok {
+		return rawURL, err
+	}
+
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+	u, err := neturl.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid upload URL %q: %w", rawURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("invalid upload URL %q: unsupported scheme %q, use https, http or s3", rawURL, u.Scheme)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("invalid upload URL %q: missing host", rawURL)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = types.GetURLPath
+	}
+	return u.String(), nil
+}
+
+// CheckUploadURL checks that an upload server answers at a normalized upload URL, so a wrong
+// URL fails before a bundle is generated. s3:// URLs are not checked.
+func CheckUploadURL(ctx context.Context, uploadURL string) error {
+	if _, _, ok, _ := parseS3URL(uploadURL); ok {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uploadURLCheckTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, uploadURL, nil)
+	if err != nil {
+		return fmt.Errorf("create HEAD request: %w", err)
+	}
+	req.Header.Set(types.ClientHeader, types.ClientHeaderValue)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("upload server %s is not reachable: %w", uploadURL, err)
+	}
+	defer resp.Body.Close()
+
+	// the upload-url endpoint only accepts GET, any other answer than 404 shows it exists
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("upload server %s has no upload endpoint at %s, the default path is %s", uploadURL, resp.Request.URL.Path, types.GetURLPath)
+	case resp.StatusCode >= 500:
+		return fmt.Errorf("upload server %s answered with status %d", uploadURL, resp.StatusCode)
+	}
+	return nil
+}
+
+// IsS3UploadURL reports whether an upload URL puts the bundle into an S3 bucket with the
+// credentials of the daemon.
+func IsS3UploadURL(rawURL string) bool {
+	_, _, ok, _ := parseS3URL(strings.TrimSpace(rawURL))
+	return ok
+}
+
+// parseS3URL splits an s3://bucket/prefix URL. ok is false for other schemes.
+func parseS3URL(rawURL string) (bucket, prefix string, ok bool, err error) {
+	u, err := neturl.Parse(rawURL)
+	if err != nil || u.Scheme != "s3" {
+		return "", "", false, nil
+	}
+	if u.Host == "" {
+		return "", "", true, fmt.Errorf("invalid S3 URL %q: missing bucket", rawURL)
+	}
+	return u.Host, strings.Trim(u.Path, "/"), true, nil
+}
+
 func getURLHash(url string) string {
 	return fmt.Sprintf("%x", sha256.Sum256([]byte(url)))
 }
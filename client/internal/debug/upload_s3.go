@@ -0,0 +1,67 @@
+//go:build !android && !ios
+
+package debug
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/google/uuid"
+)
+
+// defaultS3Region is used when neither the environment nor the shared config name a region.
+// S3-compatible stores like MinIO accept any region.
+const defaultS3Region = "us-east-1"
+
+// uploadToS3 puts the bundle into the bucket of an s3://bucket/prefix URL. Credentials, region and
+// endpoint are resolved the standard AWS way: environment variables (including AWS_ENDPOINT_URL_S3
+// for S3-compatible stores) and the shared config and credentials files. The daemon only accepts
+// s3:// URLs from root callers and its own service flags, these credentials are not for every user.
+func uploadToS3(ctx context.Context, bucket, prefix, managementURL, filePath string) (UploadResult, error) {
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return UploadResult{}, fmt.Errorf("load AWS config: %w", err)
+	}
+	if cfg.Region == "" {
+		cfg.Region = defaultS3Region
+	}
+	if _, err := cfg.Credentials.Retrieve(ctx); err != nil {
+		return UploadResult{}, fmt.Errorf("resolve AWS credentials: %w", err)
+	}
+
+	client := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		// custom endpoints are S3-compatible stores, which rarely support virtual-hosted buckets
+		if o.BaseEndpoint != nil {
+			o.UsePathStyle = true
+		}
+	})
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return UploadResult{}, fmt.Errorf("open file: %w", err)
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return UploadResult{}, fmt.Errorf("stat file: %w", err)
+	}
+
+	key := path.Join(prefix, getURLHash(managementURL), uuid.New().String())
+	out, err := client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(bucket),
+		Key:           aws.String(key),
+		Body:          file,
+		ContentLength: aws.Int64(stat.Size()),
+		ContentType:   aws.String("application/octet-stream"),
+	})
+	if err != nil {
+		return UploadResult{}, fmt.Errorf("put s3://%s/%s: %w", bucket, key, err)
+	}
+	return UploadResult{Key: key, ExpiresAt: parseAmzExpiration(aws.ToString(out.Expiration))}, nil
+}
@@ -0,0 +1,12 @@
+//go:build ios || android
+
+package debug
+
+import (
+	"context"
+	"errors"
+)
+
+func uploadToS3(context.Context, string, string, string, string) (UploadResult, error) {
+	return UploadResult{}, errors.New("uploading to S3 is not supported on this platform")
+}
@@ -2,11 +2,14 @@ package debug
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -79,3 +82,226 @@ func waitForServer(t *testing.T, addr string) {
 	}
 	t.Fatalf("server did not start listening on %s in time", addr)
 }
+
+func TestParseS3URL(t *testing.T) {
+	tests := []struct {
+		url    string
+		bucket string
+		prefix string
+		ok     bool
+		err    bool
+	}{
+		{url: "s3://bundles/netbird/debug/", bucket: "bundles", prefix: "netbird/debug", ok: true},
+		{url: "s3://bundles", bucket: "bundles", ok: true},
+		{url: "s3:///prefix", ok: true, err: true},
+		{url: types.DefaultBundleURL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			bucket, prefix, ok, err := parseS3URL(tt.url)
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.err, err != nil)
+			require.Equal(t, tt.bucket, bucket)
+			require.Equal(t, tt.prefix, prefix)
+			require.Equal(t, tt.ok, IsS3UploadURL(" "+tt.url))
+		})
+	}
+}
+
+func TestUploadDebugBundleWithOptionsRetries(t *testing.T) {
+	var urlRequests atomic.Int32
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	mux.HandleFunc(types.GetURLPath, func(w http.ResponseWriter, r *http.Request) {
+		if urlRequests.Add(1) == 1 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(types.GetURLResponse{URL: srv.URL + "/put", Key: "key"})
+	})
+	mux.HandleFunc("/put", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	file := filepath.Join(t.TempDir(), "bundle.zip")
+	require.NoError(t, os.WriteFile(file, []byte("bundle"), 0600))
+
+	_, err := UploadDebugBundleWithOptions(context.Background(), srv.URL+types.GetURLPath, srv.URL, file, UploadOptions{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "1 of 1 attempts")
+
+	urlRequests.Store(0)
+	result, err := UploadDebugBundleWithOptions(context.Background(), srv.URL+types.GetURLPath, srv.URL, file, UploadOptions{Retries: 1})
+	require.NoError(t, err)
+	require.Equal(t, "key", result.Key)
+	require.True(t, result.ExpiresAt.IsZero())
+	require.Equal(t, int32(2), urlRequests.Load())
+}
+
+func TestUploadDebugBundleExpiry(t *testing.T) {
+	reported := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	lifecycle := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		expiresAt *time.Time
+		header    string
+		want      time.Time
+	}{
+		{name: "not reported"},
+		{name: "reported by the upload server", expiresAt: &reported, want: reported},
+		{name: "s3 lifecycle rule", header: `expiry-date="Sun, 01 Feb 2026 00:00:00 GMT", rule-id="debug-bundles"`, want: lifecycle},
+		{name: "upload server wins", expiresAt: &reported, header: `expiry-date="Sun, 01 Feb 2026 00:00:00 GMT", rule-id="debug-bundles"`, want: reported},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			srv := httptest.NewServer(mux)
+			t.Cleanup(srv.Close)
+			mux.HandleFunc(types.GetURLPath, func(w http.ResponseWriter, r *http.Request) {
+				_ = json.NewEncoder(w).Encode(types.GetURLResponse{URL: srv.URL + "/put", Key: "key", ExpiresAt: tt.expiresAt})
+			})
+			mux.HandleFunc("/put", func(w http.ResponseWriter, r *http.Request) {
+				if tt.header != "" {
+					w.Header().Set("x-amz-expiration", tt.header)
+				}
+				w.WriteHeader(http.StatusOK)
+			})
+
+			file := filepath.Join(t.TempDir(), "bundle.zip")
+			require.NoError(t, os.WriteFile(file, []byte("bundle"), 0600))
+
+			result, err := UploadDebugBundleWithOptions(context.Background(), srv.URL+types.GetURLPath, srv.URL, file, UploadOptions{})
+			require.NoError(t, err)
+			require.Equal(t, "key", result.Key)
+			require.True(t, tt.want.Equal(result.ExpiresAt), "got %s, want %s", result.ExpiresAt, tt.want)
+		})
+	}
+}
+
+func TestParseAmzExpiration(t *testing.T) {
+	require.True(t, parseAmzExpiration("").IsZero())
+	require.True(t, parseAmzExpiration(`rule-id="debug-bundles"`).IsZero())
+	require.True(t, parseAmzExpiration(`expiry-date="not a date", rule-id="debug-bundles"`).IsZero())
+	require.Equal(t, time.Date(2012, 12, 23, 0, 0, 0, 0, time.UTC), parseAmzExpiration(`expiry-date="Fri, 23 Dec 2012 00:00:00 GMT", rule-id="picture-deletion-rule"`))
+}
+
+func TestUploadDebugBundleWithOptionsTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	t.Cleanup(srv.Close)
+
+	file := filepath.Join(t.TempDir(), "bundle.zip")
+	require.NoError(t, os.WriteFile(file, []byte("bundle"), 0600))
+
+	_, err := UploadDebugBundleWithOptions(context.Background(), srv.URL+types.GetURLPath, srv.URL, file, UploadOptions{Timeout: 100 * time.Millisecond})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "timed out")
+}
+
+func TestUploadDebugBundleRejected(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		retryAfter string
+		want       string
+	}{
+		{name: "rate limit", status: http.StatusTooManyRequests, body: "slow down", retryAfter: "30", want: "upload rejected: rate limit exceeded, retry after 30s (status 429)"},
+		{name: "quota", status: http.StatusForbidden, body: "daily upload quota reached", want: "upload rejected: quota exceeded (status 403)"},
+		{name: "quota on 429", status: http.StatusTooManyRequests, body: "quota exceeded", retryAfter: "90", want: "upload rejected: quota exceeded, retry after 1m30s (status 429)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				http.Error(w, tt.body, tt.status)
+			}))
+			t.Cleanup(srv.Close)
+
+			file := filepath.Join(t.TempDir(), "bundle.zip")
+			require.NoError(t, os.WriteFile(file, []byte("bundle"), 0600))
+
+			_, err := UploadDebugBundleWithOptions(context.Background(), srv.URL+types.GetURLPath, srv.URL, file, UploadOptions{Retries: 2})
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.want)
+
+			var rejected *UploadRejectedError
+			require.ErrorAs(t, err, &rejected)
+			require.Equal(t, tt.status, rejected.StatusCode)
+			require.Equal(t, int32(1), requests.Load(), "rejections are not retried")
+		})
+	}
+}
+
+func TestUploadDebugBundleServerErrorNotRejected(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "not found", http.StatusNotFound)
+	}))
+	t.Cleanup(srv.Close)
+
+	file := filepath.Join(t.TempDir(), "bundle.zip")
+	require.NoError(t, os.WriteFile(file, []byte("bundle"), 0600))
+
+	_, err := UploadDebugBundleWithOptions(context.Background(), srv.URL+types.GetURLPath, srv.URL, file, UploadOptions{})
+	require.Error(t, err)
+	var rejected *UploadRejectedError
+	require.False(t, errors.As(err, &rejected))
+	require.Contains(t, err.Error(), "status 404")
+}
+
+func TestNormalizeUploadURL(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		err  bool
+	}{
+		{in: types.DefaultBundleURL, want: types.DefaultBundleURL},
+		{in: " upload.example.com ", want: "https://upload.example.com" + types.GetURLPath},
+		{in: "http://10.0.0.1:8080/", want: "http://10.0.0.1:8080" + types.GetURLPath},
+		{in: "https://upload.example.com/custom", want: "https://upload.example.com/custom"},
+		{in: "s3://bundles/prefix", want: "s3://bundles/prefix"},
+		{in: "s3:///prefix", err: true},
+		{in: "ftp://upload.example.com", err: true},
+		{in: "https://", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := NormalizeUploadURL(tt.in)
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCheckUploadURL(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(types.GetURLPath, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	require.NoError(t, CheckUploadURL(context.Background(), srv.URL+types.GetURLPath))
+
+	err := CheckUploadURL(context.Background(), srv.URL+"/upload-ulr")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no upload endpoint")
+
+	addr := reserveLoopbackPort(t)
+	err = CheckUploadURL(context.Background(), "http://"+addr+types.GetURLPath)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not reachable")
+
+	require.NoError(t, CheckUploadURL(context.Background(), "s3://bundles"))
+}
@@ -0,0 +1,192 @@
+package debug
+
+import (
+	"archive/zip"
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// BundleVerification is the result of VerifyBundle.
+type BundleVerification struct {
+	// HasManifest is false for bundles created before manifest.json was added. Only Files is
+	// set then.
+	HasManifest   bool
+	GeneratedAt   time.Time
+	DaemonVersion string
+	CliVersion    string
+	Anonymized    bool
+	// AnonymizeLevel is empty for bundles created before the level was recorded.
+	AnonymizeLevel string
+	// Files lists all files in the bundle.
+	Files []string
+	// Missing lists files named in the manifest or SHA256SUMS that are not in the bundle.
+	Missing []string
+	// Mismatched lists files whose size or checksum differs from the manifest or SHA256SUMS.
+	Mismatched []string
+	// Unlisted lists files in the bundle that the manifest doesn't cover.
+	Unlisted []string
+}
+
+// OK reports whether the bundle matches its manifest and checksums.
+func (v *BundleVerification) OK() bool {
+	return len(v.Missing) == 0 && len(v.Mismatched) == 0 && len(v.Unlisted) == 0
+}
+
+// VerifyBundle checks a debug bundle zip against its manifest.json and SHA256SUMS.
+// Encrypted bundles have to be decrypted first.
+func VerifyBundle(path string) (*BundleVerification, error) {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("open bundle (encrypted bundles must be decrypted first): %w", err)
+	}
+	defer func() {
+		_ = archive.Close()
+	}()
+	registerZstdDecompressor(&archive.Reader)
+
+	result := &BundleVerification{}
+	actual := make(map[string]manifestFile, len(archive.File))
+	for _, f := range archive.File {
+		sum, size, err := hashZipFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", f.Name, err)
+		}
+		actual[f.Name] = manifestFile{Name: f.Name, Size: size, SHA256: sum}
+		result.Files = append(result.Files, f.Name)
+	}
+
+	if _, ok := actual[manifestFileName]; !ok {
+		return result, nil
+	}
+	result.HasManifest = true
+
+	manifest, err := readBundleManifest(&archive.Reader)
+	if err != nil {
+		return nil, err
+	}
+	result.GeneratedAt = manifest.GeneratedAt
+	result.DaemonVersion = manifest.DaemonVersion
+	result.CliVersion = manifest.CliVersion
+	result.Anonymized = manifest.Anonymized
+	result.AnonymizeLevel = string(manifest.AnonymizeLevel)
+
+	missing := map[string]struct{}{}
+	mismatched := map[string]struct{}{}
+	check := func(name string, matches func(manifestFile) bool) {
+		f, ok := actual[name]
+		if !ok {
+			missing[name] = struct{}{}
+		} else if !matches(f) {
+			mismatched[name] = struct{}{}
+		}
+	}
+
+	listed := map[string]struct{}{manifestFileName: {}, checksumsFile: {}}
+	for _, want := range manifest.Files {
+		listed[want.Name] = struct{}{}
+		check(want.Name, func(f manifestFile) bool {
+			return f.Size == want.Size && f.SHA256 == want.SHA256
+		})
+	}
+
+	sums, err := readBundleChecksums(&archive.Reader)
+	if err != nil {
+		return nil, err
+	}
+	if sums == nil {
+		missing[checksumsFile] = struct{}{}
+	}
+	for name, sum := range sums {
+		check(name, func(f manifestFile) bool {
+			return f.SHA256 == sum
+		})
+	}
+	if _, ok := sums[manifestFileName]; sums != nil && !ok {
+		// only SHA256SUMS covers the manifest, so a manifest missing from it can't be trusted
+		mismatched[manifestFileName] = struct{}{}
+	}
+
+	for name := range actual {
+		if _, ok := listed[name]; !ok {
+			result.Unlisted = append(result.Unlisted, name)
+		}
+	}
+	result.Missing = sortedKeys(missing)
+	result.Mismatched = sortedKeys(mismatched)
+	sort.Strings(result.Unlisted)
+	return result, nil
+}
+
+func hashZipFile(f *zip.File) (string, int64, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return "", 0, err
+	}
+	defer func() {
+		_ = rc.Close()
+	}()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, rc)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), size, nil
+}
+
+func readBundleManifest(archive *zip.Reader) (*bundleManifest, error) {
+	rc, err := archive.Open(manifestFileName)
+	if err != nil {
+		return nil, fmt.Errorf("open manifest: %w", err)
+	}
+	defer func() {
+		_ = rc.Close()
+	}()
+
+	var manifest bundleManifest
+	if err := json.NewDecoder(rc).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("parse manifest: %w", err)
+	}
+	return &manifest, nil
+}
+
+// readBundleChecksums parses SHA256SUMS. It returns nil if the file is not in the bundle.
+func readBundleChecksums(archive *zip.Reader) (map[string]string, error) {
+	rc, err := archive.Open(checksumsFile)
+	if err != nil {
+		return nil, nil //nolint:nilerr // a missing file is reported by the caller
+	}
+	defer func() {
+		_ = rc.Close()
+	}()
+
+	sums := map[string]string{}
+	scanner := bufio.NewScanner(rc)
+	for scanner.Scan() {
+		sum, name, ok := strings.Cut(scanner.Text(), "  ")
+		if !ok {
+			return nil, fmt.Errorf("parse %s: malformed line %q", checksumsFile, scanner.Text())
+		}
+		sums[name] = sum
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read %s: %w", checksumsFile, err)
+	}
+	return sums, nil
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,93 @@
+package debug
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestBundle(t *testing.T, files map[string]string, tamper func(g *BundleGenerator)) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "bundle.zip")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, f.Close())
+	}()
+
+	g := NewBundleGenerator(GeneratorDependencies{DaemonVersion: "0.1.0"}, BundleConfig{Anonymize: true})
+	g.archive = zip.NewWriter(f)
+	for name, content := range files {
+		require.NoError(t, g.addFileToZip(strings.NewReader(content), name))
+	}
+	require.NoError(t, g.addManifest())
+	require.NoError(t, g.addChecksums())
+	if tamper != nil {
+		tamper(g)
+	}
+	require.NoError(t, g.archive.Close())
+	return path
+}
+
+func TestVerifyBundle(t *testing.T) {
+	files := map[string]string{"status.txt": "status", "client.log": "log line\n"}
+
+	result, err := VerifyBundle(writeTestBundle(t, files, nil))
+	require.NoError(t, err)
+	assert.True(t, result.HasManifest)
+	assert.True(t, result.OK())
+	assert.True(t, result.Anonymized)
+	assert.Equal(t, "0.1.0", result.DaemonVersion)
+	assert.Len(t, result.Files, 4)
+
+	result, err = VerifyBundle(writeTestBundle(t, files, func(g *BundleGenerator) {
+		require.NoError(t, g.addFileToZip(strings.NewReader("extra"), "extra.txt"))
+	}))
+	require.NoError(t, err)
+	assert.False(t, result.OK())
+	assert.Equal(t, []string{"extra.txt"}, result.Unlisted)
+}
+
+func TestVerifyBundleMismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bundle.zip")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+
+	g := NewBundleGenerator(GeneratorDependencies{}, BundleConfig{})
+	g.archive = zip.NewWriter(f)
+	require.NoError(t, g.addFileToZip(strings.NewReader("status"), "status.txt"))
+	g.files = append(g.files, manifestFile{Name: "client.log", Size: 1, SHA256: "00"})
+	g.files[0].SHA256 = "00"
+	require.NoError(t, g.addManifest())
+	require.NoError(t, g.archive.Close())
+	require.NoError(t, f.Close())
+
+	result, err := VerifyBundle(path)
+	require.NoError(t, err)
+	assert.False(t, result.OK())
+	assert.Equal(t, []string{"SHA256SUMS", "client.log"}, result.Missing)
+	assert.Equal(t, []string{"status.txt"}, result.Mismatched)
+}
+
+func TestVerifyBundleWithoutManifest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bundle.zip")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	w := zip.NewWriter(f)
+	_, err = w.Create("status.txt")
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+
+	result, err := VerifyBundle(path)
+	require.NoError(t, err)
+	assert.False(t, result.HasManifest)
+	assert.True(t, result.OK())
+	assert.Equal(t, []string{"status.txt"}, result.Files)
+}
@@ -0,0 +1,53 @@
+package debug
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/netbirdio/netbird/client/iface"
+)
+
+const wireGuardImplFile = "wireguard-impl.txt"
+
+// WireGuardImplSource provides the WireGuard implementation of the interface.
+type WireGuardImplSource interface {
+	WireGuardImplementation() (iface.Implementation, bool)
+}
+
+// FormatWireGuardImpl renders the WireGuard implementation as text.
+func FormatWireGuardImpl(impl iface.Implementation) string {
+	mode := impl.Mode
+	switch impl.Mode {
+	case iface.ImplKernel:
+		mode = "kernel module"
+	case iface.ImplUserspace:
+		mode = "userspace (wireguard-go)"
+	case iface.ImplNetstack:
+		mode = "userspace (wireguard-go on the gVisor netstack)"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Implementation: %s\n", mode))
+	sb.WriteString(fmt.Sprintf("Version: %s\n", orUnknown(impl.Version)))
+	sb.WriteString(fmt.Sprintf("Interface: %s\n", orUnknown(impl.InterfaceName)))
+	sb.WriteString(fmt.Sprintf("Interface type: %s\n", orUnknown(impl.InterfaceType)))
+	if impl.Mode != iface.ImplKernel {
+		sb.WriteString(fmt.Sprintf("TUN driver: %s\n", orUnknown(impl.TunDriver)))
+	}
+	return sb.String()
+}
+
+func (g *BundleGenerator) addWireGuardImpl() error {
+	if g.wireGuardImpl == nil {
+		return nil
+	}
+	impl, ok := g.wireGuardImpl.WireGuardImplementation()
+	if !ok {
+		return nil
+	}
+
+	if err := g.addFileToZip(strings.NewReader(FormatWireGuardImpl(impl)), wireGuardImplFile); err != nil {
+		return fmt.Errorf("add %s to zip: %w", wireGuardImplFile, err)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package debug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netbirdio/netbird/client/iface"
+)
+
+func TestFormatWireGuardImpl(t *testing.T) {
+	kernel := FormatWireGuardImpl(iface.Implementation{
+		Mode:          iface.ImplKernel,
+		Version:       "wireguard module 1.0.0, kernel 6.8.0",
+		InterfaceName: "wt0",
+		InterfaceType: "wireguard",
+	})
+	assert.Equal(t, "Implementation: kernel module\n"+
+		"Version: wireguard module 1.0.0, kernel 6.8.0\n"+
+		"Interface: wt0\n"+
+		"Interface type: wireguard\n", kernel)
+
+	userspace := FormatWireGuardImpl(iface.Implementation{
+		Mode:          iface.ImplUserspace,
+		Version:       "github.com/netbirdio/wireguard-go v0.0.0",
+		InterfaceName: "utun100",
+		InterfaceType: "utun",
+	})
+	assert.Contains(t, userspace, "Implementation: userspace (wireguard-go)\n")
+	assert.Contains(t, userspace, "TUN driver: -\n", "unknown driver")
+}
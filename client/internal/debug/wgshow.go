@@ -25,7 +25,7 @@ func (g *BundleGenerator) addWgShow() error {
 	output := g.toWGShowFormat(result)
 	reader := bytes.NewReader([]byte(output))
 
-	if err := g.addFileToZip(reader, "wgshow.txt"); err != nil {
+	if err := g.addFileToZip(reader, "wireguard.txt"); err != nil {
 		return fmt.Errorf("add wg show to zip: %w", err)
 	}
 	return nil
@@ -54,6 +54,10 @@ func (g *BundleGenerator) toWGShowFormat(s *configurer.Stats) string {
 		if len(peer.AllowedIPs) > 0 {
 			var ipStrings []string
 			for _, ipnet := range peer.AllowedIPs {
+				if g.anonymize {
+					ipStrings = append(ipStrings, g.anonymizer.AnonymizeRoute(ipnet.String()))
+					continue
+				}
 				ipStrings = append(ipStrings, ipnet.String())
 			}
 			sb.WriteString(fmt.Sprintf("  allowed ips: %s\n", strings.Join(ipStrings, ", ")))
@@ -0,0 +1,58 @@
+package dns
+
+import (
+	"net/netip"
+	"slices"
+
+	nbdns "github.com/netbirdio/netbird/dns"
+)
+
+// DebugState is a snapshot of the DNS server configuration and query statistics, used by debug
+// bundles.
+type DebugState struct {
+	ServerIP   netip.Addr
+	ServerPort int
+	// HostManager names the platform integration that configures the host resolver,
+	// e.g. file, systemd, networkManager or noop.
+	HostManager string
+	// RouteAll is set when a primary nameserver group handles all queries.
+	RouteAll bool
+	// Domains are the match and search domains applied to the host.
+	Domains []DomainConfig
+	// NameServerGroups are the upstream nameservers per domain as received from management.
+	NameServerGroups []*nbdns.NameServerGroup
+	// CustomZones are the NetBird-managed zones answered by the local resolver.
+	CustomZones []nbdns.CustomZone
+	// HostNameservers are the host resolvers used as fallback, as captured before NetBird
+	// configured the host DNS.
+	HostNameservers []netip.Addr
+	// UpstreamHealth is the last success and failure of each upstream.
+	UpstreamHealth map[netip.AddrPort]UpstreamHealth
+	// QueryStats are the recent query failures and the cache counters.
+	QueryStats QueryStats
+}
+
+// DebugState returns the current DNS configuration and query statistics.
+func (s *DefaultServer) DebugState() DebugState {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	state := DebugState{
+		ServerIP:         s.currentConfig.ServerIP,
+		ServerPort:       s.currentConfig.ServerPort,
+		RouteAll:         s.currentConfig.RouteAll,
+		Domains:          slices.Clone(s.currentConfig.Domains),
+		NameServerGroups: slices.Clone(s.nsGroups),
+		CustomZones:      slices.Clone(s.customZones),
+		UpstreamHealth:   s.collectUpstreamHealth(),
+		QueryStats:       s.queryFailures.stats(),
+	}
+	if s.mgmtCacheResolver != nil {
+		state.QueryStats.Cache = s.mgmtCacheResolver.Stats()
+	}
+	if s.hostManager != nil {
+		state.HostManager = s.hostManager.string()
+		state.HostNameservers = s.hostManager.getOriginalNameservers()
+	}
+	return state
+}
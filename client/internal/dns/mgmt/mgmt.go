@@ -78,6 +78,27 @@ type Resolver struct {
 	refreshing map[dns.Question]*atomic.Bool
 
 	cacheTTL time.Duration
+
+	hits            atomic.Uint64
+	staleHits       atomic.Uint64
+	misses          atomic.Uint64
+	refreshes       atomic.Uint64
+	refreshFailures atomic.Uint64
+}
+
+// CacheStats are the counters of the cache since the resolver was created.
+type CacheStats struct {
+	// Entries is the number of cached questions.
+	Entries int
+	// Hits counts the A/AAAA queries answered from the cache, StaleHits those answered past
+	// the TTL while a refresh runs.
+	Hits      uint64
+	StaleHits uint64
+	// Misses counts the A/AAAA queries for a cached domain without records of the type.
+	Misses uint64
+	// Refreshes and RefreshFailures count the refreshes of stale entries.
+	Refreshes       uint64
+	RefreshFailures uint64
 }
 
 // NewResolver creates a new management domains cache resolver.
@@ -125,19 +146,25 @@ func (m *Resolver) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 	m.mutex.RLock()
 	cached, found := m.records[question]
 	inflight := m.refreshing[question]
-	var shouldRefresh bool
+	var shouldRefresh, stale bool
 	if found {
-		stale := time.Since(cached.cachedAt) > m.cacheTTL
+		stale = time.Since(cached.cachedAt) > m.cacheTTL
 		inBackoff := !cached.lastFailedRefresh.IsZero() && time.Since(cached.lastFailedRefresh) < refreshBackoff
 		shouldRefresh = stale && !inBackoff
 	}
 	m.mutex.RUnlock()
 
 	if !found {
+		m.misses.Add(1)
 		m.continueToNext(w, r)
 		return
 	}
 
+	m.hits.Add(1)
+	if stale {
+		m.staleHits.Add(1)
+	}
+
 	if inflight != nil && inflight.CompareAndSwap(false, true) {
 		log.Warnf("mgmt cache: possible resolver loop for domain=%s: served stale while an OS-fallback refresh was inflight (if NetBird is the system resolver, the OS-path predicate is wrong)",
 			question.Name)
@@ -253,14 +280,17 @@ func (m *Resolver) refreshQuestion(question dns.Question, expected *cachedRecord
 	ctx, cancel := context.WithTimeout(context.Background(), dnsTimeout)
 	defer cancel()
 
+	m.refreshes.Add(1)
 	d, err := domain.FromString(strings.TrimSuffix(question.Name, "."))
 	if err != nil {
+		m.refreshFailures.Add(1)
 		m.markRefreshFailed(question, expected)
 		return fmt.Errorf("parse domain: %w", err)
 	}
 
 	records, err := m.lookupRecords(ctx, d, question)
 	if err != nil {
+		m.refreshFailures.Add(1)
 		fails := m.markRefreshFailed(question, expected)
 		logf := log.Warnf
 		if fails == 0 || fails > 1 {
@@ -303,6 +333,22 @@ func (m *Resolver) refreshQuestion(question dns.Question, expected *cachedRecord
 	return nil
 }
 
+// Stats returns the cache counters.
+func (m *Resolver) Stats() CacheStats {
+	m.mutex.RLock()
+	entries := len(m.records)
+	m.mutex.RUnlock()
+
+	return CacheStats{
+		Entries:         entries,
+		Hits:            m.hits.Load(),
+		StaleHits:       m.staleHits.Load(),
+		Misses:          m.misses.Load(),
+		Refreshes:       m.refreshes.Load(),
+		RefreshFailures: m.refreshFailures.Load(),
+	}
+}
+
 func (m *Resolver) markRefreshing(question dns.Question) {
 	m.mutex.Lock()
 	m.refreshing[question] = &atomic.Bool{}
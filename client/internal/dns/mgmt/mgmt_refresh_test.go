@@ -309,6 +309,41 @@ func TestResolver_RefreshFailureArmsBackoff(t *testing.T) {
 	assert.Equal(t, 1, chain.callCount("mgmt.example.com.", dns.TypeA), "backoff must suppress further refreshes")
 }
 
+func TestResolver_Stats(t *testing.T) {
+	r := NewResolver()
+	chain := newFakeChain()
+	chain.err = errors.New("boom")
+	r.SetChainResolver(chain, 50)
+
+	q := dns.Question{Name: "mgmt.example.com.", Qtype: dns.TypeA, Qclass: dns.ClassINET}
+	r.records[q] = &cachedRecord{
+		records: []dns.RR{&dns.A{
+			Hdr: dns.RR_Header{Name: q.Name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 60},
+			A:   net.ParseIP("10.0.0.1").To4(),
+		}},
+		cachedAt: time.Now().Add(-2 * defaultTTL),
+	}
+
+	queryA(t, r, "mgmt.example.com.")
+	waitFor(t, time.Second, func() bool {
+		return r.Stats().RefreshFailures == 1
+	})
+	queryA(t, r, "mgmt.example.com.")
+
+	msg := new(dns.Msg)
+	msg.SetQuestion("mgmt.example.com.", dns.TypeAAAA)
+	r.ServeDNS(&test.MockResponseWriter{}, msg)
+
+	assert.Equal(t, CacheStats{
+		Entries:         1,
+		Hits:            2,
+		StaleHits:       2,
+		Misses:          1,
+		Refreshes:       1,
+		RefreshFailures: 1,
+	}, r.Stats())
+}
+
 func TestResolver_NoRootHandler_SkipsChain(t *testing.T) {
 	r := NewResolver()
 	chain := newFakeChain()
@@ -0,0 +1,139 @@
+package dns
+
+import (
+	"net/netip"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+
+	"github.com/netbirdio/netbird/client/internal/dns/mgmt"
+)
+
+// maxQueryFailures bounds the recent query failures kept for debug bundles.
+const maxQueryFailures = 50
+
+// QueryFailureKind tells a misconfigured or unreachable upstream apart from a name that does
+// not resolve.
+type QueryFailureKind int
+
+const (
+	// QueryFailureUpstream means no upstream answered: every upstream tried timed out, was
+	// unreachable or returned SERVFAIL or REFUSED.
+	QueryFailureUpstream QueryFailureKind = iota
+	// QueryFailureName means an upstream answered that the name does not exist (NXDOMAIN) or
+	// failed it for a reason every upstream would share, like a DNSSEC validation failure.
+	QueryFailureName
+)
+
+func (k QueryFailureKind) String() string {
+	if k == QueryFailureName {
+		return "name"
+	}
+	return "upstream"
+}
+
+// QueryAttempt is the outcome of one upstream tried for a failed query.
+type QueryAttempt struct {
+	Upstream netip.AddrPort
+	Err      string
+}
+
+// QueryFailure is a query forwarded to the upstreams that was not answered with records.
+type QueryFailure struct {
+	Time     time.Time
+	Name     string
+	Type     uint16
+	Kind     QueryFailureKind
+	Attempts []QueryAttempt
+}
+
+// QueryStats are the query failures and cache counters since the DNS server started.
+type QueryStats struct {
+	// Failures are the most recent failures, oldest first, at most maxQueryFailures.
+	Failures []QueryFailure
+	// UpstreamFailures and NameFailures count all failures of the kind, including the ones
+	// no longer in Failures.
+	UpstreamFailures uint64
+	NameFailures     uint64
+	// Cache are the counters of the management domains cache.
+	Cache mgmt.CacheStats
+}
+
+// queryFailureLog keeps the most recent query failures of all upstream resolvers. A nil log
+// records nothing.
+type queryFailureLog struct {
+	mu       sync.Mutex
+	failures []QueryFailure
+	upstream uint64
+	name     uint64
+}
+
+// recordUnanswered records a query no upstream answered. Attempts canceled because the DNS
+// server stopped are left out.
+func (l *queryFailureLog) recordUnanswered(q dns.Question, failures []upstreamFailure) {
+	if l == nil {
+		return
+	}
+
+	attempts := make([]QueryAttempt, 0, len(failures))
+	for _, f := range failures {
+		if f.reason == "canceled" {
+			continue
+		}
+		attempts = append(attempts, QueryAttempt{Upstream: f.upstream, Err: f.reason})
+	}
+	if len(attempts) == 0 {
+		return
+	}
+	l.record(QueryFailure{Name: q.Name, Type: q.Qtype, Kind: QueryFailureUpstream, Attempts: attempts})
+}
+
+// recordAnswer records an upstream answer that failed the name. Answers with records or an
+// empty NOERROR answer are not failures.
+func (l *queryFailureLog) recordAnswer(rm *dns.Msg, upstream netip.AddrPort) {
+	if l == nil || rm.Rcode == dns.RcodeSuccess || len(rm.Question) == 0 {
+		return
+	}
+
+	q := rm.Question[0]
+	l.record(QueryFailure{
+		Name:     q.Name,
+		Type:     q.Qtype,
+		Kind:     QueryFailureName,
+		Attempts: []QueryAttempt{{Upstream: upstream, Err: dns.RcodeToString[rm.Rcode]}},
+	})
+}
+
+func (l *queryFailureLog) record(f QueryFailure) {
+	f.Time = time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if f.Kind == QueryFailureName {
+		l.name++
+	} else {
+		l.upstream++
+	}
+	if len(l.failures) == maxQueryFailures {
+		copy(l.failures, l.failures[1:])
+		l.failures = l.failures[:len(l.failures)-1]
+	}
+	l.failures = append(l.failures, f)
+}
+
+// stats returns a snapshot of the failures, without the cache counters.
+func (l *queryFailureLog) stats() QueryStats {
+	if l == nil {
+		return QueryStats{}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return QueryStats{
+		Failures:         slices.Clone(l.failures),
+		UpstreamFailures: l.upstream,
+		NameFailures:     l.name,
+	}
+}
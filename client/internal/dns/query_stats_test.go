@@ -0,0 +1,57 @@
+package dns
+
+import (
+	"fmt"
+	"net/netip"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryFailureLog(t *testing.T) {
+	upstream := netip.MustParseAddrPort("192.0.2.53:53")
+	l := &queryFailureLog{}
+
+	q := dns.Question{Name: "unreachable.example.com.", Qtype: dns.TypeA, Qclass: dns.ClassINET}
+	l.recordUnanswered(q, []upstreamFailure{{upstream: upstream, reason: "timeout after 2s"}})
+	l.recordUnanswered(q, []upstreamFailure{{upstream: upstream, reason: "canceled"}})
+
+	ok := new(dns.Msg)
+	ok.SetQuestion("ok.example.com.", dns.TypeA)
+	l.recordAnswer(ok, upstream)
+
+	nx := new(dns.Msg)
+	nx.SetQuestion("missing.example.com.", dns.TypeAAAA)
+	nx.Rcode = dns.RcodeNameError
+	l.recordAnswer(nx, upstream)
+
+	stats := l.stats()
+	assert.Equal(t, uint64(1), stats.UpstreamFailures, "canceled attempts are not failures")
+	assert.Equal(t, uint64(1), stats.NameFailures, "answers with NOERROR are not failures")
+	require.Len(t, stats.Failures, 2)
+	assert.Equal(t, QueryFailureUpstream, stats.Failures[0].Kind)
+	assert.Equal(t, []QueryAttempt{{Upstream: upstream, Err: "timeout after 2s"}}, stats.Failures[0].Attempts)
+	assert.Equal(t, QueryFailureName, stats.Failures[1].Kind)
+	assert.Equal(t, "missing.example.com.", stats.Failures[1].Name)
+	assert.Equal(t, dns.TypeAAAA, stats.Failures[1].Type)
+	assert.Equal(t, []QueryAttempt{{Upstream: upstream, Err: "NXDOMAIN"}}, stats.Failures[1].Attempts)
+
+	assert.Empty(t, (*queryFailureLog)(nil).stats().Failures)
+}
+
+func TestQueryFailureLogBounded(t *testing.T) {
+	upstream := netip.MustParseAddrPort("192.0.2.53:53")
+	l := &queryFailureLog{}
+	for i := range maxQueryFailures + 5 {
+		q := dns.Question{Name: fmt.Sprintf("host%d.example.com.", i), Qtype: dns.TypeA}
+		l.recordUnanswered(q, []upstreamFailure{{upstream: upstream, reason: "SERVFAIL"}})
+	}
+
+	stats := l.stats()
+	assert.Equal(t, uint64(maxQueryFailures+5), stats.UpstreamFailures)
+	require.Len(t, stats.Failures, maxQueryFailures)
+	assert.Equal(t, "host5.example.com.", stats.Failures[0].Name, "the oldest failures are dropped")
+	assert.Equal(t, fmt.Sprintf("host%d.example.com.", maxQueryFailures+4), stats.Failures[maxQueryFailures-1].Name)
+}
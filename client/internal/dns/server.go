@@ -154,6 +154,8 @@ type DefaultServer struct {
 	batchMode          bool
 
 	mgmtCacheResolver *mgmt.Resolver
+	// queryFailures records the failed queries of all upstream resolvers.
+	queryFailures *queryFailureLog
 
 	// permanent related properties
 	permanent      bool
@@ -175,7 +177,9 @@ type DefaultServer struct {
 	// activeRoutes returns the subset whose peer is in StatusConnected.
 	activeRoutes func() route.HAMap
 
-	nsGroups        []*nbdns.NameServerGroup
+	nsGroups []*nbdns.NameServerGroup
+	// customZones are the local zones of the last applied configuration.
+	customZones     []nbdns.CustomZone
 	healthProjectMu sync.Mutex
 	// nsGroupProj is the per-group state used by the emission rules.
 	// Accessed only under healthProjectMu.
@@ -300,6 +304,7 @@ func newDefaultServer(
 		hostsDNSHolder:    newHostsDNSHolder(),
 		hostManager:       &noopHostConfigurator{},
 		mgmtCacheResolver: mgmtCacheResolver,
+		queryFailures:     &queryFailureLog{},
 		currentConfigHash: ^uint64(0), // Initialize to max uint64 to ensure first config is always applied
 		warningDelayBase:  warningDelayBaseFromEnv(),
 		healthRefresh:     make(chan struct{}, 1),
@@ -665,6 +670,7 @@ func (s *DefaultServer) applyConfiguration(update nbdns.Config) error {
 	s.updateMux(muxUpdates)
 
 	s.localResolver.Update(localZones)
+	s.customZones = localZones
 
 	s.currentConfig = dnsConfigToHostDNSConfig(update, s.service.RuntimeIP(), s.service.RuntimePort())
 
@@ -818,6 +824,7 @@ func (s *DefaultServer) registerFallback() {
 		return
 	}
 	handler.selectedRoutes = s.selectedRoutes
+	handler.queryFailures = s.queryFailures
 	handler.addRace(servers)
 
 	prev := s.fallbackHandler
@@ -924,6 +931,7 @@ func (s *DefaultServer) buildMergedDomainHandler(domainGroup nsGroupsByDomain, p
 		return nil, fmt.Errorf("create upstream resolver: %v", err)
 	}
 	handler.selectedRoutes = s.selectedRoutes
+	handler.queryFailures = s.queryFailures
 
 	for _, nsGroup := range domainGroup.groups {
 		servers := s.filterNameServers(nsGroup.NameServers)
@@ -155,6 +155,9 @@ type upstreamResolverBase struct {
 
 	healthMu sync.RWMutex
 	health   map[netip.AddrPort]*UpstreamHealth
+	// queryFailures is the server-wide log of failed queries for debug bundles, nil when
+	// not recorded.
+	queryFailures *queryFailureLog
 
 	statusRecorder *peer.Status
 	// selectedRoutes returns the current set of client routes the admin
@@ -303,6 +306,7 @@ func (u *upstreamResolverBase) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 		u.logUpstreamFailures(r.Question[0].Name, failures, ok, logger)
 	}
 	if !ok {
+		u.queryFailures.recordUnanswered(r.Question[0], failures)
 		u.writeErrorResponse(w, r, logger)
 	}
 }
@@ -559,6 +563,8 @@ func (u *upstreamResolverBase) writeSuccessResponse(w dns.ResponseWriter, rm *dn
 	// manipulating our internal fallthrough signaling mechanism
 	rm.MsgHdr.Zero = false
 
+	u.queryFailures.recordAnswer(rm, upstream)
+
 	if err := w.WriteMsg(rm); err != nil {
 		logger.Errorf("failed to write DNS response for question domain=%s: %s", domain, err)
 	}
@@ -14,6 +14,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
@@ -22,6 +23,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"golang.zx2c4.com/wireguard/tun/netstack"
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+	"google.golang.org/grpc/connectivity"
 
 	nberrors "github.com/netbirdio/netbird/client/errors"
 	"github.com/netbirdio/netbird/client/firewall"
@@ -34,6 +36,7 @@ import (
 	"github.com/netbirdio/netbird/client/iface/udpmux"
 	"github.com/netbirdio/netbird/client/iface/wgaddr"
 	"github.com/netbirdio/netbird/client/internal/acl"
+	"github.com/netbirdio/netbird/client/internal/benchmark"
 	"github.com/netbirdio/netbird/client/internal/debug"
 	"github.com/netbirdio/netbird/client/internal/dns"
 	dnsconfig "github.com/netbirdio/netbird/client/internal/dns/config"
@@ -54,6 +57,7 @@ import (
 	"github.com/netbirdio/netbird/client/internal/relay"
 	"github.com/netbirdio/netbird/client/internal/rosenpass"
 	"github.com/netbirdio/netbird/client/internal/routemanager"
+	"github.com/netbirdio/netbird/client/internal/startuptiming"
 	"github.com/netbirdio/netbird/client/internal/statemanager"
 	"github.com/netbirdio/netbird/client/internal/syncstore"
 	"github.com/netbirdio/netbird/client/internal/updater"
@@ -180,6 +184,7 @@ type EngineServices struct {
 	UpdateManager  *updater.Manager
 	ClientMetrics  *metrics.ClientMetrics
 	MetricsCtx     context.Context
+	StartupTiming  *startuptiming.Recorder
 }
 
 // Engine is a mechanism responsible for reacting on Signal and Management stream events and managing connections to the remote peers.
@@ -235,6 +240,10 @@ type Engine struct {
 
 	sshServer sshServer
 
+	// benchmarkResponder answers the benchmarks of other peers, benchmarkRules accept its port.
+	benchmarkResponder *benchmark.Responder
+	benchmarkRules     []firewallManager.Rule
+
 	statusRecorder *peer.Status
 
 	firewall          firewallManager.Manager
@@ -281,6 +290,16 @@ type Engine struct {
 	clientMetrics *metrics.ClientMetrics
 	metricsCtx    context.Context
 
+	// startupTiming records the startup phases until the first network map is applied.
+	startupTiming *startuptiming.Recorder
+	// firstSyncDone ends the management sync startup phase, nil once the first network map arrived.
+	firstSyncDone func(error)
+
+	// bundlePolicy is the management-provided debug bundle policy, nil if none is set.
+	bundlePolicy atomic.Pointer[debug.BundlePolicy]
+	// bundlePolicySaved is set once bundlePolicy was written to disk for offline bundles.
+	bundlePolicySaved atomic.Bool
+
 	jobExecutor   *jobexec.Executor
 	jobExecutorWG sync.WaitGroup
 
@@ -347,6 +366,7 @@ func NewEngine(
 		jobExecutor:        jobexec.NewExecutor(),
 		clientMetrics:      services.ClientMetrics,
 		metricsCtx:         services.MetricsCtx,
+		startupTiming:      services.StartupTiming,
 		updateManager:      services.UpdateManager,
 		syncStoreDir:       config.StateDir,
 	}
@@ -416,6 +436,8 @@ func (e *Engine) stopLocked() {
 
 	e.cleanupSSHConfig()
 
+	e.stopBenchmarkResponder()
+
 	if e.ingressGatewayMgr != nil {
 		if err := e.ingressGatewayMgr.Close(); err != nil {
 			log.Warnf("failed to cleanup forward rules: %v", err)
@@ -583,6 +605,8 @@ func (e *Engine) Start(netbirdConfig *mgmProto.NetbirdConfig, mgmtURL *url.URL)
 	}
 	e.dnsServer = dnsServer
 
+	e.handleBundlePolicyUpdate(netbirdConfig.GetDebugBundlePolicy())
+
 	// Populate DNS cache with NetbirdConfig and management URL for early resolution
 	if err := e.PopulateNetbirdConfig(netbirdConfig, mgmtURL); err != nil {
 		log.Warnf("failed to populate DNS cache: %v", err)
@@ -611,7 +635,10 @@ func (e *Engine) Start(netbirdConfig *mgmProto.NetbirdConfig, mgmtURL *url.URL)
 
 	e.dnsServer.SetRouteSources(e.routeManager.GetSelectedClientRoutes, e.routeManager.GetActiveClientRoutes)
 
-	if err = e.wgInterfaceCreate(); err != nil {
+	doneStartup := e.startupTiming.Start(startuptiming.PhaseInterfaceCreate)
+	err = e.wgInterfaceCreate()
+	doneStartup(err)
+	if err != nil {
 		log.Errorf("failed creating tunnel interface %s: [%s]", e.config.WgIfaceName, err.Error())
 		return fmt.Errorf("create wg interface: %w", err)
 	}
@@ -620,7 +647,10 @@ func (e *Engine) Start(netbirdConfig *mgmProto.NetbirdConfig, mgmtURL *url.URL)
 		filteredDevice.SetPanicHandler(e.triggerClientRestart)
 	}
 
-	if err := e.createFirewall(); err != nil {
+	doneStartup = e.startupTiming.Start(startuptiming.PhaseFirewall)
+	err = e.createFirewall()
+	doneStartup(err)
+	if err != nil {
 		return err
 	}
 
@@ -656,6 +686,8 @@ func (e *Engine) Start(netbirdConfig *mgmProto.NetbirdConfig, mgmtURL *url.URL)
 		e.acl = acl.NewDefaultManager(e.firewall)
 	}
 
+	e.startBenchmarkResponder()
+
 	if err := e.dnsServer.Initialize(); err != nil {
 		return fmt.Errorf("initialize dns server: %w", err)
 	}
@@ -687,6 +719,7 @@ func (e *Engine) Start(netbirdConfig *mgmProto.NetbirdConfig, mgmtURL *url.URL)
 	if err = e.receiveSignalEvents(); err != nil {
 		return err
 	}
+	e.firstSyncDone = e.startupTiming.Start(startuptiming.PhaseManagementSync)
 	e.receiveManagementEvents()
 	e.receiveJobEvents()
 
@@ -987,6 +1020,7 @@ func (e *Engine) handleSync(update *mgmProto.SyncResponse) error {
 	if e.ctx.Err() != nil {
 		return e.ctx.Err()
 	}
+	e.statusRecorder.MarkManagementSynced()
 
 	e.ApplySessionDeadline(update.GetSessionExpiresAt())
 
@@ -1004,6 +1038,10 @@ func (e *Engine) handleSync(update *mgmProto.SyncResponse) error {
 	if err != nil {
 		return err
 	}
+	if wCfg := update.GetNetbirdConfig(); wCfg != nil {
+		e.statusRecorder.RecordStateTransition(peer.StateEventConfigApplied, fmt.Sprintf("%d STUN, %d TURN, %d relay servers",
+			len(wCfg.GetStuns()), len(wCfg.GetTurns()), len(wCfg.GetRelay().GetUrls())))
+	}
 
 	// Decode the network map from either the components envelope or the
 	// legacy proto.NetworkMap before the posture-check gating below, so the
@@ -1049,6 +1087,7 @@ func (e *Engine) handleSync(update *mgmProto.SyncResponse) error {
 	if nm == nil {
 		return nil
 	}
+	e.statusRecorder.UpdateServerCapabilities(serverCapabilities(update, nm, !e.statusRecorder.GetSessionExpiresAt().IsZero()))
 
 	done = e.phase("checks")
 	err = e.updateChecksIfNew(update.Checks)
@@ -1064,16 +1103,25 @@ func (e *Engine) handleSync(update *mgmProto.SyncResponse) error {
 	// incremental-delta base on a future envelope sync.
 	if components != nil {
 		e.latestComponents = components
+		e.statusRecorder.UpdatePeerGroups(peerGroups(components))
 	}
 
 	e.persistSyncResponse(update)
 	done()
 
+	if e.firstSyncDone != nil {
+		e.firstSyncDone(nil)
+		e.firstSyncDone = nil
+	}
+
 	// only apply new changes and ignore old ones
 	if err := e.updateNetworkMap(nm); err != nil {
 		return err
 	}
+	e.startupTiming.Complete()
 
+	e.statusRecorder.RecordStateTransition(peer.StateEventNetworkMapUpdated, fmt.Sprintf("serial %d, %d peers, %d routes",
+		nm.GetSerial(), len(nm.GetRemotePeers()), len(nm.GetRoutes())))
 	e.statusRecorder.PublishEvent(cProto.SystemEvent_INFO, cProto.SystemEvent_SYSTEM, "Network map updated", "", nil)
 
 	return nil
@@ -1092,6 +1140,24 @@ func extractDNSDomainFromFQDN(fqdn string) string {
 	return ""
 }
 
+// peerGroups maps the peer keys of the components to the sorted IDs of their groups. The All
+// group is left out, every peer is in it.
+func peerGroups(components *types.NetworkMapComponents) map[string][]string {
+	groups := make(map[string][]string)
+	for _, g := range components.Groups {
+		if g.IsGroupAll() {
+			continue
+		}
+		for _, peerKey := range g.Peers {
+			groups[peerKey] = append(groups[peerKey], g.PublicID)
+		}
+	}
+	for _, ids := range groups {
+		slices.Sort(ids)
+	}
+	return groups
+}
+
 // updateNetbirdConfig applies the management-provided NetBird configuration:
 // STUN/TURN and relay servers, flow logging and DNS settings. A nil config is a no-op,
 // which is the case for sync updates carrying only a network map.
@@ -1122,6 +1188,7 @@ func (e *Engine) updateNetbirdConfig(wCfg *mgmProto.NetbirdConfig) error {
 	}
 
 	e.handleMetricsUpdate(wCfg.GetMetrics())
+	e.handleBundlePolicyUpdate(wCfg.GetDebugBundlePolicy())
 
 	if err := e.PopulateNetbirdConfig(wCfg, nil); err != nil {
 		log.Warnf("Failed to update DNS server config: %v", err)
@@ -1200,6 +1267,32 @@ func (e *Engine) handleMetricsUpdate(config *mgmProto.MetricsConfig) {
 	e.clientMetrics.UpdatePushFromMgm(e.metricsCtx, config.GetEnabled())
 }
 
+func (e *Engine) handleBundlePolicyUpdate(config *mgmProto.DebugBundlePolicy) {
+	policy := debug.BundlePolicyFromProto(config)
+	if policy != nil {
+		log.Infof("received debug bundle policy from management: %+v", *policy)
+	}
+	old := e.bundlePolicy.Swap(policy)
+	if e.bundlePolicySaved.Load() && old.Equal(policy) {
+		return
+	}
+
+	path := debug.BundlePolicyPath()
+	if path == "" {
+		return
+	}
+	if err := debug.SaveBundlePolicy(path, policy); err != nil {
+		log.Warnf("failed to save the debug bundle policy, offline bundles don't follow it: %v", err)
+		return
+	}
+	e.bundlePolicySaved.Store(true)
+}
+
+// GetBundlePolicy returns the management-provided debug bundle policy, nil if none is set.
+func (e *Engine) GetBundlePolicy() *debug.BundlePolicy {
+	return e.bundlePolicy.Load()
+}
+
 func toFlowLoggerConfig(config *mgmProto.FlowConfig) (*nftypes.FlowConfig, error) {
 	if config.GetInterval() == nil {
 		return nil, errors.New("flow interval is nil")
@@ -1228,11 +1321,14 @@ func (e *Engine) updateChecksIfNew(checks []*mgmProto.Checks) error {
 	if !ok {
 		// Gathering timed out; skip the meta sync this cycle rather than blocking the
 		// sync loop (and syncMsgMux) on a stuck system call. A later sync will retry.
+		e.statusRecorder.UpdatePostureEvaluation(postureEvaluation(checks, nil, errPostureTimeout))
 		return nil
 	}
 	e.applyInfoFlags(info)
 
-	if err := e.mgmClient.SyncMeta(info); err != nil {
+	err := e.mgmClient.SyncMeta(info)
+	e.statusRecorder.UpdatePostureEvaluation(postureEvaluation(checks, info.Files, err))
+	if err != nil {
 		return fmt.Errorf("could not sync meta: error %s", err)
 	}
 	return nil
@@ -1371,6 +1467,7 @@ func (e *Engine) receiveJobEvents() {
 }
 
 func (e *Engine) handleBundle(params *mgmProto.BundleParameters) (*mgmProto.JobResponse_Bundle, error) {
+	receivedAt := time.Now()
 	log.Infof("handle remote debug bundle request: %s", params.String())
 	syncResponse, err := e.GetLatestSyncResponse()
 	if err != nil {
@@ -1385,14 +1482,34 @@ func (e *Engine) handleBundle(params *mgmProto.BundleParameters) (*mgmProto.JobR
 		TempDir:        e.config.TempDir,
 		ClientMetrics:  e.clientMetrics,
 		DaemonVersion:  version.NetbirdVersion(),
+		StartupTiming:  e.startupTiming,
+		Policy:         e.GetBundlePolicy(),
+		NAT:            e,
+		ClockSkew:      e,
+		ChannelStates:  e,
+		WireGuardImpl:  e,
 		RefreshStatus: func() {
 			e.RunHealthProbes(e.ctx, true)
 		},
 	}
 
+	if dropStats, ok := e.firewall.(debug.DropStatsSource); ok {
+		bundleDeps.DropStats = dropStats
+	}
+	if dnsState, ok := e.dnsServer.(debug.DNSStateSource); ok {
+		bundleDeps.DNSState = dnsState
+	}
+
+	if params.GetReferenceTime() != nil {
+		bundleDeps.ClockReference = &debug.ClockReference{
+			ReferenceTime: params.GetReferenceTime().AsTime(),
+			ReceivedAt:    receivedAt,
+		}
+	}
+
 	bundleJobParams := debug.BundleConfig{
 		Anonymize:         params.Anonymize,
-		IncludeSystemInfo: true,
+		IncludeSystemInfo: debug.AllSystemInfo(),
 		LogFileCount:      uint32(params.LogFileCount),
 	}
 
@@ -1422,6 +1539,9 @@ func (e *Engine) receiveManagementEvents() {
 			// Gathering timed out; connect the stream with base info so management
 			// connectivity still comes up rather than blocking here.
 			info = system.GetInfo(e.ctx)
+			e.statusRecorder.UpdatePostureEvaluation(postureEvaluation(e.checks, nil, errPostureTimeout))
+		} else {
+			e.statusRecorder.UpdatePostureEvaluation(postureEvaluation(e.checks, info.Files, nil))
 		}
 		e.applyInfoFlags(info)
 
@@ -1539,8 +1659,11 @@ func (e *Engine) updateNetworkMap(networkMap *mgmProto.NetworkMap) error {
 	done()
 
 	done = e.phase("routes_apply")
+	doneStartup := e.startupTiming.Start(startuptiming.PhaseRouteInstall)
 	dnsRouteFeatureFlag := toDNSFeatureFlag(networkMap)
-	if err := e.routeManager.UpdateRoutes(serial, serverRoutes, clientRoutes, dnsRouteFeatureFlag); err != nil {
+	err := e.routeManager.UpdateRoutes(serial, serverRoutes, clientRoutes, dnsRouteFeatureFlag)
+	doneStartup(err)
+	if err != nil {
 		log.Errorf("failed to update routes: %v", err)
 	}
 	done()
@@ -2236,6 +2359,93 @@ func (e *Engine) GetFirewallManager() firewallManager.Manager {
 	return e.firewall
 }
 
+// STUNMappings returns the addresses the STUN servers reported for the shared ICE socket, nil
+// before the interface is up.
+func (e *Engine) STUNMappings() []udpmux.STUNMapping {
+	if e.udpMux == nil {
+		return nil
+	}
+	return e.udpMux.STUNMappings()
+}
+
+// WireGuardImplementation describes the WireGuard implementation of the interface, false before
+// the interface is created.
+func (e *Engine) WireGuardImplementation() (iface.Implementation, bool) {
+	wgIface, ok := e.wgInterface.(interface{ Implementation() iface.Implementation })
+	if !ok {
+		return iface.Implementation{}, false
+	}
+	return wgIface.Implementation(), true
+}
+
+// EvaluateACL evaluates a flow against the ACL policy of the last network map without changing
+// the firewall.
+func (e *Engine) EvaluateACL(q acl.Query) (acl.Decision, error) {
+	evaluator, ok := e.acl.(interface {
+		Evaluate(acl.Query, ...netip.Addr) (acl.Decision, error)
+	})
+	if !ok {
+		return acl.Decision{}, errors.New("ACL manager is not running")
+	}
+	return evaluator.Evaluate(q, e.GetWgAddr(), e.GetWgV6Addr())
+}
+
+// ManagementClockSkew returns how far the local clock is ahead of the management server, as
+// measured by the management client.
+func (e *Engine) ManagementClockSkew() (time.Duration, time.Time, bool) {
+	client, ok := e.mgmClient.(interface {
+		ClockSkew() (mgm.ClockSkew, bool)
+	})
+	if !ok {
+		return 0, time.Time{}, false
+	}
+	skew, ok := client.ClockSkew()
+	return skew.Offset, skew.MeasuredAt, ok
+}
+
+// ServerChannelStates returns the gRPC channel states of the management and signal clients,
+// empty for a client that does not expose it.
+func (e *Engine) ServerChannelStates() (management, signal string) {
+	type channelStater interface {
+		ChannelState() connectivity.State
+	}
+	if c, ok := e.mgmClient.(channelStater); ok {
+		management = c.ChannelState().String()
+	}
+	if c, ok := e.signal.(channelStater); ok {
+		signal = c.ChannelState().String()
+	}
+	return management, signal
+}
+
+// ActivatePeer opens the connection to the peer if it is idle under lazy connections. It
+// returns false if the peer is unknown.
+func (e *Engine) ActivatePeer(ctx context.Context, pubKey string) bool {
+	conn, ok := e.peerStore.PeerConn(pubKey)
+	if !ok {
+		return false
+	}
+	if e.connMgr != nil {
+		e.connMgr.ActivatePeer(ctx, conn)
+	}
+	return true
+}
+
+// GetDNSServer returns the DNS server, nil before the engine started.
+func (e *Engine) GetDNSServer() dns.Server {
+	return e.dnsServer
+}
+
+// GetMTU returns the MTU of the tunnel interface.
+func (e *Engine) GetMTU() uint16 {
+	return e.config.MTU
+}
+
+// GetRelayManager returns the relay client manager.
+func (e *Engine) GetRelayManager() *relayClient.Manager {
+	return e.relayManager
+}
+
 // GetExposeManager returns the expose session manager.
 func (e *Engine) GetExposeManager() *expose.Manager {
 	e.syncMsgMux.Lock()
@@ -2464,6 +2674,18 @@ func (e *Engine) GetLatestSyncResponse() (*mgmProto.SyncResponse, error) {
 	return e.syncStore.Get()
 }
 
+// GetNetworkMapHistory returns up to n stored sync responses, newest first, if persistence is enabled.
+func (e *Engine) GetNetworkMapHistory(n int) ([]syncstore.Snapshot, error) {
+	e.syncRespMux.RLock()
+	defer e.syncRespMux.RUnlock()
+
+	if e.syncStore == nil {
+		return nil, errors.New("sync response persistence is disabled")
+	}
+
+	return e.syncStore.History(n)
+}
+
 // GetWgAddr returns the wireguard address
 func (e *Engine) GetWgAddr() netip.Addr {
 	if e.wgInterface == nil {
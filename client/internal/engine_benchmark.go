@@ -0,0 +1,66 @@
+package internal
+
+import (
+	"net"
+	"net/netip"
+
+	log "github.com/sirupsen/logrus"
+
+	firewallManager "github.com/netbirdio/netbird/client/firewall/manager"
+	"github.com/netbirdio/netbird/client/iface/netstack"
+	"github.com/netbirdio/netbird/client/internal/benchmark"
+)
+
+// startBenchmarkResponder answers the "netbird debug benchmark" runs of other peers on the overlay
+// address and accepts its port from all peers, like the rosenpass port. It is not started when
+// inbound connections are blocked or in netstack mode, where the daemon has no socket on the
+// overlay address.
+func (e *Engine) startBenchmarkResponder() {
+	if e.config.BlockInbound || netstack.IsEnabled() || e.wgInterface == nil {
+		return
+	}
+
+	addr := e.wgInterface.Address()
+	responder, err := benchmark.Listen(netip.AddrPortFrom(addr.IP, benchmark.Port), addr.Network)
+	if err != nil {
+		log.Warnf("failed to start benchmark responder: %v", err)
+		return
+	}
+	e.benchmarkResponder = responder
+
+	if e.firewall == nil {
+		return
+	}
+	port := firewallManager.Port{Values: []uint16{benchmark.Port}}
+	for _, protocol := range []firewallManager.Protocol{firewallManager.ProtocolTCP, firewallManager.ProtocolUDP} {
+		rules, err := e.firewall.AddPeerFiltering(nil, net.IP{0, 0, 0, 0}, protocol, nil, &port, firewallManager.ActionAccept, "")
+		if err != nil {
+			log.Warnf("failed to allow benchmark %s traffic: %v", protocol, err)
+			continue
+		}
+		e.benchmarkRules = append(e.benchmarkRules, rules...)
+	}
+	if err := e.firewall.Flush(); err != nil {
+		log.Warnf("failed to flush benchmark firewall rules: %v", err)
+	}
+}
+
+// stopBenchmarkResponder stops the responder and removes its firewall rules.
+func (e *Engine) stopBenchmarkResponder() {
+	if e.firewall != nil {
+		for _, rule := range e.benchmarkRules {
+			if err := e.firewall.DeletePeerRule(rule); err != nil {
+				log.Warnf("failed to remove benchmark firewall rule: %v", err)
+			}
+		}
+	}
+	e.benchmarkRules = nil
+
+	if e.benchmarkResponder == nil {
+		return
+	}
+	if err := e.benchmarkResponder.Close(); err != nil {
+		log.Warnf("failed to stop benchmark responder: %v", err)
+	}
+	e.benchmarkResponder = nil
+}
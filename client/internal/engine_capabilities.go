@@ -0,0 +1,52 @@
+package internal
+
+import (
+	"time"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+	sharedgrpc "github.com/netbirdio/netbird/shared/management/grpc"
+	mgmProto "github.com/netbirdio/netbird/shared/management/proto"
+)
+
+// serverCapabilities derives the capabilities the management server offered from a full sync
+// response and its decoded network map. sessionExpiry is set when the peer has a session deadline.
+func serverCapabilities(update *mgmProto.SyncResponse, nm *mgmProto.NetworkMap, sessionExpiry bool) peer.ServerCapabilities {
+	// Envelope sync responses carry PeerConfig at the top level, legacy ones under the network map.
+	peerConfig := update.GetPeerConfig()
+	if peerConfig == nil {
+		peerConfig = nm.GetPeerConfig()
+	}
+	netbirdConfig := update.GetNetbirdConfig()
+	flow := netbirdConfig.GetFlow()
+	flowEnabled := flow.GetEnabled()
+
+	offered := map[string]bool{
+		peer.ServerCapabilityComponentNetworkMap:      update.GetVersion() == int32(sharedgrpc.ComponentNetworkMap),
+		peer.ServerCapabilityRelay:                    len(netbirdConfig.GetRelay().GetUrls()) > 0,
+		peer.ServerCapabilityFlowLogging:              flowEnabled,
+		peer.ServerCapabilityFlowCounters:             flowEnabled && flow.GetCounters(),
+		peer.ServerCapabilityFlowExitNodeCollection:   flowEnabled && flow.GetExitNodeCollection(),
+		peer.ServerCapabilityFlowDNSCollection:        flowEnabled && flow.GetDnsCollection(),
+		peer.ServerCapabilityMetricsPush:              netbirdConfig.GetMetrics().GetEnabled(),
+		peer.ServerCapabilityPostureChecks:            len(update.GetChecks()) > 0,
+		peer.ServerCapabilityDebugBundlePolicy:        netbirdConfig.GetDebugBundlePolicy() != nil,
+		peer.ServerCapabilityLazyConnection:           peerConfig.GetLazyConnectionEnabled(),
+		peer.ServerCapabilityRoutingPeerDNSResolution: peerConfig.GetRoutingPeerDnsResolutionEnabled(),
+		peer.ServerCapabilityIPv6Overlay:              len(peerConfig.GetAddressV6()) > 0,
+		peer.ServerCapabilitySSH:                      peerConfig.GetSshConfig().GetSshEnabled(),
+		peer.ServerCapabilitySSHAuth:                  nm.GetSshAuth() != nil,
+		peer.ServerCapabilityAutoUpdate:               peerConfig.GetAutoUpdate().GetVersion() != "",
+		peer.ServerCapabilitySessionExpiry:            sessionExpiry,
+	}
+
+	caps := peer.ServerCapabilities{
+		SyncVersion: update.GetVersion(),
+		ReceivedAt:  time.Now(),
+	}
+	for _, c := range peer.KnownServerCapabilities {
+		if offered[c.Name] {
+			caps.Offered = append(caps.Offered, c.Name)
+		}
+	}
+	return caps
+}
@@ -0,0 +1,40 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+	sharedgrpc "github.com/netbirdio/netbird/shared/management/grpc"
+	mgmProto "github.com/netbirdio/netbird/shared/management/proto"
+)
+
+func TestServerCapabilities(t *testing.T) {
+	update := &mgmProto.SyncResponse{
+		Version: int32(sharedgrpc.ComponentNetworkMap),
+		NetbirdConfig: &mgmProto.NetbirdConfig{
+			Relay: &mgmProto.RelayConfig{Urls: []string{"rels://relay.example.com:443"}},
+			Flow:  &mgmProto.FlowConfig{Enabled: false, Counters: true},
+		},
+		PeerConfig: &mgmProto.PeerConfig{LazyConnectionEnabled: true},
+		Checks:     []*mgmProto.Checks{{Files: []string{"/usr/bin/agent"}}},
+	}
+	nm := &mgmProto.NetworkMap{
+		PeerConfig: &mgmProto.PeerConfig{RoutingPeerDnsResolutionEnabled: true},
+		SshAuth:    &mgmProto.SSHAuth{},
+	}
+
+	caps := serverCapabilities(update, nm, true)
+	assert.Equal(t, int32(sharedgrpc.ComponentNetworkMap), caps.SyncVersion)
+	assert.False(t, caps.ReceivedAt.IsZero())
+	// the top-level peer config wins, flow counters need flow logging
+	assert.Equal(t, []string{
+		peer.ServerCapabilityComponentNetworkMap,
+		peer.ServerCapabilityRelay,
+		peer.ServerCapabilityPostureChecks,
+		peer.ServerCapabilityLazyConnection,
+		peer.ServerCapabilitySSHAuth,
+		peer.ServerCapabilitySessionExpiry,
+	}, caps.Offered)
+}
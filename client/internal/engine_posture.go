@@ -0,0 +1,52 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+	"github.com/netbirdio/netbird/client/system"
+	mgmProto "github.com/netbirdio/netbird/shared/management/proto"
+)
+
+var (
+	errPostureTimeout     = fmt.Errorf("gathering the posture check results timed out after %s", systemInfoTimeout)
+	errPostureNotGathered = errors.New("gathering the posture check results failed, only the base system information was reported")
+)
+
+// postureEvaluation pairs the gathered process check results with the checks they were gathered
+// for. GetInfoWithChecks returns the files of all checks in order. reportErr is the error of
+// reporting the results to management.
+func postureEvaluation(checks []*mgmProto.Checks, files []system.File, reportErr error) peer.PostureEvaluation {
+	evaluation := peer.PostureEvaluation{
+		EvaluatedAt: time.Now(),
+		Err:         reportErr,
+	}
+
+	var paths int
+	for _, check := range checks {
+		paths += len(check.GetFiles())
+	}
+	if len(files) != paths {
+		files = nil
+		if evaluation.Err == nil {
+			evaluation.Err = errPostureNotGathered
+		}
+	}
+
+	for _, check := range checks {
+		var c peer.PostureCheck
+		for _, path := range check.GetFiles() {
+			process := peer.PostureProcess{Path: path}
+			if len(files) > 0 {
+				process.Exists = files[0].Exist
+				process.Running = files[0].ProcessIsRunning
+				files = files[1:]
+			}
+			c.Processes = append(c.Processes, process)
+		}
+		evaluation.Checks = append(evaluation.Checks, c)
+	}
+	return evaluation
+}
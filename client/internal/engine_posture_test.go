@@ -0,0 +1,51 @@
+package internal
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+	"github.com/netbirdio/netbird/client/system"
+	mgmProto "github.com/netbirdio/netbird/shared/management/proto"
+)
+
+func TestPostureEvaluation(t *testing.T) {
+	checks := []*mgmProto.Checks{
+		{Files: []string{"/usr/bin/agent"}},
+		{Files: []string{"/opt/edr/edr", "/opt/vpn/vpn"}},
+	}
+	files := []system.File{
+		{Path: "/usr/bin/agent", Exist: true, ProcessIsRunning: true},
+		{Path: "/opt/edr/edr", Exist: true},
+		{Path: "/opt/vpn/vpn", Exist: true, ProcessIsRunning: true},
+	}
+
+	evaluation := postureEvaluation(checks, files, nil)
+	assert.NoError(t, evaluation.Err)
+	assert.False(t, evaluation.EvaluatedAt.IsZero())
+	assert.Equal(t, []peer.PostureCheck{
+		{Processes: []peer.PostureProcess{{Path: "/usr/bin/agent", Exists: true, Running: true}}},
+		{Processes: []peer.PostureProcess{
+			{Path: "/opt/edr/edr", Exists: true},
+			{Path: "/opt/vpn/vpn", Exists: true, Running: true},
+		}},
+	}, evaluation.Checks)
+	assert.True(t, evaluation.Checks[0].Passed())
+	assert.False(t, evaluation.Passed())
+
+	reportErr := errors.New("sync meta failed")
+	assert.Equal(t, reportErr, postureEvaluation(checks, files, reportErr).Err)
+}
+
+func TestPostureEvaluationNotGathered(t *testing.T) {
+	checks := []*mgmProto.Checks{{Files: []string{"/usr/bin/agent"}}}
+
+	// the fallback to the base system information has no files
+	evaluation := postureEvaluation(checks, nil, nil)
+	assert.ErrorIs(t, evaluation.Err, errPostureNotGathered)
+	assert.Equal(t, []peer.PostureCheck{{Processes: []peer.PostureProcess{{Path: "/usr/bin/agent"}}}}, evaluation.Checks)
+
+	assert.ErrorIs(t, postureEvaluation(checks, nil, errPostureTimeout).Err, errPostureTimeout)
+}
@@ -0,0 +1,103 @@
+package mtuprobe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/netip"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+)
+
+const (
+	// DefaultTimeout is how long ICMPPinger waits for an echo reply.
+	DefaultTimeout = time.Second
+
+	ipv4HeaderLen = 20
+	icmpHeaderLen = 8
+	protocolICMP  = 1
+)
+
+// ICMPPinger sends echo requests through a raw ICMP socket, which requires elevated privileges.
+type ICMPPinger struct {
+	Timeout time.Duration
+
+	seq atomic.Uint32
+}
+
+// Ping implements Pinger. Only IPv4 destinations are supported.
+func (p *ICMPPinger) Ping(ctx context.Context, dst netip.Addr, size int) error {
+	if !dst.Is4() {
+		return fmt.Errorf("unsupported destination %s: only IPv4 is supported", dst)
+	}
+	if size < ipv4HeaderLen+icmpHeaderLen {
+		return fmt.Errorf("packet size %d too small", size)
+	}
+
+	conn, err := icmp.ListenPacket("ip4:icmp", "0.0.0.0")
+	if err != nil {
+		return fmt.Errorf("listen icmp: %w", err)
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	id := rand.Intn(0xffff)
+	seq := int(p.seq.Add(1) & 0xffff)
+	msg := icmp.Message{
+		Type: ipv4.ICMPTypeEcho,
+		Body: &icmp.Echo{
+			ID:   id,
+			Seq:  seq,
+			Data: make([]byte, size-ipv4HeaderLen-icmpHeaderLen),
+		},
+	}
+	b, err := msg.Marshal(nil)
+	if err != nil {
+		return fmt.Errorf("marshal echo request: %w", err)
+	}
+
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return fmt.Errorf("set deadline: %w", err)
+	}
+
+	if _, err := conn.WriteTo(b, &net.IPAddr{IP: dst.AsSlice()}); err != nil {
+		return fmt.Errorf("send echo request: %w", err)
+	}
+
+	buf := make([]byte, size+ipv4HeaderLen)
+	for {
+		n, from, err := conn.ReadFrom(buf)
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				return fmt.Errorf("no echo reply within %s", timeout)
+			}
+			return fmt.Errorf("read echo reply: %w", err)
+		}
+
+		if addr, ok := from.(*net.IPAddr); !ok || !addr.IP.Equal(dst.AsSlice()) {
+			continue
+		}
+		reply, err := icmp.ParseMessage(protocolICMP, buf[:n])
+		if err != nil || reply.Type != ipv4.ICMPTypeEchoReply {
+			continue
+		}
+		if echo, ok := reply.Body.(*icmp.Echo); ok && echo.ID == id && echo.Seq == seq {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// Package mtuprobe discovers the largest packet size that reaches a peer through the tunnel.
+// Sizes are probed with ICMP echo requests between the minimum IPv4 MTU and the tunnel MTU,
+// so a result below the tunnel MTU points at a link that drops large encapsulated packets.
+package mtuprobe
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sync"
+)
+
+const (
+	// MinSize is the smallest packet size probed, the minimum MTU every IPv4 link must support.
+	MinSize = 576
+	// DefaultParallelism is the number of peers probed concurrently when none is given.
+	DefaultParallelism = 4
+
+	// attemptsPerSize is the number of echo requests sent for a size before it counts as lost.
+	attemptsPerSize = 3
+)
+
+// Pinger sends an ICMP echo request as an IP packet of the given size and waits for the reply.
+type Pinger interface {
+	Ping(ctx context.Context, dst netip.Addr, size int) error
+}
+
+// Target is a peer to probe.
+type Target struct {
+	// Name identifies the peer in results, usually its FQDN.
+	Name string
+	Addr netip.Addr
+}
+
+// Result is the outcome of probing a single peer.
+type Result struct {
+	Target
+	// PathMTU is the largest packet size that reached the peer, zero if the peer was unreachable.
+	PathMTU int
+	// Probes is the number of echo requests sent.
+	Probes int
+	Err    error
+}
+
+// Probe binary-searches the largest packet size between MinSize and maxSize that reaches the target.
+func Probe(ctx context.Context, p Pinger, target Target, maxSize int) Result {
+	res := Result{Target: target}
+	if maxSize < MinSize {
+		res.Err = fmt.Errorf("max size %d below minimum %d", maxSize, MinSize)
+		return res
+	}
+
+	reachable := func(size int) bool {
+		for i := 0; i < attemptsPerSize; i++ {
+			if ctx.Err() != nil {
+				return false
+			}
+			res.Probes++
+			if err := p.Ping(ctx, target.Addr, size); err == nil {
+				return true
+			}
+		}
+		return false
+	}
+
+	if !reachable(MinSize) {
+		if err := ctx.Err(); err != nil {
+			res.Err = err
+		} else {
+			res.Err = fmt.Errorf("no reply to %d byte echo requests", MinSize)
+		}
+		return res
+	}
+
+	// lo is known to pass, hi is known to fail unless it equals maxSize and passes.
+	lo, hi := MinSize, maxSize
+	if reachable(hi) {
+		res.PathMTU = hi
+		return res
+	}
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		if reachable(mid) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		res.Err = err
+		return res
+	}
+	res.PathMTU = lo
+	return res
+}
+
+// ProbeAll probes the targets with at most parallelism probes running at once.
+// Results are returned in the order of the targets.
+func ProbeAll(ctx context.Context, p Pinger, targets []Target, maxSize, parallelism int) []Result {
+	if parallelism <= 0 {
+		parallelism = DefaultParallelism
+	}
+
+	results := make([]Result, len(targets))
+	sem := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, target Target) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				results[i] = Result{Target: target, Err: ctx.Err()}
+				return
+			}
+			defer func() { <-sem }()
+			results[i] = Probe(ctx, p, target, maxSize)
+		}(i, target)
+	}
+	wg.Wait()
+
+	return results
+}
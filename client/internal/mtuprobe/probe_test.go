@@ -0,0 +1,88 @@
+package mtuprobe
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pathPinger passes packets up to the configured path MTU of each destination.
+type pathPinger struct {
+	mtu map[netip.Addr]int
+
+	running    atomic.Int32
+	maxRunning atomic.Int32
+}
+
+func (p *pathPinger) Ping(_ context.Context, dst netip.Addr, size int) error {
+	running := p.running.Add(1)
+	defer p.running.Add(-1)
+	for {
+		current := p.maxRunning.Load()
+		if running <= current || p.maxRunning.CompareAndSwap(current, running) {
+			break
+		}
+	}
+
+	if size > p.mtu[dst] {
+		return errors.New("timeout")
+	}
+	return nil
+}
+
+func TestProbe(t *testing.T) {
+	addr := netip.MustParseAddr("100.64.0.2")
+	tests := []struct {
+		name    string
+		pathMTU int
+		want    int
+		wantErr bool
+	}{
+		{name: "full tunnel MTU", pathMTU: 1280, want: 1280},
+		{name: "reduced path", pathMTU: 1100, want: 1100},
+		{name: "minimum only", pathMTU: MinSize, want: MinSize},
+		{name: "unreachable", pathMTU: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &pathPinger{mtu: map[netip.Addr]int{addr: tt.pathMTU}}
+			res := Probe(context.Background(), p, Target{Name: "peer", Addr: addr}, 1280)
+			if tt.wantErr {
+				require.Error(t, res.Err)
+				assert.Zero(t, res.PathMTU)
+				return
+			}
+			require.NoError(t, res.Err)
+			assert.Equal(t, tt.want, res.PathMTU)
+		})
+	}
+}
+
+func TestProbeAll(t *testing.T) {
+	targets := []Target{
+		{Name: "a", Addr: netip.MustParseAddr("100.64.0.1")},
+		{Name: "b", Addr: netip.MustParseAddr("100.64.0.2")},
+		{Name: "c", Addr: netip.MustParseAddr("100.64.0.3")},
+		{Name: "d", Addr: netip.MustParseAddr("100.64.0.4")},
+	}
+	p := &pathPinger{mtu: map[netip.Addr]int{
+		targets[0].Addr: 1280,
+		targets[1].Addr: 900,
+		targets[2].Addr: 1280,
+		targets[3].Addr: 1000,
+	}}
+
+	results := ProbeAll(context.Background(), p, targets, 1280, 2)
+	require.Len(t, results, len(targets))
+	for i, want := range []int{1280, 900, 1280, 1000} {
+		assert.Equal(t, targets[i].Name, results[i].Name)
+		assert.Equal(t, want, results[i].PathMTU)
+	}
+	assert.LessOrEqual(t, p.maxRunning.Load(), int32(2), "parallelism must be bounded")
+}
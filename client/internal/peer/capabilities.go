@@ -0,0 +1,84 @@
+package peer
+
+import (
+	"slices"
+	"time"
+)
+
+// Capabilities the management server can offer the peer in a sync. They are derived from the
+// settings of the sync response, the server does not advertise them as a set.
+const (
+	ServerCapabilityComponentNetworkMap      = "component-network-map"
+	ServerCapabilityRelay                    = "relay"
+	ServerCapabilityFlowLogging              = "flow-logging"
+	ServerCapabilityFlowCounters             = "flow-counters"
+	ServerCapabilityFlowExitNodeCollection   = "flow-exit-node-collection"
+	ServerCapabilityFlowDNSCollection        = "flow-dns-collection"
+	ServerCapabilityMetricsPush              = "metrics-push"
+	ServerCapabilityPostureChecks            = "posture-checks"
+	ServerCapabilityDebugBundlePolicy        = "debug-bundle-policy"
+	ServerCapabilityLazyConnection           = "lazy-connection"
+	ServerCapabilityRoutingPeerDNSResolution = "routing-peer-dns-resolution"
+	ServerCapabilityIPv6Overlay              = "ipv6-overlay"
+	ServerCapabilitySSH                      = "ssh"
+	ServerCapabilitySSHAuth                  = "ssh-auth"
+	ServerCapabilityAutoUpdate               = "auto-update"
+	ServerCapabilitySessionExpiry            = "session-expiry"
+)
+
+// ServerCapabilityInfo names a server capability and what it enables on the peer.
+type ServerCapabilityInfo struct {
+	Name        string
+	Description string
+}
+
+// KnownServerCapabilities lists all server capabilities the client derives, in display order.
+var KnownServerCapabilities = []ServerCapabilityInfo{
+	{ServerCapabilityComponentNetworkMap, "the network map is sent as components and calculated by the client"},
+	{ServerCapabilityRelay, "relay servers for connections that cannot be established directly"},
+	{ServerCapabilityFlowLogging, "traffic flow events are collected and sent to the flow service"},
+	{ServerCapabilityFlowCounters, "flow events carry packet and byte counters"},
+	{ServerCapabilityFlowExitNodeCollection, "flow events are collected for traffic through exit nodes"},
+	{ServerCapabilityFlowDNSCollection, "DNS queries are collected as flow events"},
+	{ServerCapabilityMetricsPush, "client metrics are pushed to the metrics service"},
+	{ServerCapabilityPostureChecks, "posture checks evaluated by the client, e.g. process checks"},
+	{ServerCapabilityDebugBundlePolicy, "the content of debug bundles is restricted"},
+	{ServerCapabilityLazyConnection, "peer connections are opened on demand"},
+	{ServerCapabilityRoutingPeerDNSResolution, "domain routes are resolved on the routing peer"},
+	{ServerCapabilityIPv6Overlay, "the peer has an IPv6 overlay address"},
+	{ServerCapabilitySSH, "the NetBird SSH server is enabled on the peer"},
+	{ServerCapabilitySSHAuth, "SSH access is authorized per user with JWTs"},
+	{ServerCapabilityAutoUpdate, "the client is updated to the version set by the account"},
+	{ServerCapabilitySessionExpiry, "the SSO login session of the peer expires"},
+}
+
+// ServerCapabilities are the capabilities the management server offered in its last sync.
+type ServerCapabilities struct {
+	// SyncVersion is the sync message version of the sync response.
+	SyncVersion int32
+	// Offered lists the names of the offered capabilities, in the order of KnownServerCapabilities.
+	Offered    []string
+	ReceivedAt time.Time
+}
+
+// Offers reports whether the capability was offered.
+func (c ServerCapabilities) Offers(name string) bool {
+	return slices.Contains(c.Offered, name)
+}
+
+// UpdateServerCapabilities records the capabilities of the last full sync from management.
+func (d *Status) UpdateServerCapabilities(caps ServerCapabilities) {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	d.serverCapabilities = caps
+}
+
+// GetServerCapabilities returns the capabilities of the last full sync, the zero value before
+// the first one.
+func (d *Status) GetServerCapabilities() ServerCapabilities {
+	d.mux.RLock()
+	defer d.mux.RUnlock()
+	caps := d.serverCapabilities
+	caps.Offered = slices.Clone(caps.Offered)
+	return caps
+}
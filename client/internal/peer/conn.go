@@ -667,7 +667,10 @@ func (conn *Conn) onGuardEvent() {
 	conn.dumpState.SendOffer()
 	if err := conn.handshaker.SendOffer(); err != nil {
 		conn.Log.Errorf("failed to send offer: %v", err)
+		conn.statusRecorder.AddPeerConnEvent(conn.config.Key, ConnEventOfferSent, fmt.Sprintf("failed: %v", err))
+		return
 	}
+	conn.statusRecorder.AddPeerConnEvent(conn.config.Key, ConnEventOfferSent, "")
 }
 
 func (conn *Conn) onWGDisconnected(watcherCtx context.Context) {
@@ -680,6 +683,7 @@ func (conn *Conn) onWGDisconnected(watcherCtx context.Context) {
 	}
 
 	conn.Log.Warnf("WireGuard handshake timeout detected, closing current connection")
+	conn.statusRecorder.AddPeerConnEvent(conn.config.Key, ConnEventHandshakeTimeout, "closing "+conn.currentConnPriority.String()+" connection")
 
 	// Close the active connection based on current priority
 	switch conn.currentConnPriority {
@@ -0,0 +1,94 @@
+package peer
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+// connHistorySize bounds the connection events kept per peer. The history lives in memory only
+// and starts empty on every daemon start.
+const connHistorySize = 32
+
+// Connection events recorded in the history of a peer.
+const (
+	ConnEventICEConnected      = "ice connected"
+	ConnEventICEDisconnected   = "ice disconnected"
+	ConnEventRelayConnected    = "relay connected"
+	ConnEventRelayDisconnected = "relay disconnected"
+	ConnEventStatus            = "status"
+	ConnEventOfferSent         = "offer sent"
+	ConnEventHandshakeTimeout  = "wireguard handshake timeout"
+)
+
+// ConnEvent is an entry of the connection-attempt history of a peer.
+type ConnEvent struct {
+	Time   time.Time
+	Event  string
+	Detail string
+}
+
+// AddPeerConnEvent adds an event to the connection history of a peer. Events of unknown peers
+// are dropped.
+func (d *Status) AddPeerConnEvent(peerPubKey, event, detail string) {
+	if d == nil {
+		return
+	}
+
+	d.mux.Lock()
+	defer d.mux.Unlock()
+
+	if _, ok := d.peers[peerPubKey]; !ok {
+		return
+	}
+	d.addConnEventLocked(peerPubKey, event, detail)
+}
+
+// PeerConnHistory returns the connection history of a peer, oldest first.
+func (d *Status) PeerConnHistory(peerPubKey string) []ConnEvent {
+	d.mux.RLock()
+	defer d.mux.RUnlock()
+
+	return slices.Clone(d.connHistory[peerPubKey])
+}
+
+// addConnEventLocked appends an event and drops the oldest ones beyond connHistorySize. The
+// caller must hold d.mux.
+func (d *Status) addConnEventLocked(peerPubKey, event, detail string) {
+	history := append(d.connHistory[peerPubKey], ConnEvent{
+		Time:   time.Now(),
+		Event:  event,
+		Detail: detail,
+	})
+	if len(history) > connHistorySize {
+		history = slices.Delete(history, 0, len(history)-connHistorySize)
+	}
+	d.connHistory[peerPubKey] = history
+}
+
+// ConnHistoryToProto converts a connection history to its proto form.
+func ConnHistoryToProto(history []ConnEvent) []*proto.PeerConnEvent {
+	events := make([]*proto.PeerConnEvent, 0, len(history))
+	for _, e := range history {
+		events = append(events, &proto.PeerConnEvent{
+			Time:   timestamppb.New(e.Time),
+			Event:  e.Event,
+			Detail: e.Detail,
+		})
+	}
+	return events
+}
+
+func iceConnDetail(state State) string {
+	kind := "p2p"
+	if state.Relayed {
+		kind = "turn"
+	}
+	return fmt.Sprintf("%s, local %s %s, remote %s %s", kind,
+		state.LocalIceCandidateType, state.LocalIceCandidateEndpoint,
+		state.RemoteIceCandidateType, state.RemoteIceCandidateEndpoint)
+}
@@ -0,0 +1,76 @@
+package peer
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// ExitNodeCandidate is a peer offering an exit node network, as known to the route manager.
+type ExitNodeCandidate struct {
+	NetID   string
+	Network string
+	PeerKey string
+	// Selected is set when the exit node network is selected, by the user or auto-applied by management.
+	Selected bool
+}
+
+// ExitNodeState is an exit node candidate with the health of its peer.
+type ExitNodeState struct {
+	ExitNodeCandidate
+	FQDN       string
+	IP         string
+	ConnStatus ConnStatus
+	Relayed    bool
+	Latency    time.Duration
+	// Active is set for the candidate the traffic of a selected exit node network currently leaves through.
+	Active bool
+}
+
+// UpdateExitNodes replaces the exit node candidates. The route manager calls it when the
+// routes or their selection change.
+func (d *Status) UpdateExitNodes(candidates []ExitNodeCandidate) {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	d.exitNodes = candidates
+}
+
+// exitNodeStatesLocked joins the exit node candidates with the state of their peers, sorted by
+// network ID and FQDN. Caller MUST hold d.mux.
+func (d *Status) exitNodeStatesLocked() []ExitNodeState {
+	if len(d.exitNodes) == 0 {
+		return nil
+	}
+
+	states := make([]ExitNodeState, 0, len(d.exitNodes))
+	for _, c := range d.exitNodes {
+		state := ExitNodeState{ExitNodeCandidate: c, ConnStatus: StatusIdle}
+		peerState, ok := d.peers[c.PeerKey]
+		if !ok {
+			if i := slices.IndexFunc(d.offlinePeers, func(s State) bool { return s.PubKey == c.PeerKey }); i >= 0 {
+				peerState, ok = d.offlinePeers[i], true
+			}
+		}
+		if ok {
+			state.FQDN = peerState.FQDN
+			state.IP = peerState.IP
+			state.ConnStatus = peerState.ConnStatus
+			state.Relayed = peerState.Relayed
+			state.Latency = peerState.Latency
+			// offline peers have no routes, nor a mutex guarding them
+			if peerState.Mux != nil {
+				_, carriesRoute := peerState.GetRoutes()[c.Network]
+				state.Active = c.Selected && carriesRoute
+			}
+		}
+		states = append(states, state)
+	}
+
+	slices.SortFunc(states, func(a, b ExitNodeState) int {
+		if c := strings.Compare(a.NetID, b.NetID); c != 0 {
+			return c
+		}
+		return strings.Compare(a.FQDN, b.FQDN)
+	})
+	return states
+}
@@ -0,0 +1,96 @@
+package peer
+
+import (
+	"slices"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+// PostureProcess is the local result of a process path of a posture check.
+type PostureProcess struct {
+	Path    string
+	Exists  bool
+	Running bool
+}
+
+// PostureCheck is a posture check the management server asked the client to gather, today a
+// process check.
+type PostureCheck struct {
+	Processes []PostureProcess
+}
+
+// Passed reports whether all processes of the check run, which is how the management server
+// evaluates a process check.
+func (c PostureCheck) Passed() bool {
+	for _, p := range c.Processes {
+		if !p.Running {
+			return false
+		}
+	}
+	return true
+}
+
+// PostureEvaluation is the most recent gathering of the posture check results, which are
+// reported to the management server with the system information. The server decides the access,
+// checks it evaluates from the system information alone, like the OS version, are not included.
+type PostureEvaluation struct {
+	EvaluatedAt time.Time
+	Checks      []PostureCheck
+	// Err is set when gathering or reporting the results failed or timed out, the server then
+	// evaluates the results reported before.
+	Err error
+}
+
+// Passed reports whether all checks passed.
+func (e PostureEvaluation) Passed() bool {
+	for _, c := range e.Checks {
+		if !c.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// ToProto converts the evaluation, nil before the first one.
+func (e PostureEvaluation) ToProto() *proto.PostureState {
+	if e.EvaluatedAt.IsZero() {
+		return nil
+	}
+
+	state := &proto.PostureState{EvaluatedAt: timestamppb.New(e.EvaluatedAt)}
+	if e.Err != nil {
+		state.Error = e.Err.Error()
+	}
+	for _, c := range e.Checks {
+		check := &proto.PostureCheckState{}
+		for _, p := range c.Processes {
+			check.Processes = append(check.Processes, &proto.PostureProcessState{
+				Path:    p.Path,
+				Exists:  p.Exists,
+				Running: p.Running,
+			})
+		}
+		state.Checks = append(state.Checks, check)
+	}
+	return state
+}
+
+// UpdatePostureEvaluation records the most recent posture check results.
+func (d *Status) UpdatePostureEvaluation(evaluation PostureEvaluation) {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	d.posture = evaluation
+}
+
+// GetPostureEvaluation returns the most recent posture check results, the zero value before the
+// first evaluation.
+func (d *Status) GetPostureEvaluation() PostureEvaluation {
+	d.mux.RLock()
+	defer d.mux.RUnlock()
+	evaluation := d.posture
+	evaluation.Checks = slices.Clone(evaluation.Checks)
+	return evaluation
+}
@@ -0,0 +1,122 @@
+package peer
+
+import (
+	"slices"
+	"time"
+)
+
+// maxConnectionErrors is the number of distinct recent errors kept per server connection.
+const maxConnectionErrors = 10
+
+// maxRecentRetryAttempts is the number of failed attempt times kept by the retry state.
+const maxRecentRetryAttempts = 100
+
+// ConnectionError is an error a management or signal connection failed with. Repeats of the
+// last error are counted instead of added.
+type ConnectionError struct {
+	Error string
+	// First and Last are the times the error was first and last seen in a row.
+	First time.Time
+	Last  time.Time
+	Count int
+}
+
+// ConnectionHistory records when a management or signal connection came up and went down.
+type ConnectionHistory struct {
+	LastConnected    time.Time
+	LastDisconnected time.Time
+	Disconnects      int
+	// Errors holds the recent errors, oldest first.
+	Errors []ConnectionError
+}
+
+func (h *ConnectionHistory) connected(now time.Time) {
+	h.LastConnected = now
+}
+
+func (h *ConnectionHistory) disconnected(now time.Time, wasConnected bool, err error) {
+	if wasConnected {
+		h.LastDisconnected = now
+		h.Disconnects++
+	}
+	if err == nil {
+		return
+	}
+
+	msg := err.Error()
+	if n := len(h.Errors); n > 0 && h.Errors[n-1].Error == msg {
+		h.Errors[n-1].Last = now
+		h.Errors[n-1].Count++
+		return
+	}
+	h.Errors = append(h.Errors, ConnectionError{Error: msg, First: now, Last: now, Count: 1})
+	if len(h.Errors) > maxConnectionErrors {
+		h.Errors = h.Errors[len(h.Errors)-maxConnectionErrors:]
+	}
+}
+
+func (h ConnectionHistory) clone() ConnectionHistory {
+	h.Errors = slices.Clone(h.Errors)
+	return h
+}
+
+// RetryState is the state of the client retry loop, which reconnects to management with a
+// backoff after the engine failed or stopped.
+type RetryState struct {
+	// Attempts counts the failed attempts since the client last connected to management.
+	Attempts    int
+	LastError   string
+	LastAttempt time.Time
+	// RecentAttempts are the times of the last failed attempts, oldest first.
+	RecentAttempts []time.Time
+	// NextRetry is when the retry loop tries again, zero if it is not waiting.
+	NextRetry time.Time
+}
+
+// RecordRetry records a failed attempt of the client retry loop and when it retries.
+func (d *Status) RecordRetry(err error, next time.Duration) {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+
+	now := time.Now()
+	d.retryState.Attempts++
+	d.retryState.LastAttempt = now
+	d.retryState.RecentAttempts = append(d.retryState.RecentAttempts, now)
+	if len(d.retryState.RecentAttempts) > maxRecentRetryAttempts {
+		d.retryState.RecentAttempts = d.retryState.RecentAttempts[len(d.retryState.RecentAttempts)-maxRecentRetryAttempts:]
+	}
+	d.retryState.NextRetry = now.Add(next)
+	if err != nil {
+		d.retryState.LastError = err.Error()
+	}
+}
+
+// GetRetryState returns the state of the client retry loop.
+func (d *Status) GetRetryState() RetryState {
+	d.mux.RLock()
+	defer d.mux.RUnlock()
+	state := d.retryState
+	state.RecentAttempts = slices.Clone(state.RecentAttempts)
+	return state
+}
+
+// MarkManagementSynced records the receipt of a sync response from management.
+func (d *Status) MarkManagementSynced() {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	d.lastManagementSync = time.Now()
+}
+
+// GetLastManagementSync returns when the last sync response was received, zero if none was.
+func (d *Status) GetLastManagementSync() time.Time {
+	d.mux.RLock()
+	defer d.mux.RUnlock()
+	return d.lastManagementSync
+}
+
+// GetConnectionHistory returns the connection history of the management and signal connections.
+func (d *Status) GetConnectionHistory() (management, signal ConnectionHistory) {
+	d.mux.RLock()
+	defer d.mux.RUnlock()
+	return d.managementHistory.clone(), d.signalHistory.clone()
+}
@@ -0,0 +1,56 @@
+package peer
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnectionHistory(t *testing.T) {
+	status := NewRecorder("https://mgm")
+
+	status.MarkManagementConnected()
+	status.MarkManagementDisconnected(errors.New("unavailable"))
+	status.MarkManagementDisconnected(errors.New("unavailable"))
+	status.MarkManagementDisconnected(errors.New("deadline exceeded"))
+
+	mgm, signal := status.GetConnectionHistory()
+	assert.False(t, mgm.LastConnected.IsZero())
+	assert.False(t, mgm.LastDisconnected.IsZero())
+	assert.Equal(t, 1, mgm.Disconnects, "only the transition from connected counts")
+	require.Len(t, mgm.Errors, 2)
+	assert.Equal(t, "unavailable", mgm.Errors[0].Error)
+	assert.Equal(t, 2, mgm.Errors[0].Count)
+	assert.Equal(t, "deadline exceeded", mgm.Errors[1].Error)
+	assert.True(t, signal.LastConnected.IsZero())
+}
+
+func TestConnectionHistoryErrorLimit(t *testing.T) {
+	var h ConnectionHistory
+	for i := 0; i < maxConnectionErrors+5; i++ {
+		h.disconnected(time.Now(), false, fmt.Errorf("error %d", i))
+	}
+	require.Len(t, h.Errors, maxConnectionErrors)
+	assert.Equal(t, "error 5", h.Errors[0].Error)
+	assert.Equal(t, 0, h.Disconnects)
+}
+
+func TestRetryStateResetOnConnect(t *testing.T) {
+	status := NewRecorder("https://mgm")
+
+	status.RecordRetry(errors.New("connection refused"), 2*time.Second)
+	status.RecordRetry(errors.New("connection refused"), 4*time.Second)
+	retry := status.GetRetryState()
+	assert.Equal(t, 2, retry.Attempts)
+	assert.Equal(t, "connection refused", retry.LastError)
+	assert.True(t, retry.NextRetry.After(retry.LastAttempt))
+	require.Len(t, retry.RecentAttempts, 2)
+	assert.Equal(t, retry.LastAttempt, retry.RecentAttempts[1])
+
+	status.MarkManagementConnected()
+	assert.Equal(t, RetryState{}, status.GetRetryState())
+}
@@ -0,0 +1,74 @@
+package peer
+
+import (
+	"slices"
+	"time"
+)
+
+// stateLogSize bounds the daemon state transitions kept in memory. The log outlives engine
+// restarts and log rotation, it starts empty on every daemon start.
+const stateLogSize = 512
+
+// Daemon state transitions recorded in the state log.
+const (
+	StateEventConnecting             = "connecting"
+	StateEventConnected              = "connected"
+	StateEventDisconnected           = "disconnected"
+	StateEventLoginRequired          = "login required"
+	StateEventStopped                = "stopped"
+	StateEventManagementConnected    = "management connected"
+	StateEventManagementDisconnected = "management disconnected"
+	StateEventSignalConnected        = "signal connected"
+	StateEventSignalDisconnected     = "signal disconnected"
+	StateEventConfigApplied          = "config applied"
+	StateEventNetworkMapUpdated      = "network map updated"
+)
+
+// StateTransition is an entry of the daemon state log.
+type StateTransition struct {
+	Time   time.Time
+	Event  string
+	Detail string
+}
+
+// RecordStateTransition adds a transition to the state log, dropping the oldest entry when it
+// is full.
+func (d *Status) RecordStateTransition(event, detail string) {
+	if d == nil {
+		return
+	}
+
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	d.recordStateTransitionLocked(event, detail)
+}
+
+// StateTransitions returns the state log, oldest first, and the number of older transitions
+// that no longer fit.
+func (d *Status) StateTransitions() ([]StateTransition, int) {
+	d.mux.RLock()
+	defer d.mux.RUnlock()
+	return slices.Clone(d.stateLog), d.stateLogDropped
+}
+
+// errDetail is the detail of a transition caused by err, empty for nil. Repeats of the same
+// error while disconnected are not recorded again.
+func errDetail(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// recordStateTransitionLocked appends a transition. The caller must hold d.mux.
+func (d *Status) recordStateTransitionLocked(event, detail string) {
+	d.stateLog = append(d.stateLog, StateTransition{
+		Time:   time.Now(),
+		Event:  event,
+		Detail: detail,
+	})
+	if over := len(d.stateLog) - stateLogSize; over > 0 {
+		d.stateLog = slices.Delete(d.stateLog, 0, over)
+		d.stateLogDropped += over
+	}
+}
@@ -0,0 +1,45 @@
+package peer
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateLog(t *testing.T) {
+	status := NewRecorder("https://mgm")
+
+	status.RecordStateTransition(StateEventConnecting, "mgm:443")
+	status.MarkManagementDisconnected(errors.New("unavailable"))
+	status.MarkManagementDisconnected(errors.New("unavailable"))
+	status.MarkManagementConnected()
+	status.MarkManagementConnected()
+	status.MarkManagementDisconnected(nil)
+
+	transitions, dropped := status.StateTransitions()
+	assert.Equal(t, 0, dropped)
+	require.Len(t, transitions, 4)
+	assert.Equal(t, StateEventConnecting, transitions[0].Event)
+	assert.Equal(t, "mgm:443", transitions[0].Detail)
+	assert.Equal(t, StateEventManagementDisconnected, transitions[1].Event)
+	assert.Equal(t, "unavailable", transitions[1].Detail, "the repeated error is recorded once")
+	assert.Equal(t, StateEventManagementConnected, transitions[2].Event)
+	assert.Equal(t, "https://mgm", transitions[2].Detail)
+	assert.Equal(t, StateEventManagementDisconnected, transitions[3].Event)
+	assert.Empty(t, transitions[3].Detail)
+}
+
+func TestStateLogLimit(t *testing.T) {
+	status := NewRecorder("https://mgm")
+	for i := 0; i < stateLogSize+3; i++ {
+		status.RecordStateTransition(StateEventNetworkMapUpdated, fmt.Sprintf("serial %d", i))
+	}
+
+	transitions, dropped := status.StateTransitions()
+	require.Len(t, transitions, stateLogSize)
+	assert.Equal(t, 3, dropped)
+	assert.Equal(t, "serial 3", transitions[0].Detail)
+}
@@ -71,7 +71,11 @@ type State struct {
 	Latency                    time.Duration
 	RosenpassEnabled           bool
 	SSHHostKey                 []byte
-	routes                     map[string]struct{}
+	// ConnHistory is the connection history of the peer, oldest first. Only set by GetFullStatus.
+	ConnHistory []ConnEvent
+	// Groups are the IDs of the groups the peer is in, sorted. Only set by GetFullStatus.
+	Groups []string
+	routes map[string]struct{}
 }
 
 // AddRoute add a single route to routes map
@@ -134,6 +138,8 @@ type ManagementState struct {
 	URL       string
 	Connected bool
 	Error     error
+	// Capabilities are the capabilities offered in the last full sync.
+	Capabilities ServerCapabilities
 }
 
 // RosenpassState contains the latest state of the Rosenpass configuration
@@ -152,6 +158,15 @@ type NSGroupState struct {
 	Error   error
 }
 
+// DaemonStart describes when the daemon started and how often it restarted before.
+type DaemonStart struct {
+	StartedAt time.Time
+	// RestartCount is the number of starts before this one, persisted across runs.
+	RestartCount uint32
+	// StartsLast24h counts the starts within 24 hours before StartedAt, including this one.
+	StartsLast24h uint32
+}
+
 // FullStatus contains the full state held by the Status instance
 type FullStatus struct {
 	Peers                 []State
@@ -164,6 +179,9 @@ type FullStatus struct {
 	NumOfForwardingRules  int
 	LazyConnectionEnabled bool
 	Events                []*proto.SystemEvent
+	DaemonStart           DaemonStart
+	ExitNodes             []ExitNodeState
+	Posture               PostureEvaluation
 }
 
 type StatusChangeSubscription struct {
@@ -219,6 +237,11 @@ type Status struct {
 	nsGroupStates         []NSGroupState
 	resolvedDomainsStates map[domain.Domain]ResolvedDomainInfo
 	lazyConnectionEnabled bool
+	daemonStart           DaemonStart
+	managementHistory     ConnectionHistory
+	signalHistory         ConnectionHistory
+	lastManagementSync    time.Time
+	retryState            RetryState
 
 	// To reduce the number of notification invocation this bool will be true when need to call the notification
 	// Some Peer actions mostly used by in a batch when the network map has been synchronized. In these type of events
@@ -250,6 +273,21 @@ type Status struct {
 
 	routeIDLookup routeIDLookup
 	wgIface       WGIfaceStatus
+
+	// connHistory holds the recent connection events of each peer, guarded by mux
+	connHistory map[string][]ConnEvent
+	// peerGroups maps peer keys to the IDs of their groups, guarded by mux
+	peerGroups map[string][]string
+	// exitNodes are the exit node candidates reported by the route manager, guarded by mux
+	exitNodes []ExitNodeCandidate
+	// serverCapabilities are the capabilities of the last full sync, guarded by mux
+	serverCapabilities ServerCapabilities
+	// posture are the most recent posture check results, guarded by mux
+	posture PostureEvaluation
+	// stateLog holds the recent daemon state transitions, stateLogDropped counts the older ones
+	// that no longer fit, guarded by mux
+	stateLog        []StateTransition
+	stateLogDropped int
 }
 
 // NewRecorder returns a new Status instance
@@ -265,6 +303,7 @@ func NewRecorder(mgmAddress string) *Status {
 		notifier:              newNotifier(),
 		mgmAddress:            mgmAddress,
 		resolvedDomainsStates: map[domain.Domain]ResolvedDomainInfo{},
+		connHistory:           make(map[string][]ConnEvent),
 	}
 }
 
@@ -375,6 +414,7 @@ func (d *Status) RemovePeer(peerPubKey string) error {
 	}
 
 	delete(d.peers, peerPubKey)
+	delete(d.connHistory, peerPubKey)
 	if mappedKey, exists := d.ipToKey[p.IP]; exists && mappedKey == peerPubKey {
 		delete(d.ipToKey, p.IP)
 	}
@@ -407,6 +447,7 @@ func (d *Status) UpdatePeerState(receivedState State) error {
 		peerState.RemoteIceCandidateEndpoint = receivedState.RemoteIceCandidateEndpoint
 		peerState.RelayServerAddress = receivedState.RelayServerAddress
 		peerState.RosenpassEnabled = receivedState.RosenpassEnabled
+		d.addConnEventLocked(receivedState.PubKey, ConnEventStatus, receivedState.ConnStatus.String())
 	}
 
 	d.peers[receivedState.PubKey] = peerState
@@ -513,6 +554,7 @@ func (d *Status) UpdatePeerICEState(receivedState State) error {
 	peerState.RosenpassEnabled = receivedState.RosenpassEnabled
 
 	d.peers[receivedState.PubKey] = peerState
+	d.addConnEventLocked(receivedState.PubKey, ConnEventICEConnected, iceConnDetail(receivedState))
 
 	notifyList := hasConnStatusChanged(oldState, receivedState.ConnStatus)
 	notifyRouter := hasStatusOrRelayedChange(oldState, receivedState.ConnStatus, oldIsRelayed, receivedState.Relayed)
@@ -550,6 +592,7 @@ func (d *Status) UpdatePeerRelayedState(receivedState State) error {
 	peerState.RosenpassEnabled = receivedState.RosenpassEnabled
 
 	d.peers[receivedState.PubKey] = peerState
+	d.addConnEventLocked(receivedState.PubKey, ConnEventRelayConnected, receivedState.RelayServerAddress)
 
 	notifyList := hasConnStatusChanged(oldState, receivedState.ConnStatus)
 	notifyRouter := hasStatusOrRelayedChange(oldState, receivedState.ConnStatus, oldIsRelayed, receivedState.Relayed)
@@ -586,6 +629,7 @@ func (d *Status) UpdatePeerRelayedStateToDisconnected(receivedState State) error
 	peerState.RelayServerAddress = ""
 
 	d.peers[receivedState.PubKey] = peerState
+	d.addConnEventLocked(receivedState.PubKey, ConnEventRelayDisconnected, "peer now "+receivedState.ConnStatus.String())
 
 	notifyList := hasConnStatusChanged(oldState, receivedState.ConnStatus)
 	notifyRouter := hasStatusOrRelayedChange(oldState, receivedState.ConnStatus, oldIsRelayed, receivedState.Relayed)
@@ -625,6 +669,7 @@ func (d *Status) UpdatePeerICEStateToDisconnected(receivedState State) error {
 	peerState.RemoteIceCandidateEndpoint = receivedState.RemoteIceCandidateEndpoint
 
 	d.peers[receivedState.PubKey] = peerState
+	d.addConnEventLocked(receivedState.PubKey, ConnEventICEDisconnected, "peer now "+receivedState.ConnStatus.String())
 
 	notifyList := hasConnStatusChanged(oldState, receivedState.ConnStatus)
 	notifyRouter := hasStatusOrRelayedChange(oldState, receivedState.ConnStatus, oldIsRelayed, receivedState.Relayed)
@@ -686,6 +731,13 @@ func (d *Status) UpdatePeerFQDN(peerPubKey, fqdn string) error {
 	return nil
 }
 
+// UpdatePeerGroups replaces the group IDs of all peers, keyed by the peer's public key.
+func (d *Status) UpdatePeerGroups(groups map[string][]string) {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	d.peerGroups = groups
+}
+
 // UpdatePeerSSHHostKey updates peer's SSH host key
 func (d *Status) UpdatePeerSSHHostKey(peerPubKey string, sshHostKey []byte) error {
 	d.mux.Lock()
@@ -903,6 +955,10 @@ func (d *Status) MarkManagementDisconnected(err error) {
 		d.mux.Unlock()
 		return
 	}
+	d.managementHistory.disconnected(time.Now(), d.managementState, err)
+	if d.managementState || errDetail(d.managementError) != errDetail(err) {
+		d.recordStateTransitionLocked(StateEventManagementDisconnected, errDetail(err))
+	}
 	d.managementState = false
 	d.managementError = err
 	mgm := d.managementState
@@ -920,6 +976,11 @@ func (d *Status) MarkManagementConnected() {
 		d.mux.Unlock()
 		return
 	}
+	if !d.managementState {
+		d.managementHistory.connected(time.Now())
+		d.recordStateTransitionLocked(StateEventManagementConnected, d.mgmAddress)
+	}
+	d.retryState = RetryState{}
 	d.managementState = true
 	d.managementError = nil
 	mgm := d.managementState
@@ -965,6 +1026,10 @@ func (d *Status) MarkSignalDisconnected(err error) {
 		d.mux.Unlock()
 		return
 	}
+	d.signalHistory.disconnected(time.Now(), d.signalState, err)
+	if d.signalState || errDetail(d.signalError) != errDetail(err) {
+		d.recordStateTransitionLocked(StateEventSignalDisconnected, errDetail(err))
+	}
 	d.signalState = false
 	d.signalError = err
 	mgm := d.managementState
@@ -982,6 +1047,10 @@ func (d *Status) MarkSignalConnected() {
 		d.mux.Unlock()
 		return
 	}
+	if !d.signalState {
+		d.signalHistory.connected(time.Now())
+		d.recordStateTransitionLocked(StateEventSignalConnected, d.signalAddress)
+	}
 	d.signalState = true
 	d.signalError = nil
 	mgm := d.managementState
@@ -1050,13 +1119,25 @@ func (d *Status) GetLazyConnection() bool {
 	return d.lazyConnectionEnabled
 }
 
+// SetDaemonStart records the start of the daemon, it is reported with the full status.
+func (d *Status) SetDaemonStart(start DaemonStart) {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	d.daemonStart = start
+}
+
 func (d *Status) GetManagementState() ManagementState {
 	d.mux.RLock()
 	defer d.mux.RUnlock()
 	return ManagementState{
-		d.mgmAddress,
-		d.managementState,
-		d.managementError,
+		URL:       d.mgmAddress,
+		Connected: d.managementState,
+		Error:     d.managementError,
+		Capabilities: ServerCapabilities{
+			SyncVersion: d.serverCapabilities.SyncVersion,
+			Offered:     slices.Clone(d.serverCapabilities.Offered),
+			ReceivedAt:  d.serverCapabilities.ReceivedAt,
+		},
 	}
 }
 
@@ -1138,6 +1219,7 @@ func (d *Status) GetRelayStates() []relay.ProbeResult {
 			URI:       rs.URL,
 			Err:       rs.Err,
 			Transport: rs.Transport,
+			Latency:   rs.Latency,
 		})
 	}
 	return relayStates
@@ -1177,18 +1259,26 @@ func (d *Status) GetFullStatus() FullStatus {
 		NSGroupStates:         d.GetDNSStates(),
 		NumOfForwardingRules:  len(d.ForwardingRules()),
 		LazyConnectionEnabled: d.GetLazyConnection(),
+		Posture:               d.GetPostureEvaluation(),
 	}
 
 	d.mux.RLock()
 	defer d.mux.RUnlock()
 
 	fullStatus.LocalPeerState = d.localPeer
+	fullStatus.DaemonStart = d.daemonStart
 
 	for _, status := range d.peers {
+		status.ConnHistory = slices.Clone(d.connHistory[status.PubKey])
+		status.Groups = slices.Clone(d.peerGroups[status.PubKey])
 		fullStatus.Peers = append(fullStatus.Peers, status)
 	}
 
-	fullStatus.Peers = append(fullStatus.Peers, d.offlinePeers...)
+	for _, status := range d.offlinePeers {
+		status.Groups = slices.Clone(d.peerGroups[status.PubKey])
+		fullStatus.Peers = append(fullStatus.Peers, status)
+	}
+	fullStatus.ExitNodes = d.exitNodeStatesLocked()
 	fullStatus.Events = d.GetEventHistory()
 	return fullStatus
 }
@@ -1526,6 +1616,8 @@ func (fs FullStatus) ToProto() *proto.FullStatus {
 	if err := fs.ManagementState.Error; err != nil {
 		pbFullStatus.ManagementState.Error = err.Error()
 	}
+	pbFullStatus.ManagementState.Capabilities = fs.ManagementState.Capabilities.Offered
+	pbFullStatus.ManagementState.SyncVersion = fs.ManagementState.Capabilities.SyncVersion
 
 	pbFullStatus.SignalState.URL = fs.SignalState.URL
 	pbFullStatus.SignalState.Connected = fs.SignalState.Connected
@@ -1569,6 +1661,7 @@ func (fs FullStatus) ToProto() *proto.FullStatus {
 			Networks:                   networks,
 			Latency:                    durationpb.New(peerState.Latency),
 			SshHostKey:                 peerState.SSHHostKey,
+			ConnHistory:                ConnHistoryToProto(peerState.ConnHistory),
 		}
 		pbFullStatus.Peers = append(pbFullStatus.Peers, pbPeerState)
 	}
@@ -1579,6 +1672,9 @@ func (fs FullStatus) ToProto() *proto.FullStatus {
 			Available: relayState.Err == nil,
 			Transport: relayState.Transport,
 		}
+		if relayState.Latency > 0 {
+			pbRelayState.Latency = durationpb.New(relayState.Latency)
+		}
 		if err := relayState.Err; err != nil {
 			pbRelayState.Error = err.Error()
 		}
@@ -1606,6 +1702,20 @@ func (fs FullStatus) ToProto() *proto.FullStatus {
 	}
 
 	pbFullStatus.Events = fs.Events
+	pbFullStatus.DaemonState = fs.DaemonStart.ToProto()
+	pbFullStatus.Posture = fs.Posture.ToProto()
 
 	return &pbFullStatus
 }
+
+// ToProto converts DaemonStart to proto.DaemonState. It returns nil if the start was not recorded.
+func (ds DaemonStart) ToProto() *proto.DaemonState {
+	if ds.StartedAt.IsZero() {
+		return nil
+	}
+	return &proto.DaemonState{
+		StartedAt:     timestamppb.New(ds.StartedAt),
+		RestartCount:  ds.RestartCount,
+		StartsLast24H: ds.StartsLast24h,
+	}
+}
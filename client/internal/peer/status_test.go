@@ -350,3 +350,35 @@ func TestMarkServerStateDoesNotNotifyWhenUnchanged(t *testing.T) {
 	status.MarkManagementDisconnected(err)
 	assert.False(t, notified(ch), "redundant disconnect should not notify")
 }
+
+func TestPeerConnHistory(t *testing.T) {
+	key := "abc"
+	status := NewRecorder("https://mgm")
+	require.NoError(t, status.AddPeer(key, "abc.netbird", "100.108.254.1", ""))
+
+	require.NoError(t, status.UpdatePeerRelayedState(State{PubKey: key, ConnStatus: StatusConnected, Relayed: true, RelayServerAddress: "rels://relay.netbird.io:443"}))
+	require.NoError(t, status.UpdatePeerRelayedStateToDisconnected(State{PubKey: key, ConnStatus: StatusIdle}))
+
+	history := status.PeerConnHistory(key)
+	require.Len(t, history, 2)
+	assert.Equal(t, ConnEventRelayConnected, history[0].Event)
+	assert.Equal(t, "rels://relay.netbird.io:443", history[0].Detail)
+	assert.Equal(t, ConnEventRelayDisconnected, history[1].Event)
+
+	for i := 0; i < connHistorySize+5; i++ {
+		status.AddPeerConnEvent(key, ConnEventOfferSent, "")
+	}
+	history = status.PeerConnHistory(key)
+	assert.Len(t, history, connHistorySize, "history is bounded")
+	assert.Equal(t, ConnEventOfferSent, history[0].Event, "oldest events are dropped first")
+
+	fullStatus := status.GetFullStatus()
+	require.Len(t, fullStatus.Peers, 1)
+	assert.Len(t, fullStatus.Peers[0].ConnHistory, connHistorySize)
+
+	status.AddPeerConnEvent("unknown", ConnEventOfferSent, "")
+	assert.Empty(t, status.PeerConnHistory("unknown"), "events of unknown peers are dropped")
+
+	require.NoError(t, status.RemovePeer(key))
+	assert.Empty(t, status.PeerConnHistory(key), "removing the peer drops its history")
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"net"
 	"net/url"
 	"os"
@@ -103,6 +104,11 @@ type ConfigInput struct {
 	DNSLabels domain.List
 
 	MTU *uint16
+
+	// LogLevel replaces the persisted log level together with ComponentLogLevels when set.
+	// An empty value clears it.
+	LogLevel           *string
+	ComponentLogLevels map[string]string
 }
 
 // Config Configuration type
@@ -186,6 +192,12 @@ type Config struct {
 
 	MTU uint16
 
+	// LogLevel is the log level set with "netbird debug log level --persist". The daemon applies it
+	// at startup instead of its --log-level. Empty if none was persisted.
+	LogLevel string `json:",omitempty"`
+	// ComponentLogLevels maps component names to levels overriding LogLevel for their log entries.
+	ComponentLogLevels map[string]string `json:",omitempty"`
+
 	// policy is the MDM policy that produced the currently-set values for
 	// any MDM-enforced fields. Set by applyMDMPolicy at the tail of apply()
 	// and reset on every apply() invocation. Never persisted to disk.
@@ -194,6 +206,21 @@ type Config struct {
 	policy *mdm.Policy `json:"-"`
 }
 
+// redactedSecret replaces the secrets of a redacted config.
+const redactedSecret = "**********"
+
+// Redacted returns a copy of the config with the WireGuard private and pre-shared keys and the
+// SSH key replaced. Unset secrets stay empty, so the copy still shows whether one is configured.
+func (config *Config) Redacted() *Config {
+	redacted := *config
+	for _, secret := range []*string{&redacted.PrivateKey, &redacted.PreSharedKey, &redacted.SSHKey} {
+		if *secret != "" {
+			*secret = redactedSecret
+		}
+	}
+	return &redacted
+}
+
 // Policy returns the MDM policy applied to this Config. Returns a non-nil
 // empty Policy when MDM enforcement is inactive; callers can always invoke
 // HasKey / ManagedKeys / IsEmpty without a nil check.
@@ -640,6 +667,17 @@ func (config *Config) apply(input ConfigInput) (updated bool, err error) {
 		updated = true
 	}
 
+	if input.LogLevel != nil && (*input.LogLevel != config.LogLevel || !maps.Equal(input.ComponentLogLevels, config.ComponentLogLevels)) {
+		if *input.LogLevel == "" {
+			log.Infof("clearing persisted log level")
+		} else {
+			log.Infof("persisting log level %s", *input.LogLevel)
+		}
+		config.LogLevel = *input.LogLevel
+		config.ComponentLogLevels = input.ComponentLogLevels
+		updated = true
+	}
+
 	if input.MTU != nil && *input.MTU != config.MTU {
 		log.Infof("updating MTU to %d (old value %d)", *input.MTU, config.MTU)
 		config.MTU = *input.MTU
@@ -332,3 +332,14 @@ func TestUpdateOldManagementURL(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigRedacted(t *testing.T) {
+	config := &Config{PrivateKey: "private", PreSharedKey: "", SSHKey: "ssh", WgIface: "wt0"}
+
+	redacted := config.Redacted()
+	assert.Equal(t, redactedSecret, redacted.PrivateKey)
+	assert.Empty(t, redacted.PreSharedKey, "unset secrets stay empty")
+	assert.Equal(t, redactedSecret, redacted.SSHKey)
+	assert.Equal(t, "wt0", redacted.WgIface)
+	assert.Equal(t, "private", config.PrivateKey, "the original is unchanged")
+}
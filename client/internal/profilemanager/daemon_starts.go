@@ -0,0 +1,74 @@
+package profilemanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netbirdio/netbird/util"
+)
+
+const (
+	daemonStartsFile = "daemon_starts.json"
+	// daemonStartsWindow is how far back the recent starts are kept.
+	daemonStartsWindow = 24 * time.Hour
+	// maxRecentDaemonStarts caps the recent starts a tight crash loop can record.
+	maxRecentDaemonStarts = 1000
+)
+
+// DaemonStarts is the start history of the daemon, persisted across runs.
+type DaemonStarts struct {
+	// Count is the number of daemon starts since the history was created.
+	Count uint32 `json:"count"`
+	// Recent holds the start times within the last 24 hours, oldest first.
+	Recent []time.Time `json:"recent"`
+}
+
+// Restarts returns the number of starts before the last one.
+func (d *DaemonStarts) Restarts() uint32 {
+	if d.Count == 0 {
+		return 0
+	}
+	return d.Count - 1
+}
+
+func (d *DaemonStarts) add(now time.Time) {
+	d.Count++
+
+	cutoff := now.Add(-daemonStartsWindow)
+	recent := d.Recent[:0]
+	for _, t := range d.Recent {
+		if t.After(cutoff) {
+			recent = append(recent, t)
+		}
+	}
+	recent = append(recent, now)
+	if len(recent) > maxRecentDaemonStarts {
+		recent = recent[len(recent)-maxRecentDaemonStarts:]
+	}
+	d.Recent = recent
+}
+
+// RecordDaemonStart adds a daemon start at now to the persisted start history and returns the
+// updated history. An unreadable history is started over. The returned history is valid even
+// if it could not be written.
+func (s *ServiceManager) RecordDaemonStart(now time.Time) (*DaemonStarts, error) {
+	path := filepath.Join(DefaultConfigPathDir, daemonStartsFile)
+
+	var starts DaemonStarts
+	if _, err := util.ReadJson(path, &starts); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Warnf("failed to read daemon start history, starting over: %v", err)
+		starts = DaemonStarts{}
+	}
+
+	starts.add(now)
+	if err := util.WriteJsonWithRestrictedPermission(context.Background(), path, &starts); err != nil {
+		return &starts, fmt.Errorf("write daemon start history: %w", err)
+	}
+	return &starts, nil
+}
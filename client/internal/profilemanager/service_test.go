@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -228,3 +229,18 @@ func TestRemoveProfile_DeletesStateFile(t *testing.T) {
 		assert.True(t, errors.Is(err, os.ErrNotExist), "state file should be removed")
 	})
 }
+
+func TestDaemonStartsAdd(t *testing.T) {
+	now := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+	starts := DaemonStarts{
+		Count:  3,
+		Recent: []time.Time{now.Add(-30 * time.Hour), now.Add(-2 * time.Hour)},
+	}
+
+	starts.add(now)
+	assert.Equal(t, uint32(4), starts.Count)
+	assert.Equal(t, uint32(3), starts.Restarts())
+	assert.Equal(t, []time.Time{now.Add(-2 * time.Hour), now}, starts.Recent, "starts older than a day are dropped")
+
+	assert.Zero(t, (&DaemonStarts{}).Restarts())
+}
@@ -35,6 +35,9 @@ type ProbeResult struct {
 	// Transport is the negotiated relay transport, empty
 	// for stun/turn probes or when not connected.
 	Transport string
+	// Latency is the round trip of the relay auth handshake, zero
+	// for stun/turn probes or when not connected.
+	Latency time.Duration
 }
 
 type StunTurnProbe struct {
@@ -0,0 +1,344 @@
+package internal
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	firewallManager "github.com/netbirdio/netbird/client/firewall/manager"
+	"github.com/netbirdio/netbird/client/internal/acl"
+	"github.com/netbirdio/netbird/client/internal/routemanager"
+	"github.com/netbirdio/netbird/client/internal/statemanager"
+	"github.com/netbirdio/netbird/route"
+	mgmProto "github.com/netbirdio/netbird/shared/management/proto"
+)
+
+// ReplayRule is a firewall rule the client would add for a network map.
+type ReplayRule struct {
+	Action      string
+	Protocol    string
+	Source      string
+	Destination string
+	Ports       string
+	// IPSet is the set the firewall groups peer rules with identical selectors in.
+	IPSet string
+}
+
+func (r ReplayRule) String() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("%s %s from %s", r.Action, r.Protocol, r.Source))
+	if r.Destination != "" {
+		b.WriteString(" to " + r.Destination)
+	}
+	if r.Ports != "" {
+		b.WriteString(" port " + r.Ports)
+	}
+	if r.IPSet != "" {
+		b.WriteString(" (set " + r.IPSet + ")")
+	}
+	return b.String()
+}
+
+// ReplayResult is what the client computes from a network map: the routes it serves and installs
+// and the firewall rules it adds.
+type ReplayResult struct {
+	// LocalPeerKey is the public key the routes were classified for, empty if unknown.
+	LocalPeerKey string
+	// LocalPeerKeyInferred is set when the key was derived from the map rather than given.
+	LocalPeerKeyInferred bool
+	ServerRoutes         []*route.Route
+	ClientRoutes         route.HAMap
+	PeerRules            []ReplayRule
+	RouteRules           []ReplayRule
+	// Invalid has an error per map entry the client skips, naming the entry.
+	Invalid []error
+}
+
+// ReplayNetworkMap runs a network map through the route classification and the ACL manager of the
+// client without touching the system: the firewall rules go to an in-memory recorder and no route
+// is installed. localPeerKey is the public key of the peer the map was captured on. If empty, it
+// is inferred from the routes whose routing peer is not a remote peer in the map.
+func ReplayNetworkMap(networkMap *mgmProto.NetworkMap, localPeerKey string) *ReplayResult {
+	result := &ReplayResult{LocalPeerKey: localPeerKey}
+	if localPeerKey == "" {
+		result.LocalPeerKey = inferLocalPeerKey(networkMap)
+		result.LocalPeerKeyInferred = result.LocalPeerKey != ""
+	}
+
+	result.Invalid = append(validateRoutes(networkMap.GetRoutes()), acl.ValidateRules(networkMap)...)
+
+	routes := toRoutes(networkMap.GetRoutes())
+	result.ServerRoutes, result.ClientRoutes = routeClassification(result.LocalPeerKey, routes)
+
+	recorder := &replayFirewall{}
+	acl.NewDefaultManager(recorder).ApplyFiltering(networkMap, toDNSFeatureFlag(networkMap))
+	result.PeerRules = recorder.peerRules
+	result.RouteRules = recorder.routeRules
+
+	return result
+}
+
+func routeClassification(localPeerKey string, routes []*route.Route) ([]*route.Route, route.HAMap) {
+	serverRoutesMap, clientRoutes := routemanager.ClassifyRoutes(localPeerKey, routes)
+
+	serverRoutes := make([]*route.Route, 0, len(serverRoutesMap))
+	for _, r := range serverRoutesMap {
+		serverRoutes = append(serverRoutes, r)
+	}
+	sort.Slice(serverRoutes, func(i, j int) bool {
+		return serverRoutes[i].ID < serverRoutes[j].ID
+	})
+	return serverRoutes, clientRoutes
+}
+
+// inferLocalPeerKey returns the routing peer of the map that is not a remote peer, the peer the
+// map was sent to. It returns an empty key when there is none or more than one.
+func inferLocalPeerKey(networkMap *mgmProto.NetworkMap) string {
+	remote := make(map[string]struct{})
+	for _, p := range networkMap.GetRemotePeers() {
+		remote[p.GetWgPubKey()] = struct{}{}
+	}
+	for _, p := range networkMap.GetOfflinePeers() {
+		remote[p.GetWgPubKey()] = struct{}{}
+	}
+
+	var local string
+	for _, r := range networkMap.GetRoutes() {
+		if _, ok := remote[r.GetPeer()]; ok || r.GetPeer() == "" {
+			continue
+		}
+		if local != "" && local != r.GetPeer() {
+			return ""
+		}
+		local = r.GetPeer()
+	}
+	return local
+}
+
+// validateRoutes returns an error per route toRoutes skips, naming the route.
+func validateRoutes(protoRoutes []*mgmProto.Route) []error {
+	var errs []error
+	for i, r := range protoRoutes {
+		if len(r.GetDomains()) > 0 {
+			continue
+		}
+		if _, err := netip.ParsePrefix(r.GetNetwork()); err != nil {
+			errs = append(errs, fmt.Errorf("route %d (id %s, network id %s): parse network: %w", i, r.GetID(), r.GetNetID(), err))
+		}
+	}
+	return errs
+}
+
+// FormatReplay renders a replay result as text.
+func FormatReplay(result *ReplayResult) string {
+	var b strings.Builder
+
+	switch {
+	case result.LocalPeerKey == "":
+		b.WriteString("Local peer: unknown, all routes are treated as client routes (set --peer-key)\n")
+	case result.LocalPeerKeyInferred:
+		b.WriteString(fmt.Sprintf("Local peer: %s (inferred from the routes)\n", result.LocalPeerKey))
+	default:
+		b.WriteString(fmt.Sprintf("Local peer: %s\n", result.LocalPeerKey))
+	}
+
+	b.WriteString(fmt.Sprintf("\nServer routes (%d):\n", len(result.ServerRoutes)))
+	for _, r := range result.ServerRoutes {
+		b.WriteString(fmt.Sprintf("  %s %s%s\n", r.NetID, r.NetString(), routeFlags(r)))
+	}
+
+	haIDs := make([]route.HAUniqueID, 0, len(result.ClientRoutes))
+	for id := range result.ClientRoutes {
+		haIDs = append(haIDs, id)
+	}
+	sort.Slice(haIDs, func(i, j int) bool {
+		return haIDs[i] < haIDs[j]
+	})
+	b.WriteString(fmt.Sprintf("\nClient routes (%d):\n", len(haIDs)))
+	for _, id := range haIDs {
+		routes := result.ClientRoutes[id]
+		b.WriteString(fmt.Sprintf("  %s %s\n", routes[0].NetID, routes[0].NetString()))
+		for _, r := range routes {
+			b.WriteString(fmt.Sprintf("    via %s metric %d%s\n", r.Peer, r.Metric, routeFlags(r)))
+		}
+	}
+
+	writeReplayRules(&b, "Peer firewall rules", result.PeerRules)
+	writeReplayRules(&b, "Route firewall rules", result.RouteRules)
+
+	if len(result.Invalid) > 0 {
+		b.WriteString(fmt.Sprintf("\nInvalid entries, skipped by the client (%d):\n", len(result.Invalid)))
+		for _, err := range result.Invalid {
+			b.WriteString(fmt.Sprintf("  %v\n", err))
+		}
+	}
+	return b.String()
+}
+
+func routeFlags(r *route.Route) string {
+	var flags []string
+	if r.Masquerade {
+		flags = append(flags, "masquerade")
+	}
+	if r.KeepRoute {
+		flags = append(flags, "keep-route")
+	}
+	if r.SkipAutoApply {
+		flags = append(flags, "skip-auto-apply")
+	}
+	if len(flags) == 0 {
+		return ""
+	}
+	return " [" + strings.Join(flags, ", ") + "]"
+}
+
+func writeReplayRules(b *strings.Builder, title string, rules []ReplayRule) {
+	b.WriteString(fmt.Sprintf("\n%s (%d):\n", title, len(rules)))
+	for _, r := range rules {
+		b.WriteString("  " + r.String() + "\n")
+	}
+}
+
+var _ firewallManager.Manager = (*replayFirewall)(nil)
+
+// replayFirewall records the rules the ACL manager adds and implements everything else as a no-op.
+// It reports itself stateful like the firewalls the client uses, so no return traffic rules are added.
+type replayFirewall struct {
+	peerRules  []ReplayRule
+	routeRules []ReplayRule
+}
+
+type replayFirewallRule string
+
+func (r replayFirewallRule) ID() string {
+	return string(r)
+}
+
+func portString(port *firewallManager.Port) string {
+	if port == nil {
+		return ""
+	}
+	return port.String()
+}
+
+func (f *replayFirewall) AddPeerFiltering(_ []byte, ip net.IP, proto firewallManager.Protocol, sPort, dPort *firewallManager.Port, action firewallManager.Action, ipsetName string) ([]firewallManager.Rule, error) {
+	ports := portString(dPort)
+	if sPort != nil {
+		ports = "from " + portString(sPort)
+	}
+	f.peerRules = append(f.peerRules, ReplayRule{
+		Action:   action.String(),
+		Protocol: string(proto),
+		Source:   ip.String(),
+		Ports:    ports,
+		IPSet:    ipsetName,
+	})
+	return []firewallManager.Rule{replayFirewallRule(fmt.Sprintf("peer-%d", len(f.peerRules)))}, nil
+}
+
+func (f *replayFirewall) AddRouteFiltering(_ []byte, sources []netip.Prefix, destination firewallManager.Network, proto firewallManager.Protocol, _, dPort *firewallManager.Port, action firewallManager.Action) (firewallManager.Rule, error) {
+	srcs := make([]string, 0, len(sources))
+	for _, s := range sources {
+		srcs = append(srcs, s.String())
+	}
+	f.routeRules = append(f.routeRules, ReplayRule{
+		Action:      action.String(),
+		Protocol:    string(proto),
+		Source:      strings.Join(srcs, ","),
+		Destination: destination.String(),
+		Ports:       portString(dPort),
+	})
+	return replayFirewallRule(fmt.Sprintf("route-%d", len(f.routeRules))), nil
+}
+
+func (f *replayFirewall) Init(*statemanager.Manager) error {
+	return nil
+}
+
+func (f *replayFirewall) AllowNetbird() error {
+	return nil
+}
+
+func (f *replayFirewall) DeletePeerRule(firewallManager.Rule) error {
+	return nil
+}
+
+func (f *replayFirewall) IsServerRouteSupported() bool {
+	return true
+}
+
+func (f *replayFirewall) IsStateful() bool {
+	return true
+}
+
+func (f *replayFirewall) DeleteRouteRule(firewallManager.Rule) error {
+	return nil
+}
+
+func (f *replayFirewall) AddNatRule(firewallManager.RouterPair) error {
+	return nil
+}
+
+func (f *replayFirewall) RemoveNatRule(firewallManager.RouterPair) error {
+	return nil
+}
+
+func (f *replayFirewall) SetLegacyManagement(bool) error {
+	return nil
+}
+
+func (f *replayFirewall) Close(*statemanager.Manager) error {
+	return nil
+}
+
+func (f *replayFirewall) Flush() error {
+	return nil
+}
+
+func (f *replayFirewall) SetLogLevel(log.Level) {
+	// the replay does not log firewall activity
+}
+
+func (f *replayFirewall) EnableRouting() error {
+	return nil
+}
+
+func (f *replayFirewall) DisableRouting() error {
+	return nil
+}
+
+func (f *replayFirewall) AddDNATRule(firewallManager.ForwardRule) (firewallManager.Rule, error) {
+	return replayFirewallRule("dnat"), nil
+}
+
+func (f *replayFirewall) DeleteDNATRule(firewallManager.Rule) error {
+	return nil
+}
+
+func (f *replayFirewall) UpdateSet(firewallManager.Set, []netip.Prefix) error {
+	return nil
+}
+
+func (f *replayFirewall) AddInboundDNAT(netip.Addr, firewallManager.Protocol, uint16, uint16) error {
+	return nil
+}
+
+func (f *replayFirewall) RemoveInboundDNAT(netip.Addr, firewallManager.Protocol, uint16, uint16) error {
+	return nil
+}
+
+func (f *replayFirewall) AddOutputDNAT(netip.Addr, firewallManager.Protocol, uint16, uint16) error {
+	return nil
+}
+
+func (f *replayFirewall) RemoveOutputDNAT(netip.Addr, firewallManager.Protocol, uint16, uint16) error {
+	return nil
+}
+
+func (f *replayFirewall) SetupEBPFProxyNoTrack(uint16, uint16) error {
+	return nil
+}
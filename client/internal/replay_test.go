@@ -0,0 +1,59 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mgmProto "github.com/netbirdio/netbird/shared/management/proto"
+)
+
+func TestReplayNetworkMap(t *testing.T) {
+	networkMap := &mgmProto.NetworkMap{
+		Serial: 7,
+		RemotePeers: []*mgmProto.RemotePeerConfig{
+			{WgPubKey: "remote"},
+		},
+		Routes: []*mgmProto.Route{
+			{ID: "r1", NetID: "office", Network: "10.10.0.0/16", Peer: "remote", Metric: 9999, NetworkType: 1},
+			{ID: "r2", NetID: "lab", Network: "192.168.50.0/24", Peer: "local", Masquerade: true, NetworkType: 1},
+			{ID: "r3", NetID: "broken", Network: "10.20.0.0", Peer: "remote", NetworkType: 1},
+		},
+		FirewallRules: []*mgmProto.FirewallRule{
+			{PeerIP: "100.64.0.2", Direction: mgmProto.RuleDirection_IN, Action: mgmProto.RuleAction_ACCEPT, Protocol: mgmProto.RuleProtocol_TCP, Port: "22"},
+			{PeerIP: "100.64.0.2", Direction: mgmProto.RuleDirection_OUT, Action: mgmProto.RuleAction_ACCEPT, Protocol: mgmProto.RuleProtocol_TCP, Port: "22"},
+		},
+		RoutesFirewallRules: []*mgmProto.RouteFirewallRule{
+			{SourceRanges: []string{"100.64.0.0/10"}, Destination: "192.168.50.0/24", Action: mgmProto.RuleAction_ACCEPT, Protocol: mgmProto.RuleProtocol_ALL},
+		},
+	}
+
+	result := ReplayNetworkMap(networkMap, "")
+	assert.Equal(t, "local", result.LocalPeerKey)
+	assert.True(t, result.LocalPeerKeyInferred)
+
+	require.Len(t, result.ServerRoutes, 1)
+	assert.Equal(t, "192.168.50.0/24", result.ServerRoutes[0].Network.String())
+	require.Len(t, result.ClientRoutes, 1)
+
+	// stateful firewalls need no rule for outbound traffic
+	require.Len(t, result.PeerRules, 1)
+	assert.Equal(t, "accept tcp from 100.64.0.2 port 22 (set nb0000001)", result.PeerRules[0].String())
+	require.Len(t, result.RouteRules, 1)
+	assert.Equal(t, "accept all from 100.64.0.0/10 to 192.168.50.0/24", result.RouteRules[0].String())
+
+	require.Len(t, result.Invalid, 1)
+	assert.Contains(t, result.Invalid[0].Error(), "route 2 (id r3, network id broken)")
+
+	out := FormatReplay(result)
+	assert.Contains(t, out, "Local peer: local (inferred from the routes)")
+	assert.Contains(t, out, "lab 192.168.50.0/24 [masquerade]")
+	assert.Contains(t, out, "via remote metric 9999")
+	assert.Contains(t, out, "Invalid entries, skipped by the client (1):")
+
+	result = ReplayNetworkMap(networkMap, "remote")
+	assert.False(t, result.LocalPeerKeyInferred)
+	assert.Len(t, result.ServerRoutes, 1)
+	assert.Equal(t, "10.10.0.0/16", result.ServerRoutes[0].Network.String())
+}
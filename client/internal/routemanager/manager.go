@@ -463,6 +463,7 @@ func (m *DefaultManager) UpdateRoutes(
 
 		// Update route selector based on management server's isSelected status
 		m.updateRouteSelectorFromManagement(clientRoutes)
+		m.updateExitNodeStatus(clientRoutes)
 
 		filteredClientRoutes := m.routeSelector.FilterSelectedExitNodes(clientRoutes)
 
@@ -618,6 +619,8 @@ func (m *DefaultManager) TriggerSelection(networks route.HAMap) {
 		log.Errorf("failed to update state: %v", err)
 	}
 
+	m.updateExitNodeStatus(m.clientRoutes)
+
 	// A selection change flips Network.selected without altering the candidate
 	// set, so bump the revision to push the new state to the UI.
 	m.statusRecorder.BumpNetworksRevision()
@@ -671,13 +674,19 @@ func (m *DefaultManager) updateClientNetworks(updateSerial uint64, networks rout
 }
 
 func (m *DefaultManager) ClassifyRoutes(newRoutes []*route.Route) (map[route.ID]*route.Route, route.HAMap) {
+	return ClassifyRoutes(m.pubKey, newRoutes)
+}
+
+// ClassifyRoutes splits routes into the routes the peer with pubKey serves and the client routes
+// it installs, grouped by HA network. Client routes this platform cannot install are dropped.
+func ClassifyRoutes(pubKey string, newRoutes []*route.Route) (map[route.ID]*route.Route, route.HAMap) {
 	newClientRoutesIDMap := make(route.HAMap)
 	newServerRoutesMap := make(map[route.ID]*route.Route)
 	ownNetworkIDs := make(map[route.HAUniqueID]bool)
 
 	for _, newRoute := range newRoutes {
 		haID := newRoute.GetHAUniqueID()
-		if newRoute.Peer == m.pubKey {
+		if newRoute.Peer == pubKey {
 			ownNetworkIDs[haID] = true
 			newServerRoutesMap[newRoute.ID] = newRoute
 		}
@@ -820,6 +829,31 @@ func (m *DefaultManager) collectExitNodeInfo(clientRoutes route.HAMap) exitNodeI
 	return info
 }
 
+// updateExitNodeStatus reports every peer offering an exit node to the status recorder, with the
+// selection of its network. The synthesized "-v6" partner follows its base and is left out.
+func (m *DefaultManager) updateExitNodeStatus(clientRoutes route.HAMap) {
+	var candidates []peer.ExitNodeCandidate
+	for haID, routes := range clientRoutes {
+		if !m.isExitNodeRoute(routes) {
+			continue
+		}
+		netID := haID.NetID()
+		if strings.HasSuffix(string(netID), route.V6ExitSuffix) {
+			continue
+		}
+		selected := m.routeSelector.IsSelected(netID)
+		for _, r := range routes {
+			candidates = append(candidates, peer.ExitNodeCandidate{
+				NetID:    string(netID),
+				Network:  r.Network.String(),
+				PeerKey:  r.Peer,
+				Selected: selected,
+			})
+		}
+	}
+	m.statusRecorder.UpdateExitNodes(candidates)
+}
+
 func (m *DefaultManager) isExitNodeRoute(routes []*route.Route) bool {
 	if len(routes) == 0 {
 		return false
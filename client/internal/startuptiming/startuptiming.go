@@ -0,0 +1,151 @@
+// Package startuptiming records how long each phase of the client startup takes,
+// from loading the config until the first network map is applied.
+package startuptiming
+
+import (
+	"sync"
+	"time"
+)
+
+// Startup phases in the order they usually run.
+const (
+	PhaseConfigLoad        = "config_load"
+	PhaseManagementConnect = "management_connect"
+	PhaseManagementLogin   = "management_login"
+	PhaseSignalConnect     = "signal_connect"
+	PhaseRelayConnect      = "relay_connect"
+	PhaseInterfaceCreate   = "interface_create"
+	PhaseFirewall          = "firewall"
+	PhaseEngineStart       = "engine_start"
+	PhaseManagementSync    = "management_sync"
+	PhaseRouteInstall      = "route_install"
+)
+
+// Phase is a single timed startup phase.
+type Phase struct {
+	Name string
+	// Attempt is the connect attempt the phase belongs to, starting at 1.
+	Attempt  int
+	Started  time.Time
+	Duration time.Duration
+	// Err is the error the phase ended with, empty on success.
+	Err string
+}
+
+// Snapshot is a point-in-time copy of the recorded startup.
+type Snapshot struct {
+	StartedAt time.Time
+	Phases    []Phase
+	// Complete is set once the first network map has been applied.
+	Complete bool
+	// Total is the time from the start until completion, or until now if startup is still running.
+	Total time.Duration
+}
+
+// Recorder collects startup phase durations. A nil Recorder is valid and records nothing.
+type Recorder struct {
+	mu          sync.Mutex
+	startedAt   time.Time
+	completedAt time.Time
+	attempt     int
+	phases      []Phase
+}
+
+// New returns an empty recorder.
+func New() *Recorder {
+	return &Recorder{}
+}
+
+// Begin discards any previous recording and starts timing a new startup.
+func (r *Recorder) Begin() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.startedAt = time.Now()
+	r.completedAt = time.Time{}
+	r.attempt = 0
+	r.phases = nil
+}
+
+// NextAttempt marks the start of a connect attempt. Phases recorded afterward belong to it.
+func (r *Recorder) NextAttempt() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.startedAt.IsZero() || !r.completedAt.IsZero() {
+		return
+	}
+	r.attempt++
+}
+
+// Start begins timing the named phase and returns a function ending it with the phase's error, if any.
+// Phases started before Begin or after the startup completed are ignored.
+func (r *Recorder) Start(name string) func(err error) {
+	if r == nil {
+		return func(error) {}
+	}
+
+	started := time.Now()
+	return func(err error) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		if r.startedAt.IsZero() || !r.completedAt.IsZero() {
+			return
+		}
+
+		phase := Phase{
+			Name:     name,
+			Attempt:  max(r.attempt, 1),
+			Started:  started,
+			Duration: time.Since(started),
+		}
+		if err != nil {
+			phase.Err = err.Error()
+		}
+		r.phases = append(r.phases, phase)
+	}
+}
+
+// Complete marks the startup as finished. Later phases are ignored until the next Begin.
+func (r *Recorder) Complete() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.startedAt.IsZero() || !r.completedAt.IsZero() {
+		return
+	}
+	r.completedAt = time.Now()
+}
+
+// Snapshot returns a copy of the recorded startup.
+func (r *Recorder) Snapshot() Snapshot {
+	if r == nil {
+		return Snapshot{}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := Snapshot{
+		StartedAt: r.startedAt,
+		Phases:    append([]Phase(nil), r.phases...),
+		Complete:  !r.completedAt.IsZero(),
+	}
+	switch {
+	case r.startedAt.IsZero():
+	case s.Complete:
+		s.Total = r.completedAt.Sub(r.startedAt)
+	default:
+		s.Total = time.Since(r.startedAt)
+	}
+	return s
+}
@@ -0,0 +1,49 @@
+package startuptiming
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder(t *testing.T) {
+	r := New()
+
+	r.Start(PhaseConfigLoad)(nil)
+	assert.Empty(t, r.Snapshot().Phases, "phases before Begin must be ignored")
+
+	r.Begin()
+	r.Start(PhaseConfigLoad)(nil)
+	r.NextAttempt()
+	r.Start(PhaseManagementLogin)(errors.New("connection refused"))
+	r.NextAttempt()
+	r.Start(PhaseManagementLogin)(nil)
+
+	snapshot := r.Snapshot()
+	assert.False(t, snapshot.Complete)
+	require.Len(t, snapshot.Phases, 3)
+	assert.Equal(t, 1, snapshot.Phases[0].Attempt)
+	assert.Equal(t, "connection refused", snapshot.Phases[1].Err)
+	assert.Equal(t, 2, snapshot.Phases[2].Attempt)
+
+	r.Complete()
+	r.Start(PhaseRouteInstall)(nil)
+
+	snapshot = r.Snapshot()
+	assert.True(t, snapshot.Complete)
+	assert.Len(t, snapshot.Phases, 3, "phases after completion must be ignored")
+
+	r.Begin()
+	assert.Empty(t, r.Snapshot().Phases, "Begin must reset the recording")
+}
+
+func TestNilRecorder(t *testing.T) {
+	var r *Recorder
+	r.Begin()
+	r.NextAttempt()
+	r.Start(PhaseEngineStart)(nil)
+	r.Complete()
+	assert.Equal(t, Snapshot{}, r.Snapshot())
+}
@@ -88,6 +88,29 @@ func (s *diskStore) Get() (*mgmProto.SyncResponse, error) {
 	return resp, nil
 }
 
+// History returns only the latest sync response; the disk store keeps no history.
+func (s *diskStore) History(n int) ([]Snapshot, error) {
+	if n < 1 {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	stat, err := os.Stat(s.path)
+	s.mu.Unlock()
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("stat sync response file %s: %w", s.path, err)
+	}
+
+	resp, err := s.Get()
+	if err != nil || resp == nil {
+		return nil, err
+	}
+	return []Snapshot{{ReceivedAt: stat.ModTime(), Response: resp}}, nil
+}
+
 func (s *diskStore) Clear() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -3,7 +3,7 @@
 package syncstore
 
 // New returns the platform default store. On all non-iOS platforms the sync
-// response is kept in memory; dir is unused.
+// responses are kept in memory, with a history of the last few; dir is unused.
 func New(_ string) Store {
-	return NewMemoryStore()
+	return NewMemoryStore(historySize())
 }
@@ -3,6 +3,7 @@ package syncstore
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/protobuf/proto"
@@ -10,28 +11,43 @@ import (
 	mgmProto "github.com/netbirdio/netbird/shared/management/proto"
 )
 
-// memoryStore keeps the latest sync response in memory.
+// memoryStore keeps the latest sync responses in memory, up to historySize of them.
 type memoryStore struct {
-	mu     sync.RWMutex
-	latest *mgmProto.SyncResponse
+	mu          sync.RWMutex
+	historySize int
+	// history holds the stored sync responses, oldest first.
+	history []Snapshot
 }
 
-// NewMemoryStore returns a Store that keeps the sync response in memory.
-func NewMemoryStore() Store {
-	return &memoryStore{}
+// NewMemoryStore returns a Store that keeps the last historySize sync responses in memory.
+// A historySize below 1 keeps only the latest one.
+func NewMemoryStore(historySize int) Store {
+	return &memoryStore{historySize: max(historySize, 1)}
 }
 
 func (s *memoryStore) Set(resp *mgmProto.SyncResponse) error {
+	if resp == nil {
+		return s.Clear()
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.latest = resp
+	s.history = append(s.history, Snapshot{ReceivedAt: time.Now(), Response: resp})
+	if len(s.history) > s.historySize {
+		// drop the reference so the oldest response can be collected
+		s.history[0] = Snapshot{}
+		s.history = s.history[1:]
+	}
 	return nil
 }
 
 func (s *memoryStore) Get() (*mgmProto.SyncResponse, error) {
 	s.mu.RLock()
-	latest := s.latest
+	var latest *mgmProto.SyncResponse
+	if len(s.history) > 0 {
+		latest = s.history[len(s.history)-1].Response
+	}
 	s.mu.RUnlock()
 
 	if latest == nil {
@@ -40,17 +56,42 @@ func (s *memoryStore) Get() (*mgmProto.SyncResponse, error) {
 	}
 
 	log.Debugf("retrieving latest sync response with size %d bytes", proto.Size(latest))
-	sr, ok := proto.Clone(latest).(*mgmProto.SyncResponse)
-	if !ok {
-		return nil, fmt.Errorf("clone sync response")
+	return cloneSyncResponse(latest)
+}
+
+func (s *memoryStore) History(n int) ([]Snapshot, error) {
+	if n < 1 {
+		return nil, nil
 	}
-	return sr, nil
+
+	s.mu.RLock()
+	stored := make([]Snapshot, len(s.history))
+	copy(stored, s.history)
+	s.mu.RUnlock()
+
+	snapshots := make([]Snapshot, 0, min(n, len(stored)))
+	for i := len(stored) - 1; i >= 0 && len(snapshots) < n; i-- {
+		resp, err := cloneSyncResponse(stored[i].Response)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, Snapshot{ReceivedAt: stored[i].ReceivedAt, Response: resp})
+	}
+	return snapshots, nil
 }
 
 func (s *memoryStore) Clear() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.latest = nil
+	s.history = nil
 	return nil
 }
+
+func cloneSyncResponse(resp *mgmProto.SyncResponse) (*mgmProto.SyncResponse, error) {
+	sr, ok := proto.Clone(resp).(*mgmProto.SyncResponse)
+	if !ok {
+		return nil, fmt.Errorf("clone sync response")
+	}
+	return sr, nil
+}
@@ -9,9 +9,28 @@
 package syncstore
 
 import (
+	"os"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
 	mgmProto "github.com/netbirdio/netbird/shared/management/proto"
 )
 
+const (
+	// DefaultHistorySize is the number of sync responses kept by stores that retain history.
+	DefaultHistorySize = 5
+	// EnvHistorySize overrides DefaultHistorySize.
+	EnvHistorySize = "NB_SYNC_RESPONSE_HISTORY_SIZE"
+)
+
+// Snapshot is a stored sync response and the time it was stored.
+type Snapshot struct {
+	ReceivedAt time.Time
+	Response   *mgmProto.SyncResponse
+}
+
 // Store persists the latest sync response and returns it on demand.
 //
 // Implementations must be safe for concurrent use.
@@ -23,7 +42,26 @@ type Store interface {
 	// The returned value is an independent copy that the caller may retain.
 	Get() (*mgmProto.SyncResponse, error)
 
+	// History returns up to n stored sync responses, newest first. Stores that
+	// keep no history return only the latest one. The returned responses are
+	// independent copies.
+	History(n int) ([]Snapshot, error)
+
 	// Clear removes any stored sync response. It is safe to call when nothing
 	// is stored.
 	Clear() error
 }
+
+// historySize returns the configured number of sync responses to keep.
+func historySize() int {
+	value, ok := os.LookupEnv(EnvHistorySize)
+	if !ok {
+		return DefaultHistorySize
+	}
+	size, err := strconv.Atoi(value)
+	if err != nil || size < 1 {
+		log.Warnf("invalid %s %q, using %d", EnvHistorySize, value, DefaultHistorySize)
+		return DefaultHistorySize
+	}
+	return size
+}
@@ -233,12 +233,16 @@ func (c *Client) DebugBundle(anonymize bool) (string, error) {
 		deps.SyncResponse = resp
 
 		if e := cc.Engine(); e != nil {
+			deps.Policy = e.GetBundlePolicy()
 			deps.RefreshStatus = func() {
 				e.RunHealthProbes(context.Background(), true)
 			}
 			if cm := e.GetClientMetrics(); cm != nil {
 				deps.ClientMetrics = cm
 			}
+			if dnsState, ok := e.GetDNSServer().(debug.DNSStateSource); ok {
+				deps.DNSState = dnsState
+			}
 		}
 	}
 
@@ -246,7 +250,7 @@ func (c *Client) DebugBundle(anonymize bool) (string, error) {
 		deps,
 		debug.BundleConfig{
 			Anonymize:         anonymize,
-			IncludeSystemInfo: true,
+			IncludeSystemInfo: debug.AllSystemInfo(),
 		},
 	)
 
@@ -192,7 +192,7 @@ func (x SystemEvent_Severity) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SystemEvent_Severity.Descriptor instead.
 func (SystemEvent_Severity) EnumDescriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{53, 0}
+	return file_daemon_proto_rawDescGZIP(), []int{109, 0}
 }
 
 type SystemEvent_Category int32
@@ -247,7 +247,7 @@ func (x SystemEvent_Category) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SystemEvent_Category.Descriptor instead.
 func (SystemEvent_Category) EnumDescriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{53, 1}
+	return file_daemon_proto_rawDescGZIP(), []int{109, 1}
 }
 
 type EmptyRequest struct {
@@ -1451,6 +1451,105 @@ func (x *GetConfigResponse) GetMDMManagedFields() []string {
 	return nil
 }
 
+type GetEffectiveConfigRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// showSecrets includes the private, pre-shared and SSH keys instead of redacting them.
+	ShowSecrets   bool `protobuf:"varint,1,opt,name=showSecrets,proto3" json:"showSecrets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEffectiveConfigRequest) Reset() {
+	*x = GetEffectiveConfigRequest{}
+	mi := &file_daemon_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEffectiveConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEffectiveConfigRequest) ProtoMessage() {}
+
+func (x *GetEffectiveConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEffectiveConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetEffectiveConfigRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetEffectiveConfigRequest) GetShowSecrets() bool {
+	if x != nil {
+		return x.ShowSecrets
+	}
+	return false
+}
+
+type GetEffectiveConfigResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// config is the effective configuration as JSON, in the format of the profile file.
+	Config string `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	// mDMManagedFields lists the fields enforced by MDM policy.
+	MDMManagedFields []string `protobuf:"bytes,2,rep,name=mDMManagedFields,proto3" json:"mDMManagedFields,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetEffectiveConfigResponse) Reset() {
+	*x = GetEffectiveConfigResponse{}
+	mi := &file_daemon_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEffectiveConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEffectiveConfigResponse) ProtoMessage() {}
+
+func (x *GetEffectiveConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEffectiveConfigResponse.ProtoReflect.Descriptor instead.
+func (*GetEffectiveConfigResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetEffectiveConfigResponse) GetConfig() string {
+	if x != nil {
+		return x.Config
+	}
+	return ""
+}
+
+func (x *GetEffectiveConfigResponse) GetMDMManagedFields() []string {
+	if x != nil {
+		return x.MDMManagedFields
+	}
+	return nil
+}
+
 // PeerState contains the latest state of a peer
 type PeerState struct {
 	state                      protoimpl.MessageState `protogen:"open.v1"`
@@ -1473,13 +1572,18 @@ type PeerState struct {
 	RelayAddress               string                 `protobuf:"bytes,18,opt,name=relayAddress,proto3" json:"relayAddress,omitempty"`
 	SshHostKey                 []byte                 `protobuf:"bytes,19,opt,name=sshHostKey,proto3" json:"sshHostKey,omitempty"`
 	Ipv6                       string                 `protobuf:"bytes,20,opt,name=ipv6,proto3" json:"ipv6,omitempty"`
-	unknownFields              protoimpl.UnknownFields
-	sizeCache                  protoimpl.SizeCache
+	// connHistory holds the recent connection events of the peer, oldest first.
+	ConnHistory []*PeerConnEvent `protobuf:"bytes,21,rep,name=connHistory,proto3" json:"connHistory,omitempty"`
+	// groups are the IDs of the groups the peer is in, from the network map. Management only sends
+	// them with the component network map, and the All group is left out.
+	Groups        []string `protobuf:"bytes,22,rep,name=groups,proto3" json:"groups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PeerState) Reset() {
 	*x = PeerState{}
-	mi := &file_daemon_proto_msgTypes[13]
+	mi := &file_daemon_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1491,7 +1595,7 @@ func (x *PeerState) String() string {
 func (*PeerState) ProtoMessage() {}
 
 func (x *PeerState) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[13]
+	mi := &file_daemon_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1504,7 +1608,7 @@ func (x *PeerState) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PeerState.ProtoReflect.Descriptor instead.
 func (*PeerState) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{13}
+	return file_daemon_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *PeerState) GetIP() string {
@@ -1640,6 +1744,82 @@ func (x *PeerState) GetIpv6() string {
 	return ""
 }
 
+func (x *PeerState) GetConnHistory() []*PeerConnEvent {
+	if x != nil {
+		return x.ConnHistory
+	}
+	return nil
+}
+
+func (x *PeerState) GetGroups() []string {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+// PeerConnEvent is an entry of the connection history of a peer, e.g. an ICE or relay connection
+// coming up or going down, a sent offer or a WireGuard handshake timeout.
+type PeerConnEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Event         string                 `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
+	Detail        string                 `protobuf:"bytes,3,opt,name=detail,proto3" json:"detail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeerConnEvent) Reset() {
+	*x = PeerConnEvent{}
+	mi := &file_daemon_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeerConnEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerConnEvent) ProtoMessage() {}
+
+func (x *PeerConnEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerConnEvent.ProtoReflect.Descriptor instead.
+func (*PeerConnEvent) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PeerConnEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *PeerConnEvent) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *PeerConnEvent) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
 // LocalPeerState contains the latest state of the local peer
 type LocalPeerState struct {
 	state               protoimpl.MessageState `protogen:"open.v1"`
@@ -1658,7 +1838,7 @@ type LocalPeerState struct {
 
 func (x *LocalPeerState) Reset() {
 	*x = LocalPeerState{}
-	mi := &file_daemon_proto_msgTypes[14]
+	mi := &file_daemon_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1670,7 +1850,7 @@ func (x *LocalPeerState) String() string {
 func (*LocalPeerState) ProtoMessage() {}
 
 func (x *LocalPeerState) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[14]
+	mi := &file_daemon_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1683,7 +1863,7 @@ func (x *LocalPeerState) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LocalPeerState.ProtoReflect.Descriptor instead.
 func (*LocalPeerState) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{14}
+	return file_daemon_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *LocalPeerState) GetIP() string {
@@ -1761,7 +1941,7 @@ type SignalState struct {
 
 func (x *SignalState) Reset() {
 	*x = SignalState{}
-	mi := &file_daemon_proto_msgTypes[15]
+	mi := &file_daemon_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1773,7 +1953,7 @@ func (x *SignalState) String() string {
 func (*SignalState) ProtoMessage() {}
 
 func (x *SignalState) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[15]
+	mi := &file_daemon_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1786,7 +1966,7 @@ func (x *SignalState) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignalState.ProtoReflect.Descriptor instead.
 func (*SignalState) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{15}
+	return file_daemon_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *SignalState) GetURL() string {
@@ -1812,17 +1992,24 @@ func (x *SignalState) GetError() string {
 
 // ManagementState contains the latest state of a management connection
 type ManagementState struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	URL           string                 `protobuf:"bytes,1,opt,name=URL,proto3" json:"URL,omitempty"`
-	Connected     bool                   `protobuf:"varint,2,opt,name=connected,proto3" json:"connected,omitempty"`
-	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	URL       string                 `protobuf:"bytes,1,opt,name=URL,proto3" json:"URL,omitempty"`
+	Connected bool                   `protobuf:"varint,2,opt,name=connected,proto3" json:"connected,omitempty"`
+	Error     string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// clockOffset is how far the local clock is ahead of the management server, unset before it was measured.
+	ClockOffset *durationpb.Duration `protobuf:"bytes,4,opt,name=clockOffset,proto3" json:"clockOffset,omitempty"`
+	// capabilities are the capabilities the server offered in the last full sync, derived from its
+	// settings, e.g. flow-logging or posture-checks.
+	Capabilities []string `protobuf:"bytes,5,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	// syncVersion is the sync message version of the last full sync.
+	SyncVersion   int32 `protobuf:"varint,6,opt,name=syncVersion,proto3" json:"syncVersion,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ManagementState) Reset() {
 	*x = ManagementState{}
-	mi := &file_daemon_proto_msgTypes[16]
+	mi := &file_daemon_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1834,7 +2021,7 @@ func (x *ManagementState) String() string {
 func (*ManagementState) ProtoMessage() {}
 
 func (x *ManagementState) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[16]
+	mi := &file_daemon_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1847,7 +2034,7 @@ func (x *ManagementState) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ManagementState.ProtoReflect.Descriptor instead.
 func (*ManagementState) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{16}
+	return file_daemon_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *ManagementState) GetURL() string {
@@ -1871,6 +2058,27 @@ func (x *ManagementState) GetError() string {
 	return ""
 }
 
+func (x *ManagementState) GetClockOffset() *durationpb.Duration {
+	if x != nil {
+		return x.ClockOffset
+	}
+	return nil
+}
+
+func (x *ManagementState) GetCapabilities() []string {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+func (x *ManagementState) GetSyncVersion() int32 {
+	if x != nil {
+		return x.SyncVersion
+	}
+	return 0
+}
+
 // RelayState contains the latest state of the relay
 type RelayState struct {
 	state     protoimpl.MessageState `protogen:"open.v1"`
@@ -1879,14 +2087,17 @@ type RelayState struct {
 	Error     string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
 	// transport is the negotiated relay transport (e.g. "ws", "quic"),
 	// empty for stun/turn probes or when not connected.
-	Transport     string `protobuf:"bytes,4,opt,name=transport,proto3" json:"transport,omitempty"`
+	Transport string `protobuf:"bytes,4,opt,name=transport,proto3" json:"transport,omitempty"`
+	// latency is the round trip of the relay auth handshake, unset for
+	// stun/turn probes or when not connected.
+	Latency       *durationpb.Duration `protobuf:"bytes,5,opt,name=latency,proto3" json:"latency,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RelayState) Reset() {
 	*x = RelayState{}
-	mi := &file_daemon_proto_msgTypes[17]
+	mi := &file_daemon_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1898,7 +2109,7 @@ func (x *RelayState) String() string {
 func (*RelayState) ProtoMessage() {}
 
 func (x *RelayState) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[17]
+	mi := &file_daemon_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1911,7 +2122,7 @@ func (x *RelayState) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RelayState.ProtoReflect.Descriptor instead.
 func (*RelayState) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{17}
+	return file_daemon_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *RelayState) GetURI() string {
@@ -1942,6 +2153,13 @@ func (x *RelayState) GetTransport() string {
 	return ""
 }
 
+func (x *RelayState) GetLatency() *durationpb.Duration {
+	if x != nil {
+		return x.Latency
+	}
+	return nil
+}
+
 type NSGroupState struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Servers       []string               `protobuf:"bytes,1,rep,name=servers,proto3" json:"servers,omitempty"`
@@ -1954,7 +2172,7 @@ type NSGroupState struct {
 
 func (x *NSGroupState) Reset() {
 	*x = NSGroupState{}
-	mi := &file_daemon_proto_msgTypes[18]
+	mi := &file_daemon_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1966,7 +2184,7 @@ func (x *NSGroupState) String() string {
 func (*NSGroupState) ProtoMessage() {}
 
 func (x *NSGroupState) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[18]
+	mi := &file_daemon_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1979,7 +2197,7 @@ func (x *NSGroupState) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NSGroupState.ProtoReflect.Descriptor instead.
 func (*NSGroupState) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{18}
+	return file_daemon_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *NSGroupState) GetServers() []string {
@@ -2024,7 +2242,7 @@ type SSHSessionInfo struct {
 
 func (x *SSHSessionInfo) Reset() {
 	*x = SSHSessionInfo{}
-	mi := &file_daemon_proto_msgTypes[19]
+	mi := &file_daemon_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2036,7 +2254,7 @@ func (x *SSHSessionInfo) String() string {
 func (*SSHSessionInfo) ProtoMessage() {}
 
 func (x *SSHSessionInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[19]
+	mi := &file_daemon_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2049,7 +2267,7 @@ func (x *SSHSessionInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SSHSessionInfo.ProtoReflect.Descriptor instead.
 func (*SSHSessionInfo) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{19}
+	return file_daemon_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *SSHSessionInfo) GetUsername() string {
@@ -2098,7 +2316,7 @@ type SSHServerState struct {
 
 func (x *SSHServerState) Reset() {
 	*x = SSHServerState{}
-	mi := &file_daemon_proto_msgTypes[20]
+	mi := &file_daemon_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2110,7 +2328,7 @@ func (x *SSHServerState) String() string {
 func (*SSHServerState) ProtoMessage() {}
 
 func (x *SSHServerState) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[20]
+	mi := &file_daemon_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2123,7 +2341,7 @@ func (x *SSHServerState) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SSHServerState.ProtoReflect.Descriptor instead.
 func (*SSHServerState) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{20}
+	return file_daemon_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *SSHServerState) GetEnabled() bool {
@@ -2158,13 +2376,20 @@ type FullStatus struct {
 	// on it to know when to re-fetch ListNetworks via the push stream, instead
 	// of polling on every status snapshot.
 	NetworksRevision uint64 `protobuf:"varint,11,opt,name=networksRevision,proto3" json:"networksRevision,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	// daemonState reports the uptime and restarts of the daemon, also while disconnected.
+	DaemonState *DaemonState `protobuf:"bytes,12,opt,name=daemonState,proto3" json:"daemonState,omitempty"`
+	// exitNodes lists every peer offering an exit node, with the health of its connection.
+	ExitNodes []*ExitNodeState `protobuf:"bytes,13,rep,name=exitNodes,proto3" json:"exitNodes,omitempty"`
+	// posture is the most recent evaluation of the posture checks the management server asked the
+	// client to gather, unset before the first one.
+	Posture       *PostureState `protobuf:"bytes,14,opt,name=posture,proto3" json:"posture,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *FullStatus) Reset() {
 	*x = FullStatus{}
-	mi := &file_daemon_proto_msgTypes[21]
+	mi := &file_daemon_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2176,7 +2401,7 @@ func (x *FullStatus) String() string {
 func (*FullStatus) ProtoMessage() {}
 
 func (x *FullStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[21]
+	mi := &file_daemon_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2189,7 +2414,7 @@ func (x *FullStatus) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FullStatus.ProtoReflect.Descriptor instead.
 func (*FullStatus) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{21}
+	return file_daemon_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *FullStatus) GetManagementState() *ManagementState {
@@ -2269,28 +2494,54 @@ func (x *FullStatus) GetNetworksRevision() uint64 {
 	return 0
 }
 
-// Networks
-type ListNetworksRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+func (x *FullStatus) GetDaemonState() *DaemonState {
+	if x != nil {
+		return x.DaemonState
+	}
+	return nil
+}
+
+func (x *FullStatus) GetExitNodes() []*ExitNodeState {
+	if x != nil {
+		return x.ExitNodes
+	}
+	return nil
+}
+
+func (x *FullStatus) GetPosture() *PostureState {
+	if x != nil {
+		return x.Posture
+	}
+	return nil
+}
+
+// PostureState holds the local results of the posture checks. The management server evaluates
+// them and decides the access, only process checks are gathered by the client.
+type PostureState struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	EvaluatedAt *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=evaluatedAt,proto3" json:"evaluatedAt,omitempty"`
+	Checks      []*PostureCheckState   `protobuf:"bytes,2,rep,name=checks,proto3" json:"checks,omitempty"`
+	// error is set when gathering or reporting the results failed or timed out.
+	Error         string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListNetworksRequest) Reset() {
-	*x = ListNetworksRequest{}
-	mi := &file_daemon_proto_msgTypes[22]
+func (x *PostureState) Reset() {
+	*x = PostureState{}
+	mi := &file_daemon_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListNetworksRequest) String() string {
+func (x *PostureState) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListNetworksRequest) ProtoMessage() {}
+func (*PostureState) ProtoMessage() {}
 
-func (x *ListNetworksRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[22]
+func (x *PostureState) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2301,33 +2552,55 @@ func (x *ListNetworksRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListNetworksRequest.ProtoReflect.Descriptor instead.
-func (*ListNetworksRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use PostureState.ProtoReflect.Descriptor instead.
+func (*PostureState) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{25}
 }
 
-type ListNetworksResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Routes        []*Network             `protobuf:"bytes,1,rep,name=routes,proto3" json:"routes,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *PostureState) GetEvaluatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EvaluatedAt
+	}
+	return nil
 }
 
-func (x *ListNetworksResponse) Reset() {
-	*x = ListNetworksResponse{}
-	mi := &file_daemon_proto_msgTypes[23]
+func (x *PostureState) GetChecks() []*PostureCheckState {
+	if x != nil {
+		return x.Checks
+	}
+	return nil
+}
+
+func (x *PostureState) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// PostureCheckState is a process check, it passes when all of its processes run.
+type PostureCheckState struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Processes     []*PostureProcessState `protobuf:"bytes,1,rep,name=processes,proto3" json:"processes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostureCheckState) Reset() {
+	*x = PostureCheckState{}
+	mi := &file_daemon_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListNetworksResponse) String() string {
+func (x *PostureCheckState) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListNetworksResponse) ProtoMessage() {}
+func (*PostureCheckState) ProtoMessage() {}
 
-func (x *ListNetworksResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[23]
+func (x *PostureCheckState) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2338,42 +2611,42 @@ func (x *ListNetworksResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListNetworksResponse.ProtoReflect.Descriptor instead.
-func (*ListNetworksResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use PostureCheckState.ProtoReflect.Descriptor instead.
+func (*PostureCheckState) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *ListNetworksResponse) GetRoutes() []*Network {
+func (x *PostureCheckState) GetProcesses() []*PostureProcessState {
 	if x != nil {
-		return x.Routes
+		return x.Processes
 	}
 	return nil
 }
 
-type SelectNetworksRequest struct {
+type PostureProcessState struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	NetworkIDs    []string               `protobuf:"bytes,1,rep,name=networkIDs,proto3" json:"networkIDs,omitempty"`
-	Append        bool                   `protobuf:"varint,2,opt,name=append,proto3" json:"append,omitempty"`
-	All           bool                   `protobuf:"varint,3,opt,name=all,proto3" json:"all,omitempty"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Exists        bool                   `protobuf:"varint,2,opt,name=exists,proto3" json:"exists,omitempty"`
+	Running       bool                   `protobuf:"varint,3,opt,name=running,proto3" json:"running,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SelectNetworksRequest) Reset() {
-	*x = SelectNetworksRequest{}
-	mi := &file_daemon_proto_msgTypes[24]
+func (x *PostureProcessState) Reset() {
+	*x = PostureProcessState{}
+	mi := &file_daemon_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SelectNetworksRequest) String() string {
+func (x *PostureProcessState) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SelectNetworksRequest) ProtoMessage() {}
+func (*PostureProcessState) ProtoMessage() {}
 
-func (x *SelectNetworksRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[24]
+func (x *PostureProcessState) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2384,53 +2657,66 @@ func (x *SelectNetworksRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SelectNetworksRequest.ProtoReflect.Descriptor instead.
-func (*SelectNetworksRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use PostureProcessState.ProtoReflect.Descriptor instead.
+func (*PostureProcessState) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *SelectNetworksRequest) GetNetworkIDs() []string {
+func (x *PostureProcessState) GetPath() string {
 	if x != nil {
-		return x.NetworkIDs
+		return x.Path
 	}
-	return nil
+	return ""
 }
 
-func (x *SelectNetworksRequest) GetAppend() bool {
+func (x *PostureProcessState) GetExists() bool {
 	if x != nil {
-		return x.Append
+		return x.Exists
 	}
 	return false
 }
 
-func (x *SelectNetworksRequest) GetAll() bool {
+func (x *PostureProcessState) GetRunning() bool {
 	if x != nil {
-		return x.All
+		return x.Running
 	}
 	return false
 }
 
-type SelectNetworksResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+type ExitNodeState struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// netID is the network ID of the exit node route; peers sharing it are HA candidates.
+	NetID      string               `protobuf:"bytes,1,opt,name=netID,proto3" json:"netID,omitempty"`
+	Network    string               `protobuf:"bytes,2,opt,name=network,proto3" json:"network,omitempty"`
+	PubKey     string               `protobuf:"bytes,3,opt,name=pubKey,proto3" json:"pubKey,omitempty"`
+	Fqdn       string               `protobuf:"bytes,4,opt,name=fqdn,proto3" json:"fqdn,omitempty"`
+	IP         string               `protobuf:"bytes,5,opt,name=IP,proto3" json:"IP,omitempty"`
+	ConnStatus string               `protobuf:"bytes,6,opt,name=connStatus,proto3" json:"connStatus,omitempty"`
+	Relayed    bool                 `protobuf:"varint,7,opt,name=relayed,proto3" json:"relayed,omitempty"`
+	Latency    *durationpb.Duration `protobuf:"bytes,8,opt,name=latency,proto3" json:"latency,omitempty"`
+	// selected is set when the exit node network is selected.
+	Selected bool `protobuf:"varint,9,opt,name=selected,proto3" json:"selected,omitempty"`
+	// active is set for the peer the traffic of a selected exit node currently leaves through.
+	Active        bool `protobuf:"varint,10,opt,name=active,proto3" json:"active,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SelectNetworksResponse) Reset() {
-	*x = SelectNetworksResponse{}
-	mi := &file_daemon_proto_msgTypes[25]
+func (x *ExitNodeState) Reset() {
+	*x = ExitNodeState{}
+	mi := &file_daemon_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SelectNetworksResponse) String() string {
+func (x *ExitNodeState) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SelectNetworksResponse) ProtoMessage() {}
+func (*ExitNodeState) ProtoMessage() {}
 
-func (x *SelectNetworksResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[25]
+func (x *ExitNodeState) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2441,158 +2727,107 @@ func (x *SelectNetworksResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SelectNetworksResponse.ProtoReflect.Descriptor instead.
-func (*SelectNetworksResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{25}
-}
-
-type IPList struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Ips           []string               `protobuf:"bytes,1,rep,name=ips,proto3" json:"ips,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *IPList) Reset() {
-	*x = IPList{}
-	mi := &file_daemon_proto_msgTypes[26]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *IPList) String() string {
-	return protoimpl.X.MessageStringOf(x)
+// Deprecated: Use ExitNodeState.ProtoReflect.Descriptor instead.
+func (*ExitNodeState) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{28}
 }
 
-func (*IPList) ProtoMessage() {}
-
-func (x *IPList) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[26]
+func (x *ExitNodeState) GetNetID() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.NetID
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use IPList.ProtoReflect.Descriptor instead.
-func (*IPList) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{26}
+	return ""
 }
 
-func (x *IPList) GetIps() []string {
+func (x *ExitNodeState) GetNetwork() string {
 	if x != nil {
-		return x.Ips
+		return x.Network
 	}
-	return nil
-}
-
-type Network struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ID            string                 `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	Range         string                 `protobuf:"bytes,2,opt,name=range,proto3" json:"range,omitempty"`
-	Selected      bool                   `protobuf:"varint,3,opt,name=selected,proto3" json:"selected,omitempty"`
-	Domains       []string               `protobuf:"bytes,4,rep,name=domains,proto3" json:"domains,omitempty"`
-	ResolvedIPs   map[string]*IPList     `protobuf:"bytes,5,rep,name=resolvedIPs,proto3" json:"resolvedIPs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *Network) Reset() {
-	*x = Network{}
-	mi := &file_daemon_proto_msgTypes[27]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *Network) String() string {
-	return protoimpl.X.MessageStringOf(x)
+	return ""
 }
 
-func (*Network) ProtoMessage() {}
-
-func (x *Network) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[27]
+func (x *ExitNodeState) GetPubKey() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.PubKey
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use Network.ProtoReflect.Descriptor instead.
-func (*Network) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{27}
+func (x *ExitNodeState) GetFqdn() string {
+	if x != nil {
+		return x.Fqdn
+	}
+	return ""
 }
 
-func (x *Network) GetID() string {
+func (x *ExitNodeState) GetIP() string {
 	if x != nil {
-		return x.ID
+		return x.IP
 	}
 	return ""
 }
 
-func (x *Network) GetRange() string {
+func (x *ExitNodeState) GetConnStatus() string {
 	if x != nil {
-		return x.Range
+		return x.ConnStatus
 	}
 	return ""
 }
 
-func (x *Network) GetSelected() bool {
+func (x *ExitNodeState) GetRelayed() bool {
 	if x != nil {
-		return x.Selected
+		return x.Relayed
 	}
 	return false
 }
 
-func (x *Network) GetDomains() []string {
+func (x *ExitNodeState) GetLatency() *durationpb.Duration {
 	if x != nil {
-		return x.Domains
+		return x.Latency
 	}
 	return nil
 }
 
-func (x *Network) GetResolvedIPs() map[string]*IPList {
+func (x *ExitNodeState) GetSelected() bool {
 	if x != nil {
-		return x.ResolvedIPs
+		return x.Selected
 	}
-	return nil
+	return false
 }
 
-// ForwardingRules
-type PortInfo struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to PortSelection:
-	//
-	//	*PortInfo_Port
-	//	*PortInfo_Range_
-	PortSelection isPortInfo_PortSelection `protobuf_oneof:"portSelection"`
+func (x *ExitNodeState) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+type DaemonState struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	StartedAt *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=startedAt,proto3" json:"startedAt,omitempty"`
+	// restartCount is the number of daemon starts before this one, persisted across runs.
+	RestartCount uint32 `protobuf:"varint,2,opt,name=restartCount,proto3" json:"restartCount,omitempty"`
+	// startsLast24h counts the daemon starts within 24 hours before startedAt, including this one.
+	StartsLast24H uint32 `protobuf:"varint,3,opt,name=startsLast24h,proto3" json:"startsLast24h,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PortInfo) Reset() {
-	*x = PortInfo{}
-	mi := &file_daemon_proto_msgTypes[28]
+func (x *DaemonState) Reset() {
+	*x = DaemonState{}
+	mi := &file_daemon_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PortInfo) String() string {
+func (x *DaemonState) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PortInfo) ProtoMessage() {}
+func (*DaemonState) ProtoMessage() {}
 
-func (x *PortInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[28]
+func (x *DaemonState) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2603,78 +2838,54 @@ func (x *PortInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PortInfo.ProtoReflect.Descriptor instead.
-func (*PortInfo) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use DaemonState.ProtoReflect.Descriptor instead.
+func (*DaemonState) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *PortInfo) GetPortSelection() isPortInfo_PortSelection {
+func (x *DaemonState) GetStartedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.PortSelection
+		return x.StartedAt
 	}
 	return nil
 }
 
-func (x *PortInfo) GetPort() uint32 {
+func (x *DaemonState) GetRestartCount() uint32 {
 	if x != nil {
-		if x, ok := x.PortSelection.(*PortInfo_Port); ok {
-			return x.Port
-		}
+		return x.RestartCount
 	}
 	return 0
 }
 
-func (x *PortInfo) GetRange() *PortInfo_Range {
+func (x *DaemonState) GetStartsLast24H() uint32 {
 	if x != nil {
-		if x, ok := x.PortSelection.(*PortInfo_Range_); ok {
-			return x.Range
-		}
+		return x.StartsLast24H
 	}
-	return nil
-}
-
-type isPortInfo_PortSelection interface {
-	isPortInfo_PortSelection()
-}
-
-type PortInfo_Port struct {
-	Port uint32 `protobuf:"varint,1,opt,name=port,proto3,oneof"`
-}
-
-type PortInfo_Range_ struct {
-	Range *PortInfo_Range `protobuf:"bytes,2,opt,name=range,proto3,oneof"`
+	return 0
 }
 
-func (*PortInfo_Port) isPortInfo_PortSelection() {}
-
-func (*PortInfo_Range_) isPortInfo_PortSelection() {}
-
-type ForwardingRule struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	Protocol           string                 `protobuf:"bytes,1,opt,name=protocol,proto3" json:"protocol,omitempty"`
-	DestinationPort    *PortInfo              `protobuf:"bytes,2,opt,name=destinationPort,proto3" json:"destinationPort,omitempty"`
-	TranslatedAddress  string                 `protobuf:"bytes,3,opt,name=translatedAddress,proto3" json:"translatedAddress,omitempty"`
-	TranslatedHostname string                 `protobuf:"bytes,4,opt,name=translatedHostname,proto3" json:"translatedHostname,omitempty"`
-	TranslatedPort     *PortInfo              `protobuf:"bytes,5,opt,name=translatedPort,proto3" json:"translatedPort,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+// Networks
+type ListNetworksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ForwardingRule) Reset() {
-	*x = ForwardingRule{}
-	mi := &file_daemon_proto_msgTypes[29]
+func (x *ListNetworksRequest) Reset() {
+	*x = ListNetworksRequest{}
+	mi := &file_daemon_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ForwardingRule) String() string {
+func (x *ListNetworksRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ForwardingRule) ProtoMessage() {}
+func (*ListNetworksRequest) ProtoMessage() {}
 
-func (x *ForwardingRule) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[29]
+func (x *ListNetworksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2685,68 +2896,3399 @@ func (x *ForwardingRule) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ForwardingRule.ProtoReflect.Descriptor instead.
-func (*ForwardingRule) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{29}
+// Deprecated: Use ListNetworksRequest.ProtoReflect.Descriptor instead.
+func (*ListNetworksRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{30}
+}
+
+type ListNetworksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Routes        []*Network             `protobuf:"bytes,1,rep,name=routes,proto3" json:"routes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNetworksResponse) Reset() {
+	*x = ListNetworksResponse{}
+	mi := &file_daemon_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNetworksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNetworksResponse) ProtoMessage() {}
+
+func (x *ListNetworksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNetworksResponse.ProtoReflect.Descriptor instead.
+func (*ListNetworksResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListNetworksResponse) GetRoutes() []*Network {
+	if x != nil {
+		return x.Routes
+	}
+	return nil
+}
+
+type SelectNetworksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NetworkIDs    []string               `protobuf:"bytes,1,rep,name=networkIDs,proto3" json:"networkIDs,omitempty"`
+	Append        bool                   `protobuf:"varint,2,opt,name=append,proto3" json:"append,omitempty"`
+	All           bool                   `protobuf:"varint,3,opt,name=all,proto3" json:"all,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SelectNetworksRequest) Reset() {
+	*x = SelectNetworksRequest{}
+	mi := &file_daemon_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SelectNetworksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelectNetworksRequest) ProtoMessage() {}
+
+func (x *SelectNetworksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelectNetworksRequest.ProtoReflect.Descriptor instead.
+func (*SelectNetworksRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SelectNetworksRequest) GetNetworkIDs() []string {
+	if x != nil {
+		return x.NetworkIDs
+	}
+	return nil
+}
+
+func (x *SelectNetworksRequest) GetAppend() bool {
+	if x != nil {
+		return x.Append
+	}
+	return false
+}
+
+func (x *SelectNetworksRequest) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+type SelectNetworksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SelectNetworksResponse) Reset() {
+	*x = SelectNetworksResponse{}
+	mi := &file_daemon_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SelectNetworksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelectNetworksResponse) ProtoMessage() {}
+
+func (x *SelectNetworksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelectNetworksResponse.ProtoReflect.Descriptor instead.
+func (*SelectNetworksResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{33}
+}
+
+type IPList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ips           []string               `protobuf:"bytes,1,rep,name=ips,proto3" json:"ips,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IPList) Reset() {
+	*x = IPList{}
+	mi := &file_daemon_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IPList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IPList) ProtoMessage() {}
+
+func (x *IPList) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IPList.ProtoReflect.Descriptor instead.
+func (*IPList) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *IPList) GetIps() []string {
+	if x != nil {
+		return x.Ips
+	}
+	return nil
+}
+
+type Network struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ID            string                 `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Range         string                 `protobuf:"bytes,2,opt,name=range,proto3" json:"range,omitempty"`
+	Selected      bool                   `protobuf:"varint,3,opt,name=selected,proto3" json:"selected,omitempty"`
+	Domains       []string               `protobuf:"bytes,4,rep,name=domains,proto3" json:"domains,omitempty"`
+	ResolvedIPs   map[string]*IPList     `protobuf:"bytes,5,rep,name=resolvedIPs,proto3" json:"resolvedIPs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Network) Reset() {
+	*x = Network{}
+	mi := &file_daemon_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Network) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Network) ProtoMessage() {}
+
+func (x *Network) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Network.ProtoReflect.Descriptor instead.
+func (*Network) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *Network) GetID() string {
+	if x != nil {
+		return x.ID
+	}
+	return ""
+}
+
+func (x *Network) GetRange() string {
+	if x != nil {
+		return x.Range
+	}
+	return ""
+}
+
+func (x *Network) GetSelected() bool {
+	if x != nil {
+		return x.Selected
+	}
+	return false
+}
+
+func (x *Network) GetDomains() []string {
+	if x != nil {
+		return x.Domains
+	}
+	return nil
+}
+
+func (x *Network) GetResolvedIPs() map[string]*IPList {
+	if x != nil {
+		return x.ResolvedIPs
+	}
+	return nil
+}
+
+// ForwardingRules
+type PortInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to PortSelection:
+	//
+	//	*PortInfo_Port
+	//	*PortInfo_Range_
+	PortSelection isPortInfo_PortSelection `protobuf_oneof:"portSelection"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PortInfo) Reset() {
+	*x = PortInfo{}
+	mi := &file_daemon_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PortInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PortInfo) ProtoMessage() {}
+
+func (x *PortInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PortInfo.ProtoReflect.Descriptor instead.
+func (*PortInfo) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *PortInfo) GetPortSelection() isPortInfo_PortSelection {
+	if x != nil {
+		return x.PortSelection
+	}
+	return nil
+}
+
+func (x *PortInfo) GetPort() uint32 {
+	if x != nil {
+		if x, ok := x.PortSelection.(*PortInfo_Port); ok {
+			return x.Port
+		}
+	}
+	return 0
+}
+
+func (x *PortInfo) GetRange() *PortInfo_Range {
+	if x != nil {
+		if x, ok := x.PortSelection.(*PortInfo_Range_); ok {
+			return x.Range
+		}
+	}
+	return nil
+}
+
+type isPortInfo_PortSelection interface {
+	isPortInfo_PortSelection()
+}
+
+type PortInfo_Port struct {
+	Port uint32 `protobuf:"varint,1,opt,name=port,proto3,oneof"`
+}
+
+type PortInfo_Range_ struct {
+	Range *PortInfo_Range `protobuf:"bytes,2,opt,name=range,proto3,oneof"`
+}
+
+func (*PortInfo_Port) isPortInfo_PortSelection() {}
+
+func (*PortInfo_Range_) isPortInfo_PortSelection() {}
+
+type ForwardingRule struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Protocol           string                 `protobuf:"bytes,1,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	DestinationPort    *PortInfo              `protobuf:"bytes,2,opt,name=destinationPort,proto3" json:"destinationPort,omitempty"`
+	TranslatedAddress  string                 `protobuf:"bytes,3,opt,name=translatedAddress,proto3" json:"translatedAddress,omitempty"`
+	TranslatedHostname string                 `protobuf:"bytes,4,opt,name=translatedHostname,proto3" json:"translatedHostname,omitempty"`
+	TranslatedPort     *PortInfo              `protobuf:"bytes,5,opt,name=translatedPort,proto3" json:"translatedPort,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ForwardingRule) Reset() {
+	*x = ForwardingRule{}
+	mi := &file_daemon_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForwardingRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForwardingRule) ProtoMessage() {}
+
+func (x *ForwardingRule) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForwardingRule.ProtoReflect.Descriptor instead.
+func (*ForwardingRule) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *ForwardingRule) GetProtocol() string {
 	if x != nil {
-		return x.Protocol
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *ForwardingRule) GetDestinationPort() *PortInfo {
+	if x != nil {
+		return x.DestinationPort
+	}
+	return nil
+}
+
+func (x *ForwardingRule) GetTranslatedAddress() string {
+	if x != nil {
+		return x.TranslatedAddress
+	}
+	return ""
+}
+
+func (x *ForwardingRule) GetTranslatedHostname() string {
+	if x != nil {
+		return x.TranslatedHostname
+	}
+	return ""
+}
+
+func (x *ForwardingRule) GetTranslatedPort() *PortInfo {
+	if x != nil {
+		return x.TranslatedPort
+	}
+	return nil
+}
+
+type ForwardingRulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rules         []*ForwardingRule      `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForwardingRulesResponse) Reset() {
+	*x = ForwardingRulesResponse{}
+	mi := &file_daemon_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForwardingRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForwardingRulesResponse) ProtoMessage() {}
+
+func (x *ForwardingRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForwardingRulesResponse.ProtoReflect.Descriptor instead.
+func (*ForwardingRulesResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ForwardingRulesResponse) GetRules() []*ForwardingRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+// DebugBundler
+type DebugBundleRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Anonymize    bool                   `protobuf:"varint,1,opt,name=anonymize,proto3" json:"anonymize,omitempty"`
+	SystemInfo   bool                   `protobuf:"varint,3,opt,name=systemInfo,proto3" json:"systemInfo,omitempty"`
+	UploadURL    string                 `protobuf:"bytes,4,opt,name=uploadURL,proto3" json:"uploadURL,omitempty"`
+	LogFileCount uint32                 `protobuf:"varint,5,opt,name=logFileCount,proto3" json:"logFileCount,omitempty"`
+	CliVersion   string                 `protobuf:"bytes,6,opt,name=cliVersion,proto3" json:"cliVersion,omitempty"`
+	// anonymizePreview forces anonymization, skips the upload and returns a summary
+	// of the redacted values. The summary is never written to the bundle.
+	AnonymizePreview bool `protobuf:"varint,7,opt,name=anonymizePreview,proto3" json:"anonymizePreview,omitempty"`
+	// peerMtuProbe probes the path MTU of all connected peers and adds the results to the bundle.
+	PeerMtuProbe bool `protobuf:"varint,8,opt,name=peerMtuProbe,proto3" json:"peerMtuProbe,omitempty"`
+	// encryptionKey is an age X25519 recipient (age1...) or an OpenPGP public key, armored or binary.
+	// When set, the bundle is written encrypted to this key.
+	EncryptionKey []byte `protobuf:"bytes,9,opt,name=encryptionKey,proto3" json:"encryptionKey,omitempty"`
+	// statusFormat selects the status files in the bundle: text (default), json or both.
+	StatusFormat string `protobuf:"bytes,10,opt,name=statusFormat,proto3" json:"statusFormat,omitempty"`
+	// maxSize caps the uncompressed log content of the bundle in bytes. 0 means no cap.
+	MaxSize uint64 `protobuf:"varint,11,opt,name=maxSize,proto3" json:"maxSize,omitempty"`
+	// networkMapCount is the number of stored network maps to include, newest first.
+	// 0 and 1 include only the latest one.
+	NetworkMapCount uint32 `protobuf:"varint,12,opt,name=networkMapCount,proto3" json:"networkMapCount,omitempty"`
+	// anonymizationMap seeds the anonymizer so values are anonymized as in earlier bundles.
+	AnonymizationMap []*AnonymizationMapping `protobuf:"bytes,13,rep,name=anonymizationMap,proto3" json:"anonymizationMap,omitempty"`
+	// persistAnonymizationMap returns the updated map in the response and anonymizes the
+	// status file with the same mapping as the logs.
+	PersistAnonymizationMap bool `protobuf:"varint,14,opt,name=persistAnonymizationMap,proto3" json:"persistAnonymizationMap,omitempty"`
+	// since keeps only log lines written within this duration before the bundle is created.
+	// Rotated logs are then selected by age and logFileCount is ignored.
+	Since *durationpb.Duration `protobuf:"bytes,15,opt,name=since,proto3" json:"since,omitempty"`
+	// uploadTimeout bounds each upload attempt, including the presigned URL request.
+	// Unset uses the daemon default.
+	UploadTimeout *durationpb.Duration `protobuf:"bytes,16,opt,name=uploadTimeout,proto3" json:"uploadTimeout,omitempty"`
+	// uploadRetries is the number of upload attempts after a failed one, with exponential backoff.
+	UploadRetries uint32 `protobuf:"varint,17,opt,name=uploadRetries,proto3" json:"uploadRetries,omitempty"`
+	// profiles adds a goroutine dump, a heap profile and a CPU profile under profiles/.
+	Profiles bool `protobuf:"varint,18,opt,name=profiles,proto3" json:"profiles,omitempty"`
+	// profileCpuDuration is the length of the CPU profile. Unset uses 5 seconds, at most 1 minute.
+	ProfileCpuDuration *durationpb.Duration `protobuf:"bytes,19,opt,name=profileCpuDuration,proto3" json:"profileCpuDuration,omitempty"`
+	// peers limits the peers in the embedded status to those matching any selector by FQDN
+	// prefix or NetBird IP. The request fails when a selector matches no peer.
+	Peers []string `protobuf:"bytes,20,rep,name=peers,proto3" json:"peers,omitempty"`
+	// label is added to the bundle file name, e.g. the creation time of "debug for" interval bundles.
+	Label string `protobuf:"bytes,21,opt,name=label,proto3" json:"label,omitempty"`
+	// excludeCapture leaves a running bundle packet capture untouched instead of stopping it and
+	// adding it to this bundle.
+	ExcludeCapture bool `protobuf:"varint,22,opt,name=excludeCapture,proto3" json:"excludeCapture,omitempty"`
+	// anonymizeLevel is "full", "ips-only" or "none". It overrides anonymize when set; empty maps
+	// anonymize to "full" or "none".
+	AnonymizeLevel string `protobuf:"bytes,23,opt,name=anonymizeLevel,proto3" json:"anonymizeLevel,omitempty"`
+	// extraPaths are absolute paths or glob patterns of files added under extra/ without
+	// anonymization. Paths in directories that may hold credentials are rejected.
+	ExtraPaths []string `protobuf:"bytes,24,rep,name=extraPaths,proto3" json:"extraPaths,omitempty"`
+	// compression is "none", "gzip" or "zstd". Empty means gzip.
+	Compression string `protobuf:"bytes,25,opt,name=compression,proto3" json:"compression,omitempty"`
+	// uploadLast uploads the bundle the daemon generated last to uploadURL instead of generating a
+	// new one. All other options are ignored.
+	UploadLast bool `protobuf:"varint,26,opt,name=uploadLast,proto3" json:"uploadLast,omitempty"`
+	// skipUploadURLCheck skips checking that the upload server answers before the bundle is
+	// generated. The URL is still normalized.
+	SkipUploadURLCheck bool `protobuf:"varint,27,opt,name=skipUploadURLCheck,proto3" json:"skipUploadURLCheck,omitempty"`
+	// stagedLogsId is the id returned by StageDebugLogs. The staged copies are merged with the
+	// current logs and removed afterwards.
+	StagedLogsId string `protobuf:"bytes,28,opt,name=stagedLogsId,proto3" json:"stagedLogsId,omitempty"`
+	// logInclude keeps only the log lines matching any of these regular expressions.
+	LogInclude []string `protobuf:"bytes,29,rep,name=logInclude,proto3" json:"logInclude,omitempty"`
+	// logExclude drops the log lines matching any of these regular expressions.
+	LogExclude []string `protobuf:"bytes,30,rep,name=logExclude,proto3" json:"logExclude,omitempty"`
+	// outputDir is an absolute directory the daemon writes the bundle to instead of its temporary
+	// directory. It must exist and be writable by the daemon.
+	OutputDir string `protobuf:"bytes,31,opt,name=outputDir,proto3" json:"outputDir,omitempty"`
+	// systemInfoCollectors selects the system information collectors (os, cpu, mem, disk, env,
+	// network-interfaces, routes, ip-rules, firewall, sysctls, dns). When empty, systemInfo selects
+	// all or none.
+	SystemInfoCollectors []string `protobuf:"bytes,32,rep,name=systemInfoCollectors,proto3" json:"systemInfoCollectors,omitempty"`
+	// anonymizePatterns are regular expressions whose matches are replaced with placeholders like
+	// REDACTED-1 in anonymized files.
+	AnonymizePatterns []string `protobuf:"bytes,33,rep,name=anonymizePatterns,proto3" json:"anonymizePatterns,omitempty"`
+	// allowSecrets keeps the private, pre-shared and SSH keys and other credentials in the bundle,
+	// they are masked otherwise. Only for local debugging.
+	AllowSecrets bool `protobuf:"varint,34,opt,name=allowSecrets,proto3" json:"allowSecrets,omitempty"`
+	// splitSize writes the bundle in numbered parts of at most this many bytes, 0 writes one file.
+	// It cannot be combined with uploadURL.
+	SplitSize uint64 `protobuf:"varint,35,opt,name=splitSize,proto3" json:"splitSize,omitempty"`
+	// aclQueries are evaluated against the ACL policy and written to acl_queries.txt.
+	AclQueries []*ACLQuery `protobuf:"bytes,36,rep,name=aclQueries,proto3" json:"aclQueries,omitempty"`
+	// commandOutput is the output of the commands "netbird debug for --run" ran during the
+	// session, written to command-output.txt.
+	CommandOutput []byte `protobuf:"bytes,37,opt,name=commandOutput,proto3" json:"commandOutput,omitempty"`
+	// peerGroup limits the peers in the bundle status to the members of this group ID.
+	PeerGroup string `protobuf:"bytes,38,opt,name=peerGroup,proto3" json:"peerGroup,omitempty"`
+	// interfaceStatsStart are the interface counters at the start of a "debug for" session. The
+	// bundle adds the change since then to interface-stats.txt.
+	InterfaceStatsStart *InterfaceStatsSnapshot `protobuf:"bytes,39,opt,name=interfaceStatsStart,proto3" json:"interfaceStatsStart,omitempty"`
+	// domain scopes the bundle status, DNS state and resolved domains to this domain. It must match
+	// a configured match domain, nameserver group, NetBird zone or domain route.
+	Domain string `protobuf:"bytes,40,opt,name=domain,proto3" json:"domain,omitempty"`
+	// resourceTimeline are the runtime stats of the daemon sampled during a "debug for" session,
+	// written to resource-timeline.csv.
+	ResourceTimeline []*RuntimeStatsSample `protobuf:"bytes,41,rep,name=resourceTimeline,proto3" json:"resourceTimeline,omitempty"`
+	// logTail keeps only the last lines of each log file, counted after since and the log
+	// patterns. Zero keeps all lines.
+	LogTail uint32 `protobuf:"varint,42,opt,name=logTail,proto3" json:"logTail,omitempty"`
+	// sessionStart is the start of a "debug for" session, the state transitions since then are
+	// marked in events.json.
+	SessionStart *timestamppb.Timestamp `protobuf:"bytes,43,opt,name=sessionStart,proto3" json:"sessionStart,omitempty"`
+	// traceroute is the output of the traceroutes "debug bundle --trace-infra" ran to the
+	// management, signal and relay servers, written to traceroute.txt.
+	Traceroute []byte `protobuf:"bytes,44,opt,name=traceroute,proto3" json:"traceroute,omitempty"`
+	// incrementalLogs leaves out the log content that was already in the previous incremental or
+	// full bundle and records where the logs end for the next one.
+	IncrementalLogs bool `protobuf:"varint,45,opt,name=incrementalLogs,proto3" json:"incrementalLogs,omitempty"`
+	// fullLogs includes the whole logs and starts a new incremental series from this bundle.
+	FullLogs bool `protobuf:"varint,46,opt,name=fullLogs,proto3" json:"fullLogs,omitempty"`
+	// noLogs leaves out all logs, for a bundle of the config, status and network map only. The
+	// log selection fields are ignored.
+	NoLogs        bool `protobuf:"varint,47,opt,name=noLogs,proto3" json:"noLogs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DebugBundleRequest) Reset() {
+	*x = DebugBundleRequest{}
+	mi := &file_daemon_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DebugBundleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugBundleRequest) ProtoMessage() {}
+
+func (x *DebugBundleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugBundleRequest.ProtoReflect.Descriptor instead.
+func (*DebugBundleRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *DebugBundleRequest) GetAnonymize() bool {
+	if x != nil {
+		return x.Anonymize
+	}
+	return false
+}
+
+func (x *DebugBundleRequest) GetSystemInfo() bool {
+	if x != nil {
+		return x.SystemInfo
+	}
+	return false
+}
+
+func (x *DebugBundleRequest) GetUploadURL() string {
+	if x != nil {
+		return x.UploadURL
+	}
+	return ""
+}
+
+func (x *DebugBundleRequest) GetLogFileCount() uint32 {
+	if x != nil {
+		return x.LogFileCount
+	}
+	return 0
+}
+
+func (x *DebugBundleRequest) GetCliVersion() string {
+	if x != nil {
+		return x.CliVersion
+	}
+	return ""
+}
+
+func (x *DebugBundleRequest) GetAnonymizePreview() bool {
+	if x != nil {
+		return x.AnonymizePreview
+	}
+	return false
+}
+
+func (x *DebugBundleRequest) GetPeerMtuProbe() bool {
+	if x != nil {
+		return x.PeerMtuProbe
+	}
+	return false
+}
+
+func (x *DebugBundleRequest) GetEncryptionKey() []byte {
+	if x != nil {
+		return x.EncryptionKey
+	}
+	return nil
+}
+
+func (x *DebugBundleRequest) GetStatusFormat() string {
+	if x != nil {
+		return x.StatusFormat
+	}
+	return ""
+}
+
+func (x *DebugBundleRequest) GetMaxSize() uint64 {
+	if x != nil {
+		return x.MaxSize
+	}
+	return 0
+}
+
+func (x *DebugBundleRequest) GetNetworkMapCount() uint32 {
+	if x != nil {
+		return x.NetworkMapCount
+	}
+	return 0
+}
+
+func (x *DebugBundleRequest) GetAnonymizationMap() []*AnonymizationMapping {
+	if x != nil {
+		return x.AnonymizationMap
+	}
+	return nil
+}
+
+func (x *DebugBundleRequest) GetPersistAnonymizationMap() bool {
+	if x != nil {
+		return x.PersistAnonymizationMap
+	}
+	return false
+}
+
+func (x *DebugBundleRequest) GetSince() *durationpb.Duration {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *DebugBundleRequest) GetUploadTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.UploadTimeout
+	}
+	return nil
+}
+
+func (x *DebugBundleRequest) GetUploadRetries() uint32 {
+	if x != nil {
+		return x.UploadRetries
+	}
+	return 0
+}
+
+func (x *DebugBundleRequest) GetProfiles() bool {
+	if x != nil {
+		return x.Profiles
+	}
+	return false
+}
+
+func (x *DebugBundleRequest) GetProfileCpuDuration() *durationpb.Duration {
+	if x != nil {
+		return x.ProfileCpuDuration
+	}
+	return nil
+}
+
+func (x *DebugBundleRequest) GetPeers() []string {
+	if x != nil {
+		return x.Peers
+	}
+	return nil
+}
+
+func (x *DebugBundleRequest) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *DebugBundleRequest) GetExcludeCapture() bool {
+	if x != nil {
+		return x.ExcludeCapture
+	}
+	return false
+}
+
+func (x *DebugBundleRequest) GetAnonymizeLevel() string {
+	if x != nil {
+		return x.AnonymizeLevel
+	}
+	return ""
+}
+
+func (x *DebugBundleRequest) GetExtraPaths() []string {
+	if x != nil {
+		return x.ExtraPaths
+	}
+	return nil
+}
+
+func (x *DebugBundleRequest) GetCompression() string {
+	if x != nil {
+		return x.Compression
+	}
+	return ""
+}
+
+func (x *DebugBundleRequest) GetUploadLast() bool {
+	if x != nil {
+		return x.UploadLast
+	}
+	return false
+}
+
+func (x *DebugBundleRequest) GetSkipUploadURLCheck() bool {
+	if x != nil {
+		return x.SkipUploadURLCheck
+	}
+	return false
+}
+
+func (x *DebugBundleRequest) GetStagedLogsId() string {
+	if x != nil {
+		return x.StagedLogsId
+	}
+	return ""
+}
+
+func (x *DebugBundleRequest) GetLogInclude() []string {
+	if x != nil {
+		return x.LogInclude
+	}
+	return nil
+}
+
+func (x *DebugBundleRequest) GetLogExclude() []string {
+	if x != nil {
+		return x.LogExclude
+	}
+	return nil
+}
+
+func (x *DebugBundleRequest) GetOutputDir() string {
+	if x != nil {
+		return x.OutputDir
+	}
+	return ""
+}
+
+func (x *DebugBundleRequest) GetSystemInfoCollectors() []string {
+	if x != nil {
+		return x.SystemInfoCollectors
+	}
+	return nil
+}
+
+func (x *DebugBundleRequest) GetAnonymizePatterns() []string {
+	if x != nil {
+		return x.AnonymizePatterns
+	}
+	return nil
+}
+
+func (x *DebugBundleRequest) GetAllowSecrets() bool {
+	if x != nil {
+		return x.AllowSecrets
+	}
+	return false
+}
+
+func (x *DebugBundleRequest) GetSplitSize() uint64 {
+	if x != nil {
+		return x.SplitSize
+	}
+	return 0
+}
+
+func (x *DebugBundleRequest) GetAclQueries() []*ACLQuery {
+	if x != nil {
+		return x.AclQueries
+	}
+	return nil
+}
+
+func (x *DebugBundleRequest) GetCommandOutput() []byte {
+	if x != nil {
+		return x.CommandOutput
+	}
+	return nil
+}
+
+func (x *DebugBundleRequest) GetPeerGroup() string {
+	if x != nil {
+		return x.PeerGroup
+	}
+	return ""
+}
+
+func (x *DebugBundleRequest) GetInterfaceStatsStart() *InterfaceStatsSnapshot {
+	if x != nil {
+		return x.InterfaceStatsStart
+	}
+	return nil
+}
+
+func (x *DebugBundleRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *DebugBundleRequest) GetResourceTimeline() []*RuntimeStatsSample {
+	if x != nil {
+		return x.ResourceTimeline
+	}
+	return nil
+}
+
+func (x *DebugBundleRequest) GetLogTail() uint32 {
+	if x != nil {
+		return x.LogTail
+	}
+	return 0
+}
+
+func (x *DebugBundleRequest) GetSessionStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SessionStart
+	}
+	return nil
+}
+
+func (x *DebugBundleRequest) GetTraceroute() []byte {
+	if x != nil {
+		return x.Traceroute
+	}
+	return nil
+}
+
+func (x *DebugBundleRequest) GetIncrementalLogs() bool {
+	if x != nil {
+		return x.IncrementalLogs
+	}
+	return false
+}
+
+func (x *DebugBundleRequest) GetFullLogs() bool {
+	if x != nil {
+		return x.FullLogs
+	}
+	return false
+}
+
+func (x *DebugBundleRequest) GetNoLogs() bool {
+	if x != nil {
+		return x.NoLogs
+	}
+	return false
+}
+
+type StageDebugLogsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StageDebugLogsRequest) Reset() {
+	*x = StageDebugLogsRequest{}
+	mi := &file_daemon_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StageDebugLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StageDebugLogsRequest) ProtoMessage() {}
+
+func (x *StageDebugLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StageDebugLogsRequest.ProtoReflect.Descriptor instead.
+func (*StageDebugLogsRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{40}
+}
+
+type StageDebugLogsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id identifies the staged copies in DebugBundleRequest.stagedLogsId.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// files lists the staged log files, e.g. client.log.
+	Files         []string `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StageDebugLogsResponse) Reset() {
+	*x = StageDebugLogsResponse{}
+	mi := &file_daemon_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StageDebugLogsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StageDebugLogsResponse) ProtoMessage() {}
+
+func (x *StageDebugLogsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StageDebugLogsResponse.ProtoReflect.Descriptor instead.
+func (*StageDebugLogsResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *StageDebugLogsResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *StageDebugLogsResponse) GetFiles() []string {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+type GetInterfaceStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInterfaceStatsRequest) Reset() {
+	*x = GetInterfaceStatsRequest{}
+	mi := &file_daemon_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInterfaceStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInterfaceStatsRequest) ProtoMessage() {}
+
+func (x *GetInterfaceStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInterfaceStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetInterfaceStatsRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{42}
+}
+
+type GetInterfaceStatsResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Snapshot      *InterfaceStatsSnapshot `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInterfaceStatsResponse) Reset() {
+	*x = GetInterfaceStatsResponse{}
+	mi := &file_daemon_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInterfaceStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInterfaceStatsResponse) ProtoMessage() {}
+
+func (x *GetInterfaceStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInterfaceStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetInterfaceStatsResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetInterfaceStatsResponse) GetSnapshot() *InterfaceStatsSnapshot {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+// InterfaceStatsSnapshot holds the traffic counters of the interfaces at one point in time.
+type InterfaceStatsSnapshot struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Time  *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	// interfaces are the counters of the network interfaces that are up, without loopback.
+	Interfaces []*InterfaceCounters `protobuf:"bytes,2,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
+	// wireGuard holds the bytes sent and received over the WireGuard interface, summed over its
+	// peers. Unset when the interface is not up.
+	WireGuard     *InterfaceCounters `protobuf:"bytes,3,opt,name=wireGuard,proto3" json:"wireGuard,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InterfaceStatsSnapshot) Reset() {
+	*x = InterfaceStatsSnapshot{}
+	mi := &file_daemon_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InterfaceStatsSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InterfaceStatsSnapshot) ProtoMessage() {}
+
+func (x *InterfaceStatsSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InterfaceStatsSnapshot.ProtoReflect.Descriptor instead.
+func (*InterfaceStatsSnapshot) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *InterfaceStatsSnapshot) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *InterfaceStatsSnapshot) GetInterfaces() []*InterfaceCounters {
+	if x != nil {
+		return x.Interfaces
+	}
+	return nil
+}
+
+func (x *InterfaceStatsSnapshot) GetWireGuard() *InterfaceCounters {
+	if x != nil {
+		return x.WireGuard
+	}
+	return nil
+}
+
+type GetRuntimeStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRuntimeStatsRequest) Reset() {
+	*x = GetRuntimeStatsRequest{}
+	mi := &file_daemon_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRuntimeStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRuntimeStatsRequest) ProtoMessage() {}
+
+func (x *GetRuntimeStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRuntimeStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetRuntimeStatsRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{45}
+}
+
+type GetRuntimeStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sample        *RuntimeStatsSample    `protobuf:"bytes,1,opt,name=sample,proto3" json:"sample,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRuntimeStatsResponse) Reset() {
+	*x = GetRuntimeStatsResponse{}
+	mi := &file_daemon_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRuntimeStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRuntimeStatsResponse) ProtoMessage() {}
+
+func (x *GetRuntimeStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRuntimeStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetRuntimeStatsResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *GetRuntimeStatsResponse) GetSample() *RuntimeStatsSample {
+	if x != nil {
+		return x.Sample
+	}
+	return nil
+}
+
+// RuntimeStatsSample is a sample of the resource usage of the daemon process.
+type RuntimeStatsSample struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Time       *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Goroutines uint64                 `protobuf:"varint,2,opt,name=goroutines,proto3" json:"goroutines,omitempty"`
+	// openFds is the number of open file descriptors, -1 when the platform does not report it.
+	OpenFds int64 `protobuf:"varint,3,opt,name=openFds,proto3" json:"openFds,omitempty"`
+	// heapBytes is the memory of the live and not yet swept heap objects.
+	HeapBytes uint64 `protobuf:"varint,4,opt,name=heapBytes,proto3" json:"heapBytes,omitempty"`
+	// totalBytes is all memory mapped by the Go runtime.
+	TotalBytes    uint64 `protobuf:"varint,5,opt,name=totalBytes,proto3" json:"totalBytes,omitempty"`
+	GcCycles      uint64 `protobuf:"varint,6,opt,name=gcCycles,proto3" json:"gcCycles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RuntimeStatsSample) Reset() {
+	*x = RuntimeStatsSample{}
+	mi := &file_daemon_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuntimeStatsSample) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuntimeStatsSample) ProtoMessage() {}
+
+func (x *RuntimeStatsSample) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuntimeStatsSample.ProtoReflect.Descriptor instead.
+func (*RuntimeStatsSample) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *RuntimeStatsSample) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *RuntimeStatsSample) GetGoroutines() uint64 {
+	if x != nil {
+		return x.Goroutines
+	}
+	return 0
+}
+
+func (x *RuntimeStatsSample) GetOpenFds() int64 {
+	if x != nil {
+		return x.OpenFds
+	}
+	return 0
+}
+
+func (x *RuntimeStatsSample) GetHeapBytes() uint64 {
+	if x != nil {
+		return x.HeapBytes
+	}
+	return 0
+}
+
+func (x *RuntimeStatsSample) GetTotalBytes() uint64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
+
+func (x *RuntimeStatsSample) GetGcCycles() uint64 {
+	if x != nil {
+		return x.GcCycles
+	}
+	return 0
+}
+
+// InterfaceCounters are the traffic counters of a network interface since it was created.
+type InterfaceCounters struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	RxBytes       uint64                 `protobuf:"varint,2,opt,name=rxBytes,proto3" json:"rxBytes,omitempty"`
+	TxBytes       uint64                 `protobuf:"varint,3,opt,name=txBytes,proto3" json:"txBytes,omitempty"`
+	RxPackets     uint64                 `protobuf:"varint,4,opt,name=rxPackets,proto3" json:"rxPackets,omitempty"`
+	TxPackets     uint64                 `protobuf:"varint,5,opt,name=txPackets,proto3" json:"txPackets,omitempty"`
+	RxErrors      uint64                 `protobuf:"varint,6,opt,name=rxErrors,proto3" json:"rxErrors,omitempty"`
+	TxErrors      uint64                 `protobuf:"varint,7,opt,name=txErrors,proto3" json:"txErrors,omitempty"`
+	RxDropped     uint64                 `protobuf:"varint,8,opt,name=rxDropped,proto3" json:"rxDropped,omitempty"`
+	TxDropped     uint64                 `protobuf:"varint,9,opt,name=txDropped,proto3" json:"txDropped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InterfaceCounters) Reset() {
+	*x = InterfaceCounters{}
+	mi := &file_daemon_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InterfaceCounters) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InterfaceCounters) ProtoMessage() {}
+
+func (x *InterfaceCounters) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InterfaceCounters.ProtoReflect.Descriptor instead.
+func (*InterfaceCounters) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *InterfaceCounters) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *InterfaceCounters) GetRxBytes() uint64 {
+	if x != nil {
+		return x.RxBytes
+	}
+	return 0
+}
+
+func (x *InterfaceCounters) GetTxBytes() uint64 {
+	if x != nil {
+		return x.TxBytes
+	}
+	return 0
+}
+
+func (x *InterfaceCounters) GetRxPackets() uint64 {
+	if x != nil {
+		return x.RxPackets
+	}
+	return 0
+}
+
+func (x *InterfaceCounters) GetTxPackets() uint64 {
+	if x != nil {
+		return x.TxPackets
+	}
+	return 0
+}
+
+func (x *InterfaceCounters) GetRxErrors() uint64 {
+	if x != nil {
+		return x.RxErrors
+	}
+	return 0
+}
+
+func (x *InterfaceCounters) GetTxErrors() uint64 {
+	if x != nil {
+		return x.TxErrors
+	}
+	return 0
+}
+
+func (x *InterfaceCounters) GetRxDropped() uint64 {
+	if x != nil {
+		return x.RxDropped
+	}
+	return 0
+}
+
+func (x *InterfaceCounters) GetTxDropped() uint64 {
+	if x != nil {
+		return x.TxDropped
+	}
+	return 0
+}
+
+type DebugBundleResponse struct {
+	state                protoimpl.MessageState  `protogen:"open.v1"`
+	Path                 string                  `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	UploadedKey          string                  `protobuf:"bytes,2,opt,name=uploadedKey,proto3" json:"uploadedKey,omitempty"`
+	UploadFailureReason  string                  `protobuf:"bytes,3,opt,name=uploadFailureReason,proto3" json:"uploadFailureReason,omitempty"`
+	AnonymizationPreview []*AnonymizationSummary `protobuf:"bytes,4,rep,name=anonymizationPreview,proto3" json:"anonymizationPreview,omitempty"`
+	// truncatedLogs lists the logs shortened or left out to stay within maxSize.
+	TruncatedLogs []string `protobuf:"bytes,5,rep,name=truncatedLogs,proto3" json:"truncatedLogs,omitempty"`
+	// anonymizationMap holds all mappings of the bundle when persistAnonymizationMap was set.
+	AnonymizationMap []*AnonymizationMapping `protobuf:"bytes,6,rep,name=anonymizationMap,proto3" json:"anonymizationMap,omitempty"`
+	// anonymized reports whether the bundle is anonymized, also when the management policy forced it.
+	Anonymized bool `protobuf:"varint,7,opt,name=anonymized,proto3" json:"anonymized,omitempty"`
+	// parts lists the part files of a split bundle in order, path is the first of them.
+	Parts []string `protobuf:"bytes,8,rep,name=parts,proto3" json:"parts,omitempty"`
+	// expiresAt is when the upload server deletes the uploaded bundle, unset when it does not report it.
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DebugBundleResponse) Reset() {
+	*x = DebugBundleResponse{}
+	mi := &file_daemon_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DebugBundleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugBundleResponse) ProtoMessage() {}
+
+func (x *DebugBundleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugBundleResponse.ProtoReflect.Descriptor instead.
+func (*DebugBundleResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *DebugBundleResponse) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *DebugBundleResponse) GetUploadedKey() string {
+	if x != nil {
+		return x.UploadedKey
+	}
+	return ""
+}
+
+func (x *DebugBundleResponse) GetUploadFailureReason() string {
+	if x != nil {
+		return x.UploadFailureReason
+	}
+	return ""
+}
+
+func (x *DebugBundleResponse) GetAnonymizationPreview() []*AnonymizationSummary {
+	if x != nil {
+		return x.AnonymizationPreview
+	}
+	return nil
+}
+
+func (x *DebugBundleResponse) GetTruncatedLogs() []string {
+	if x != nil {
+		return x.TruncatedLogs
+	}
+	return nil
+}
+
+func (x *DebugBundleResponse) GetAnonymizationMap() []*AnonymizationMapping {
+	if x != nil {
+		return x.AnonymizationMap
+	}
+	return nil
+}
+
+func (x *DebugBundleResponse) GetAnonymized() bool {
+	if x != nil {
+		return x.Anonymized
+	}
+	return false
+}
+
+func (x *DebugBundleResponse) GetParts() []string {
+	if x != nil {
+		return x.Parts
+	}
+	return nil
+}
+
+func (x *DebugBundleResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type DebugBundleChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DebugBundleChunk) Reset() {
+	*x = DebugBundleChunk{}
+	mi := &file_daemon_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DebugBundleChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugBundleChunk) ProtoMessage() {}
+
+func (x *DebugBundleChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugBundleChunk.ProtoReflect.Descriptor instead.
+func (*DebugBundleChunk) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *DebugBundleChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type DebugBundleProgressEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// stage describes the part of the bundle being collected, e.g. "collecting logs".
+	Stage string `protobuf:"bytes,1,opt,name=stage,proto3" json:"stage,omitempty"`
+	// response is set on the last event only.
+	Response      *DebugBundleResponse `protobuf:"bytes,2,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DebugBundleProgressEvent) Reset() {
+	*x = DebugBundleProgressEvent{}
+	mi := &file_daemon_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DebugBundleProgressEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugBundleProgressEvent) ProtoMessage() {}
+
+func (x *DebugBundleProgressEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugBundleProgressEvent.ProtoReflect.Descriptor instead.
+func (*DebugBundleProgressEvent) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *DebugBundleProgressEvent) GetStage() string {
+	if x != nil {
+		return x.Stage
+	}
+	return ""
+}
+
+func (x *DebugBundleProgressEvent) GetResponse() *DebugBundleResponse {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+type AnonymizationSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	Count         uint32                 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Samples       []*AnonymizationSample `protobuf:"bytes,3,rep,name=samples,proto3" json:"samples,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnonymizationSummary) Reset() {
+	*x = AnonymizationSummary{}
+	mi := &file_daemon_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnonymizationSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnonymizationSummary) ProtoMessage() {}
+
+func (x *AnonymizationSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnonymizationSummary.ProtoReflect.Descriptor instead.
+func (*AnonymizationSummary) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *AnonymizationSummary) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *AnonymizationSummary) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *AnonymizationSummary) GetSamples() []*AnonymizationSample {
+	if x != nil {
+		return x.Samples
+	}
+	return nil
+}
+
+type AnonymizationSample struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Original      string                 `protobuf:"bytes,1,opt,name=original,proto3" json:"original,omitempty"`
+	Anonymized    string                 `protobuf:"bytes,2,opt,name=anonymized,proto3" json:"anonymized,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnonymizationSample) Reset() {
+	*x = AnonymizationSample{}
+	mi := &file_daemon_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnonymizationSample) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnonymizationSample) ProtoMessage() {}
+
+func (x *AnonymizationSample) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnonymizationSample.ProtoReflect.Descriptor instead.
+func (*AnonymizationSample) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *AnonymizationSample) GetOriginal() string {
+	if x != nil {
+		return x.Original
+	}
+	return ""
+}
+
+func (x *AnonymizationSample) GetAnonymized() string {
+	if x != nil {
+		return x.Anonymized
+	}
+	return ""
+}
+
+type AnonymizationMapping struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	Original      string                 `protobuf:"bytes,2,opt,name=original,proto3" json:"original,omitempty"`
+	Anonymized    string                 `protobuf:"bytes,3,opt,name=anonymized,proto3" json:"anonymized,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnonymizationMapping) Reset() {
+	*x = AnonymizationMapping{}
+	mi := &file_daemon_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnonymizationMapping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnonymizationMapping) ProtoMessage() {}
+
+func (x *AnonymizationMapping) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnonymizationMapping.ProtoReflect.Descriptor instead.
+func (*AnonymizationMapping) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *AnonymizationMapping) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *AnonymizationMapping) GetOriginal() string {
+	if x != nil {
+		return x.Original
+	}
+	return ""
+}
+
+func (x *AnonymizationMapping) GetAnonymized() string {
+	if x != nil {
+		return x.Anonymized
+	}
+	return ""
+}
+
+type GetLogLevelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLogLevelRequest) Reset() {
+	*x = GetLogLevelRequest{}
+	mi := &file_daemon_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLogLevelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLogLevelRequest) ProtoMessage() {}
+
+func (x *GetLogLevelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLogLevelRequest.ProtoReflect.Descriptor instead.
+func (*GetLogLevelRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{55}
+}
+
+type GetLogLevelResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Level LogLevel               `protobuf:"varint,1,opt,name=level,proto3,enum=daemon.LogLevel" json:"level,omitempty"`
+	// defaultLevel is the level the daemon was started with.
+	DefaultLevel LogLevel `protobuf:"varint,2,opt,name=defaultLevel,proto3,enum=daemon.LogLevel" json:"defaultLevel,omitempty"`
+	// isDefault is false when the level was changed for this session.
+	IsDefault bool `protobuf:"varint,3,opt,name=isDefault,proto3" json:"isDefault,omitempty"`
+	// components maps component names to levels overriding level for their log entries.
+	Components map[string]LogLevel `protobuf:"bytes,4,rep,name=components,proto3" json:"components,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value,enum=daemon.LogLevel"`
+	// persisted is true when the active profile config holds a log level that the daemon applies at startup.
+	Persisted     bool `protobuf:"varint,5,opt,name=persisted,proto3" json:"persisted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLogLevelResponse) Reset() {
+	*x = GetLogLevelResponse{}
+	mi := &file_daemon_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLogLevelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLogLevelResponse) ProtoMessage() {}
+
+func (x *GetLogLevelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLogLevelResponse.ProtoReflect.Descriptor instead.
+func (*GetLogLevelResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GetLogLevelResponse) GetLevel() LogLevel {
+	if x != nil {
+		return x.Level
+	}
+	return LogLevel_UNKNOWN
+}
+
+func (x *GetLogLevelResponse) GetDefaultLevel() LogLevel {
+	if x != nil {
+		return x.DefaultLevel
+	}
+	return LogLevel_UNKNOWN
+}
+
+func (x *GetLogLevelResponse) GetIsDefault() bool {
+	if x != nil {
+		return x.IsDefault
+	}
+	return false
+}
+
+func (x *GetLogLevelResponse) GetComponents() map[string]LogLevel {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *GetLogLevelResponse) GetPersisted() bool {
+	if x != nil {
+		return x.Persisted
+	}
+	return false
+}
+
+type SetLogLevelRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// level is the base level. UNKNOWN keeps the current base level, which requires components.
+	Level LogLevel `protobuf:"varint,1,opt,name=level,proto3,enum=daemon.LogLevel" json:"level,omitempty"`
+	// components maps component names (ice, relay, grpc, peer, dns, route, firewall, engine) to
+	// levels overriding the base level for their log entries. They replace any previous overrides.
+	Components map[string]LogLevel `protobuf:"bytes,2,rep,name=components,proto3" json:"components,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value,enum=daemon.LogLevel"`
+	// persist writes the level into the active profile config so it survives daemon restarts.
+	// Persisting the daemon default level without components clears the persisted level.
+	Persist bool `protobuf:"varint,3,opt,name=persist,proto3" json:"persist,omitempty"`
+	// profileName targets a profile instead of the running daemon. If it is not the active profile,
+	// the level is only written into its config and applies when the profile is next activated.
+	ProfileName string `protobuf:"bytes,4,opt,name=profileName,proto3" json:"profileName,omitempty"`
+	// username owns the targeted profile, required with profileName.
+	Username      string `protobuf:"bytes,5,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLogLevelRequest) Reset() {
+	*x = SetLogLevelRequest{}
+	mi := &file_daemon_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLogLevelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLogLevelRequest) ProtoMessage() {}
+
+func (x *SetLogLevelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLogLevelRequest.ProtoReflect.Descriptor instead.
+func (*SetLogLevelRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *SetLogLevelRequest) GetLevel() LogLevel {
+	if x != nil {
+		return x.Level
+	}
+	return LogLevel_UNKNOWN
+}
+
+func (x *SetLogLevelRequest) GetComponents() map[string]LogLevel {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *SetLogLevelRequest) GetPersist() bool {
+	if x != nil {
+		return x.Persist
+	}
+	return false
+}
+
+func (x *SetLogLevelRequest) GetProfileName() string {
+	if x != nil {
+		return x.ProfileName
+	}
+	return ""
+}
+
+func (x *SetLogLevelRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type SetLogLevelResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// unknownComponents lists the requested components that do not exist and were ignored.
+	UnknownComponents []string `protobuf:"bytes,1,rep,name=unknownComponents,proto3" json:"unknownComponents,omitempty"`
+	// persisted is true when the level was written into the profile config.
+	Persisted bool `protobuf:"varint,2,opt,name=persisted,proto3" json:"persisted,omitempty"`
+	// persistCleared is true when a persisted level was removed from the profile config.
+	PersistCleared bool `protobuf:"varint,3,opt,name=persistCleared,proto3" json:"persistCleared,omitempty"`
+	// applied is true when the level took effect in the running daemon.
+	Applied bool `protobuf:"varint,4,opt,name=applied,proto3" json:"applied,omitempty"`
+	// profileName is the display name of the profile whose level was set.
+	ProfileName   string `protobuf:"bytes,5,opt,name=profileName,proto3" json:"profileName,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLogLevelResponse) Reset() {
+	*x = SetLogLevelResponse{}
+	mi := &file_daemon_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLogLevelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLogLevelResponse) ProtoMessage() {}
+
+func (x *SetLogLevelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLogLevelResponse.ProtoReflect.Descriptor instead.
+func (*SetLogLevelResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *SetLogLevelResponse) GetUnknownComponents() []string {
+	if x != nil {
+		return x.UnknownComponents
+	}
+	return nil
+}
+
+func (x *SetLogLevelResponse) GetPersisted() bool {
+	if x != nil {
+		return x.Persisted
+	}
+	return false
+}
+
+func (x *SetLogLevelResponse) GetPersistCleared() bool {
+	if x != nil {
+		return x.PersistCleared
+	}
+	return false
+}
+
+func (x *SetLogLevelResponse) GetApplied() bool {
+	if x != nil {
+		return x.Applied
+	}
+	return false
+}
+
+func (x *SetLogLevelResponse) GetProfileName() string {
+	if x != nil {
+		return x.ProfileName
+	}
+	return ""
+}
+
+type RotateLogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateLogRequest) Reset() {
+	*x = RotateLogRequest{}
+	mi := &file_daemon_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateLogRequest) ProtoMessage() {}
+
+func (x *RotateLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateLogRequest.ProtoReflect.Descriptor instead.
+func (*RotateLogRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{59}
+}
+
+type RotateLogResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// rotatedPath is the file the active log was moved to. It is compressed to a .gz file
+	// in the background.
+	RotatedPath   string `protobuf:"bytes,1,opt,name=rotatedPath,proto3" json:"rotatedPath,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateLogResponse) Reset() {
+	*x = RotateLogResponse{}
+	mi := &file_daemon_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateLogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateLogResponse) ProtoMessage() {}
+
+func (x *RotateLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateLogResponse.ProtoReflect.Descriptor instead.
+func (*RotateLogResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *RotateLogResponse) GetRotatedPath() string {
+	if x != nil {
+		return x.RotatedPath
+	}
+	return ""
+}
+
+type SetLogFormatRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// format is "text" or "json".
+	Format        string `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLogFormatRequest) Reset() {
+	*x = SetLogFormatRequest{}
+	mi := &file_daemon_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLogFormatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLogFormatRequest) ProtoMessage() {}
+
+func (x *SetLogFormatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLogFormatRequest.ProtoReflect.Descriptor instead.
+func (*SetLogFormatRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *SetLogFormatRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+type SetLogFormatResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// previousFormat is the format before the switch: "text", "json" or "syslog".
+	PreviousFormat string `protobuf:"bytes,1,opt,name=previousFormat,proto3" json:"previousFormat,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SetLogFormatResponse) Reset() {
+	*x = SetLogFormatResponse{}
+	mi := &file_daemon_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLogFormatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLogFormatResponse) ProtoMessage() {}
+
+func (x *SetLogFormatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLogFormatResponse.ProtoReflect.Descriptor instead.
+func (*SetLogFormatResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *SetLogFormatResponse) GetPreviousFormat() string {
+	if x != nil {
+		return x.PreviousFormat
+	}
+	return ""
+}
+
+type TailLogsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// level drops lines more verbose than it. UNKNOWN streams every line the daemon writes; lines
+	// more verbose than the daemon log level are never written.
+	Level LogLevel `protobuf:"varint,1,opt,name=level,proto3,enum=daemon.LogLevel" json:"level,omitempty"`
+	// components limits the lines to these components (ice, relay, grpc, peer, dns, route,
+	// firewall, engine). Empty streams all lines.
+	Components    []string `protobuf:"bytes,2,rep,name=components,proto3" json:"components,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TailLogsRequest) Reset() {
+	*x = TailLogsRequest{}
+	mi := &file_daemon_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TailLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TailLogsRequest) ProtoMessage() {}
+
+func (x *TailLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TailLogsRequest.ProtoReflect.Descriptor instead.
+func (*TailLogsRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *TailLogsRequest) GetLevel() LogLevel {
+	if x != nil {
+		return x.Level
+	}
+	return LogLevel_UNKNOWN
+}
+
+func (x *TailLogsRequest) GetComponents() []string {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+type LogLine struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Time  *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Level LogLevel               `protobuf:"varint,2,opt,name=level,proto3,enum=daemon.LogLevel" json:"level,omitempty"`
+	// component is the component the line belongs to, empty if none.
+	Component string `protobuf:"bytes,3,opt,name=component,proto3" json:"component,omitempty"`
+	// line is the entry formatted as a text line.
+	Line string `protobuf:"bytes,4,opt,name=line,proto3" json:"line,omitempty"`
+	// dropped is the number of lines dropped before this one because the client read too slowly.
+	Dropped       uint32 `protobuf:"varint,5,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogLine) Reset() {
+	*x = LogLine{}
+	mi := &file_daemon_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogLine) ProtoMessage() {}
+
+func (x *LogLine) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogLine.ProtoReflect.Descriptor instead.
+func (*LogLine) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *LogLine) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *LogLine) GetLevel() LogLevel {
+	if x != nil {
+		return x.Level
+	}
+	return LogLevel_UNKNOWN
+}
+
+func (x *LogLine) GetComponent() string {
+	if x != nil {
+		return x.Component
+	}
+	return ""
+}
+
+func (x *LogLine) GetLine() string {
+	if x != nil {
+		return x.Line
+	}
+	return ""
+}
+
+func (x *LogLine) GetDropped() uint32 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+type RegisterUILogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterUILogRequest) Reset() {
+	*x = RegisterUILogRequest{}
+	mi := &file_daemon_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterUILogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterUILogRequest) ProtoMessage() {}
+
+func (x *RegisterUILogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterUILogRequest.ProtoReflect.Descriptor instead.
+func (*RegisterUILogRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *RegisterUILogRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type RegisterUILogResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterUILogResponse) Reset() {
+	*x = RegisterUILogResponse{}
+	mi := &file_daemon_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterUILogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterUILogResponse) ProtoMessage() {}
+
+func (x *RegisterUILogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterUILogResponse.ProtoReflect.Descriptor instead.
+func (*RegisterUILogResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{66}
+}
+
+// State represents a daemon state entry
+type State struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *State) Reset() {
+	*x = State{}
+	mi := &file_daemon_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *State) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*State) ProtoMessage() {}
+
+func (x *State) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use State.ProtoReflect.Descriptor instead.
+func (*State) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *State) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// ListStatesRequest is empty as it requires no parameters
+type ListStatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStatesRequest) Reset() {
+	*x = ListStatesRequest{}
+	mi := &file_daemon_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStatesRequest) ProtoMessage() {}
+
+func (x *ListStatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStatesRequest.ProtoReflect.Descriptor instead.
+func (*ListStatesRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{68}
+}
+
+// ListStatesResponse contains a list of states
+type ListStatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	States        []*State               `protobuf:"bytes,1,rep,name=states,proto3" json:"states,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStatesResponse) Reset() {
+	*x = ListStatesResponse{}
+	mi := &file_daemon_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStatesResponse) ProtoMessage() {}
+
+func (x *ListStatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStatesResponse.ProtoReflect.Descriptor instead.
+func (*ListStatesResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *ListStatesResponse) GetStates() []*State {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+// CleanStateRequest for cleaning states
+type CleanStateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StateName     string                 `protobuf:"bytes,1,opt,name=state_name,json=stateName,proto3" json:"state_name,omitempty"`
+	All           bool                   `protobuf:"varint,2,opt,name=all,proto3" json:"all,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CleanStateRequest) Reset() {
+	*x = CleanStateRequest{}
+	mi := &file_daemon_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CleanStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CleanStateRequest) ProtoMessage() {}
+
+func (x *CleanStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CleanStateRequest.ProtoReflect.Descriptor instead.
+func (*CleanStateRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *CleanStateRequest) GetStateName() string {
+	if x != nil {
+		return x.StateName
+	}
+	return ""
+}
+
+func (x *CleanStateRequest) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+// CleanStateResponse contains the result of the clean operation
+type CleanStateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CleanedStates int32                  `protobuf:"varint,1,opt,name=cleaned_states,json=cleanedStates,proto3" json:"cleaned_states,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CleanStateResponse) Reset() {
+	*x = CleanStateResponse{}
+	mi := &file_daemon_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CleanStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CleanStateResponse) ProtoMessage() {}
+
+func (x *CleanStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CleanStateResponse.ProtoReflect.Descriptor instead.
+func (*CleanStateResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *CleanStateResponse) GetCleanedStates() int32 {
+	if x != nil {
+		return x.CleanedStates
+	}
+	return 0
+}
+
+// DeleteStateRequest for deleting states
+type DeleteStateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StateName     string                 `protobuf:"bytes,1,opt,name=state_name,json=stateName,proto3" json:"state_name,omitempty"`
+	All           bool                   `protobuf:"varint,2,opt,name=all,proto3" json:"all,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteStateRequest) Reset() {
+	*x = DeleteStateRequest{}
+	mi := &file_daemon_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteStateRequest) ProtoMessage() {}
+
+func (x *DeleteStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteStateRequest.ProtoReflect.Descriptor instead.
+func (*DeleteStateRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *DeleteStateRequest) GetStateName() string {
+	if x != nil {
+		return x.StateName
+	}
+	return ""
+}
+
+func (x *DeleteStateRequest) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+// DeleteStateResponse contains the result of the delete operation
+type DeleteStateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeletedStates int32                  `protobuf:"varint,1,opt,name=deleted_states,json=deletedStates,proto3" json:"deleted_states,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteStateResponse) Reset() {
+	*x = DeleteStateResponse{}
+	mi := &file_daemon_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteStateResponse) ProtoMessage() {}
+
+func (x *DeleteStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteStateResponse.ProtoReflect.Descriptor instead.
+func (*DeleteStateResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *DeleteStateResponse) GetDeletedStates() int32 {
+	if x != nil {
+		return x.DeletedStates
+	}
+	return 0
+}
+
+type SetSyncResponsePersistenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetSyncResponsePersistenceRequest) Reset() {
+	*x = SetSyncResponsePersistenceRequest{}
+	mi := &file_daemon_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetSyncResponsePersistenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetSyncResponsePersistenceRequest) ProtoMessage() {}
+
+func (x *SetSyncResponsePersistenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetSyncResponsePersistenceRequest.ProtoReflect.Descriptor instead.
+func (*SetSyncResponsePersistenceRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *SetSyncResponsePersistenceRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type SetSyncResponsePersistenceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetSyncResponsePersistenceResponse) Reset() {
+	*x = SetSyncResponsePersistenceResponse{}
+	mi := &file_daemon_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetSyncResponsePersistenceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetSyncResponsePersistenceResponse) ProtoMessage() {}
+
+func (x *SetSyncResponsePersistenceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetSyncResponsePersistenceResponse.ProtoReflect.Descriptor instead.
+func (*SetSyncResponsePersistenceResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{75}
+}
+
+type GetSyncResponsePersistenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSyncResponsePersistenceRequest) Reset() {
+	*x = GetSyncResponsePersistenceRequest{}
+	mi := &file_daemon_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSyncResponsePersistenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSyncResponsePersistenceRequest) ProtoMessage() {}
+
+func (x *GetSyncResponsePersistenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSyncResponsePersistenceRequest.ProtoReflect.Descriptor instead.
+func (*GetSyncResponsePersistenceRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{76}
+}
+
+type GetSyncResponsePersistenceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSyncResponsePersistenceResponse) Reset() {
+	*x = GetSyncResponsePersistenceResponse{}
+	mi := &file_daemon_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSyncResponsePersistenceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSyncResponsePersistenceResponse) ProtoMessage() {}
+
+func (x *GetSyncResponsePersistenceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSyncResponsePersistenceResponse.ProtoReflect.Descriptor instead.
+func (*GetSyncResponsePersistenceResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *GetSyncResponsePersistenceResponse) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type TCPFlags struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Syn           bool                   `protobuf:"varint,1,opt,name=syn,proto3" json:"syn,omitempty"`
+	Ack           bool                   `protobuf:"varint,2,opt,name=ack,proto3" json:"ack,omitempty"`
+	Fin           bool                   `protobuf:"varint,3,opt,name=fin,proto3" json:"fin,omitempty"`
+	Rst           bool                   `protobuf:"varint,4,opt,name=rst,proto3" json:"rst,omitempty"`
+	Psh           bool                   `protobuf:"varint,5,opt,name=psh,proto3" json:"psh,omitempty"`
+	Urg           bool                   `protobuf:"varint,6,opt,name=urg,proto3" json:"urg,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TCPFlags) Reset() {
+	*x = TCPFlags{}
+	mi := &file_daemon_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TCPFlags) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TCPFlags) ProtoMessage() {}
+
+func (x *TCPFlags) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TCPFlags.ProtoReflect.Descriptor instead.
+func (*TCPFlags) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *TCPFlags) GetSyn() bool {
+	if x != nil {
+		return x.Syn
+	}
+	return false
+}
+
+func (x *TCPFlags) GetAck() bool {
+	if x != nil {
+		return x.Ack
+	}
+	return false
+}
+
+func (x *TCPFlags) GetFin() bool {
+	if x != nil {
+		return x.Fin
+	}
+	return false
+}
+
+func (x *TCPFlags) GetRst() bool {
+	if x != nil {
+		return x.Rst
+	}
+	return false
+}
+
+func (x *TCPFlags) GetPsh() bool {
+	if x != nil {
+		return x.Psh
+	}
+	return false
+}
+
+func (x *TCPFlags) GetUrg() bool {
+	if x != nil {
+		return x.Urg
+	}
+	return false
+}
+
+type TracePacketRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	SourceIp        string                 `protobuf:"bytes,1,opt,name=source_ip,json=sourceIp,proto3" json:"source_ip,omitempty"`
+	DestinationIp   string                 `protobuf:"bytes,2,opt,name=destination_ip,json=destinationIp,proto3" json:"destination_ip,omitempty"`
+	Protocol        string                 `protobuf:"bytes,3,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	SourcePort      uint32                 `protobuf:"varint,4,opt,name=source_port,json=sourcePort,proto3" json:"source_port,omitempty"`
+	DestinationPort uint32                 `protobuf:"varint,5,opt,name=destination_port,json=destinationPort,proto3" json:"destination_port,omitempty"`
+	Direction       string                 `protobuf:"bytes,6,opt,name=direction,proto3" json:"direction,omitempty"`
+	TcpFlags        *TCPFlags              `protobuf:"bytes,7,opt,name=tcp_flags,json=tcpFlags,proto3,oneof" json:"tcp_flags,omitempty"`
+	IcmpType        *uint32                `protobuf:"varint,8,opt,name=icmp_type,json=icmpType,proto3,oneof" json:"icmp_type,omitempty"`
+	IcmpCode        *uint32                `protobuf:"varint,9,opt,name=icmp_code,json=icmpCode,proto3,oneof" json:"icmp_code,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TracePacketRequest) Reset() {
+	*x = TracePacketRequest{}
+	mi := &file_daemon_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TracePacketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TracePacketRequest) ProtoMessage() {}
+
+func (x *TracePacketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TracePacketRequest.ProtoReflect.Descriptor instead.
+func (*TracePacketRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *TracePacketRequest) GetSourceIp() string {
+	if x != nil {
+		return x.SourceIp
+	}
+	return ""
+}
+
+func (x *TracePacketRequest) GetDestinationIp() string {
+	if x != nil {
+		return x.DestinationIp
+	}
+	return ""
+}
+
+func (x *TracePacketRequest) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *TracePacketRequest) GetSourcePort() uint32 {
+	if x != nil {
+		return x.SourcePort
+	}
+	return 0
+}
+
+func (x *TracePacketRequest) GetDestinationPort() uint32 {
+	if x != nil {
+		return x.DestinationPort
+	}
+	return 0
+}
+
+func (x *TracePacketRequest) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *TracePacketRequest) GetTcpFlags() *TCPFlags {
+	if x != nil {
+		return x.TcpFlags
+	}
+	return nil
+}
+
+func (x *TracePacketRequest) GetIcmpType() uint32 {
+	if x != nil && x.IcmpType != nil {
+		return *x.IcmpType
+	}
+	return 0
+}
+
+func (x *TracePacketRequest) GetIcmpCode() uint32 {
+	if x != nil && x.IcmpCode != nil {
+		return *x.IcmpCode
+	}
+	return 0
+}
+
+type TraceStage struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Name              string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Message           string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Allowed           bool                   `protobuf:"varint,3,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	ForwardingDetails *string                `protobuf:"bytes,4,opt,name=forwarding_details,json=forwardingDetails,proto3,oneof" json:"forwarding_details,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *TraceStage) Reset() {
+	*x = TraceStage{}
+	mi := &file_daemon_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TraceStage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TraceStage) ProtoMessage() {}
+
+func (x *TraceStage) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TraceStage.ProtoReflect.Descriptor instead.
+func (*TraceStage) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *TraceStage) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TraceStage) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *TraceStage) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
+	}
+	return false
+}
+
+func (x *TraceStage) GetForwardingDetails() string {
+	if x != nil && x.ForwardingDetails != nil {
+		return *x.ForwardingDetails
+	}
+	return ""
+}
+
+type TracePacketResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Stages           []*TraceStage          `protobuf:"bytes,1,rep,name=stages,proto3" json:"stages,omitempty"`
+	FinalDisposition bool                   `protobuf:"varint,2,opt,name=final_disposition,json=finalDisposition,proto3" json:"final_disposition,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *TracePacketResponse) Reset() {
+	*x = TracePacketResponse{}
+	mi := &file_daemon_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TracePacketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TracePacketResponse) ProtoMessage() {}
+
+func (x *TracePacketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TracePacketResponse.ProtoReflect.Descriptor instead.
+func (*TracePacketResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *TracePacketResponse) GetStages() []*TraceStage {
+	if x != nil {
+		return x.Stages
+	}
+	return nil
+}
+
+func (x *TracePacketResponse) GetFinalDisposition() bool {
+	if x != nil {
+		return x.FinalDisposition
+	}
+	return false
+}
+
+// ACLQuery is a flow evaluated against the ACL policy.
+type ACLQuery struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// source and destination are IP addresses, "self" is the local overlay address.
+	Source      string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Destination string `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
+	// protocol is tcp, udp, icmp or all.
+	Protocol string `protobuf:"bytes,3,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	// port is the destination port of tcp and udp flows, 0 only matches rules without ports.
+	Port          uint32 `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ACLQuery) Reset() {
+	*x = ACLQuery{}
+	mi := &file_daemon_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ACLQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ACLQuery) ProtoMessage() {}
+
+func (x *ACLQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *ForwardingRule) GetDestinationPort() *PortInfo {
+// Deprecated: Use ACLQuery.ProtoReflect.Descriptor instead.
+func (*ACLQuery) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *ACLQuery) GetSource() string {
 	if x != nil {
-		return x.DestinationPort
+		return x.Source
 	}
-	return nil
+	return ""
 }
 
-func (x *ForwardingRule) GetTranslatedAddress() string {
+func (x *ACLQuery) GetDestination() string {
 	if x != nil {
-		return x.TranslatedAddress
+		return x.Destination
 	}
 	return ""
 }
 
-func (x *ForwardingRule) GetTranslatedHostname() string {
+func (x *ACLQuery) GetProtocol() string {
 	if x != nil {
-		return x.TranslatedHostname
+		return x.Protocol
 	}
 	return ""
 }
 
-func (x *ForwardingRule) GetTranslatedPort() *PortInfo {
+func (x *ACLQuery) GetPort() uint32 {
 	if x != nil {
-		return x.TranslatedPort
+		return x.Port
 	}
-	return nil
+	return 0
 }
 
-type ForwardingRulesResponse struct {
+type EvaluateACLRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Rules         []*ForwardingRule      `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	Query         *ACLQuery              `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ForwardingRulesResponse) Reset() {
-	*x = ForwardingRulesResponse{}
-	mi := &file_daemon_proto_msgTypes[30]
+func (x *EvaluateACLRequest) Reset() {
+	*x = EvaluateACLRequest{}
+	mi := &file_daemon_proto_msgTypes[83]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ForwardingRulesResponse) String() string {
+func (x *EvaluateACLRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ForwardingRulesResponse) ProtoMessage() {}
+func (*EvaluateACLRequest) ProtoMessage() {}
 
-func (x *ForwardingRulesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[30]
+func (x *EvaluateACLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[83]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2757,45 +6299,43 @@ func (x *ForwardingRulesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ForwardingRulesResponse.ProtoReflect.Descriptor instead.
-func (*ForwardingRulesResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use EvaluateACLRequest.ProtoReflect.Descriptor instead.
+func (*EvaluateACLRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{83}
 }
 
-func (x *ForwardingRulesResponse) GetRules() []*ForwardingRule {
+func (x *EvaluateACLRequest) GetQuery() *ACLQuery {
 	if x != nil {
-		return x.Rules
+		return x.Query
 	}
 	return nil
 }
 
-// DebugBundler
-type DebugBundleRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Anonymize     bool                   `protobuf:"varint,1,opt,name=anonymize,proto3" json:"anonymize,omitempty"`
-	SystemInfo    bool                   `protobuf:"varint,3,opt,name=systemInfo,proto3" json:"systemInfo,omitempty"`
-	UploadURL     string                 `protobuf:"bytes,4,opt,name=uploadURL,proto3" json:"uploadURL,omitempty"`
-	LogFileCount  uint32                 `protobuf:"varint,5,opt,name=logFileCount,proto3" json:"logFileCount,omitempty"`
-	CliVersion    string                 `protobuf:"bytes,6,opt,name=cliVersion,proto3" json:"cliVersion,omitempty"`
+type EvaluateACLResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Allowed bool                   `protobuf:"varint,1,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	// rule names the deciding rule, empty when no rule matched.
+	Rule          string `protobuf:"bytes,2,opt,name=rule,proto3" json:"rule,omitempty"`
+	Reason        string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DebugBundleRequest) Reset() {
-	*x = DebugBundleRequest{}
-	mi := &file_daemon_proto_msgTypes[31]
+func (x *EvaluateACLResponse) Reset() {
+	*x = EvaluateACLResponse{}
+	mi := &file_daemon_proto_msgTypes[84]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DebugBundleRequest) String() string {
+func (x *EvaluateACLResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DebugBundleRequest) ProtoMessage() {}
+func (*EvaluateACLResponse) ProtoMessage() {}
 
-func (x *DebugBundleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[31]
+func (x *EvaluateACLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[84]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2806,70 +6346,296 @@ func (x *DebugBundleRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DebugBundleRequest.ProtoReflect.Descriptor instead.
-func (*DebugBundleRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{31}
+// Deprecated: Use EvaluateACLResponse.ProtoReflect.Descriptor instead.
+func (*EvaluateACLResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{84}
 }
 
-func (x *DebugBundleRequest) GetAnonymize() bool {
+func (x *EvaluateACLResponse) GetAllowed() bool {
 	if x != nil {
-		return x.Anonymize
+		return x.Allowed
 	}
 	return false
 }
 
-func (x *DebugBundleRequest) GetSystemInfo() bool {
+func (x *EvaluateACLResponse) GetRule() string {
 	if x != nil {
-		return x.SystemInfo
+		return x.Rule
 	}
-	return false
+	return ""
 }
 
-func (x *DebugBundleRequest) GetUploadURL() string {
+func (x *EvaluateACLResponse) GetReason() string {
 	if x != nil {
-		return x.UploadURL
+		return x.Reason
 	}
 	return ""
 }
 
-func (x *DebugBundleRequest) GetLogFileCount() uint32 {
+type GetDropStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDropStatsRequest) Reset() {
+	*x = GetDropStatsRequest{}
+	mi := &file_daemon_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDropStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDropStatsRequest) ProtoMessage() {}
+
+func (x *GetDropStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[85]
 	if x != nil {
-		return x.LogFileCount
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *DebugBundleRequest) GetCliVersion() string {
+// Deprecated: Use GetDropStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetDropStatsRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{85}
+}
+
+type DropCounter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reason        string                 `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	Packets       uint64                 `protobuf:"varint,2,opt,name=packets,proto3" json:"packets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DropCounter) Reset() {
+	*x = DropCounter{}
+	mi := &file_daemon_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DropCounter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DropCounter) ProtoMessage() {}
+
+func (x *DropCounter) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[86]
 	if x != nil {
-		return x.CliVersion
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DropCounter.ProtoReflect.Descriptor instead.
+func (*DropCounter) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *DropCounter) GetReason() string {
+	if x != nil {
+		return x.Reason
 	}
 	return ""
 }
 
-type DebugBundleResponse struct {
-	state               protoimpl.MessageState `protogen:"open.v1"`
-	Path                string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
-	UploadedKey         string                 `protobuf:"bytes,2,opt,name=uploadedKey,proto3" json:"uploadedKey,omitempty"`
-	UploadFailureReason string                 `protobuf:"bytes,3,opt,name=uploadFailureReason,proto3" json:"uploadFailureReason,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+func (x *DropCounter) GetPackets() uint64 {
+	if x != nil {
+		return x.Packets
+	}
+	return 0
 }
 
-func (x *DebugBundleResponse) Reset() {
-	*x = DebugBundleResponse{}
-	mi := &file_daemon_proto_msgTypes[32]
+type GetDropStatsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// inbound_packets is the number of inbound packets seen by the filter.
+	InboundPackets uint64         `protobuf:"varint,1,opt,name=inbound_packets,json=inboundPackets,proto3" json:"inbound_packets,omitempty"`
+	Drops          []*DropCounter `protobuf:"bytes,2,rep,name=drops,proto3" json:"drops,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetDropStatsResponse) Reset() {
+	*x = GetDropStatsResponse{}
+	mi := &file_daemon_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDropStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDropStatsResponse) ProtoMessage() {}
+
+func (x *GetDropStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDropStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetDropStatsResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *GetDropStatsResponse) GetInboundPackets() uint64 {
+	if x != nil {
+		return x.InboundPackets
+	}
+	return 0
+}
+
+func (x *GetDropStatsResponse) GetDrops() []*DropCounter {
+	if x != nil {
+		return x.Drops
+	}
+	return nil
+}
+
+type RelayFailoverTestRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// timeout bounds the wait for the failover, the daemon default applies when unset.
+	Timeout       *durationpb.Duration `protobuf:"bytes,1,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelayFailoverTestRequest) Reset() {
+	*x = RelayFailoverTestRequest{}
+	mi := &file_daemon_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelayFailoverTestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelayFailoverTestRequest) ProtoMessage() {}
+
+func (x *RelayFailoverTestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelayFailoverTestRequest.ProtoReflect.Descriptor instead.
+func (*RelayFailoverTestRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *RelayFailoverTestRequest) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+type RelayFailoverEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// elapsed is the time since the home relay was made unreachable.
+	Elapsed       *durationpb.Duration `protobuf:"bytes,1,opt,name=elapsed,proto3" json:"elapsed,omitempty"`
+	Message       string               `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelayFailoverEvent) Reset() {
+	*x = RelayFailoverEvent{}
+	mi := &file_daemon_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelayFailoverEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelayFailoverEvent) ProtoMessage() {}
+
+func (x *RelayFailoverEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelayFailoverEvent.ProtoReflect.Descriptor instead.
+func (*RelayFailoverEvent) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *RelayFailoverEvent) GetElapsed() *durationpb.Duration {
+	if x != nil {
+		return x.Elapsed
+	}
+	return nil
+}
+
+func (x *RelayFailoverEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type RelayFailoverTestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Duration      *durationpb.Duration   `protobuf:"bytes,3,opt,name=duration,proto3" json:"duration,omitempty"`
+	Events        []*RelayFailoverEvent  `protobuf:"bytes,4,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelayFailoverTestResponse) Reset() {
+	*x = RelayFailoverTestResponse{}
+	mi := &file_daemon_proto_msgTypes[90]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DebugBundleResponse) String() string {
+func (x *RelayFailoverTestResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DebugBundleResponse) ProtoMessage() {}
+func (*RelayFailoverTestResponse) ProtoMessage() {}
 
-func (x *DebugBundleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[32]
+func (x *RelayFailoverTestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[90]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2880,53 +6646,60 @@ func (x *DebugBundleResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DebugBundleResponse.ProtoReflect.Descriptor instead.
-func (*DebugBundleResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{32}
+// Deprecated: Use RelayFailoverTestResponse.ProtoReflect.Descriptor instead.
+func (*RelayFailoverTestResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{90}
 }
 
-func (x *DebugBundleResponse) GetPath() string {
+func (x *RelayFailoverTestResponse) GetFrom() string {
 	if x != nil {
-		return x.Path
+		return x.From
 	}
 	return ""
 }
 
-func (x *DebugBundleResponse) GetUploadedKey() string {
+func (x *RelayFailoverTestResponse) GetTo() string {
 	if x != nil {
-		return x.UploadedKey
+		return x.To
 	}
 	return ""
 }
 
-func (x *DebugBundleResponse) GetUploadFailureReason() string {
+func (x *RelayFailoverTestResponse) GetDuration() *durationpb.Duration {
 	if x != nil {
-		return x.UploadFailureReason
+		return x.Duration
 	}
-	return ""
+	return nil
 }
 
-type GetLogLevelRequest struct {
+func (x *RelayFailoverTestResponse) GetEvents() []*RelayFailoverEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type GetStartupTimingRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetLogLevelRequest) Reset() {
-	*x = GetLogLevelRequest{}
-	mi := &file_daemon_proto_msgTypes[33]
+func (x *GetStartupTimingRequest) Reset() {
+	*x = GetStartupTimingRequest{}
+	mi := &file_daemon_proto_msgTypes[91]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetLogLevelRequest) String() string {
+func (x *GetStartupTimingRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetLogLevelRequest) ProtoMessage() {}
+func (*GetStartupTimingRequest) ProtoMessage() {}
 
-func (x *GetLogLevelRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[33]
+func (x *GetStartupTimingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[91]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2937,33 +6710,39 @@ func (x *GetLogLevelRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetLogLevelRequest.ProtoReflect.Descriptor instead.
-func (*GetLogLevelRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{33}
+// Deprecated: Use GetStartupTimingRequest.ProtoReflect.Descriptor instead.
+func (*GetStartupTimingRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{91}
 }
 
-type GetLogLevelResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Level         LogLevel               `protobuf:"varint,1,opt,name=level,proto3,enum=daemon.LogLevel" json:"level,omitempty"`
+type StartupPhase struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// attempt is the connect attempt the phase belongs to, starting at 1.
+	Attempt  int32                  `protobuf:"varint,2,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	Started  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=started,proto3" json:"started,omitempty"`
+	Duration *durationpb.Duration   `protobuf:"bytes,4,opt,name=duration,proto3" json:"duration,omitempty"`
+	// error is set when the phase failed.
+	Error         string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetLogLevelResponse) Reset() {
-	*x = GetLogLevelResponse{}
-	mi := &file_daemon_proto_msgTypes[34]
+func (x *StartupPhase) Reset() {
+	*x = StartupPhase{}
+	mi := &file_daemon_proto_msgTypes[92]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetLogLevelResponse) String() string {
+func (x *StartupPhase) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetLogLevelResponse) ProtoMessage() {}
+func (*StartupPhase) ProtoMessage() {}
 
-func (x *GetLogLevelResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[34]
+func (x *StartupPhase) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[92]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2974,83 +6753,73 @@ func (x *GetLogLevelResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetLogLevelResponse.ProtoReflect.Descriptor instead.
-func (*GetLogLevelResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{34}
+// Deprecated: Use StartupPhase.ProtoReflect.Descriptor instead.
+func (*StartupPhase) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{92}
 }
 
-func (x *GetLogLevelResponse) GetLevel() LogLevel {
+func (x *StartupPhase) GetName() string {
 	if x != nil {
-		return x.Level
+		return x.Name
 	}
-	return LogLevel_UNKNOWN
-}
-
-type SetLogLevelRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Level         LogLevel               `protobuf:"varint,1,opt,name=level,proto3,enum=daemon.LogLevel" json:"level,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *SetLogLevelRequest) Reset() {
-	*x = SetLogLevelRequest{}
-	mi := &file_daemon_proto_msgTypes[35]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+	return ""
 }
 
-func (x *SetLogLevelRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *StartupPhase) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
 }
 
-func (*SetLogLevelRequest) ProtoMessage() {}
-
-func (x *SetLogLevelRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[35]
+func (x *StartupPhase) GetStarted() *timestamppb.Timestamp {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Started
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use SetLogLevelRequest.ProtoReflect.Descriptor instead.
-func (*SetLogLevelRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{35}
+func (x *StartupPhase) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
 }
 
-func (x *SetLogLevelRequest) GetLevel() LogLevel {
+func (x *StartupPhase) GetError() string {
 	if x != nil {
-		return x.Level
+		return x.Error
 	}
-	return LogLevel_UNKNOWN
+	return ""
 }
 
-type SetLogLevelResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+type GetStartupTimingResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// started_at is unset when no startup was recorded.
+	StartedAt *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// complete is set once the first network map has been applied.
+	Complete      bool                 `protobuf:"varint,2,opt,name=complete,proto3" json:"complete,omitempty"`
+	Total         *durationpb.Duration `protobuf:"bytes,3,opt,name=total,proto3" json:"total,omitempty"`
+	Phases        []*StartupPhase      `protobuf:"bytes,4,rep,name=phases,proto3" json:"phases,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SetLogLevelResponse) Reset() {
-	*x = SetLogLevelResponse{}
-	mi := &file_daemon_proto_msgTypes[36]
+func (x *GetStartupTimingResponse) Reset() {
+	*x = GetStartupTimingResponse{}
+	mi := &file_daemon_proto_msgTypes[93]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SetLogLevelResponse) String() string {
+func (x *GetStartupTimingResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetLogLevelResponse) ProtoMessage() {}
+func (*GetStartupTimingResponse) ProtoMessage() {}
 
-func (x *SetLogLevelResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[36]
+func (x *GetStartupTimingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[93]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3061,33 +6830,66 @@ func (x *SetLogLevelResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetLogLevelResponse.ProtoReflect.Descriptor instead.
-func (*SetLogLevelResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{36}
+// Deprecated: Use GetStartupTimingResponse.ProtoReflect.Descriptor instead.
+func (*GetStartupTimingResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{93}
 }
 
-type RegisterUILogRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+func (x *GetStartupTimingResponse) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *GetStartupTimingResponse) GetComplete() bool {
+	if x != nil {
+		return x.Complete
+	}
+	return false
+}
+
+func (x *GetStartupTimingResponse) GetTotal() *durationpb.Duration {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+func (x *GetStartupTimingResponse) GetPhases() []*StartupPhase {
+	if x != nil {
+		return x.Phases
+	}
+	return nil
+}
+
+type ProbePeerMTURequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// peers are peer FQDNs or overlay IPs to probe.
+	Peers []string `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
+	// all probes every connected peer instead of the given ones.
+	All bool `protobuf:"varint,2,opt,name=all,proto3" json:"all,omitempty"`
+	// parallelism bounds the number of peers probed at once, the daemon default applies when zero.
+	Parallelism   uint32 `protobuf:"varint,3,opt,name=parallelism,proto3" json:"parallelism,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RegisterUILogRequest) Reset() {
-	*x = RegisterUILogRequest{}
-	mi := &file_daemon_proto_msgTypes[37]
+func (x *ProbePeerMTURequest) Reset() {
+	*x = ProbePeerMTURequest{}
+	mi := &file_daemon_proto_msgTypes[94]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RegisterUILogRequest) String() string {
+func (x *ProbePeerMTURequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RegisterUILogRequest) ProtoMessage() {}
+func (*ProbePeerMTURequest) ProtoMessage() {}
 
-func (x *RegisterUILogRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[37]
+func (x *ProbePeerMTURequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[94]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3098,39 +6900,59 @@ func (x *RegisterUILogRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RegisterUILogRequest.ProtoReflect.Descriptor instead.
-func (*RegisterUILogRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{37}
+// Deprecated: Use ProbePeerMTURequest.ProtoReflect.Descriptor instead.
+func (*ProbePeerMTURequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{94}
 }
 
-func (x *RegisterUILogRequest) GetPath() string {
+func (x *ProbePeerMTURequest) GetPeers() []string {
 	if x != nil {
-		return x.Path
+		return x.Peers
 	}
-	return ""
+	return nil
 }
 
-type RegisterUILogResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+func (x *ProbePeerMTURequest) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+func (x *ProbePeerMTURequest) GetParallelism() uint32 {
+	if x != nil {
+		return x.Parallelism
+	}
+	return 0
+}
+
+type PeerMTUResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Peer  string                 `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
+	Ip    string                 `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
+	// path_mtu is the largest packet size that reached the peer, zero if it was unreachable.
+	PathMtu       uint32 `protobuf:"varint,3,opt,name=path_mtu,json=pathMtu,proto3" json:"path_mtu,omitempty"`
+	Probes        uint32 `protobuf:"varint,4,opt,name=probes,proto3" json:"probes,omitempty"`
+	Error         string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RegisterUILogResponse) Reset() {
-	*x = RegisterUILogResponse{}
-	mi := &file_daemon_proto_msgTypes[38]
+func (x *PeerMTUResult) Reset() {
+	*x = PeerMTUResult{}
+	mi := &file_daemon_proto_msgTypes[95]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RegisterUILogResponse) String() string {
+func (x *PeerMTUResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RegisterUILogResponse) ProtoMessage() {}
+func (*PeerMTUResult) ProtoMessage() {}
 
-func (x *RegisterUILogResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[38]
+func (x *PeerMTUResult) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[95]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3141,78 +6963,70 @@ func (x *RegisterUILogResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RegisterUILogResponse.ProtoReflect.Descriptor instead.
-func (*RegisterUILogResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{38}
-}
-
-// State represents a daemon state entry
-type State struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// Deprecated: Use PeerMTUResult.ProtoReflect.Descriptor instead.
+func (*PeerMTUResult) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{95}
 }
 
-func (x *State) Reset() {
-	*x = State{}
-	mi := &file_daemon_proto_msgTypes[39]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *PeerMTUResult) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
 }
 
-func (x *State) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *PeerMTUResult) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
 }
 
-func (*State) ProtoMessage() {}
-
-func (x *State) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[39]
+func (x *PeerMTUResult) GetPathMtu() uint32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.PathMtu
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use State.ProtoReflect.Descriptor instead.
-func (*State) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{39}
+func (x *PeerMTUResult) GetProbes() uint32 {
+	if x != nil {
+		return x.Probes
+	}
+	return 0
 }
 
-func (x *State) GetName() string {
+func (x *PeerMTUResult) GetError() string {
 	if x != nil {
-		return x.Name
+		return x.Error
 	}
 	return ""
 }
 
-// ListStatesRequest is empty as it requires no parameters
-type ListStatesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+type ProbePeerMTUResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// tunnel_mtu is the local tunnel MTU, the largest size probed.
+	TunnelMtu     uint32           `protobuf:"varint,1,opt,name=tunnel_mtu,json=tunnelMtu,proto3" json:"tunnel_mtu,omitempty"`
+	Results       []*PeerMTUResult `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListStatesRequest) Reset() {
-	*x = ListStatesRequest{}
-	mi := &file_daemon_proto_msgTypes[40]
+func (x *ProbePeerMTUResponse) Reset() {
+	*x = ProbePeerMTUResponse{}
+	mi := &file_daemon_proto_msgTypes[96]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListStatesRequest) String() string {
+func (x *ProbePeerMTUResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListStatesRequest) ProtoMessage() {}
+func (*ProbePeerMTUResponse) ProtoMessage() {}
 
-func (x *ListStatesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[40]
+func (x *ProbePeerMTUResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[96]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3223,34 +7037,53 @@ func (x *ListStatesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListStatesRequest.ProtoReflect.Descriptor instead.
-func (*ListStatesRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{40}
+// Deprecated: Use ProbePeerMTUResponse.ProtoReflect.Descriptor instead.
+func (*ProbePeerMTUResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *ProbePeerMTUResponse) GetTunnelMtu() uint32 {
+	if x != nil {
+		return x.TunnelMtu
+	}
+	return 0
+}
+
+func (x *ProbePeerMTUResponse) GetResults() []*PeerMTUResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
 }
 
-// ListStatesResponse contains a list of states
-type ListStatesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	States        []*State               `protobuf:"bytes,1,rep,name=states,proto3" json:"states,omitempty"`
+type PeerProbeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// peer is a peer FQDN, FQDN label or overlay IP.
+	Peer string `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
+	// count is the number of echo requests, the daemon default applies when zero.
+	Count uint32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// timeout bounds opening the connection and waiting for each echo reply, the daemon default
+	// applies when unset.
+	Timeout       *durationpb.Duration `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListStatesResponse) Reset() {
-	*x = ListStatesResponse{}
-	mi := &file_daemon_proto_msgTypes[41]
+func (x *PeerProbeRequest) Reset() {
+	*x = PeerProbeRequest{}
+	mi := &file_daemon_proto_msgTypes[97]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListStatesResponse) String() string {
+func (x *PeerProbeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListStatesResponse) ProtoMessage() {}
+func (*PeerProbeRequest) ProtoMessage() {}
 
-func (x *ListStatesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[41]
+func (x *PeerProbeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[97]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3261,42 +7094,71 @@ func (x *ListStatesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListStatesResponse.ProtoReflect.Descriptor instead.
-func (*ListStatesResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{41}
+// Deprecated: Use PeerProbeRequest.ProtoReflect.Descriptor instead.
+func (*PeerProbeRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{97}
 }
 
-func (x *ListStatesResponse) GetStates() []*State {
+func (x *PeerProbeRequest) GetPeer() string {
 	if x != nil {
-		return x.States
+		return x.Peer
+	}
+	return ""
+}
+
+func (x *PeerProbeRequest) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *PeerProbeRequest) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
 	}
 	return nil
 }
 
-// CleanStateRequest for cleaning states
-type CleanStateRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	StateName     string                 `protobuf:"bytes,1,opt,name=state_name,json=stateName,proto3" json:"state_name,omitempty"`
-	All           bool                   `protobuf:"varint,2,opt,name=all,proto3" json:"all,omitempty"`
+type PeerProbeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Peer  string                 `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
+	Ip    string                 `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
+	// reachable is true if the connection is up and the peer answered through the tunnel.
+	Reachable bool   `protobuf:"varint,3,opt,name=reachable,proto3" json:"reachable,omitempty"`
+	Error     string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	// connectTime is how long opening the connection took, unset if it was already connected.
+	ConnectTime                *durationpb.Duration `protobuf:"bytes,5,opt,name=connectTime,proto3" json:"connectTime,omitempty"`
+	Relayed                    bool                 `protobuf:"varint,6,opt,name=relayed,proto3" json:"relayed,omitempty"`
+	RelayAddress               string               `protobuf:"bytes,7,opt,name=relayAddress,proto3" json:"relayAddress,omitempty"`
+	LocalIceCandidateType      string               `protobuf:"bytes,8,opt,name=localIceCandidateType,proto3" json:"localIceCandidateType,omitempty"`
+	RemoteIceCandidateType     string               `protobuf:"bytes,9,opt,name=remoteIceCandidateType,proto3" json:"remoteIceCandidateType,omitempty"`
+	LocalIceCandidateEndpoint  string               `protobuf:"bytes,10,opt,name=localIceCandidateEndpoint,proto3" json:"localIceCandidateEndpoint,omitempty"`
+	RemoteIceCandidateEndpoint string               `protobuf:"bytes,11,opt,name=remoteIceCandidateEndpoint,proto3" json:"remoteIceCandidateEndpoint,omitempty"`
+	// rtts are the round trip times of the answered echo requests.
+	Rtts []*durationpb.Duration `protobuf:"bytes,12,rep,name=rtts,proto3" json:"rtts,omitempty"`
+	Lost uint32                 `protobuf:"varint,13,opt,name=lost,proto3" json:"lost,omitempty"`
+	// note explains a probe without RTTs, e.g. in netstack mode.
+	Note          string `protobuf:"bytes,14,opt,name=note,proto3" json:"note,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CleanStateRequest) Reset() {
-	*x = CleanStateRequest{}
-	mi := &file_daemon_proto_msgTypes[42]
+func (x *PeerProbeResponse) Reset() {
+	*x = PeerProbeResponse{}
+	mi := &file_daemon_proto_msgTypes[98]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CleanStateRequest) String() string {
+func (x *PeerProbeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CleanStateRequest) ProtoMessage() {}
+func (*PeerProbeResponse) ProtoMessage() {}
 
-func (x *CleanStateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[42]
+func (x *PeerProbeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[98]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3307,94 +7169,135 @@ func (x *CleanStateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CleanStateRequest.ProtoReflect.Descriptor instead.
-func (*CleanStateRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{42}
+// Deprecated: Use PeerProbeResponse.ProtoReflect.Descriptor instead.
+func (*PeerProbeResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{98}
 }
 
-func (x *CleanStateRequest) GetStateName() string {
+func (x *PeerProbeResponse) GetPeer() string {
 	if x != nil {
-		return x.StateName
+		return x.Peer
 	}
 	return ""
 }
 
-func (x *CleanStateRequest) GetAll() bool {
+func (x *PeerProbeResponse) GetIp() string {
 	if x != nil {
-		return x.All
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *PeerProbeResponse) GetReachable() bool {
+	if x != nil {
+		return x.Reachable
 	}
 	return false
 }
 
-// CleanStateResponse contains the result of the clean operation
-type CleanStateResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	CleanedStates int32                  `protobuf:"varint,1,opt,name=cleaned_states,json=cleanedStates,proto3" json:"cleaned_states,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *PeerProbeResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
 }
 
-func (x *CleanStateResponse) Reset() {
-	*x = CleanStateResponse{}
-	mi := &file_daemon_proto_msgTypes[43]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *PeerProbeResponse) GetConnectTime() *durationpb.Duration {
+	if x != nil {
+		return x.ConnectTime
+	}
+	return nil
 }
 
-func (x *CleanStateResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *PeerProbeResponse) GetRelayed() bool {
+	if x != nil {
+		return x.Relayed
+	}
+	return false
 }
 
-func (*CleanStateResponse) ProtoMessage() {}
+func (x *PeerProbeResponse) GetRelayAddress() string {
+	if x != nil {
+		return x.RelayAddress
+	}
+	return ""
+}
 
-func (x *CleanStateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[43]
+func (x *PeerProbeResponse) GetLocalIceCandidateType() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.LocalIceCandidateType
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use CleanStateResponse.ProtoReflect.Descriptor instead.
-func (*CleanStateResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{43}
+func (x *PeerProbeResponse) GetRemoteIceCandidateType() string {
+	if x != nil {
+		return x.RemoteIceCandidateType
+	}
+	return ""
 }
 
-func (x *CleanStateResponse) GetCleanedStates() int32 {
+func (x *PeerProbeResponse) GetLocalIceCandidateEndpoint() string {
 	if x != nil {
-		return x.CleanedStates
+		return x.LocalIceCandidateEndpoint
+	}
+	return ""
+}
+
+func (x *PeerProbeResponse) GetRemoteIceCandidateEndpoint() string {
+	if x != nil {
+		return x.RemoteIceCandidateEndpoint
+	}
+	return ""
+}
+
+func (x *PeerProbeResponse) GetRtts() []*durationpb.Duration {
+	if x != nil {
+		return x.Rtts
+	}
+	return nil
+}
+
+func (x *PeerProbeResponse) GetLost() uint32 {
+	if x != nil {
+		return x.Lost
 	}
 	return 0
 }
 
-// DeleteStateRequest for deleting states
-type DeleteStateRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	StateName     string                 `protobuf:"bytes,1,opt,name=state_name,json=stateName,proto3" json:"state_name,omitempty"`
-	All           bool                   `protobuf:"varint,2,opt,name=all,proto3" json:"all,omitempty"`
+func (x *PeerProbeResponse) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type BenchmarkPeerRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// peer is a peer FQDN, FQDN label or overlay IP.
+	Peer string `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
+	// duration is the time of the transfers, half of it each direction, the daemon default applies
+	// when unset.
+	Duration      *durationpb.Duration `protobuf:"bytes,2,opt,name=duration,proto3" json:"duration,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteStateRequest) Reset() {
-	*x = DeleteStateRequest{}
-	mi := &file_daemon_proto_msgTypes[44]
+func (x *BenchmarkPeerRequest) Reset() {
+	*x = BenchmarkPeerRequest{}
+	mi := &file_daemon_proto_msgTypes[99]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteStateRequest) String() string {
+func (x *BenchmarkPeerRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteStateRequest) ProtoMessage() {}
+func (*BenchmarkPeerRequest) ProtoMessage() {}
 
-func (x *DeleteStateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[44]
+func (x *BenchmarkPeerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[99]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3405,48 +7308,61 @@ func (x *DeleteStateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteStateRequest.ProtoReflect.Descriptor instead.
-func (*DeleteStateRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{44}
+// Deprecated: Use BenchmarkPeerRequest.ProtoReflect.Descriptor instead.
+func (*BenchmarkPeerRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{99}
 }
 
-func (x *DeleteStateRequest) GetStateName() string {
+func (x *BenchmarkPeerRequest) GetPeer() string {
 	if x != nil {
-		return x.StateName
+		return x.Peer
 	}
 	return ""
 }
 
-func (x *DeleteStateRequest) GetAll() bool {
+func (x *BenchmarkPeerRequest) GetDuration() *durationpb.Duration {
 	if x != nil {
-		return x.All
+		return x.Duration
 	}
-	return false
+	return nil
 }
 
-// DeleteStateResponse contains the result of the delete operation
-type DeleteStateResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	DeletedStates int32                  `protobuf:"varint,1,opt,name=deleted_states,json=deletedStates,proto3" json:"deleted_states,omitempty"`
+type BenchmarkPeerResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Peer         string                 `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
+	Ip           string                 `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
+	Relayed      bool                   `protobuf:"varint,3,opt,name=relayed,proto3" json:"relayed,omitempty"`
+	RelayAddress string                 `protobuf:"bytes,4,opt,name=relayAddress,proto3" json:"relayAddress,omitempty"`
+	// pathChanged is set when the connection switched between direct and relayed during the run.
+	PathChanged      bool                 `protobuf:"varint,5,opt,name=pathChanged,proto3" json:"pathChanged,omitempty"`
+	UploadBytes      uint64               `protobuf:"varint,6,opt,name=uploadBytes,proto3" json:"uploadBytes,omitempty"`
+	UploadDuration   *durationpb.Duration `protobuf:"bytes,7,opt,name=uploadDuration,proto3" json:"uploadDuration,omitempty"`
+	DownloadBytes    uint64               `protobuf:"varint,8,opt,name=downloadBytes,proto3" json:"downloadBytes,omitempty"`
+	DownloadDuration *durationpb.Duration `protobuf:"bytes,9,opt,name=downloadDuration,proto3" json:"downloadDuration,omitempty"`
+	// rtts are the round trip times of the answered echo packets.
+	Rtts     []*durationpb.Duration `protobuf:"bytes,10,rep,name=rtts,proto3" json:"rtts,omitempty"`
+	EchoLost uint32                 `protobuf:"varint,11,opt,name=echoLost,proto3" json:"echoLost,omitempty"`
+	// maxBytes is the limit of the data transferred in each direction.
+	MaxBytes      uint64 `protobuf:"varint,12,opt,name=maxBytes,proto3" json:"maxBytes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteStateResponse) Reset() {
-	*x = DeleteStateResponse{}
-	mi := &file_daemon_proto_msgTypes[45]
+func (x *BenchmarkPeerResponse) Reset() {
+	*x = BenchmarkPeerResponse{}
+	mi := &file_daemon_proto_msgTypes[100]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteStateResponse) String() string {
+func (x *BenchmarkPeerResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteStateResponse) ProtoMessage() {}
+func (*BenchmarkPeerResponse) ProtoMessage() {}
 
-func (x *DeleteStateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[45]
+func (x *BenchmarkPeerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[100]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3457,40 +7373,116 @@ func (x *DeleteStateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteStateResponse.ProtoReflect.Descriptor instead.
-func (*DeleteStateResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{45}
+// Deprecated: Use BenchmarkPeerResponse.ProtoReflect.Descriptor instead.
+func (*BenchmarkPeerResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{100}
 }
 
-func (x *DeleteStateResponse) GetDeletedStates() int32 {
+func (x *BenchmarkPeerResponse) GetPeer() string {
 	if x != nil {
-		return x.DeletedStates
+		return x.Peer
+	}
+	return ""
+}
+
+func (x *BenchmarkPeerResponse) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *BenchmarkPeerResponse) GetRelayed() bool {
+	if x != nil {
+		return x.Relayed
+	}
+	return false
+}
+
+func (x *BenchmarkPeerResponse) GetRelayAddress() string {
+	if x != nil {
+		return x.RelayAddress
+	}
+	return ""
+}
+
+func (x *BenchmarkPeerResponse) GetPathChanged() bool {
+	if x != nil {
+		return x.PathChanged
+	}
+	return false
+}
+
+func (x *BenchmarkPeerResponse) GetUploadBytes() uint64 {
+	if x != nil {
+		return x.UploadBytes
 	}
 	return 0
 }
 
-type SetSyncResponsePersistenceRequest struct {
+func (x *BenchmarkPeerResponse) GetUploadDuration() *durationpb.Duration {
+	if x != nil {
+		return x.UploadDuration
+	}
+	return nil
+}
+
+func (x *BenchmarkPeerResponse) GetDownloadBytes() uint64 {
+	if x != nil {
+		return x.DownloadBytes
+	}
+	return 0
+}
+
+func (x *BenchmarkPeerResponse) GetDownloadDuration() *durationpb.Duration {
+	if x != nil {
+		return x.DownloadDuration
+	}
+	return nil
+}
+
+func (x *BenchmarkPeerResponse) GetRtts() []*durationpb.Duration {
+	if x != nil {
+		return x.Rtts
+	}
+	return nil
+}
+
+func (x *BenchmarkPeerResponse) GetEchoLost() uint32 {
+	if x != nil {
+		return x.EchoLost
+	}
+	return 0
+}
+
+func (x *BenchmarkPeerResponse) GetMaxBytes() uint64 {
+	if x != nil {
+		return x.MaxBytes
+	}
+	return 0
+}
+
+type GetInterfaceConflictsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SetSyncResponsePersistenceRequest) Reset() {
-	*x = SetSyncResponsePersistenceRequest{}
-	mi := &file_daemon_proto_msgTypes[46]
+func (x *GetInterfaceConflictsRequest) Reset() {
+	*x = GetInterfaceConflictsRequest{}
+	mi := &file_daemon_proto_msgTypes[101]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SetSyncResponsePersistenceRequest) String() string {
+func (x *GetInterfaceConflictsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetSyncResponsePersistenceRequest) ProtoMessage() {}
+func (*GetInterfaceConflictsRequest) ProtoMessage() {}
 
-func (x *SetSyncResponsePersistenceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[46]
+func (x *GetInterfaceConflictsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[101]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3501,39 +7493,39 @@ func (x *SetSyncResponsePersistenceRequest) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetSyncResponsePersistenceRequest.ProtoReflect.Descriptor instead.
-func (*SetSyncResponsePersistenceRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{46}
-}
-
-func (x *SetSyncResponsePersistenceRequest) GetEnabled() bool {
-	if x != nil {
-		return x.Enabled
-	}
-	return false
+// Deprecated: Use GetInterfaceConflictsRequest.ProtoReflect.Descriptor instead.
+func (*GetInterfaceConflictsRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{101}
 }
 
-type SetSyncResponsePersistenceResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+type InterfaceConflict struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// kind is "overlay" or "route".
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	// network is the NetBird overlay network or route prefix.
+	Network   string `protobuf:"bytes,2,opt,name=network,proto3" json:"network,omitempty"`
+	Interface string `protobuf:"bytes,3,opt,name=interface,proto3" json:"interface,omitempty"`
+	// host is the overlapping host interface address with its prefix length.
+	Host          string `protobuf:"bytes,4,opt,name=host,proto3" json:"host,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SetSyncResponsePersistenceResponse) Reset() {
-	*x = SetSyncResponsePersistenceResponse{}
-	mi := &file_daemon_proto_msgTypes[47]
+func (x *InterfaceConflict) Reset() {
+	*x = InterfaceConflict{}
+	mi := &file_daemon_proto_msgTypes[102]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SetSyncResponsePersistenceResponse) String() string {
+func (x *InterfaceConflict) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetSyncResponsePersistenceResponse) ProtoMessage() {}
+func (*InterfaceConflict) ProtoMessage() {}
 
-func (x *SetSyncResponsePersistenceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[47]
+func (x *InterfaceConflict) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[102]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3544,38 +7536,64 @@ func (x *SetSyncResponsePersistenceResponse) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetSyncResponsePersistenceResponse.ProtoReflect.Descriptor instead.
-func (*SetSyncResponsePersistenceResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{47}
+// Deprecated: Use InterfaceConflict.ProtoReflect.Descriptor instead.
+func (*InterfaceConflict) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{102}
 }
 
-type TCPFlags struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Syn           bool                   `protobuf:"varint,1,opt,name=syn,proto3" json:"syn,omitempty"`
-	Ack           bool                   `protobuf:"varint,2,opt,name=ack,proto3" json:"ack,omitempty"`
-	Fin           bool                   `protobuf:"varint,3,opt,name=fin,proto3" json:"fin,omitempty"`
-	Rst           bool                   `protobuf:"varint,4,opt,name=rst,proto3" json:"rst,omitempty"`
-	Psh           bool                   `protobuf:"varint,5,opt,name=psh,proto3" json:"psh,omitempty"`
-	Urg           bool                   `protobuf:"varint,6,opt,name=urg,proto3" json:"urg,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *InterfaceConflict) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
 }
 
-func (x *TCPFlags) Reset() {
-	*x = TCPFlags{}
-	mi := &file_daemon_proto_msgTypes[48]
+func (x *InterfaceConflict) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *InterfaceConflict) GetInterface() string {
+	if x != nil {
+		return x.Interface
+	}
+	return ""
+}
+
+func (x *InterfaceConflict) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+type GetInterfaceConflictsResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Overlay           []string               `protobuf:"bytes,1,rep,name=overlay,proto3" json:"overlay,omitempty"`
+	RoutesChecked     int32                  `protobuf:"varint,2,opt,name=routes_checked,json=routesChecked,proto3" json:"routes_checked,omitempty"`
+	InterfacesChecked int32                  `protobuf:"varint,3,opt,name=interfaces_checked,json=interfacesChecked,proto3" json:"interfaces_checked,omitempty"`
+	Conflicts         []*InterfaceConflict   `protobuf:"bytes,4,rep,name=conflicts,proto3" json:"conflicts,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetInterfaceConflictsResponse) Reset() {
+	*x = GetInterfaceConflictsResponse{}
+	mi := &file_daemon_proto_msgTypes[103]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TCPFlags) String() string {
+func (x *GetInterfaceConflictsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TCPFlags) ProtoMessage() {}
+func (*GetInterfaceConflictsResponse) ProtoMessage() {}
 
-func (x *TCPFlags) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[48]
+func (x *GetInterfaceConflictsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[103]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3586,83 +7604,102 @@ func (x *TCPFlags) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TCPFlags.ProtoReflect.Descriptor instead.
-func (*TCPFlags) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{48}
+// Deprecated: Use GetInterfaceConflictsResponse.ProtoReflect.Descriptor instead.
+func (*GetInterfaceConflictsResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{103}
 }
 
-func (x *TCPFlags) GetSyn() bool {
+func (x *GetInterfaceConflictsResponse) GetOverlay() []string {
 	if x != nil {
-		return x.Syn
+		return x.Overlay
 	}
-	return false
+	return nil
 }
 
-func (x *TCPFlags) GetAck() bool {
+func (x *GetInterfaceConflictsResponse) GetRoutesChecked() int32 {
 	if x != nil {
-		return x.Ack
+		return x.RoutesChecked
 	}
-	return false
+	return 0
 }
 
-func (x *TCPFlags) GetFin() bool {
+func (x *GetInterfaceConflictsResponse) GetInterfacesChecked() int32 {
 	if x != nil {
-		return x.Fin
+		return x.InterfacesChecked
 	}
-	return false
+	return 0
 }
 
-func (x *TCPFlags) GetRst() bool {
+func (x *GetInterfaceConflictsResponse) GetConflicts() []*InterfaceConflict {
 	if x != nil {
-		return x.Rst
+		return x.Conflicts
 	}
-	return false
+	return nil
 }
 
-func (x *TCPFlags) GetPsh() bool {
-	if x != nil {
-		return x.Psh
-	}
-	return false
+type GetRouteConflictsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TCPFlags) GetUrg() bool {
+func (x *GetRouteConflictsRequest) Reset() {
+	*x = GetRouteConflictsRequest{}
+	mi := &file_daemon_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRouteConflictsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRouteConflictsRequest) ProtoMessage() {}
+
+func (x *GetRouteConflictsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[104]
 	if x != nil {
-		return x.Urg
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return false
+	return mi.MessageOf(x)
 }
 
-type TracePacketRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	SourceIp        string                 `protobuf:"bytes,1,opt,name=source_ip,json=sourceIp,proto3" json:"source_ip,omitempty"`
-	DestinationIp   string                 `protobuf:"bytes,2,opt,name=destination_ip,json=destinationIp,proto3" json:"destination_ip,omitempty"`
-	Protocol        string                 `protobuf:"bytes,3,opt,name=protocol,proto3" json:"protocol,omitempty"`
-	SourcePort      uint32                 `protobuf:"varint,4,opt,name=source_port,json=sourcePort,proto3" json:"source_port,omitempty"`
-	DestinationPort uint32                 `protobuf:"varint,5,opt,name=destination_port,json=destinationPort,proto3" json:"destination_port,omitempty"`
-	Direction       string                 `protobuf:"bytes,6,opt,name=direction,proto3" json:"direction,omitempty"`
-	TcpFlags        *TCPFlags              `protobuf:"bytes,7,opt,name=tcp_flags,json=tcpFlags,proto3,oneof" json:"tcp_flags,omitempty"`
-	IcmpType        *uint32                `protobuf:"varint,8,opt,name=icmp_type,json=icmpType,proto3,oneof" json:"icmp_type,omitempty"`
-	IcmpCode        *uint32                `protobuf:"varint,9,opt,name=icmp_code,json=icmpCode,proto3,oneof" json:"icmp_code,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+// Deprecated: Use GetRouteConflictsRequest.ProtoReflect.Descriptor instead.
+func (*GetRouteConflictsRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{104}
 }
 
-func (x *TracePacketRequest) Reset() {
-	*x = TracePacketRequest{}
-	mi := &file_daemon_proto_msgTypes[49]
+type ConflictingRoute struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Prefix string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// gateway is empty for routes without a next hop address.
+	Gateway       string `protobuf:"bytes,2,opt,name=gateway,proto3" json:"gateway,omitempty"`
+	Interface     string `protobuf:"bytes,3,opt,name=interface,proto3" json:"interface,omitempty"`
+	Table         string `protobuf:"bytes,4,opt,name=table,proto3" json:"table,omitempty"`
+	Metric        int32  `protobuf:"varint,5,opt,name=metric,proto3" json:"metric,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConflictingRoute) Reset() {
+	*x = ConflictingRoute{}
+	mi := &file_daemon_proto_msgTypes[105]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TracePacketRequest) String() string {
+func (x *ConflictingRoute) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TracePacketRequest) ProtoMessage() {}
+func (*ConflictingRoute) ProtoMessage() {}
 
-func (x *TracePacketRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[49]
+func (x *ConflictingRoute) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[105]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3673,99 +7710,72 @@ func (x *TracePacketRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TracePacketRequest.ProtoReflect.Descriptor instead.
-func (*TracePacketRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{49}
+// Deprecated: Use ConflictingRoute.ProtoReflect.Descriptor instead.
+func (*ConflictingRoute) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{105}
 }
 
-func (x *TracePacketRequest) GetSourceIp() string {
+func (x *ConflictingRoute) GetPrefix() string {
 	if x != nil {
-		return x.SourceIp
+		return x.Prefix
 	}
 	return ""
 }
 
-func (x *TracePacketRequest) GetDestinationIp() string {
+func (x *ConflictingRoute) GetGateway() string {
 	if x != nil {
-		return x.DestinationIp
+		return x.Gateway
 	}
 	return ""
 }
 
-func (x *TracePacketRequest) GetProtocol() string {
+func (x *ConflictingRoute) GetInterface() string {
 	if x != nil {
-		return x.Protocol
+		return x.Interface
 	}
 	return ""
 }
 
-func (x *TracePacketRequest) GetSourcePort() uint32 {
-	if x != nil {
-		return x.SourcePort
-	}
-	return 0
-}
-
-func (x *TracePacketRequest) GetDestinationPort() uint32 {
-	if x != nil {
-		return x.DestinationPort
-	}
-	return 0
-}
-
-func (x *TracePacketRequest) GetDirection() string {
+func (x *ConflictingRoute) GetTable() string {
 	if x != nil {
-		return x.Direction
+		return x.Table
 	}
 	return ""
 }
 
-func (x *TracePacketRequest) GetTcpFlags() *TCPFlags {
+func (x *ConflictingRoute) GetMetric() int32 {
 	if x != nil {
-		return x.TcpFlags
-	}
-	return nil
-}
-
-func (x *TracePacketRequest) GetIcmpType() uint32 {
-	if x != nil && x.IcmpType != nil {
-		return *x.IcmpType
-	}
-	return 0
-}
-
-func (x *TracePacketRequest) GetIcmpCode() uint32 {
-	if x != nil && x.IcmpCode != nil {
-		return *x.IcmpCode
+		return x.Metric
 	}
 	return 0
 }
 
-type TraceStage struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	Name              string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Message           string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Allowed           bool                   `protobuf:"varint,3,opt,name=allowed,proto3" json:"allowed,omitempty"`
-	ForwardingDetails *string                `protobuf:"bytes,4,opt,name=forwarding_details,json=forwardingDetails,proto3,oneof" json:"forwarding_details,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+type RouteConflict struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Netbird *ConflictingRoute      `protobuf:"bytes,1,opt,name=netbird,proto3" json:"netbird,omitempty"`
+	Host    *ConflictingRoute      `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	// winner is "host", "netbird" or "unclear".
+	Winner        string `protobuf:"bytes,3,opt,name=winner,proto3" json:"winner,omitempty"`
+	Reason        string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TraceStage) Reset() {
-	*x = TraceStage{}
-	mi := &file_daemon_proto_msgTypes[50]
+func (x *RouteConflict) Reset() {
+	*x = RouteConflict{}
+	mi := &file_daemon_proto_msgTypes[106]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TraceStage) String() string {
+func (x *RouteConflict) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TraceStage) ProtoMessage() {}
+func (*RouteConflict) ProtoMessage() {}
 
-func (x *TraceStage) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[50]
+func (x *RouteConflict) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[106]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3776,62 +7786,65 @@ func (x *TraceStage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TraceStage.ProtoReflect.Descriptor instead.
-func (*TraceStage) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{50}
+// Deprecated: Use RouteConflict.ProtoReflect.Descriptor instead.
+func (*RouteConflict) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{106}
 }
 
-func (x *TraceStage) GetName() string {
+func (x *RouteConflict) GetNetbird() *ConflictingRoute {
 	if x != nil {
-		return x.Name
+		return x.Netbird
 	}
-	return ""
+	return nil
 }
 
-func (x *TraceStage) GetMessage() string {
+func (x *RouteConflict) GetHost() *ConflictingRoute {
 	if x != nil {
-		return x.Message
+		return x.Host
 	}
-	return ""
+	return nil
 }
 
-func (x *TraceStage) GetAllowed() bool {
+func (x *RouteConflict) GetWinner() string {
 	if x != nil {
-		return x.Allowed
+		return x.Winner
 	}
-	return false
+	return ""
 }
 
-func (x *TraceStage) GetForwardingDetails() string {
-	if x != nil && x.ForwardingDetails != nil {
-		return *x.ForwardingDetails
+func (x *RouteConflict) GetReason() string {
+	if x != nil {
+		return x.Reason
 	}
 	return ""
 }
 
-type TracePacketResponse struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	Stages           []*TraceStage          `protobuf:"bytes,1,rep,name=stages,proto3" json:"stages,omitempty"`
-	FinalDisposition bool                   `protobuf:"varint,2,opt,name=final_disposition,json=finalDisposition,proto3" json:"final_disposition,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+type GetRouteConflictsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Interface     string                 `protobuf:"bytes,1,opt,name=interface,proto3" json:"interface,omitempty"`
+	PolicyRouting bool                   `protobuf:"varint,2,opt,name=policy_routing,json=policyRouting,proto3" json:"policy_routing,omitempty"`
+	NetbirdRoutes int32                  `protobuf:"varint,3,opt,name=netbird_routes,json=netbirdRoutes,proto3" json:"netbird_routes,omitempty"`
+	HostRoutes    int32                  `protobuf:"varint,4,opt,name=host_routes,json=hostRoutes,proto3" json:"host_routes,omitempty"`
+	Conflicts     []*RouteConflict       `protobuf:"bytes,5,rep,name=conflicts,proto3" json:"conflicts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TracePacketResponse) Reset() {
-	*x = TracePacketResponse{}
-	mi := &file_daemon_proto_msgTypes[51]
+func (x *GetRouteConflictsResponse) Reset() {
+	*x = GetRouteConflictsResponse{}
+	mi := &file_daemon_proto_msgTypes[107]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TracePacketResponse) String() string {
+func (x *GetRouteConflictsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TracePacketResponse) ProtoMessage() {}
+func (*GetRouteConflictsResponse) ProtoMessage() {}
 
-func (x *TracePacketResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[51]
+func (x *GetRouteConflictsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[107]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3842,25 +7855,46 @@ func (x *TracePacketResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TracePacketResponse.ProtoReflect.Descriptor instead.
-func (*TracePacketResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{51}
+// Deprecated: Use GetRouteConflictsResponse.ProtoReflect.Descriptor instead.
+func (*GetRouteConflictsResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{107}
 }
 
-func (x *TracePacketResponse) GetStages() []*TraceStage {
+func (x *GetRouteConflictsResponse) GetInterface() string {
 	if x != nil {
-		return x.Stages
+		return x.Interface
 	}
-	return nil
+	return ""
 }
 
-func (x *TracePacketResponse) GetFinalDisposition() bool {
+func (x *GetRouteConflictsResponse) GetPolicyRouting() bool {
 	if x != nil {
-		return x.FinalDisposition
+		return x.PolicyRouting
 	}
 	return false
 }
 
+func (x *GetRouteConflictsResponse) GetNetbirdRoutes() int32 {
+	if x != nil {
+		return x.NetbirdRoutes
+	}
+	return 0
+}
+
+func (x *GetRouteConflictsResponse) GetHostRoutes() int32 {
+	if x != nil {
+		return x.HostRoutes
+	}
+	return 0
+}
+
+func (x *GetRouteConflictsResponse) GetConflicts() []*RouteConflict {
+	if x != nil {
+		return x.Conflicts
+	}
+	return nil
+}
+
 type SubscribeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -3869,7 +7903,7 @@ type SubscribeRequest struct {
 
 func (x *SubscribeRequest) Reset() {
 	*x = SubscribeRequest{}
-	mi := &file_daemon_proto_msgTypes[52]
+	mi := &file_daemon_proto_msgTypes[108]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3881,7 +7915,7 @@ func (x *SubscribeRequest) String() string {
 func (*SubscribeRequest) ProtoMessage() {}
 
 func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[52]
+	mi := &file_daemon_proto_msgTypes[108]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3894,7 +7928,7 @@ func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
 func (*SubscribeRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{52}
+	return file_daemon_proto_rawDescGZIP(), []int{108}
 }
 
 type SystemEvent struct {
@@ -3912,7 +7946,7 @@ type SystemEvent struct {
 
 func (x *SystemEvent) Reset() {
 	*x = SystemEvent{}
-	mi := &file_daemon_proto_msgTypes[53]
+	mi := &file_daemon_proto_msgTypes[109]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3924,7 +7958,7 @@ func (x *SystemEvent) String() string {
 func (*SystemEvent) ProtoMessage() {}
 
 func (x *SystemEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[53]
+	mi := &file_daemon_proto_msgTypes[109]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3937,7 +7971,7 @@ func (x *SystemEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SystemEvent.ProtoReflect.Descriptor instead.
 func (*SystemEvent) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{53}
+	return file_daemon_proto_rawDescGZIP(), []int{109}
 }
 
 func (x *SystemEvent) GetId() string {
@@ -3997,7 +8031,7 @@ type GetEventsRequest struct {
 
 func (x *GetEventsRequest) Reset() {
 	*x = GetEventsRequest{}
-	mi := &file_daemon_proto_msgTypes[54]
+	mi := &file_daemon_proto_msgTypes[110]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4009,7 +8043,7 @@ func (x *GetEventsRequest) String() string {
 func (*GetEventsRequest) ProtoMessage() {}
 
 func (x *GetEventsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[54]
+	mi := &file_daemon_proto_msgTypes[110]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4022,7 +8056,7 @@ func (x *GetEventsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetEventsRequest.ProtoReflect.Descriptor instead.
 func (*GetEventsRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{54}
+	return file_daemon_proto_rawDescGZIP(), []int{110}
 }
 
 type GetEventsResponse struct {
@@ -4034,7 +8068,7 @@ type GetEventsResponse struct {
 
 func (x *GetEventsResponse) Reset() {
 	*x = GetEventsResponse{}
-	mi := &file_daemon_proto_msgTypes[55]
+	mi := &file_daemon_proto_msgTypes[111]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4046,7 +8080,7 @@ func (x *GetEventsResponse) String() string {
 func (*GetEventsResponse) ProtoMessage() {}
 
 func (x *GetEventsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[55]
+	mi := &file_daemon_proto_msgTypes[111]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4059,7 +8093,7 @@ func (x *GetEventsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetEventsResponse.ProtoReflect.Descriptor instead.
 func (*GetEventsResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{55}
+	return file_daemon_proto_rawDescGZIP(), []int{111}
 }
 
 func (x *GetEventsResponse) GetEvents() []*SystemEvent {
@@ -4081,7 +8115,7 @@ type SwitchProfileRequest struct {
 
 func (x *SwitchProfileRequest) Reset() {
 	*x = SwitchProfileRequest{}
-	mi := &file_daemon_proto_msgTypes[56]
+	mi := &file_daemon_proto_msgTypes[112]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4093,7 +8127,7 @@ func (x *SwitchProfileRequest) String() string {
 func (*SwitchProfileRequest) ProtoMessage() {}
 
 func (x *SwitchProfileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[56]
+	mi := &file_daemon_proto_msgTypes[112]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4106,7 +8140,7 @@ func (x *SwitchProfileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SwitchProfileRequest.ProtoReflect.Descriptor instead.
 func (*SwitchProfileRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{56}
+	return file_daemon_proto_rawDescGZIP(), []int{112}
 }
 
 func (x *SwitchProfileRequest) GetProfileName() string {
@@ -4135,7 +8169,7 @@ type SwitchProfileResponse struct {
 
 func (x *SwitchProfileResponse) Reset() {
 	*x = SwitchProfileResponse{}
-	mi := &file_daemon_proto_msgTypes[57]
+	mi := &file_daemon_proto_msgTypes[113]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4147,7 +8181,7 @@ func (x *SwitchProfileResponse) String() string {
 func (*SwitchProfileResponse) ProtoMessage() {}
 
 func (x *SwitchProfileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[57]
+	mi := &file_daemon_proto_msgTypes[113]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4160,7 +8194,7 @@ func (x *SwitchProfileResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SwitchProfileResponse.ProtoReflect.Descriptor instead.
 func (*SwitchProfileResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{57}
+	return file_daemon_proto_rawDescGZIP(), []int{113}
 }
 
 func (x *SwitchProfileResponse) GetId() string {
@@ -4216,7 +8250,7 @@ type SetConfigRequest struct {
 
 func (x *SetConfigRequest) Reset() {
 	*x = SetConfigRequest{}
-	mi := &file_daemon_proto_msgTypes[58]
+	mi := &file_daemon_proto_msgTypes[114]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4228,7 +8262,7 @@ func (x *SetConfigRequest) String() string {
 func (*SetConfigRequest) ProtoMessage() {}
 
 func (x *SetConfigRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[58]
+	mi := &file_daemon_proto_msgTypes[114]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4241,7 +8275,7 @@ func (x *SetConfigRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetConfigRequest.ProtoReflect.Descriptor instead.
 func (*SetConfigRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{58}
+	return file_daemon_proto_rawDescGZIP(), []int{114}
 }
 
 func (x *SetConfigRequest) GetUsername() string {
@@ -4497,7 +8531,7 @@ type SetConfigResponse struct {
 
 func (x *SetConfigResponse) Reset() {
 	*x = SetConfigResponse{}
-	mi := &file_daemon_proto_msgTypes[59]
+	mi := &file_daemon_proto_msgTypes[115]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4509,7 +8543,7 @@ func (x *SetConfigResponse) String() string {
 func (*SetConfigResponse) ProtoMessage() {}
 
 func (x *SetConfigResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[59]
+	mi := &file_daemon_proto_msgTypes[115]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4522,7 +8556,7 @@ func (x *SetConfigResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetConfigResponse.ProtoReflect.Descriptor instead.
 func (*SetConfigResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{59}
+	return file_daemon_proto_rawDescGZIP(), []int{115}
 }
 
 type AddProfileRequest struct {
@@ -4537,7 +8571,7 @@ type AddProfileRequest struct {
 
 func (x *AddProfileRequest) Reset() {
 	*x = AddProfileRequest{}
-	mi := &file_daemon_proto_msgTypes[60]
+	mi := &file_daemon_proto_msgTypes[116]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4549,7 +8583,7 @@ func (x *AddProfileRequest) String() string {
 func (*AddProfileRequest) ProtoMessage() {}
 
 func (x *AddProfileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[60]
+	mi := &file_daemon_proto_msgTypes[116]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4562,7 +8596,7 @@ func (x *AddProfileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddProfileRequest.ProtoReflect.Descriptor instead.
 func (*AddProfileRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{60}
+	return file_daemon_proto_rawDescGZIP(), []int{116}
 }
 
 func (x *AddProfileRequest) GetUsername() string {
@@ -4590,7 +8624,7 @@ type AddProfileResponse struct {
 
 func (x *AddProfileResponse) Reset() {
 	*x = AddProfileResponse{}
-	mi := &file_daemon_proto_msgTypes[61]
+	mi := &file_daemon_proto_msgTypes[117]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4602,7 +8636,7 @@ func (x *AddProfileResponse) String() string {
 func (*AddProfileResponse) ProtoMessage() {}
 
 func (x *AddProfileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[61]
+	mi := &file_daemon_proto_msgTypes[117]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4615,7 +8649,7 @@ func (x *AddProfileResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddProfileResponse.ProtoReflect.Descriptor instead.
 func (*AddProfileResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{61}
+	return file_daemon_proto_rawDescGZIP(), []int{117}
 }
 
 func (x *AddProfileResponse) GetId() string {
@@ -4638,7 +8672,7 @@ type RenameProfileRequest struct {
 
 func (x *RenameProfileRequest) Reset() {
 	*x = RenameProfileRequest{}
-	mi := &file_daemon_proto_msgTypes[62]
+	mi := &file_daemon_proto_msgTypes[118]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4650,7 +8684,7 @@ func (x *RenameProfileRequest) String() string {
 func (*RenameProfileRequest) ProtoMessage() {}
 
 func (x *RenameProfileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[62]
+	mi := &file_daemon_proto_msgTypes[118]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4663,7 +8697,7 @@ func (x *RenameProfileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RenameProfileRequest.ProtoReflect.Descriptor instead.
 func (*RenameProfileRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{62}
+	return file_daemon_proto_rawDescGZIP(), []int{118}
 }
 
 func (x *RenameProfileRequest) GetUsername() string {
@@ -4697,7 +8731,7 @@ type RenameProfileResponse struct {
 
 func (x *RenameProfileResponse) Reset() {
 	*x = RenameProfileResponse{}
-	mi := &file_daemon_proto_msgTypes[63]
+	mi := &file_daemon_proto_msgTypes[119]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4709,7 +8743,7 @@ func (x *RenameProfileResponse) String() string {
 func (*RenameProfileResponse) ProtoMessage() {}
 
 func (x *RenameProfileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[63]
+	mi := &file_daemon_proto_msgTypes[119]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4722,7 +8756,7 @@ func (x *RenameProfileResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RenameProfileResponse.ProtoReflect.Descriptor instead.
 func (*RenameProfileResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{63}
+	return file_daemon_proto_rawDescGZIP(), []int{119}
 }
 
 func (x *RenameProfileResponse) GetOldProfileName() string {
@@ -4744,7 +8778,7 @@ type RemoveProfileRequest struct {
 
 func (x *RemoveProfileRequest) Reset() {
 	*x = RemoveProfileRequest{}
-	mi := &file_daemon_proto_msgTypes[64]
+	mi := &file_daemon_proto_msgTypes[120]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4756,7 +8790,7 @@ func (x *RemoveProfileRequest) String() string {
 func (*RemoveProfileRequest) ProtoMessage() {}
 
 func (x *RemoveProfileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[64]
+	mi := &file_daemon_proto_msgTypes[120]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4769,7 +8803,7 @@ func (x *RemoveProfileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveProfileRequest.ProtoReflect.Descriptor instead.
 func (*RemoveProfileRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{64}
+	return file_daemon_proto_rawDescGZIP(), []int{120}
 }
 
 func (x *RemoveProfileRequest) GetUsername() string {
@@ -4797,7 +8831,7 @@ type RemoveProfileResponse struct {
 
 func (x *RemoveProfileResponse) Reset() {
 	*x = RemoveProfileResponse{}
-	mi := &file_daemon_proto_msgTypes[65]
+	mi := &file_daemon_proto_msgTypes[121]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4809,7 +8843,7 @@ func (x *RemoveProfileResponse) String() string {
 func (*RemoveProfileResponse) ProtoMessage() {}
 
 func (x *RemoveProfileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[65]
+	mi := &file_daemon_proto_msgTypes[121]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4822,7 +8856,7 @@ func (x *RemoveProfileResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveProfileResponse.ProtoReflect.Descriptor instead.
 func (*RemoveProfileResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{65}
+	return file_daemon_proto_rawDescGZIP(), []int{121}
 }
 
 func (x *RemoveProfileResponse) GetId() string {
@@ -4841,7 +8875,7 @@ type ListProfilesRequest struct {
 
 func (x *ListProfilesRequest) Reset() {
 	*x = ListProfilesRequest{}
-	mi := &file_daemon_proto_msgTypes[66]
+	mi := &file_daemon_proto_msgTypes[122]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4853,7 +8887,7 @@ func (x *ListProfilesRequest) String() string {
 func (*ListProfilesRequest) ProtoMessage() {}
 
 func (x *ListProfilesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[66]
+	mi := &file_daemon_proto_msgTypes[122]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4866,7 +8900,7 @@ func (x *ListProfilesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListProfilesRequest.ProtoReflect.Descriptor instead.
 func (*ListProfilesRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{66}
+	return file_daemon_proto_rawDescGZIP(), []int{122}
 }
 
 func (x *ListProfilesRequest) GetUsername() string {
@@ -4885,7 +8919,7 @@ type ListProfilesResponse struct {
 
 func (x *ListProfilesResponse) Reset() {
 	*x = ListProfilesResponse{}
-	mi := &file_daemon_proto_msgTypes[67]
+	mi := &file_daemon_proto_msgTypes[123]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4897,7 +8931,7 @@ func (x *ListProfilesResponse) String() string {
 func (*ListProfilesResponse) ProtoMessage() {}
 
 func (x *ListProfilesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[67]
+	mi := &file_daemon_proto_msgTypes[123]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4910,7 +8944,7 @@ func (x *ListProfilesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListProfilesResponse.ProtoReflect.Descriptor instead.
 func (*ListProfilesResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{67}
+	return file_daemon_proto_rawDescGZIP(), []int{123}
 }
 
 func (x *ListProfilesResponse) GetProfiles() []*Profile {
@@ -4931,7 +8965,7 @@ type Profile struct {
 
 func (x *Profile) Reset() {
 	*x = Profile{}
-	mi := &file_daemon_proto_msgTypes[68]
+	mi := &file_daemon_proto_msgTypes[124]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4943,7 +8977,7 @@ func (x *Profile) String() string {
 func (*Profile) ProtoMessage() {}
 
 func (x *Profile) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[68]
+	mi := &file_daemon_proto_msgTypes[124]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4956,7 +8990,7 @@ func (x *Profile) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Profile.ProtoReflect.Descriptor instead.
 func (*Profile) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{68}
+	return file_daemon_proto_rawDescGZIP(), []int{124}
 }
 
 func (x *Profile) GetName() string {
@@ -4988,7 +9022,7 @@ type GetActiveProfileRequest struct {
 
 func (x *GetActiveProfileRequest) Reset() {
 	*x = GetActiveProfileRequest{}
-	mi := &file_daemon_proto_msgTypes[69]
+	mi := &file_daemon_proto_msgTypes[125]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5000,7 +9034,7 @@ func (x *GetActiveProfileRequest) String() string {
 func (*GetActiveProfileRequest) ProtoMessage() {}
 
 func (x *GetActiveProfileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[69]
+	mi := &file_daemon_proto_msgTypes[125]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5013,7 +9047,7 @@ func (x *GetActiveProfileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetActiveProfileRequest.ProtoReflect.Descriptor instead.
 func (*GetActiveProfileRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{69}
+	return file_daemon_proto_rawDescGZIP(), []int{125}
 }
 
 type GetActiveProfileResponse struct {
@@ -5027,7 +9061,7 @@ type GetActiveProfileResponse struct {
 
 func (x *GetActiveProfileResponse) Reset() {
 	*x = GetActiveProfileResponse{}
-	mi := &file_daemon_proto_msgTypes[70]
+	mi := &file_daemon_proto_msgTypes[126]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5039,7 +9073,7 @@ func (x *GetActiveProfileResponse) String() string {
 func (*GetActiveProfileResponse) ProtoMessage() {}
 
 func (x *GetActiveProfileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[70]
+	mi := &file_daemon_proto_msgTypes[126]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5052,7 +9086,7 @@ func (x *GetActiveProfileResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetActiveProfileResponse.ProtoReflect.Descriptor instead.
 func (*GetActiveProfileResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{70}
+	return file_daemon_proto_rawDescGZIP(), []int{126}
 }
 
 func (x *GetActiveProfileResponse) GetProfileName() string {
@@ -5086,7 +9120,7 @@ type LogoutRequest struct {
 
 func (x *LogoutRequest) Reset() {
 	*x = LogoutRequest{}
-	mi := &file_daemon_proto_msgTypes[71]
+	mi := &file_daemon_proto_msgTypes[127]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5098,7 +9132,7 @@ func (x *LogoutRequest) String() string {
 func (*LogoutRequest) ProtoMessage() {}
 
 func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[71]
+	mi := &file_daemon_proto_msgTypes[127]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5111,7 +9145,7 @@ func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogoutRequest.ProtoReflect.Descriptor instead.
 func (*LogoutRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{71}
+	return file_daemon_proto_rawDescGZIP(), []int{127}
 }
 
 func (x *LogoutRequest) GetProfileName() string {
@@ -5136,19 +9170,236 @@ type LogoutResponse struct {
 
 func (x *LogoutResponse) Reset() {
 	*x = LogoutResponse{}
-	mi := &file_daemon_proto_msgTypes[72]
+	mi := &file_daemon_proto_msgTypes[128]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutResponse) ProtoMessage() {}
+
+func (x *LogoutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[128]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutResponse.ProtoReflect.Descriptor instead.
+func (*LogoutResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{128}
+}
+
+type WailsUIReadyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WailsUIReadyRequest) Reset() {
+	*x = WailsUIReadyRequest{}
+	mi := &file_daemon_proto_msgTypes[129]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WailsUIReadyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WailsUIReadyRequest) ProtoMessage() {}
+
+func (x *WailsUIReadyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[129]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WailsUIReadyRequest.ProtoReflect.Descriptor instead.
+func (*WailsUIReadyRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{129}
+}
+
+type WailsUIReadyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WailsUIReadyResponse) Reset() {
+	*x = WailsUIReadyResponse{}
+	mi := &file_daemon_proto_msgTypes[130]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WailsUIReadyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WailsUIReadyResponse) ProtoMessage() {}
+
+func (x *WailsUIReadyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[130]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WailsUIReadyResponse.ProtoReflect.Descriptor instead.
+func (*WailsUIReadyResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{130}
+}
+
+type GetFeaturesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFeaturesRequest) Reset() {
+	*x = GetFeaturesRequest{}
+	mi := &file_daemon_proto_msgTypes[131]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFeaturesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFeaturesRequest) ProtoMessage() {}
+
+func (x *GetFeaturesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[131]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFeaturesRequest.ProtoReflect.Descriptor instead.
+func (*GetFeaturesRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{131}
+}
+
+type GetFeaturesResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	DisableProfiles       bool                   `protobuf:"varint,1,opt,name=disable_profiles,json=disableProfiles,proto3" json:"disable_profiles,omitempty"`
+	DisableUpdateSettings bool                   `protobuf:"varint,2,opt,name=disable_update_settings,json=disableUpdateSettings,proto3" json:"disable_update_settings,omitempty"`
+	DisableNetworks       bool                   `protobuf:"varint,3,opt,name=disable_networks,json=disableNetworks,proto3" json:"disable_networks,omitempty"`
+	// disableAdvancedView gates the upcoming UI revision's advanced
+	// section. Tristate: unset = no MDM directive, the UI applies its
+	// own default; true = MDM enforces disable; false = MDM enforces
+	// enable. Sourced exclusively from the MDM policy — no CLI /
+	// config flag backs this value.
+	DisableAdvancedView *bool `protobuf:"varint,4,opt,name=disable_advanced_view,json=disableAdvancedView,proto3,oneof" json:"disable_advanced_view,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *GetFeaturesResponse) Reset() {
+	*x = GetFeaturesResponse{}
+	mi := &file_daemon_proto_msgTypes[132]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFeaturesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFeaturesResponse) ProtoMessage() {}
+
+func (x *GetFeaturesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[132]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFeaturesResponse.ProtoReflect.Descriptor instead.
+func (*GetFeaturesResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{132}
+}
+
+func (x *GetFeaturesResponse) GetDisableProfiles() bool {
+	if x != nil {
+		return x.DisableProfiles
+	}
+	return false
+}
+
+func (x *GetFeaturesResponse) GetDisableUpdateSettings() bool {
+	if x != nil {
+		return x.DisableUpdateSettings
+	}
+	return false
+}
+
+func (x *GetFeaturesResponse) GetDisableNetworks() bool {
+	if x != nil {
+		return x.DisableNetworks
+	}
+	return false
+}
+
+func (x *GetFeaturesResponse) GetDisableAdvancedView() bool {
+	if x != nil && x.DisableAdvancedView != nil {
+		return *x.DisableAdvancedView
+	}
+	return false
+}
+
+type ListFeatureFlagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFeatureFlagsRequest) Reset() {
+	*x = ListFeatureFlagsRequest{}
+	mi := &file_daemon_proto_msgTypes[133]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LogoutResponse) String() string {
+func (x *ListFeatureFlagsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LogoutResponse) ProtoMessage() {}
+func (*ListFeatureFlagsRequest) ProtoMessage() {}
 
-func (x *LogoutResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[72]
+func (x *ListFeatureFlagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[133]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5159,32 +9410,39 @@ func (x *LogoutResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LogoutResponse.ProtoReflect.Descriptor instead.
-func (*LogoutResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{72}
+// Deprecated: Use ListFeatureFlagsRequest.ProtoReflect.Descriptor instead.
+func (*ListFeatureFlagsRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{133}
 }
 
-type WailsUIReadyRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+type FeatureFlag struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Name    string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Enabled bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// source is one of default, env, config or management.
+	Source string `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
+	Detail string `protobuf:"bytes,4,opt,name=detail,proto3" json:"detail,omitempty"`
+	// experimental marks features that are not considered stable yet.
+	Experimental  bool `protobuf:"varint,5,opt,name=experimental,proto3" json:"experimental,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *WailsUIReadyRequest) Reset() {
-	*x = WailsUIReadyRequest{}
-	mi := &file_daemon_proto_msgTypes[73]
+func (x *FeatureFlag) Reset() {
+	*x = FeatureFlag{}
+	mi := &file_daemon_proto_msgTypes[134]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WailsUIReadyRequest) String() string {
+func (x *FeatureFlag) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WailsUIReadyRequest) ProtoMessage() {}
+func (*FeatureFlag) ProtoMessage() {}
 
-func (x *WailsUIReadyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[73]
+func (x *FeatureFlag) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[134]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5195,32 +9453,68 @@ func (x *WailsUIReadyRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WailsUIReadyRequest.ProtoReflect.Descriptor instead.
-func (*WailsUIReadyRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{73}
+// Deprecated: Use FeatureFlag.ProtoReflect.Descriptor instead.
+func (*FeatureFlag) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{134}
 }
 
-type WailsUIReadyResponse struct {
+func (x *FeatureFlag) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FeatureFlag) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *FeatureFlag) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *FeatureFlag) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+func (x *FeatureFlag) GetExperimental() bool {
+	if x != nil {
+		return x.Experimental
+	}
+	return false
+}
+
+type ListFeatureFlagsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Flags         []*FeatureFlag         `protobuf:"bytes,1,rep,name=flags,proto3" json:"flags,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *WailsUIReadyResponse) Reset() {
-	*x = WailsUIReadyResponse{}
-	mi := &file_daemon_proto_msgTypes[74]
+func (x *ListFeatureFlagsResponse) Reset() {
+	*x = ListFeatureFlagsResponse{}
+	mi := &file_daemon_proto_msgTypes[135]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WailsUIReadyResponse) String() string {
+func (x *ListFeatureFlagsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WailsUIReadyResponse) ProtoMessage() {}
+func (*ListFeatureFlagsResponse) ProtoMessage() {}
 
-func (x *WailsUIReadyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[74]
+func (x *ListFeatureFlagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[135]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5231,32 +9525,39 @@ func (x *WailsUIReadyResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WailsUIReadyResponse.ProtoReflect.Descriptor instead.
-func (*WailsUIReadyResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{74}
+// Deprecated: Use ListFeatureFlagsResponse.ProtoReflect.Descriptor instead.
+func (*ListFeatureFlagsResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{135}
 }
 
-type GetFeaturesRequest struct {
+func (x *ListFeatureFlagsResponse) GetFlags() []*FeatureFlag {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+type GetBuildInfoRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetFeaturesRequest) Reset() {
-	*x = GetFeaturesRequest{}
-	mi := &file_daemon_proto_msgTypes[75]
+func (x *GetBuildInfoRequest) Reset() {
+	*x = GetBuildInfoRequest{}
+	mi := &file_daemon_proto_msgTypes[136]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetFeaturesRequest) String() string {
+func (x *GetBuildInfoRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetFeaturesRequest) ProtoMessage() {}
+func (*GetBuildInfoRequest) ProtoMessage() {}
 
-func (x *GetFeaturesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[75]
+func (x *GetBuildInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[136]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5267,41 +9568,41 @@ func (x *GetFeaturesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetFeaturesRequest.ProtoReflect.Descriptor instead.
-func (*GetFeaturesRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{75}
+// Deprecated: Use GetBuildInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetBuildInfoRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{136}
 }
 
-type GetFeaturesResponse struct {
-	state                 protoimpl.MessageState `protogen:"open.v1"`
-	DisableProfiles       bool                   `protobuf:"varint,1,opt,name=disable_profiles,json=disableProfiles,proto3" json:"disable_profiles,omitempty"`
-	DisableUpdateSettings bool                   `protobuf:"varint,2,opt,name=disable_update_settings,json=disableUpdateSettings,proto3" json:"disable_update_settings,omitempty"`
-	DisableNetworks       bool                   `protobuf:"varint,3,opt,name=disable_networks,json=disableNetworks,proto3" json:"disable_networks,omitempty"`
-	// disableAdvancedView gates the upcoming UI revision's advanced
-	// section. Tristate: unset = no MDM directive, the UI applies its
-	// own default; true = MDM enforces disable; false = MDM enforces
-	// enable. Sourced exclusively from the MDM policy — no CLI /
-	// config flag backs this value.
-	DisableAdvancedView *bool `protobuf:"varint,4,opt,name=disable_advanced_view,json=disableAdvancedView,proto3,oneof" json:"disable_advanced_view,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+type GetBuildInfoResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Version string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// commit is empty if the build does not record it.
+	Commit string `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
+	// buildDate is the commit date of the build in RFC 3339, empty if unknown.
+	BuildDate string `protobuf:"bytes,3,opt,name=buildDate,proto3" json:"buildDate,omitempty"`
+	GoVersion string `protobuf:"bytes,4,opt,name=goVersion,proto3" json:"goVersion,omitempty"`
+	// platform is GOOS/GOARCH.
+	Platform      string         `protobuf:"bytes,5,opt,name=platform,proto3" json:"platform,omitempty"`
+	Flags         []*FeatureFlag `protobuf:"bytes,6,rep,name=flags,proto3" json:"flags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetFeaturesResponse) Reset() {
-	*x = GetFeaturesResponse{}
-	mi := &file_daemon_proto_msgTypes[76]
+func (x *GetBuildInfoResponse) Reset() {
+	*x = GetBuildInfoResponse{}
+	mi := &file_daemon_proto_msgTypes[137]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetFeaturesResponse) String() string {
+func (x *GetBuildInfoResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetFeaturesResponse) ProtoMessage() {}
+func (*GetBuildInfoResponse) ProtoMessage() {}
 
-func (x *GetFeaturesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[76]
+func (x *GetBuildInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[137]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5312,37 +9613,51 @@ func (x *GetFeaturesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetFeaturesResponse.ProtoReflect.Descriptor instead.
-func (*GetFeaturesResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{76}
+// Deprecated: Use GetBuildInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetBuildInfoResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{137}
 }
 
-func (x *GetFeaturesResponse) GetDisableProfiles() bool {
+func (x *GetBuildInfoResponse) GetVersion() string {
 	if x != nil {
-		return x.DisableProfiles
+		return x.Version
 	}
-	return false
+	return ""
 }
 
-func (x *GetFeaturesResponse) GetDisableUpdateSettings() bool {
+func (x *GetBuildInfoResponse) GetCommit() string {
 	if x != nil {
-		return x.DisableUpdateSettings
+		return x.Commit
 	}
-	return false
+	return ""
 }
 
-func (x *GetFeaturesResponse) GetDisableNetworks() bool {
+func (x *GetBuildInfoResponse) GetBuildDate() string {
 	if x != nil {
-		return x.DisableNetworks
+		return x.BuildDate
 	}
-	return false
+	return ""
 }
 
-func (x *GetFeaturesResponse) GetDisableAdvancedView() bool {
-	if x != nil && x.DisableAdvancedView != nil {
-		return *x.DisableAdvancedView
+func (x *GetBuildInfoResponse) GetGoVersion() string {
+	if x != nil {
+		return x.GoVersion
 	}
-	return false
+	return ""
+}
+
+func (x *GetBuildInfoResponse) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *GetBuildInfoResponse) GetFlags() []*FeatureFlag {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
 }
 
 // MDMManagedFieldsViolation is attached as a gRPC error detail on a
@@ -5359,7 +9674,7 @@ type MDMManagedFieldsViolation struct {
 
 func (x *MDMManagedFieldsViolation) Reset() {
 	*x = MDMManagedFieldsViolation{}
-	mi := &file_daemon_proto_msgTypes[77]
+	mi := &file_daemon_proto_msgTypes[138]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5371,7 +9686,7 @@ func (x *MDMManagedFieldsViolation) String() string {
 func (*MDMManagedFieldsViolation) ProtoMessage() {}
 
 func (x *MDMManagedFieldsViolation) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[77]
+	mi := &file_daemon_proto_msgTypes[138]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5384,7 +9699,7 @@ func (x *MDMManagedFieldsViolation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MDMManagedFieldsViolation.ProtoReflect.Descriptor instead.
 func (*MDMManagedFieldsViolation) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{77}
+	return file_daemon_proto_rawDescGZIP(), []int{138}
 }
 
 func (x *MDMManagedFieldsViolation) GetFields() []string {
@@ -5402,7 +9717,7 @@ type TriggerUpdateRequest struct {
 
 func (x *TriggerUpdateRequest) Reset() {
 	*x = TriggerUpdateRequest{}
-	mi := &file_daemon_proto_msgTypes[78]
+	mi := &file_daemon_proto_msgTypes[139]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5414,7 +9729,7 @@ func (x *TriggerUpdateRequest) String() string {
 func (*TriggerUpdateRequest) ProtoMessage() {}
 
 func (x *TriggerUpdateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[78]
+	mi := &file_daemon_proto_msgTypes[139]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5427,7 +9742,7 @@ func (x *TriggerUpdateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TriggerUpdateRequest.ProtoReflect.Descriptor instead.
 func (*TriggerUpdateRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{78}
+	return file_daemon_proto_rawDescGZIP(), []int{139}
 }
 
 type TriggerUpdateResponse struct {
@@ -5440,7 +9755,7 @@ type TriggerUpdateResponse struct {
 
 func (x *TriggerUpdateResponse) Reset() {
 	*x = TriggerUpdateResponse{}
-	mi := &file_daemon_proto_msgTypes[79]
+	mi := &file_daemon_proto_msgTypes[140]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5452,7 +9767,7 @@ func (x *TriggerUpdateResponse) String() string {
 func (*TriggerUpdateResponse) ProtoMessage() {}
 
 func (x *TriggerUpdateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[79]
+	mi := &file_daemon_proto_msgTypes[140]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5465,7 +9780,7 @@ func (x *TriggerUpdateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TriggerUpdateResponse.ProtoReflect.Descriptor instead.
 func (*TriggerUpdateResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{79}
+	return file_daemon_proto_rawDescGZIP(), []int{140}
 }
 
 func (x *TriggerUpdateResponse) GetSuccess() bool {
@@ -5493,7 +9808,7 @@ type GetPeerSSHHostKeyRequest struct {
 
 func (x *GetPeerSSHHostKeyRequest) Reset() {
 	*x = GetPeerSSHHostKeyRequest{}
-	mi := &file_daemon_proto_msgTypes[80]
+	mi := &file_daemon_proto_msgTypes[141]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5505,7 +9820,7 @@ func (x *GetPeerSSHHostKeyRequest) String() string {
 func (*GetPeerSSHHostKeyRequest) ProtoMessage() {}
 
 func (x *GetPeerSSHHostKeyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[80]
+	mi := &file_daemon_proto_msgTypes[141]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5518,7 +9833,7 @@ func (x *GetPeerSSHHostKeyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPeerSSHHostKeyRequest.ProtoReflect.Descriptor instead.
 func (*GetPeerSSHHostKeyRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{80}
+	return file_daemon_proto_rawDescGZIP(), []int{141}
 }
 
 func (x *GetPeerSSHHostKeyRequest) GetPeerAddress() string {
@@ -5545,7 +9860,7 @@ type GetPeerSSHHostKeyResponse struct {
 
 func (x *GetPeerSSHHostKeyResponse) Reset() {
 	*x = GetPeerSSHHostKeyResponse{}
-	mi := &file_daemon_proto_msgTypes[81]
+	mi := &file_daemon_proto_msgTypes[142]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5557,7 +9872,7 @@ func (x *GetPeerSSHHostKeyResponse) String() string {
 func (*GetPeerSSHHostKeyResponse) ProtoMessage() {}
 
 func (x *GetPeerSSHHostKeyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[81]
+	mi := &file_daemon_proto_msgTypes[142]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5570,7 +9885,7 @@ func (x *GetPeerSSHHostKeyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPeerSSHHostKeyResponse.ProtoReflect.Descriptor instead.
 func (*GetPeerSSHHostKeyResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{81}
+	return file_daemon_proto_rawDescGZIP(), []int{142}
 }
 
 func (x *GetPeerSSHHostKeyResponse) GetSshHostKey() []byte {
@@ -5612,7 +9927,7 @@ type RequestJWTAuthRequest struct {
 
 func (x *RequestJWTAuthRequest) Reset() {
 	*x = RequestJWTAuthRequest{}
-	mi := &file_daemon_proto_msgTypes[82]
+	mi := &file_daemon_proto_msgTypes[143]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5624,7 +9939,7 @@ func (x *RequestJWTAuthRequest) String() string {
 func (*RequestJWTAuthRequest) ProtoMessage() {}
 
 func (x *RequestJWTAuthRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[82]
+	mi := &file_daemon_proto_msgTypes[143]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5637,7 +9952,7 @@ func (x *RequestJWTAuthRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestJWTAuthRequest.ProtoReflect.Descriptor instead.
 func (*RequestJWTAuthRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{82}
+	return file_daemon_proto_rawDescGZIP(), []int{143}
 }
 
 func (x *RequestJWTAuthRequest) GetHint() string {
@@ -5670,7 +9985,7 @@ type RequestJWTAuthResponse struct {
 
 func (x *RequestJWTAuthResponse) Reset() {
 	*x = RequestJWTAuthResponse{}
-	mi := &file_daemon_proto_msgTypes[83]
+	mi := &file_daemon_proto_msgTypes[144]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5682,7 +9997,7 @@ func (x *RequestJWTAuthResponse) String() string {
 func (*RequestJWTAuthResponse) ProtoMessage() {}
 
 func (x *RequestJWTAuthResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[83]
+	mi := &file_daemon_proto_msgTypes[144]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5695,7 +10010,7 @@ func (x *RequestJWTAuthResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestJWTAuthResponse.ProtoReflect.Descriptor instead.
 func (*RequestJWTAuthResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{83}
+	return file_daemon_proto_rawDescGZIP(), []int{144}
 }
 
 func (x *RequestJWTAuthResponse) GetVerificationURI() string {
@@ -5760,7 +10075,7 @@ type WaitJWTTokenRequest struct {
 
 func (x *WaitJWTTokenRequest) Reset() {
 	*x = WaitJWTTokenRequest{}
-	mi := &file_daemon_proto_msgTypes[84]
+	mi := &file_daemon_proto_msgTypes[145]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5772,7 +10087,7 @@ func (x *WaitJWTTokenRequest) String() string {
 func (*WaitJWTTokenRequest) ProtoMessage() {}
 
 func (x *WaitJWTTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[84]
+	mi := &file_daemon_proto_msgTypes[145]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5785,7 +10100,7 @@ func (x *WaitJWTTokenRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WaitJWTTokenRequest.ProtoReflect.Descriptor instead.
 func (*WaitJWTTokenRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{84}
+	return file_daemon_proto_rawDescGZIP(), []int{145}
 }
 
 func (x *WaitJWTTokenRequest) GetDeviceCode() string {
@@ -5817,7 +10132,7 @@ type WaitJWTTokenResponse struct {
 
 func (x *WaitJWTTokenResponse) Reset() {
 	*x = WaitJWTTokenResponse{}
-	mi := &file_daemon_proto_msgTypes[85]
+	mi := &file_daemon_proto_msgTypes[146]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5829,7 +10144,7 @@ func (x *WaitJWTTokenResponse) String() string {
 func (*WaitJWTTokenResponse) ProtoMessage() {}
 
 func (x *WaitJWTTokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[85]
+	mi := &file_daemon_proto_msgTypes[146]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5842,7 +10157,7 @@ func (x *WaitJWTTokenResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WaitJWTTokenResponse.ProtoReflect.Descriptor instead.
 func (*WaitJWTTokenResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{85}
+	return file_daemon_proto_rawDescGZIP(), []int{146}
 }
 
 func (x *WaitJWTTokenResponse) GetToken() string {
@@ -5878,7 +10193,7 @@ type RequestExtendAuthSessionRequest struct {
 
 func (x *RequestExtendAuthSessionRequest) Reset() {
 	*x = RequestExtendAuthSessionRequest{}
-	mi := &file_daemon_proto_msgTypes[86]
+	mi := &file_daemon_proto_msgTypes[147]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5890,7 +10205,7 @@ func (x *RequestExtendAuthSessionRequest) String() string {
 func (*RequestExtendAuthSessionRequest) ProtoMessage() {}
 
 func (x *RequestExtendAuthSessionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[86]
+	mi := &file_daemon_proto_msgTypes[147]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5903,7 +10218,7 @@ func (x *RequestExtendAuthSessionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestExtendAuthSessionRequest.ProtoReflect.Descriptor instead.
 func (*RequestExtendAuthSessionRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{86}
+	return file_daemon_proto_rawDescGZIP(), []int{147}
 }
 
 func (x *RequestExtendAuthSessionRequest) GetHint() string {
@@ -5934,7 +10249,7 @@ type RequestExtendAuthSessionResponse struct {
 
 func (x *RequestExtendAuthSessionResponse) Reset() {
 	*x = RequestExtendAuthSessionResponse{}
-	mi := &file_daemon_proto_msgTypes[87]
+	mi := &file_daemon_proto_msgTypes[148]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5946,7 +10261,7 @@ func (x *RequestExtendAuthSessionResponse) String() string {
 func (*RequestExtendAuthSessionResponse) ProtoMessage() {}
 
 func (x *RequestExtendAuthSessionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[87]
+	mi := &file_daemon_proto_msgTypes[148]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5959,7 +10274,7 @@ func (x *RequestExtendAuthSessionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestExtendAuthSessionResponse.ProtoReflect.Descriptor instead.
 func (*RequestExtendAuthSessionResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{87}
+	return file_daemon_proto_rawDescGZIP(), []int{148}
 }
 
 func (x *RequestExtendAuthSessionResponse) GetVerificationURI() string {
@@ -6012,7 +10327,7 @@ type WaitExtendAuthSessionRequest struct {
 
 func (x *WaitExtendAuthSessionRequest) Reset() {
 	*x = WaitExtendAuthSessionRequest{}
-	mi := &file_daemon_proto_msgTypes[88]
+	mi := &file_daemon_proto_msgTypes[149]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6024,7 +10339,7 @@ func (x *WaitExtendAuthSessionRequest) String() string {
 func (*WaitExtendAuthSessionRequest) ProtoMessage() {}
 
 func (x *WaitExtendAuthSessionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[88]
+	mi := &file_daemon_proto_msgTypes[149]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6037,7 +10352,7 @@ func (x *WaitExtendAuthSessionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WaitExtendAuthSessionRequest.ProtoReflect.Descriptor instead.
 func (*WaitExtendAuthSessionRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{88}
+	return file_daemon_proto_rawDescGZIP(), []int{149}
 }
 
 func (x *WaitExtendAuthSessionRequest) GetDeviceCode() string {
@@ -6066,7 +10381,7 @@ type WaitExtendAuthSessionResponse struct {
 
 func (x *WaitExtendAuthSessionResponse) Reset() {
 	*x = WaitExtendAuthSessionResponse{}
-	mi := &file_daemon_proto_msgTypes[89]
+	mi := &file_daemon_proto_msgTypes[150]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6078,7 +10393,7 @@ func (x *WaitExtendAuthSessionResponse) String() string {
 func (*WaitExtendAuthSessionResponse) ProtoMessage() {}
 
 func (x *WaitExtendAuthSessionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[89]
+	mi := &file_daemon_proto_msgTypes[150]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6091,7 +10406,7 @@ func (x *WaitExtendAuthSessionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WaitExtendAuthSessionResponse.ProtoReflect.Descriptor instead.
 func (*WaitExtendAuthSessionResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{89}
+	return file_daemon_proto_rawDescGZIP(), []int{150}
 }
 
 func (x *WaitExtendAuthSessionResponse) GetSessionExpiresAt() *timestamppb.Timestamp {
@@ -6111,7 +10426,7 @@ type DismissSessionWarningRequest struct {
 
 func (x *DismissSessionWarningRequest) Reset() {
 	*x = DismissSessionWarningRequest{}
-	mi := &file_daemon_proto_msgTypes[90]
+	mi := &file_daemon_proto_msgTypes[151]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6123,7 +10438,7 @@ func (x *DismissSessionWarningRequest) String() string {
 func (*DismissSessionWarningRequest) ProtoMessage() {}
 
 func (x *DismissSessionWarningRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[90]
+	mi := &file_daemon_proto_msgTypes[151]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6136,7 +10451,7 @@ func (x *DismissSessionWarningRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DismissSessionWarningRequest.ProtoReflect.Descriptor instead.
 func (*DismissSessionWarningRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{90}
+	return file_daemon_proto_rawDescGZIP(), []int{151}
 }
 
 // DismissSessionWarningResponse acknowledges the dismissal. Carries no
@@ -6150,7 +10465,7 @@ type DismissSessionWarningResponse struct {
 
 func (x *DismissSessionWarningResponse) Reset() {
 	*x = DismissSessionWarningResponse{}
-	mi := &file_daemon_proto_msgTypes[91]
+	mi := &file_daemon_proto_msgTypes[152]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6162,7 +10477,7 @@ func (x *DismissSessionWarningResponse) String() string {
 func (*DismissSessionWarningResponse) ProtoMessage() {}
 
 func (x *DismissSessionWarningResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[91]
+	mi := &file_daemon_proto_msgTypes[152]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6175,7 +10490,7 @@ func (x *DismissSessionWarningResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DismissSessionWarningResponse.ProtoReflect.Descriptor instead.
 func (*DismissSessionWarningResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{91}
+	return file_daemon_proto_rawDescGZIP(), []int{152}
 }
 
 // StartCPUProfileRequest for starting CPU profiling
@@ -6187,7 +10502,7 @@ type StartCPUProfileRequest struct {
 
 func (x *StartCPUProfileRequest) Reset() {
 	*x = StartCPUProfileRequest{}
-	mi := &file_daemon_proto_msgTypes[92]
+	mi := &file_daemon_proto_msgTypes[153]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6199,7 +10514,7 @@ func (x *StartCPUProfileRequest) String() string {
 func (*StartCPUProfileRequest) ProtoMessage() {}
 
 func (x *StartCPUProfileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[92]
+	mi := &file_daemon_proto_msgTypes[153]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6212,7 +10527,7 @@ func (x *StartCPUProfileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StartCPUProfileRequest.ProtoReflect.Descriptor instead.
 func (*StartCPUProfileRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{92}
+	return file_daemon_proto_rawDescGZIP(), []int{153}
 }
 
 // StartCPUProfileResponse confirms CPU profiling has started
@@ -6224,7 +10539,7 @@ type StartCPUProfileResponse struct {
 
 func (x *StartCPUProfileResponse) Reset() {
 	*x = StartCPUProfileResponse{}
-	mi := &file_daemon_proto_msgTypes[93]
+	mi := &file_daemon_proto_msgTypes[154]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6236,7 +10551,7 @@ func (x *StartCPUProfileResponse) String() string {
 func (*StartCPUProfileResponse) ProtoMessage() {}
 
 func (x *StartCPUProfileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[93]
+	mi := &file_daemon_proto_msgTypes[154]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6249,7 +10564,7 @@ func (x *StartCPUProfileResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StartCPUProfileResponse.ProtoReflect.Descriptor instead.
 func (*StartCPUProfileResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{93}
+	return file_daemon_proto_rawDescGZIP(), []int{154}
 }
 
 // StopCPUProfileRequest for stopping CPU profiling
@@ -6261,7 +10576,7 @@ type StopCPUProfileRequest struct {
 
 func (x *StopCPUProfileRequest) Reset() {
 	*x = StopCPUProfileRequest{}
-	mi := &file_daemon_proto_msgTypes[94]
+	mi := &file_daemon_proto_msgTypes[155]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6273,7 +10588,7 @@ func (x *StopCPUProfileRequest) String() string {
 func (*StopCPUProfileRequest) ProtoMessage() {}
 
 func (x *StopCPUProfileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[94]
+	mi := &file_daemon_proto_msgTypes[155]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6286,7 +10601,7 @@ func (x *StopCPUProfileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopCPUProfileRequest.ProtoReflect.Descriptor instead.
 func (*StopCPUProfileRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{94}
+	return file_daemon_proto_rawDescGZIP(), []int{155}
 }
 
 // StopCPUProfileResponse confirms CPU profiling has stopped
@@ -6298,7 +10613,7 @@ type StopCPUProfileResponse struct {
 
 func (x *StopCPUProfileResponse) Reset() {
 	*x = StopCPUProfileResponse{}
-	mi := &file_daemon_proto_msgTypes[95]
+	mi := &file_daemon_proto_msgTypes[156]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6310,7 +10625,7 @@ func (x *StopCPUProfileResponse) String() string {
 func (*StopCPUProfileResponse) ProtoMessage() {}
 
 func (x *StopCPUProfileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[95]
+	mi := &file_daemon_proto_msgTypes[156]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6323,7 +10638,7 @@ func (x *StopCPUProfileResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopCPUProfileResponse.ProtoReflect.Descriptor instead.
 func (*StopCPUProfileResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{95}
+	return file_daemon_proto_rawDescGZIP(), []int{156}
 }
 
 type InstallerResultRequest struct {
@@ -6334,7 +10649,7 @@ type InstallerResultRequest struct {
 
 func (x *InstallerResultRequest) Reset() {
 	*x = InstallerResultRequest{}
-	mi := &file_daemon_proto_msgTypes[96]
+	mi := &file_daemon_proto_msgTypes[157]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6346,7 +10661,7 @@ func (x *InstallerResultRequest) String() string {
 func (*InstallerResultRequest) ProtoMessage() {}
 
 func (x *InstallerResultRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[96]
+	mi := &file_daemon_proto_msgTypes[157]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6359,7 +10674,7 @@ func (x *InstallerResultRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstallerResultRequest.ProtoReflect.Descriptor instead.
 func (*InstallerResultRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{96}
+	return file_daemon_proto_rawDescGZIP(), []int{157}
 }
 
 type InstallerResultResponse struct {
@@ -6372,7 +10687,7 @@ type InstallerResultResponse struct {
 
 func (x *InstallerResultResponse) Reset() {
 	*x = InstallerResultResponse{}
-	mi := &file_daemon_proto_msgTypes[97]
+	mi := &file_daemon_proto_msgTypes[158]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6384,7 +10699,7 @@ func (x *InstallerResultResponse) String() string {
 func (*InstallerResultResponse) ProtoMessage() {}
 
 func (x *InstallerResultResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[97]
+	mi := &file_daemon_proto_msgTypes[158]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6397,7 +10712,7 @@ func (x *InstallerResultResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstallerResultResponse.ProtoReflect.Descriptor instead.
 func (*InstallerResultResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{97}
+	return file_daemon_proto_rawDescGZIP(), []int{158}
 }
 
 func (x *InstallerResultResponse) GetSuccess() bool {
@@ -6430,7 +10745,7 @@ type ExposeServiceRequest struct {
 
 func (x *ExposeServiceRequest) Reset() {
 	*x = ExposeServiceRequest{}
-	mi := &file_daemon_proto_msgTypes[98]
+	mi := &file_daemon_proto_msgTypes[159]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6442,7 +10757,7 @@ func (x *ExposeServiceRequest) String() string {
 func (*ExposeServiceRequest) ProtoMessage() {}
 
 func (x *ExposeServiceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[98]
+	mi := &file_daemon_proto_msgTypes[159]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6455,7 +10770,7 @@ func (x *ExposeServiceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExposeServiceRequest.ProtoReflect.Descriptor instead.
 func (*ExposeServiceRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{98}
+	return file_daemon_proto_rawDescGZIP(), []int{159}
 }
 
 func (x *ExposeServiceRequest) GetPort() uint32 {
@@ -6526,7 +10841,7 @@ type ExposeServiceEvent struct {
 
 func (x *ExposeServiceEvent) Reset() {
 	*x = ExposeServiceEvent{}
-	mi := &file_daemon_proto_msgTypes[99]
+	mi := &file_daemon_proto_msgTypes[160]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6538,7 +10853,7 @@ func (x *ExposeServiceEvent) String() string {
 func (*ExposeServiceEvent) ProtoMessage() {}
 
 func (x *ExposeServiceEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[99]
+	mi := &file_daemon_proto_msgTypes[160]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6551,7 +10866,7 @@ func (x *ExposeServiceEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExposeServiceEvent.ProtoReflect.Descriptor instead.
 func (*ExposeServiceEvent) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{99}
+	return file_daemon_proto_rawDescGZIP(), []int{160}
 }
 
 func (x *ExposeServiceEvent) GetEvent() isExposeServiceEvent_Event {
@@ -6592,7 +10907,7 @@ type ExposeServiceReady struct {
 
 func (x *ExposeServiceReady) Reset() {
 	*x = ExposeServiceReady{}
-	mi := &file_daemon_proto_msgTypes[100]
+	mi := &file_daemon_proto_msgTypes[161]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6604,7 +10919,7 @@ func (x *ExposeServiceReady) String() string {
 func (*ExposeServiceReady) ProtoMessage() {}
 
 func (x *ExposeServiceReady) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[100]
+	mi := &file_daemon_proto_msgTypes[161]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6617,7 +10932,7 @@ func (x *ExposeServiceReady) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExposeServiceReady.ProtoReflect.Descriptor instead.
 func (*ExposeServiceReady) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{100}
+	return file_daemon_proto_rawDescGZIP(), []int{161}
 }
 
 func (x *ExposeServiceReady) GetServiceName() string {
@@ -6662,7 +10977,7 @@ type StartCaptureRequest struct {
 
 func (x *StartCaptureRequest) Reset() {
 	*x = StartCaptureRequest{}
-	mi := &file_daemon_proto_msgTypes[101]
+	mi := &file_daemon_proto_msgTypes[162]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6674,7 +10989,7 @@ func (x *StartCaptureRequest) String() string {
 func (*StartCaptureRequest) ProtoMessage() {}
 
 func (x *StartCaptureRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[101]
+	mi := &file_daemon_proto_msgTypes[162]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6687,7 +11002,7 @@ func (x *StartCaptureRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StartCaptureRequest.ProtoReflect.Descriptor instead.
 func (*StartCaptureRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{101}
+	return file_daemon_proto_rawDescGZIP(), []int{162}
 }
 
 func (x *StartCaptureRequest) GetTextOutput() bool {
@@ -6741,7 +11056,7 @@ type CapturePacket struct {
 
 func (x *CapturePacket) Reset() {
 	*x = CapturePacket{}
-	mi := &file_daemon_proto_msgTypes[102]
+	mi := &file_daemon_proto_msgTypes[163]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6753,7 +11068,7 @@ func (x *CapturePacket) String() string {
 func (*CapturePacket) ProtoMessage() {}
 
 func (x *CapturePacket) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[102]
+	mi := &file_daemon_proto_msgTypes[163]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6766,7 +11081,7 @@ func (x *CapturePacket) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CapturePacket.ProtoReflect.Descriptor instead.
 func (*CapturePacket) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{102}
+	return file_daemon_proto_rawDescGZIP(), []int{163}
 }
 
 func (x *CapturePacket) GetData() []byte {
@@ -6780,14 +11095,17 @@ type StartBundleCaptureRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// timeout auto-stops the capture after this duration.
 	// Clamped to a server-side maximum (10 minutes). Zero or unset defaults to the maximum.
-	Timeout       *durationpb.Duration `protobuf:"bytes,1,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Timeout *durationpb.Duration `protobuf:"bytes,1,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// peer limits the capture to the tunnel IPs and routed networks of this peer,
+	// given by FQDN, hostname or tunnel IP. The capture is then kept in anonymized bundles.
+	Peer          string `protobuf:"bytes,2,opt,name=peer,proto3" json:"peer,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *StartBundleCaptureRequest) Reset() {
 	*x = StartBundleCaptureRequest{}
-	mi := &file_daemon_proto_msgTypes[103]
+	mi := &file_daemon_proto_msgTypes[164]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6799,7 +11117,7 @@ func (x *StartBundleCaptureRequest) String() string {
 func (*StartBundleCaptureRequest) ProtoMessage() {}
 
 func (x *StartBundleCaptureRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[103]
+	mi := &file_daemon_proto_msgTypes[164]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6812,7 +11130,7 @@ func (x *StartBundleCaptureRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StartBundleCaptureRequest.ProtoReflect.Descriptor instead.
 func (*StartBundleCaptureRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{103}
+	return file_daemon_proto_rawDescGZIP(), []int{164}
 }
 
 func (x *StartBundleCaptureRequest) GetTimeout() *durationpb.Duration {
@@ -6822,15 +11140,24 @@ func (x *StartBundleCaptureRequest) GetTimeout() *durationpb.Duration {
 	return nil
 }
 
+func (x *StartBundleCaptureRequest) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
 type StartBundleCaptureResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// filter_expr is the filter derived from peer, empty when capturing all traffic.
+	FilterExpr    string `protobuf:"bytes,1,opt,name=filter_expr,json=filterExpr,proto3" json:"filter_expr,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *StartBundleCaptureResponse) Reset() {
 	*x = StartBundleCaptureResponse{}
-	mi := &file_daemon_proto_msgTypes[104]
+	mi := &file_daemon_proto_msgTypes[165]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6842,7 +11169,7 @@ func (x *StartBundleCaptureResponse) String() string {
 func (*StartBundleCaptureResponse) ProtoMessage() {}
 
 func (x *StartBundleCaptureResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[104]
+	mi := &file_daemon_proto_msgTypes[165]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6855,7 +11182,14 @@ func (x *StartBundleCaptureResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StartBundleCaptureResponse.ProtoReflect.Descriptor instead.
 func (*StartBundleCaptureResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{104}
+	return file_daemon_proto_rawDescGZIP(), []int{165}
+}
+
+func (x *StartBundleCaptureResponse) GetFilterExpr() string {
+	if x != nil {
+		return x.FilterExpr
+	}
+	return ""
 }
 
 type StopBundleCaptureRequest struct {
@@ -6866,7 +11200,7 @@ type StopBundleCaptureRequest struct {
 
 func (x *StopBundleCaptureRequest) Reset() {
 	*x = StopBundleCaptureRequest{}
-	mi := &file_daemon_proto_msgTypes[105]
+	mi := &file_daemon_proto_msgTypes[166]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6878,7 +11212,7 @@ func (x *StopBundleCaptureRequest) String() string {
 func (*StopBundleCaptureRequest) ProtoMessage() {}
 
 func (x *StopBundleCaptureRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[105]
+	mi := &file_daemon_proto_msgTypes[166]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6891,7 +11225,7 @@ func (x *StopBundleCaptureRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopBundleCaptureRequest.ProtoReflect.Descriptor instead.
 func (*StopBundleCaptureRequest) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{105}
+	return file_daemon_proto_rawDescGZIP(), []int{166}
 }
 
 type StopBundleCaptureResponse struct {
@@ -6902,7 +11236,7 @@ type StopBundleCaptureResponse struct {
 
 func (x *StopBundleCaptureResponse) Reset() {
 	*x = StopBundleCaptureResponse{}
-	mi := &file_daemon_proto_msgTypes[106]
+	mi := &file_daemon_proto_msgTypes[167]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6914,7 +11248,7 @@ func (x *StopBundleCaptureResponse) String() string {
 func (*StopBundleCaptureResponse) ProtoMessage() {}
 
 func (x *StopBundleCaptureResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[106]
+	mi := &file_daemon_proto_msgTypes[167]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6927,7 +11261,7 @@ func (x *StopBundleCaptureResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopBundleCaptureResponse.ProtoReflect.Descriptor instead.
 func (*StopBundleCaptureResponse) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{106}
+	return file_daemon_proto_rawDescGZIP(), []int{167}
 }
 
 type PortInfo_Range struct {
@@ -6940,7 +11274,7 @@ type PortInfo_Range struct {
 
 func (x *PortInfo_Range) Reset() {
 	*x = PortInfo_Range{}
-	mi := &file_daemon_proto_msgTypes[108]
+	mi := &file_daemon_proto_msgTypes[169]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6952,7 +11286,7 @@ func (x *PortInfo_Range) String() string {
 func (*PortInfo_Range) ProtoMessage() {}
 
 func (x *PortInfo_Range) ProtoReflect() protoreflect.Message {
-	mi := &file_daemon_proto_msgTypes[108]
+	mi := &file_daemon_proto_msgTypes[169]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6965,7 +11299,7 @@ func (x *PortInfo_Range) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PortInfo_Range.ProtoReflect.Descriptor instead.
 func (*PortInfo_Range) Descriptor() ([]byte, []int) {
-	return file_daemon_proto_rawDescGZIP(), []int{28, 0}
+	return file_daemon_proto_rawDescGZIP(), []int{36, 0}
 }
 
 func (x *PortInfo_Range) GetStart() uint32 {
@@ -7128,7 +11462,12 @@ const file_daemon_proto_rawDesc = "" +
 	"\x0edisableSSHAuth\x18\x19 \x01(\bR\x0edisableSSHAuth\x12&\n" +
 	"\x0esshJWTCacheTTL\x18\x1a \x01(\x05R\x0esshJWTCacheTTL\x12!\n" +
 	"\fdisable_ipv6\x18\x1b \x01(\bR\vdisableIpv6\x12*\n" +
-	"\x10mDMManagedFields\x18\x1c \x03(\tR\x10mDMManagedFields\"\x92\x06\n" +
+	"\x10mDMManagedFields\x18\x1c \x03(\tR\x10mDMManagedFields\"=\n" +
+	"\x19GetEffectiveConfigRequest\x12 \n" +
+	"\vshowSecrets\x18\x01 \x01(\bR\vshowSecrets\"`\n" +
+	"\x1aGetEffectiveConfigResponse\x12\x16\n" +
+	"\x06config\x18\x01 \x01(\tR\x06config\x12*\n" +
+	"\x10mDMManagedFields\x18\x02 \x03(\tR\x10mDMManagedFields\"\xe3\x06\n" +
 	"\tPeerState\x12\x0e\n" +
 	"\x02IP\x18\x01 \x01(\tR\x02IP\x12\x16\n" +
 	"\x06pubKey\x18\x02 \x01(\tR\x06pubKey\x12\x1e\n" +
@@ -7153,7 +11492,13 @@ const file_daemon_proto_rawDesc = "" +
 	"\n" +
 	"sshHostKey\x18\x13 \x01(\fR\n" +
 	"sshHostKey\x12\x12\n" +
-	"\x04ipv6\x18\x14 \x01(\tR\x04ipv6\"\x9c\x02\n" +
+	"\x04ipv6\x18\x14 \x01(\tR\x04ipv6\x127\n" +
+	"\vconnHistory\x18\x15 \x03(\v2\x15.daemon.PeerConnEventR\vconnHistory\x12\x16\n" +
+	"\x06groups\x18\x16 \x03(\tR\x06groups\"m\n" +
+	"\rPeerConnEvent\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x14\n" +
+	"\x05event\x18\x02 \x01(\tR\x05event\x12\x16\n" +
+	"\x06detail\x18\x03 \x01(\tR\x06detail\"\x9c\x02\n" +
 	"\x0eLocalPeerState\x12\x0e\n" +
 	"\x02IP\x18\x01 \x01(\tR\x02IP\x12\x16\n" +
 	"\x06pubKey\x18\x02 \x01(\tR\x06pubKey\x12(\n" +
@@ -7167,17 +11512,21 @@ const file_daemon_proto_rawDesc = "" +
 	"\vSignalState\x12\x10\n" +
 	"\x03URL\x18\x01 \x01(\tR\x03URL\x12\x1c\n" +
 	"\tconnected\x18\x02 \x01(\bR\tconnected\x12\x14\n" +
-	"\x05error\x18\x03 \x01(\tR\x05error\"W\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"\xda\x01\n" +
 	"\x0fManagementState\x12\x10\n" +
 	"\x03URL\x18\x01 \x01(\tR\x03URL\x12\x1c\n" +
 	"\tconnected\x18\x02 \x01(\bR\tconnected\x12\x14\n" +
-	"\x05error\x18\x03 \x01(\tR\x05error\"p\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12;\n" +
+	"\vclockOffset\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\vclockOffset\x12\"\n" +
+	"\fcapabilities\x18\x05 \x03(\tR\fcapabilities\x12 \n" +
+	"\vsyncVersion\x18\x06 \x01(\x05R\vsyncVersion\"\xa5\x01\n" +
 	"\n" +
 	"RelayState\x12\x10\n" +
 	"\x03URI\x18\x01 \x01(\tR\x03URI\x12\x1c\n" +
 	"\tavailable\x18\x02 \x01(\bR\tavailable\x12\x14\n" +
 	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1c\n" +
-	"\ttransport\x18\x04 \x01(\tR\ttransport\"r\n" +
+	"\ttransport\x18\x04 \x01(\tR\ttransport\x123\n" +
+	"\alatency\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\alatency\"r\n" +
 	"\fNSGroupState\x12\x18\n" +
 	"\aservers\x18\x01 \x03(\tR\aservers\x12\x18\n" +
 	"\adomains\x18\x02 \x03(\tR\adomains\x12\x18\n" +
@@ -7191,7 +11540,7 @@ const file_daemon_proto_rawDesc = "" +
 	"\fportForwards\x18\x05 \x03(\tR\fportForwards\"^\n" +
 	"\x0eSSHServerState\x12\x18\n" +
 	"\aenabled\x18\x01 \x01(\bR\aenabled\x122\n" +
-	"\bsessions\x18\x02 \x03(\v2\x16.daemon.SSHSessionInfoR\bsessions\"\xdb\x04\n" +
+	"\bsessions\x18\x02 \x03(\v2\x16.daemon.SSHSessionInfoR\bsessions\"\xf7\x05\n" +
 	"\n" +
 	"FullStatus\x12A\n" +
 	"\x0fmanagementState\x18\x01 \x01(\v2\x17.daemon.ManagementStateR\x0fmanagementState\x125\n" +
@@ -7206,7 +11555,38 @@ const file_daemon_proto_rawDesc = "" +
 	"\x15lazyConnectionEnabled\x18\t \x01(\bR\x15lazyConnectionEnabled\x12>\n" +
 	"\x0esshServerState\x18\n" +
 	" \x01(\v2\x16.daemon.SSHServerStateR\x0esshServerState\x12*\n" +
-	"\x10networksRevision\x18\v \x01(\x04R\x10networksRevision\"\x15\n" +
+	"\x10networksRevision\x18\v \x01(\x04R\x10networksRevision\x125\n" +
+	"\vdaemonState\x18\f \x01(\v2\x13.daemon.DaemonStateR\vdaemonState\x123\n" +
+	"\texitNodes\x18\r \x03(\v2\x15.daemon.ExitNodeStateR\texitNodes\x12.\n" +
+	"\aposture\x18\x0e \x01(\v2\x14.daemon.PostureStateR\aposture\"\x95\x01\n" +
+	"\fPostureState\x12<\n" +
+	"\vevaluatedAt\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\vevaluatedAt\x121\n" +
+	"\x06checks\x18\x02 \x03(\v2\x19.daemon.PostureCheckStateR\x06checks\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"N\n" +
+	"\x11PostureCheckState\x129\n" +
+	"\tprocesses\x18\x01 \x03(\v2\x1b.daemon.PostureProcessStateR\tprocesses\"[\n" +
+	"\x13PostureProcessState\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x16\n" +
+	"\x06exists\x18\x02 \x01(\bR\x06exists\x12\x18\n" +
+	"\arunning\x18\x03 \x01(\bR\arunning\"\x9e\x02\n" +
+	"\rExitNodeState\x12\x14\n" +
+	"\x05netID\x18\x01 \x01(\tR\x05netID\x12\x18\n" +
+	"\anetwork\x18\x02 \x01(\tR\anetwork\x12\x16\n" +
+	"\x06pubKey\x18\x03 \x01(\tR\x06pubKey\x12\x12\n" +
+	"\x04fqdn\x18\x04 \x01(\tR\x04fqdn\x12\x0e\n" +
+	"\x02IP\x18\x05 \x01(\tR\x02IP\x12\x1e\n" +
+	"\n" +
+	"connStatus\x18\x06 \x01(\tR\n" +
+	"connStatus\x12\x18\n" +
+	"\arelayed\x18\a \x01(\bR\arelayed\x123\n" +
+	"\alatency\x18\b \x01(\v2\x19.google.protobuf.DurationR\alatency\x12\x1a\n" +
+	"\bselected\x18\t \x01(\bR\bselected\x12\x16\n" +
+	"\x06active\x18\n" +
+	" \x01(\bR\x06active\"\x91\x01\n" +
+	"\vDaemonState\x128\n" +
+	"\tstartedAt\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12\"\n" +
+	"\frestartCount\x18\x02 \x01(\rR\frestartCount\x12$\n" +
+	"\rstartsLast24h\x18\x03 \x01(\rR\rstartsLast24h\"\x15\n" +
 	"\x13ListNetworksRequest\"?\n" +
 	"\x14ListNetworksResponse\x12'\n" +
 	"\x06routes\x18\x01 \x03(\v2\x0f.daemon.NetworkR\x06routes\"a\n" +
@@ -7242,7 +11622,7 @@ const file_daemon_proto_rawDesc = "" +
 	"\x12translatedHostname\x18\x04 \x01(\tR\x12translatedHostname\x128\n" +
 	"\x0etranslatedPort\x18\x05 \x01(\v2\x10.daemon.PortInfoR\x0etranslatedPort\"G\n" +
 	"\x17ForwardingRulesResponse\x12,\n" +
-	"\x05rules\x18\x01 \x03(\v2\x16.daemon.ForwardingRuleR\x05rules\"\xb4\x01\n" +
+	"\x05rules\x18\x01 \x03(\v2\x16.daemon.ForwardingRuleR\x05rules\"\xc9\x0e\n" +
 	"\x12DebugBundleRequest\x12\x1c\n" +
 	"\tanonymize\x18\x01 \x01(\bR\tanonymize\x12\x1e\n" +
 	"\n" +
@@ -7252,17 +11632,177 @@ const file_daemon_proto_rawDesc = "" +
 	"\flogFileCount\x18\x05 \x01(\rR\flogFileCount\x12\x1e\n" +
 	"\n" +
 	"cliVersion\x18\x06 \x01(\tR\n" +
-	"cliVersion\"}\n" +
+	"cliVersion\x12*\n" +
+	"\x10anonymizePreview\x18\a \x01(\bR\x10anonymizePreview\x12\"\n" +
+	"\fpeerMtuProbe\x18\b \x01(\bR\fpeerMtuProbe\x12$\n" +
+	"\rencryptionKey\x18\t \x01(\fR\rencryptionKey\x12\"\n" +
+	"\fstatusFormat\x18\n" +
+	" \x01(\tR\fstatusFormat\x12\x18\n" +
+	"\amaxSize\x18\v \x01(\x04R\amaxSize\x12(\n" +
+	"\x0fnetworkMapCount\x18\f \x01(\rR\x0fnetworkMapCount\x12H\n" +
+	"\x10anonymizationMap\x18\r \x03(\v2\x1c.daemon.AnonymizationMappingR\x10anonymizationMap\x128\n" +
+	"\x17persistAnonymizationMap\x18\x0e \x01(\bR\x17persistAnonymizationMap\x12/\n" +
+	"\x05since\x18\x0f \x01(\v2\x19.google.protobuf.DurationR\x05since\x12?\n" +
+	"\ruploadTimeout\x18\x10 \x01(\v2\x19.google.protobuf.DurationR\ruploadTimeout\x12$\n" +
+	"\ruploadRetries\x18\x11 \x01(\rR\ruploadRetries\x12\x1a\n" +
+	"\bprofiles\x18\x12 \x01(\bR\bprofiles\x12I\n" +
+	"\x12profileCpuDuration\x18\x13 \x01(\v2\x19.google.protobuf.DurationR\x12profileCpuDuration\x12\x14\n" +
+	"\x05peers\x18\x14 \x03(\tR\x05peers\x12\x14\n" +
+	"\x05label\x18\x15 \x01(\tR\x05label\x12&\n" +
+	"\x0eexcludeCapture\x18\x16 \x01(\bR\x0eexcludeCapture\x12&\n" +
+	"\x0eanonymizeLevel\x18\x17 \x01(\tR\x0eanonymizeLevel\x12\x1e\n" +
+	"\n" +
+	"extraPaths\x18\x18 \x03(\tR\n" +
+	"extraPaths\x12 \n" +
+	"\vcompression\x18\x19 \x01(\tR\vcompression\x12\x1e\n" +
+	"\n" +
+	"uploadLast\x18\x1a \x01(\bR\n" +
+	"uploadLast\x12.\n" +
+	"\x12skipUploadURLCheck\x18\x1b \x01(\bR\x12skipUploadURLCheck\x12\"\n" +
+	"\fstagedLogsId\x18\x1c \x01(\tR\fstagedLogsId\x12\x1e\n" +
+	"\n" +
+	"logInclude\x18\x1d \x03(\tR\n" +
+	"logInclude\x12\x1e\n" +
+	"\n" +
+	"logExclude\x18\x1e \x03(\tR\n" +
+	"logExclude\x12\x1c\n" +
+	"\toutputDir\x18\x1f \x01(\tR\toutputDir\x122\n" +
+	"\x14systemInfoCollectors\x18  \x03(\tR\x14systemInfoCollectors\x12,\n" +
+	"\x11anonymizePatterns\x18! \x03(\tR\x11anonymizePatterns\x12\"\n" +
+	"\fallowSecrets\x18\" \x01(\bR\fallowSecrets\x12\x1c\n" +
+	"\tsplitSize\x18# \x01(\x04R\tsplitSize\x120\n" +
+	"\n" +
+	"aclQueries\x18$ \x03(\v2\x10.daemon.ACLQueryR\n" +
+	"aclQueries\x12$\n" +
+	"\rcommandOutput\x18% \x01(\fR\rcommandOutput\x12\x1c\n" +
+	"\tpeerGroup\x18& \x01(\tR\tpeerGroup\x12P\n" +
+	"\x13interfaceStatsStart\x18' \x01(\v2\x1e.daemon.InterfaceStatsSnapshotR\x13interfaceStatsStart\x12\x16\n" +
+	"\x06domain\x18( \x01(\tR\x06domain\x12F\n" +
+	"\x10resourceTimeline\x18) \x03(\v2\x1a.daemon.RuntimeStatsSampleR\x10resourceTimeline\x12\x18\n" +
+	"\alogTail\x18* \x01(\rR\alogTail\x12>\n" +
+	"\fsessionStart\x18+ \x01(\v2\x1a.google.protobuf.TimestampR\fsessionStart\x12\x1e\n" +
+	"\n" +
+	"traceroute\x18, \x01(\fR\n" +
+	"traceroute\x12(\n" +
+	"\x0fincrementalLogs\x18- \x01(\bR\x0fincrementalLogs\x12\x1a\n" +
+	"\bfullLogs\x18. \x01(\bR\bfullLogs\x12\x16\n" +
+	"\x06noLogs\x18/ \x01(\bR\x06noLogs\"\x17\n" +
+	"\x15StageDebugLogsRequest\">\n" +
+	"\x16StageDebugLogsResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05files\x18\x02 \x03(\tR\x05files\"\x1a\n" +
+	"\x18GetInterfaceStatsRequest\"W\n" +
+	"\x19GetInterfaceStatsResponse\x12:\n" +
+	"\bsnapshot\x18\x01 \x01(\v2\x1e.daemon.InterfaceStatsSnapshotR\bsnapshot\"\xbc\x01\n" +
+	"\x16InterfaceStatsSnapshot\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x129\n" +
+	"\n" +
+	"interfaces\x18\x02 \x03(\v2\x19.daemon.InterfaceCountersR\n" +
+	"interfaces\x127\n" +
+	"\twireGuard\x18\x03 \x01(\v2\x19.daemon.InterfaceCountersR\twireGuard\"\x18\n" +
+	"\x16GetRuntimeStatsRequest\"M\n" +
+	"\x17GetRuntimeStatsResponse\x122\n" +
+	"\x06sample\x18\x01 \x01(\v2\x1a.daemon.RuntimeStatsSampleR\x06sample\"\xd8\x01\n" +
+	"\x12RuntimeStatsSample\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x1e\n" +
+	"\n" +
+	"goroutines\x18\x02 \x01(\x04R\n" +
+	"goroutines\x12\x18\n" +
+	"\aopenFds\x18\x03 \x01(\x03R\aopenFds\x12\x1c\n" +
+	"\theapBytes\x18\x04 \x01(\x04R\theapBytes\x12\x1e\n" +
+	"\n" +
+	"totalBytes\x18\x05 \x01(\x04R\n" +
+	"totalBytes\x12\x1a\n" +
+	"\bgcCycles\x18\x06 \x01(\x04R\bgcCycles\"\x8b\x02\n" +
+	"\x11InterfaceCounters\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\arxBytes\x18\x02 \x01(\x04R\arxBytes\x12\x18\n" +
+	"\atxBytes\x18\x03 \x01(\x04R\atxBytes\x12\x1c\n" +
+	"\trxPackets\x18\x04 \x01(\x04R\trxPackets\x12\x1c\n" +
+	"\ttxPackets\x18\x05 \x01(\x04R\ttxPackets\x12\x1a\n" +
+	"\brxErrors\x18\x06 \x01(\x04R\brxErrors\x12\x1a\n" +
+	"\btxErrors\x18\a \x01(\x04R\btxErrors\x12\x1c\n" +
+	"\trxDropped\x18\b \x01(\x04R\trxDropped\x12\x1c\n" +
+	"\ttxDropped\x18\t \x01(\x04R\ttxDropped\"\xaf\x03\n" +
 	"\x13DebugBundleResponse\x12\x12\n" +
 	"\x04path\x18\x01 \x01(\tR\x04path\x12 \n" +
 	"\vuploadedKey\x18\x02 \x01(\tR\vuploadedKey\x120\n" +
-	"\x13uploadFailureReason\x18\x03 \x01(\tR\x13uploadFailureReason\"\x14\n" +
-	"\x12GetLogLevelRequest\"=\n" +
+	"\x13uploadFailureReason\x18\x03 \x01(\tR\x13uploadFailureReason\x12P\n" +
+	"\x14anonymizationPreview\x18\x04 \x03(\v2\x1c.daemon.AnonymizationSummaryR\x14anonymizationPreview\x12$\n" +
+	"\rtruncatedLogs\x18\x05 \x03(\tR\rtruncatedLogs\x12H\n" +
+	"\x10anonymizationMap\x18\x06 \x03(\v2\x1c.daemon.AnonymizationMappingR\x10anonymizationMap\x12\x1e\n" +
+	"\n" +
+	"anonymized\x18\a \x01(\bR\n" +
+	"anonymized\x12\x14\n" +
+	"\x05parts\x18\b \x03(\tR\x05parts\x128\n" +
+	"\texpiresAt\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"&\n" +
+	"\x10DebugBundleChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"i\n" +
+	"\x18DebugBundleProgressEvent\x12\x14\n" +
+	"\x05stage\x18\x01 \x01(\tR\x05stage\x127\n" +
+	"\bresponse\x18\x02 \x01(\v2\x1b.daemon.DebugBundleResponseR\bresponse\"\x7f\n" +
+	"\x14AnonymizationSummary\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\rR\x05count\x125\n" +
+	"\asamples\x18\x03 \x03(\v2\x1b.daemon.AnonymizationSampleR\asamples\"Q\n" +
+	"\x13AnonymizationSample\x12\x1a\n" +
+	"\boriginal\x18\x01 \x01(\tR\boriginal\x12\x1e\n" +
+	"\n" +
+	"anonymized\x18\x02 \x01(\tR\n" +
+	"anonymized\"n\n" +
+	"\x14AnonymizationMapping\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x1a\n" +
+	"\boriginal\x18\x02 \x01(\tR\boriginal\x12\x1e\n" +
+	"\n" +
+	"anonymized\x18\x03 \x01(\tR\n" +
+	"anonymized\"\x14\n" +
+	"\x12GetLogLevelRequest\"\xcd\x02\n" +
 	"\x13GetLogLevelResponse\x12&\n" +
-	"\x05level\x18\x01 \x01(\x0e2\x10.daemon.LogLevelR\x05level\"<\n" +
+	"\x05level\x18\x01 \x01(\x0e2\x10.daemon.LogLevelR\x05level\x124\n" +
+	"\fdefaultLevel\x18\x02 \x01(\x0e2\x10.daemon.LogLevelR\fdefaultLevel\x12\x1c\n" +
+	"\tisDefault\x18\x03 \x01(\bR\tisDefault\x12K\n" +
+	"\n" +
+	"components\x18\x04 \x03(\v2+.daemon.GetLogLevelResponse.ComponentsEntryR\n" +
+	"components\x12\x1c\n" +
+	"\tpersisted\x18\x05 \x01(\bR\tpersisted\x1aO\n" +
+	"\x0fComponentsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12&\n" +
+	"\x05value\x18\x02 \x01(\x0e2\x10.daemon.LogLevelR\x05value:\x028\x01\"\xb1\x02\n" +
 	"\x12SetLogLevelRequest\x12&\n" +
-	"\x05level\x18\x01 \x01(\x0e2\x10.daemon.LogLevelR\x05level\"\x15\n" +
-	"\x13SetLogLevelResponse\"*\n" +
+	"\x05level\x18\x01 \x01(\x0e2\x10.daemon.LogLevelR\x05level\x12J\n" +
+	"\n" +
+	"components\x18\x02 \x03(\v2*.daemon.SetLogLevelRequest.ComponentsEntryR\n" +
+	"components\x12\x18\n" +
+	"\apersist\x18\x03 \x01(\bR\apersist\x12 \n" +
+	"\vprofileName\x18\x04 \x01(\tR\vprofileName\x12\x1a\n" +
+	"\busername\x18\x05 \x01(\tR\busername\x1aO\n" +
+	"\x0fComponentsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12&\n" +
+	"\x05value\x18\x02 \x01(\x0e2\x10.daemon.LogLevelR\x05value:\x028\x01\"\xc5\x01\n" +
+	"\x13SetLogLevelResponse\x12,\n" +
+	"\x11unknownComponents\x18\x01 \x03(\tR\x11unknownComponents\x12\x1c\n" +
+	"\tpersisted\x18\x02 \x01(\bR\tpersisted\x12&\n" +
+	"\x0epersistCleared\x18\x03 \x01(\bR\x0epersistCleared\x12\x18\n" +
+	"\aapplied\x18\x04 \x01(\bR\aapplied\x12 \n" +
+	"\vprofileName\x18\x05 \x01(\tR\vprofileName\"\x12\n" +
+	"\x10RotateLogRequest\"5\n" +
+	"\x11RotateLogResponse\x12 \n" +
+	"\vrotatedPath\x18\x01 \x01(\tR\vrotatedPath\"-\n" +
+	"\x13SetLogFormatRequest\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\">\n" +
+	"\x14SetLogFormatResponse\x12&\n" +
+	"\x0epreviousFormat\x18\x01 \x01(\tR\x0epreviousFormat\"Y\n" +
+	"\x0fTailLogsRequest\x12&\n" +
+	"\x05level\x18\x01 \x01(\x0e2\x10.daemon.LogLevelR\x05level\x12\x1e\n" +
+	"\n" +
+	"components\x18\x02 \x03(\tR\n" +
+	"components\"\xad\x01\n" +
+	"\aLogLine\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12&\n" +
+	"\x05level\x18\x02 \x01(\x0e2\x10.daemon.LogLevelR\x05level\x12\x1c\n" +
+	"\tcomponent\x18\x03 \x01(\tR\tcomponent\x12\x12\n" +
+	"\x04line\x18\x04 \x01(\tR\x04line\x12\x18\n" +
+	"\adropped\x18\x05 \x01(\rR\adropped\"*\n" +
 	"\x14RegisterUILogRequest\x12\x12\n" +
 	"\x04path\x18\x01 \x01(\tR\x04path\"\x17\n" +
 	"\x15RegisterUILogResponse\"\x1b\n" +
@@ -7285,7 +11825,10 @@ const file_daemon_proto_rawDesc = "" +
 	"\x0edeleted_states\x18\x01 \x01(\x05R\rdeletedStates\"=\n" +
 	"!SetSyncResponsePersistenceRequest\x12\x18\n" +
 	"\aenabled\x18\x01 \x01(\bR\aenabled\"$\n" +
-	"\"SetSyncResponsePersistenceResponse\"v\n" +
+	"\"SetSyncResponsePersistenceResponse\"#\n" +
+	"!GetSyncResponsePersistenceRequest\">\n" +
+	"\"GetSyncResponsePersistenceResponse\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\"v\n" +
 	"\bTCPFlags\x12\x10\n" +
 	"\x03syn\x18\x01 \x01(\bR\x03syn\x12\x10\n" +
 	"\x03ack\x18\x02 \x01(\bR\x03ack\x12\x10\n" +
@@ -7319,7 +11862,129 @@ const file_daemon_proto_rawDesc = "" +
 	"\x13_forwarding_details\"n\n" +
 	"\x13TracePacketResponse\x12*\n" +
 	"\x06stages\x18\x01 \x03(\v2\x12.daemon.TraceStageR\x06stages\x12+\n" +
-	"\x11final_disposition\x18\x02 \x01(\bR\x10finalDisposition\"\x12\n" +
+	"\x11final_disposition\x18\x02 \x01(\bR\x10finalDisposition\"t\n" +
+	"\bACLQuery\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12 \n" +
+	"\vdestination\x18\x02 \x01(\tR\vdestination\x12\x1a\n" +
+	"\bprotocol\x18\x03 \x01(\tR\bprotocol\x12\x12\n" +
+	"\x04port\x18\x04 \x01(\rR\x04port\"<\n" +
+	"\x12EvaluateACLRequest\x12&\n" +
+	"\x05query\x18\x01 \x01(\v2\x10.daemon.ACLQueryR\x05query\"[\n" +
+	"\x13EvaluateACLResponse\x12\x18\n" +
+	"\aallowed\x18\x01 \x01(\bR\aallowed\x12\x12\n" +
+	"\x04rule\x18\x02 \x01(\tR\x04rule\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\x15\n" +
+	"\x13GetDropStatsRequest\"?\n" +
+	"\vDropCounter\x12\x16\n" +
+	"\x06reason\x18\x01 \x01(\tR\x06reason\x12\x18\n" +
+	"\apackets\x18\x02 \x01(\x04R\apackets\"j\n" +
+	"\x14GetDropStatsResponse\x12'\n" +
+	"\x0finbound_packets\x18\x01 \x01(\x04R\x0einboundPackets\x12)\n" +
+	"\x05drops\x18\x02 \x03(\v2\x13.daemon.DropCounterR\x05drops\"O\n" +
+	"\x18RelayFailoverTestRequest\x123\n" +
+	"\atimeout\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\atimeout\"c\n" +
+	"\x12RelayFailoverEvent\x123\n" +
+	"\aelapsed\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\aelapsed\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xaa\x01\n" +
+	"\x19RelayFailoverTestResponse\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x125\n" +
+	"\bduration\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\bduration\x122\n" +
+	"\x06events\x18\x04 \x03(\v2\x1a.daemon.RelayFailoverEventR\x06events\"\x19\n" +
+	"\x17GetStartupTimingRequest\"\xbf\x01\n" +
+	"\fStartupPhase\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aattempt\x18\x02 \x01(\x05R\aattempt\x124\n" +
+	"\astarted\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\astarted\x125\n" +
+	"\bduration\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\bduration\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"\xd0\x01\n" +
+	"\x18GetStartupTimingResponse\x129\n" +
+	"\n" +
+	"started_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12\x1a\n" +
+	"\bcomplete\x18\x02 \x01(\bR\bcomplete\x12/\n" +
+	"\x05total\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x05total\x12,\n" +
+	"\x06phases\x18\x04 \x03(\v2\x14.daemon.StartupPhaseR\x06phases\"_\n" +
+	"\x13ProbePeerMTURequest\x12\x14\n" +
+	"\x05peers\x18\x01 \x03(\tR\x05peers\x12\x10\n" +
+	"\x03all\x18\x02 \x01(\bR\x03all\x12 \n" +
+	"\vparallelism\x18\x03 \x01(\rR\vparallelism\"|\n" +
+	"\rPeerMTUResult\x12\x12\n" +
+	"\x04peer\x18\x01 \x01(\tR\x04peer\x12\x0e\n" +
+	"\x02ip\x18\x02 \x01(\tR\x02ip\x12\x19\n" +
+	"\bpath_mtu\x18\x03 \x01(\rR\apathMtu\x12\x16\n" +
+	"\x06probes\x18\x04 \x01(\rR\x06probes\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"f\n" +
+	"\x14ProbePeerMTUResponse\x12\x1d\n" +
+	"\n" +
+	"tunnel_mtu\x18\x01 \x01(\rR\ttunnelMtu\x12/\n" +
+	"\aresults\x18\x02 \x03(\v2\x15.daemon.PeerMTUResultR\aresults\"q\n" +
+	"\x10PeerProbeRequest\x12\x12\n" +
+	"\x04peer\x18\x01 \x01(\tR\x04peer\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\rR\x05count\x123\n" +
+	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\"\xa9\x04\n" +
+	"\x11PeerProbeResponse\x12\x12\n" +
+	"\x04peer\x18\x01 \x01(\tR\x04peer\x12\x0e\n" +
+	"\x02ip\x18\x02 \x01(\tR\x02ip\x12\x1c\n" +
+	"\treachable\x18\x03 \x01(\bR\treachable\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12;\n" +
+	"\vconnectTime\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\vconnectTime\x12\x18\n" +
+	"\arelayed\x18\x06 \x01(\bR\arelayed\x12\"\n" +
+	"\frelayAddress\x18\a \x01(\tR\frelayAddress\x124\n" +
+	"\x15localIceCandidateType\x18\b \x01(\tR\x15localIceCandidateType\x126\n" +
+	"\x16remoteIceCandidateType\x18\t \x01(\tR\x16remoteIceCandidateType\x12<\n" +
+	"\x19localIceCandidateEndpoint\x18\n" +
+	" \x01(\tR\x19localIceCandidateEndpoint\x12>\n" +
+	"\x1aremoteIceCandidateEndpoint\x18\v \x01(\tR\x1aremoteIceCandidateEndpoint\x12-\n" +
+	"\x04rtts\x18\f \x03(\v2\x19.google.protobuf.DurationR\x04rtts\x12\x12\n" +
+	"\x04lost\x18\r \x01(\rR\x04lost\x12\x12\n" +
+	"\x04note\x18\x0e \x01(\tR\x04note\"a\n" +
+	"\x14BenchmarkPeerRequest\x12\x12\n" +
+	"\x04peer\x18\x01 \x01(\tR\x04peer\x125\n" +
+	"\bduration\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\bduration\"\xd4\x03\n" +
+	"\x15BenchmarkPeerResponse\x12\x12\n" +
+	"\x04peer\x18\x01 \x01(\tR\x04peer\x12\x0e\n" +
+	"\x02ip\x18\x02 \x01(\tR\x02ip\x12\x18\n" +
+	"\arelayed\x18\x03 \x01(\bR\arelayed\x12\"\n" +
+	"\frelayAddress\x18\x04 \x01(\tR\frelayAddress\x12 \n" +
+	"\vpathChanged\x18\x05 \x01(\bR\vpathChanged\x12 \n" +
+	"\vuploadBytes\x18\x06 \x01(\x04R\vuploadBytes\x12A\n" +
+	"\x0euploadDuration\x18\a \x01(\v2\x19.google.protobuf.DurationR\x0euploadDuration\x12$\n" +
+	"\rdownloadBytes\x18\b \x01(\x04R\rdownloadBytes\x12E\n" +
+	"\x10downloadDuration\x18\t \x01(\v2\x19.google.protobuf.DurationR\x10downloadDuration\x12-\n" +
+	"\x04rtts\x18\n" +
+	" \x03(\v2\x19.google.protobuf.DurationR\x04rtts\x12\x1a\n" +
+	"\bechoLost\x18\v \x01(\rR\bechoLost\x12\x1a\n" +
+	"\bmaxBytes\x18\f \x01(\x04R\bmaxBytes\"\x1e\n" +
+	"\x1cGetInterfaceConflictsRequest\"s\n" +
+	"\x11InterfaceConflict\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x18\n" +
+	"\anetwork\x18\x02 \x01(\tR\anetwork\x12\x1c\n" +
+	"\tinterface\x18\x03 \x01(\tR\tinterface\x12\x12\n" +
+	"\x04host\x18\x04 \x01(\tR\x04host\"\xc8\x01\n" +
+	"\x1dGetInterfaceConflictsResponse\x12\x18\n" +
+	"\aoverlay\x18\x01 \x03(\tR\aoverlay\x12%\n" +
+	"\x0eroutes_checked\x18\x02 \x01(\x05R\rroutesChecked\x12-\n" +
+	"\x12interfaces_checked\x18\x03 \x01(\x05R\x11interfacesChecked\x127\n" +
+	"\tconflicts\x18\x04 \x03(\v2\x19.daemon.InterfaceConflictR\tconflicts\"\x1a\n" +
+	"\x18GetRouteConflictsRequest\"\x90\x01\n" +
+	"\x10ConflictingRoute\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x18\n" +
+	"\agateway\x18\x02 \x01(\tR\agateway\x12\x1c\n" +
+	"\tinterface\x18\x03 \x01(\tR\tinterface\x12\x14\n" +
+	"\x05table\x18\x04 \x01(\tR\x05table\x12\x16\n" +
+	"\x06metric\x18\x05 \x01(\x05R\x06metric\"\xa1\x01\n" +
+	"\rRouteConflict\x122\n" +
+	"\anetbird\x18\x01 \x01(\v2\x18.daemon.ConflictingRouteR\anetbird\x12,\n" +
+	"\x04host\x18\x02 \x01(\v2\x18.daemon.ConflictingRouteR\x04host\x12\x16\n" +
+	"\x06winner\x18\x03 \x01(\tR\x06winner\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"\xdd\x01\n" +
+	"\x19GetRouteConflictsResponse\x12\x1c\n" +
+	"\tinterface\x18\x01 \x01(\tR\tinterface\x12%\n" +
+	"\x0epolicy_routing\x18\x02 \x01(\bR\rpolicyRouting\x12%\n" +
+	"\x0enetbird_routes\x18\x03 \x01(\x05R\rnetbirdRoutes\x12\x1f\n" +
+	"\vhost_routes\x18\x04 \x01(\x05R\n" +
+	"hostRoutes\x123\n" +
+	"\tconflicts\x18\x05 \x03(\v2\x15.daemon.RouteConflictR\tconflicts\"\x12\n" +
 	"\x10SubscribeRequest\"\x93\x04\n" +
 	"\vSystemEvent\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x128\n" +
@@ -7463,7 +12128,24 @@ const file_daemon_proto_rawDesc = "" +
 	"\x17disable_update_settings\x18\x02 \x01(\bR\x15disableUpdateSettings\x12)\n" +
 	"\x10disable_networks\x18\x03 \x01(\bR\x0fdisableNetworks\x127\n" +
 	"\x15disable_advanced_view\x18\x04 \x01(\bH\x00R\x13disableAdvancedView\x88\x01\x01B\x18\n" +
-	"\x16_disable_advanced_view\"3\n" +
+	"\x16_disable_advanced_view\"\x19\n" +
+	"\x17ListFeatureFlagsRequest\"\x8f\x01\n" +
+	"\vFeatureFlag\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\x12\x16\n" +
+	"\x06source\x18\x03 \x01(\tR\x06source\x12\x16\n" +
+	"\x06detail\x18\x04 \x01(\tR\x06detail\x12\"\n" +
+	"\fexperimental\x18\x05 \x01(\bR\fexperimental\"E\n" +
+	"\x18ListFeatureFlagsResponse\x12)\n" +
+	"\x05flags\x18\x01 \x03(\v2\x13.daemon.FeatureFlagR\x05flags\"\x15\n" +
+	"\x13GetBuildInfoRequest\"\xcb\x01\n" +
+	"\x14GetBuildInfoResponse\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12\x16\n" +
+	"\x06commit\x18\x02 \x01(\tR\x06commit\x12\x1c\n" +
+	"\tbuildDate\x18\x03 \x01(\tR\tbuildDate\x12\x1c\n" +
+	"\tgoVersion\x18\x04 \x01(\tR\tgoVersion\x12\x1a\n" +
+	"\bplatform\x18\x05 \x01(\tR\bplatform\x12)\n" +
+	"\x05flags\x18\x06 \x03(\v2\x13.daemon.FeatureFlagR\x05flags\"3\n" +
 	"\x19MDMManagedFieldsViolation\x12\x16\n" +
 	"\x06fields\x18\x01 \x03(\tR\x06fields\"\x16\n" +
 	"\x14TriggerUpdateRequest\"M\n" +
@@ -7560,10 +12242,13 @@ const file_daemon_proto_rawDesc = "" +
 	"\averbose\x18\x05 \x01(\bR\averbose\x12\x14\n" +
 	"\x05ascii\x18\x06 \x01(\bR\x05ascii\"#\n" +
 	"\rCapturePacket\x12\x12\n" +
-	"\x04data\x18\x01 \x01(\fR\x04data\"P\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"d\n" +
 	"\x19StartBundleCaptureRequest\x123\n" +
-	"\atimeout\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\atimeout\"\x1c\n" +
-	"\x1aStartBundleCaptureResponse\"\x1a\n" +
+	"\atimeout\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x12\x12\n" +
+	"\x04peer\x18\x02 \x01(\tR\x04peer\"=\n" +
+	"\x1aStartBundleCaptureResponse\x12\x1f\n" +
+	"\vfilter_expr\x18\x01 \x01(\tR\n" +
+	"filterExpr\"\x1a\n" +
 	"\x18StopBundleCaptureRequest\"\x1b\n" +
 	"\x19StopBundleCaptureResponse*b\n" +
 	"\bLogLevel\x12\v\n" +
@@ -7583,7 +12268,7 @@ const file_daemon_proto_rawDesc = "" +
 	"\n" +
 	"EXPOSE_UDP\x10\x03\x12\x0e\n" +
 	"\n" +
-	"EXPOSE_TLS\x10\x042\xa3\x1c\n" +
+	"EXPOSE_TLS\x10\x042\x8c*\n" +
 	"\rDaemonService\x126\n" +
 	"\x05Login\x12\x14.daemon.LoginRequest\x1a\x15.daemon.LoginResponse\"\x00\x12K\n" +
 	"\fWaitSSOLogin\x12\x1b.daemon.WaitSSOLoginRequest\x1a\x1c.daemon.WaitSSOLoginResponse\"\x00\x12-\n" +
@@ -7591,21 +12276,40 @@ const file_daemon_proto_rawDesc = "" +
 	"\x06Status\x12\x15.daemon.StatusRequest\x1a\x16.daemon.StatusResponse\"\x00\x12D\n" +
 	"\x0fSubscribeStatus\x12\x15.daemon.StatusRequest\x1a\x16.daemon.StatusResponse\"\x000\x01\x123\n" +
 	"\x04Down\x12\x13.daemon.DownRequest\x1a\x14.daemon.DownResponse\"\x00\x12B\n" +
-	"\tGetConfig\x12\x18.daemon.GetConfigRequest\x1a\x19.daemon.GetConfigResponse\"\x00\x12K\n" +
+	"\tGetConfig\x12\x18.daemon.GetConfigRequest\x1a\x19.daemon.GetConfigResponse\"\x00\x12]\n" +
+	"\x12GetEffectiveConfig\x12!.daemon.GetEffectiveConfigRequest\x1a\".daemon.GetEffectiveConfigResponse\"\x00\x12K\n" +
 	"\fListNetworks\x12\x1b.daemon.ListNetworksRequest\x1a\x1c.daemon.ListNetworksResponse\"\x00\x12Q\n" +
 	"\x0eSelectNetworks\x12\x1d.daemon.SelectNetworksRequest\x1a\x1e.daemon.SelectNetworksResponse\"\x00\x12S\n" +
 	"\x10DeselectNetworks\x12\x1d.daemon.SelectNetworksRequest\x1a\x1e.daemon.SelectNetworksResponse\"\x00\x12J\n" +
 	"\x0fForwardingRules\x12\x14.daemon.EmptyRequest\x1a\x1f.daemon.ForwardingRulesResponse\"\x00\x12H\n" +
-	"\vDebugBundle\x12\x1a.daemon.DebugBundleRequest\x1a\x1b.daemon.DebugBundleResponse\"\x00\x12H\n" +
+	"\vDebugBundle\x12\x1a.daemon.DebugBundleRequest\x1a\x1b.daemon.DebugBundleResponse\"\x00\x12M\n" +
+	"\x11DebugBundleStream\x12\x1a.daemon.DebugBundleRequest\x1a\x18.daemon.DebugBundleChunk\"\x000\x01\x12[\n" +
+	"\x17DebugBundleWithProgress\x12\x1a.daemon.DebugBundleRequest\x1a .daemon.DebugBundleProgressEvent\"\x000\x01\x12H\n" +
 	"\vGetLogLevel\x12\x1a.daemon.GetLogLevelRequest\x1a\x1b.daemon.GetLogLevelResponse\"\x00\x12H\n" +
-	"\vSetLogLevel\x12\x1a.daemon.SetLogLevelRequest\x1a\x1b.daemon.SetLogLevelResponse\"\x00\x12E\n" +
+	"\vSetLogLevel\x12\x1a.daemon.SetLogLevelRequest\x1a\x1b.daemon.SetLogLevelResponse\"\x00\x12B\n" +
+	"\tRotateLog\x12\x18.daemon.RotateLogRequest\x1a\x19.daemon.RotateLogResponse\"\x00\x12K\n" +
+	"\fSetLogFormat\x12\x1b.daemon.SetLogFormatRequest\x1a\x1c.daemon.SetLogFormatResponse\"\x00\x128\n" +
+	"\bTailLogs\x12\x17.daemon.TailLogsRequest\x1a\x0f.daemon.LogLine\"\x000\x01\x12Q\n" +
+	"\x0eStageDebugLogs\x12\x1d.daemon.StageDebugLogsRequest\x1a\x1e.daemon.StageDebugLogsResponse\"\x00\x12Z\n" +
+	"\x11GetInterfaceStats\x12 .daemon.GetInterfaceStatsRequest\x1a!.daemon.GetInterfaceStatsResponse\"\x00\x12T\n" +
+	"\x0fGetRuntimeStats\x12\x1e.daemon.GetRuntimeStatsRequest\x1a\x1f.daemon.GetRuntimeStatsResponse\"\x00\x12E\n" +
 	"\n" +
 	"ListStates\x12\x19.daemon.ListStatesRequest\x1a\x1a.daemon.ListStatesResponse\"\x00\x12E\n" +
 	"\n" +
 	"CleanState\x12\x19.daemon.CleanStateRequest\x1a\x1a.daemon.CleanStateResponse\"\x00\x12H\n" +
 	"\vDeleteState\x12\x1a.daemon.DeleteStateRequest\x1a\x1b.daemon.DeleteStateResponse\"\x00\x12u\n" +
-	"\x1aSetSyncResponsePersistence\x12).daemon.SetSyncResponsePersistenceRequest\x1a*.daemon.SetSyncResponsePersistenceResponse\"\x00\x12H\n" +
-	"\vTracePacket\x12\x1a.daemon.TracePacketRequest\x1a\x1b.daemon.TracePacketResponse\"\x00\x12F\n" +
+	"\x1aSetSyncResponsePersistence\x12).daemon.SetSyncResponsePersistenceRequest\x1a*.daemon.SetSyncResponsePersistenceResponse\"\x00\x12u\n" +
+	"\x1aGetSyncResponsePersistence\x12).daemon.GetSyncResponsePersistenceRequest\x1a*.daemon.GetSyncResponsePersistenceResponse\"\x00\x12H\n" +
+	"\vTracePacket\x12\x1a.daemon.TracePacketRequest\x1a\x1b.daemon.TracePacketResponse\"\x00\x12H\n" +
+	"\vEvaluateACL\x12\x1a.daemon.EvaluateACLRequest\x1a\x1b.daemon.EvaluateACLResponse\"\x00\x12K\n" +
+	"\fGetDropStats\x12\x1b.daemon.GetDropStatsRequest\x1a\x1c.daemon.GetDropStatsResponse\"\x00\x12Z\n" +
+	"\x11RelayFailoverTest\x12 .daemon.RelayFailoverTestRequest\x1a!.daemon.RelayFailoverTestResponse\"\x00\x12W\n" +
+	"\x10GetStartupTiming\x12\x1f.daemon.GetStartupTimingRequest\x1a .daemon.GetStartupTimingResponse\"\x00\x12K\n" +
+	"\fProbePeerMTU\x12\x1b.daemon.ProbePeerMTURequest\x1a\x1c.daemon.ProbePeerMTUResponse\"\x00\x12B\n" +
+	"\tPeerProbe\x12\x18.daemon.PeerProbeRequest\x1a\x19.daemon.PeerProbeResponse\"\x00\x12N\n" +
+	"\rBenchmarkPeer\x12\x1c.daemon.BenchmarkPeerRequest\x1a\x1d.daemon.BenchmarkPeerResponse\"\x00\x12f\n" +
+	"\x15GetInterfaceConflicts\x12$.daemon.GetInterfaceConflictsRequest\x1a%.daemon.GetInterfaceConflictsResponse\"\x00\x12Z\n" +
+	"\x11GetRouteConflicts\x12 .daemon.GetRouteConflictsRequest\x1a!.daemon.GetRouteConflictsResponse\"\x00\x12F\n" +
 	"\fStartCapture\x12\x1b.daemon.StartCaptureRequest\x1a\x15.daemon.CapturePacket\"\x000\x01\x12]\n" +
 	"\x12StartBundleCapture\x12!.daemon.StartBundleCaptureRequest\x1a\".daemon.StartBundleCaptureResponse\"\x00\x12Z\n" +
 	"\x11StopBundleCapture\x12 .daemon.StopBundleCaptureRequest\x1a!.daemon.StopBundleCaptureResponse\"\x00\x12D\n" +
@@ -7621,7 +12325,9 @@ const file_daemon_proto_rawDesc = "" +
 	"\fListProfiles\x12\x1b.daemon.ListProfilesRequest\x1a\x1c.daemon.ListProfilesResponse\"\x00\x12W\n" +
 	"\x10GetActiveProfile\x12\x1f.daemon.GetActiveProfileRequest\x1a .daemon.GetActiveProfileResponse\"\x00\x129\n" +
 	"\x06Logout\x12\x15.daemon.LogoutRequest\x1a\x16.daemon.LogoutResponse\"\x00\x12H\n" +
-	"\vGetFeatures\x12\x1a.daemon.GetFeaturesRequest\x1a\x1b.daemon.GetFeaturesResponse\"\x00\x12N\n" +
+	"\vGetFeatures\x12\x1a.daemon.GetFeaturesRequest\x1a\x1b.daemon.GetFeaturesResponse\"\x00\x12W\n" +
+	"\x10ListFeatureFlags\x12\x1f.daemon.ListFeatureFlagsRequest\x1a .daemon.ListFeatureFlagsResponse\"\x00\x12K\n" +
+	"\fGetBuildInfo\x12\x1b.daemon.GetBuildInfoRequest\x1a\x1c.daemon.GetBuildInfoResponse\"\x00\x12N\n" +
 	"\rTriggerUpdate\x12\x1c.daemon.TriggerUpdateRequest\x1a\x1d.daemon.TriggerUpdateResponse\"\x00\x12Z\n" +
 	"\x11GetPeerSSHHostKey\x12 .daemon.GetPeerSSHHostKeyRequest\x1a!.daemon.GetPeerSSHHostKeyResponse\"\x00\x12Q\n" +
 	"\x0eRequestJWTAuth\x12\x1d.daemon.RequestJWTAuthRequest\x1a\x1e.daemon.RequestJWTAuthResponse\"\x00\x12K\n" +
@@ -7648,7 +12354,7 @@ func file_daemon_proto_rawDescGZIP() []byte {
 }
 
 var file_daemon_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_daemon_proto_msgTypes = make([]protoimpl.MessageInfo, 110)
+var file_daemon_proto_msgTypes = make([]protoimpl.MessageInfo, 173)
 var file_daemon_proto_goTypes = []any{
 	(LogLevel)(0),                              // 0: daemon.LogLevel
 	(ExposeProtocol)(0),                        // 1: daemon.ExposeProtocol
@@ -7667,243 +12373,412 @@ var file_daemon_proto_goTypes = []any{
 	(*DownResponse)(nil),                       // 14: daemon.DownResponse
 	(*GetConfigRequest)(nil),                   // 15: daemon.GetConfigRequest
 	(*GetConfigResponse)(nil),                  // 16: daemon.GetConfigResponse
-	(*PeerState)(nil),                          // 17: daemon.PeerState
-	(*LocalPeerState)(nil),                     // 18: daemon.LocalPeerState
-	(*SignalState)(nil),                        // 19: daemon.SignalState
-	(*ManagementState)(nil),                    // 20: daemon.ManagementState
-	(*RelayState)(nil),                         // 21: daemon.RelayState
-	(*NSGroupState)(nil),                       // 22: daemon.NSGroupState
-	(*SSHSessionInfo)(nil),                     // 23: daemon.SSHSessionInfo
-	(*SSHServerState)(nil),                     // 24: daemon.SSHServerState
-	(*FullStatus)(nil),                         // 25: daemon.FullStatus
-	(*ListNetworksRequest)(nil),                // 26: daemon.ListNetworksRequest
-	(*ListNetworksResponse)(nil),               // 27: daemon.ListNetworksResponse
-	(*SelectNetworksRequest)(nil),              // 28: daemon.SelectNetworksRequest
-	(*SelectNetworksResponse)(nil),             // 29: daemon.SelectNetworksResponse
-	(*IPList)(nil),                             // 30: daemon.IPList
-	(*Network)(nil),                            // 31: daemon.Network
-	(*PortInfo)(nil),                           // 32: daemon.PortInfo
-	(*ForwardingRule)(nil),                     // 33: daemon.ForwardingRule
-	(*ForwardingRulesResponse)(nil),            // 34: daemon.ForwardingRulesResponse
-	(*DebugBundleRequest)(nil),                 // 35: daemon.DebugBundleRequest
-	(*DebugBundleResponse)(nil),                // 36: daemon.DebugBundleResponse
-	(*GetLogLevelRequest)(nil),                 // 37: daemon.GetLogLevelRequest
-	(*GetLogLevelResponse)(nil),                // 38: daemon.GetLogLevelResponse
-	(*SetLogLevelRequest)(nil),                 // 39: daemon.SetLogLevelRequest
-	(*SetLogLevelResponse)(nil),                // 40: daemon.SetLogLevelResponse
-	(*RegisterUILogRequest)(nil),               // 41: daemon.RegisterUILogRequest
-	(*RegisterUILogResponse)(nil),              // 42: daemon.RegisterUILogResponse
-	(*State)(nil),                              // 43: daemon.State
-	(*ListStatesRequest)(nil),                  // 44: daemon.ListStatesRequest
-	(*ListStatesResponse)(nil),                 // 45: daemon.ListStatesResponse
-	(*CleanStateRequest)(nil),                  // 46: daemon.CleanStateRequest
-	(*CleanStateResponse)(nil),                 // 47: daemon.CleanStateResponse
-	(*DeleteStateRequest)(nil),                 // 48: daemon.DeleteStateRequest
-	(*DeleteStateResponse)(nil),                // 49: daemon.DeleteStateResponse
-	(*SetSyncResponsePersistenceRequest)(nil),  // 50: daemon.SetSyncResponsePersistenceRequest
-	(*SetSyncResponsePersistenceResponse)(nil), // 51: daemon.SetSyncResponsePersistenceResponse
-	(*TCPFlags)(nil),                           // 52: daemon.TCPFlags
-	(*TracePacketRequest)(nil),                 // 53: daemon.TracePacketRequest
-	(*TraceStage)(nil),                         // 54: daemon.TraceStage
-	(*TracePacketResponse)(nil),                // 55: daemon.TracePacketResponse
-	(*SubscribeRequest)(nil),                   // 56: daemon.SubscribeRequest
-	(*SystemEvent)(nil),                        // 57: daemon.SystemEvent
-	(*GetEventsRequest)(nil),                   // 58: daemon.GetEventsRequest
-	(*GetEventsResponse)(nil),                  // 59: daemon.GetEventsResponse
-	(*SwitchProfileRequest)(nil),               // 60: daemon.SwitchProfileRequest
-	(*SwitchProfileResponse)(nil),              // 61: daemon.SwitchProfileResponse
-	(*SetConfigRequest)(nil),                   // 62: daemon.SetConfigRequest
-	(*SetConfigResponse)(nil),                  // 63: daemon.SetConfigResponse
-	(*AddProfileRequest)(nil),                  // 64: daemon.AddProfileRequest
-	(*AddProfileResponse)(nil),                 // 65: daemon.AddProfileResponse
-	(*RenameProfileRequest)(nil),               // 66: daemon.RenameProfileRequest
-	(*RenameProfileResponse)(nil),              // 67: daemon.RenameProfileResponse
-	(*RemoveProfileRequest)(nil),               // 68: daemon.RemoveProfileRequest
-	(*RemoveProfileResponse)(nil),              // 69: daemon.RemoveProfileResponse
-	(*ListProfilesRequest)(nil),                // 70: daemon.ListProfilesRequest
-	(*ListProfilesResponse)(nil),               // 71: daemon.ListProfilesResponse
-	(*Profile)(nil),                            // 72: daemon.Profile
-	(*GetActiveProfileRequest)(nil),            // 73: daemon.GetActiveProfileRequest
-	(*GetActiveProfileResponse)(nil),           // 74: daemon.GetActiveProfileResponse
-	(*LogoutRequest)(nil),                      // 75: daemon.LogoutRequest
-	(*LogoutResponse)(nil),                     // 76: daemon.LogoutResponse
-	(*WailsUIReadyRequest)(nil),                // 77: daemon.WailsUIReadyRequest
-	(*WailsUIReadyResponse)(nil),               // 78: daemon.WailsUIReadyResponse
-	(*GetFeaturesRequest)(nil),                 // 79: daemon.GetFeaturesRequest
-	(*GetFeaturesResponse)(nil),                // 80: daemon.GetFeaturesResponse
-	(*MDMManagedFieldsViolation)(nil),          // 81: daemon.MDMManagedFieldsViolation
-	(*TriggerUpdateRequest)(nil),               // 82: daemon.TriggerUpdateRequest
-	(*TriggerUpdateResponse)(nil),              // 83: daemon.TriggerUpdateResponse
-	(*GetPeerSSHHostKeyRequest)(nil),           // 84: daemon.GetPeerSSHHostKeyRequest
-	(*GetPeerSSHHostKeyResponse)(nil),          // 85: daemon.GetPeerSSHHostKeyResponse
-	(*RequestJWTAuthRequest)(nil),              // 86: daemon.RequestJWTAuthRequest
-	(*RequestJWTAuthResponse)(nil),             // 87: daemon.RequestJWTAuthResponse
-	(*WaitJWTTokenRequest)(nil),                // 88: daemon.WaitJWTTokenRequest
-	(*WaitJWTTokenResponse)(nil),               // 89: daemon.WaitJWTTokenResponse
-	(*RequestExtendAuthSessionRequest)(nil),    // 90: daemon.RequestExtendAuthSessionRequest
-	(*RequestExtendAuthSessionResponse)(nil),   // 91: daemon.RequestExtendAuthSessionResponse
-	(*WaitExtendAuthSessionRequest)(nil),       // 92: daemon.WaitExtendAuthSessionRequest
-	(*WaitExtendAuthSessionResponse)(nil),      // 93: daemon.WaitExtendAuthSessionResponse
-	(*DismissSessionWarningRequest)(nil),       // 94: daemon.DismissSessionWarningRequest
-	(*DismissSessionWarningResponse)(nil),      // 95: daemon.DismissSessionWarningResponse
-	(*StartCPUProfileRequest)(nil),             // 96: daemon.StartCPUProfileRequest
-	(*StartCPUProfileResponse)(nil),            // 97: daemon.StartCPUProfileResponse
-	(*StopCPUProfileRequest)(nil),              // 98: daemon.StopCPUProfileRequest
-	(*StopCPUProfileResponse)(nil),             // 99: daemon.StopCPUProfileResponse
-	(*InstallerResultRequest)(nil),             // 100: daemon.InstallerResultRequest
-	(*InstallerResultResponse)(nil),            // 101: daemon.InstallerResultResponse
-	(*ExposeServiceRequest)(nil),               // 102: daemon.ExposeServiceRequest
-	(*ExposeServiceEvent)(nil),                 // 103: daemon.ExposeServiceEvent
-	(*ExposeServiceReady)(nil),                 // 104: daemon.ExposeServiceReady
-	(*StartCaptureRequest)(nil),                // 105: daemon.StartCaptureRequest
-	(*CapturePacket)(nil),                      // 106: daemon.CapturePacket
-	(*StartBundleCaptureRequest)(nil),          // 107: daemon.StartBundleCaptureRequest
-	(*StartBundleCaptureResponse)(nil),         // 108: daemon.StartBundleCaptureResponse
-	(*StopBundleCaptureRequest)(nil),           // 109: daemon.StopBundleCaptureRequest
-	(*StopBundleCaptureResponse)(nil),          // 110: daemon.StopBundleCaptureResponse
-	nil,                                        // 111: daemon.Network.ResolvedIPsEntry
-	(*PortInfo_Range)(nil),                     // 112: daemon.PortInfo.Range
-	nil,                                        // 113: daemon.SystemEvent.MetadataEntry
-	(*durationpb.Duration)(nil),                // 114: google.protobuf.Duration
-	(*timestamppb.Timestamp)(nil),              // 115: google.protobuf.Timestamp
+	(*GetEffectiveConfigRequest)(nil),          // 17: daemon.GetEffectiveConfigRequest
+	(*GetEffectiveConfigResponse)(nil),         // 18: daemon.GetEffectiveConfigResponse
+	(*PeerState)(nil),                          // 19: daemon.PeerState
+	(*PeerConnEvent)(nil),                      // 20: daemon.PeerConnEvent
+	(*LocalPeerState)(nil),                     // 21: daemon.LocalPeerState
+	(*SignalState)(nil),                        // 22: daemon.SignalState
+	(*ManagementState)(nil),                    // 23: daemon.ManagementState
+	(*RelayState)(nil),                         // 24: daemon.RelayState
+	(*NSGroupState)(nil),                       // 25: daemon.NSGroupState
+	(*SSHSessionInfo)(nil),                     // 26: daemon.SSHSessionInfo
+	(*SSHServerState)(nil),                     // 27: daemon.SSHServerState
+	(*FullStatus)(nil),                         // 28: daemon.FullStatus
+	(*PostureState)(nil),                       // 29: daemon.PostureState
+	(*PostureCheckState)(nil),                  // 30: daemon.PostureCheckState
+	(*PostureProcessState)(nil),                // 31: daemon.PostureProcessState
+	(*ExitNodeState)(nil),                      // 32: daemon.ExitNodeState
+	(*DaemonState)(nil),                        // 33: daemon.DaemonState
+	(*ListNetworksRequest)(nil),                // 34: daemon.ListNetworksRequest
+	(*ListNetworksResponse)(nil),               // 35: daemon.ListNetworksResponse
+	(*SelectNetworksRequest)(nil),              // 36: daemon.SelectNetworksRequest
+	(*SelectNetworksResponse)(nil),             // 37: daemon.SelectNetworksResponse
+	(*IPList)(nil),                             // 38: daemon.IPList
+	(*Network)(nil),                            // 39: daemon.Network
+	(*PortInfo)(nil),                           // 40: daemon.PortInfo
+	(*ForwardingRule)(nil),                     // 41: daemon.ForwardingRule
+	(*ForwardingRulesResponse)(nil),            // 42: daemon.ForwardingRulesResponse
+	(*DebugBundleRequest)(nil),                 // 43: daemon.DebugBundleRequest
+	(*StageDebugLogsRequest)(nil),              // 44: daemon.StageDebugLogsRequest
+	(*StageDebugLogsResponse)(nil),             // 45: daemon.StageDebugLogsResponse
+	(*GetInterfaceStatsRequest)(nil),           // 46: daemon.GetInterfaceStatsRequest
+	(*GetInterfaceStatsResponse)(nil),          // 47: daemon.GetInterfaceStatsResponse
+	(*InterfaceStatsSnapshot)(nil),             // 48: daemon.InterfaceStatsSnapshot
+	(*GetRuntimeStatsRequest)(nil),             // 49: daemon.GetRuntimeStatsRequest
+	(*GetRuntimeStatsResponse)(nil),            // 50: daemon.GetRuntimeStatsResponse
+	(*RuntimeStatsSample)(nil),                 // 51: daemon.RuntimeStatsSample
+	(*InterfaceCounters)(nil),                  // 52: daemon.InterfaceCounters
+	(*DebugBundleResponse)(nil),                // 53: daemon.DebugBundleResponse
+	(*DebugBundleChunk)(nil),                   // 54: daemon.DebugBundleChunk
+	(*DebugBundleProgressEvent)(nil),           // 55: daemon.DebugBundleProgressEvent
+	(*AnonymizationSummary)(nil),               // 56: daemon.AnonymizationSummary
+	(*AnonymizationSample)(nil),                // 57: daemon.AnonymizationSample
+	(*AnonymizationMapping)(nil),               // 58: daemon.AnonymizationMapping
+	(*GetLogLevelRequest)(nil),                 // 59: daemon.GetLogLevelRequest
+	(*GetLogLevelResponse)(nil),                // 60: daemon.GetLogLevelResponse
+	(*SetLogLevelRequest)(nil),                 // 61: daemon.SetLogLevelRequest
+	(*SetLogLevelResponse)(nil),                // 62: daemon.SetLogLevelResponse
+	(*RotateLogRequest)(nil),                   // 63: daemon.RotateLogRequest
+	(*RotateLogResponse)(nil),                  // 64: daemon.RotateLogResponse
+	(*SetLogFormatRequest)(nil),                // 65: daemon.SetLogFormatRequest
+	(*SetLogFormatResponse)(nil),               // 66: daemon.SetLogFormatResponse
+	(*TailLogsRequest)(nil),                    // 67: daemon.TailLogsRequest
+	(*LogLine)(nil),                            // 68: daemon.LogLine
+	(*RegisterUILogRequest)(nil),               // 69: daemon.RegisterUILogRequest
+	(*RegisterUILogResponse)(nil),              // 70: daemon.RegisterUILogResponse
+	(*State)(nil),                              // 71: daemon.State
+	(*ListStatesRequest)(nil),                  // 72: daemon.ListStatesRequest
+	(*ListStatesResponse)(nil),                 // 73: daemon.ListStatesResponse
+	(*CleanStateRequest)(nil),                  // 74: daemon.CleanStateRequest
+	(*CleanStateResponse)(nil),                 // 75: daemon.CleanStateResponse
+	(*DeleteStateRequest)(nil),                 // 76: daemon.DeleteStateRequest
+	(*DeleteStateResponse)(nil),                // 77: daemon.DeleteStateResponse
+	(*SetSyncResponsePersistenceRequest)(nil),  // 78: daemon.SetSyncResponsePersistenceRequest
+	(*SetSyncResponsePersistenceResponse)(nil), // 79: daemon.SetSyncResponsePersistenceResponse
+	(*GetSyncResponsePersistenceRequest)(nil),  // 80: daemon.GetSyncResponsePersistenceRequest
+	(*GetSyncResponsePersistenceResponse)(nil), // 81: daemon.GetSyncResponsePersistenceResponse
+	(*TCPFlags)(nil),                           // 82: daemon.TCPFlags
+	(*TracePacketRequest)(nil),                 // 83: daemon.TracePacketRequest
+	(*TraceStage)(nil),                         // 84: daemon.TraceStage
+	(*TracePacketResponse)(nil),                // 85: daemon.TracePacketResponse
+	(*ACLQuery)(nil),                           // 86: daemon.ACLQuery
+	(*EvaluateACLRequest)(nil),                 // 87: daemon.EvaluateACLRequest
+	(*EvaluateACLResponse)(nil),                // 88: daemon.EvaluateACLResponse
+	(*GetDropStatsRequest)(nil),                // 89: daemon.GetDropStatsRequest
+	(*DropCounter)(nil),                        // 90: daemon.DropCounter
+	(*GetDropStatsResponse)(nil),               // 91: daemon.GetDropStatsResponse
+	(*RelayFailoverTestRequest)(nil),           // 92: daemon.RelayFailoverTestRequest
+	(*RelayFailoverEvent)(nil),                 // 93: daemon.RelayFailoverEvent
+	(*RelayFailoverTestResponse)(nil),          // 94: daemon.RelayFailoverTestResponse
+	(*GetStartupTimingRequest)(nil),            // 95: daemon.GetStartupTimingRequest
+	(*StartupPhase)(nil),                       // 96: daemon.StartupPhase
+	(*GetStartupTimingResponse)(nil),           // 97: daemon.GetStartupTimingResponse
+	(*ProbePeerMTURequest)(nil),                // 98: daemon.ProbePeerMTURequest
+	(*PeerMTUResult)(nil),                      // 99: daemon.PeerMTUResult
+	(*ProbePeerMTUResponse)(nil),               // 100: daemon.ProbePeerMTUResponse
+	(*PeerProbeRequest)(nil),                   // 101: daemon.PeerProbeRequest
+	(*PeerProbeResponse)(nil),                  // 102: daemon.PeerProbeResponse
+	(*BenchmarkPeerRequest)(nil),               // 103: daemon.BenchmarkPeerRequest
+	(*BenchmarkPeerResponse)(nil),              // 104: daemon.BenchmarkPeerResponse
+	(*GetInterfaceConflictsRequest)(nil),       // 105: daemon.GetInterfaceConflictsRequest
+	(*InterfaceConflict)(nil),                  // 106: daemon.InterfaceConflict
+	(*GetInterfaceConflictsResponse)(nil),      // 107: daemon.GetInterfaceConflictsResponse
+	(*GetRouteConflictsRequest)(nil),           // 108: daemon.GetRouteConflictsRequest
+	(*ConflictingRoute)(nil),                   // 109: daemon.ConflictingRoute
+	(*RouteConflict)(nil),                      // 110: daemon.RouteConflict
+	(*GetRouteConflictsResponse)(nil),          // 111: daemon.GetRouteConflictsResponse
+	(*SubscribeRequest)(nil),                   // 112: daemon.SubscribeRequest
+	(*SystemEvent)(nil),                        // 113: daemon.SystemEvent
+	(*GetEventsRequest)(nil),                   // 114: daemon.GetEventsRequest
+	(*GetEventsResponse)(nil),                  // 115: daemon.GetEventsResponse
+	(*SwitchProfileRequest)(nil),               // 116: daemon.SwitchProfileRequest
+	(*SwitchProfileResponse)(nil),              // 117: daemon.SwitchProfileResponse
+	(*SetConfigRequest)(nil),                   // 118: daemon.SetConfigRequest
+	(*SetConfigResponse)(nil),                  // 119: daemon.SetConfigResponse
+	(*AddProfileRequest)(nil),                  // 120: daemon.AddProfileRequest
+	(*AddProfileResponse)(nil),                 // 121: daemon.AddProfileResponse
+	(*RenameProfileRequest)(nil),               // 122: daemon.RenameProfileRequest
+	(*RenameProfileResponse)(nil),              // 123: daemon.RenameProfileResponse
+	(*RemoveProfileRequest)(nil),               // 124: daemon.RemoveProfileRequest
+	(*RemoveProfileResponse)(nil),              // 125: daemon.RemoveProfileResponse
+	(*ListProfilesRequest)(nil),                // 126: daemon.ListProfilesRequest
+	(*ListProfilesResponse)(nil),               // 127: daemon.ListProfilesResponse
+	(*Profile)(nil),                            // 128: daemon.Profile
+	(*GetActiveProfileRequest)(nil),            // 129: daemon.GetActiveProfileRequest
+	(*GetActiveProfileResponse)(nil),           // 130: daemon.GetActiveProfileResponse
+	(*LogoutRequest)(nil),                      // 131: daemon.LogoutRequest
+	(*LogoutResponse)(nil),                     // 132: daemon.LogoutResponse
+	(*WailsUIReadyRequest)(nil),                // 133: daemon.WailsUIReadyRequest
+	(*WailsUIReadyResponse)(nil),               // 134: daemon.WailsUIReadyResponse
+	(*GetFeaturesRequest)(nil),                 // 135: daemon.GetFeaturesRequest
+	(*GetFeaturesResponse)(nil),                // 136: daemon.GetFeaturesResponse
+	(*ListFeatureFlagsRequest)(nil),            // 137: daemon.ListFeatureFlagsRequest
+	(*FeatureFlag)(nil),                        // 138: daemon.FeatureFlag
+	(*ListFeatureFlagsResponse)(nil),           // 139: daemon.ListFeatureFlagsResponse
+	(*GetBuildInfoRequest)(nil),                // 140: daemon.GetBuildInfoRequest
+	(*GetBuildInfoResponse)(nil),               // 141: daemon.GetBuildInfoResponse
+	(*MDMManagedFieldsViolation)(nil),          // 142: daemon.MDMManagedFieldsViolation
+	(*TriggerUpdateRequest)(nil),               // 143: daemon.TriggerUpdateRequest
+	(*TriggerUpdateResponse)(nil),              // 144: daemon.TriggerUpdateResponse
+	(*GetPeerSSHHostKeyRequest)(nil),           // 145: daemon.GetPeerSSHHostKeyRequest
+	(*GetPeerSSHHostKeyResponse)(nil),          // 146: daemon.GetPeerSSHHostKeyResponse
+	(*RequestJWTAuthRequest)(nil),              // 147: daemon.RequestJWTAuthRequest
+	(*RequestJWTAuthResponse)(nil),             // 148: daemon.RequestJWTAuthResponse
+	(*WaitJWTTokenRequest)(nil),                // 149: daemon.WaitJWTTokenRequest
+	(*WaitJWTTokenResponse)(nil),               // 150: daemon.WaitJWTTokenResponse
+	(*RequestExtendAuthSessionRequest)(nil),    // 151: daemon.RequestExtendAuthSessionRequest
+	(*RequestExtendAuthSessionResponse)(nil),   // 152: daemon.RequestExtendAuthSessionResponse
+	(*WaitExtendAuthSessionRequest)(nil),       // 153: daemon.WaitExtendAuthSessionRequest
+	(*WaitExtendAuthSessionResponse)(nil),      // 154: daemon.WaitExtendAuthSessionResponse
+	(*DismissSessionWarningRequest)(nil),       // 155: daemon.DismissSessionWarningRequest
+	(*DismissSessionWarningResponse)(nil),      // 156: daemon.DismissSessionWarningResponse
+	(*StartCPUProfileRequest)(nil),             // 157: daemon.StartCPUProfileRequest
+	(*StartCPUProfileResponse)(nil),            // 158: daemon.StartCPUProfileResponse
+	(*StopCPUProfileRequest)(nil),              // 159: daemon.StopCPUProfileRequest
+	(*StopCPUProfileResponse)(nil),             // 160: daemon.StopCPUProfileResponse
+	(*InstallerResultRequest)(nil),             // 161: daemon.InstallerResultRequest
+	(*InstallerResultResponse)(nil),            // 162: daemon.InstallerResultResponse
+	(*ExposeServiceRequest)(nil),               // 163: daemon.ExposeServiceRequest
+	(*ExposeServiceEvent)(nil),                 // 164: daemon.ExposeServiceEvent
+	(*ExposeServiceReady)(nil),                 // 165: daemon.ExposeServiceReady
+	(*StartCaptureRequest)(nil),                // 166: daemon.StartCaptureRequest
+	(*CapturePacket)(nil),                      // 167: daemon.CapturePacket
+	(*StartBundleCaptureRequest)(nil),          // 168: daemon.StartBundleCaptureRequest
+	(*StartBundleCaptureResponse)(nil),         // 169: daemon.StartBundleCaptureResponse
+	(*StopBundleCaptureRequest)(nil),           // 170: daemon.StopBundleCaptureRequest
+	(*StopBundleCaptureResponse)(nil),          // 171: daemon.StopBundleCaptureResponse
+	nil,                                        // 172: daemon.Network.ResolvedIPsEntry
+	(*PortInfo_Range)(nil),                     // 173: daemon.PortInfo.Range
+	nil,                                        // 174: daemon.GetLogLevelResponse.ComponentsEntry
+	nil,                                        // 175: daemon.SetLogLevelRequest.ComponentsEntry
+	nil,                                        // 176: daemon.SystemEvent.MetadataEntry
+	(*durationpb.Duration)(nil),                // 177: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),              // 178: google.protobuf.Timestamp
 }
 var file_daemon_proto_depIdxs = []int32{
-	114, // 0: daemon.LoginRequest.dnsRouteInterval:type_name -> google.protobuf.Duration
-	25,  // 1: daemon.StatusResponse.fullStatus:type_name -> daemon.FullStatus
-	115, // 2: daemon.StatusResponse.sessionExpiresAt:type_name -> google.protobuf.Timestamp
-	115, // 3: daemon.PeerState.connStatusUpdate:type_name -> google.protobuf.Timestamp
-	115, // 4: daemon.PeerState.lastWireguardHandshake:type_name -> google.protobuf.Timestamp
-	114, // 5: daemon.PeerState.latency:type_name -> google.protobuf.Duration
-	23,  // 6: daemon.SSHServerState.sessions:type_name -> daemon.SSHSessionInfo
-	20,  // 7: daemon.FullStatus.managementState:type_name -> daemon.ManagementState
-	19,  // 8: daemon.FullStatus.signalState:type_name -> daemon.SignalState
-	18,  // 9: daemon.FullStatus.localPeerState:type_name -> daemon.LocalPeerState
-	17,  // 10: daemon.FullStatus.peers:type_name -> daemon.PeerState
-	21,  // 11: daemon.FullStatus.relays:type_name -> daemon.RelayState
-	22,  // 12: daemon.FullStatus.dns_servers:type_name -> daemon.NSGroupState
-	57,  // 13: daemon.FullStatus.events:type_name -> daemon.SystemEvent
-	24,  // 14: daemon.FullStatus.sshServerState:type_name -> daemon.SSHServerState
-	31,  // 15: daemon.ListNetworksResponse.routes:type_name -> daemon.Network
-	111, // 16: daemon.Network.resolvedIPs:type_name -> daemon.Network.ResolvedIPsEntry
-	112, // 17: daemon.PortInfo.range:type_name -> daemon.PortInfo.Range
-	32,  // 18: daemon.ForwardingRule.destinationPort:type_name -> daemon.PortInfo
-	32,  // 19: daemon.ForwardingRule.translatedPort:type_name -> daemon.PortInfo
-	33,  // 20: daemon.ForwardingRulesResponse.rules:type_name -> daemon.ForwardingRule
-	0,   // 21: daemon.GetLogLevelResponse.level:type_name -> daemon.LogLevel
-	0,   // 22: daemon.SetLogLevelRequest.level:type_name -> daemon.LogLevel
-	43,  // 23: daemon.ListStatesResponse.states:type_name -> daemon.State
-	52,  // 24: daemon.TracePacketRequest.tcp_flags:type_name -> daemon.TCPFlags
-	54,  // 25: daemon.TracePacketResponse.stages:type_name -> daemon.TraceStage
-	2,   // 26: daemon.SystemEvent.severity:type_name -> daemon.SystemEvent.Severity
-	3,   // 27: daemon.SystemEvent.category:type_name -> daemon.SystemEvent.Category
-	115, // 28: daemon.SystemEvent.timestamp:type_name -> google.protobuf.Timestamp
-	113, // 29: daemon.SystemEvent.metadata:type_name -> daemon.SystemEvent.MetadataEntry
-	57,  // 30: daemon.GetEventsResponse.events:type_name -> daemon.SystemEvent
-	114, // 31: daemon.SetConfigRequest.dnsRouteInterval:type_name -> google.protobuf.Duration
-	72,  // 32: daemon.ListProfilesResponse.profiles:type_name -> daemon.Profile
-	115, // 33: daemon.WaitExtendAuthSessionResponse.sessionExpiresAt:type_name -> google.protobuf.Timestamp
-	1,   // 34: daemon.ExposeServiceRequest.protocol:type_name -> daemon.ExposeProtocol
-	104, // 35: daemon.ExposeServiceEvent.ready:type_name -> daemon.ExposeServiceReady
-	114, // 36: daemon.StartCaptureRequest.duration:type_name -> google.protobuf.Duration
-	114, // 37: daemon.StartBundleCaptureRequest.timeout:type_name -> google.protobuf.Duration
-	30,  // 38: daemon.Network.ResolvedIPsEntry.value:type_name -> daemon.IPList
-	5,   // 39: daemon.DaemonService.Login:input_type -> daemon.LoginRequest
-	7,   // 40: daemon.DaemonService.WaitSSOLogin:input_type -> daemon.WaitSSOLoginRequest
-	9,   // 41: daemon.DaemonService.Up:input_type -> daemon.UpRequest
-	11,  // 42: daemon.DaemonService.Status:input_type -> daemon.StatusRequest
-	11,  // 43: daemon.DaemonService.SubscribeStatus:input_type -> daemon.StatusRequest
-	13,  // 44: daemon.DaemonService.Down:input_type -> daemon.DownRequest
-	15,  // 45: daemon.DaemonService.GetConfig:input_type -> daemon.GetConfigRequest
-	26,  // 46: daemon.DaemonService.ListNetworks:input_type -> daemon.ListNetworksRequest
-	28,  // 47: daemon.DaemonService.SelectNetworks:input_type -> daemon.SelectNetworksRequest
-	28,  // 48: daemon.DaemonService.DeselectNetworks:input_type -> daemon.SelectNetworksRequest
-	4,   // 49: daemon.DaemonService.ForwardingRules:input_type -> daemon.EmptyRequest
-	35,  // 50: daemon.DaemonService.DebugBundle:input_type -> daemon.DebugBundleRequest
-	37,  // 51: daemon.DaemonService.GetLogLevel:input_type -> daemon.GetLogLevelRequest
-	39,  // 52: daemon.DaemonService.SetLogLevel:input_type -> daemon.SetLogLevelRequest
-	44,  // 53: daemon.DaemonService.ListStates:input_type -> daemon.ListStatesRequest
-	46,  // 54: daemon.DaemonService.CleanState:input_type -> daemon.CleanStateRequest
-	48,  // 55: daemon.DaemonService.DeleteState:input_type -> daemon.DeleteStateRequest
-	50,  // 56: daemon.DaemonService.SetSyncResponsePersistence:input_type -> daemon.SetSyncResponsePersistenceRequest
-	53,  // 57: daemon.DaemonService.TracePacket:input_type -> daemon.TracePacketRequest
-	105, // 58: daemon.DaemonService.StartCapture:input_type -> daemon.StartCaptureRequest
-	107, // 59: daemon.DaemonService.StartBundleCapture:input_type -> daemon.StartBundleCaptureRequest
-	109, // 60: daemon.DaemonService.StopBundleCapture:input_type -> daemon.StopBundleCaptureRequest
-	56,  // 61: daemon.DaemonService.SubscribeEvents:input_type -> daemon.SubscribeRequest
-	58,  // 62: daemon.DaemonService.GetEvents:input_type -> daemon.GetEventsRequest
-	41,  // 63: daemon.DaemonService.RegisterUILog:input_type -> daemon.RegisterUILogRequest
-	60,  // 64: daemon.DaemonService.SwitchProfile:input_type -> daemon.SwitchProfileRequest
-	62,  // 65: daemon.DaemonService.SetConfig:input_type -> daemon.SetConfigRequest
-	64,  // 66: daemon.DaemonService.AddProfile:input_type -> daemon.AddProfileRequest
-	66,  // 67: daemon.DaemonService.RenameProfile:input_type -> daemon.RenameProfileRequest
-	68,  // 68: daemon.DaemonService.RemoveProfile:input_type -> daemon.RemoveProfileRequest
-	70,  // 69: daemon.DaemonService.ListProfiles:input_type -> daemon.ListProfilesRequest
-	73,  // 70: daemon.DaemonService.GetActiveProfile:input_type -> daemon.GetActiveProfileRequest
-	75,  // 71: daemon.DaemonService.Logout:input_type -> daemon.LogoutRequest
-	79,  // 72: daemon.DaemonService.GetFeatures:input_type -> daemon.GetFeaturesRequest
-	82,  // 73: daemon.DaemonService.TriggerUpdate:input_type -> daemon.TriggerUpdateRequest
-	84,  // 74: daemon.DaemonService.GetPeerSSHHostKey:input_type -> daemon.GetPeerSSHHostKeyRequest
-	86,  // 75: daemon.DaemonService.RequestJWTAuth:input_type -> daemon.RequestJWTAuthRequest
-	88,  // 76: daemon.DaemonService.WaitJWTToken:input_type -> daemon.WaitJWTTokenRequest
-	90,  // 77: daemon.DaemonService.RequestExtendAuthSession:input_type -> daemon.RequestExtendAuthSessionRequest
-	92,  // 78: daemon.DaemonService.WaitExtendAuthSession:input_type -> daemon.WaitExtendAuthSessionRequest
-	94,  // 79: daemon.DaemonService.DismissSessionWarning:input_type -> daemon.DismissSessionWarningRequest
-	96,  // 80: daemon.DaemonService.StartCPUProfile:input_type -> daemon.StartCPUProfileRequest
-	98,  // 81: daemon.DaemonService.StopCPUProfile:input_type -> daemon.StopCPUProfileRequest
-	100, // 82: daemon.DaemonService.GetInstallerResult:input_type -> daemon.InstallerResultRequest
-	102, // 83: daemon.DaemonService.ExposeService:input_type -> daemon.ExposeServiceRequest
-	77,  // 84: daemon.DaemonService.WailsUIReady:input_type -> daemon.WailsUIReadyRequest
-	6,   // 85: daemon.DaemonService.Login:output_type -> daemon.LoginResponse
-	8,   // 86: daemon.DaemonService.WaitSSOLogin:output_type -> daemon.WaitSSOLoginResponse
-	10,  // 87: daemon.DaemonService.Up:output_type -> daemon.UpResponse
-	12,  // 88: daemon.DaemonService.Status:output_type -> daemon.StatusResponse
-	12,  // 89: daemon.DaemonService.SubscribeStatus:output_type -> daemon.StatusResponse
-	14,  // 90: daemon.DaemonService.Down:output_type -> daemon.DownResponse
-	16,  // 91: daemon.DaemonService.GetConfig:output_type -> daemon.GetConfigResponse
-	27,  // 92: daemon.DaemonService.ListNetworks:output_type -> daemon.ListNetworksResponse
-	29,  // 93: daemon.DaemonService.SelectNetworks:output_type -> daemon.SelectNetworksResponse
-	29,  // 94: daemon.DaemonService.DeselectNetworks:output_type -> daemon.SelectNetworksResponse
-	34,  // 95: daemon.DaemonService.ForwardingRules:output_type -> daemon.ForwardingRulesResponse
-	36,  // 96: daemon.DaemonService.DebugBundle:output_type -> daemon.DebugBundleResponse
-	38,  // 97: daemon.DaemonService.GetLogLevel:output_type -> daemon.GetLogLevelResponse
-	40,  // 98: daemon.DaemonService.SetLogLevel:output_type -> daemon.SetLogLevelResponse
-	45,  // 99: daemon.DaemonService.ListStates:output_type -> daemon.ListStatesResponse
-	47,  // 100: daemon.DaemonService.CleanState:output_type -> daemon.CleanStateResponse
-	49,  // 101: daemon.DaemonService.DeleteState:output_type -> daemon.DeleteStateResponse
-	51,  // 102: daemon.DaemonService.SetSyncResponsePersistence:output_type -> daemon.SetSyncResponsePersistenceResponse
-	55,  // 103: daemon.DaemonService.TracePacket:output_type -> daemon.TracePacketResponse
-	106, // 104: daemon.DaemonService.StartCapture:output_type -> daemon.CapturePacket
-	108, // 105: daemon.DaemonService.StartBundleCapture:output_type -> daemon.StartBundleCaptureResponse
-	110, // 106: daemon.DaemonService.StopBundleCapture:output_type -> daemon.StopBundleCaptureResponse
-	57,  // 107: daemon.DaemonService.SubscribeEvents:output_type -> daemon.SystemEvent
-	59,  // 108: daemon.DaemonService.GetEvents:output_type -> daemon.GetEventsResponse
-	42,  // 109: daemon.DaemonService.RegisterUILog:output_type -> daemon.RegisterUILogResponse
-	61,  // 110: daemon.DaemonService.SwitchProfile:output_type -> daemon.SwitchProfileResponse
-	63,  // 111: daemon.DaemonService.SetConfig:output_type -> daemon.SetConfigResponse
-	65,  // 112: daemon.DaemonService.AddProfile:output_type -> daemon.AddProfileResponse
-	67,  // 113: daemon.DaemonService.RenameProfile:output_type -> daemon.RenameProfileResponse
-	69,  // 114: daemon.DaemonService.RemoveProfile:output_type -> daemon.RemoveProfileResponse
-	71,  // 115: daemon.DaemonService.ListProfiles:output_type -> daemon.ListProfilesResponse
-	74,  // 116: daemon.DaemonService.GetActiveProfile:output_type -> daemon.GetActiveProfileResponse
-	76,  // 117: daemon.DaemonService.Logout:output_type -> daemon.LogoutResponse
-	80,  // 118: daemon.DaemonService.GetFeatures:output_type -> daemon.GetFeaturesResponse
-	83,  // 119: daemon.DaemonService.TriggerUpdate:output_type -> daemon.TriggerUpdateResponse
-	85,  // 120: daemon.DaemonService.GetPeerSSHHostKey:output_type -> daemon.GetPeerSSHHostKeyResponse
-	87,  // 121: daemon.DaemonService.RequestJWTAuth:output_type -> daemon.RequestJWTAuthResponse
-	89,  // 122: daemon.DaemonService.WaitJWTToken:output_type -> daemon.WaitJWTTokenResponse
-	91,  // 123: daemon.DaemonService.RequestExtendAuthSession:output_type -> daemon.RequestExtendAuthSessionResponse
-	93,  // 124: daemon.DaemonService.WaitExtendAuthSession:output_type -> daemon.WaitExtendAuthSessionResponse
-	95,  // 125: daemon.DaemonService.DismissSessionWarning:output_type -> daemon.DismissSessionWarningResponse
-	97,  // 126: daemon.DaemonService.StartCPUProfile:output_type -> daemon.StartCPUProfileResponse
-	99,  // 127: daemon.DaemonService.StopCPUProfile:output_type -> daemon.StopCPUProfileResponse
-	101, // 128: daemon.DaemonService.GetInstallerResult:output_type -> daemon.InstallerResultResponse
-	103, // 129: daemon.DaemonService.ExposeService:output_type -> daemon.ExposeServiceEvent
-	78,  // 130: daemon.DaemonService.WailsUIReady:output_type -> daemon.WailsUIReadyResponse
-	85,  // [85:131] is the sub-list for method output_type
-	39,  // [39:85] is the sub-list for method input_type
-	39,  // [39:39] is the sub-list for extension type_name
-	39,  // [39:39] is the sub-list for extension extendee
-	0,   // [0:39] is the sub-list for field type_name
+	177, // 0: daemon.LoginRequest.dnsRouteInterval:type_name -> google.protobuf.Duration
+	28,  // 1: daemon.StatusResponse.fullStatus:type_name -> daemon.FullStatus
+	178, // 2: daemon.StatusResponse.sessionExpiresAt:type_name -> google.protobuf.Timestamp
+	178, // 3: daemon.PeerState.connStatusUpdate:type_name -> google.protobuf.Timestamp
+	178, // 4: daemon.PeerState.lastWireguardHandshake:type_name -> google.protobuf.Timestamp
+	177, // 5: daemon.PeerState.latency:type_name -> google.protobuf.Duration
+	20,  // 6: daemon.PeerState.connHistory:type_name -> daemon.PeerConnEvent
+	178, // 7: daemon.PeerConnEvent.time:type_name -> google.protobuf.Timestamp
+	177, // 8: daemon.ManagementState.clockOffset:type_name -> google.protobuf.Duration
+	177, // 9: daemon.RelayState.latency:type_name -> google.protobuf.Duration
+	26,  // 10: daemon.SSHServerState.sessions:type_name -> daemon.SSHSessionInfo
+	23,  // 11: daemon.FullStatus.managementState:type_name -> daemon.ManagementState
+	22,  // 12: daemon.FullStatus.signalState:type_name -> daemon.SignalState
+	21,  // 13: daemon.FullStatus.localPeerState:type_name -> daemon.LocalPeerState
+	19,  // 14: daemon.FullStatus.peers:type_name -> daemon.PeerState
+	24,  // 15: daemon.FullStatus.relays:type_name -> daemon.RelayState
+	25,  // 16: daemon.FullStatus.dns_servers:type_name -> daemon.NSGroupState
+	113, // 17: daemon.FullStatus.events:type_name -> daemon.SystemEvent
+	27,  // 18: daemon.FullStatus.sshServerState:type_name -> daemon.SSHServerState
+	33,  // 19: daemon.FullStatus.daemonState:type_name -> daemon.DaemonState
+	32,  // 20: daemon.FullStatus.exitNodes:type_name -> daemon.ExitNodeState
+	29,  // 21: daemon.FullStatus.posture:type_name -> daemon.PostureState
+	178, // 22: daemon.PostureState.evaluatedAt:type_name -> google.protobuf.Timestamp
+	30,  // 23: daemon.PostureState.checks:type_name -> daemon.PostureCheckState
+	31,  // 24: daemon.PostureCheckState.processes:type_name -> daemon.PostureProcessState
+	177, // 25: daemon.ExitNodeState.latency:type_name -> google.protobuf.Duration
+	178, // 26: daemon.DaemonState.startedAt:type_name -> google.protobuf.Timestamp
+	39,  // 27: daemon.ListNetworksResponse.routes:type_name -> daemon.Network
+	172, // 28: daemon.Network.resolvedIPs:type_name -> daemon.Network.ResolvedIPsEntry
+	173, // 29: daemon.PortInfo.range:type_name -> daemon.PortInfo.Range
+	40,  // 30: daemon.ForwardingRule.destinationPort:type_name -> daemon.PortInfo
+	40,  // 31: daemon.ForwardingRule.translatedPort:type_name -> daemon.PortInfo
+	41,  // 32: daemon.ForwardingRulesResponse.rules:type_name -> daemon.ForwardingRule
+	58,  // 33: daemon.DebugBundleRequest.anonymizationMap:type_name -> daemon.AnonymizationMapping
+	177, // 34: daemon.DebugBundleRequest.since:type_name -> google.protobuf.Duration
+	177, // 35: daemon.DebugBundleRequest.uploadTimeout:type_name -> google.protobuf.Duration
+	177, // 36: daemon.DebugBundleRequest.profileCpuDuration:type_name -> google.protobuf.Duration
+	86,  // 37: daemon.DebugBundleRequest.aclQueries:type_name -> daemon.ACLQuery
+	48,  // 38: daemon.DebugBundleRequest.interfaceStatsStart:type_name -> daemon.InterfaceStatsSnapshot
+	51,  // 39: daemon.DebugBundleRequest.resourceTimeline:type_name -> daemon.RuntimeStatsSample
+	178, // 40: daemon.DebugBundleRequest.sessionStart:type_name -> google.protobuf.Timestamp
+	48,  // 41: daemon.GetInterfaceStatsResponse.snapshot:type_name -> daemon.InterfaceStatsSnapshot
+	178, // 42: daemon.InterfaceStatsSnapshot.time:type_name -> google.protobuf.Timestamp
+	52,  // 43: daemon.InterfaceStatsSnapshot.interfaces:type_name -> daemon.InterfaceCounters
+	52,  // 44: daemon.InterfaceStatsSnapshot.wireGuard:type_name -> daemon.InterfaceCounters
+	51,  // 45: daemon.GetRuntimeStatsResponse.sample:type_name -> daemon.RuntimeStatsSample
+	178, // 46: daemon.RuntimeStatsSample.time:type_name -> google.protobuf.Timestamp
+	56,  // 47: daemon.DebugBundleResponse.anonymizationPreview:type_name -> daemon.AnonymizationSummary
+	58,  // 48: daemon.DebugBundleResponse.anonymizationMap:type_name -> daemon.AnonymizationMapping
+	178, // 49: daemon.DebugBundleResponse.expiresAt:type_name -> google.protobuf.Timestamp
+	53,  // 50: daemon.DebugBundleProgressEvent.response:type_name -> daemon.DebugBundleResponse
+	57,  // 51: daemon.AnonymizationSummary.samples:type_name -> daemon.AnonymizationSample
+	0,   // 52: daemon.GetLogLevelResponse.level:type_name -> daemon.LogLevel
+	0,   // 53: daemon.GetLogLevelResponse.defaultLevel:type_name -> daemon.LogLevel
+	174, // 54: daemon.GetLogLevelResponse.components:type_name -> daemon.GetLogLevelResponse.ComponentsEntry
+	0,   // 55: daemon.SetLogLevelRequest.level:type_name -> daemon.LogLevel
+	175, // 56: daemon.SetLogLevelRequest.components:type_name -> daemon.SetLogLevelRequest.ComponentsEntry
+	0,   // 57: daemon.TailLogsRequest.level:type_name -> daemon.LogLevel
+	178, // 58: daemon.LogLine.time:type_name -> google.protobuf.Timestamp
+	0,   // 59: daemon.LogLine.level:type_name -> daemon.LogLevel
+	71,  // 60: daemon.ListStatesResponse.states:type_name -> daemon.State
+	82,  // 61: daemon.TracePacketRequest.tcp_flags:type_name -> daemon.TCPFlags
+	84,  // 62: daemon.TracePacketResponse.stages:type_name -> daemon.TraceStage
+	86,  // 63: daemon.EvaluateACLRequest.query:type_name -> daemon.ACLQuery
+	90,  // 64: daemon.GetDropStatsResponse.drops:type_name -> daemon.DropCounter
+	177, // 65: daemon.RelayFailoverTestRequest.timeout:type_name -> google.protobuf.Duration
+	177, // 66: daemon.RelayFailoverEvent.elapsed:type_name -> google.protobuf.Duration
+	177, // 67: daemon.RelayFailoverTestResponse.duration:type_name -> google.protobuf.Duration
+	93,  // 68: daemon.RelayFailoverTestResponse.events:type_name -> daemon.RelayFailoverEvent
+	178, // 69: daemon.StartupPhase.started:type_name -> google.protobuf.Timestamp
+	177, // 70: daemon.StartupPhase.duration:type_name -> google.protobuf.Duration
+	178, // 71: daemon.GetStartupTimingResponse.started_at:type_name -> google.protobuf.Timestamp
+	177, // 72: daemon.GetStartupTimingResponse.total:type_name -> google.protobuf.Duration
+	96,  // 73: daemon.GetStartupTimingResponse.phases:type_name -> daemon.StartupPhase
+	99,  // 74: daemon.ProbePeerMTUResponse.results:type_name -> daemon.PeerMTUResult
+	177, // 75: daemon.PeerProbeRequest.timeout:type_name -> google.protobuf.Duration
+	177, // 76: daemon.PeerProbeResponse.connectTime:type_name -> google.protobuf.Duration
+	177, // 77: daemon.PeerProbeResponse.rtts:type_name -> google.protobuf.Duration
+	177, // 78: daemon.BenchmarkPeerRequest.duration:type_name -> google.protobuf.Duration
+	177, // 79: daemon.BenchmarkPeerResponse.uploadDuration:type_name -> google.protobuf.Duration
+	177, // 80: daemon.BenchmarkPeerResponse.downloadDuration:type_name -> google.protobuf.Duration
+	177, // 81: daemon.BenchmarkPeerResponse.rtts:type_name -> google.protobuf.Duration
+	106, // 82: daemon.GetInterfaceConflictsResponse.conflicts:type_name -> daemon.InterfaceConflict
+	109, // 83: daemon.RouteConflict.netbird:type_name -> daemon.ConflictingRoute
+	109, // 84: daemon.RouteConflict.host:type_name -> daemon.ConflictingRoute
+	110, // 85: daemon.GetRouteConflictsResponse.conflicts:type_name -> daemon.RouteConflict
+	2,   // 86: daemon.SystemEvent.severity:type_name -> daemon.SystemEvent.Severity
+	3,   // 87: daemon.SystemEvent.category:type_name -> daemon.SystemEvent.Category
+	178, // 88: daemon.SystemEvent.timestamp:type_name -> google.protobuf.Timestamp
+	176, // 89: daemon.SystemEvent.metadata:type_name -> daemon.SystemEvent.MetadataEntry
+	113, // 90: daemon.GetEventsResponse.events:type_name -> daemon.SystemEvent
+	177, // 91: daemon.SetConfigRequest.dnsRouteInterval:type_name -> google.protobuf.Duration
+	128, // 92: daemon.ListProfilesResponse.profiles:type_name -> daemon.Profile
+	138, // 93: daemon.ListFeatureFlagsResponse.flags:type_name -> daemon.FeatureFlag
+	138, // 94: daemon.GetBuildInfoResponse.flags:type_name -> daemon.FeatureFlag
+	178, // 95: daemon.WaitExtendAuthSessionResponse.sessionExpiresAt:type_name -> google.protobuf.Timestamp
+	1,   // 96: daemon.ExposeServiceRequest.protocol:type_name -> daemon.ExposeProtocol
+	165, // 97: daemon.ExposeServiceEvent.ready:type_name -> daemon.ExposeServiceReady
+	177, // 98: daemon.StartCaptureRequest.duration:type_name -> google.protobuf.Duration
+	177, // 99: daemon.StartBundleCaptureRequest.timeout:type_name -> google.protobuf.Duration
+	38,  // 100: daemon.Network.ResolvedIPsEntry.value:type_name -> daemon.IPList
+	0,   // 101: daemon.GetLogLevelResponse.ComponentsEntry.value:type_name -> daemon.LogLevel
+	0,   // 102: daemon.SetLogLevelRequest.ComponentsEntry.value:type_name -> daemon.LogLevel
+	5,   // 103: daemon.DaemonService.Login:input_type -> daemon.LoginRequest
+	7,   // 104: daemon.DaemonService.WaitSSOLogin:input_type -> daemon.WaitSSOLoginRequest
+	9,   // 105: daemon.DaemonService.Up:input_type -> daemon.UpRequest
+	11,  // 106: daemon.DaemonService.Status:input_type -> daemon.StatusRequest
+	11,  // 107: daemon.DaemonService.SubscribeStatus:input_type -> daemon.StatusRequest
+	13,  // 108: daemon.DaemonService.Down:input_type -> daemon.DownRequest
+	15,  // 109: daemon.DaemonService.GetConfig:input_type -> daemon.GetConfigRequest
+	17,  // 110: daemon.DaemonService.GetEffectiveConfig:input_type -> daemon.GetEffectiveConfigRequest
+	34,  // 111: daemon.DaemonService.ListNetworks:input_type -> daemon.ListNetworksRequest
+	36,  // 112: daemon.DaemonService.SelectNetworks:input_type -> daemon.SelectNetworksRequest
+	36,  // 113: daemon.DaemonService.DeselectNetworks:input_type -> daemon.SelectNetworksRequest
+	4,   // 114: daemon.DaemonService.ForwardingRules:input_type -> daemon.EmptyRequest
+	43,  // 115: daemon.DaemonService.DebugBundle:input_type -> daemon.DebugBundleRequest
+	43,  // 116: daemon.DaemonService.DebugBundleStream:input_type -> daemon.DebugBundleRequest
+	43,  // 117: daemon.DaemonService.DebugBundleWithProgress:input_type -> daemon.DebugBundleRequest
+	59,  // 118: daemon.DaemonService.GetLogLevel:input_type -> daemon.GetLogLevelRequest
+	61,  // 119: daemon.DaemonService.SetLogLevel:input_type -> daemon.SetLogLevelRequest
+	63,  // 120: daemon.DaemonService.RotateLog:input_type -> daemon.RotateLogRequest
+	65,  // 121: daemon.DaemonService.SetLogFormat:input_type -> daemon.SetLogFormatRequest
+	67,  // 122: daemon.DaemonService.TailLogs:input_type -> daemon.TailLogsRequest
+	44,  // 123: daemon.DaemonService.StageDebugLogs:input_type -> daemon.StageDebugLogsRequest
+	46,  // 124: daemon.DaemonService.GetInterfaceStats:input_type -> daemon.GetInterfaceStatsRequest
+	49,  // 125: daemon.DaemonService.GetRuntimeStats:input_type -> daemon.GetRuntimeStatsRequest
+	72,  // 126: daemon.DaemonService.ListStates:input_type -> daemon.ListStatesRequest
+	74,  // 127: daemon.DaemonService.CleanState:input_type -> daemon.CleanStateRequest
+	76,  // 128: daemon.DaemonService.DeleteState:input_type -> daemon.DeleteStateRequest
+	78,  // 129: daemon.DaemonService.SetSyncResponsePersistence:input_type -> daemon.SetSyncResponsePersistenceRequest
+	80,  // 130: daemon.DaemonService.GetSyncResponsePersistence:input_type -> daemon.GetSyncResponsePersistenceRequest
+	83,  // 131: daemon.DaemonService.TracePacket:input_type -> daemon.TracePacketRequest
+	87,  // 132: daemon.DaemonService.EvaluateACL:input_type -> daemon.EvaluateACLRequest
+	89,  // 133: daemon.DaemonService.GetDropStats:input_type -> daemon.GetDropStatsRequest
+	92,  // 134: daemon.DaemonService.RelayFailoverTest:input_type -> daemon.RelayFailoverTestRequest
+	95,  // 135: daemon.DaemonService.GetStartupTiming:input_type -> daemon.GetStartupTimingRequest
+	98,  // 136: daemon.DaemonService.ProbePeerMTU:input_type -> daemon.ProbePeerMTURequest
+	101, // 137: daemon.DaemonService.PeerProbe:input_type -> daemon.PeerProbeRequest
+	103, // 138: daemon.DaemonService.BenchmarkPeer:input_type -> daemon.BenchmarkPeerRequest
+	105, // 139: daemon.DaemonService.GetInterfaceConflicts:input_type -> daemon.GetInterfaceConflictsRequest
+	108, // 140: daemon.DaemonService.GetRouteConflicts:input_type -> daemon.GetRouteConflictsRequest
+	166, // 141: daemon.DaemonService.StartCapture:input_type -> daemon.StartCaptureRequest
+	168, // 142: daemon.DaemonService.StartBundleCapture:input_type -> daemon.StartBundleCaptureRequest
+	170, // 143: daemon.DaemonService.StopBundleCapture:input_type -> daemon.StopBundleCaptureRequest
+	112, // 144: daemon.DaemonService.SubscribeEvents:input_type -> daemon.SubscribeRequest
+	114, // 145: daemon.DaemonService.GetEvents:input_type -> daemon.GetEventsRequest
+	69,  // 146: daemon.DaemonService.RegisterUILog:input_type -> daemon.RegisterUILogRequest
+	116, // 147: daemon.DaemonService.SwitchProfile:input_type -> daemon.SwitchProfileRequest
+	118, // 148: daemon.DaemonService.SetConfig:input_type -> daemon.SetConfigRequest
+	120, // 149: daemon.DaemonService.AddProfile:input_type -> daemon.AddProfileRequest
+	122, // 150: daemon.DaemonService.RenameProfile:input_type -> daemon.RenameProfileRequest
+	124, // 151: daemon.DaemonService.RemoveProfile:input_type -> daemon.RemoveProfileRequest
+	126, // 152: daemon.DaemonService.ListProfiles:input_type -> daemon.ListProfilesRequest
+	129, // 153: daemon.DaemonService.GetActiveProfile:input_type -> daemon.GetActiveProfileRequest
+	131, // 154: daemon.DaemonService.Logout:input_type -> daemon.LogoutRequest
+	135, // 155: daemon.DaemonService.GetFeatures:input_type -> daemon.GetFeaturesRequest
+	137, // 156: daemon.DaemonService.ListFeatureFlags:input_type -> daemon.ListFeatureFlagsRequest
+	140, // 157: daemon.DaemonService.GetBuildInfo:input_type -> daemon.GetBuildInfoRequest
+	143, // 158: daemon.DaemonService.TriggerUpdate:input_type -> daemon.TriggerUpdateRequest
+	145, // 159: daemon.DaemonService.GetPeerSSHHostKey:input_type -> daemon.GetPeerSSHHostKeyRequest
+	147, // 160: daemon.DaemonService.RequestJWTAuth:input_type -> daemon.RequestJWTAuthRequest
+	149, // 161: daemon.DaemonService.WaitJWTToken:input_type -> daemon.WaitJWTTokenRequest
+	151, // 162: daemon.DaemonService.RequestExtendAuthSession:input_type -> daemon.RequestExtendAuthSessionRequest
+	153, // 163: daemon.DaemonService.WaitExtendAuthSession:input_type -> daemon.WaitExtendAuthSessionRequest
+	155, // 164: daemon.DaemonService.DismissSessionWarning:input_type -> daemon.DismissSessionWarningRequest
+	157, // 165: daemon.DaemonService.StartCPUProfile:input_type -> daemon.StartCPUProfileRequest
+	159, // 166: daemon.DaemonService.StopCPUProfile:input_type -> daemon.StopCPUProfileRequest
+	161, // 167: daemon.DaemonService.GetInstallerResult:input_type -> daemon.InstallerResultRequest
+	163, // 168: daemon.DaemonService.ExposeService:input_type -> daemon.ExposeServiceRequest
+	133, // 169: daemon.DaemonService.WailsUIReady:input_type -> daemon.WailsUIReadyRequest
+	6,   // 170: daemon.DaemonService.Login:output_type -> daemon.LoginResponse
+	8,   // 171: daemon.DaemonService.WaitSSOLogin:output_type -> daemon.WaitSSOLoginResponse
+	10,  // 172: daemon.DaemonService.Up:output_type -> daemon.UpResponse
+	12,  // 173: daemon.DaemonService.Status:output_type -> daemon.StatusResponse
+	12,  // 174: daemon.DaemonService.SubscribeStatus:output_type -> daemon.StatusResponse
+	14,  // 175: daemon.DaemonService.Down:output_type -> daemon.DownResponse
+	16,  // 176: daemon.DaemonService.GetConfig:output_type -> daemon.GetConfigResponse
+	18,  // 177: daemon.DaemonService.GetEffectiveConfig:output_type -> daemon.GetEffectiveConfigResponse
+	35,  // 178: daemon.DaemonService.ListNetworks:output_type -> daemon.ListNetworksResponse
+	37,  // 179: daemon.DaemonService.SelectNetworks:output_type -> daemon.SelectNetworksResponse
+	37,  // 180: daemon.DaemonService.DeselectNetworks:output_type -> daemon.SelectNetworksResponse
+	42,  // 181: daemon.DaemonService.ForwardingRules:output_type -> daemon.ForwardingRulesResponse
+	53,  // 182: daemon.DaemonService.DebugBundle:output_type -> daemon.DebugBundleResponse
+	54,  // 183: daemon.DaemonService.DebugBundleStream:output_type -> daemon.DebugBundleChunk
+	55,  // 184: daemon.DaemonService.DebugBundleWithProgress:output_type -> daemon.DebugBundleProgressEvent
+	60,  // 185: daemon.DaemonService.GetLogLevel:output_type -> daemon.GetLogLevelResponse
+	62,  // 186: daemon.DaemonService.SetLogLevel:output_type -> daemon.SetLogLevelResponse
+	64,  // 187: daemon.DaemonService.RotateLog:output_type -> daemon.RotateLogResponse
+	66,  // 188: daemon.DaemonService.SetLogFormat:output_type -> daemon.SetLogFormatResponse
+	68,  // 189: daemon.DaemonService.TailLogs:output_type -> daemon.LogLine
+	45,  // 190: daemon.DaemonService.StageDebugLogs:output_type -> daemon.StageDebugLogsResponse
+	47,  // 191: daemon.DaemonService.GetInterfaceStats:output_type -> daemon.GetInterfaceStatsResponse
+	50,  // 192: daemon.DaemonService.GetRuntimeStats:output_type -> daemon.GetRuntimeStatsResponse
+	73,  // 193: daemon.DaemonService.ListStates:output_type -> daemon.ListStatesResponse
+	75,  // 194: daemon.DaemonService.CleanState:output_type -> daemon.CleanStateResponse
+	77,  // 195: daemon.DaemonService.DeleteState:output_type -> daemon.DeleteStateResponse
+	79,  // 196: daemon.DaemonService.SetSyncResponsePersistence:output_type -> daemon.SetSyncResponsePersistenceResponse
+	81,  // 197: daemon.DaemonService.GetSyncResponsePersistence:output_type -> daemon.GetSyncResponsePersistenceResponse
+	85,  // 198: daemon.DaemonService.TracePacket:output_type -> daemon.TracePacketResponse
+	88,  // 199: daemon.DaemonService.EvaluateACL:output_type -> daemon.EvaluateACLResponse
+	91,  // 200: daemon.DaemonService.GetDropStats:output_type -> daemon.GetDropStatsResponse
+	94,  // 201: daemon.DaemonService.RelayFailoverTest:output_type -> daemon.RelayFailoverTestResponse
+	97,  // 202: daemon.DaemonService.GetStartupTiming:output_type -> daemon.GetStartupTimingResponse
+	100, // 203: daemon.DaemonService.ProbePeerMTU:output_type -> daemon.ProbePeerMTUResponse
+	102, // 204: daemon.DaemonService.PeerProbe:output_type -> daemon.PeerProbeResponse
+	104, // 205: daemon.DaemonService.BenchmarkPeer:output_type -> daemon.BenchmarkPeerResponse
+	107, // 206: daemon.DaemonService.GetInterfaceConflicts:output_type -> daemon.GetInterfaceConflictsResponse
+	111, // 207: daemon.DaemonService.GetRouteConflicts:output_type -> daemon.GetRouteConflictsResponse
+	167, // 208: daemon.DaemonService.StartCapture:output_type -> daemon.CapturePacket
+	169, // 209: daemon.DaemonService.StartBundleCapture:output_type -> daemon.StartBundleCaptureResponse
+	171, // 210: daemon.DaemonService.StopBundleCapture:output_type -> daemon.StopBundleCaptureResponse
+	113, // 211: daemon.DaemonService.SubscribeEvents:output_type -> daemon.SystemEvent
+	115, // 212: daemon.DaemonService.GetEvents:output_type -> daemon.GetEventsResponse
+	70,  // 213: daemon.DaemonService.RegisterUILog:output_type -> daemon.RegisterUILogResponse
+	117, // 214: daemon.DaemonService.SwitchProfile:output_type -> daemon.SwitchProfileResponse
+	119, // 215: daemon.DaemonService.SetConfig:output_type -> daemon.SetConfigResponse
+	121, // 216: daemon.DaemonService.AddProfile:output_type -> daemon.AddProfileResponse
+	123, // 217: daemon.DaemonService.RenameProfile:output_type -> daemon.RenameProfileResponse
+	125, // 218: daemon.DaemonService.RemoveProfile:output_type -> daemon.RemoveProfileResponse
+	127, // 219: daemon.DaemonService.ListProfiles:output_type -> daemon.ListProfilesResponse
+	130, // 220: daemon.DaemonService.GetActiveProfile:output_type -> daemon.GetActiveProfileResponse
+	132, // 221: daemon.DaemonService.Logout:output_type -> daemon.LogoutResponse
+	136, // 222: daemon.DaemonService.GetFeatures:output_type -> daemon.GetFeaturesResponse
+	139, // 223: daemon.DaemonService.ListFeatureFlags:output_type -> daemon.ListFeatureFlagsResponse
+	141, // 224: daemon.DaemonService.GetBuildInfo:output_type -> daemon.GetBuildInfoResponse
+	144, // 225: daemon.DaemonService.TriggerUpdate:output_type -> daemon.TriggerUpdateResponse
+	146, // 226: daemon.DaemonService.GetPeerSSHHostKey:output_type -> daemon.GetPeerSSHHostKeyResponse
+	148, // 227: daemon.DaemonService.RequestJWTAuth:output_type -> daemon.RequestJWTAuthResponse
+	150, // 228: daemon.DaemonService.WaitJWTToken:output_type -> daemon.WaitJWTTokenResponse
+	152, // 229: daemon.DaemonService.RequestExtendAuthSession:output_type -> daemon.RequestExtendAuthSessionResponse
+	154, // 230: daemon.DaemonService.WaitExtendAuthSession:output_type -> daemon.WaitExtendAuthSessionResponse
+	156, // 231: daemon.DaemonService.DismissSessionWarning:output_type -> daemon.DismissSessionWarningResponse
+	158, // 232: daemon.DaemonService.StartCPUProfile:output_type -> daemon.StartCPUProfileResponse
+	160, // 233: daemon.DaemonService.StopCPUProfile:output_type -> daemon.StopCPUProfileResponse
+	162, // 234: daemon.DaemonService.GetInstallerResult:output_type -> daemon.InstallerResultResponse
+	164, // 235: daemon.DaemonService.ExposeService:output_type -> daemon.ExposeServiceEvent
+	134, // 236: daemon.DaemonService.WailsUIReady:output_type -> daemon.WailsUIReadyResponse
+	170, // [170:237] is the sub-list for method output_type
+	103, // [103:170] is the sub-list for method input_type
+	103, // [103:103] is the sub-list for extension type_name
+	103, // [103:103] is the sub-list for extension extendee
+	0,   // [0:103] is the sub-list for field type_name
 }
 
 func init() { file_daemon_proto_init() }
@@ -7914,19 +12789,19 @@ func file_daemon_proto_init() {
 	file_daemon_proto_msgTypes[1].OneofWrappers = []any{}
 	file_daemon_proto_msgTypes[5].OneofWrappers = []any{}
 	file_daemon_proto_msgTypes[7].OneofWrappers = []any{}
-	file_daemon_proto_msgTypes[28].OneofWrappers = []any{
+	file_daemon_proto_msgTypes[36].OneofWrappers = []any{
 		(*PortInfo_Port)(nil),
 		(*PortInfo_Range_)(nil),
 	}
-	file_daemon_proto_msgTypes[49].OneofWrappers = []any{}
-	file_daemon_proto_msgTypes[50].OneofWrappers = []any{}
-	file_daemon_proto_msgTypes[56].OneofWrappers = []any{}
-	file_daemon_proto_msgTypes[58].OneofWrappers = []any{}
-	file_daemon_proto_msgTypes[71].OneofWrappers = []any{}
-	file_daemon_proto_msgTypes[76].OneofWrappers = []any{}
-	file_daemon_proto_msgTypes[82].OneofWrappers = []any{}
-	file_daemon_proto_msgTypes[86].OneofWrappers = []any{}
-	file_daemon_proto_msgTypes[99].OneofWrappers = []any{
+	file_daemon_proto_msgTypes[79].OneofWrappers = []any{}
+	file_daemon_proto_msgTypes[80].OneofWrappers = []any{}
+	file_daemon_proto_msgTypes[112].OneofWrappers = []any{}
+	file_daemon_proto_msgTypes[114].OneofWrappers = []any{}
+	file_daemon_proto_msgTypes[127].OneofWrappers = []any{}
+	file_daemon_proto_msgTypes[132].OneofWrappers = []any{}
+	file_daemon_proto_msgTypes[143].OneofWrappers = []any{}
+	file_daemon_proto_msgTypes[147].OneofWrappers = []any{}
+	file_daemon_proto_msgTypes[160].OneofWrappers = []any{
 		(*ExposeServiceEvent_Ready)(nil),
 	}
 	type x struct{}
@@ -7935,7 +12810,7 @@ func file_daemon_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_daemon_proto_rawDesc), len(file_daemon_proto_rawDesc)),
 			NumEnums:      4,
-			NumMessages:   110,
+			NumMessages:   173,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -26,18 +26,37 @@ const (
 	DaemonService_SubscribeStatus_FullMethodName            = "/daemon.DaemonService/SubscribeStatus"
 	DaemonService_Down_FullMethodName                       = "/daemon.DaemonService/Down"
 	DaemonService_GetConfig_FullMethodName                  = "/daemon.DaemonService/GetConfig"
+	DaemonService_GetEffectiveConfig_FullMethodName         = "/daemon.DaemonService/GetEffectiveConfig"
 	DaemonService_ListNetworks_FullMethodName               = "/daemon.DaemonService/ListNetworks"
 	DaemonService_SelectNetworks_FullMethodName             = "/daemon.DaemonService/SelectNetworks"
 	DaemonService_DeselectNetworks_FullMethodName           = "/daemon.DaemonService/DeselectNetworks"
 	DaemonService_ForwardingRules_FullMethodName            = "/daemon.DaemonService/ForwardingRules"
 	DaemonService_DebugBundle_FullMethodName                = "/daemon.DaemonService/DebugBundle"
+	DaemonService_DebugBundleStream_FullMethodName          = "/daemon.DaemonService/DebugBundleStream"
+	DaemonService_DebugBundleWithProgress_FullMethodName    = "/daemon.DaemonService/DebugBundleWithProgress"
 	DaemonService_GetLogLevel_FullMethodName                = "/daemon.DaemonService/GetLogLevel"
 	DaemonService_SetLogLevel_FullMethodName                = "/daemon.DaemonService/SetLogLevel"
+	DaemonService_RotateLog_FullMethodName                  = "/daemon.DaemonService/RotateLog"
+	DaemonService_SetLogFormat_FullMethodName               = "/daemon.DaemonService/SetLogFormat"
+	DaemonService_TailLogs_FullMethodName                   = "/daemon.DaemonService/TailLogs"
+	DaemonService_StageDebugLogs_FullMethodName             = "/daemon.DaemonService/StageDebugLogs"
+	DaemonService_GetInterfaceStats_FullMethodName          = "/daemon.DaemonService/GetInterfaceStats"
+	DaemonService_GetRuntimeStats_FullMethodName            = "/daemon.DaemonService/GetRuntimeStats"
 	DaemonService_ListStates_FullMethodName                 = "/daemon.DaemonService/ListStates"
 	DaemonService_CleanState_FullMethodName                 = "/daemon.DaemonService/CleanState"
 	DaemonService_DeleteState_FullMethodName                = "/daemon.DaemonService/DeleteState"
 	DaemonService_SetSyncResponsePersistence_FullMethodName = "/daemon.DaemonService/SetSyncResponsePersistence"
+	DaemonService_GetSyncResponsePersistence_FullMethodName = "/daemon.DaemonService/GetSyncResponsePersistence"
 	DaemonService_TracePacket_FullMethodName                = "/daemon.DaemonService/TracePacket"
+	DaemonService_EvaluateACL_FullMethodName                = "/daemon.DaemonService/EvaluateACL"
+	DaemonService_GetDropStats_FullMethodName               = "/daemon.DaemonService/GetDropStats"
+	DaemonService_RelayFailoverTest_FullMethodName          = "/daemon.DaemonService/RelayFailoverTest"
+	DaemonService_GetStartupTiming_FullMethodName           = "/daemon.DaemonService/GetStartupTiming"
+	DaemonService_ProbePeerMTU_FullMethodName               = "/daemon.DaemonService/ProbePeerMTU"
+	DaemonService_PeerProbe_FullMethodName                  = "/daemon.DaemonService/PeerProbe"
+	DaemonService_BenchmarkPeer_FullMethodName              = "/daemon.DaemonService/BenchmarkPeer"
+	DaemonService_GetInterfaceConflicts_FullMethodName      = "/daemon.DaemonService/GetInterfaceConflicts"
+	DaemonService_GetRouteConflicts_FullMethodName          = "/daemon.DaemonService/GetRouteConflicts"
 	DaemonService_StartCapture_FullMethodName               = "/daemon.DaemonService/StartCapture"
 	DaemonService_StartBundleCapture_FullMethodName         = "/daemon.DaemonService/StartBundleCapture"
 	DaemonService_StopBundleCapture_FullMethodName          = "/daemon.DaemonService/StopBundleCapture"
@@ -53,6 +72,8 @@ const (
 	DaemonService_GetActiveProfile_FullMethodName           = "/daemon.DaemonService/GetActiveProfile"
 	DaemonService_Logout_FullMethodName                     = "/daemon.DaemonService/Logout"
 	DaemonService_GetFeatures_FullMethodName                = "/daemon.DaemonService/GetFeatures"
+	DaemonService_ListFeatureFlags_FullMethodName           = "/daemon.DaemonService/ListFeatureFlags"
+	DaemonService_GetBuildInfo_FullMethodName               = "/daemon.DaemonService/GetBuildInfo"
 	DaemonService_TriggerUpdate_FullMethodName              = "/daemon.DaemonService/TriggerUpdate"
 	DaemonService_GetPeerSSHHostKey_FullMethodName          = "/daemon.DaemonService/GetPeerSSHHostKey"
 	DaemonService_RequestJWTAuth_FullMethodName             = "/daemon.DaemonService/RequestJWTAuth"
@@ -89,6 +110,9 @@ type DaemonServiceClient interface {
 	Down(ctx context.Context, in *DownRequest, opts ...grpc.CallOption) (*DownResponse, error)
 	// GetConfig of the daemon.
 	GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*GetConfigResponse, error)
+	// GetEffectiveConfig returns the configuration the daemon is running with, after merging the
+	// profile file, environment, CLI input and MDM policy.
+	GetEffectiveConfig(ctx context.Context, in *GetEffectiveConfigRequest, opts ...grpc.CallOption) (*GetEffectiveConfigResponse, error)
 	// List available networks
 	ListNetworks(ctx context.Context, in *ListNetworksRequest, opts ...grpc.CallOption) (*ListNetworksResponse, error)
 	// Select specific routes
@@ -98,10 +122,30 @@ type DaemonServiceClient interface {
 	ForwardingRules(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*ForwardingRulesResponse, error)
 	// DebugBundle creates a debug bundle
 	DebugBundle(ctx context.Context, in *DebugBundleRequest, opts ...grpc.CallOption) (*DebugBundleResponse, error)
+	// DebugBundleStream creates a debug bundle and streams its content in chunks. The bundle is
+	// removed from the daemon host once sent. Uploading is not supported.
+	DebugBundleStream(ctx context.Context, in *DebugBundleRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DebugBundleChunk], error)
+	// DebugBundleWithProgress creates a debug bundle like DebugBundle and streams the stage of the
+	// generation while it runs. The last event carries the response.
+	DebugBundleWithProgress(ctx context.Context, in *DebugBundleRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DebugBundleProgressEvent], error)
 	// GetLogLevel gets the log level of the daemon
 	GetLogLevel(ctx context.Context, in *GetLogLevelRequest, opts ...grpc.CallOption) (*GetLogLevelResponse, error)
 	// SetLogLevel sets the log level of the daemon
 	SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error)
+	// RotateLog closes the active daemon log file and starts a new one
+	RotateLog(ctx context.Context, in *RotateLogRequest, opts ...grpc.CallOption) (*RotateLogResponse, error)
+	// SetLogFormat switches the daemon log between text and JSON lines until the daemon restarts.
+	SetLogFormat(ctx context.Context, in *SetLogFormatRequest, opts ...grpc.CallOption) (*SetLogFormatResponse, error)
+	// TailLogs streams the daemon log lines as they are written until the client disconnects.
+	TailLogs(ctx context.Context, in *TailLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LogLine], error)
+	// StageDebugLogs copies the current daemon logs aside, to be merged into a later debug bundle.
+	StageDebugLogs(ctx context.Context, in *StageDebugLogsRequest, opts ...grpc.CallOption) (*StageDebugLogsResponse, error)
+	// GetInterfaceStats returns the traffic counters of the WireGuard interface and the network
+	// interfaces, taken by "debug for" at the start of the session.
+	GetInterfaceStats(ctx context.Context, in *GetInterfaceStatsRequest, opts ...grpc.CallOption) (*GetInterfaceStatsResponse, error)
+	// GetRuntimeStats returns the goroutine count, open file descriptors and memory of the daemon,
+	// sampled by "debug for" every second.
+	GetRuntimeStats(ctx context.Context, in *GetRuntimeStatsRequest, opts ...grpc.CallOption) (*GetRuntimeStatsResponse, error)
 	// List all states
 	ListStates(ctx context.Context, in *ListStatesRequest, opts ...grpc.CallOption) (*ListStatesResponse, error)
 	// Clean specific state or all states
@@ -110,7 +154,28 @@ type DaemonServiceClient interface {
 	DeleteState(ctx context.Context, in *DeleteStateRequest, opts ...grpc.CallOption) (*DeleteStateResponse, error)
 	// SetSyncResponsePersistence enables or disables sync response persistence
 	SetSyncResponsePersistence(ctx context.Context, in *SetSyncResponsePersistenceRequest, opts ...grpc.CallOption) (*SetSyncResponsePersistenceResponse, error)
+	// GetSyncResponsePersistence returns whether sync response persistence is enabled
+	GetSyncResponsePersistence(ctx context.Context, in *GetSyncResponsePersistenceRequest, opts ...grpc.CallOption) (*GetSyncResponsePersistenceResponse, error)
 	TracePacket(ctx context.Context, in *TracePacketRequest, opts ...grpc.CallOption) (*TracePacketResponse, error)
+	// EvaluateACL evaluates a flow against the current ACL policy without changing the firewall.
+	EvaluateACL(ctx context.Context, in *EvaluateACLRequest, opts ...grpc.CallOption) (*EvaluateACLResponse, error)
+	// GetDropStats returns the userspace filter's inbound packet drop counters by reason.
+	GetDropStats(ctx context.Context, in *GetDropStatsRequest, opts ...grpc.CallOption) (*GetDropStatsResponse, error)
+	// RelayFailoverTest makes the home relay unreachable, waits for the daemon to fail over to
+	// another relay and reports the sequence and timing.
+	RelayFailoverTest(ctx context.Context, in *RelayFailoverTestRequest, opts ...grpc.CallOption) (*RelayFailoverTestResponse, error)
+	// GetStartupTiming returns the duration of each phase of the latest client startup.
+	GetStartupTiming(ctx context.Context, in *GetStartupTimingRequest, opts ...grpc.CallOption) (*GetStartupTimingResponse, error)
+	// ProbePeerMTU discovers the largest packet size that reaches each given peer through the tunnel.
+	ProbePeerMTU(ctx context.Context, in *ProbePeerMTURequest, opts ...grpc.CallOption) (*ProbePeerMTUResponse, error)
+	// PeerProbe opens the connection to a peer if it is not up and pings the peer through the tunnel.
+	PeerProbe(ctx context.Context, in *PeerProbeRequest, opts ...grpc.CallOption) (*PeerProbeResponse, error)
+	// BenchmarkPeer measures the throughput, round trip time and loss to a peer through the tunnel.
+	BenchmarkPeer(ctx context.Context, in *BenchmarkPeerRequest, opts ...grpc.CallOption) (*BenchmarkPeerResponse, error)
+	// GetInterfaceConflicts reports host interface subnets overlapping the overlay network or routes.
+	GetInterfaceConflicts(ctx context.Context, in *GetInterfaceConflictsRequest, opts ...grpc.CallOption) (*GetInterfaceConflictsResponse, error)
+	// GetRouteConflicts reports host routes overlapping the routes installed by NetBird.
+	GetRouteConflicts(ctx context.Context, in *GetRouteConflictsRequest, opts ...grpc.CallOption) (*GetRouteConflictsResponse, error)
 	// StartCapture begins streaming packet capture on the WireGuard interface.
 	// Requires --enable-capture set at service install/reconfigure time.
 	StartCapture(ctx context.Context, in *StartCaptureRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CapturePacket], error)
@@ -135,6 +200,11 @@ type DaemonServiceClient interface {
 	// Logout disconnects from the network and deletes the peer from the management server
 	Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutResponse, error)
 	GetFeatures(ctx context.Context, in *GetFeaturesRequest, opts ...grpc.CallOption) (*GetFeaturesResponse, error)
+	// ListFeatureFlags returns the effective state of the client's feature flags
+	// and the source that decided each value.
+	ListFeatureFlags(ctx context.Context, in *ListFeatureFlagsRequest, opts ...grpc.CallOption) (*ListFeatureFlagsResponse, error)
+	// GetBuildInfo returns the build metadata of the daemon and its effective feature flags.
+	GetBuildInfo(ctx context.Context, in *GetBuildInfoRequest, opts ...grpc.CallOption) (*GetBuildInfoResponse, error)
 	// TriggerUpdate initiates installation of the pending enforced version.
 	// Called when the user clicks the install button in the UI (Mode 2 / enforced update).
 	TriggerUpdate(ctx context.Context, in *TriggerUpdateRequest, opts ...grpc.CallOption) (*TriggerUpdateResponse, error)
@@ -260,6 +330,16 @@ func (c *daemonServiceClient) GetConfig(ctx context.Context, in *GetConfigReques
 	return out, nil
 }
 
+func (c *daemonServiceClient) GetEffectiveConfig(ctx context.Context, in *GetEffectiveConfigRequest, opts ...grpc.CallOption) (*GetEffectiveConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetEffectiveConfigResponse)
+	err := c.cc.Invoke(ctx, DaemonService_GetEffectiveConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *daemonServiceClient) ListNetworks(ctx context.Context, in *ListNetworksRequest, opts ...grpc.CallOption) (*ListNetworksResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListNetworksResponse)
@@ -310,6 +390,44 @@ func (c *daemonServiceClient) DebugBundle(ctx context.Context, in *DebugBundleRe
 	return out, nil
 }
 
+func (c *daemonServiceClient) DebugBundleStream(ctx context.Context, in *DebugBundleRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DebugBundleChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DaemonService_ServiceDesc.Streams[1], DaemonService_DebugBundleStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DebugBundleRequest, DebugBundleChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DaemonService_DebugBundleStreamClient = grpc.ServerStreamingClient[DebugBundleChunk]
+
+func (c *daemonServiceClient) DebugBundleWithProgress(ctx context.Context, in *DebugBundleRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DebugBundleProgressEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DaemonService_ServiceDesc.Streams[2], DaemonService_DebugBundleWithProgress_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DebugBundleRequest, DebugBundleProgressEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DaemonService_DebugBundleWithProgressClient = grpc.ServerStreamingClient[DebugBundleProgressEvent]
+
 func (c *daemonServiceClient) GetLogLevel(ctx context.Context, in *GetLogLevelRequest, opts ...grpc.CallOption) (*GetLogLevelResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetLogLevelResponse)
@@ -330,6 +448,75 @@ func (c *daemonServiceClient) SetLogLevel(ctx context.Context, in *SetLogLevelRe
 	return out, nil
 }
 
+func (c *daemonServiceClient) RotateLog(ctx context.Context, in *RotateLogRequest, opts ...grpc.CallOption) (*RotateLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RotateLogResponse)
+	err := c.cc.Invoke(ctx, DaemonService_RotateLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) SetLogFormat(ctx context.Context, in *SetLogFormatRequest, opts ...grpc.CallOption) (*SetLogFormatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetLogFormatResponse)
+	err := c.cc.Invoke(ctx, DaemonService_SetLogFormat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) TailLogs(ctx context.Context, in *TailLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LogLine], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DaemonService_ServiceDesc.Streams[3], DaemonService_TailLogs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[TailLogsRequest, LogLine]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DaemonService_TailLogsClient = grpc.ServerStreamingClient[LogLine]
+
+func (c *daemonServiceClient) StageDebugLogs(ctx context.Context, in *StageDebugLogsRequest, opts ...grpc.CallOption) (*StageDebugLogsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StageDebugLogsResponse)
+	err := c.cc.Invoke(ctx, DaemonService_StageDebugLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) GetInterfaceStats(ctx context.Context, in *GetInterfaceStatsRequest, opts ...grpc.CallOption) (*GetInterfaceStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetInterfaceStatsResponse)
+	err := c.cc.Invoke(ctx, DaemonService_GetInterfaceStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) GetRuntimeStats(ctx context.Context, in *GetRuntimeStatsRequest, opts ...grpc.CallOption) (*GetRuntimeStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRuntimeStatsResponse)
+	err := c.cc.Invoke(ctx, DaemonService_GetRuntimeStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *daemonServiceClient) ListStates(ctx context.Context, in *ListStatesRequest, opts ...grpc.CallOption) (*ListStatesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListStatesResponse)
@@ -370,6 +557,16 @@ func (c *daemonServiceClient) SetSyncResponsePersistence(ctx context.Context, in
 	return out, nil
 }
 
+func (c *daemonServiceClient) GetSyncResponsePersistence(ctx context.Context, in *GetSyncResponsePersistenceRequest, opts ...grpc.CallOption) (*GetSyncResponsePersistenceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSyncResponsePersistenceResponse)
+	err := c.cc.Invoke(ctx, DaemonService_GetSyncResponsePersistence_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *daemonServiceClient) TracePacket(ctx context.Context, in *TracePacketRequest, opts ...grpc.CallOption) (*TracePacketResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(TracePacketResponse)
@@ -380,9 +577,99 @@ func (c *daemonServiceClient) TracePacket(ctx context.Context, in *TracePacketRe
 	return out, nil
 }
 
+func (c *daemonServiceClient) EvaluateACL(ctx context.Context, in *EvaluateACLRequest, opts ...grpc.CallOption) (*EvaluateACLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EvaluateACLResponse)
+	err := c.cc.Invoke(ctx, DaemonService_EvaluateACL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) GetDropStats(ctx context.Context, in *GetDropStatsRequest, opts ...grpc.CallOption) (*GetDropStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDropStatsResponse)
+	err := c.cc.Invoke(ctx, DaemonService_GetDropStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) RelayFailoverTest(ctx context.Context, in *RelayFailoverTestRequest, opts ...grpc.CallOption) (*RelayFailoverTestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RelayFailoverTestResponse)
+	err := c.cc.Invoke(ctx, DaemonService_RelayFailoverTest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) GetStartupTiming(ctx context.Context, in *GetStartupTimingRequest, opts ...grpc.CallOption) (*GetStartupTimingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStartupTimingResponse)
+	err := c.cc.Invoke(ctx, DaemonService_GetStartupTiming_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) ProbePeerMTU(ctx context.Context, in *ProbePeerMTURequest, opts ...grpc.CallOption) (*ProbePeerMTUResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProbePeerMTUResponse)
+	err := c.cc.Invoke(ctx, DaemonService_ProbePeerMTU_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) PeerProbe(ctx context.Context, in *PeerProbeRequest, opts ...grpc.CallOption) (*PeerProbeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PeerProbeResponse)
+	err := c.cc.Invoke(ctx, DaemonService_PeerProbe_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) BenchmarkPeer(ctx context.Context, in *BenchmarkPeerRequest, opts ...grpc.CallOption) (*BenchmarkPeerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BenchmarkPeerResponse)
+	err := c.cc.Invoke(ctx, DaemonService_BenchmarkPeer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) GetInterfaceConflicts(ctx context.Context, in *GetInterfaceConflictsRequest, opts ...grpc.CallOption) (*GetInterfaceConflictsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetInterfaceConflictsResponse)
+	err := c.cc.Invoke(ctx, DaemonService_GetInterfaceConflicts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) GetRouteConflicts(ctx context.Context, in *GetRouteConflictsRequest, opts ...grpc.CallOption) (*GetRouteConflictsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRouteConflictsResponse)
+	err := c.cc.Invoke(ctx, DaemonService_GetRouteConflicts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *daemonServiceClient) StartCapture(ctx context.Context, in *StartCaptureRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CapturePacket], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &DaemonService_ServiceDesc.Streams[1], DaemonService_StartCapture_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &DaemonService_ServiceDesc.Streams[4], DaemonService_StartCapture_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -421,7 +708,7 @@ func (c *daemonServiceClient) StopBundleCapture(ctx context.Context, in *StopBun
 
 func (c *daemonServiceClient) SubscribeEvents(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SystemEvent], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &DaemonService_ServiceDesc.Streams[2], DaemonService_SubscribeEvents_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &DaemonService_ServiceDesc.Streams[5], DaemonService_SubscribeEvents_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -548,6 +835,26 @@ func (c *daemonServiceClient) GetFeatures(ctx context.Context, in *GetFeaturesRe
 	return out, nil
 }
 
+func (c *daemonServiceClient) ListFeatureFlags(ctx context.Context, in *ListFeatureFlagsRequest, opts ...grpc.CallOption) (*ListFeatureFlagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFeatureFlagsResponse)
+	err := c.cc.Invoke(ctx, DaemonService_ListFeatureFlags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) GetBuildInfo(ctx context.Context, in *GetBuildInfoRequest, opts ...grpc.CallOption) (*GetBuildInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBuildInfoResponse)
+	err := c.cc.Invoke(ctx, DaemonService_GetBuildInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *daemonServiceClient) TriggerUpdate(ctx context.Context, in *TriggerUpdateRequest, opts ...grpc.CallOption) (*TriggerUpdateResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(TriggerUpdateResponse)
@@ -650,7 +957,7 @@ func (c *daemonServiceClient) GetInstallerResult(ctx context.Context, in *Instal
 
 func (c *daemonServiceClient) ExposeService(ctx context.Context, in *ExposeServiceRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExposeServiceEvent], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &DaemonService_ServiceDesc.Streams[3], DaemonService_ExposeService_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &DaemonService_ServiceDesc.Streams[6], DaemonService_ExposeService_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -699,6 +1006,9 @@ type DaemonServiceServer interface {
 	Down(context.Context, *DownRequest) (*DownResponse, error)
 	// GetConfig of the daemon.
 	GetConfig(context.Context, *GetConfigRequest) (*GetConfigResponse, error)
+	// GetEffectiveConfig returns the configuration the daemon is running with, after merging the
+	// profile file, environment, CLI input and MDM policy.
+	GetEffectiveConfig(context.Context, *GetEffectiveConfigRequest) (*GetEffectiveConfigResponse, error)
 	// List available networks
 	ListNetworks(context.Context, *ListNetworksRequest) (*ListNetworksResponse, error)
 	// Select specific routes
@@ -708,10 +1018,30 @@ type DaemonServiceServer interface {
 	ForwardingRules(context.Context, *EmptyRequest) (*ForwardingRulesResponse, error)
 	// DebugBundle creates a debug bundle
 	DebugBundle(context.Context, *DebugBundleRequest) (*DebugBundleResponse, error)
+	// DebugBundleStream creates a debug bundle and streams its content in chunks. The bundle is
+	// removed from the daemon host once sent. Uploading is not supported.
+	DebugBundleStream(*DebugBundleRequest, grpc.ServerStreamingServer[DebugBundleChunk]) error
+	// DebugBundleWithProgress creates a debug bundle like DebugBundle and streams the stage of the
+	// generation while it runs. The last event carries the response.
+	DebugBundleWithProgress(*DebugBundleRequest, grpc.ServerStreamingServer[DebugBundleProgressEvent]) error
 	// GetLogLevel gets the log level of the daemon
 	GetLogLevel(context.Context, *GetLogLevelRequest) (*GetLogLevelResponse, error)
 	// SetLogLevel sets the log level of the daemon
 	SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error)
+	// RotateLog closes the active daemon log file and starts a new one
+	RotateLog(context.Context, *RotateLogRequest) (*RotateLogResponse, error)
+	// SetLogFormat switches the daemon log between text and JSON lines until the daemon restarts.
+	SetLogFormat(context.Context, *SetLogFormatRequest) (*SetLogFormatResponse, error)
+	// TailLogs streams the daemon log lines as they are written until the client disconnects.
+	TailLogs(*TailLogsRequest, grpc.ServerStreamingServer[LogLine]) error
+	// StageDebugLogs copies the current daemon logs aside, to be merged into a later debug bundle.
+	StageDebugLogs(context.Context, *StageDebugLogsRequest) (*StageDebugLogsResponse, error)
+	// GetInterfaceStats returns the traffic counters of the WireGuard interface and the network
+	// interfaces, taken by "debug for" at the start of the session.
+	GetInterfaceStats(context.Context, *GetInterfaceStatsRequest) (*GetInterfaceStatsResponse, error)
+	// GetRuntimeStats returns the goroutine count, open file descriptors and memory of the daemon,
+	// sampled by "debug for" every second.
+	GetRuntimeStats(context.Context, *GetRuntimeStatsRequest) (*GetRuntimeStatsResponse, error)
 	// List all states
 	ListStates(context.Context, *ListStatesRequest) (*ListStatesResponse, error)
 	// Clean specific state or all states
@@ -720,7 +1050,28 @@ type DaemonServiceServer interface {
 	DeleteState(context.Context, *DeleteStateRequest) (*DeleteStateResponse, error)
 	// SetSyncResponsePersistence enables or disables sync response persistence
 	SetSyncResponsePersistence(context.Context, *SetSyncResponsePersistenceRequest) (*SetSyncResponsePersistenceResponse, error)
+	// GetSyncResponsePersistence returns whether sync response persistence is enabled
+	GetSyncResponsePersistence(context.Context, *GetSyncResponsePersistenceRequest) (*GetSyncResponsePersistenceResponse, error)
 	TracePacket(context.Context, *TracePacketRequest) (*TracePacketResponse, error)
+	// EvaluateACL evaluates a flow against the current ACL policy without changing the firewall.
+	EvaluateACL(context.Context, *EvaluateACLRequest) (*EvaluateACLResponse, error)
+	// GetDropStats returns the userspace filter's inbound packet drop counters by reason.
+	GetDropStats(context.Context, *GetDropStatsRequest) (*GetDropStatsResponse, error)
+	// RelayFailoverTest makes the home relay unreachable, waits for the daemon to fail over to
+	// another relay and reports the sequence and timing.
+	RelayFailoverTest(context.Context, *RelayFailoverTestRequest) (*RelayFailoverTestResponse, error)
+	// GetStartupTiming returns the duration of each phase of the latest client startup.
+	GetStartupTiming(context.Context, *GetStartupTimingRequest) (*GetStartupTimingResponse, error)
+	// ProbePeerMTU discovers the largest packet size that reaches each given peer through the tunnel.
+	ProbePeerMTU(context.Context, *ProbePeerMTURequest) (*ProbePeerMTUResponse, error)
+	// PeerProbe opens the connection to a peer if it is not up and pings the peer through the tunnel.
+	PeerProbe(context.Context, *PeerProbeRequest) (*PeerProbeResponse, error)
+	// BenchmarkPeer measures the throughput, round trip time and loss to a peer through the tunnel.
+	BenchmarkPeer(context.Context, *BenchmarkPeerRequest) (*BenchmarkPeerResponse, error)
+	// GetInterfaceConflicts reports host interface subnets overlapping the overlay network or routes.
+	GetInterfaceConflicts(context.Context, *GetInterfaceConflictsRequest) (*GetInterfaceConflictsResponse, error)
+	// GetRouteConflicts reports host routes overlapping the routes installed by NetBird.
+	GetRouteConflicts(context.Context, *GetRouteConflictsRequest) (*GetRouteConflictsResponse, error)
 	// StartCapture begins streaming packet capture on the WireGuard interface.
 	// Requires --enable-capture set at service install/reconfigure time.
 	StartCapture(*StartCaptureRequest, grpc.ServerStreamingServer[CapturePacket]) error
@@ -745,6 +1096,11 @@ type DaemonServiceServer interface {
 	// Logout disconnects from the network and deletes the peer from the management server
 	Logout(context.Context, *LogoutRequest) (*LogoutResponse, error)
 	GetFeatures(context.Context, *GetFeaturesRequest) (*GetFeaturesResponse, error)
+	// ListFeatureFlags returns the effective state of the client's feature flags
+	// and the source that decided each value.
+	ListFeatureFlags(context.Context, *ListFeatureFlagsRequest) (*ListFeatureFlagsResponse, error)
+	// GetBuildInfo returns the build metadata of the daemon and its effective feature flags.
+	GetBuildInfo(context.Context, *GetBuildInfoRequest) (*GetBuildInfoResponse, error)
 	// TriggerUpdate initiates installation of the pending enforced version.
 	// Called when the user clicks the install button in the UI (Mode 2 / enforced update).
 	TriggerUpdate(context.Context, *TriggerUpdateRequest) (*TriggerUpdateResponse, error)
@@ -812,6 +1168,9 @@ func (UnimplementedDaemonServiceServer) Down(context.Context, *DownRequest) (*Do
 func (UnimplementedDaemonServiceServer) GetConfig(context.Context, *GetConfigRequest) (*GetConfigResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetConfig not implemented")
 }
+func (UnimplementedDaemonServiceServer) GetEffectiveConfig(context.Context, *GetEffectiveConfigRequest) (*GetEffectiveConfigResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetEffectiveConfig not implemented")
+}
 func (UnimplementedDaemonServiceServer) ListNetworks(context.Context, *ListNetworksRequest) (*ListNetworksResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListNetworks not implemented")
 }
@@ -827,12 +1186,36 @@ func (UnimplementedDaemonServiceServer) ForwardingRules(context.Context, *EmptyR
 func (UnimplementedDaemonServiceServer) DebugBundle(context.Context, *DebugBundleRequest) (*DebugBundleResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DebugBundle not implemented")
 }
+func (UnimplementedDaemonServiceServer) DebugBundleStream(*DebugBundleRequest, grpc.ServerStreamingServer[DebugBundleChunk]) error {
+	return status.Error(codes.Unimplemented, "method DebugBundleStream not implemented")
+}
+func (UnimplementedDaemonServiceServer) DebugBundleWithProgress(*DebugBundleRequest, grpc.ServerStreamingServer[DebugBundleProgressEvent]) error {
+	return status.Error(codes.Unimplemented, "method DebugBundleWithProgress not implemented")
+}
 func (UnimplementedDaemonServiceServer) GetLogLevel(context.Context, *GetLogLevelRequest) (*GetLogLevelResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetLogLevel not implemented")
 }
 func (UnimplementedDaemonServiceServer) SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method SetLogLevel not implemented")
 }
+func (UnimplementedDaemonServiceServer) RotateLog(context.Context, *RotateLogRequest) (*RotateLogResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RotateLog not implemented")
+}
+func (UnimplementedDaemonServiceServer) SetLogFormat(context.Context, *SetLogFormatRequest) (*SetLogFormatResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetLogFormat not implemented")
+}
+func (UnimplementedDaemonServiceServer) TailLogs(*TailLogsRequest, grpc.ServerStreamingServer[LogLine]) error {
+	return status.Error(codes.Unimplemented, "method TailLogs not implemented")
+}
+func (UnimplementedDaemonServiceServer) StageDebugLogs(context.Context, *StageDebugLogsRequest) (*StageDebugLogsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method StageDebugLogs not implemented")
+}
+func (UnimplementedDaemonServiceServer) GetInterfaceStats(context.Context, *GetInterfaceStatsRequest) (*GetInterfaceStatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetInterfaceStats not implemented")
+}
+func (UnimplementedDaemonServiceServer) GetRuntimeStats(context.Context, *GetRuntimeStatsRequest) (*GetRuntimeStatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRuntimeStats not implemented")
+}
 func (UnimplementedDaemonServiceServer) ListStates(context.Context, *ListStatesRequest) (*ListStatesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListStates not implemented")
 }
@@ -845,9 +1228,39 @@ func (UnimplementedDaemonServiceServer) DeleteState(context.Context, *DeleteStat
 func (UnimplementedDaemonServiceServer) SetSyncResponsePersistence(context.Context, *SetSyncResponsePersistenceRequest) (*SetSyncResponsePersistenceResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method SetSyncResponsePersistence not implemented")
 }
+func (UnimplementedDaemonServiceServer) GetSyncResponsePersistence(context.Context, *GetSyncResponsePersistenceRequest) (*GetSyncResponsePersistenceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSyncResponsePersistence not implemented")
+}
 func (UnimplementedDaemonServiceServer) TracePacket(context.Context, *TracePacketRequest) (*TracePacketResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method TracePacket not implemented")
 }
+func (UnimplementedDaemonServiceServer) EvaluateACL(context.Context, *EvaluateACLRequest) (*EvaluateACLResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method EvaluateACL not implemented")
+}
+func (UnimplementedDaemonServiceServer) GetDropStats(context.Context, *GetDropStatsRequest) (*GetDropStatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDropStats not implemented")
+}
+func (UnimplementedDaemonServiceServer) RelayFailoverTest(context.Context, *RelayFailoverTestRequest) (*RelayFailoverTestResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RelayFailoverTest not implemented")
+}
+func (UnimplementedDaemonServiceServer) GetStartupTiming(context.Context, *GetStartupTimingRequest) (*GetStartupTimingResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetStartupTiming not implemented")
+}
+func (UnimplementedDaemonServiceServer) ProbePeerMTU(context.Context, *ProbePeerMTURequest) (*ProbePeerMTUResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ProbePeerMTU not implemented")
+}
+func (UnimplementedDaemonServiceServer) PeerProbe(context.Context, *PeerProbeRequest) (*PeerProbeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PeerProbe not implemented")
+}
+func (UnimplementedDaemonServiceServer) BenchmarkPeer(context.Context, *BenchmarkPeerRequest) (*BenchmarkPeerResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BenchmarkPeer not implemented")
+}
+func (UnimplementedDaemonServiceServer) GetInterfaceConflicts(context.Context, *GetInterfaceConflictsRequest) (*GetInterfaceConflictsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetInterfaceConflicts not implemented")
+}
+func (UnimplementedDaemonServiceServer) GetRouteConflicts(context.Context, *GetRouteConflictsRequest) (*GetRouteConflictsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRouteConflicts not implemented")
+}
 func (UnimplementedDaemonServiceServer) StartCapture(*StartCaptureRequest, grpc.ServerStreamingServer[CapturePacket]) error {
 	return status.Error(codes.Unimplemented, "method StartCapture not implemented")
 }
@@ -893,6 +1306,12 @@ func (UnimplementedDaemonServiceServer) Logout(context.Context, *LogoutRequest)
 func (UnimplementedDaemonServiceServer) GetFeatures(context.Context, *GetFeaturesRequest) (*GetFeaturesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetFeatures not implemented")
 }
+func (UnimplementedDaemonServiceServer) ListFeatureFlags(context.Context, *ListFeatureFlagsRequest) (*ListFeatureFlagsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListFeatureFlags not implemented")
+}
+func (UnimplementedDaemonServiceServer) GetBuildInfo(context.Context, *GetBuildInfoRequest) (*GetBuildInfoResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBuildInfo not implemented")
+}
 func (UnimplementedDaemonServiceServer) TriggerUpdate(context.Context, *TriggerUpdateRequest) (*TriggerUpdateResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method TriggerUpdate not implemented")
 }
@@ -1069,6 +1488,24 @@ func _DaemonService_GetConfig_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DaemonService_GetEffectiveConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEffectiveConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetEffectiveConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_GetEffectiveConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetEffectiveConfig(ctx, req.(*GetEffectiveConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DaemonService_ListNetworks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListNetworksRequest)
 	if err := dec(in); err != nil {
@@ -1159,6 +1596,28 @@ func _DaemonService_DebugBundle_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DaemonService_DebugBundleStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DebugBundleRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaemonServiceServer).DebugBundleStream(m, &grpc.GenericServerStream[DebugBundleRequest, DebugBundleChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DaemonService_DebugBundleStreamServer = grpc.ServerStreamingServer[DebugBundleChunk]
+
+func _DaemonService_DebugBundleWithProgress_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DebugBundleRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaemonServiceServer).DebugBundleWithProgress(m, &grpc.GenericServerStream[DebugBundleRequest, DebugBundleProgressEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DaemonService_DebugBundleWithProgressServer = grpc.ServerStreamingServer[DebugBundleProgressEvent]
+
 func _DaemonService_GetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetLogLevelRequest)
 	if err := dec(in); err != nil {
@@ -1195,6 +1654,107 @@ func _DaemonService_SetLogLevel_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DaemonService_RotateLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).RotateLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_RotateLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).RotateLog(ctx, req.(*RotateLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_SetLogFormat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLogFormatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).SetLogFormat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_SetLogFormat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).SetLogFormat(ctx, req.(*SetLogFormatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_TailLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TailLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaemonServiceServer).TailLogs(m, &grpc.GenericServerStream[TailLogsRequest, LogLine]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DaemonService_TailLogsServer = grpc.ServerStreamingServer[LogLine]
+
+func _DaemonService_StageDebugLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StageDebugLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).StageDebugLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_StageDebugLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).StageDebugLogs(ctx, req.(*StageDebugLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_GetInterfaceStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInterfaceStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetInterfaceStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_GetInterfaceStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetInterfaceStats(ctx, req.(*GetInterfaceStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_GetRuntimeStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRuntimeStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetRuntimeStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_GetRuntimeStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetRuntimeStats(ctx, req.(*GetRuntimeStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DaemonService_ListStates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListStatesRequest)
 	if err := dec(in); err != nil {
@@ -1267,6 +1827,24 @@ func _DaemonService_SetSyncResponsePersistence_Handler(srv interface{}, ctx cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DaemonService_GetSyncResponsePersistence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSyncResponsePersistenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetSyncResponsePersistence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_GetSyncResponsePersistence_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetSyncResponsePersistence(ctx, req.(*GetSyncResponsePersistenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DaemonService_TracePacket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(TracePacketRequest)
 	if err := dec(in); err != nil {
@@ -1285,6 +1863,168 @@ func _DaemonService_TracePacket_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DaemonService_EvaluateACL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateACLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).EvaluateACL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_EvaluateACL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).EvaluateACL(ctx, req.(*EvaluateACLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_GetDropStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDropStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetDropStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_GetDropStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetDropStats(ctx, req.(*GetDropStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_RelayFailoverTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RelayFailoverTestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).RelayFailoverTest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_RelayFailoverTest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).RelayFailoverTest(ctx, req.(*RelayFailoverTestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_GetStartupTiming_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStartupTimingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetStartupTiming(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_GetStartupTiming_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetStartupTiming(ctx, req.(*GetStartupTimingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_ProbePeerMTU_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProbePeerMTURequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).ProbePeerMTU(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_ProbePeerMTU_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).ProbePeerMTU(ctx, req.(*ProbePeerMTURequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_PeerProbe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeerProbeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).PeerProbe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_PeerProbe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).PeerProbe(ctx, req.(*PeerProbeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_BenchmarkPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BenchmarkPeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).BenchmarkPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_BenchmarkPeer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).BenchmarkPeer(ctx, req.(*BenchmarkPeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_GetInterfaceConflicts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInterfaceConflictsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetInterfaceConflicts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_GetInterfaceConflicts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetInterfaceConflicts(ctx, req.(*GetInterfaceConflictsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_GetRouteConflicts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRouteConflictsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetRouteConflicts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_GetRouteConflicts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetRouteConflicts(ctx, req.(*GetRouteConflictsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DaemonService_StartCapture_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(StartCaptureRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -1541,6 +2281,42 @@ func _DaemonService_GetFeatures_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DaemonService_ListFeatureFlags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFeatureFlagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).ListFeatureFlags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_ListFeatureFlags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).ListFeatureFlags(ctx, req.(*ListFeatureFlagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_GetBuildInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBuildInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetBuildInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaemonService_GetBuildInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetBuildInfo(ctx, req.(*GetBuildInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DaemonService_TriggerUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(TriggerUpdateRequest)
 	if err := dec(in); err != nil {
@@ -1781,6 +2557,10 @@ var DaemonService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetConfig",
 			Handler:    _DaemonService_GetConfig_Handler,
 		},
+		{
+			MethodName: "GetEffectiveConfig",
+			Handler:    _DaemonService_GetEffectiveConfig_Handler,
+		},
 		{
 			MethodName: "ListNetworks",
 			Handler:    _DaemonService_ListNetworks_Handler,
@@ -1809,6 +2589,26 @@ var DaemonService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SetLogLevel",
 			Handler:    _DaemonService_SetLogLevel_Handler,
 		},
+		{
+			MethodName: "RotateLog",
+			Handler:    _DaemonService_RotateLog_Handler,
+		},
+		{
+			MethodName: "SetLogFormat",
+			Handler:    _DaemonService_SetLogFormat_Handler,
+		},
+		{
+			MethodName: "StageDebugLogs",
+			Handler:    _DaemonService_StageDebugLogs_Handler,
+		},
+		{
+			MethodName: "GetInterfaceStats",
+			Handler:    _DaemonService_GetInterfaceStats_Handler,
+		},
+		{
+			MethodName: "GetRuntimeStats",
+			Handler:    _DaemonService_GetRuntimeStats_Handler,
+		},
 		{
 			MethodName: "ListStates",
 			Handler:    _DaemonService_ListStates_Handler,
@@ -1825,10 +2625,50 @@ var DaemonService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SetSyncResponsePersistence",
 			Handler:    _DaemonService_SetSyncResponsePersistence_Handler,
 		},
+		{
+			MethodName: "GetSyncResponsePersistence",
+			Handler:    _DaemonService_GetSyncResponsePersistence_Handler,
+		},
 		{
 			MethodName: "TracePacket",
 			Handler:    _DaemonService_TracePacket_Handler,
 		},
+		{
+			MethodName: "EvaluateACL",
+			Handler:    _DaemonService_EvaluateACL_Handler,
+		},
+		{
+			MethodName: "GetDropStats",
+			Handler:    _DaemonService_GetDropStats_Handler,
+		},
+		{
+			MethodName: "RelayFailoverTest",
+			Handler:    _DaemonService_RelayFailoverTest_Handler,
+		},
+		{
+			MethodName: "GetStartupTiming",
+			Handler:    _DaemonService_GetStartupTiming_Handler,
+		},
+		{
+			MethodName: "ProbePeerMTU",
+			Handler:    _DaemonService_ProbePeerMTU_Handler,
+		},
+		{
+			MethodName: "PeerProbe",
+			Handler:    _DaemonService_PeerProbe_Handler,
+		},
+		{
+			MethodName: "BenchmarkPeer",
+			Handler:    _DaemonService_BenchmarkPeer_Handler,
+		},
+		{
+			MethodName: "GetInterfaceConflicts",
+			Handler:    _DaemonService_GetInterfaceConflicts_Handler,
+		},
+		{
+			MethodName: "GetRouteConflicts",
+			Handler:    _DaemonService_GetRouteConflicts_Handler,
+		},
 		{
 			MethodName: "StartBundleCapture",
 			Handler:    _DaemonService_StartBundleCapture_Handler,
@@ -1881,6 +2721,14 @@ var DaemonService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetFeatures",
 			Handler:    _DaemonService_GetFeatures_Handler,
 		},
+		{
+			MethodName: "ListFeatureFlags",
+			Handler:    _DaemonService_ListFeatureFlags_Handler,
+		},
+		{
+			MethodName: "GetBuildInfo",
+			Handler:    _DaemonService_GetBuildInfo_Handler,
+		},
 		{
 			MethodName: "TriggerUpdate",
 			Handler:    _DaemonService_TriggerUpdate_Handler,
@@ -1932,6 +2780,21 @@ var DaemonService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _DaemonService_SubscribeStatus_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "DebugBundleStream",
+			Handler:       _DaemonService_DebugBundleStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "DebugBundleWithProgress",
+			Handler:       _DaemonService_DebugBundleWithProgress_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "TailLogs",
+			Handler:       _DaemonService_TailLogs_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "StartCapture",
 			Handler:       _DaemonService_StartCapture_Handler,
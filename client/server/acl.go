@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	fw "github.com/netbirdio/netbird/client/firewall/manager"
+	"github.com/netbirdio/netbird/client/internal/acl"
+	"github.com/netbirdio/netbird/client/internal/debug"
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+// EvaluateACL evaluates a flow against the ACL policy of the running engine without changing
+// the firewall.
+func (s *Server) EvaluateACL(_ context.Context, req *proto.EvaluateACLRequest) (*proto.EvaluateACLResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	decision, err := s.evaluateACL(req.GetQuery())
+	if err != nil {
+		return nil, err
+	}
+	return &proto.EvaluateACLResponse{
+		Allowed: decision.Allowed,
+		Rule:    decision.Rule,
+		Reason:  decision.Reason,
+	}, nil
+}
+
+// bundleACLQueries evaluates the --acl-query flows of a debug bundle. Queries that cannot be
+// evaluated are kept with their error.
+func (s *Server) bundleACLQueries(queries []*proto.ACLQuery) []debug.ACLQuery {
+	var results []debug.ACLQuery
+	for _, q := range queries {
+		result := debug.ACLQuery{
+			Source:      q.GetSource(),
+			Destination: q.GetDestination(),
+			Protocol:    q.GetProtocol(),
+			Port:        q.GetPort(),
+		}
+		decision, err := s.evaluateACL(q)
+		if err != nil {
+			result.Error = status.Convert(err).Message()
+		} else {
+			result.Allowed = decision.Allowed
+			result.Rule = decision.Rule
+			result.Reason = decision.Reason
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// evaluateACL resolves and evaluates a query. The caller holds s.mutex.
+func (s *Server) evaluateACL(q *proto.ACLQuery) (acl.Decision, error) {
+	if s.connectClient == nil {
+		return acl.Decision{}, status.Error(codes.FailedPrecondition, "client is not connected")
+	}
+	engine := s.connectClient.Engine()
+	if engine == nil {
+		return acl.Decision{}, status.Error(codes.FailedPrecondition, "engine is not running")
+	}
+
+	src, dst, err := s.resolveTraceAddresses(q.GetSource(), q.GetDestination(), engine)
+	if err != nil {
+		return acl.Decision{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	protocol, err := parseACLProtocol(q.GetProtocol())
+	if err != nil {
+		return acl.Decision{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if q.GetPort() > 65535 {
+		return acl.Decision{}, status.Errorf(codes.InvalidArgument, "invalid port %d", q.GetPort())
+	}
+
+	decision, err := engine.EvaluateACL(acl.Query{
+		Source:      src,
+		Destination: dst,
+		Protocol:    protocol,
+		Port:        uint16(q.GetPort()),
+	})
+	if errors.Is(err, acl.ErrNoPolicy) {
+		return acl.Decision{}, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return acl.Decision{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return decision, nil
+}
+
+func parseACLProtocol(protocol string) (fw.Protocol, error) {
+	switch p := fw.Protocol(protocol); p {
+	case fw.ProtocolTCP, fw.ProtocolUDP, fw.ProtocolICMP, fw.ProtocolALL:
+		return p, nil
+	default:
+		return "", fmt.Errorf("invalid protocol %q, use tcp, udp, icmp or all", protocol)
+	}
+}
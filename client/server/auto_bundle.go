@@ -0,0 +1,178 @@
+//go:build !android && !ios
+
+package server
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netbirdio/netbird/client/internal/debug"
+	"github.com/netbirdio/netbird/client/internal/peer"
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+const (
+	// DefaultAutoBundleWindow and DefaultAutoBundleMinInterval apply when the durations are unset.
+	DefaultAutoBundleWindow      = 30 * time.Minute
+	DefaultAutoBundleMinInterval = 6 * time.Hour
+
+	autoBundlePollInterval = 5 * time.Second
+	// autoBundleLabel names the bundles netbird.debug.reconnect-failures.*.zip.
+	autoBundleLabel = "reconnect-failures"
+)
+
+// AutoBundleSettings configures the debug bundles the daemon creates on its own after repeated
+// reconnect failures, e.g. on unattended devices whose logs rotate away before someone notices
+// the outage. They are set with "netbird service install --auto-bundle-failures".
+type AutoBundleSettings struct {
+	// Failures is the number of consecutive reconnect failures within Window that create a
+	// bundle. Zero disables the bundles.
+	Failures int
+	Window   time.Duration
+	// MinInterval is the least time between two bundles.
+	MinInterval time.Duration
+	OutputDir   string
+	UploadURL   string
+	Anonymize   bool
+}
+
+// normalized returns the settings with the defaults for unset durations, the upload URL
+// normalized and the output directory resolved. Invalid values are logged and dropped.
+func (s AutoBundleSettings) normalized() AutoBundleSettings {
+	if s.Window <= 0 {
+		s.Window = DefaultAutoBundleWindow
+	}
+	if s.MinInterval <= 0 {
+		s.MinInterval = DefaultAutoBundleMinInterval
+	}
+
+	if s.UploadURL != "" {
+		uploadURL, err := debug.NormalizeUploadURL(s.UploadURL)
+		if err != nil {
+			log.Warnf("ignoring the auto bundle upload URL, the bundles are not uploaded: %v", err)
+			uploadURL = ""
+		}
+		s.UploadURL = uploadURL
+	}
+
+	if s.OutputDir != "" {
+		outputDir, err := debug.ResolveOutputDir(s.OutputDir)
+		if err != nil {
+			log.Warnf("ignoring the auto bundle output directory: %v", err)
+		}
+		s.OutputDir = outputDir
+	}
+	return s
+}
+
+func (s AutoBundleSettings) String() string {
+	outputDir := s.OutputDir
+	if outputDir == "" {
+		outputDir = os.TempDir()
+	}
+	upload := "none"
+	if s.UploadURL != "" {
+		upload = s.UploadURL
+	}
+	return fmt.Sprintf("failures=%d window=%s min-interval=%s anonymize=%t output-dir=%s upload=%s",
+		s.Failures, s.Window, s.MinInterval, s.Anonymize, outputDir, upload)
+}
+
+// reconnectFailureTrigger decides from the failure counter of the client retry loop when a
+// bundle is due.
+type reconnectFailureTrigger struct {
+	settings AutoBundleSettings
+	// attempts is the failure counter seen last.
+	attempts int
+	// failures are the times of the consecutive failures within the window, oldest first.
+	failures   []time.Time
+	lastBundle time.Time
+}
+
+// observe takes the current retry state and reports whether a bundle is due. The retry loop
+// resets its counter when the client connects, which ends a series of consecutive failures.
+func (t *reconnectFailureTrigger) observe(state peer.RetryState, now time.Time) bool {
+	if state.Attempts < t.attempts {
+		t.attempts = 0
+		t.failures = nil
+	}
+	// the attempts since the last poll are the last ones recorded
+	if missed := state.Attempts - t.attempts; missed > 0 {
+		recent := state.RecentAttempts
+		if len(recent) > missed {
+			recent = recent[len(recent)-missed:]
+		}
+		t.failures = append(t.failures, recent...)
+		t.attempts = state.Attempts
+	}
+
+	cutoff := now.Add(-t.settings.Window)
+	for len(t.failures) > 0 && t.failures[0].Before(cutoff) {
+		t.failures = t.failures[1:]
+	}
+
+	if len(t.failures) < t.settings.Failures {
+		return false
+	}
+	if !t.lastBundle.IsZero() && now.Sub(t.lastBundle) < t.settings.MinInterval {
+		return false
+	}
+	t.lastBundle = now
+	t.failures = nil
+	return true
+}
+
+// EnableAutoBundle watches the reconnect failures and creates a debug bundle when they pile up.
+// It does nothing if settings.Failures is zero.
+func (s *Server) EnableAutoBundle(settings AutoBundleSettings) {
+	if settings.Failures <= 0 {
+		return
+	}
+	settings = settings.normalized()
+	log.Infof("debug bundles after reconnect failures enabled with %s", settings)
+
+	go func() {
+		trigger := &reconnectFailureTrigger{settings: settings}
+		ticker := time.NewTicker(autoBundlePollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.rootCtx.Done():
+				return
+			case now := <-ticker.C:
+				state := s.statusRecorder.GetRetryState()
+				if trigger.observe(state, now) {
+					s.createAutoBundle(settings, state)
+				}
+			}
+		}
+	}()
+}
+
+// createAutoBundle creates a bundle like "netbird debug bundle" would, without the packet
+// capture a user may be running. The upload server is not checked first, it may be unreachable
+// for the same reason the client is.
+func (s *Server) createAutoBundle(settings AutoBundleSettings, state peer.RetryState) {
+	log.Warnf("%d reconnect failures within %s, last error: %s. Creating a debug bundle",
+		settings.Failures, settings.Window, state.LastError)
+
+	req := &proto.DebugBundleRequest{
+		Anonymize:          settings.Anonymize,
+		SystemInfo:         true,
+		LogFileCount:       1,
+		Label:              autoBundleLabel,
+		OutputDir:          settings.OutputDir,
+		UploadURL:          settings.UploadURL,
+		SkipUploadURLCheck: true,
+		ExcludeCapture:     true,
+	}
+	resp, err := s.debugBundle(s.rootCtx, req, nil)
+	if err != nil {
+		log.Errorf("failed to create debug bundle after reconnect failures: %v", err)
+		return
+	}
+	log.Infof("created debug bundle after reconnect failures at %s", resp.GetPath())
+}
@@ -0,0 +1,74 @@
+//go:build !android && !ios
+
+package server
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+)
+
+func TestReconnectFailureTrigger(t *testing.T) {
+	start := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	trigger := &reconnectFailureTrigger{settings: AutoBundleSettings{
+		Failures:    3,
+		Window:      10 * time.Minute,
+		MinInterval: time.Hour,
+	}}
+	failed := func(attempts int, at time.Time) bool {
+		return trigger.observe(peer.RetryState{Attempts: attempts, LastAttempt: at, RecentAttempts: []time.Time{at}}, at)
+	}
+
+	assert.False(t, failed(1, start))
+	assert.False(t, failed(2, start.Add(time.Minute)))
+	assert.False(t, failed(0, start.Add(2*time.Minute)), "connecting ends the consecutive failures")
+	assert.False(t, failed(1, start.Add(3*time.Minute)))
+	assert.False(t, failed(2, start.Add(4*time.Minute)))
+	assert.True(t, failed(3, start.Add(5*time.Minute)))
+
+	assert.False(t, failed(6, start.Add(6*time.Minute)), "bundles are rate limited")
+	assert.False(t, failed(7, start.Add(20*time.Minute)), "failures before the window do not count")
+	assert.False(t, failed(8, start.Add(70*time.Minute)))
+	assert.False(t, failed(9, start.Add(71*time.Minute)))
+	assert.True(t, failed(10, start.Add(72*time.Minute)))
+}
+
+func TestReconnectFailureTriggerMissedPolls(t *testing.T) {
+	start := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	trigger := &reconnectFailureTrigger{settings: AutoBundleSettings{Failures: 3, Window: time.Minute}}
+
+	state := peer.RetryState{Attempts: 4, LastAttempt: start, RecentAttempts: []time.Time{
+		start.Add(-2 * time.Minute), start.Add(-40 * time.Second), start.Add(-20 * time.Second), start,
+	}}
+	assert.True(t, trigger.observe(state, start), "failures between two polls count")
+
+	trigger = &reconnectFailureTrigger{settings: AutoBundleSettings{Failures: 3, Window: time.Minute}}
+	state.RecentAttempts = []time.Time{
+		start.Add(-3 * time.Minute), start.Add(-2 * time.Minute), start.Add(-90 * time.Second), start,
+	}
+	assert.False(t, trigger.observe(state, start), "missed failures keep the time they happened at")
+}
+
+func TestAutoBundleSettingsNormalized(t *testing.T) {
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+
+	settings := AutoBundleSettings{Failures: 5, Window: 15 * time.Minute, OutputDir: dir, Anonymize: true}.normalized()
+	assert.Equal(t, AutoBundleSettings{
+		Failures:    5,
+		Window:      15 * time.Minute,
+		MinInterval: DefaultAutoBundleMinInterval,
+		OutputDir:   dir,
+		Anonymize:   true,
+	}, settings)
+
+	settings = AutoBundleSettings{Failures: 5, OutputDir: "relative", UploadURL: "ftp://upload"}.normalized()
+	assert.Empty(t, settings.OutputDir, "an invalid output directory is dropped")
+	assert.Empty(t, settings.UploadURL, "an invalid upload URL is dropped")
+	assert.Equal(t, DefaultAutoBundleWindow, settings.Window)
+}
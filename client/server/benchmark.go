@@ -0,0 +1,111 @@
+//go:build !android && !ios
+
+package server
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	gstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/netbirdio/netbird/client/iface/netstack"
+	"github.com/netbirdio/netbird/client/internal/benchmark"
+	"github.com/netbirdio/netbird/client/internal/peer"
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+// BenchmarkPeer opens the connection to a peer if it is not up and measures the throughput, round
+// trip time and loss to the benchmark responder of the peer. One benchmark runs at a time. Peers
+// without a responder fail with FailedPrecondition.
+func (s *Server) BenchmarkPeer(ctx context.Context, req *proto.BenchmarkPeerRequest) (*proto.BenchmarkPeerResponse, error) {
+	duration := benchmark.DefaultDuration
+	if req.GetDuration() != nil {
+		duration = req.GetDuration().AsDuration()
+	}
+	if duration <= 0 || duration > benchmark.MaxDuration {
+		return nil, gstatus.Errorf(codes.InvalidArgument, "duration must be positive and at most %s", benchmark.MaxDuration)
+	}
+
+	s.mutex.Lock()
+	connectClient := s.connectClient
+	s.mutex.Unlock()
+
+	if connectClient == nil {
+		return nil, gstatus.Error(codes.FailedPrecondition, "client is not connected")
+	}
+	engine := connectClient.Engine()
+	if engine == nil {
+		return nil, gstatus.Error(codes.FailedPrecondition, "engine is not running")
+	}
+	if netstack.IsEnabled() {
+		return nil, gstatus.Error(codes.FailedPrecondition, "benchmarks are not supported in netstack mode")
+	}
+
+	st, ok := findPeerState(s.statusRecorder.GetFullStatus().Peers, req.GetPeer())
+	if !ok {
+		return nil, gstatus.Errorf(codes.NotFound, "peer %s not found", req.GetPeer())
+	}
+	addr, err := netip.ParseAddr(strings.Split(st.IP, "/")[0])
+	if err != nil || !addr.Is4() {
+		return nil, gstatus.Errorf(codes.FailedPrecondition, "peer %s has no IPv4 overlay address", req.GetPeer())
+	}
+
+	if !s.benchmarkMu.TryLock() {
+		return nil, gstatus.Error(codes.Aborted, "another benchmark is running")
+	}
+	defer s.benchmarkMu.Unlock()
+
+	if st.ConnStatus != peer.StatusConnected {
+		if !engine.ActivatePeer(ctx, st.PubKey) {
+			return nil, gstatus.Errorf(codes.Unavailable, "peer %s has no connection in the engine", req.GetPeer())
+		}
+		if _, err := s.waitPeerConnected(ctx, st.PubKey, defaultPeerProbeTimeout); err != nil {
+			return nil, gstatus.Errorf(codes.Unavailable, "connect to peer %s: %v", req.GetPeer(), err)
+		}
+	}
+	before, _ := s.statusRecorder.GetPeer(st.PubKey)
+
+	result, err := benchmark.Run(ctx, netip.AddrPortFrom(addr, benchmark.Port), duration)
+	switch {
+	case errors.Is(err, benchmark.ErrNotSupported):
+		return nil, gstatus.Errorf(codes.FailedPrecondition, "%v, it needs a NetBird version with the benchmark responder that does not block inbound connections", err)
+	case errors.Is(err, benchmark.ErrBusy):
+		return nil, gstatus.Error(codes.Aborted, err.Error())
+	case err != nil:
+		return nil, gstatus.Errorf(codes.Unavailable, "benchmark peer %s: %v", req.GetPeer(), err)
+	}
+
+	after, err := s.statusRecorder.GetPeer(st.PubKey)
+	if err != nil {
+		after = before
+	}
+
+	name := st.FQDN
+	if name == "" {
+		name = st.PubKey
+	}
+	log.Infof("benchmark to %s: %.1f Mbps up, %.1f Mbps down, relayed %t", name, result.Upload.Mbps(), result.Download.Mbps(), after.Relayed)
+
+	resp := &proto.BenchmarkPeerResponse{
+		Peer:             name,
+		Ip:               addr.String(),
+		Relayed:          after.Relayed,
+		RelayAddress:     after.RelayServerAddress,
+		PathChanged:      before.Relayed != after.Relayed,
+		UploadBytes:      uint64(result.Upload.Bytes),
+		UploadDuration:   durationpb.New(result.Upload.Duration),
+		DownloadBytes:    uint64(result.Download.Bytes),
+		DownloadDuration: durationpb.New(result.Download.Duration),
+		EchoLost:         uint32(result.EchoLost),
+		MaxBytes:         benchmark.MaxBytes,
+	}
+	for _, rtt := range result.RTTs {
+		resp.Rtts = append(resp.Rtts, durationpb.New(rtt))
+	}
+	return resp, nil
+}
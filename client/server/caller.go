@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+	"net"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	grpcpeer "google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// callerAuthInfo holds the credentials of the process connected to the daemon unix socket.
+type callerAuthInfo struct {
+	credentials.CommonAuthInfo
+	known bool
+	uid   int
+	pid   int
+}
+
+func (callerAuthInfo) AuthType() string {
+	return "peercred"
+}
+
+// privileged reports whether the caller runs as root outside of the daemon. Calls from the daemon
+// itself come from the JSON gateway or the debug bundle HTTP endpoint on behalf of any client of
+// the JSON socket.
+func (i callerAuthInfo) privileged() bool {
+	return i.known && i.uid == 0 && i.pid != os.Getpid()
+}
+
+type callerCredentials struct {
+	credentials.TransportCredentials
+}
+
+// NewCallerCredentials returns the transport credentials of the daemon socket. Like insecure
+// credentials they don't encrypt, but they record the user of unix socket connections for the
+// RPC options restricted to root. Connections on other sockets have no known user.
+func NewCallerCredentials() credentials.TransportCredentials {
+	return callerCredentials{TransportCredentials: insecure.NewCredentials()}
+}
+
+func (c callerCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	info := callerAuthInfo{CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.NoSecurity}}
+	if unixConn, ok := conn.(*net.UnixConn); ok {
+		uid, pid, err := peerCredentials(unixConn)
+		if err != nil {
+			log.Debugf("failed to read the credentials of the daemon client: %v", err)
+		} else {
+			info.known, info.uid, info.pid = true, uid, pid
+		}
+	}
+	return conn, info, nil
+}
+
+func (c callerCredentials) Clone() credentials.TransportCredentials {
+	return callerCredentials{TransportCredentials: c.TransportCredentials.Clone()}
+}
+
+// checkPrivilegedCaller fails unless the RPC comes from root on the daemon unix socket. The
+// option names what is restricted in the error.
+func checkPrivilegedCaller(ctx context.Context, option string) error {
+	if p, ok := grpcpeer.FromContext(ctx); ok {
+		if info, ok := p.AuthInfo.(callerAuthInfo); ok && info.privileged() {
+			return nil
+		}
+	}
+	return status.Errorf(codes.PermissionDenied, "%s requires running the command as root on the daemon unix socket", option)
+}
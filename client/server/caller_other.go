@@ -0,0 +1,13 @@
+//go:build !linux && !darwin
+
+package server
+
+import (
+	"errors"
+	"net"
+)
+
+// peerCredentials is not supported here, RPC options restricted to root are refused.
+func peerCredentials(*net.UnixConn) (uid, pid int, err error) {
+	return 0, 0, errors.New("peer credentials are not supported on this platform")
+}
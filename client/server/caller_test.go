@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	grpcpeer "google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func rootCallerContext() context.Context {
+	return grpcpeer.NewContext(context.Background(), &grpcpeer.Peer{
+		AuthInfo: callerAuthInfo{known: true, uid: 0, pid: os.Getpid() + 1},
+	})
+}
+
+func userCallerContext() context.Context {
+	return grpcpeer.NewContext(context.Background(), &grpcpeer.Peer{
+		AuthInfo: callerAuthInfo{known: true, uid: 1000, pid: os.Getpid() + 1},
+	})
+}
+
+func TestCallerCredentialsHandshake(t *testing.T) {
+	if runtime.GOOS != "linux" && runtime.GOOS != "darwin" {
+		t.Skip("peer credentials are not supported")
+	}
+
+	l, err := net.Listen("unix", filepath.Join(t.TempDir(), "daemon.sock"))
+	require.NoError(t, err)
+	defer l.Close()
+
+	client, err := net.Dial("unix", l.Addr().String())
+	require.NoError(t, err)
+	defer client.Close()
+	conn, err := l.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, authInfo, err := NewCallerCredentials().ServerHandshake(conn)
+	require.NoError(t, err)
+	info, ok := authInfo.(callerAuthInfo)
+	require.True(t, ok)
+	assert.True(t, info.known)
+	assert.Equal(t, os.Getuid(), info.uid)
+	assert.Equal(t, os.Getpid(), info.pid)
+	assert.False(t, info.privileged(), "calls from the daemon itself are not privileged")
+}
+
+func TestCheckPrivilegedCaller(t *testing.T) {
+	withCaller := func(info callerAuthInfo) context.Context {
+		return grpcpeer.NewContext(context.Background(), &grpcpeer.Peer{AuthInfo: info})
+	}
+
+	assert.NoError(t, checkPrivilegedCaller(withCaller(callerAuthInfo{known: true, uid: 0, pid: os.Getpid() + 1}), "option"))
+
+	for name, ctx := range map[string]context.Context{
+		"no peer":      context.Background(),
+		"unknown user": withCaller(callerAuthInfo{}),
+		"user":         withCaller(callerAuthInfo{known: true, uid: 1000, pid: os.Getpid() + 1}),
+		"daemon":       withCaller(callerAuthInfo{known: true, uid: 0, pid: os.Getpid()}),
+	} {
+		err := checkPrivilegedCaller(ctx, "option")
+		assert.Equal(t, codes.PermissionDenied, status.Code(err), name)
+	}
+}
@@ -2,8 +2,12 @@ package server
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"net/netip"
 	"os"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -12,11 +16,16 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/netbirdio/netbird/client/internal"
+	"github.com/netbirdio/netbird/client/internal/peer"
 	"github.com/netbirdio/netbird/client/proto"
 	"github.com/netbirdio/netbird/util/capture"
 )
 
-const maxBundleCaptureDuration = 10 * time.Minute
+const (
+	maxBundleCaptureDuration = 10 * time.Minute
+	// maxBundleCaptureBytes bounds the temp file of a bundle capture; later packets are dropped.
+	maxBundleCaptureBytes = 100 * 1024 * 1024
+)
 
 // bundleCapture holds the state of an in-progress capture destined for the
 // debug bundle. The lifecycle is:
@@ -31,6 +40,8 @@ type bundleCapture struct {
 	engine  *internal.Engine
 	cancel  context.CancelFunc
 	stopped bool
+	// peer is set when the capture was limited to a single peer on request.
+	peer string
 }
 
 // stop halts the capture session and closes the pcap writer. Idempotent.
@@ -208,12 +219,28 @@ func (s *Server) StartBundleCapture(_ context.Context, req *proto.StartBundleCap
 		timeout = maxBundleCaptureDuration
 	}
 
+	var filterExpr string
+	var matcher capture.Matcher
+	if req.GetPeer() != "" {
+		filterExpr, err = peerCaptureFilter(s.statusRecorder.GetFullStatus().Peers, req.GetPeer())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if matcher, err = capture.ParseFilter(filterExpr); err != nil {
+			return nil, status.Errorf(codes.Internal, "parse peer filter %q: %v", filterExpr, err)
+		}
+	}
+
 	f, err := os.CreateTemp("", "netbird.capture.*.pcap")
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "create temp file: %v", err)
 	}
 
-	sess, err := capture.NewSession(capture.Options{Output: f})
+	sess, err := capture.NewSession(capture.Options{
+		Output:   f,
+		Matcher:  matcher,
+		MaxBytes: maxBundleCaptureBytes,
+	})
 	if err != nil {
 		f.Close()
 		os.Remove(f.Name())
@@ -235,6 +262,7 @@ func (s *Server) StartBundleCapture(_ context.Context, req *proto.StartBundleCap
 		file:   f,
 		engine: engine,
 		cancel: cancel,
+		peer:   req.GetPeer(),
 	}
 
 	s.bundleCapture = bc
@@ -250,9 +278,9 @@ func (s *Server) StartBundleCapture(_ context.Context, req *proto.StartBundleCap
 		s.mutex.Unlock()
 		log.Infof("bundle capture auto-stopped after timeout")
 	}()
-	log.Infof("bundle capture started (timeout=%s, file=%s)", timeout, f.Name())
+	log.Infof("bundle capture started (timeout=%s, file=%s, expr=%q)", timeout, f.Name(), filterExpr)
 
-	return &proto.StartBundleCaptureResponse{}, nil
+	return &proto.StartBundleCaptureResponse{FilterExpr: filterExpr}, nil
 }
 
 // StopBundleCapture stops the running bundle capture. Idempotent.
@@ -283,16 +311,18 @@ func (s *Server) stopBundleCaptureLocked() {
 		stats.Packets, stats.Bytes, stats.Dropped)
 }
 
-// bundleCapturePath returns the temp file path if a capture has been taken,
-// stops any running capture, and returns "". Called from DebugBundle.
+// takeBundleCapture stops the bundle capture and hands it over to DebugBundle, which includes
+// the temp file and cleans it up without holding s.mutex. Returns nil if no capture was taken.
 // Must hold s.mutex.
-func (s *Server) bundleCapturePath() string {
-	if s.bundleCapture == nil {
-		return ""
+func (s *Server) takeBundleCapture() *bundleCapture {
+	bc := s.bundleCapture
+	if bc == nil {
+		return nil
 	}
 
-	s.bundleCapture.stop()
-	return s.bundleCapture.path()
+	bc.stop()
+	s.bundleCapture = nil
+	return bc
 }
 
 // cleanupBundleCapture removes the temp file and clears state. Must hold s.mutex.
@@ -354,6 +384,41 @@ func (s *Server) getCaptureEngineLocked() (*internal.Engine, error) {
 	return engine, nil
 }
 
+// peerCaptureFilter returns a filter expression matching the tunnel IPs and routed
+// networks of the named peer, i.e. the allowed IPs of its WireGuard peer entry.
+func peerCaptureFilter(states []peer.State, name string) (string, error) {
+	st, ok := findPeerState(states, name)
+	if !ok {
+		return "", fmt.Errorf("peer %q not found", name)
+	}
+
+	var terms []string
+	for _, ip := range []string{st.IP, st.IPv6} {
+		addr, err := netip.ParseAddr(strings.Split(ip, "/")[0])
+		if err != nil {
+			continue
+		}
+		terms = append(terms, "host "+addr.String())
+	}
+
+	var routes []string
+	for route := range st.GetRoutes() {
+		prefix, err := netip.ParsePrefix(route)
+		if err != nil {
+			// domain routes resolve to addresses only known to the route manager
+			continue
+		}
+		routes = append(routes, "net "+prefix.Masked().String())
+	}
+	sort.Strings(routes)
+	terms = append(terms, routes...)
+
+	if len(terms) == 0 {
+		return "", fmt.Errorf("peer %q has no tunnel IP", name)
+	}
+	return strings.Join(terms, " or "), nil
+}
+
 // parseCaptureFilter returns a Matcher from the request.
 // Returns nil (match all) when no filter expression is set.
 func parseCaptureFilter(req *proto.StartCaptureRequest) (capture.Matcher, error) {
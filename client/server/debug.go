@@ -7,78 +7,222 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
 	"runtime/pprof"
+	"slices"
+	"sort"
+	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/netbirdio/netbird/client/anonymize"
+	firewall "github.com/netbirdio/netbird/client/firewall/manager"
+	"github.com/netbirdio/netbird/client/internal"
 	"github.com/netbirdio/netbird/client/internal/debug"
+	"github.com/netbirdio/netbird/client/internal/profilemanager"
+	"github.com/netbirdio/netbird/client/internal/syncstore"
 	"github.com/netbirdio/netbird/client/proto"
+	nbstatus "github.com/netbirdio/netbird/client/status"
 	mgmProto "github.com/netbirdio/netbird/shared/management/proto"
+	"github.com/netbirdio/netbird/util"
 	"github.com/netbirdio/netbird/version"
 )
 
+const (
+	defaultRelayFailoverTimeout = 2 * time.Minute
+	debugBundleChunkSize        = 64 * 1024
+)
+
 // DebugBundle creates a debug bundle and returns the location.
-func (s *Server) DebugBundle(_ context.Context, req *proto.DebugBundleRequest) (resp *proto.DebugBundleResponse, err error) {
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
+func (s *Server) DebugBundle(ctx context.Context, req *proto.DebugBundleRequest) (*proto.DebugBundleResponse, error) {
+	if err := checkBundleCaller(ctx, req); err != nil {
+		return nil, err
+	}
+	return s.debugBundle(ctx, req, nil)
+}
 
-	syncResponse, err := s.getLatestSyncResponse()
+// DebugBundleWithProgress creates a debug bundle like DebugBundle and streams the stage of the
+// bundle generation while it runs. The last event carries the response.
+func (s *Server) DebugBundleWithProgress(req *proto.DebugBundleRequest, stream proto.DaemonService_DebugBundleWithProgressServer) error {
+	if err := checkBundleCaller(stream.Context(), req); err != nil {
+		return err
+	}
+	progress := func(stage string) {
+		if err := stream.Send(&proto.DebugBundleProgressEvent{Stage: stage}); err != nil {
+			log.Debugf("failed to send debug bundle progress: %v", err)
+		}
+	}
+
+	resp, err := s.debugBundle(stream.Context(), req, progress)
 	if err != nil {
-		log.Warnf("failed to get latest sync response: %v", err)
+		return err
 	}
+	if err := stream.Send(&proto.DebugBundleProgressEvent{Response: resp}); err != nil {
+		return fmt.Errorf("send debug bundle response: %w", err)
+	}
+	return nil
+}
 
-	var clientMetrics debug.MetricsExporter
-	if s.connectClient != nil {
-		if engine := s.connectClient.Engine(); engine != nil {
-			if cm := engine.GetClientMetrics(); cm != nil {
-				clientMetrics = cm
-			}
+func (s *Server) debugBundle(ctx context.Context, req *proto.DebugBundleRequest, progress func(stage string)) (resp *proto.DebugBundleResponse, err error) {
+	// checked before locking, the check waits for the network
+	if err := checkUploadURL(ctx, req); err != nil {
+		return nil, err
+	}
+
+	// Bundles run one at a time but don't hold s.mutex: the CPU profile, the MTU probe and the
+	// upload take minutes and would block every other RPC.
+	s.bundleMu.Lock()
+	defer s.bundleMu.Unlock()
+
+	if req.GetUploadLast() {
+		return s.uploadLastDebugBundle(ctx, req, progress)
+	}
+
+	statusFormat, err := debug.ParseStatusFormat(req.GetStatusFormat())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	compression, err := debug.ParseCompression(req.GetCompression())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := checkSplitSize(req); err != nil {
+		return nil, err
+	}
+	anonymizeLevel, err := anonymize.ResolveLevel(req.GetAnonymizeLevel(), req.GetAnonymize())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := debug.ValidateExtraPaths(req.GetExtraPaths()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if len(req.GetCommandOutput()) > debug.MaxCommandOutput {
+		return nil, status.Errorf(codes.InvalidArgument, "command output exceeds %d bytes", debug.MaxCommandOutput)
+	}
+	if len(req.GetTraceroute()) > debug.MaxTracerouteOutput {
+		return nil, status.Errorf(codes.InvalidArgument, "traceroute output exceeds %d bytes", debug.MaxTracerouteOutput)
+	}
+	logFilter, err := debug.ParseLogFilter(req.GetLogInclude(), req.GetLogExclude())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	systemInfo, err := bundleSystemInfo(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	anonymizePatterns, err := anonymize.CompilePatterns(req.GetAnonymizePatterns())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	var outputDir string
+	if dir := req.GetOutputDir(); dir != "" {
+		outputDir, err = debug.ResolveOutputDir(dir)
+		if err != nil {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
 		}
 	}
 
-	var cpuProfileData []byte
-	if s.cpuProfileBuf != nil && !s.cpuProfiling {
-		cpuProfileData = s.cpuProfileBuf.Bytes()
-		defer func() {
-			s.cpuProfileBuf = nil
-		}()
+	state, err := s.takeBundleState(req)
+	if err != nil {
+		return nil, err
+	}
+	if state.capture != nil {
+		defer state.capture.cleanup()
 	}
 
-	capturePath := s.bundleCapturePath()
-	defer s.cleanupBundleCapture()
+	var stagedLogsDir string
+	if id := req.GetStagedLogsId(); id != "" {
+		stagedLogsDir, err = debug.StagedLogsDir(debug.StagedLogsRoot(), id)
+		if err != nil {
+			log.Warnf("failed to use staged logs, the bundle only has the current logs: %v", err)
+		}
+	}
 
-	var refreshStatus func()
-	if s.connectClient != nil {
-		engine := s.connectClient.Engine()
-		if engine != nil {
-			refreshStatus = func() {
-				log.Debug("refreshing system health status for debug bundle")
-				// Background ctx: the bundle wants a full, fresh probe regardless
-				// of the DebugBundle RPC client's lifetime. The engine's own ctx
-				// still aborts it on shutdown.
-				engine.RunHealthProbes(context.Background(), true)
-			}
+	var peerMTU *debug.PeerMTUReport
+	if req.GetPeerMtuProbe() {
+		peerMTU, err = s.probePeerMTU(ctx, state.connectClient, nil, true, 0)
+		if err != nil {
+			log.Warnf("failed to probe peer MTU for debug bundle: %v", err)
 		}
 	}
 
+	logBaseline, err := bundleLogBaseline(req)
+	if err != nil {
+		return nil, err
+	}
+
 	bundleGenerator := debug.NewBundleGenerator(
 		debug.GeneratorDependencies{
-			InternalConfig: s.config,
+			InternalConfig: state.config,
 			StatusRecorder: s.statusRecorder,
-			SyncResponse:   syncResponse,
+			SyncResponse:   state.syncResponse,
 			LogPath:        s.logFile,
-			UILogPath:      s.uiLogPath,
-			CPUProfile:     cpuProfileData,
-			CapturePath:    capturePath,
-			RefreshStatus:  refreshStatus,
-			ClientMetrics:  clientMetrics,
+			UILogPath:      state.uiLogPath,
+			TempDir:        outputDir,
+			CPUProfile:     state.cpuProfile,
+			CapturePath:    state.capturePath,
+			RefreshStatus:  state.refreshStatus,
+			ClientMetrics:  state.clientMetrics,
 			DaemonVersion:  version.NetbirdVersion(),
 			CliVersion:     req.CliVersion,
+			DropStats:      state.dropStats,
+			DNSState:       state.dnsState,
+			StartupTiming:  s.startupTiming,
+			PeerMTU:        peerMTU,
+			PeerProbes:     s.recentPeerProbes(),
+			ACLQueries:     state.aclQueries,
+			Policy:         state.policy,
+
+			NetworkMapHistory: state.networkMapHistory,
+			Progress:          progress,
+			Firewall:          state.firewall,
+			NAT:               state.nat,
+			ClockSkew:         state.clockSkew,
+			ChannelStates:     state.channelStates,
+			WireGuardImpl:     state.wireGuardImpl,
 		},
 		debug.BundleConfig{
-			Anonymize:         req.GetAnonymize(),
-			IncludeSystemInfo: req.GetSystemInfo(),
-			LogFileCount:      req.GetLogFileCount(),
+			Anonymize:           anonymizeLevel != anonymize.LevelNone || req.GetAnonymizePreview(),
+			AnonymizeIPsOnly:    anonymizeLevel == anonymize.LevelIPsOnly,
+			IncludeSystemInfo:   systemInfo,
+			LogFileCount:        req.GetLogFileCount(),
+			EncryptionKey:       req.GetEncryptionKey(),
+			StatusFormat:        statusFormat,
+			MaxSize:             int64(req.GetMaxSize()),
+			Since:               req.GetSince().AsDuration(),
+			LogTail:             int(req.GetLogTail()),
+			LogBaseline:         logBaseline,
+			RecordLogBaseline:   req.GetIncrementalLogs() || req.GetFullLogs(),
+			IncludeProfiles:     req.GetProfiles(),
+			CPUProfileDuration:  req.GetProfileCpuDuration().AsDuration(),
+			IncludeRawCapture:   state.perPeerCapture,
+			AnonymizationMap:    fromProtoAnonymizationMap(req.GetAnonymizationMap()),
+			StableAnonymization: req.GetPersistAnonymizationMap(),
+			AnonymizePatterns:   anonymizePatterns,
+			AllowSecrets:        req.GetAllowSecrets(),
+			PeerSelectors:       req.GetPeers(),
+			PeerGroup:           req.GetPeerGroup(),
+			Domain:              req.GetDomain(),
+			Label:               req.GetLabel(),
+			ExtraPaths:          req.GetExtraPaths(),
+			CommandOutput:       req.GetCommandOutput(),
+			Traceroute:          req.GetTraceroute(),
+			InterfaceStatsStart: fromProtoInterfaceStats(req.GetInterfaceStatsStart()),
+			SessionStart:        sessionStart(req.GetSessionStart()),
+			ResourceTimeline:    fromProtoRuntimeStats(req.GetResourceTimeline()),
+			Compression:         compression,
+			StagedLogsDir:       stagedLogsDir,
+			LogFilter:           logFilter,
+			SplitSize:           int64(req.GetSplitSize()),
+			NoLogs:              req.GetNoLogs(),
 		},
 	)
 
@@ -86,19 +230,555 @@ func (s *Server) DebugBundle(_ context.Context, req *proto.DebugBundleRequest) (
 	if err != nil {
 		return nil, fmt.Errorf("generate debug bundle: %w", err)
 	}
+	if stagedLogsDir != "" {
+		if err := os.RemoveAll(stagedLogsDir); err != nil {
+			log.Warnf("failed to remove staged logs: %v", err)
+		}
+	}
+	if baseline := bundleGenerator.RecordedLogBaseline(); baseline != nil {
+		if err := debug.SaveLogBaseline(logBaselinePath(), baseline); err != nil {
+			log.Warnf("failed to save the log baseline, the next incremental bundle has the whole logs: %v", err)
+		}
+	}
+	s.lastBundlePath = path
+	s.lastBundleAnonymized = bundleGenerator.Anonymized()
+	s.lastBundleSplit = len(bundleGenerator.Parts()) > 0
+	s.lastBundleSecrets = req.GetAllowSecrets()
+	s.lastBundlePrivileged = len(req.GetExtraPaths()) > 0 || req.GetOutputDir() != ""
+
+	var truncatedLogs []string
+	for _, t := range bundleGenerator.TruncatedLogs() {
+		truncatedLogs = append(truncatedLogs, t.String())
+	}
+	if len(truncatedLogs) > 0 {
+		log.Infof("debug bundle logs truncated to stay within %d bytes: %s", req.GetMaxSize(), strings.Join(truncatedLogs, ", "))
+	}
+
+	resp := &proto.DebugBundleResponse{
+		Path:          path,
+		TruncatedLogs: truncatedLogs,
+		Anonymized:    s.lastBundleAnonymized,
+		Parts:         bundleGenerator.Parts(),
+	}
+	if req.GetPersistAnonymizationMap() {
+		resp.AnonymizationMap = toProtoAnonymizationMap(bundleGenerator.AnonymizationMappings())
+	}
+
+	if req.GetAnonymizePreview() {
+		resp.AnonymizationPreview = toProtoAnonymizationPreview(bundleGenerator.AnonymizationPreview())
+		return resp, nil
+	}
+
+	if req.GetUploadURL() == "" {
+		return resp, nil
+	}
+	s.uploadDebugBundle(req, resp, state.managementURL(), progress)
+	return resp, nil
+}
+
+// bundleState is what a debug bundle takes from the server. It is taken under s.mutex, the
+// bundle is generated without holding it.
+type bundleState struct {
+	connectClient     *internal.ConnectClient
+	config            *profilemanager.Config
+	uiLogPath         string
+	syncResponse      *mgmProto.SyncResponse
+	networkMapHistory []syncstore.Snapshot
+	clientMetrics     debug.MetricsExporter
+	cpuProfile        []byte
+	capture           *bundleCapture
+	capturePath       string
+	perPeerCapture    bool
+	refreshStatus     func()
+	policy            *debug.BundlePolicy
+	aclQueries        []debug.ACLQuery
+
+	dropStats     debug.DropStatsSource
+	dnsState      debug.DNSStateSource
+	firewall      firewall.Manager
+	nat           debug.NATSource
+	clockSkew     debug.ClockSkewSource
+	channelStates debug.ChannelStateSource
+	wireGuardImpl debug.WireGuardImplSource
+}
+
+func (b *bundleState) managementURL() string {
+	if b.config == nil || b.config.ManagementURL == nil {
+		return ""
+	}
+	return b.config.ManagementURL.String()
+}
+
+// takeBundleState checks the peers, group and domain of the request against the current state
+// and takes what the bundle needs from the server. The finished CPU profile and the bundle
+// capture are handed over to the bundle, the caller cleans up the capture.
+func (s *Server) takeBundleState(req *proto.DebugBundleRequest) (*bundleState, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.checkPeerSelectors(req.GetPeers()); err != nil {
+		return nil, err
+	}
+	if err := s.checkPeerGroup(req.GetPeerGroup()); err != nil {
+		return nil, err
+	}
+	if err := s.checkBundleDomain(req.GetDomain()); err != nil {
+		return nil, err
+	}
+
+	state := &bundleState{
+		connectClient: s.connectClient,
+		uiLogPath:     s.uiLogPath,
+		aclQueries:    s.bundleACLQueries(req.GetAclQueries()),
+		dropStats:     s.dropStatsSource(),
+		dnsState:      s.dnsStateSource(),
+		firewall:      s.firewallManager(),
+		nat:           s.natSource(),
+		clockSkew:     s.clockSkewSource(),
+		channelStates: s.channelStateSource(),
+		wireGuardImpl: s.wireGuardImplSource(),
+	}
+	if s.config != nil {
+		// a copy, SetLogLevel changes the config in place
+		config := *s.config
+		state.config = &config
+	}
+
+	var err error
+	state.syncResponse, err = s.getLatestSyncResponse()
+	if err != nil {
+		log.Warnf("failed to get latest sync response: %v", err)
+	}
+
+	if count := req.GetNetworkMapCount(); count > 1 && s.connectClient != nil {
+		history, err := s.connectClient.GetNetworkMapHistory(int(count))
+		if err != nil {
+			log.Warnf("failed to get network map history: %v", err)
+		} else {
+			state.networkMapHistory = history
+			// keep network_map.json consistent with the history index
+			state.syncResponse = history[0].Response
+		}
+	}
+
+	if s.connectClient != nil {
+		if engine := s.connectClient.Engine(); engine != nil {
+			if cm := engine.GetClientMetrics(); cm != nil {
+				state.clientMetrics = cm
+			}
+			state.policy = engine.GetBundlePolicy()
+			state.refreshStatus = func() {
+				log.Debug("refreshing system health status for debug bundle")
+				// Background ctx: the bundle wants a full, fresh probe regardless
+				// of the DebugBundle RPC client's lifetime. The engine's own ctx
+				// still aborts it on shutdown.
+				engine.RunHealthProbes(context.Background(), true)
+			}
+		}
+	}
+
+	if s.cpuProfileBuf != nil && !s.cpuProfiling {
+		state.cpuProfile = s.cpuProfileBuf.Bytes()
+		s.cpuProfileBuf = nil
+	}
+
+	if !req.GetExcludeCapture() {
+		if state.capture = s.takeBundleCapture(); state.capture != nil {
+			state.capturePath, state.perPeerCapture = state.capture.path(), state.capture.peer != ""
+		}
+	}
+	return state, nil
+}
+
+// checkBundleCaller fails for the bundle options that read what any user of the daemon socket
+// must not get: secrets and files outside of NetBird are only included for root, and only root
+// chooses where the daemon writes the bundle or uploads it with the daemon's AWS credentials.
+// A bundle with secrets is never uploaded.
+func checkBundleCaller(ctx context.Context, req *proto.DebugBundleRequest) error {
+	if req.GetAllowSecrets() {
+		if req.GetUploadURL() != "" {
+			return status.Error(codes.InvalidArgument, "a debug bundle with secrets cannot be uploaded")
+		}
+		if err := checkPrivilegedCaller(ctx, "keeping secrets in a debug bundle"); err != nil {
+			return err
+		}
+	}
+	if len(req.GetExtraPaths()) > 0 {
+		if err := checkPrivilegedCaller(ctx, "including extra files in a debug bundle"); err != nil {
+			return err
+		}
+	}
+	if req.GetOutputDir() != "" {
+		if err := checkPrivilegedCaller(ctx, "writing a debug bundle to an output directory"); err != nil {
+			return err
+		}
+	}
+	if debug.IsS3UploadURL(req.GetUploadURL()) {
+		if err := checkPrivilegedCaller(ctx, "uploading a debug bundle to S3"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkUploadURL normalizes the upload URL of the request and checks that the upload server
+// answers, unless the check is skipped.
+func checkUploadURL(ctx context.Context, req *proto.DebugBundleRequest) error {
+	if req.GetUploadURL() == "" || req.GetAnonymizePreview() {
+		return nil
+	}
+
+	uploadURL, err := debug.NormalizeUploadURL(req.GetUploadURL())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	req.UploadURL = uploadURL
+
+	if req.GetSkipUploadURLCheck() {
+		return nil
+	}
+	if err := debug.CheckUploadURL(ctx, uploadURL); err != nil {
+		return status.Errorf(codes.FailedPrecondition, "%v (skip this check with --no-url-check)", err)
+	}
+	return nil
+}
 
+// uploadLastDebugBundle uploads the bundle generated last instead of generating a new one. A
+// bundle generated with root-only options is only uploaded for root.
+func (s *Server) uploadLastDebugBundle(ctx context.Context, req *proto.DebugBundleRequest, progress func(stage string)) (*proto.DebugBundleResponse, error) {
 	if req.GetUploadURL() == "" {
-		return &proto.DebugBundleResponse{Path: path}, nil
+		return nil, status.Error(codes.InvalidArgument, "uploading the last debug bundle requires an upload URL")
+	}
+	if s.lastBundlePath == "" {
+		return nil, status.Error(codes.FailedPrecondition, "no debug bundle was generated since the daemon started")
+	}
+	if s.lastBundleSplit {
+		return nil, status.Error(codes.FailedPrecondition, "the last debug bundle was split into parts and cannot be uploaded")
+	}
+	if s.lastBundleSecrets {
+		return nil, status.Error(codes.FailedPrecondition, "the last debug bundle contains secrets and cannot be uploaded")
+	}
+	if s.lastBundlePrivileged {
+		if err := checkPrivilegedCaller(ctx, "uploading a debug bundle generated with extra files or an output directory"); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := os.Stat(s.lastBundlePath); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "the last debug bundle %s is not available anymore: %v", s.lastBundlePath, err)
+	}
+
+	s.mutex.Lock()
+	var managementURL string
+	if s.config != nil && s.config.ManagementURL != nil {
+		managementURL = s.config.ManagementURL.String()
+	}
+	s.mutex.Unlock()
+	if managementURL == "" {
+		return nil, status.Error(codes.FailedPrecondition, "config is not defined, please call login command first")
+	}
+
+	log.Infof("uploading the last debug bundle %s", s.lastBundlePath)
+	resp := &proto.DebugBundleResponse{Path: s.lastBundlePath, Anonymized: s.lastBundleAnonymized}
+	s.uploadDebugBundle(req, resp, managementURL, progress)
+	return resp, nil
+}
+
+// uploadDebugBundle uploads the bundle at resp.Path and sets the key and expiry or the failure reason
+// in resp.
+func (s *Server) uploadDebugBundle(req *proto.DebugBundleRequest, resp *proto.DebugBundleResponse, managementURL string, progress func(stage string)) {
+	path := resp.GetPath()
+	if progress != nil {
+		progress("uploading")
 	}
-	key, err := debug.UploadDebugBundle(context.Background(), req.GetUploadURL(), s.config.ManagementURL.String(), path)
+	uploadOpts := debug.UploadOptions{
+		Timeout: req.GetUploadTimeout().AsDuration(),
+		Retries: req.GetUploadRetries(),
+	}
+	if uploadOpts.Timeout <= 0 {
+		uploadOpts.Timeout = debug.DefaultUploadTimeout
+	}
+	result, err := debug.UploadDebugBundleWithOptions(context.Background(), req.GetUploadURL(), managementURL, path, uploadOpts)
 	if err != nil {
 		log.Errorf("failed to upload debug bundle to %s: %v", req.GetUploadURL(), err)
-		return &proto.DebugBundleResponse{Path: path, UploadFailureReason: err.Error()}, nil
+		resp.UploadFailureReason = err.Error()
+		return
+	}
+
+	log.Infof("debug bundle uploaded to %s with key %s", req.GetUploadURL(), result.Key)
+
+	resp.UploadedKey = result.Key
+	if !result.ExpiresAt.IsZero() {
+		resp.ExpiresAt = timestamppb.New(result.ExpiresAt)
+	}
+}
+
+// checkSplitSize fails when the split size of the request is too small or the bundle is also
+// uploaded or streamed, which need it in one file.
+func checkSplitSize(req *proto.DebugBundleRequest) error {
+	size := req.GetSplitSize()
+	if size == 0 {
+		return nil
+	}
+	if size < debug.MinSplitSize || size > math.MaxInt64 {
+		return status.Errorf(codes.InvalidArgument, "split size must be at least %d bytes", debug.MinSplitSize)
+	}
+	if req.GetUploadURL() != "" && !req.GetAnonymizePreview() {
+		return status.Error(codes.InvalidArgument, "a split debug bundle cannot be uploaded")
+	}
+	return nil
+}
+
+// checkPeerSelectors fails when a peer selector of a debug bundle matches no known peer, so
+// the bundle is not generated with a status missing the requested peers.
+func (s *Server) checkPeerSelectors(selectors []string) error {
+	if len(selectors) == 0 {
+		return nil
+	}
+	if s.statusRecorder == nil {
+		return status.Error(codes.FailedPrecondition, "peer status is not available")
+	}
+
+	fullStatus := nbstatus.ToProtoFullStatus(s.statusRecorder.GetFullStatus())
+	if _, unmatched := nbstatus.SelectPeers(fullStatus.GetPeers(), selectors); len(unmatched) > 0 {
+		return status.Errorf(codes.InvalidArgument, "no peer matches %s", strings.Join(unmatched, ", "))
 	}
+	return nil
+}
 
-	log.Infof("debug bundle uploaded to %s with key %s", req.GetUploadURL(), key)
+// checkPeerGroup fails when no peer is in the group of a debug bundle, which is also the case
+// when management sends no groups.
+func (s *Server) checkPeerGroup(groupID string) error {
+	if groupID == "" {
+		return nil
+	}
+	if s.statusRecorder == nil {
+		return status.Error(codes.FailedPrecondition, "peer status is not available")
+	}
 
-	return &proto.DebugBundleResponse{Path: path, UploadedKey: key}, nil
+	fullStatus := nbstatus.ToProtoFullStatus(s.statusRecorder.GetFullStatus())
+	if len(nbstatus.PeersInGroup(fullStatus.GetPeers(), groupID)) == 0 {
+		return status.Errorf(codes.InvalidArgument, "no peer is in group %s", groupID)
+	}
+	return nil
+}
+
+// checkBundleDomain fails when the domain of a debug bundle is not configured, so the bundle is
+// not generated with empty DNS and status sections. The domain has to match a match or search
+// domain, a nameserver group, a NetBird zone or a domain route.
+func (s *Server) checkBundleDomain(scope string) error {
+	if scope == "" {
+		return nil
+	}
+	if source := s.dnsStateSource(); source != nil && debug.DNSStateHasDomain(source.DebugState(), scope) {
+		return nil
+	}
+	if s.statusRecorder != nil {
+		fullStatus := nbstatus.ToProtoFullStatus(s.statusRecorder.GetFullStatus())
+		if len(nbstatus.NetworksForDomain(fullStatus.GetLocalPeerState().GetNetworks(), scope)) > 0 {
+			return nil
+		}
+		for _, p := range fullStatus.GetPeers() {
+			if len(nbstatus.NetworksForDomain(p.GetNetworks(), scope)) > 0 {
+				return nil
+			}
+		}
+	}
+	return status.Errorf(codes.InvalidArgument, "domain %s is not configured: no match domain, nameserver group, NetBird zone or domain route matches it", scope)
+}
+
+// DebugBundleStream creates a debug bundle, streams it to the client and removes it.
+func (s *Server) DebugBundleStream(req *proto.DebugBundleRequest, stream proto.DaemonService_DebugBundleStreamServer) error {
+	if req.GetUploadURL() != "" {
+		return status.Error(codes.InvalidArgument, "a streamed debug bundle cannot be uploaded")
+	}
+	if req.GetAllowSecrets() {
+		return status.Error(codes.InvalidArgument, "a streamed debug bundle cannot contain secrets")
+	}
+	if req.GetOutputDir() != "" {
+		return status.Error(codes.InvalidArgument, "a streamed debug bundle is not kept in an output directory")
+	}
+	if req.GetSplitSize() != 0 {
+		return status.Error(codes.InvalidArgument, "a streamed debug bundle cannot be split")
+	}
+
+	resp, err := s.DebugBundle(stream.Context(), req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := os.Remove(resp.GetPath()); err != nil {
+			log.Errorf("failed to remove streamed debug bundle %s: %v", resp.GetPath(), err)
+		}
+	}()
+
+	f, err := os.Open(resp.GetPath())
+	if err != nil {
+		return fmt.Errorf("open debug bundle: %w", err)
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.Debugf("failed to close debug bundle: %v", err)
+		}
+	}()
+
+	buf := make([]byte, debugBundleChunkSize)
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			if sendErr := stream.Send(&proto.DebugBundleChunk{Data: buf[:n]}); sendErr != nil {
+				return fmt.Errorf("send debug bundle chunk: %w", sendErr)
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read debug bundle: %w", err)
+		}
+	}
+}
+
+// bundleSystemInfo returns the system information collectors of a bundle request. Clients
+// without collector selection only set systemInfo, which selects all of them.
+func bundleSystemInfo(req *proto.DebugBundleRequest) (debug.SystemInfoSet, error) {
+	if collectors := req.GetSystemInfoCollectors(); len(collectors) > 0 {
+		return debug.NewSystemInfoSet(collectors)
+	}
+	if req.GetSystemInfo() {
+		return debug.AllSystemInfo(), nil
+	}
+	return nil, nil
+}
+
+func toProtoAnonymizationPreview(summaries []debug.AnonymizationSummary) []*proto.AnonymizationSummary {
+	preview := make([]*proto.AnonymizationSummary, 0, len(summaries))
+	for _, s := range summaries {
+		summary := &proto.AnonymizationSummary{
+			Category: string(s.Category),
+			Count:    uint32(s.Count),
+		}
+		for _, m := range s.Samples {
+			summary.Samples = append(summary.Samples, &proto.AnonymizationSample{
+				Original:   m.Original,
+				Anonymized: m.Anonymized,
+			})
+		}
+		preview = append(preview, summary)
+	}
+	return preview
+}
+
+func toProtoAnonymizationMap(mappings []anonymize.Mapping) []*proto.AnonymizationMapping {
+	result := make([]*proto.AnonymizationMapping, 0, len(mappings))
+	for _, m := range mappings {
+		result = append(result, &proto.AnonymizationMapping{
+			Category:   string(m.Category),
+			Original:   m.Original,
+			Anonymized: m.Anonymized,
+		})
+	}
+	return result
+}
+
+func fromProtoAnonymizationMap(mappings []*proto.AnonymizationMapping) []anonymize.Mapping {
+	result := make([]anonymize.Mapping, 0, len(mappings))
+	for _, m := range mappings {
+		result = append(result, anonymize.Mapping{
+			Category:   anonymize.Category(m.GetCategory()),
+			Original:   m.GetOriginal(),
+			Anonymized: m.GetAnonymized(),
+		})
+	}
+	return result
+}
+
+func toProtoInterfaceStats(stats debug.InterfaceStats) *proto.InterfaceStatsSnapshot {
+	snapshot := &proto.InterfaceStatsSnapshot{Time: timestamppb.New(stats.Time)}
+	for _, c := range stats.Interfaces {
+		snapshot.Interfaces = append(snapshot.Interfaces, toProtoInterfaceCounters(c))
+	}
+	if stats.WireGuard != nil {
+		snapshot.WireGuard = toProtoInterfaceCounters(*stats.WireGuard)
+	}
+	return snapshot
+}
+
+func toProtoInterfaceCounters(c debug.InterfaceCounters) *proto.InterfaceCounters {
+	return &proto.InterfaceCounters{
+		Name:      c.Name,
+		RxBytes:   c.RxBytes,
+		TxBytes:   c.TxBytes,
+		RxPackets: c.RxPackets,
+		TxPackets: c.TxPackets,
+		RxErrors:  c.RxErrors,
+		TxErrors:  c.TxErrors,
+		RxDropped: c.RxDropped,
+		TxDropped: c.TxDropped,
+	}
+}
+
+// fromProtoInterfaceStats returns nil when the request has no interface counters.
+func fromProtoInterfaceStats(snapshot *proto.InterfaceStatsSnapshot) *debug.InterfaceStats {
+	if snapshot == nil {
+		return nil
+	}
+	stats := &debug.InterfaceStats{Time: snapshot.GetTime().AsTime()}
+	for _, c := range snapshot.GetInterfaces() {
+		stats.Interfaces = append(stats.Interfaces, fromProtoInterfaceCounters(c))
+	}
+	if wg := snapshot.GetWireGuard(); wg != nil {
+		counters := fromProtoInterfaceCounters(wg)
+		stats.WireGuard = &counters
+	}
+	return stats
+}
+
+func fromProtoInterfaceCounters(c *proto.InterfaceCounters) debug.InterfaceCounters {
+	return debug.InterfaceCounters{
+		Name:      c.GetName(),
+		RxBytes:   c.GetRxBytes(),
+		TxBytes:   c.GetTxBytes(),
+		RxPackets: c.GetRxPackets(),
+		TxPackets: c.GetTxPackets(),
+		RxErrors:  c.GetRxErrors(),
+		TxErrors:  c.GetTxErrors(),
+		RxDropped: c.GetRxDropped(),
+		TxDropped: c.GetTxDropped(),
+	}
+}
+
+func toProtoRuntimeStats(stats debug.RuntimeStats) *proto.RuntimeStatsSample {
+	return &proto.RuntimeStatsSample{
+		Time:       timestamppb.New(stats.Time),
+		Goroutines: stats.Goroutines,
+		OpenFds:    stats.OpenFDs,
+		HeapBytes:  stats.HeapBytes,
+		TotalBytes: stats.TotalBytes,
+		GcCycles:   stats.GCCycles,
+	}
+}
+
+func fromProtoRuntimeStats(samples []*proto.RuntimeStatsSample) []debug.RuntimeStats {
+	stats := make([]debug.RuntimeStats, 0, len(samples))
+	for _, s := range samples {
+		stats = append(stats, debug.RuntimeStats{
+			Time:       s.GetTime().AsTime(),
+			Goroutines: s.GetGoroutines(),
+			OpenFDs:    s.GetOpenFds(),
+			HeapBytes:  s.GetHeapBytes(),
+			TotalBytes: s.GetTotalBytes(),
+			GCCycles:   s.GetGcCycles(),
+		})
+	}
+	return stats
+}
+
+// sessionStart converts the start of a "debug for" session, zero when the request has none.
+func sessionStart(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
 }
 
 // GetLogLevel gets the current logging level for the server.
@@ -106,33 +786,325 @@ func (s *Server) GetLogLevel(_ context.Context, _ *proto.GetLogLevelRequest) (*p
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	level := ParseLogLevel(log.GetLevel().String())
-	return &proto.GetLogLevelResponse{Level: level}, nil
+	level, components := util.ComponentLevels(log.StandardLogger())
+	resp := &proto.GetLogLevelResponse{
+		Level:        ParseLogLevel(level.String()),
+		DefaultLevel: ParseLogLevel(s.defaultLogLevel.String()),
+		IsDefault:    level == s.defaultLogLevel && len(components) == 0,
+		Persisted:    s.config != nil && s.config.LogLevel != "",
+	}
+	if len(components) > 0 {
+		resp.Components = make(map[string]proto.LogLevel, len(components))
+		for name, l := range components {
+			resp.Components[name] = ParseLogLevel(l.String())
+		}
+	}
+	return resp, nil
 }
 
-// SetLogLevel sets the logging level for the server.
+// SetLogLevel sets the logging level for the server, or persists it into an inactive profile.
 func (s *Server) SetLogLevel(_ context.Context, req *proto.SetLogLevelRequest) (*proto.SetLogLevelResponse, error) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	level, err := log.ParseLevel(req.Level.String())
+	level, _ := util.ComponentLevels(log.StandardLogger())
+	if req.GetLevel() != proto.LogLevel_UNKNOWN || len(req.GetComponents()) == 0 {
+		var err error
+		if level, err = log.ParseLevel(req.GetLevel().String()); err != nil {
+			return nil, fmt.Errorf("invalid log level: %w", err)
+		}
+	}
+
+	components := make(map[string]log.Level, len(req.GetComponents()))
+	for name, l := range req.GetComponents() {
+		componentLevel, err := log.ParseLevel(l.String())
+		if err != nil {
+			return nil, fmt.Errorf("invalid log level for component %s: %w", name, err)
+		}
+		components[strings.ToLower(name)] = componentLevel
+	}
+
+	activeProf, err := s.profileManager.GetActiveProfileState()
 	if err != nil {
-		return nil, fmt.Errorf("invalid log level: %w", err)
+		return nil, status.Errorf(codes.Internal, "get active profile: %v", err)
+	}
+	profileName := string(activeProf.ID)
+	if p, err := s.profileManager.ResolveProfile(profileName, activeProf.Username); err == nil {
+		profileName = p.Name
+	}
+	if req.GetProfileName() != "" {
+		target, err := s.resolveProfileHandle(req.GetProfileName(), req.GetUsername())
+		if err != nil {
+			return nil, err
+		}
+		profileName = target.Name
+		if !isActiveProfile(target, req.GetUsername(), activeProf) {
+			if s.checkProfilesDisabled() {
+				return nil, status.Error(codes.Unavailable, errProfilesDisabled)
+			}
+			return s.persistInactiveLogLevel(target, level, components)
+		}
 	}
 
-	log.SetLevel(level)
+	unknown := util.SetComponentLevels(log.StandardLogger(), level, components)
+	for _, name := range unknown {
+		log.Warnf("ignoring log level for unknown component %q", name)
+	}
 
 	if s.connectClient != nil {
-		s.connectClient.SetLogLevel(level)
+		firewallLevel := level
+		if l, ok := components["firewall"]; ok {
+			firewallLevel = l
+		}
+		s.connectClient.SetLogLevel(firewallLevel)
 	}
 
-	log.Infof("Log level set to %s", level.String())
+	if len(components) > len(unknown) {
+		log.Infof("Log level set to %s, components: %s", level.String(), formatComponentLevels(components, unknown))
+	} else {
+		log.Infof("Log level set to %s", level.String())
+	}
 
 	// Signal the desktop UI so it can attach/detach its gui-client.log. Rides
 	// the SubscribeEvents stream as a marked event (see publishLogLevelChanged).
-	s.publishLogLevelChanged(level.String())
+	s.publishLogLevelChanged(log.GetLevel().String())
+
+	resp := &proto.SetLogLevelResponse{UnknownComponents: unknown, Applied: true, ProfileName: profileName}
+	if req.GetPersist() {
+		for _, name := range unknown {
+			delete(components, name)
+		}
+		cfgPath, err := activeProf.FilePath()
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "log level set for this session, but persisting it failed: active profile file path: %v", err)
+		}
+		cleared, err := s.persistLogLevel(cfgPath, level, components)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "log level set for this session, but persisting it failed: %v", err)
+		}
+		resp.Persisted = !cleared
+		resp.PersistCleared = cleared
+	}
+	return resp, nil
+}
+
+// isActiveProfile reports whether the resolved profile of username is the active profile. The
+// default profile is shared by all users.
+func isActiveProfile(p *profilemanager.Profile, username string, activeProf *profilemanager.ActiveProfileState) bool {
+	if p.ID != activeProf.ID {
+		return false
+	}
+	return p.IsDefault() || username == activeProf.Username
+}
+
+// persistInactiveLogLevel writes the level into the config of a profile that is not active. The
+// running daemon keeps its level, the profile applies it when it is next activated.
+func (s *Server) persistInactiveLogLevel(target *profilemanager.Profile, level log.Level, components map[string]log.Level) (*proto.SetLogLevelResponse, error) {
+	var unknown []string
+	for name := range components {
+		if _, ok := util.LogComponents[name]; !ok {
+			unknown = append(unknown, name)
+			delete(components, name)
+		}
+	}
+	sort.Strings(unknown)
+
+	cleared, err := s.persistLogLevel(target.Path, level, components)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "persist log level into profile %s: %v", target.Name, err)
+	}
+	log.Infof("Log level %s persisted into inactive profile %s", level.String(), target.Name)
 
-	return &proto.SetLogLevelResponse{}, nil
+	return &proto.SetLogLevelResponse{
+		UnknownComponents: unknown,
+		Persisted:         !cleared,
+		PersistCleared:    cleared,
+		ProfileName:       target.Name,
+	}, nil
+}
+
+// persistLogLevel writes the level into the profile config at cfgPath, which the daemon applies
+// at startup. The daemon default level without components clears the persisted level instead.
+// The running config is only updated for the active profile.
+func (s *Server) persistLogLevel(cfgPath string, level log.Level, components map[string]log.Level) (bool, error) {
+	activeProf, err := s.profileManager.GetActiveProfileState()
+	if err != nil {
+		return false, fmt.Errorf("get active profile: %w", err)
+	}
+	activePath, err := activeProf.FilePath()
+	if err != nil {
+		return false, fmt.Errorf("active profile file path: %w", err)
+	}
+
+	cleared := level == s.defaultLogLevel && len(components) == 0
+	var persisted string
+	var componentLevels map[string]string
+	if !cleared {
+		persisted = level.String()
+		for name, l := range components {
+			if componentLevels == nil {
+				componentLevels = make(map[string]string, len(components))
+			}
+			componentLevels[name] = l.String()
+		}
+	}
+
+	config, err := profilemanager.UpdateOrCreateConfig(profilemanager.ConfigInput{
+		ConfigPath:         cfgPath,
+		LogLevel:           &persisted,
+		ComponentLogLevels: componentLevels,
+	})
+	if err != nil {
+		return false, fmt.Errorf("update config: %w", err)
+	}
+	if s.config != nil && cfgPath == activePath {
+		s.config.LogLevel = config.LogLevel
+		s.config.ComponentLogLevels = config.ComponentLogLevels
+	}
+	return cleared, nil
+}
+
+// RotateLog rotates the daemon log file and returns the path of the rotated-out file.
+func (s *Server) RotateLog(_ context.Context, _ *proto.RotateLogRequest) (*proto.RotateLogResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.logFile == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "%v: the daemon logs to the console or syslog", util.ErrLogRotationNotApplicable)
+	}
+	rotatedPath, err := util.RotateLogFile(s.logFile)
+	if errors.Is(err, util.ErrLogRotationNotApplicable) {
+		return nil, status.Errorf(codes.FailedPrecondition, "%v: rotation of %s is disabled", err, s.logFile)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "rotate log: %v", err)
+	}
+
+	log.Infof("log file rotated, previous log moved to %s", rotatedPath)
+	return &proto.RotateLogResponse{RotatedPath: rotatedPath}, nil
+}
+
+// StageDebugLogs copies the daemon logs aside for a later debug bundle, which merges them with
+// the logs it finds then. Used by "debug for" so a daemon restart during the session loses no lines.
+func (s *Server) StageDebugLogs(_ context.Context, _ *proto.StageDebugLogsRequest) (*proto.StageDebugLogsResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.logFile == "" {
+		return nil, status.Error(codes.FailedPrecondition, "the daemon logs to the console or syslog, there is no log file to stage")
+	}
+	id, files, err := debug.StageLogs(debug.StagedLogsRoot(), s.logFile)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "stage logs: %v", err)
+	}
+
+	log.Debugf("staged debug logs %s: %s", id, strings.Join(files, ", "))
+	return &proto.StageDebugLogsResponse{Id: id, Files: files}, nil
+}
+
+// GetInterfaceStats returns the current interface counters. "debug for" takes them at the start
+// of the session and sends them back with the bundle request, which adds the change since then.
+func (s *Server) GetInterfaceStats(_ context.Context, _ *proto.GetInterfaceStatsRequest) (*proto.GetInterfaceStatsResponse, error) {
+	var wg debug.WireGuardStatsSource
+	if s.statusRecorder != nil {
+		wg = s.statusRecorder
+	}
+	stats, err := debug.CollectInterfaceStats(wg)
+	if err != nil {
+		log.Debugf("failed to read some interface counters: %v", err)
+	}
+	return &proto.GetInterfaceStatsResponse{Snapshot: toProtoInterfaceStats(stats)}, nil
+}
+
+// GetRuntimeStats returns the resource usage of the daemon, sampled by "debug for" every second.
+func (s *Server) GetRuntimeStats(_ context.Context, _ *proto.GetRuntimeStatsRequest) (*proto.GetRuntimeStatsResponse, error) {
+	return &proto.GetRuntimeStatsResponse{Sample: toProtoRuntimeStats(debug.CollectRuntimeStats())}, nil
+}
+
+// SetLogFormat switches the daemon log between text and JSON lines.
+func (s *Server) SetLogFormat(_ context.Context, req *proto.SetLogFormatRequest) (*proto.SetLogFormatResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	previous := util.LogFormat(log.StandardLogger())
+	if previous == util.LogFormatSyslog {
+		return nil, status.Error(codes.FailedPrecondition, "the daemon logs to syslog, which uses its own format")
+	}
+
+	format := strings.ToLower(req.GetFormat())
+	if err := util.SetLogFormat(log.StandardLogger(), format); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	log.Infof("Log format set to %s, previous format: %s", format, previous)
+	return &proto.SetLogFormatResponse{PreviousFormat: previous}, nil
+}
+
+// TailLogs streams the daemon log lines matching the request until the client disconnects.
+func (s *Server) TailLogs(req *proto.TailLogsRequest, stream proto.DaemonService_TailLogsServer) error {
+	level := log.TraceLevel
+	if req.GetLevel() != proto.LogLevel_UNKNOWN {
+		var err error
+		if level, err = log.ParseLevel(req.GetLevel().String()); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid log level: %v", err)
+		}
+	}
+	for _, name := range req.GetComponents() {
+		if _, ok := util.LogComponents[name]; !ok {
+			return status.Errorf(codes.InvalidArgument, "unknown log component %q", name)
+		}
+	}
+
+	s.logTailOnce.Do(func() {
+		s.logTail = util.NewLogTail()
+		log.AddHook(s.logTail)
+	})
+
+	redactor := s.logTailRedactor()
+	sub := s.logTail.Subscribe(level, req.GetComponents())
+	defer s.logTail.Unsubscribe(sub)
+	log.Debugf("log tail client subscribed, level %s, components %v", level, req.GetComponents())
+
+	for {
+		select {
+		case line := <-sub.Lines():
+			if err := stream.Send(&proto.LogLine{
+				Time:      timestamppb.New(line.Time),
+				Level:     ParseLogLevel(line.Level.String()),
+				Component: line.Component,
+				Line:      redactor.Redact(line.Text),
+				Dropped:   line.Dropped,
+			}); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// logTailRedactor returns the redactor of streamed log lines. Unlike bundles, which only root gets
+// with secrets, streamed lines are always redacted.
+func (s *Server) logTailRedactor() *debug.SecretRedactor {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	syncResponse, err := s.getLatestSyncResponse()
+	if err != nil {
+		log.Debugf("log tail redacts without the network map secrets: %v", err)
+	}
+	return debug.NewSecretRedactor(s.config, syncResponse)
+}
+
+func formatComponentLevels(components map[string]log.Level, skip []string) string {
+	parts := make([]string, 0, len(components))
+	for name, level := range components {
+		if !slices.Contains(skip, name) {
+			parts = append(parts, name+"="+level.String())
+		}
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
 }
 
 // RegisterUILog records the desktop UI's absolute log path so DebugBundle can
@@ -162,6 +1134,339 @@ func (s *Server) SetSyncResponsePersistence(_ context.Context, req *proto.SetSyn
 	return &proto.SetSyncResponsePersistenceResponse{}, nil
 }
 
+// GetSyncResponsePersistence returns whether sync response persistence is enabled.
+func (s *Server) GetSyncResponsePersistence(_ context.Context, _ *proto.GetSyncResponsePersistenceRequest) (*proto.GetSyncResponsePersistenceResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return &proto.GetSyncResponsePersistenceResponse{Enabled: s.persistSyncResponse}, nil
+}
+
+// ListFeatureFlags returns the effective state of the client's feature flags.
+func (s *Server) ListFeatureFlags(_ context.Context, _ *proto.ListFeatureFlagsRequest) (*proto.ListFeatureFlagsResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	syncResponse, err := s.getLatestSyncResponse()
+	if err != nil {
+		log.Debugf("feature flags without sync response: %v", err)
+	}
+
+	return &proto.ListFeatureFlagsResponse{
+		Flags: toProtoFeatureFlags(debug.CollectFeatureFlags(s.config, syncResponse)),
+	}, nil
+}
+
+// GetBuildInfo returns the build metadata of the daemon and its effective feature flags.
+func (s *Server) GetBuildInfo(_ context.Context, _ *proto.GetBuildInfoRequest) (*proto.GetBuildInfoResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	syncResponse, err := s.getLatestSyncResponse()
+	if err != nil {
+		log.Debugf("build info without sync response: %v", err)
+	}
+
+	build := version.Build()
+	return &proto.GetBuildInfoResponse{
+		Version:   build.Version,
+		Commit:    build.Commit,
+		BuildDate: build.Date,
+		GoVersion: build.GoVersion,
+		Platform:  build.Platform,
+		Flags:     toProtoFeatureFlags(debug.CollectFeatureFlags(s.config, syncResponse)),
+	}, nil
+}
+
+func toProtoFeatureFlags(flags []debug.FeatureFlag) []*proto.FeatureFlag {
+	protoFlags := make([]*proto.FeatureFlag, 0, len(flags))
+	for _, f := range flags {
+		protoFlags = append(protoFlags, &proto.FeatureFlag{
+			Name:         f.Name,
+			Enabled:      f.Enabled,
+			Source:       string(f.Source),
+			Detail:       f.Detail,
+			Experimental: f.Experimental,
+		})
+	}
+	return protoFlags
+}
+
+// GetDropStats returns the userspace filter's inbound packet drop counters.
+func (s *Server) GetDropStats(_ context.Context, _ *proto.GetDropStatsRequest) (*proto.GetDropStatsResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	source := s.dropStatsSource()
+	if source == nil {
+		return nil, errors.New("drop stats are only available with the userspace firewall")
+	}
+
+	stats := source.DropStats()
+	resp := &proto.GetDropStatsResponse{
+		InboundPackets: stats.InboundPackets,
+	}
+	for _, d := range debug.DropCounts(stats) {
+		resp.Drops = append(resp.Drops, &proto.DropCounter{
+			Reason:  d.Reason,
+			Packets: d.Packets,
+		})
+	}
+
+	return resp, nil
+}
+
+// dropStatsSource returns the firewall manager if it keeps drop counters, nil otherwise.
+func (s *Server) dropStatsSource() debug.DropStatsSource {
+	if s.connectClient == nil {
+		return nil
+	}
+
+	engine := s.connectClient.Engine()
+	if engine == nil {
+		return nil
+	}
+
+	source, ok := engine.GetFirewallManager().(debug.DropStatsSource)
+	if !ok {
+		return nil
+	}
+	return source
+}
+
+// firewallManager returns the engine's firewall manager, nil if the engine is not running.
+func (s *Server) firewallManager() firewall.Manager {
+	if s.connectClient == nil {
+		return nil
+	}
+
+	engine := s.connectClient.Engine()
+	if engine == nil {
+		return nil
+	}
+	return engine.GetFirewallManager()
+}
+
+// natSource returns the engine as the source of STUN discovery results, nil if it is not running.
+func (s *Server) natSource() debug.NATSource {
+	if s.connectClient == nil {
+		return nil
+	}
+
+	engine := s.connectClient.Engine()
+	if engine == nil {
+		return nil
+	}
+	return engine
+}
+
+// clockSkewSource returns the engine as the source of the management clock skew, nil if it is
+// not running.
+func (s *Server) clockSkewSource() debug.ClockSkewSource {
+	if s.connectClient == nil {
+		return nil
+	}
+
+	engine := s.connectClient.Engine()
+	if engine == nil {
+		return nil
+	}
+	return engine
+}
+
+// wireGuardImplSource returns the engine as the source of the WireGuard implementation, nil if it
+// is not running.
+func (s *Server) wireGuardImplSource() debug.WireGuardImplSource {
+	if s.connectClient == nil {
+		return nil
+	}
+
+	engine := s.connectClient.Engine()
+	if engine == nil {
+		return nil
+	}
+	return engine
+}
+
+// channelStateSource returns the engine as the source of the management and signal gRPC channel
+// states, nil if it is not running.
+func (s *Server) channelStateSource() debug.ChannelStateSource {
+	if s.connectClient == nil {
+		return nil
+	}
+
+	engine := s.connectClient.Engine()
+	if engine == nil {
+		return nil
+	}
+	return engine
+}
+
+func (s *Server) dnsStateSource() debug.DNSStateSource {
+	if s.connectClient == nil {
+		return nil
+	}
+
+	engine := s.connectClient.Engine()
+	if engine == nil {
+		return nil
+	}
+
+	source, ok := engine.GetDNSServer().(debug.DNSStateSource)
+	if !ok {
+		return nil
+	}
+	return source
+}
+
+// RelayFailoverTest makes the home relay unreachable and reports how the daemon fails over to another relay.
+func (s *Server) RelayFailoverTest(ctx context.Context, req *proto.RelayFailoverTestRequest) (*proto.RelayFailoverTestResponse, error) {
+	s.mutex.Lock()
+	connectClient := s.connectClient
+	s.mutex.Unlock()
+
+	if connectClient == nil {
+		return nil, errors.New("client is not connected")
+	}
+	engine := connectClient.Engine()
+	if engine == nil {
+		return nil, errors.New("engine is not running")
+	}
+	relayManager := engine.GetRelayManager()
+	if relayManager == nil {
+		return nil, errors.New("relay is not configured")
+	}
+
+	timeout := defaultRelayFailoverTimeout
+	if req.GetTimeout() != nil && req.GetTimeout().AsDuration() > 0 {
+		timeout = req.GetTimeout().AsDuration()
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result, err := relayManager.TestFailover(ctx)
+	if err != nil && result == nil {
+		return nil, fmt.Errorf("relay failover test: %w", err)
+	}
+
+	resp := &proto.RelayFailoverTestResponse{
+		From:     result.From,
+		To:       result.To,
+		Duration: durationpb.New(result.Duration),
+	}
+	for _, e := range result.Events {
+		resp.Events = append(resp.Events, &proto.RelayFailoverEvent{
+			Elapsed: durationpb.New(e.Elapsed),
+			Message: e.Message,
+		})
+	}
+	if err != nil {
+		log.Warnf("relay failover test did not complete: %v", err)
+	}
+
+	return resp, nil
+}
+
+// GetStartupTiming returns the duration of each phase of the latest client startup.
+func (s *Server) GetStartupTiming(_ context.Context, _ *proto.GetStartupTimingRequest) (*proto.GetStartupTimingResponse, error) {
+	snapshot := s.startupTiming.Snapshot()
+
+	resp := &proto.GetStartupTimingResponse{
+		Complete: snapshot.Complete,
+		Total:    durationpb.New(snapshot.Total),
+	}
+	if !snapshot.StartedAt.IsZero() {
+		resp.StartedAt = timestamppb.New(snapshot.StartedAt)
+	}
+	for _, p := range snapshot.Phases {
+		resp.Phases = append(resp.Phases, &proto.StartupPhase{
+			Name:     p.Name,
+			Attempt:  int32(p.Attempt),
+			Started:  timestamppb.New(p.Started),
+			Duration: durationpb.New(p.Duration),
+			Error:    p.Err,
+		})
+	}
+
+	return resp, nil
+}
+
+// GetInterfaceConflicts compares the overlay network and routes against the host interfaces.
+func (s *Server) GetInterfaceConflicts(_ context.Context, _ *proto.GetInterfaceConflictsRequest) (*proto.GetInterfaceConflictsResponse, error) {
+	s.mutex.Lock()
+	var wgIface string
+	if s.config != nil {
+		wgIface = s.config.WgIface
+	}
+	s.mutex.Unlock()
+
+	report, err := debug.CheckInterfaceConflicts(s.statusRecorder.GetFullStatus(), wgIface)
+	if err != nil {
+		return nil, fmt.Errorf("check interface conflicts: %w", err)
+	}
+
+	resp := &proto.GetInterfaceConflictsResponse{
+		RoutesChecked:     int32(report.RoutesChecked),
+		InterfacesChecked: int32(report.InterfacesChecked),
+	}
+	for _, prefix := range report.Overlay {
+		resp.Overlay = append(resp.Overlay, prefix.String())
+	}
+	for _, c := range report.Conflicts {
+		resp.Conflicts = append(resp.Conflicts, &proto.InterfaceConflict{
+			Kind:      c.Kind,
+			Network:   c.Network.String(),
+			Interface: c.Interface,
+			Host:      c.Host.String(),
+		})
+	}
+	return resp, nil
+}
+
+// GetRouteConflicts compares the routes installed by NetBird against the host routing table.
+func (s *Server) GetRouteConflicts(_ context.Context, _ *proto.GetRouteConflictsRequest) (*proto.GetRouteConflictsResponse, error) {
+	s.mutex.Lock()
+	var wgIface string
+	if s.config != nil {
+		wgIface = s.config.WgIface
+	}
+	s.mutex.Unlock()
+
+	report, err := debug.CheckRouteConflicts(wgIface)
+	if err != nil {
+		return nil, fmt.Errorf("check route conflicts: %w", err)
+	}
+
+	resp := &proto.GetRouteConflictsResponse{
+		Interface:     report.Interface,
+		PolicyRouting: report.PolicyRouting,
+		NetbirdRoutes: int32(report.NetBirdRoutes),
+		HostRoutes:    int32(report.HostRoutes),
+	}
+	for _, c := range report.Conflicts {
+		resp.Conflicts = append(resp.Conflicts, &proto.RouteConflict{
+			Netbird: toProtoConflictingRoute(c.NetBird),
+			Host:    toProtoConflictingRoute(c.Host),
+			Winner:  c.Winner,
+			Reason:  c.Reason,
+		})
+	}
+	return resp, nil
+}
+
+func toProtoConflictingRoute(r debug.RouteEntry) *proto.ConflictingRoute {
+	route := &proto.ConflictingRoute{
+		Prefix:    r.Prefix.String(),
+		Interface: r.Interface,
+		Table:     r.Table,
+		Metric:    int32(r.Metric),
+	}
+	if r.Gateway.IsValid() {
+		route.Gateway = r.Gateway.String()
+	}
+	return route
+}
+
 func (s *Server) getLatestSyncResponse() (*mgmProto.SyncResponse, error) {
 	cClient := s.connectClient
 	if cClient == nil {
@@ -210,3 +1515,34 @@ func (s *Server) StopCPUProfile(_ context.Context, _ *proto.StopCPUProfileReques
 
 	return &proto.StopCPUProfileResponse{}, nil
 }
+
+// logBaselinePath is the file that keeps the log baseline of incremental bundles.
+func logBaselinePath() string {
+	return filepath.Join(profilemanager.DefaultConfigPathDir, debug.LogBaselineFile)
+}
+
+// bundleLogBaseline returns the baseline of an incremental bundle request, nil for other bundles
+// and for the first incremental one.
+func bundleLogBaseline(req *proto.DebugBundleRequest) (*debug.LogBaseline, error) {
+	if req.GetIncrementalLogs() && req.GetFullLogs() {
+		return nil, status.Error(codes.InvalidArgument, "incremental and full logs cannot be combined")
+	}
+	if req.GetNoLogs() && (req.GetIncrementalLogs() || req.GetFullLogs()) {
+		return nil, status.Error(codes.InvalidArgument, "incremental or full logs cannot be combined with no logs")
+	}
+	if !req.GetIncrementalLogs() {
+		return nil, nil
+	}
+
+	baseline, err := debug.LoadLogBaseline(logBaselinePath())
+	if err != nil {
+		log.Warnf("failed to load the log baseline, the bundle has the whole logs: %v", err)
+		return nil, nil
+	}
+	if baseline == nil {
+		log.Info("no log baseline recorded yet, the incremental bundle has the whole logs")
+		return nil, nil
+	}
+	log.Infof("leaving out the log content of the baseline bundle %s", baseline.Bundle)
+	return baseline, nil
+}
@@ -3,7 +3,11 @@ package server
 import (
 	"strings"
 
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netbirdio/netbird/client/internal/profilemanager"
 	"github.com/netbirdio/netbird/client/proto"
+	"github.com/netbirdio/netbird/util"
 )
 
 func ParseLogLevel(level string) proto.LogLevel {
@@ -14,7 +18,7 @@ func ParseLogLevel(level string) proto.LogLevel {
 		return proto.LogLevel_FATAL
 	case "error":
 		return proto.LogLevel_ERROR
-	case "warn":
+	case "warn", "warning":
 		return proto.LogLevel_WARN
 	case "info":
 		return proto.LogLevel_INFO
@@ -26,3 +30,31 @@ func ParseLogLevel(level string) proto.LogLevel {
 		return proto.LogLevel_UNKNOWN
 	}
 }
+
+// applyPersistedLogLevel applies the log level persisted with "netbird debug log level --persist".
+func applyPersistedLogLevel(config *profilemanager.Config) {
+	if config.LogLevel == "" {
+		return
+	}
+
+	level, err := log.ParseLevel(config.LogLevel)
+	if err != nil {
+		log.Warnf("ignoring persisted log level: %v", err)
+		return
+	}
+
+	components := make(map[string]log.Level, len(config.ComponentLogLevels))
+	for name, value := range config.ComponentLogLevels {
+		componentLevel, err := log.ParseLevel(value)
+		if err != nil {
+			log.Warnf("ignoring persisted log level of component %s: %v", name, err)
+			continue
+		}
+		components[name] = componentLevel
+	}
+
+	for _, name := range util.SetComponentLevels(log.StandardLogger(), level, components) {
+		log.Warnf("ignoring persisted log level for unknown component %q", name)
+	}
+	log.Infof("Log level set to %s from the profile config", level.String())
+}
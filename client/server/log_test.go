@@ -0,0 +1,68 @@
+package server
+
+import (
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netbirdio/netbird/client/internal/profilemanager"
+	"github.com/netbirdio/netbird/util"
+)
+
+// keepLogLevels restores the log levels of the standard logger when the test ends.
+func keepLogLevels(t *testing.T) {
+	t.Helper()
+	level, components := util.ComponentLevels(log.StandardLogger())
+	t.Cleanup(func() {
+		util.SetComponentLevels(log.StandardLogger(), level, components)
+	})
+}
+
+func TestApplyPersistedLogLevel(t *testing.T) {
+	keepLogLevels(t)
+
+	tests := []struct {
+		name           string
+		config         *profilemanager.Config
+		wantLevel      log.Level
+		wantComponents map[string]log.Level
+	}{
+		{
+			name:      "nothing persisted",
+			config:    &profilemanager.Config{},
+			wantLevel: log.WarnLevel,
+		},
+		{
+			name:      "base level",
+			config:    &profilemanager.Config{LogLevel: "debug"},
+			wantLevel: log.DebugLevel,
+		},
+		{
+			name: "component levels",
+			config: &profilemanager.Config{
+				LogLevel:           "info",
+				ComponentLogLevels: map[string]string{"ice": "trace", "bogus": "debug", "dns": "loud"},
+			},
+			wantLevel:      log.InfoLevel,
+			wantComponents: map[string]log.Level{"ice": log.TraceLevel},
+		},
+		{
+			name:      "invalid level",
+			config:    &profilemanager.Config{LogLevel: "loud", ComponentLogLevels: map[string]string{"ice": "trace"}},
+			wantLevel: log.WarnLevel,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			util.SetComponentLevels(log.StandardLogger(), log.WarnLevel, nil)
+
+			applyPersistedLogLevel(tt.config)
+
+			level, components := util.ComponentLevels(log.StandardLogger())
+			assert.Equal(t, tt.wantLevel, level)
+			assert.Equal(t, tt.wantComponents, components)
+		})
+	}
+}
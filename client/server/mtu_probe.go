@@ -0,0 +1,129 @@
+//go:build !android && !ios
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/netbirdio/netbird/client/iface/netstack"
+	"github.com/netbirdio/netbird/client/internal"
+	"github.com/netbirdio/netbird/client/internal/debug"
+	"github.com/netbirdio/netbird/client/internal/mtuprobe"
+	"github.com/netbirdio/netbird/client/internal/peer"
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+// ProbePeerMTU discovers the largest packet size that reaches each requested peer through the tunnel.
+func (s *Server) ProbePeerMTU(ctx context.Context, req *proto.ProbePeerMTURequest) (*proto.ProbePeerMTUResponse, error) {
+	if !req.GetAll() && len(req.GetPeers()) == 0 {
+		return nil, errors.New("no peers given")
+	}
+
+	s.mutex.Lock()
+	connectClient := s.connectClient
+	s.mutex.Unlock()
+
+	report, err := s.probePeerMTU(ctx, connectClient, req.GetPeers(), req.GetAll(), int(req.GetParallelism()))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &proto.ProbePeerMTUResponse{TunnelMtu: uint32(report.TunnelMTU)}
+	for _, p := range report.Peers {
+		resp.Results = append(resp.Results, &proto.PeerMTUResult{
+			Peer:    p.Peer,
+			Ip:      p.IP,
+			PathMtu: uint32(p.PathMTU),
+			Probes:  uint32(p.Probes),
+			Error:   p.Error,
+		})
+	}
+	return resp, nil
+}
+
+// probePeerMTU probes the given peers, or all connected peers if all is set. It does not take s.mutex,
+// the caller passes the connect client so probing doesn't block other RPCs.
+func (s *Server) probePeerMTU(ctx context.Context, connectClient *internal.ConnectClient, peers []string, all bool, parallelism int) (*debug.PeerMTUReport, error) {
+	if connectClient == nil {
+		return nil, errors.New("client is not connected")
+	}
+	engine := connectClient.Engine()
+	if engine == nil {
+		return nil, errors.New("engine is not running")
+	}
+	if netstack.IsEnabled() {
+		return nil, errors.New("peer MTU probing is not supported in netstack mode")
+	}
+
+	targets, err := mtuProbeTargets(s.statusRecorder.GetFullStatus().Peers, peers, all)
+	if err != nil {
+		return nil, err
+	}
+
+	tunnelMTU := int(engine.GetMTU())
+	results := mtuprobe.ProbeAll(ctx, &mtuprobe.ICMPPinger{}, targets, tunnelMTU, parallelism)
+
+	return &debug.PeerMTUReport{
+		TunnelMTU: tunnelMTU,
+		Peers:     debug.PeerMTUs(results),
+	}, nil
+}
+
+// mtuProbeTargets selects the connected peers when all is set, otherwise the peers matching
+// the given FQDNs, FQDN labels or overlay IPs.
+func mtuProbeTargets(states []peer.State, peers []string, all bool) ([]mtuprobe.Target, error) {
+	var targets []mtuprobe.Target
+	if all {
+		for _, st := range states {
+			if st.ConnStatus != peer.StatusConnected {
+				continue
+			}
+			if target, ok := mtuProbeTarget(st); ok {
+				targets = append(targets, target)
+			}
+		}
+		return targets, nil
+	}
+
+	for _, name := range peers {
+		st, ok := findPeerState(states, name)
+		if !ok {
+			return nil, fmt.Errorf("peer %s not found", name)
+		}
+		target, ok := mtuProbeTarget(st)
+		if !ok {
+			return nil, fmt.Errorf("peer %s has no IPv4 overlay address", name)
+		}
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
+
+func mtuProbeTarget(st peer.State) (mtuprobe.Target, bool) {
+	addr, err := netip.ParseAddr(strings.Split(st.IP, "/")[0])
+	if err != nil || !addr.Is4() {
+		return mtuprobe.Target{}, false
+	}
+
+	name := st.FQDN
+	if name == "" {
+		name = st.PubKey
+	}
+	return mtuprobe.Target{Name: name, Addr: addr}, true
+}
+
+func findPeerState(states []peer.State, name string) (peer.State, bool) {
+	name = strings.TrimSuffix(strings.ToLower(name), ".")
+	for _, st := range states {
+		fqdn := strings.ToLower(st.FQDN)
+		label, _, _ := strings.Cut(fqdn, ".")
+		if name == fqdn || name == label || name == strings.Split(st.IP, "/")[0] {
+			return st, true
+		}
+	}
+	return peer.State{}, false
+}
@@ -0,0 +1,219 @@
+//go:build !android && !ios
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	gstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/netbirdio/netbird/client/iface/netstack"
+	"github.com/netbirdio/netbird/client/internal"
+	"github.com/netbirdio/netbird/client/internal/debug"
+	"github.com/netbirdio/netbird/client/internal/mtuprobe"
+	"github.com/netbirdio/netbird/client/internal/peer"
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+const (
+	defaultPeerProbeCount   = 3
+	maxPeerProbeCount       = 100
+	defaultPeerProbeTimeout = 15 * time.Second
+	// peerProbeEchoSize is the size of the echo requests, small enough for any tunnel MTU.
+	peerProbeEchoSize = 64
+	// maxPeerProbes and maxPeerProbeAge bound the probe results kept for debug bundles.
+	maxPeerProbes   = 20
+	maxPeerProbeAge = time.Hour
+)
+
+// PeerProbe opens the connection to a peer if it is not up and pings the peer through the tunnel.
+// An unknown peer fails with NotFound, an unreachable one is reported in the response.
+func (s *Server) PeerProbe(ctx context.Context, req *proto.PeerProbeRequest) (*proto.PeerProbeResponse, error) {
+	s.mutex.Lock()
+	connectClient := s.connectClient
+	s.mutex.Unlock()
+
+	if connectClient == nil {
+		return nil, gstatus.Error(codes.FailedPrecondition, "client is not connected")
+	}
+	engine := connectClient.Engine()
+	if engine == nil {
+		return nil, gstatus.Error(codes.FailedPrecondition, "engine is not running")
+	}
+
+	st, ok := findPeerState(s.statusRecorder.GetFullStatus().Peers, req.GetPeer())
+	if !ok {
+		return nil, gstatus.Errorf(codes.NotFound, "peer %s not found", req.GetPeer())
+	}
+
+	count := int(req.GetCount())
+	if count == 0 {
+		count = defaultPeerProbeCount
+	}
+	if count > maxPeerProbeCount {
+		return nil, gstatus.Errorf(codes.InvalidArgument, "count %d above maximum %d", count, maxPeerProbeCount)
+	}
+	timeout := defaultPeerProbeTimeout
+	if req.GetTimeout() != nil {
+		timeout = req.GetTimeout().AsDuration()
+	}
+	if timeout <= 0 {
+		return nil, gstatus.Error(codes.InvalidArgument, "timeout must be positive")
+	}
+
+	probe := s.probePeer(ctx, engine, st, count, timeout)
+	s.recordPeerProbe(probe)
+	return toProtoPeerProbe(probe), nil
+}
+
+// probePeer activates the connection to the peer, waits until it is connected and sends count
+// echo requests through the tunnel.
+func (s *Server) probePeer(ctx context.Context, engine *internal.Engine, st peer.State, count int, timeout time.Duration) debug.PeerProbe {
+	probe := debug.PeerProbe{
+		Peer: st.FQDN,
+		IP:   strings.Split(st.IP, "/")[0],
+		Time: time.Now(),
+	}
+	if probe.Peer == "" {
+		probe.Peer = st.PubKey
+	}
+
+	if st.ConnStatus != peer.StatusConnected {
+		started := time.Now()
+		if !engine.ActivatePeer(ctx, st.PubKey) {
+			probe.Error = "peer has no connection in the engine"
+			return probe
+		}
+		connected, err := s.waitPeerConnected(ctx, st.PubKey, timeout)
+		if err != nil {
+			probe.Error = err.Error()
+		}
+		if !connected {
+			return probe
+		}
+		probe.ConnectTime = time.Since(started)
+	}
+
+	if current, err := s.statusRecorder.GetPeer(st.PubKey); err == nil {
+		st = current
+	}
+	probe.Relayed = st.Relayed
+	probe.RelayAddress = st.RelayServerAddress
+	probe.LocalCandidate = st.LocalIceCandidateType
+	probe.RemoteCandidate = st.RemoteIceCandidateType
+	probe.LocalEndpoint = st.LocalIceCandidateEndpoint
+	probe.RemoteEndpoint = st.RemoteIceCandidateEndpoint
+
+	addr, err := netip.ParseAddr(probe.IP)
+	switch {
+	case netstack.IsEnabled():
+		probe.Reachable = true
+		probe.Note = "pinging through the tunnel is not supported in netstack mode, the connection is up"
+		return probe
+	case err != nil || !addr.Is4():
+		probe.Reachable = true
+		probe.Note = "the peer has no IPv4 overlay address to ping, the connection is up"
+		return probe
+	}
+
+	pinger := &mtuprobe.ICMPPinger{Timeout: min(timeout, mtuprobe.DefaultTimeout)}
+	var lastErr error
+	for i := 0; i < count && ctx.Err() == nil; i++ {
+		sent := time.Now()
+		if err := pinger.Ping(ctx, addr, peerProbeEchoSize); err != nil {
+			probe.Lost++
+			lastErr = err
+			continue
+		}
+		probe.RTTs = append(probe.RTTs, time.Since(sent))
+	}
+
+	probe.Reachable = len(probe.RTTs) > 0
+	if !probe.Reachable && lastErr != nil {
+		probe.Error = fmt.Sprintf("connected, but no echo reply through the tunnel: %v", lastErr)
+	}
+	return probe
+}
+
+// waitPeerConnected polls the peer state until it is connected, the timeout passes or ctx is done.
+func (s *Server) waitPeerConnected(ctx context.Context, pubKey string, timeout time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		st, err := s.statusRecorder.GetPeer(pubKey)
+		if err != nil {
+			return false, fmt.Errorf("peer was removed: %w", err)
+		}
+		if st.ConnStatus == peer.StatusConnected {
+			return true, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return false, fmt.Errorf("connection not established within %s, the peer is %s", timeout, strings.ToLower(st.ConnStatus.String()))
+		case <-ticker.C:
+		}
+	}
+}
+
+// recordPeerProbe keeps the probe for the next debug bundles.
+func (s *Server) recordPeerProbe(probe debug.PeerProbe) {
+	s.peerProbesMu.Lock()
+	defer s.peerProbesMu.Unlock()
+
+	s.peerProbes = append(s.peerProbes, probe)
+	if len(s.peerProbes) > maxPeerProbes {
+		s.peerProbes = s.peerProbes[len(s.peerProbes)-maxPeerProbes:]
+	}
+	log.Debugf("peer probe to %s: reachable %t, %d/%d echo replies", probe.Peer, probe.Reachable, len(probe.RTTs), len(probe.RTTs)+probe.Lost)
+}
+
+// recentPeerProbes returns the probes of the last hour, oldest first.
+func (s *Server) recentPeerProbes() []debug.PeerProbe {
+	s.peerProbesMu.Lock()
+	defer s.peerProbesMu.Unlock()
+
+	cutoff := time.Now().Add(-maxPeerProbeAge)
+	var recent []debug.PeerProbe
+	for _, p := range s.peerProbes {
+		if p.Time.After(cutoff) {
+			recent = append(recent, p)
+		}
+	}
+	return recent
+}
+
+func toProtoPeerProbe(p debug.PeerProbe) *proto.PeerProbeResponse {
+	resp := &proto.PeerProbeResponse{
+		Peer:                       p.Peer,
+		Ip:                         p.IP,
+		Reachable:                  p.Reachable,
+		Error:                      p.Error,
+		Relayed:                    p.Relayed,
+		RelayAddress:               p.RelayAddress,
+		LocalIceCandidateType:      p.LocalCandidate,
+		RemoteIceCandidateType:     p.RemoteCandidate,
+		LocalIceCandidateEndpoint:  p.LocalEndpoint,
+		RemoteIceCandidateEndpoint: p.RemoteEndpoint,
+		Lost:                       uint32(p.Lost),
+		Note:                       p.Note,
+	}
+	if p.ConnectTime > 0 {
+		resp.ConnectTime = durationpb.New(p.ConnectTime)
+	}
+	for _, rtt := range p.RTTs {
+		resp.Rtts = append(resp.Rtts, durationpb.New(rtt))
+	}
+	return resp
+}
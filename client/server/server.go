@@ -3,6 +3,7 @@ package server
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -19,9 +20,11 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	gstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/netbirdio/netbird/client/internal/auth"
+	"github.com/netbirdio/netbird/client/internal/debug"
 	"github.com/netbirdio/netbird/client/internal/expose"
 	"github.com/netbirdio/netbird/client/internal/profilemanager"
 	sleephandler "github.com/netbirdio/netbird/client/internal/sleep/handler"
@@ -32,9 +35,11 @@ import (
 
 	"github.com/netbirdio/netbird/client/internal"
 	"github.com/netbirdio/netbird/client/internal/peer"
+	"github.com/netbirdio/netbird/client/internal/startuptiming"
 	"github.com/netbirdio/netbird/client/internal/statemanager"
 	"github.com/netbirdio/netbird/client/internal/updater"
 	"github.com/netbirdio/netbird/client/proto"
+	"github.com/netbirdio/netbird/util"
 	"github.com/netbirdio/netbird/util/capture"
 	"github.com/netbirdio/netbird/version"
 )
@@ -125,6 +130,39 @@ type Server struct {
 
 	updateManager *updater.Manager
 
+	// startupTiming records the phases of the latest client startup.
+	startupTiming *startuptiming.Recorder
+	// startedAt is when Start first ran in this process, zero before.
+	startedAt time.Time
+
+	// defaultLogLevel is the log level the daemon was started with.
+	defaultLogLevel log.Level
+
+	// logTail fans out log lines to TailLogs clients. It is hooked into the standard logger by
+	// the first TailLogs call.
+	logTail     *util.LogTail
+	logTailOnce sync.Once
+
+	// bundleMu is held while a debug bundle is generated or uploaded, so bundles run one at a
+	// time without holding mutex.
+	bundleMu sync.Mutex
+	// lastBundlePath is the debug bundle generated last, uploaded by UploadLast requests.
+	// Guarded by bundleMu.
+	lastBundlePath       string
+	lastBundleAnonymized bool
+	lastBundleSplit      bool
+	lastBundleSecrets    bool
+	// lastBundlePrivileged is set when the last bundle has extra files or was written to an
+	// output directory, options only root has.
+	lastBundlePrivileged bool
+
+	// peerProbes are the recent PeerProbe results, added to debug bundles.
+	peerProbesMu sync.Mutex
+	peerProbes   []debug.PeerProbe
+
+	// benchmarkMu is held while a BenchmarkPeer runs, so benchmarks don't skew each other.
+	benchmarkMu sync.Mutex
+
 	jwtCache *jwtCache
 }
 
@@ -150,6 +188,8 @@ func New(ctx context.Context, logFile string, configFile string, profilesDisable
 		jwtCache:               newJWTCache(),
 		extendAuthSessionFlow:  auth.NewPendingFlow(),
 		probeThrottle:          newProbeThrottle(probeThreshold),
+		startupTiming:          startuptiming.New(),
+		defaultLogLevel:        log.GetLevel(),
 	}
 	agent := &serverAgent{s}
 	s.sleepHandler = sleephandler.New(agent)
@@ -158,6 +198,26 @@ func New(ctx context.Context, logFile string, configFile string, profilesDisable
 	return s
 }
 
+// recordDaemonStart counts this daemon start in the persisted start history and passes it to the
+// status recorder, so restarts show in the status even before the client connects.
+func (s *Server) recordDaemonStart() {
+	s.startedAt = time.Now()
+
+	starts, err := s.profileManager.RecordDaemonStart(s.startedAt)
+	if err != nil {
+		log.Warnf("failed to record daemon start: %v", err)
+	}
+	if restarts := starts.Restarts(); restarts > 0 {
+		log.Infof("daemon started, %d restarts before, %d starts in the last 24 hours", restarts, len(starts.Recent))
+	}
+
+	s.statusRecorder.SetDaemonStart(peer.DaemonStart{
+		StartedAt:     s.startedAt,
+		RestartCount:  starts.Restarts(),
+		StartsLast24h: uint32(len(starts.Recent)),
+	})
+}
+
 func (s *Server) Start() error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
@@ -181,6 +241,10 @@ func (s *Server) Start() error {
 		log.Warnf("failed to redirect stderr: %v", err)
 	}
 
+	if s.startedAt.IsZero() {
+		s.recordDaemonStart()
+	}
+
 	if err := RestoreResidualState(s.rootCtx, s.profileManager.GetStatePath()); err != nil {
 		log.Warnf(errRestoreResidualState, err)
 	}
@@ -228,13 +292,17 @@ func (s *Server) Start() error {
 		return fmt.Errorf("failed to get active profile state: %w", err)
 	}
 
+	s.startupTiming.Begin()
+	doneStartup := s.startupTiming.Start(startuptiming.PhaseConfigLoad)
 	config, existingConfig, err := s.getConfig(activeProf)
+	doneStartup(err)
 	if err != nil {
 		log.Errorf("failed to get active profile config: %v", err)
 
 		return err
 	}
 	s.config = config
+	applyPersistedLogLevel(config)
 
 	s.statusRecorder.UpdateManagementAddress(config.ManagementURL.String())
 	s.statusRecorder.UpdateRosenpass(config.RosenpassEnabled, config.RosenpassPermissive)
@@ -935,7 +1003,10 @@ func (s *Server) Up(callerCtx context.Context, msg *proto.UpRequest) (*proto.UpR
 
 	log.Infof("active profile: %s for %s", activeProf.ID, activeProf.Username)
 
+	s.startupTiming.Begin()
+	doneStartup := s.startupTiming.Start(startuptiming.PhaseConfigLoad)
 	config, _, err := s.getConfig(activeProf)
+	doneStartup(err)
 	if err != nil {
 		s.mutex.Unlock()
 		log.Errorf("failed to get active profile config: %v", err)
@@ -1441,12 +1512,32 @@ func (s *Server) buildStatusResponse(ctx context.Context, msg *proto.StatusReque
 		pbFullStatus.Events = s.statusRecorder.GetEventHistory()
 		pbFullStatus.SshServerState = s.getSSHServerState()
 		pbFullStatus.NetworksRevision = s.statusRecorder.GetNetworksRevision()
+		if offset, ok := s.managementClockOffset(); ok && pbFullStatus.ManagementState != nil {
+			pbFullStatus.ManagementState.ClockOffset = durationpb.New(offset)
+		}
 		statusResponse.FullStatus = pbFullStatus
 	}
 
 	return &statusResponse, nil
 }
 
+// managementClockOffset returns the clock skew to the management server measured by the engine.
+func (s *Server) managementClockOffset() (time.Duration, bool) {
+	s.mutex.Lock()
+	connectClient := s.connectClient
+	s.mutex.Unlock()
+
+	if connectClient == nil {
+		return 0, false
+	}
+	engine := connectClient.Engine()
+	if engine == nil {
+		return 0, false
+	}
+	offset, _, ok := engine.ManagementClockSkew()
+	return offset, ok
+}
+
 // getSSHServerState retrieves the current SSH server state including enabled status and active sessions
 func (s *Server) getSSHServerState() *proto.SSHServerState {
 	s.mutex.Lock()
@@ -1992,6 +2083,37 @@ func (s *Server) GetConfig(ctx context.Context, req *proto.GetConfigRequest) (*p
 	}, nil
 }
 
+// GetEffectiveConfig returns the configuration the daemon is running with. Secrets are redacted
+// unless a root caller asks for them.
+func (s *Server) GetEffectiveConfig(ctx context.Context, req *proto.GetEffectiveConfigRequest) (*proto.GetEffectiveConfigResponse, error) {
+	if req.GetShowSecrets() {
+		if err := checkPrivilegedCaller(ctx, "showing config secrets"); err != nil {
+			return nil, err
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.config == nil {
+		return nil, gstatus.Error(codes.FailedPrecondition, "config is not defined, please call login command first")
+	}
+
+	cfg := s.config
+	if !req.GetShowSecrets() {
+		cfg = cfg.Redacted()
+	}
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return nil, gstatus.Errorf(codes.Internal, "marshal config: %v", err)
+	}
+
+	return &proto.GetEffectiveConfigResponse{
+		Config:           string(data),
+		MDMManagedFields: s.config.Policy().ManagedKeys(),
+	}, nil
+}
+
 // AddProfile adds a new profile to the daemon.
 func (s *Server) AddProfile(ctx context.Context, msg *proto.AddProfileRequest) (*proto.AddProfileResponse, error) {
 	s.mutex.Lock()
@@ -2218,6 +2340,7 @@ func (s *Server) connect(ctx context.Context, config *profilemanager.Config, sta
 	log.Tracef("running client connection")
 	client := internal.NewConnectClient(ctx, config, statusRecorder)
 	client.SetUpdateManager(s.updateManager)
+	client.SetStartupTiming(s.startupTiming)
 	client.SetSyncResponsePersistence(s.persistSyncResponse)
 
 	s.mutex.Lock()
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"net/url"
 	"os/user"
 	"path/filepath"
@@ -12,6 +13,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/netbirdio/netbird/client/internal"
 	"github.com/netbirdio/netbird/client/internal/profilemanager"
@@ -157,3 +160,31 @@ func TestServer_SubcribeEvents(t *testing.T) {
 
 	assert.NoError(t, err)
 }
+
+func TestServer_GetEffectiveConfigSecrets(t *testing.T) {
+	s := &Server{config: &profilemanager.Config{
+		PrivateKey:   "private-key",
+		PreSharedKey: "pre-shared-key",
+	}}
+
+	_, err := s.GetEffectiveConfig(userCallerContext(), &daemonProto.GetEffectiveConfigRequest{ShowSecrets: true})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "only root sees secrets")
+
+	_, err = s.GetEffectiveConfig(context.Background(), &daemonProto.GetEffectiveConfigRequest{ShowSecrets: true})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "unknown callers do not see secrets")
+
+	resp, err := s.GetEffectiveConfig(userCallerContext(), &daemonProto.GetEffectiveConfigRequest{})
+	require.NoError(t, err)
+	var redacted profilemanager.Config
+	require.NoError(t, json.Unmarshal([]byte(resp.GetConfig()), &redacted))
+	assert.NotEqual(t, "private-key", redacted.PrivateKey)
+	assert.NotEqual(t, "pre-shared-key", redacted.PreSharedKey)
+	assert.NotContains(t, resp.GetConfig(), "private-key")
+
+	resp, err = s.GetEffectiveConfig(rootCallerContext(), &daemonProto.GetEffectiveConfigRequest{ShowSecrets: true})
+	require.NoError(t, err)
+	var full profilemanager.Config
+	require.NoError(t, json.Unmarshal([]byte(resp.GetConfig()), &full))
+	assert.Equal(t, "private-key", full.PrivateKey)
+	assert.Equal(t, "pre-shared-key", full.PreSharedKey)
+}
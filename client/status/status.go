@@ -7,6 +7,7 @@ import (
 	"net/netip"
 	"os"
 	"runtime"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -54,11 +55,49 @@ type ConvertOptions struct {
 	PrefixNamesFilterMap map[string]struct{}
 	IPsFilter            map[string]struct{}
 	ConnectionTypeFilter string
-	ProfileName          string
+	// PeerSelectors keeps only the peers matched by any of the selectors, see SelectPeers.
+	PeerSelectors []string
+	ProfileName   string
+	// GroupFilter keeps only the peers in the group with this ID, see PeersInGroup.
+	GroupFilter string
+	// DomainFilter keeps only the peers, nameserver groups and networks related to this domain,
+	// see PeersForDomain and NSGroupsForDomain.
+	DomainFilter string
 	// SessionExpiresAt is the absolute UTC instant at which the peer's SSO
 	// session expires. Zero when the peer is not SSO-tracked or login
 	// expiration is disabled. Sourced from StatusResponse.SessionExpiresAt.
 	SessionExpiresAt time.Time
+	// Anonymizer is used when Anonymize is set, e.g. one loaded with earlier mappings.
+	// Nil creates a fresh anonymizer.
+	Anonymizer *anonymize.Anonymizer
+	// AnonymizeLevel overrides Anonymize when set. Empty maps Anonymize to
+	// anonymize.LevelFull or anonymize.LevelNone.
+	AnonymizeLevel anonymize.Level
+	// StaleHandshakeThreshold is the handshake age after which a connected peer is stale.
+	// Zero uses DefaultStaleHandshakeThreshold.
+	StaleHandshakeThreshold time.Duration
+}
+
+// DefaultStaleHandshakeThreshold is the default handshake age after which a connected peer is
+// stale. WireGuard renews the handshake of an active session every two minutes and rejects
+// session keys older than three minutes, and the keepalive keeps connected sessions active.
+const DefaultStaleHandshakeThreshold = 3 * time.Minute
+
+// IsStaleHandshake reports whether a connected peer had no WireGuard handshake within threshold.
+// A peer that never completed a handshake is stale once its connection is older than threshold.
+// Peers that are not connected are never stale.
+func IsStaleHandshake(p *proto.PeerState, threshold time.Duration, now time.Time) bool {
+	if p.GetConnStatus() != peer.StatusConnected.String() {
+		return false
+	}
+	if threshold <= 0 {
+		threshold = DefaultStaleHandshakeThreshold
+	}
+	last := p.GetLastWireguardHandshake()
+	if last == nil || last.AsTime().IsZero() {
+		return now.Sub(p.GetConnStatusUpdate().AsTime()) > threshold
+	}
+	return now.Sub(last.AsTime()) > threshold
 }
 
 type PeerStateDetailOutput struct {
@@ -78,12 +117,35 @@ type PeerStateDetailOutput struct {
 	Latency                time.Duration    `json:"latency" yaml:"latency"`
 	RosenpassEnabled       bool             `json:"quantumResistance" yaml:"quantumResistance"`
 	Networks               []string         `json:"networks" yaml:"networks"`
+	// ConnHistory holds the recent connection events of the peer, oldest first.
+	ConnHistory []PeerConnEventOutput `json:"connectionHistory,omitempty" yaml:"connectionHistory,omitempty"`
+	// Stale is set for connected peers without a WireGuard handshake within the stale
+	// threshold, see IsStaleHandshake.
+	Stale bool `json:"stale" yaml:"stale"`
+	// Groups are the IDs of the groups the peer is in, without the All group.
+	Groups []string `json:"groups,omitempty" yaml:"groups,omitempty"`
+}
+
+type PeerConnEventOutput struct {
+	Time   time.Time `json:"time" yaml:"time"`
+	Event  string    `json:"event" yaml:"event"`
+	Detail string    `json:"detail,omitempty" yaml:"detail,omitempty"`
 }
 
 type PeersStateOutput struct {
 	Total     int                     `json:"total" yaml:"total"`
 	Connected int                     `json:"connected" yaml:"connected"`
+	Stale     int                     `json:"stale" yaml:"stale"`
 	Details   []PeerStateDetailOutput `json:"details" yaml:"details"`
+	// Groups counts the peers of each group, sorted by ID. Empty when management sends no groups.
+	Groups []PeerGroupOutput `json:"groups,omitempty" yaml:"groups,omitempty"`
+}
+
+// PeerGroupOutput counts the peers of a group.
+type PeerGroupOutput struct {
+	ID        string `json:"id" yaml:"id"`
+	Total     int    `json:"total" yaml:"total"`
+	Connected int    `json:"connected" yaml:"connected"`
 }
 
 type SignalStateOutput struct {
@@ -96,6 +158,8 @@ type ManagementStateOutput struct {
 	URL       string `json:"url" yaml:"url"`
 	Connected bool   `json:"connected" yaml:"connected"`
 	Error     string `json:"error" yaml:"error"`
+	// Capabilities are the capabilities the server offered in the last full sync.
+	Capabilities []string `json:"capabilities,omitempty" yaml:"capabilities,omitempty"`
 }
 
 type RelayStateOutputDetail struct {
@@ -103,6 +167,8 @@ type RelayStateOutputDetail struct {
 	Available bool   `json:"available" yaml:"available"`
 	Error     string `json:"error" yaml:"error"`
 	Transport string `json:"transport,omitempty" yaml:"transport,omitempty"`
+	// Latency is the round trip of the relay auth handshake, zero if not connected.
+	Latency time.Duration `json:"latency,omitempty" yaml:"latency,omitempty"`
 }
 
 type RelayStateOutput struct {
@@ -111,6 +177,19 @@ type RelayStateOutput struct {
 	Details   []RelayStateOutputDetail `json:"details" yaml:"details"`
 }
 
+// latency returns the measured latency of the relay server with the given address, zero if unknown.
+func (r RelayStateOutput) latency(address string) time.Duration {
+	if address == "" {
+		return 0
+	}
+	for _, detail := range r.Details {
+		if detail.URI == address {
+			return detail.Latency
+		}
+	}
+	return 0
+}
+
 type IceCandidateType struct {
 	Local  string `json:"local" yaml:"local"`
 	Remote string `json:"remote" yaml:"remote"`
@@ -164,27 +243,68 @@ type OutputOverview struct {
 	// expiration is disabled. Pointer (rather than zero-value time.Time) so
 	// JSON / YAML omit the field entirely with `,omitempty`.
 	SessionExpiresAt *time.Time `json:"sessionExpiresAt,omitempty" yaml:"sessionExpiresAt,omitempty"`
+	// Daemon reports the uptime and restarts of the daemon. nil for daemons that do not report them.
+	Daemon *DaemonStateOutput `json:"daemon,omitempty" yaml:"daemon,omitempty"`
+	// ExitNodes lists the peers offering an exit node, sorted by network ID.
+	ExitNodes []ExitNodeOutput `json:"exitNodes,omitempty" yaml:"exitNodes,omitempty"`
+}
+
+// ExitNodeOutput is a peer offering an exit node. Peers with the same network ID are candidates
+// for the same exit node, traffic leaves through the active one.
+type ExitNodeOutput struct {
+	NetID    string        `json:"netId" yaml:"netId"`
+	Network  string        `json:"network" yaml:"network"`
+	FQDN     string        `json:"fqdn" yaml:"fqdn"`
+	IP       string        `json:"netbirdIp" yaml:"netbirdIp"`
+	PubKey   string        `json:"publicKey" yaml:"publicKey"`
+	Status   string        `json:"status" yaml:"status"`
+	Relayed  bool          `json:"relayed" yaml:"relayed"`
+	Latency  time.Duration `json:"latency" yaml:"latency"`
+	Selected bool          `json:"selected" yaml:"selected"`
+	Active   bool          `json:"active" yaml:"active"`
+}
+
+// DaemonStateOutput reports when the daemon started and how often it restarted before.
+type DaemonStateOutput struct {
+	StartedAt     time.Time `json:"startedAt" yaml:"startedAt"`
+	RestartCount  uint32    `json:"restartCount" yaml:"restartCount"`
+	StartsLast24h uint32    `json:"startsLast24h" yaml:"startsLast24h"`
 }
 
 // ConvertToStatusOutputOverview converts protobuf status to the output overview.
 func ConvertToStatusOutputOverview(pbFullStatus *proto.FullStatus, opts ConvertOptions) OutputOverview {
 	managementState := pbFullStatus.GetManagementState()
 	managementOverview := ManagementStateOutput{
-		URL:       managementState.GetURL(),
-		Connected: managementState.GetConnected(),
-		Error:     managementState.Error,
+		URL:          managementState.GetURL(),
+		Connected:    managementState.GetConnected(),
+		Error:        managementState.GetError(),
+		Capabilities: managementState.GetCapabilities(),
 	}
 
 	signalState := pbFullStatus.GetSignalState()
 	signalOverview := SignalStateOutput{
 		URL:       signalState.GetURL(),
 		Connected: signalState.GetConnected(),
-		Error:     signalState.Error,
+		Error:     signalState.GetError(),
 	}
 
 	relayOverview := mapRelays(pbFullStatus.GetRelays())
 	sshServerOverview := mapSSHServer(pbFullStatus.GetSshServerState())
-	peersOverview := mapPeers(pbFullStatus.GetPeers(), opts.StatusFilter, opts.PrefixNamesFilter, opts.PrefixNamesFilterMap, opts.IPsFilter, opts.ConnectionTypeFilter)
+	peers := pbFullStatus.GetPeers()
+	if len(opts.PeerSelectors) > 0 {
+		peers, _ = SelectPeers(peers, opts.PeerSelectors)
+	}
+	if opts.GroupFilter != "" {
+		peers = PeersInGroup(peers, opts.GroupFilter)
+	}
+	nsGroups := pbFullStatus.GetDnsServers()
+	networks := pbFullStatus.GetLocalPeerState().GetNetworks()
+	if opts.DomainFilter != "" {
+		peers = PeersForDomain(peers, nsGroups, opts.DomainFilter)
+		nsGroups = NSGroupsForDomain(nsGroups, opts.DomainFilter)
+		networks = NetworksForDomain(networks, opts.DomainFilter)
+	}
+	peersOverview := mapPeers(peers, opts.StatusFilter, opts.PrefixNamesFilter, opts.PrefixNamesFilterMap, opts.IPsFilter, opts.ConnectionTypeFilter, opts.StaleHandshakeThreshold)
 
 	overview := OutputOverview{
 		Peers:                   peersOverview,
@@ -202,9 +322,9 @@ func ConvertToStatusOutputOverview(pbFullStatus *proto.FullStatus, opts ConvertO
 		FQDN:                    pbFullStatus.GetLocalPeerState().GetFqdn(),
 		RosenpassEnabled:        pbFullStatus.GetLocalPeerState().GetRosenpassEnabled(),
 		RosenpassPermissive:     pbFullStatus.GetLocalPeerState().GetRosenpassPermissive(),
-		Networks:                pbFullStatus.GetLocalPeerState().GetNetworks(),
+		Networks:                networks,
 		NumberOfForwardingRules: int(pbFullStatus.GetNumberOfForwardingRules()),
-		NSServerGroups:          mapNSGroups(pbFullStatus.GetDnsServers()),
+		NSServerGroups:          mapNSGroups(nsGroups),
 		Events:                  mapEvents(pbFullStatus.GetEvents()),
 		LazyConnectionEnabled:   pbFullStatus.GetLazyConnectionEnabled(),
 		ProfileName:             opts.ProfileName,
@@ -214,15 +334,175 @@ func ConvertToStatusOutputOverview(pbFullStatus *proto.FullStatus, opts ConvertO
 		t := opts.SessionExpiresAt
 		overview.SessionExpiresAt = &t
 	}
+	overview.ExitNodes = mapExitNodes(pbFullStatus.GetExitNodes())
+	if daemonState := pbFullStatus.GetDaemonState(); daemonState.GetStartedAt() != nil {
+		overview.Daemon = &DaemonStateOutput{
+			StartedAt:     daemonState.GetStartedAt().AsTime(),
+			RestartCount:  daemonState.GetRestartCount(),
+			StartsLast24h: daemonState.GetStartsLast24H(),
+		}
+	}
 
-	if opts.Anonymize {
-		anonymizer := anonymize.NewAnonymizer(anonymize.DefaultAddresses())
+	level := opts.AnonymizeLevel
+	if level == "" {
+		level = anonymize.LevelFromBool(opts.Anonymize)
+	}
+	if level != anonymize.LevelNone {
+		anonymizer := opts.Anonymizer
+		if anonymizer == nil {
+			anonymizer = anonymize.NewAnonymizer(anonymize.DefaultAddresses())
+		}
+		anonymizer.SetIPsOnly(level == anonymize.LevelIPsOnly)
 		anonymizeOverview(anonymizer, &overview)
 	}
 
 	return overview
 }
 
+// SelectPeers returns the peers matched by any of the selectors, in their original order,
+// and the selectors that matched no peer. A selector matches a peer by its NetBird IPv4 or
+// IPv6 address or by a case-insensitive prefix of its FQDN.
+func SelectPeers(peers []*proto.PeerState, selectors []string) ([]*proto.PeerState, []string) {
+	matched := make(map[string]bool, len(selectors))
+	var selected []*proto.PeerState
+	for _, p := range peers {
+		found := false
+		for _, sel := range selectors {
+			if peerMatchesSelector(p, sel) {
+				matched[sel] = true
+				found = true
+			}
+		}
+		if found {
+			selected = append(selected, p)
+		}
+	}
+
+	var unmatched []string
+	for _, sel := range selectors {
+		if !matched[sel] {
+			unmatched = append(unmatched, sel)
+		}
+	}
+	return selected, unmatched
+}
+
+// PeersInGroup returns the peers in the group with the given ID, in their original order.
+func PeersInGroup(peers []*proto.PeerState, groupID string) []*proto.PeerState {
+	var members []*proto.PeerState
+	for _, p := range peers {
+		if slices.Contains(p.GetGroups(), groupID) {
+			members = append(members, p)
+		}
+	}
+	return members
+}
+
+// DomainMatches reports whether name is related to the domain scope: the same domain, a subdomain
+// of it or a parent domain like a match domain that covers it. The comparison ignores case, a
+// wildcard label and a trailing dot.
+func DomainMatches(name, scope string) bool {
+	name, scope = normalizeDomain(name), normalizeDomain(scope)
+	if name == "" || scope == "" {
+		return false
+	}
+	return name == scope || strings.HasSuffix(name, "."+scope) || strings.HasSuffix(scope, "."+name)
+}
+
+func normalizeDomain(d string) string {
+	d = strings.ToLower(strings.TrimSpace(d))
+	d = strings.TrimPrefix(d, "*.")
+	return strings.TrimSuffix(d, ".")
+}
+
+// NSGroupsForDomain returns the nameserver groups that resolve the domain, in their original order:
+// the groups with a matching domain or, when there is none, the primary groups.
+func NSGroupsForDomain(groups []*proto.NSGroupState, scope string) []*proto.NSGroupState {
+	var matched, primary []*proto.NSGroupState
+	for _, g := range groups {
+		if len(g.GetDomains()) == 0 {
+			primary = append(primary, g)
+			continue
+		}
+		if slices.ContainsFunc(g.GetDomains(), func(d string) bool { return DomainMatches(d, scope) }) {
+			matched = append(matched, g)
+		}
+	}
+	if len(matched) > 0 {
+		return matched
+	}
+	return primary
+}
+
+// NetworksForDomain returns the domain routes among networks that match the domain. A network
+// entry of a domain route lists its domains separated by commas.
+func NetworksForDomain(networks []string, scope string) []string {
+	var matched []string
+	for _, network := range networks {
+		for _, d := range strings.Split(network, ",") {
+			if DomainMatches(d, scope) {
+				matched = append(matched, network)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// PeersForDomain returns the peers related to the domain, in their original order: the peers
+// routing a matching domain route, the peers serving as nameserver for the domain and the peers
+// with an FQDN in the domain.
+func PeersForDomain(peers []*proto.PeerState, groups []*proto.NSGroupState, scope string) []*proto.PeerState {
+	nameservers := make(map[string]bool)
+	for _, g := range NSGroupsForDomain(groups, scope) {
+		for _, server := range g.GetServers() {
+			if addrPort, err := netip.ParseAddrPort(server); err == nil {
+				nameservers[addrPort.Addr().Unmap().String()] = true
+			} else {
+				nameservers[server] = true
+			}
+		}
+	}
+
+	var related []*proto.PeerState
+	for _, p := range peers {
+		isNameserver := nameservers[p.GetIP()] || (p.GetIpv6() != "" && nameservers[p.GetIpv6()])
+		if isNameserver || len(NetworksForDomain(p.GetNetworks(), scope)) > 0 || DomainMatches(p.GetFqdn(), scope) {
+			related = append(related, p)
+		}
+	}
+	return related
+}
+
+func peerMatchesSelector(p *proto.PeerState, selector string) bool {
+	if selector == "" {
+		return false
+	}
+	if selector == p.GetIP() || (p.GetIpv6() != "" && selector == p.GetIpv6()) {
+		return true
+	}
+	return strings.HasPrefix(strings.ToLower(p.GetFqdn()), strings.ToLower(selector))
+}
+
+func mapExitNodes(nodes []*proto.ExitNodeState) []ExitNodeOutput {
+	var out []ExitNodeOutput
+	for _, n := range nodes {
+		out = append(out, ExitNodeOutput{
+			NetID:    n.GetNetID(),
+			Network:  n.GetNetwork(),
+			FQDN:     n.GetFqdn(),
+			IP:       n.GetIP(),
+			PubKey:   n.GetPubKey(),
+			Status:   n.GetConnStatus(),
+			Relayed:  n.GetRelayed(),
+			Latency:  n.GetLatency().AsDuration(),
+			Selected: n.GetSelected(),
+			Active:   n.GetActive(),
+		})
+	}
+	return out
+}
+
 func mapRelays(relays []*proto.RelayState) RelayStateOutput {
 	var relayStateDetail []RelayStateOutputDetail
 
@@ -235,6 +515,7 @@ func mapRelays(relays []*proto.RelayState) RelayStateOutput {
 				Available: available,
 				Error:     relayErrorString(relay.GetError()),
 				Transport: relay.GetTransport(),
+				Latency:   relay.GetLatency().AsDuration(),
 			},
 		)
 
@@ -301,9 +582,12 @@ func mapPeers(
 	prefixNamesFilterMap map[string]struct{},
 	ipsFilter map[string]struct{},
 	connectionTypeFilter string,
+	staleThreshold time.Duration,
 ) PeersStateOutput {
 	var peersStateDetail []PeerStateDetailOutput
 	peersConnected := 0
+	peersStale := 0
+	now := time.Now()
 	for _, pbPeerState := range peers {
 		localICE := ""
 		remoteICE := ""
@@ -364,6 +648,12 @@ func mapPeers(
 			Latency:                pbPeerState.GetLatency().AsDuration(),
 			RosenpassEnabled:       pbPeerState.GetRosenpassEnabled(),
 			Networks:               pbPeerState.GetNetworks(),
+			ConnHistory:            mapConnHistory(pbPeerState.GetConnHistory()),
+			Stale:                  IsStaleHandshake(pbPeerState, staleThreshold, now),
+			Groups:                 pbPeerState.GetGroups(),
+		}
+		if peerState.Stale {
+			peersStale++
 		}
 
 		peersStateDetail = append(peersStateDetail, peerState)
@@ -374,11 +664,59 @@ func mapPeers(
 	peersOverview := PeersStateOutput{
 		Total:     len(peersStateDetail),
 		Connected: peersConnected,
+		Stale:     peersStale,
 		Details:   peersStateDetail,
+		Groups:    countPeerGroups(peersStateDetail),
 	}
 	return peersOverview
 }
 
+// countPeerGroups counts the total and connected peers of each group, sorted by group ID.
+func countPeerGroups(peers []PeerStateDetailOutput) []PeerGroupOutput {
+	counts := make(map[string]*PeerGroupOutput)
+	for _, p := range peers {
+		for _, id := range p.Groups {
+			group, ok := counts[id]
+			if !ok {
+				group = &PeerGroupOutput{ID: id}
+				counts[id] = group
+			}
+			group.Total++
+			if p.Status == peer.StatusConnected.String() {
+				group.Connected++
+			}
+		}
+	}
+
+	groups := make([]PeerGroupOutput, 0, len(counts))
+	for _, g := range counts {
+		groups = append(groups, *g)
+	}
+	slices.SortFunc(groups, func(a, b PeerGroupOutput) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	if len(groups) == 0 {
+		return nil
+	}
+	return groups
+}
+
+func mapConnHistory(events []*proto.PeerConnEvent) []PeerConnEventOutput {
+	if len(events) == 0 {
+		return nil
+	}
+
+	history := make([]PeerConnEventOutput, 0, len(events))
+	for _, e := range events {
+		history = append(history, PeerConnEventOutput{
+			Time:   e.GetTime().AsTime().Local(),
+			Event:  e.GetEvent(),
+			Detail: e.GetDetail(),
+		})
+	}
+	return history
+}
+
 func sortPeersByIP(peersStateDetail []PeerStateDetailOutput) {
 	if len(peersStateDetail) > 0 {
 		sort.SliceStable(peersStateDetail, func(i, j int) bool {
@@ -414,6 +752,9 @@ func (o *OutputOverview) GeneralSummary(showURL bool, showRelays bool, showNameS
 		managementConnString = "Connected"
 		if showURL {
 			managementConnString = fmt.Sprintf("%s to %s", managementConnString, o.ManagementState.URL)
+			if len(o.ManagementState.Capabilities) > 0 {
+				managementConnString += fmt.Sprintf("\n  Capabilities: %s", strings.Join(o.ManagementState.Capabilities, ", "))
+			}
 		}
 	} else {
 		managementConnString = "Disconnected"
@@ -465,6 +806,9 @@ func (o *OutputOverview) GeneralSummary(showURL bool, showRelays bool, showNameS
 			} else if relay.Transport != "" {
 				available = fmt.Sprintf("%s via %s", available, relay.Transport)
 			}
+			if relay.Available && relay.Latency > 0 {
+				available = fmt.Sprintf("%s, latency %s", available, relay.Latency)
+			}
 
 			relaysString += fmt.Sprintf("\n  [%s] is %s%s", relay.URI, available, reason)
 		}
@@ -559,6 +903,9 @@ func (o *OutputOverview) GeneralSummary(showURL bool, showRelays bool, showNameS
 	}
 
 	peersCountString := fmt.Sprintf("%d/%d Connected", o.Peers.Connected, o.Peers.Total)
+	if o.Peers.Stale > 0 {
+		peersCountString += fmt.Sprintf(", %d stale (no recent WireGuard handshake)", o.Peers.Stale)
+	}
 
 	var sessionExpiryString string
 	if o.SessionExpiresAt != nil && !o.SessionExpiresAt.IsZero() {
@@ -586,6 +933,16 @@ func (o *OutputOverview) GeneralSummary(showURL bool, showRelays bool, showNameS
 		daemonVersion = o.DaemonVersion
 	}
 
+	var daemonUptimeString string
+	if o.Daemon != nil {
+		daemonUptimeString = fmt.Sprintf(
+			"Daemon uptime: %s (restarts: %d, %d starts in the last 24h)\n",
+			HumaniseDuration(time.Since(o.Daemon.StartedAt)),
+			o.Daemon.RestartCount,
+			o.Daemon.StartsLast24h,
+		)
+	}
+
 	cliVersion := version.NetbirdVersion()
 	if o.CliVersion != "" {
 		cliVersion = o.CliVersion
@@ -599,6 +956,7 @@ func (o *OutputOverview) GeneralSummary(showURL bool, showRelays bool, showNameS
 	summary := fmt.Sprintf(
 		"OS: %s\n"+
 			"Daemon version: %s\n"+
+			"%s"+
 			"CLI version: %s\n"+
 			"Profile: %s\n"+
 			"Management: %s\n"+
@@ -619,6 +977,7 @@ func (o *OutputOverview) GeneralSummary(showURL bool, showRelays bool, showNameS
 			"Peers count: %s\n",
 		fmt.Sprintf("%s/%s%s", goos, goarch, goarm),
 		daemonVersion,
+		daemonUptimeString,
 		cliVersion,
 		o.ProfileName,
 		managementConnString,
@@ -641,24 +1000,82 @@ func (o *OutputOverview) GeneralSummary(showURL bool, showRelays bool, showNameS
 	return summary
 }
 
-// FullDetailSummary returns a full detailed summary with peer details and events.
+// FullDetailSummary returns a full detailed summary with peer details and events. When the peers
+// are in groups, the details are split into a section per group, and a peer in several groups is
+// listed in each of them.
 func (o *OutputOverview) FullDetailSummary() string {
-	parsedPeersString := parsePeers(o.Peers, o.RosenpassEnabled, o.RosenpassPermissive)
+	parsedPeersString := parsePeers(o.Peers, o.Relays, o.RosenpassEnabled, o.RosenpassPermissive)
+	if len(o.Peers.Groups) > 0 {
+		parsedPeersString = parseGroupedPeers(o.Peers, o.Relays, o.RosenpassEnabled, o.RosenpassPermissive)
+	}
 	parsedEventsString := parseEvents(o.Events)
 	summary := o.GeneralSummary(true, true, true, true)
 
+	var exitNodesString string
+	if len(o.ExitNodes) > 0 {
+		exitNodesString = "Exit nodes:" + parseExitNodes(o.ExitNodes) + "\n"
+	}
+
 	return fmt.Sprintf(
 		"Peers detail:"+
 			"%s\n"+
+			"%s"+
 			"Events:"+
 			"%s\n"+
 			"%s",
 		parsedPeersString,
+		exitNodesString,
 		parsedEventsString,
 		summary,
 	)
 }
 
+// parseExitNodes renders the exit nodes with a section per network ID: whether it is selected and
+// which candidate is active, followed by the health of each candidate.
+func parseExitNodes(nodes []ExitNodeOutput) string {
+	var sb strings.Builder
+	for i := 0; i < len(nodes); {
+		j := i
+		for j < len(nodes) && nodes[j].NetID == nodes[i].NetID {
+			j++
+		}
+		candidates := nodes[i:j]
+		i = j
+
+		state := "not selected"
+		if candidates[0].Selected {
+			state = "selected, no active peer"
+			for _, c := range candidates {
+				if c.Active {
+					state = "selected, active via " + c.FQDN
+					break
+				}
+			}
+		}
+		sb.WriteString(fmt.Sprintf("\n %s (%s), %s:\n", candidates[0].NetID, candidates[0].Network, state))
+
+		for _, c := range candidates {
+			health := c.Status
+			if c.Status == peer.StatusConnected.String() {
+				connType := "P2P"
+				if c.Relayed {
+					connType = "Relayed"
+				}
+				health += ", " + connType
+				if c.Latency > 0 {
+					health += ", latency " + c.Latency.String()
+				}
+			}
+			active := ""
+			if c.Active {
+				active = " (active)"
+			}
+			sb.WriteString(fmt.Sprintf("  %s (%s): %s%s\n", c.FQDN, c.IP, health, active))
+		}
+	}
+	return sb.String()
+}
+
 func ToProtoFullStatus(fullStatus peer.FullStatus) *proto.FullStatus {
 	pbFullStatus := proto.FullStatus{
 		ManagementState: &proto.ManagementState{},
@@ -672,6 +1089,8 @@ func ToProtoFullStatus(fullStatus peer.FullStatus) *proto.FullStatus {
 	if err := fullStatus.ManagementState.Error; err != nil {
 		pbFullStatus.ManagementState.Error = err.Error()
 	}
+	pbFullStatus.ManagementState.Capabilities = fullStatus.ManagementState.Capabilities.Offered
+	pbFullStatus.ManagementState.SyncVersion = fullStatus.ManagementState.Capabilities.SyncVersion
 
 	pbFullStatus.SignalState.URL = fullStatus.SignalState.URL
 	pbFullStatus.SignalState.Connected = fullStatus.SignalState.Connected
@@ -711,6 +1130,8 @@ func ToProtoFullStatus(fullStatus peer.FullStatus) *proto.FullStatus {
 			Networks:                   maps.Keys(peerState.GetRoutes()),
 			Latency:                    durationpb.New(peerState.Latency),
 			SshHostKey:                 peerState.SSHHostKey,
+			ConnHistory:                peer.ConnHistoryToProto(peerState.ConnHistory),
+			Groups:                     peerState.Groups,
 		}
 		pbFullStatus.Peers = append(pbFullStatus.Peers, pbPeerState)
 	}
@@ -719,6 +1140,10 @@ func ToProtoFullStatus(fullStatus peer.FullStatus) *proto.FullStatus {
 		pbRelayState := &proto.RelayState{
 			URI:       relayState.URI,
 			Available: relayState.Err == nil,
+			Transport: relayState.Transport,
+		}
+		if relayState.Latency > 0 {
+			pbRelayState.Latency = durationpb.New(relayState.Latency)
 		}
 		if err := relayState.Err; err != nil {
 			pbRelayState.Error = err.Error()
@@ -746,12 +1171,29 @@ func ToProtoFullStatus(fullStatus peer.FullStatus) *proto.FullStatus {
 		pbFullStatus.DnsServers = append(pbFullStatus.DnsServers, pbDnsState)
 	}
 
+	for _, exitNode := range fullStatus.ExitNodes {
+		pbFullStatus.ExitNodes = append(pbFullStatus.ExitNodes, &proto.ExitNodeState{
+			NetID:      exitNode.NetID,
+			Network:    exitNode.Network,
+			PubKey:     exitNode.PeerKey,
+			Fqdn:       exitNode.FQDN,
+			IP:         exitNode.IP,
+			ConnStatus: exitNode.ConnStatus.String(),
+			Relayed:    exitNode.Relayed,
+			Latency:    durationpb.New(exitNode.Latency),
+			Selected:   exitNode.Selected,
+			Active:     exitNode.Active,
+		})
+	}
+
 	pbFullStatus.Events = fullStatus.Events
+	pbFullStatus.DaemonState = fullStatus.DaemonStart.ToProto()
+	pbFullStatus.Posture = fullStatus.Posture.ToProto()
 
 	return &pbFullStatus
 }
 
-func parsePeers(peers PeersStateOutput, rosenpassEnabled, rosenpassPermissive bool) string {
+func parsePeers(peers PeersStateOutput, relays RelayStateOutput, rosenpassEnabled, rosenpassPermissive bool) string {
 	var (
 		peersString = ""
 	)
@@ -778,6 +1220,11 @@ func parsePeers(peers PeersStateOutput, rosenpassEnabled, rosenpassPermissive bo
 			remoteICEEndpoint = peerState.IceCandidateEndpoint.Remote
 		}
 
+		relayAddress := peerState.RelayAddress
+		if latency := relays.latency(peerState.RelayAddress); latency > 0 {
+			relayAddress = fmt.Sprintf("%s (relay latency %s)", relayAddress, latency)
+		}
+
 		rosenpassEnabledStatus := "false"
 		if rosenpassEnabled {
 			if peerState.RosenpassEnabled {
@@ -806,6 +1253,16 @@ func parsePeers(peers PeersStateOutput, rosenpassEnabled, rosenpassPermissive bo
 			ipv6Line = fmt.Sprintf("  NetBird IPv6: %s\n", peerState.IPv6)
 		}
 
+		groupsLine := ""
+		if len(peerState.Groups) > 0 {
+			groupsLine = fmt.Sprintf("  Groups: %s\n", strings.Join(peerState.Groups, ", "))
+		}
+
+		lastHandshake := timeAgo(peerState.LastWireguardHandshake)
+		if peerState.Stale {
+			lastHandshake += " (stale)"
+		}
+
 		peerString := fmt.Sprintf(
 			"\n %s:\n"+
 				"  NetBird IP: %s\n"+
@@ -822,6 +1279,7 @@ func parsePeers(peers PeersStateOutput, rosenpassEnabled, rosenpassPermissive bo
 				"  Transfer status (received/sent) %s/%s\n"+
 				"  Quantum resistance: %s\n"+
 				"  Networks: %s\n"+
+				"%s"+
 				"  Latency: %s\n",
 			domain.Domain(peerState.FQDN).SafeString(),
 			peerState.IP,
@@ -833,21 +1291,82 @@ func parsePeers(peers PeersStateOutput, rosenpassEnabled, rosenpassPermissive bo
 			remoteICE,
 			localICEEndpoint,
 			remoteICEEndpoint,
-			peerState.RelayAddress,
+			relayAddress,
 			timeAgo(peerState.LastStatusUpdate),
-			timeAgo(peerState.LastWireguardHandshake),
+			lastHandshake,
 			toIEC(peerState.TransferReceived),
 			toIEC(peerState.TransferSent),
 			rosenpassEnabledStatus,
 			networks,
+			groupsLine,
 			peerState.Latency.String(),
 		)
+		peerString += parseConnHistory(peerState.ConnHistory)
 
 		peersString += peerString
 	}
 	return peersString
 }
 
+// parseGroupedPeers renders the peer details in a section per group, headed by its peer counts,
+// followed by the peers in no group.
+func parseGroupedPeers(peers PeersStateOutput, relays RelayStateOutput, rosenpassEnabled, rosenpassPermissive bool) string {
+	var builder strings.Builder
+	for _, group := range peers.Groups {
+		members := PeersStateOutput{}
+		for _, p := range peers.Details {
+			if slices.Contains(p.Groups, group.ID) {
+				members.Details = append(members.Details, p)
+			}
+		}
+		builder.WriteString(fmt.Sprintf("\n Group %s (%d/%d connected):", group.ID, group.Connected, group.Total))
+		builder.WriteString(indentLines(parsePeers(members, relays, rosenpassEnabled, rosenpassPermissive)))
+	}
+
+	ungrouped := PeersStateOutput{}
+	for _, p := range peers.Details {
+		if len(p.Groups) == 0 {
+			ungrouped.Details = append(ungrouped.Details, p)
+			if p.Status == peer.StatusConnected.String() {
+				ungrouped.Connected++
+			}
+		}
+	}
+	if len(ungrouped.Details) > 0 {
+		builder.WriteString(fmt.Sprintf("\n No group (%d/%d connected):", ungrouped.Connected, len(ungrouped.Details)))
+		builder.WriteString(indentLines(parsePeers(ungrouped, relays, rosenpassEnabled, rosenpassPermissive)))
+	}
+	return builder.String()
+}
+
+// indentLines indents every non-empty line by two spaces.
+func indentLines(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = "  " + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func parseConnHistory(history []PeerConnEventOutput) string {
+	if len(history) == 0 {
+		return ""
+	}
+
+	var builder strings.Builder
+	builder.WriteString("  Connection history:\n")
+	for _, e := range history {
+		line := e.Event
+		if e.Detail != "" {
+			line += ": " + e.Detail
+		}
+		builder.WriteString(fmt.Sprintf("    %s %s\n", e.Time.Format("2006-01-02 15:04:05"), line))
+	}
+	return builder.String()
+}
+
 func skipDetailByFilters(peerState *proto.PeerState, peerStatus string, statusFilter string, prefixNamesFilter []string, prefixNamesFilterMap map[string]struct{}, ipsFilter map[string]struct{}, connectionTypeFilter, connType string) bool {
 	statusEval := false
 	ipEval := false
@@ -993,6 +1512,10 @@ func anonymizePeerDetail(a *anonymize.Anonymizer, peer *PeerStateDetailOutput) {
 	for i, route := range peer.Networks {
 		peer.Networks[i] = a.AnonymizeRoute(route)
 	}
+
+	for i, e := range peer.ConnHistory {
+		peer.ConnHistory[i].Detail = a.AnonymizeString(e.Detail)
+	}
 }
 
 func anonymizeOverview(a *anonymize.Anonymizer, overview *OutputOverview) {
@@ -1033,6 +1556,13 @@ func anonymizeOverview(a *anonymize.Anonymizer, overview *OutputOverview) {
 
 	overview.FQDN = a.AnonymizeDomain(overview.FQDN)
 
+	// the same anonymizer as the peer details keeps an exit node recognizable among the peers,
+	// whose NetBird IPs are kept as well
+	for i, node := range overview.ExitNodes {
+		overview.ExitNodes[i].NetID = a.AnonymizeString(node.NetID)
+		overview.ExitNodes[i].FQDN = a.AnonymizeDomain(node.FQDN)
+	}
+
 	for i, event := range overview.Events {
 		overview.Events[i].Message = a.AnonymizeString(event.Message)
 		overview.Events[i].UserMessage = a.AnonymizeString(event.UserMessage)
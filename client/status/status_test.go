@@ -13,6 +13,7 @@ import (
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/netbirdio/netbird/client/internal/peer"
 	"github.com/netbirdio/netbird/client/proto"
 	"github.com/netbirdio/netbird/version"
 )
@@ -130,6 +131,7 @@ var overview = OutputOverview{
 	Peers: PeersStateOutput{
 		Total:     2,
 		Connected: 2,
+		Stale:     2,
 		Details: []PeerStateDetailOutput{
 			{
 				IP:               "192.168.178.101",
@@ -154,6 +156,7 @@ var overview = OutputOverview{
 					"10.1.0.0/24",
 				},
 				Latency: time.Duration(10000000),
+				Stale:   true,
 			},
 			{
 				IP:               "192.168.178.102",
@@ -174,6 +177,7 @@ var overview = OutputOverview{
 				TransferReceived:       2000,
 				TransferSent:           1000,
 				Latency:                time.Duration(10000000),
+				Stale:                  true,
 			},
 		},
 	},
@@ -253,6 +257,93 @@ func TestConversionFromFullStatusToOutputOverview(t *testing.T) {
 	assert.Equal(t, overview, convertedResult)
 }
 
+func TestPeerSelectors(t *testing.T) {
+	selected, unmatched := SelectPeers(resp.GetFullStatus().GetPeers(), []string{"PEER-1", "192.168.178.102", "peer-9"})
+	require.Len(t, selected, 2)
+	assert.Equal(t, "Pubkey1", selected[0].GetPubKey())
+	assert.Equal(t, "Pubkey2", selected[1].GetPubKey())
+	assert.Equal(t, []string{"peer-9"}, unmatched)
+
+	convertedResult := ConvertToStatusOutputOverview(resp.GetFullStatus(), ConvertOptions{
+		PeerSelectors: []string{"fd00::1"},
+	})
+	require.Len(t, convertedResult.Peers.Details, 1)
+	assert.Equal(t, "peer-1.awesome-domain.com", convertedResult.Peers.Details[0].FQDN)
+	assert.Equal(t, []string{"10.1.0.0/24"}, convertedResult.Peers.Details[0].Networks)
+	assert.Equal(t, 1, convertedResult.Peers.Total)
+}
+
+func TestPeerGroups(t *testing.T) {
+	fullStatus := &proto.FullStatus{
+		Peers: []*proto.PeerState{
+			{IP: "100.64.0.1", Fqdn: "peer-a.netbird.cloud", ConnStatus: "Connected", Groups: []string{"g1", "g2"}},
+			{IP: "100.64.0.2", Fqdn: "peer-b.netbird.cloud", ConnStatus: "Idle", Groups: []string{"g1"}},
+			{IP: "100.64.0.3", Fqdn: "peer-c.netbird.cloud", ConnStatus: "Connected"},
+		},
+		ManagementState: &proto.ManagementState{},
+		SignalState:     &proto.SignalState{},
+	}
+
+	converted := ConvertToStatusOutputOverview(fullStatus, ConvertOptions{})
+	assert.Equal(t, []PeerGroupOutput{
+		{ID: "g1", Total: 2, Connected: 1},
+		{ID: "g2", Total: 1, Connected: 1},
+	}, converted.Peers.Groups)
+
+	detail := converted.FullDetailSummary()
+	assert.Contains(t, detail, "\n Group g1 (1/2 connected):\n   peer-a.netbird.cloud:\n    NetBird IP: 100.64.0.1\n")
+	assert.Contains(t, detail, "\n Group g2 (1/1 connected):\n   peer-a.netbird.cloud:\n")
+	assert.Contains(t, detail, "\n No group (1/1 connected):\n   peer-c.netbird.cloud:\n")
+	assert.Contains(t, detail, "    Groups: g1, g2\n")
+
+	filtered := ConvertToStatusOutputOverview(fullStatus, ConvertOptions{GroupFilter: "g2"})
+	require.Len(t, filtered.Peers.Details, 1)
+	assert.Equal(t, "peer-a.netbird.cloud", filtered.Peers.Details[0].FQDN)
+	assert.Equal(t, 1, filtered.Peers.Total)
+
+	assert.Empty(t, PeersInGroup(fullStatus.GetPeers(), "g3"))
+}
+
+func TestDomainFilter(t *testing.T) {
+	assert.True(t, DomainMatches("Corp.Example.com.", "corp.example.com"))
+	assert.True(t, DomainMatches("db.corp.example.com", "corp.example.com"))
+	assert.True(t, DomainMatches("*.example.com", "db.corp.example.com"))
+	assert.False(t, DomainMatches("notcorp.example.com", "corp.example.com"))
+	assert.False(t, DomainMatches("", "corp.example.com"))
+
+	fullStatus := &proto.FullStatus{
+		Peers: []*proto.PeerState{
+			{IP: "100.64.0.1", Fqdn: "router.netbird.cloud", Networks: []string{"corp.example.com,*.corp.example.com"}},
+			{IP: "100.64.0.2", Fqdn: "dns.netbird.cloud"},
+			{IP: "100.64.0.3", Fqdn: "other.netbird.cloud", Networks: []string{"10.0.0.0/24"}},
+		},
+		ManagementState: &proto.ManagementState{},
+		SignalState:     &proto.SignalState{},
+		LocalPeerState: &proto.LocalPeerState{
+			Networks: []string{"10.0.0.0/24", "corp.example.com,*.corp.example.com", "shop.example.org"},
+		},
+		DnsServers: []*proto.NSGroupState{
+			{Servers: []string{"8.8.8.8:53"}, Enabled: true},
+			{Servers: []string{"100.64.0.2:53"}, Domains: []string{"corp.example.com"}, Enabled: true},
+			{Servers: []string{"192.0.2.53:53"}, Domains: []string{"example.org"}, Enabled: true},
+		},
+	}
+
+	converted := ConvertToStatusOutputOverview(fullStatus, ConvertOptions{DomainFilter: "db.corp.example.com"})
+	require.Len(t, converted.Peers.Details, 2)
+	assert.Equal(t, "router.netbird.cloud", converted.Peers.Details[0].FQDN)
+	assert.Equal(t, "dns.netbird.cloud", converted.Peers.Details[1].FQDN)
+	assert.Equal(t, []string{"corp.example.com,*.corp.example.com"}, converted.Networks)
+	require.Len(t, converted.NSServerGroups, 1)
+	assert.Equal(t, []string{"100.64.0.2:53"}, converted.NSServerGroups[0].Servers)
+
+	// without a matching group the domain resolves through the primary nameservers
+	groups := NSGroupsForDomain(fullStatus.GetDnsServers(), "unrelated.test")
+	require.Len(t, groups, 1)
+	assert.Equal(t, []string{"8.8.8.8:53"}, groups[0].GetServers())
+	assert.Empty(t, NetworksForDomain(fullStatus.GetLocalPeerState().GetNetworks(), "unrelated.test"))
+}
+
 func TestSortingOfPeers(t *testing.T) {
 	peers := []PeerStateDetailOutput{
 		{
@@ -286,6 +377,7 @@ func TestParsingToJSON(t *testing.T) {
           "peers": {
             "total": 2,
             "connected": 2,
+            "stale": 2,
             "details": [
               {
                 "fqdn": "peer-1.awesome-domain.com",
@@ -311,7 +403,8 @@ func TestParsingToJSON(t *testing.T) {
                 "quantumResistance": false,
                 "networks": [
                   "10.1.0.0/24"
-                ]
+                ],
+                "stale": true
               },
               {
                 "fqdn": "peer-2.awesome-domain.com",
@@ -334,7 +427,8 @@ func TestParsingToJSON(t *testing.T) {
                 "transferSent": 1000,
 				"latency": 10000000,
                 "quantumResistance": false,
-                "networks": null
+                "networks": null,
+                "stale": true
               }
             ]
           },
@@ -424,6 +518,7 @@ func TestParsingToYAML(t *testing.T) {
 		`peers:
     total: 2
     connected: 2
+    stale: 2
     details:
         - fqdn: peer-1.awesome-domain.com
           netbirdIp: 192.168.178.101
@@ -446,6 +541,7 @@ func TestParsingToYAML(t *testing.T) {
           quantumResistance: false
           networks:
             - 10.1.0.0/24
+          stale: true
         - fqdn: peer-2.awesome-domain.com
           netbirdIp: 192.168.178.102
           publicKey: Pubkey2
@@ -465,6 +561,7 @@ func TestParsingToYAML(t *testing.T) {
           latency: 10ms
           quantumResistance: false
           networks: []
+          stale: true
 cliVersion: development
 daemonVersion: 0.14.1
 daemonStatus: Connected
@@ -544,7 +641,7 @@ func TestParsingToDetail(t *testing.T) {
   ICE candidate endpoints (Local/Remote): -/-
   Relay server address: 
   Last connection update: %s
-  Last WireGuard handshake: %s
+  Last WireGuard handshake: %s (stale)
   Transfer status (received/sent) 200 B/100 B
   Quantum resistance: false
   Networks: 10.1.0.0/24
@@ -560,7 +657,7 @@ func TestParsingToDetail(t *testing.T) {
   ICE candidate endpoints (Local/Remote): 10.0.0.1:10001/10.0.10.1:10002
   Relay server address: 
   Last connection update: %s
-  Last WireGuard handshake: %s
+  Last WireGuard handshake: %s (stale)
   Transfer status (received/sent) 2.0 KiB/1000 B
   Quantum resistance: false
   Networks: -
@@ -588,7 +685,7 @@ Quantum resistance: false
 Lazy connection: false
 SSH Server: Disabled
 Networks: 10.10.0.0/24
-Peers count: 2/2 Connected
+Peers count: 2/2 Connected, 2 stale (no recent WireGuard handshake)
 `, lastConnectionUpdate1, lastHandshake1, lastConnectionUpdate2, lastHandshake2, runtime.GOOS, runtime.GOARCH, overview.CliVersion, overview.WgPort)
 
 	assert.Equal(t, expectedDetail, detail)
@@ -614,7 +711,7 @@ Quantum resistance: false
 Lazy connection: false
 SSH Server: Disabled
 Networks: 10.10.0.0/24
-Peers count: 2/2 Connected
+Peers count: 2/2 Connected, 2 stale (no recent WireGuard handshake)
 `
 
 	assert.Equal(t, expectedString, shortVersion)
@@ -704,3 +801,95 @@ func TestMapRelaysTransport(t *testing.T) {
 	assert.Equal(t, "quic", out.Details[0].Transport)
 	assert.Equal(t, "ws", out.Details[1].Transport)
 }
+
+func TestRelayLatency(t *testing.T) {
+	relays := mapRelays([]*proto.RelayState{
+		{URI: "rels://relay.example:443", Available: true, Transport: "quic", Latency: durationpb.New(23 * time.Millisecond)},
+		{URI: "rels://relay2.example:443", Available: false, Error: "connection refused"},
+	})
+	assert.Equal(t, 23*time.Millisecond, relays.Details[0].Latency)
+	assert.Equal(t, 23*time.Millisecond, relays.latency("rels://relay.example:443"))
+	assert.Zero(t, relays.latency("rels://relay2.example:443"))
+	assert.Zero(t, relays.latency(""))
+
+	in := overview
+	in.Relays = relays
+	out := in.GeneralSummary(false, true, false, false)
+	assert.Contains(t, out, "[rels://relay.example:443] is Available via quic, latency 23ms")
+
+	in.Peers = PeersStateOutput{Details: []PeerStateDetailOutput{{FQDN: "peer.netbird.cloud", RelayAddress: "rels://relay.example:443"}}}
+	assert.Contains(t, in.FullDetailSummary(), "Relay server address: rels://relay.example:443 (relay latency 23ms)")
+}
+
+func TestDaemonUptime(t *testing.T) {
+	startedAt := time.Now().Add(-2*time.Hour - 15*time.Minute)
+	converted := ConvertToStatusOutputOverview(&proto.FullStatus{
+		DaemonState: &proto.DaemonState{StartedAt: timestamppb.New(startedAt), RestartCount: 40, StartsLast24H: 5},
+	}, ConvertOptions{})
+	require.NotNil(t, converted.Daemon)
+	assert.Equal(t, uint32(40), converted.Daemon.RestartCount)
+
+	in := overview
+	in.Daemon = converted.Daemon
+	assert.Contains(t, in.FullDetailSummary(), "Daemon uptime: 2h 15m (restarts: 40, 5 starts in the last 24h)\n")
+
+	assert.Nil(t, ConvertToStatusOutputOverview(&proto.FullStatus{}, ConvertOptions{}).Daemon)
+}
+
+func TestConvertPartialFullStatus(t *testing.T) {
+	// older daemons may leave out any part of the status
+	converted := ConvertToStatusOutputOverview(&proto.FullStatus{}, ConvertOptions{})
+	assert.False(t, converted.ManagementState.Connected)
+	assert.Empty(t, converted.ManagementState.Error)
+	assert.Empty(t, converted.SignalState.Error)
+	assert.NotPanics(t, func() { _ = converted.FullDetailSummary() })
+}
+
+func TestIsStaleHandshake(t *testing.T) {
+	now := time.Now()
+	connected := func(handshake, update time.Time) *proto.PeerState {
+		return &proto.PeerState{
+			ConnStatus:             peer.StatusConnected.String(),
+			ConnStatusUpdate:       timestamppb.New(update),
+			LastWireguardHandshake: timestamppb.New(handshake),
+		}
+	}
+
+	assert.False(t, IsStaleHandshake(connected(now.Add(-time.Minute), now.Add(-time.Hour)), 0, now))
+	assert.True(t, IsStaleHandshake(connected(now.Add(-5*time.Minute), now.Add(-time.Hour)), 0, now))
+	assert.False(t, IsStaleHandshake(connected(now.Add(-5*time.Minute), now.Add(-time.Hour)), 10*time.Minute, now))
+
+	assert.False(t, IsStaleHandshake(connected(time.Time{}, now.Add(-time.Minute)), 0, now), "a new connection has time for its first handshake")
+	assert.True(t, IsStaleHandshake(connected(time.Time{}, now.Add(-time.Hour)), 0, now))
+
+	idle := connected(now.Add(-time.Hour), now.Add(-time.Hour))
+	idle.ConnStatus = peer.StatusIdle.String()
+	assert.False(t, IsStaleHandshake(idle, 0, now), "peers that are not connected are not stale")
+}
+
+func TestExitNodes(t *testing.T) {
+	fullStatus := &proto.FullStatus{
+		ExitNodes: []*proto.ExitNodeState{
+			{NetID: "exit-de", Network: "0.0.0.0/0", Fqdn: "de-1.corp.example", IP: "100.64.0.5", ConnStatus: "Connected", Latency: durationpb.New(23 * time.Millisecond), Selected: true, Active: true},
+			{NetID: "exit-de", Network: "0.0.0.0/0", Fqdn: "de-2.corp.example", IP: "100.64.0.6", ConnStatus: "Connected", Relayed: true, Selected: true},
+			{NetID: "exit-us", Network: "0.0.0.0/0", Fqdn: "us-1.corp.example", IP: "100.64.0.7", ConnStatus: "Idle"},
+		},
+		ManagementState: &proto.ManagementState{},
+		SignalState:     &proto.SignalState{},
+	}
+
+	converted := ConvertToStatusOutputOverview(fullStatus, ConvertOptions{})
+	require.Len(t, converted.ExitNodes, 3)
+	assert.True(t, converted.ExitNodes[0].Active)
+
+	detail := converted.FullDetailSummary()
+	assert.Contains(t, detail, "Exit nodes:\n"+
+		" exit-de (0.0.0.0/0), selected, active via de-1.corp.example:\n"+
+		"  de-1.corp.example (100.64.0.5): Connected, P2P, latency 23ms (active)\n"+
+		"  de-2.corp.example (100.64.0.6): Connected, Relayed\n"+
+		"\n exit-us (0.0.0.0/0), not selected:\n"+
+		"  us-1.corp.example (100.64.0.7): Idle\n")
+
+	anonymized := ConvertToStatusOutputOverview(fullStatus, ConvertOptions{Anonymize: true})
+	assert.NotContains(t, anonymized.ExitNodes[0].FQDN, "corp.example")
+}
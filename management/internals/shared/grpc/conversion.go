@@ -114,6 +114,15 @@ func toNetbirdConfig(config *nbconfig.Config, turnCredentials *Token, relayToken
 		nbConfig.Metrics = &proto.MetricsConfig{
 			Enabled: settings.MetricsPushEnabled,
 		}
+
+		if policy := settings.DebugBundlePolicy; policy != nil {
+			nbConfig.DebugBundlePolicy = &proto.DebugBundlePolicy{
+				ForceAnonymize:    policy.ForceAnonymize,
+				ExcludeCaptures:   policy.ExcludeCaptures,
+				ExcludeNetworkMap: policy.ExcludeNetworkMap,
+				ExcludeSystemInfo: policy.ExcludeSystemInfo,
+			}
+		}
 	}
 
 	return nbConfig
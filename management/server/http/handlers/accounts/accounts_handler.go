@@ -295,6 +295,15 @@ func (h *handler) updateAccountRequestSettings(req api.PutApiAccountsAccountIdJS
 		}
 	}
 
+	if req.Settings.DebugBundlePolicy != nil {
+		returnSettings.DebugBundlePolicy = &types.DebugBundlePolicy{
+			ForceAnonymize:    req.Settings.DebugBundlePolicy.ForceAnonymize,
+			ExcludeCaptures:   req.Settings.DebugBundlePolicy.ExcludeCaptures,
+			ExcludeNetworkMap: req.Settings.DebugBundlePolicy.ExcludeNetworkMap,
+			ExcludeSystemInfo: req.Settings.DebugBundlePolicy.ExcludeSystemInfo,
+		}
+	}
+
 	if returnSettings.AgentNetworkOnly &&
 		(returnSettings.DashboardFeatures == nil ||
 			returnSettings.DashboardFeatures.AgentNetwork == nil ||
@@ -451,6 +460,14 @@ func toAccountResponse(accountID string, settings *types.Settings, meta *types.A
 			AgentNetwork: settings.DashboardFeatures.AgentNetwork,
 		}
 	}
+	if settings.DebugBundlePolicy != nil {
+		apiSettings.DebugBundlePolicy = &api.AccountDebugBundlePolicy{
+			ForceAnonymize:    settings.DebugBundlePolicy.ForceAnonymize,
+			ExcludeCaptures:   settings.DebugBundlePolicy.ExcludeCaptures,
+			ExcludeNetworkMap: settings.DebugBundlePolicy.ExcludeNetworkMap,
+			ExcludeSystemInfo: settings.DebugBundlePolicy.ExcludeSystemInfo,
+		}
+	}
 
 	apiOnboarding := api.AccountOnboarding{
 		OnboardingFlowPending: onboarding.OnboardingFlowPending,
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/netbirdio/netbird/shared/management/http/api"
 	"github.com/netbirdio/netbird/shared/management/proto"
@@ -222,6 +223,7 @@ func (j *Job) buildStreamBundleResponse() (*proto.JobRequest, error) {
 				BundleForTime: int64(p.BundleForTime),
 				LogFileCount:  int32(p.LogFileCount),
 				Anonymize:     p.Anonymize,
+				ReferenceTime: timestamppb.Now(),
 			},
 		},
 	}, nil
@@ -85,6 +85,10 @@ type Settings struct {
 	// a schema change.
 	DashboardFeatures *DashboardFeatures `gorm:"serializer:json"`
 
+	// DebugBundlePolicy restricts what debug bundles created by the account's peers may contain.
+	// It is enforced by the clients regardless of local flags. Nil means no restrictions.
+	DebugBundlePolicy *DebugBundlePolicy `gorm:"serializer:json"`
+
 	// EmbeddedIdpEnabled indicates if the embedded identity provider is enabled.
 	// This is a runtime-only field, not stored in the database.
 	EmbeddedIdpEnabled bool `gorm:"-"`
@@ -134,6 +138,9 @@ func (s *Settings) Copy() *Settings {
 	if s.DashboardFeatures != nil {
 		settings.DashboardFeatures = s.DashboardFeatures.Copy()
 	}
+	if s.DebugBundlePolicy != nil {
+		settings.DebugBundlePolicy = s.DebugBundlePolicy.Copy()
+	}
 	return settings
 }
 
@@ -156,6 +163,32 @@ func (d *DashboardFeatures) Copy() *DashboardFeatures {
 	return c
 }
 
+// DebugBundlePolicy restricts the content of debug bundles created by peers.
+type DebugBundlePolicy struct {
+	// ForceAnonymize anonymizes bundles even if the user did not ask for it.
+	ForceAnonymize bool `json:"force_anonymize"`
+	// ExcludeCaptures leaves packet captures out of bundles.
+	ExcludeCaptures bool `json:"exclude_captures"`
+	// ExcludeNetworkMap leaves the network map out of bundles.
+	ExcludeNetworkMap bool `json:"exclude_network_map"`
+	// ExcludeSystemInfo leaves routes, interfaces and firewall rules of the host out of bundles.
+	ExcludeSystemInfo bool `json:"exclude_system_info"`
+}
+
+// Copy returns a copy of the DebugBundlePolicy struct.
+func (p *DebugBundlePolicy) Copy() *DebugBundlePolicy {
+	c := *p
+	return &c
+}
+
+// Equal reports whether two policies are the same, treating nil as no policy.
+func (p *DebugBundlePolicy) Equal(other *DebugBundlePolicy) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+	return *p == *other
+}
+
 type ExtraSettings struct {
 	// PeerApprovalEnabled enables or disables the need for peers bo be approved by an administrator
 	PeerApprovalEnabled bool
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/metadata"
+)
+
+// ClockSkew is the offset of the local clock to the management server, measured from the Date
+// header of a management response.
+type ClockSkew struct {
+	// Offset is how far the local clock is ahead of the server. The Date header has second
+	// precision, so the offset is only accurate to about a second.
+	Offset time.Duration
+	// MeasuredAt is the local time of the measurement.
+	MeasuredAt time.Time
+}
+
+// ClockSkew returns the last clock skew measured to the management server. ok is false if no
+// response carried a Date header yet.
+func (c *GrpcClient) ClockSkew() (ClockSkew, bool) {
+	c.clockSkewMu.RLock()
+	defer c.clockSkewMu.RUnlock()
+
+	if c.clockSkew == nil {
+		return ClockSkew{}, false
+	}
+	return *c.clockSkew, true
+}
+
+// recordClockSkew compares the Date header of a response with the local time halfway between
+// sending the request and receiving the response.
+func (c *GrpcClient) recordClockSkew(header metadata.MD, sentAt, receivedAt time.Time) {
+	values := header.Get("date")
+	if len(values) == 0 {
+		return
+	}
+	serverTime, err := http.ParseTime(values[0])
+	if err != nil {
+		log.Debugf("failed to parse management server date %q: %v", values[0], err)
+		return
+	}
+
+	// the header is truncated to the second, the server time is on average half a second later
+	serverTime = serverTime.Add(500 * time.Millisecond)
+	localTime := sentAt.Add(receivedAt.Sub(sentAt) / 2)
+
+	c.clockSkewMu.Lock()
+	defer c.clockSkewMu.Unlock()
+	c.clockSkew = &ClockSkew{Offset: localTime.Sub(serverTime), MeasuredAt: receivedAt}
+}
@@ -0,0 +1,33 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestRecordClockSkew(t *testing.T) {
+	c := &GrpcClient{}
+
+	c.recordClockSkew(metadata.MD{}, time.Now(), time.Now())
+	_, ok := c.ClockSkew()
+	assert.False(t, ok, "no date header")
+
+	c.recordClockSkew(metadata.Pairs("date", "not a date"), time.Now(), time.Now())
+	_, ok = c.ClockSkew()
+	assert.False(t, ok, "invalid date header")
+
+	serverTime := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	sentAt := serverTime.Add(2 * time.Minute)
+	receivedAt := sentAt.Add(time.Second)
+	c.recordClockSkew(metadata.Pairs("date", serverTime.Format(http.TimeFormat)), sentAt, receivedAt)
+
+	skew, ok := c.ClockSkew()
+	require.True(t, ok)
+	assert.Equal(t, 2*time.Minute, skew.Offset)
+	assert.Equal(t, receivedAt, skew.MeasuredAt)
+}
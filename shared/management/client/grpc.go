@@ -19,6 +19,7 @@ import (
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/metadata"
 
 	nbgrpc "github.com/netbirdio/netbird/client/grpc"
 	"github.com/netbirdio/netbird/client/system"
@@ -69,6 +70,10 @@ type GrpcClient struct {
 	// connection while the Sync stream keeps failing.
 	syncStreamMu  sync.RWMutex
 	syncStreamErr error
+
+	// clockSkew is the last clock skew measured from a GetServerKey response, nil before.
+	clockSkewMu sync.RWMutex
+	clockSkew   *ClockSkew
 }
 
 type ExposeRequest struct {
@@ -539,10 +544,13 @@ func (c *GrpcClient) HealthCheck() error {
 func (c *GrpcClient) getServerPublicKey() (*wgtypes.Key, error) {
 	mgmCtx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
 	defer cancel()
-	resp, err := c.realClient.GetServerKey(mgmCtx, &proto.Empty{})
+	var header metadata.MD
+	sentAt := time.Now()
+	resp, err := c.realClient.GetServerKey(mgmCtx, &proto.Empty{}, grpc.Header(&header))
 	if err != nil {
 		return nil, fmt.Errorf("failed getting Management Service public key: %w", err)
 	}
+	c.recordClockSkew(header, sentAt, time.Now())
 
 	serverKey, err := wgtypes.ParseKey(resp.Key)
 	if err != nil {
@@ -552,6 +560,11 @@ func (c *GrpcClient) getServerPublicKey() (*wgtypes.Key, error) {
 	return &serverKey, nil
 }
 
+// ChannelState returns the state of the underlying gRPC channel.
+func (c *GrpcClient) ChannelState() connectivity.State {
+	return c.conn.GetState()
+}
+
 // IsHealthy returns the current connection status without blocking.
 // Used by the engine to monitor connectivity in the background.
 func (c *GrpcClient) IsHealthy() bool {
@@ -1618,6 +1618,21 @@ type AccountDashboardFeatures struct {
 	AgentNetwork *bool `json:"agent_network,omitempty"`
 }
 
+// AccountDebugBundlePolicy Restricts what debug bundles created by the account's peers may contain. Peers enforce the policy regardless of local flags.
+type AccountDebugBundlePolicy struct {
+	// ExcludeCaptures Leaves packet captures out of debug bundles.
+	ExcludeCaptures bool `json:"exclude_captures"`
+
+	// ExcludeNetworkMap Leaves the network map out of debug bundles.
+	ExcludeNetworkMap bool `json:"exclude_network_map"`
+
+	// ExcludeSystemInfo Leaves host routes, interfaces and firewall rules out of debug bundles.
+	ExcludeSystemInfo bool `json:"exclude_system_info"`
+
+	// ForceAnonymize Anonymizes debug bundles even if the user did not request it.
+	ForceAnonymize bool `json:"force_anonymize"`
+}
+
 // AccountExtraSettings defines model for AccountExtraSettings.
 type AccountExtraSettings struct {
 	// NetworkTrafficLogsEnabled Enables or disables network traffic logging. If enabled, all network traffic events from peers will be stored.
@@ -1665,6 +1680,9 @@ type AccountSettings struct {
 	// DashboardFeatures Per-account dashboard section visibility overrides. Omitted keys follow the default dashboard behavior.
 	DashboardFeatures *AccountDashboardFeatures `json:"dashboard_features,omitempty"`
 
+	// DebugBundlePolicy Restricts what debug bundles created by the account's peers may contain. Peers enforce the policy regardless of local flags.
+	DebugBundlePolicy *AccountDebugBundlePolicy `json:"debug_bundle_policy,omitempty"`
+
 	// DnsDomain Allows to define a custom dns domain for the account
 	DnsDomain *string `json:"dns_domain,omitempty"`
 
@@ -191,6 +191,9 @@ type Client struct {
 	// transport is the negotiated relay transport of the
 	// current connection, guarded by mu.
 	transport string
+	// latency is the round trip of the auth handshake of the
+	// current connection, guarded by mu.
+	latency time.Duration
 }
 
 // Transport returns the negotiated relay transport of the current connection,
@@ -201,6 +204,14 @@ func (c *Client) Transport() string {
 	return c.transport
 }
 
+// Latency returns the round trip time of the auth handshake of the current connection, including
+// the server's token validation, or zero when not connected.
+func (c *Client) Latency() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.latency
+}
+
 // SetTransportFallback wires the shared datagram-transport fallback tracker.
 func (c *Client) SetTransportFallback(tf *transportFallback) {
 	c.transportFallback = tf
@@ -491,6 +502,7 @@ func (c *Client) handShake(ctx context.Context) (*RelayAddr, error) {
 		return nil, err
 	}
 
+	sent := time.Now()
 	_, err = c.relayConn.Write(msg)
 	if err != nil {
 		c.log.Errorf("failed to send auth message: %s", err)
@@ -502,6 +514,7 @@ func (c *Client) handShake(ctx context.Context) (*RelayAddr, error) {
 		c.log.Errorf("failed to read auth response: %s", err)
 		return nil, err
 	}
+	c.latency = time.Since(sent)
 
 	_, err = messages.ValidateVersion(buf[:n])
 	if err != nil {
@@ -813,6 +826,7 @@ func (c *Client) close(gracefullyExit bool) error {
 	}
 	c.serviceIsRunning = false
 	c.transport = ""
+	c.latency = 0
 
 	c.muInstanceURL.Lock()
 	c.instanceURL = nil
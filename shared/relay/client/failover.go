@@ -0,0 +1,102 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const failoverPollInterval = 100 * time.Millisecond
+
+// FailoverEvent is a single step of a relay failover test.
+type FailoverEvent struct {
+	// Elapsed is the time since the test made the home relay unreachable.
+	Elapsed time.Duration
+	Message string
+}
+
+// FailoverResult describes a completed relay failover test.
+type FailoverResult struct {
+	// From is the relay server URL that was made unreachable.
+	From string
+	// To is the relay server URL the manager failed over to.
+	To       string
+	Duration time.Duration
+	Events   []FailoverEvent
+}
+
+// TestFailover makes the current home relay server unreachable for the manager, waits until the manager
+// connects to another relay server and then makes the server reachable again. The home relay connection
+// is closed during the test, so peer connections relayed through it are re-established afterward.
+func (m *Manager) TestFailover(ctx context.Context) (*FailoverResult, error) {
+	if !m.failoverRunning.CompareAndSwap(false, true) {
+		return nil, errors.New("relay failover test already in progress")
+	}
+	defer m.failoverRunning.Store(false)
+
+	if urls := m.ServerURLs(); len(urls) < 2 {
+		return nil, fmt.Errorf("relay failover needs at least 2 relay servers, have %d", len(urls))
+	}
+
+	m.relayClientMu.RLock()
+	home := m.relayClient
+	m.relayClientMu.RUnlock()
+	if home == nil || !home.Ready() {
+		return nil, ErrRelayClientNotConnected
+	}
+
+	result := &FailoverResult{From: home.connectionURL}
+	start := time.Now()
+	record := func(format string, args ...any) {
+		msg := fmt.Sprintf(format, args...)
+		log.Infof("relay failover test: %s", msg)
+		result.Events = append(result.Events, FailoverEvent{Elapsed: time.Since(start), Message: msg})
+	}
+
+	m.serverPicker.excludeURL(result.From)
+	defer m.serverPicker.clearExcludedURL()
+	record("marked relay server %s unreachable", result.From)
+
+	if err := home.Close(); err != nil {
+		record("close connection to %s: %v", result.From, err)
+	} else {
+		record("closed connection to %s", result.From)
+	}
+
+	ticker := time.NewTicker(failoverPollInterval)
+	defer ticker.Stop()
+
+	var lastErr error
+	for {
+		select {
+		case <-ctx.Done():
+			record("gave up waiting for a new relay server")
+			return result, fmt.Errorf("wait for relay failover: %w", ctx.Err())
+		case <-ticker.C:
+		}
+
+		if err := m.reconnectGuard.LastError(); err != nil && (lastErr == nil || err.Error() != lastErr.Error()) {
+			record("reconnect attempt failed: %v", err)
+			lastErr = err
+		}
+
+		m.relayClientMu.RLock()
+		current := m.relayClient
+		m.relayClientMu.RUnlock()
+		if current == nil || current == home || !current.Ready() {
+			continue
+		}
+
+		result.To = current.connectionURL
+		result.Duration = time.Since(start)
+		record("connected to relay server %s", result.To)
+		break
+	}
+
+	m.serverPicker.clearExcludedURL()
+	record("restored relay server %s", result.From)
+	return result, nil
+}
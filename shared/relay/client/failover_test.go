@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/netbirdio/netbird/client/iface"
+	"github.com/netbirdio/netbird/relay/server"
+)
+
+func startFailoverTestServer(t *testing.T, address string) server.ListenerConfig {
+	t.Helper()
+
+	lstCfg := server.ListenerConfig{Address: address}
+	srv, err := server.NewServer(newManagerTestServerConfig(lstCfg.Address))
+	require.NoError(t, err, "failed to create server")
+
+	errChan := make(chan error, 1)
+	go func() {
+		if err := srv.Listen(lstCfg); err != nil {
+			errChan <- err
+		}
+	}()
+	t.Cleanup(func() {
+		if err := srv.Shutdown(context.Background()); err != nil {
+			t.Errorf("failed to close server: %s", err)
+		}
+	})
+
+	require.NoError(t, waitForServerToStart(errChan), "failed to start server")
+	return lstCfg
+}
+
+func TestManager_TestFailover(t *testing.T) {
+	srvCfg1 := startFailoverTestServer(t, "localhost:52601")
+	srvCfg2 := startFailoverTestServer(t, "localhost:52602")
+
+	mCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	urls := append(toURL(srvCfg1), toURL(srvCfg2)...)
+	mgr := NewManager(mCtx, urls, "alice", iface.DefaultMTU)
+	require.NoError(t, mgr.Serve(), "failed to serve manager")
+
+	ctx, cancelTest := context.WithTimeout(mCtx, 30*time.Second)
+	defer cancelTest()
+
+	result, err := mgr.TestFailover(ctx)
+	require.NoError(t, err, "failover test")
+	require.NotEmpty(t, result.From)
+	require.NotEmpty(t, result.To)
+	require.NotEqual(t, result.From, result.To, "manager must fail over to a different relay")
+	require.Positive(t, result.Duration)
+	require.NotEmpty(t, result.Events)
+
+	require.True(t, mgr.Ready(), "manager must be connected after failover")
+	require.Nil(t, mgr.serverPicker.excludedURL.Load(), "excluded relay must be restored")
+}
+
+func TestManager_TestFailoverSingleServer(t *testing.T) {
+	srvCfg := startFailoverTestServer(t, "localhost:52603")
+
+	mCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mgr := NewManager(mCtx, toURL(srvCfg), "alice", iface.DefaultMTU)
+	require.NoError(t, mgr.Serve(), "failed to serve manager")
+
+	_, err := mgr.TestFailover(mCtx)
+	require.Error(t, err, "failover needs a second relay server")
+	require.True(t, mgr.Ready(), "home relay must stay connected")
+}
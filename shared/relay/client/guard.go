@@ -137,7 +137,7 @@ func (g *Guard) drainRelayClientChan() {
 }
 
 func (g *Guard) isServerURLStillValid(rc *Client) bool {
-	for _, url := range g.serverPicker.ServerURLs.Load().([]string) {
+	for _, url := range g.serverPicker.serverURLs() {
 		if url == rc.connectionURL {
 			return true
 		}
@@ -8,6 +8,7 @@ import (
 	"net/netip"
 	"reflect"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -55,6 +56,8 @@ type RelayConnState struct {
 	URL string
 	// Transport is the negotiated transport, empty if not connected.
 	Transport string
+	// Latency is the round trip of the auth handshake, zero if not connected.
+	Latency time.Duration
 	// Err is set when the relay is not connected.
 	Err error
 }
@@ -99,6 +102,8 @@ type Manager struct {
 	// transportFallback is shared across home and foreign relay clients so a
 	// datagram-too-large failure makes that server avoid datagram-sized transports across reconnects.
 	transportFallback *transportFallback
+
+	failoverRunning atomic.Bool
 }
 
 // NewManager creates a new manager instance.
@@ -554,5 +559,5 @@ func relayConnState(c *Client) RelayConnState {
 	if err != nil {
 		return RelayConnState{URL: c.connectionURL, Err: err}
 	}
-	return RelayConnState{URL: addr, Transport: c.Transport()}
+	return RelayConnState{URL: addr, Transport: c.Transport(), Latency: c.Latency()}
 }
@@ -30,21 +30,51 @@ type ServerPicker struct {
 	MTU               uint16
 	ConnectionTimeout time.Duration
 	TransportFallback *transportFallback
+
+	// excludedURL is a server URL temporarily treated as unreachable, used by
+	// the failover test.
+	excludedURL atomic.Pointer[string]
+}
+
+// serverURLs returns the configured server URLs without the excluded one.
+func (sp *ServerPicker) serverURLs() []string {
+	urls := sp.ServerURLs.Load().([]string)
+	excluded := sp.excludedURL.Load()
+	if excluded == nil {
+		return urls
+	}
+
+	filtered := make([]string, 0, len(urls))
+	for _, url := range urls {
+		if url != *excluded {
+			filtered = append(filtered, url)
+		}
+	}
+	return filtered
+}
+
+func (sp *ServerPicker) excludeURL(url string) {
+	sp.excludedURL.Store(&url)
+}
+
+func (sp *ServerPicker) clearExcludedURL() {
+	sp.excludedURL.Store(nil)
 }
 
 func (sp *ServerPicker) PickServer(parentCtx context.Context) (*Client, error) {
 	ctx, cancel := context.WithTimeout(parentCtx, sp.ConnectionTimeout)
 	defer cancel()
 
-	totalServers := len(sp.ServerURLs.Load().([]string))
+	urls := sp.serverURLs()
+	totalServers := len(urls)
 
 	connResultChan := make(chan connResult, totalServers)
 	successChan := make(chan connResult, 1)
 	errChan := make(chan error, 1)
 	concurrentLimiter := make(chan struct{}, maxConcurrentServers)
 
-	log.Debugf("pick server from list: %v", sp.ServerURLs.Load().([]string))
-	for _, url := range sp.ServerURLs.Load().([]string) {
+	log.Debugf("pick server from list: %v", urls)
+	for _, url := range urls {
 		// todo check if we have a successful connection so we do not need to connect to other servers
 		concurrentLimiter <- struct{}{}
 		go func(url string) {
@@ -292,6 +292,11 @@ func (c *GrpcClient) Ready() bool {
 	return c.signalConn.GetState() == connectivity.Ready || c.signalConn.GetState() == connectivity.Idle
 }
 
+// ChannelState returns the state of the underlying gRPC channel.
+func (c *GrpcClient) ChannelState() connectivity.State {
+	return c.signalConn.GetState()
+}
+
 // IsHealthy reports whether the Signal connection is usable, based on the
 // transport state plus the receive watchdog's verdict, and updates the status
 // recorder accordingly. It does not actively probe: the watchdog
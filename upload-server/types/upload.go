@@ -1,5 +1,7 @@
 package types
 
+import "time"
+
 const (
 	// ClientHeader is the header used to identify the client
 	ClientHeader = "x-nb-client"
@@ -15,4 +17,6 @@ const (
 type GetURLResponse struct {
 	URL string
 	Key string
+	// ExpiresAt is when the server deletes the bundle, nil when the server does not report it.
+	ExpiresAt *time.Time `json:",omitempty"`
 }
@@ -49,6 +49,9 @@ type Options struct {
 	SnapLen uint32
 	// BufSize is the internal channel buffer size. 0 means 256.
 	BufSize int
+	// MaxBytes caps the pcap output size. Packets that no longer fit are
+	// dropped and counted in Stats.Dropped. 0 means no limit.
+	MaxBytes int64
 }
 
 // Stats reports capture session counters.
@@ -13,6 +13,9 @@ const (
 	// linkTypeRaw is LINKTYPE_RAW: raw IPv4/IPv6 packets without link-layer header.
 	linkTypeRaw    = 101
 	defaultSnapLen = 65535
+
+	pcapGlobalHeaderLen = 24
+	pcapRecordHeaderLen = 16
 )
 
 // PcapWriter writes packets in pcap format to an underlying writer.
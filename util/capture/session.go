@@ -28,6 +28,10 @@ type Session struct {
 	snapLen uint32
 	flushFn func()
 
+	maxBytes int64
+	// pcapBytes is the pcap output written so far, only touched by the writer goroutine.
+	pcapBytes int64
+
 	ch      chan packetEntry
 	done    chan struct{}
 	stopped chan struct{}
@@ -58,12 +62,13 @@ func NewSession(opts Options) (*Session, error) {
 	}
 
 	s := &Session{
-		matcher: opts.Matcher,
-		snapLen: snapLen,
-		ch:      make(chan packetEntry, bufSize),
-		done:    make(chan struct{}),
-		stopped: make(chan struct{}),
-		started: time.Now(),
+		matcher:  opts.Matcher,
+		snapLen:  snapLen,
+		maxBytes: opts.MaxBytes,
+		ch:       make(chan packetEntry, bufSize),
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+		started:  time.Now(),
 	}
 
 	if opts.Output != nil {
@@ -169,6 +174,18 @@ func (s *Session) drain() {
 }
 
 func (s *Session) write(pkt packetEntry) {
+	if s.pcapW != nil && s.maxBytes > 0 {
+		size := int64(pcapRecordHeaderLen + len(pkt.data))
+		if s.pcapBytes == 0 {
+			size += pcapGlobalHeaderLen
+		}
+		if s.pcapBytes+size > s.maxBytes {
+			s.dropped.Add(1)
+			return
+		}
+		s.pcapBytes += size
+	}
+
 	if s.pcapW != nil {
 		// Best-effort: if the writer fails (broken pipe etc.), discard silently.
 		_ = s.pcapW.WritePacket(pkt.ts, pkt.data)
@@ -94,6 +94,30 @@ func TestSession_Filter(t *testing.T) {
 	assert.Equal(t, int64(1), stats.Packets, "only matching packet should be captured")
 }
 
+func TestSession_MaxBytes(t *testing.T) {
+	pkt := buildIPv4Packet(t,
+		netip.MustParseAddr("10.0.0.1"),
+		netip.MustParseAddr("10.0.0.2"),
+		protoTCP, 12345, 443)
+	record := pcapRecordHeaderLen + len(pkt)
+
+	var buf bytes.Buffer
+	sess, err := NewSession(Options{
+		Output:   &buf,
+		BufSize:  16,
+		MaxBytes: int64(pcapGlobalHeaderLen + 2*record + record/2),
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 4; i++ {
+		sess.Offer(pkt, true)
+	}
+	sess.Stop()
+
+	assert.Equal(t, pcapGlobalHeaderLen+2*record, buf.Len(), "only whole records that fit are written")
+	assert.Equal(t, int64(2), sess.Stats().Dropped)
+}
+
 func TestSession_StopIdempotent(t *testing.T) {
 	var buf bytes.Buffer
 	sess, err := NewSession(Options{Output: &buf})
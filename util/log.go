@@ -161,6 +161,7 @@ func newRotatedOutput(logPath string) io.Writer {
 		MaxAge:      30, // days
 		Compression: "gzip",
 	}
+	registerRotatedOutput(timberjackLogger)
 	return timberjackLogger
 }
 
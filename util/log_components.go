@@ -0,0 +1,112 @@
+package util
+
+import (
+	"maps"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// LogComponents maps the component names accepted by SetComponentLevels to the source
+// directories and files whose log entries they cover.
+var LogComponents = map[string][]string{
+	"ice":      {"client/internal/peer/ice/", "client/internal/peer/worker_ice.go", "client/iface/udpmux/", "client/iface/bind/"},
+	"relay":    {"shared/relay/", "client/internal/peer/worker_relay.go", "client/iface/wgproxy/"},
+	"grpc":     {"client/grpc/", "shared/management/client/", "shared/signal/client/"},
+	"peer":     {"client/internal/peer/"},
+	"dns":      {"client/internal/dns/", "client/internal/dnsfwd/"},
+	"route":    {"client/internal/routemanager/"},
+	"firewall": {"client/firewall/"},
+	"engine":   {"client/internal/engine", "client/internal/connect.go"},
+}
+
+type componentPrefix struct {
+	prefix    string
+	component string
+}
+
+// componentFormatter drops entries above the level of their component. The logger level is
+// raised to the most verbose component level so that those entries reach the formatter.
+type componentFormatter struct {
+	log.Formatter
+	base     log.Level
+	levels   map[string]log.Level
+	prefixes []componentPrefix
+}
+
+func (f *componentFormatter) Format(entry *log.Entry) ([]byte, error) {
+	if entry.Level > f.level(entry) {
+		return nil, nil
+	}
+	return f.Formatter.Format(entry)
+}
+
+func (f *componentFormatter) level(entry *log.Entry) log.Level {
+	if entry.Caller == nil {
+		return f.base
+	}
+	for _, p := range f.prefixes {
+		if strings.Contains(entry.Caller.File, "/"+p.prefix) {
+			return f.levels[p.component]
+		}
+	}
+	return f.base
+}
+
+// SetComponentLevels sets the base level of the logger and overrides it for the given
+// components. Entries of other components keep the base level. An empty map removes all
+// overrides. Unknown component names are ignored and returned.
+func SetComponentLevels(logger *log.Logger, base log.Level, levels map[string]log.Level) []string {
+	var unknown []string
+	known := make(map[string]log.Level, len(levels))
+	for name, level := range levels {
+		if _, ok := LogComponents[name]; !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		known[name] = level
+	}
+	sort.Strings(unknown)
+
+	inner := logger.Formatter
+	if f, ok := inner.(*componentFormatter); ok {
+		inner = f.Formatter
+	}
+
+	if len(known) == 0 {
+		logger.SetFormatter(inner)
+		logger.SetLevel(base)
+		return unknown
+	}
+
+	f := &componentFormatter{
+		Formatter: inner,
+		base:      base,
+		levels:    known,
+	}
+	maxLevel := base
+	for name, level := range known {
+		maxLevel = max(maxLevel, level)
+		for _, prefix := range LogComponents[name] {
+			f.prefixes = append(f.prefixes, componentPrefix{prefix: prefix, component: name})
+		}
+	}
+	// the most specific directory wins, e.g. peer/ice/ over peer/
+	sort.Slice(f.prefixes, func(i, j int) bool {
+		return len(f.prefixes[i].prefix) > len(f.prefixes[j].prefix)
+	})
+
+	logger.SetFormatter(f)
+	logger.SetLevel(maxLevel)
+	return unknown
+}
+
+// ComponentLevels returns the base level of the logger and the component overrides set by
+// SetComponentLevels.
+func ComponentLevels(logger *log.Logger) (log.Level, map[string]log.Level) {
+	if f, ok := logger.Formatter.(*componentFormatter); ok {
+		return f.base, maps.Clone(f.levels)
+	}
+	return logger.GetLevel(), nil
+}
@@ -0,0 +1,51 @@
+package util
+
+import (
+	"io"
+	"runtime"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetComponentLevels(t *testing.T) {
+	logger := log.New()
+	logger.SetOutput(io.Discard)
+	logger.SetFormatter(&log.TextFormatter{DisableTimestamp: true})
+
+	unknown := SetComponentLevels(logger, log.InfoLevel, map[string]log.Level{
+		"ice":   log.TraceLevel,
+		"peer":  log.WarnLevel,
+		"bogus": log.DebugLevel,
+	})
+	assert.Equal(t, []string{"bogus"}, unknown)
+	assert.Equal(t, log.TraceLevel, logger.GetLevel(), "logger level follows the most verbose component")
+
+	base, levels := ComponentLevels(logger)
+	assert.Equal(t, log.InfoLevel, base)
+	assert.Equal(t, map[string]log.Level{"ice": log.TraceLevel, "peer": log.WarnLevel}, levels)
+
+	logFrom := func(file string, level log.Level) string {
+		entry := log.NewEntry(logger)
+		entry.Level = level
+		entry.Caller = &runtime.Frame{File: file}
+		formatted, err := logger.Formatter.Format(entry)
+		require.NoError(t, err)
+		return string(formatted)
+	}
+
+	assert.NotEmpty(t, logFrom("/src/netbird/client/internal/peer/ice/agent.go", log.TraceLevel))
+	assert.Empty(t, logFrom("/src/netbird/client/internal/peer/conn.go", log.InfoLevel), "peer is limited to warn")
+	assert.NotEmpty(t, logFrom("/src/netbird/client/internal/peer/conn.go", log.WarnLevel))
+	assert.Empty(t, logFrom("/src/netbird/client/internal/dns/server.go", log.DebugLevel), "unlisted components use the base level")
+	assert.NotEmpty(t, logFrom("/src/netbird/client/internal/dns/server.go", log.InfoLevel))
+
+	SetComponentLevels(logger, log.DebugLevel, nil)
+	assert.Equal(t, log.DebugLevel, logger.GetLevel())
+	_, levels = ComponentLevels(logger)
+	assert.Empty(t, levels)
+	_, isComponent := logger.Formatter.(*componentFormatter)
+	assert.False(t, isComponent, "clearing the overrides restores the original formatter")
+}
@@ -0,0 +1,102 @@
+package util
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netbirdio/netbird/formatter/syslog"
+	"github.com/netbirdio/netbird/formatter/txt"
+)
+
+const (
+	LogFormatText   = "text"
+	LogFormatJSON   = "json"
+	LogFormatSyslog = "syslog"
+)
+
+// EntryKeyComponent is the JSON key holding the LogComponents name of an entry.
+const EntryKeyComponent = "component"
+
+// componentPrefixes lists the source prefixes of LogComponents, most specific first.
+var componentPrefixes = sync.OnceValue(func() []componentPrefix {
+	var prefixes []componentPrefix
+	for name, paths := range LogComponents {
+		for _, prefix := range paths {
+			prefixes = append(prefixes, componentPrefix{prefix: prefix, component: name})
+		}
+	}
+	sort.Slice(prefixes, func(i, j int) bool {
+		return len(prefixes[i].prefix) > len(prefixes[j].prefix)
+	})
+	return prefixes
+})
+
+// jsonFormatter adds the component of an entry as a structured key.
+type jsonFormatter struct {
+	*log.JSONFormatter
+}
+
+func (f *jsonFormatter) Format(entry *log.Entry) ([]byte, error) {
+	if component := entryComponent(entry); component != "" {
+		entry.Data[EntryKeyComponent] = component
+	}
+	return f.JSONFormatter.Format(entry)
+}
+
+// entryComponent returns the LogComponents name of the source of an entry, empty if the entry
+// has no caller or belongs to no component.
+func entryComponent(entry *log.Entry) string {
+	if entry.Caller == nil {
+		return ""
+	}
+	for _, p := range componentPrefixes() {
+		if strings.Contains(entry.Caller.File, "/"+p.prefix) {
+			return p.component
+		}
+	}
+	return ""
+}
+
+// SetLogFormat switches the formatter of an initialized logger between text and JSON. Component
+// levels set with SetComponentLevels are kept. The logger serializes formatting with the
+// formatter switch, so entries are never written half in one format.
+func SetLogFormat(logger *log.Logger, format string) error {
+	var inner log.Formatter
+	switch format {
+	case LogFormatText:
+		inner = txt.NewTextFormatter()
+	case LogFormatJSON:
+		inner = &jsonFormatter{JSONFormatter: &log.JSONFormatter{}}
+	default:
+		return fmt.Errorf("unknown log format %q, expected %s or %s", format, LogFormatText, LogFormatJSON)
+	}
+
+	if f, ok := logger.Formatter.(*componentFormatter); ok {
+		wrapped := *f
+		wrapped.Formatter = inner
+		inner = &wrapped
+	}
+	logger.SetFormatter(inner)
+	return nil
+}
+
+// LogFormat returns the format the logger writes: LogFormatText, LogFormatJSON or LogFormatSyslog.
+func LogFormat(logger *log.Logger) string {
+	inner := logger.Formatter
+	if f, ok := inner.(*componentFormatter); ok {
+		inner = f.Formatter
+	}
+
+	switch inner.(type) {
+	case *log.JSONFormatter, *jsonFormatter:
+		return LogFormatJSON
+	case *syslog.Formatter:
+		return LogFormatSyslog
+	default:
+		return LogFormatText
+	}
+}
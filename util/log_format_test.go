@@ -0,0 +1,43 @@
+package util
+
+import (
+	"encoding/json"
+	"io"
+	"runtime"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetLogFormat(t *testing.T) {
+	logger := log.New()
+	logger.SetOutput(io.Discard)
+	SetComponentLevels(logger, log.InfoLevel, map[string]log.Level{"ice": log.TraceLevel})
+	assert.Equal(t, LogFormatText, LogFormat(logger))
+
+	require.NoError(t, SetLogFormat(logger, LogFormatJSON))
+	assert.Equal(t, LogFormatJSON, LogFormat(logger))
+
+	base, levels := ComponentLevels(logger)
+	assert.Equal(t, log.InfoLevel, base, "component levels survive the switch")
+	assert.Equal(t, map[string]log.Level{"ice": log.TraceLevel}, levels)
+
+	entry := log.NewEntry(logger).WithField("peer", "key")
+	entry.Level = log.TraceLevel
+	entry.Message = "checking candidates"
+	entry.Caller = &runtime.Frame{File: "/src/netbird/client/internal/peer/ice/agent.go"}
+	formatted, err := logger.Formatter.Format(entry)
+	require.NoError(t, err)
+
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(formatted, &fields))
+	assert.Equal(t, "ice", fields[EntryKeyComponent], "the most specific component wins")
+	assert.Equal(t, "key", fields["peer"])
+	assert.Equal(t, "trace", fields["level"])
+
+	require.NoError(t, SetLogFormat(logger, LogFormatText))
+	assert.Equal(t, LogFormatText, LogFormat(logger))
+	assert.Error(t, SetLogFormat(logger, "xml"))
+}
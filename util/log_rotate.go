@@ -0,0 +1,72 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/DeRuina/timberjack"
+)
+
+// ErrLogRotationNotApplicable is returned by RotateLogFile when the log is not written to a
+// rotated file, e.g. when logging to the console or syslog or when rotation is disabled.
+var ErrLogRotationNotApplicable = errors.New("log rotation not applicable")
+
+var (
+	rotatedOutputsMu sync.Mutex
+	// rotatedOutputs holds the rotating writers created by newRotatedOutput by log path.
+	rotatedOutputs = map[string]*timberjack.Logger{}
+)
+
+func registerRotatedOutput(logger *timberjack.Logger) {
+	rotatedOutputsMu.Lock()
+	defer rotatedOutputsMu.Unlock()
+	rotatedOutputs[logger.Filename] = logger
+}
+
+// RotateLogFile closes the active log file at logPath, moves it aside and starts a new one.
+// It returns the path of the rotated-out file. Rotated files are compressed in the
+// background, so the returned file may be replaced by a .gz file shortly afterwards.
+func RotateLogFile(logPath string) (string, error) {
+	rotatedOutputsMu.Lock()
+	logger, ok := rotatedOutputs[filepath.ToSlash(logPath)]
+	rotatedOutputsMu.Unlock()
+	if !ok {
+		return "", ErrLogRotationNotApplicable
+	}
+
+	before := logBackups(logPath)
+	if err := logger.Rotate(); err != nil {
+		return "", fmt.Errorf("rotate %s: %w", logPath, err)
+	}
+
+	for _, backup := range slices.Sorted(maps.Keys(logBackups(logPath))) {
+		if _, ok := before[backup]; !ok {
+			return backup, nil
+		}
+	}
+	return "", fmt.Errorf("rotated file of %s not found", logPath)
+}
+
+// logBackups returns the files next to logPath that share its base name, except logPath itself.
+func logBackups(logPath string) map[string]struct{} {
+	prefix := strings.TrimSuffix(filepath.Base(logPath), filepath.Ext(logPath))
+	matches, err := filepath.Glob(filepath.Join(filepath.Dir(logPath), prefix+"*"))
+	if err != nil {
+		return nil
+	}
+
+	backups := make(map[string]struct{}, len(matches))
+	for _, m := range matches {
+		if info, err := os.Stat(m); err != nil || info.IsDir() || filepath.Clean(m) == filepath.Clean(logPath) {
+			continue
+		}
+		backups[m] = struct{}{}
+	}
+	return backups
+}
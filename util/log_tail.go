@@ -0,0 +1,139 @@
+package util
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/netbirdio/netbird/formatter/txt"
+)
+
+// logTailBuffer is the number of lines buffered per tail subscriber. Lines beyond it are dropped
+// until the subscriber catches up, so a slow client never blocks logging.
+const logTailBuffer = 1024
+
+// LogLine is a log entry delivered to a tail subscriber.
+type LogLine struct {
+	Time      time.Time
+	Level     log.Level
+	Component string
+	// Text is the entry formatted as a text line without the trailing newline.
+	Text string
+	// Dropped is the number of lines dropped before this one because the subscriber was too slow.
+	Dropped uint32
+}
+
+// LogTail is a logrus hook fanning out log entries to live subscribers. It only sees entries
+// that pass the logger level and leaves the formatter and output of the logger untouched.
+type LogTail struct {
+	mu          sync.RWMutex
+	subscribers map[*LogTailSubscription]struct{}
+	active      atomic.Int32
+	formatter   log.Formatter
+}
+
+// LogTailSubscription receives the lines of a LogTail matching its filters.
+type LogTailSubscription struct {
+	level      log.Level
+	components []string
+	lines      chan LogLine
+	dropped    atomic.Uint32
+}
+
+// NewLogTail creates a LogTail. Add it to a logger with AddHook.
+func NewLogTail() *LogTail {
+	return &LogTail{
+		subscribers: make(map[*LogTailSubscription]struct{}),
+		formatter:   txt.NewTextFormatter(),
+	}
+}
+
+// Levels implements log.Hook.
+func (t *LogTail) Levels() []log.Level {
+	return log.AllLevels
+}
+
+// Fire implements log.Hook.
+func (t *LogTail) Fire(entry *log.Entry) error {
+	if t.active.Load() == 0 {
+		return nil
+	}
+
+	component := entryComponent(entry)
+	var line *LogLine
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	for sub := range t.subscribers {
+		if !sub.matches(entry.Level, component) {
+			continue
+		}
+		if line == nil {
+			formatted, err := t.formatter.Format(entry)
+			if err != nil {
+				return err
+			}
+			line = &LogLine{
+				Time:      entry.Time,
+				Level:     entry.Level,
+				Component: component,
+				Text:      strings.TrimRight(string(formatted), "\n"),
+			}
+		}
+		sub.send(*line)
+	}
+	return nil
+}
+
+// Subscribe starts delivering lines at or above level, limited to the given components when
+// not empty. Call Unsubscribe when done.
+func (t *LogTail) Subscribe(level log.Level, components []string) *LogTailSubscription {
+	sub := &LogTailSubscription{
+		level:      level,
+		components: slices.Clone(components),
+		lines:      make(chan LogLine, logTailBuffer),
+	}
+
+	t.mu.Lock()
+	t.subscribers[sub] = struct{}{}
+	t.mu.Unlock()
+	t.active.Add(1)
+	return sub
+}
+
+// Unsubscribe stops the delivery to a subscription.
+func (t *LogTail) Unsubscribe(sub *LogTailSubscription) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.subscribers[sub]; !ok {
+		return
+	}
+	delete(t.subscribers, sub)
+	t.active.Add(-1)
+}
+
+// Lines returns the channel the subscription receives its lines on.
+func (s *LogTailSubscription) Lines() <-chan LogLine {
+	return s.lines
+}
+
+func (s *LogTailSubscription) matches(level log.Level, component string) bool {
+	if level > s.level {
+		return false
+	}
+	return len(s.components) == 0 || slices.Contains(s.components, component)
+}
+
+func (s *LogTailSubscription) send(line LogLine) {
+	line.Dropped = s.dropped.Load()
+	select {
+	case s.lines <- line:
+		s.dropped.Add(-line.Dropped)
+	default:
+		s.dropped.Add(1)
+	}
+}
@@ -0,0 +1,71 @@
+package util
+
+import (
+	"io"
+	"runtime"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogTail(t *testing.T) {
+	logger := log.New()
+	logger.SetOutput(io.Discard)
+	logger.SetLevel(log.TraceLevel)
+	tail := NewLogTail()
+	logger.AddHook(tail)
+
+	all := tail.Subscribe(log.TraceLevel, nil)
+	ice := tail.Subscribe(log.DebugLevel, []string{"ice"})
+
+	// entries keep a caller only when the logger reports callers, so fire them directly
+	for level, msg := range map[log.Level]string{log.DebugLevel: "checking candidates", log.TraceLevel: "candidate pair"} {
+		entry := log.NewEntry(logger)
+		entry.Level = level
+		entry.Message = msg
+		entry.Caller = &runtime.Frame{File: "/src/netbird/client/internal/peer/ice/agent.go"}
+		require.NoError(t, tail.Fire(entry))
+	}
+	logger.Info("engine started")
+
+	require.Len(t, all.Lines(), 3)
+	require.Len(t, ice.Lines(), 1, "trace and other components are filtered")
+
+	line := <-ice.Lines()
+	assert.Equal(t, log.DebugLevel, line.Level)
+	assert.Equal(t, "ice", line.Component)
+	assert.Contains(t, line.Text, "checking candidates")
+	assert.NotContains(t, line.Text, "\n")
+
+	tail.Unsubscribe(ice)
+	tail.Unsubscribe(ice)
+	logger.Info("after unsubscribe")
+	assert.Len(t, all.Lines(), 4)
+	assert.Empty(t, ice.Lines())
+}
+
+func TestLogTailDropsForSlowSubscriber(t *testing.T) {
+	logger := log.New()
+	logger.SetOutput(io.Discard)
+	tail := NewLogTail()
+	logger.AddHook(tail)
+
+	sub := tail.Subscribe(log.InfoLevel, nil)
+	for range logTailBuffer + 5 {
+		logger.Info("line")
+	}
+	require.Len(t, sub.Lines(), logTailBuffer)
+	for range logTailBuffer {
+		<-sub.Lines()
+	}
+
+	logger.Info("caught up")
+	line := <-sub.Lines()
+	assert.Equal(t, uint32(5), line.Dropped)
+
+	logger.Info("next")
+	line = <-sub.Lines()
+	assert.Zero(t, line.Dropped)
+}
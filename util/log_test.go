@@ -94,3 +94,37 @@ func TestIsRotationDisabled_EnvFlag(t *testing.T) {
 	t.Setenv("NB_LOG_DISABLE_ROTATION", "true")
 	require.True(t, isRotationDisabled(logger))
 }
+
+// TestRotateLogFile forces a rotation and asserts the returned backup holds the old content.
+func TestRotateLogFile(t *testing.T) {
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "netbird.log")
+
+	_, err := RotateLogFile(logPath)
+	require.ErrorIs(t, err, ErrLogRotationNotApplicable)
+
+	w, err := setupLogFile(logPath, false)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if c, ok := w.(io.Closer); ok {
+			_ = c.Close()
+		}
+	})
+
+	_, err = w.Write([]byte("before rotation\n"))
+	require.NoError(t, err)
+
+	rotated, err := RotateLogFile(logPath)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(filepath.Base(rotated), "netbird-"), rotated)
+
+	info, err := os.Stat(logPath)
+	require.NoError(t, err)
+	require.Zero(t, info.Size(), "a new log file should be started")
+
+	require.Eventually(t, func() bool {
+		_, errPlain := os.Stat(rotated)
+		_, errGz := os.Stat(strings.TrimSuffix(rotated, ".gz") + ".gz")
+		return errPlain == nil || errGz == nil
+	}, 5*time.Second, 50*time.Millisecond, "rotated file %s should exist", rotated)
+}
@@ -0,0 +1,56 @@
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// will be replaced with the release commit and commit date when using goreleaser
+var (
+	commit    string
+	buildDate string
+)
+
+// BuildInfo describes the binary that is running.
+type BuildInfo struct {
+	Version string
+	// Commit is the release commit, or the VCS revision of non-release builds. Empty if unknown.
+	Commit string
+	// Date is the commit date of the build in RFC 3339. Empty if unknown.
+	Date      string
+	GoVersion string
+	// Platform is GOOS/GOARCH.
+	Platform string
+}
+
+// Build returns the build metadata of the running binary. Release builds take commit and
+// date from the linker flags, other builds from the VCS settings embedded by the Go toolchain.
+func Build() BuildInfo {
+	info := BuildInfo{
+		Version:   NetbirdVersion(),
+		Commit:    commit,
+		Date:      buildDate,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	if info.Commit == "" {
+		info.Commit = NetbirdCommit()
+	}
+	if info.Date == "" {
+		info.Date = vcsTime()
+	}
+	return info
+}
+
+func vcsTime() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	for _, s := range info.Settings {
+		if s.Key == "vcs.time" {
+			return s.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,23 @@
+package version
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuild(t *testing.T) {
+	info := Build()
+	assert.Equal(t, NetbirdVersion(), info.Version)
+	assert.Equal(t, runtime.Version(), info.GoVersion)
+	assert.Equal(t, runtime.GOOS+"/"+runtime.GOARCH, info.Platform)
+
+	origCommit, origDate := commit, buildDate
+	t.Cleanup(func() { commit, buildDate = origCommit, origDate })
+	commit, buildDate = "0123456789abcdef", "2025-01-02T03:04:05Z"
+
+	info = Build()
+	assert.Equal(t, "0123456789abcdef", info.Commit, "linker flags take precedence over VCS settings")
+	assert.Equal(t, "2025-01-02T03:04:05Z", info.Date)
+}